@@ -22,7 +22,8 @@ package accounts
 
 import (
 	"errors"
-	"fmt"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -30,6 +31,7 @@ import (
 	"github.com/yeeco/gyee/common/address"
 	"github.com/yeeco/gyee/config"
 	"github.com/yeeco/gyee/crypto/keystore"
+	"github.com/yeeco/gyee/crypto/keystore/cipher"
 	"github.com/yeeco/gyee/crypto/secp256k1"
 	"github.com/yeeco/gyee/utils/logging"
 )
@@ -67,11 +69,22 @@ var (
 type AccountManager struct {
 	ks *keystore.Keystore
 	//accounts map[string]*Account
+
+	// scopes of the unlocked accounts, and the log of signing made with them
+	unlocks map[string]*unlockState
+	audit   *auditLog
+	mu      sync.Mutex
 }
 
 func NewAccountManager(config *config.Config) (*AccountManager, error) {
+	ks, err := keystore.NewKeystoreWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
 	am := &AccountManager{
-		ks: keystore.NewKeystoreWithConfig(config),
+		ks:      ks,
+		unlocks: make(map[string]*unlockState),
+		audit:   newAuditLog(filepath.Join(config.NodeDir, "account_audit.log")),
 	}
 	//accounts := Accounts{}
 	//accounts.Accounts = make(map[string]*Account)
@@ -110,6 +123,24 @@ func (am *AccountManager) Accounts() []*address.Address {
 	return addrs
 }
 
+// KeyInfo returns the cipher and kdf settings of the account key file.
+func (am *AccountManager) KeyInfo(address *address.Address) (*cipher.KeyInfo, error) {
+	return am.ks.KeyInfo(address.String())
+}
+
+// Upgrade re-encrypts the account key with the kdf and cost params given,
+// or with the configured default cipher if kdf is empty.
+func (am *AccountManager) Upgrade(address *address.Address, passphrase []byte, kdf string, params map[string]int) error {
+	var c cipher.Cipher
+	if len(kdf) > 0 || len(params) > 0 {
+		var err error
+		if c, err = cipher.NewCipher(kdf, params); err != nil {
+			return err
+		}
+	}
+	return am.ks.Upgrade(address.String(), passphrase, c)
+}
+
 func (am *AccountManager) ResetPassword(address *address.Address, oldPass []byte, newPass []byte) error {
 	return nil
 }
@@ -122,28 +153,22 @@ func (am *AccountManager) Import(keyContent []byte, passphrase []byte) (*address
 //TODO：需要搞定keystore的问题
 
 func (am *AccountManager) Unlock(address *address.Address, passphrase []byte, duration time.Duration) error {
-	return am.ks.Unlock(address.String(), passphrase, duration)
+	return am.UnlockWithScope(address, passphrase, duration, UnlockScope{})
 }
 
 func (am *AccountManager) Lock(address *address.Address) error {
-	return am.ks.Lock(address.String())
-}
-
-func (am *AccountManager) GetUnlocked(address string) ([]byte, error) {
-	return am.ks.GetUnlocked(address)
+	return am.Revoke(address)
 }
 
 func (am *AccountManager) SignHash(address *address.Address, hash common.Hash) ([]byte, error) {
-	key, err := am.ks.GetUnlocked(address.String())
+	key, err := am.getUnlockedForHash(address, AuditKindHash, hash)
 	if err != nil {
 		logging.Logger.WithFields(logrus.Fields{
 			"err":     err,
 			"address": address,
 			"hash":    hash,
 		}).Error("Failed to get unlocked private key.")
-		return nil, ErrAccountIsLocked
+		return nil, err
 	}
-
-	fmt.Println("key:", key)
-	return nil, nil
+	return signHash(key, hash)
 }
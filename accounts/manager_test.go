@@ -27,8 +27,8 @@ import (
 	"time"
 
 	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
 	"github.com/yeeco/gyee/config"
-	"github.com/yeeco/gyee/core"
 )
 
 var (
@@ -52,12 +52,12 @@ func Test_List(t *testing.T) {
 }
 
 func Test_Unlock(t *testing.T) {
-	addr, _ := core.AddressParse("0105cfa04d12fb46fcea51d22cf1f340631bbe930dc0e026ba21")
+	addr, _ := address.AddressParse("0105cfa04d12fb46fcea51d22cf1f340631bbe930dc0e026ba21")
 
 	am.ks.Unlock("0105cfa04d12fb46fcea51d22cf1f340631bbe930dc0e026ba21", []byte(passphrase), time.Duration(time.Second))
-	am.SignHash(addr, common.Hash("abc"))
+	am.SignHash(addr, common.BytesToHash([]byte("abc")))
 	time.Sleep(time.Duration(500) * time.Millisecond)
-	am.SignHash(addr, common.Hash("abc"))
+	am.SignHash(addr, common.BytesToHash([]byte("abc")))
 	time.Sleep(time.Duration(2) * time.Second)
-	am.SignHash(addr, common.Hash("cdf"))
+	am.SignHash(addr, common.BytesToHash([]byte("cdf")))
 }
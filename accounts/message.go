@@ -0,0 +1,113 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  The gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/crypto"
+	"github.com/yeeco/gyee/crypto/hash"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+)
+
+// messagePrefix is prepended to off-chain messages before hashing.
+// Transactions are signed over the hash of their protobuf encoding, which
+// never starts with 0x19, so a signed message can not be replayed as a tx.
+const messagePrefix = "\x19Gyee Signed Message:\n"
+
+var (
+	// ErrMessageSignerMismatch message signature not from address
+	ErrMessageSignerMismatch = errors.New("message signature mismatch")
+)
+
+// MessageHash returns the hash signed for an off-chain message:
+// sha3256(prefix || decimal message length || message).
+func MessageHash(message []byte) common.Hash {
+	var h common.Hash
+	h.SetBytes(hash.Sha3256([]byte(messagePrefix), []byte(strconv.Itoa(len(message))), message))
+	return h
+}
+
+// SignMessage signs the off-chain message with the unlocked account key.
+func (am *AccountManager) SignMessage(address *address.Address, message []byte) ([]byte, error) {
+	h := MessageHash(message)
+	key, err := am.getUnlockedForHash(address, AuditKindMessage, h)
+	if err != nil {
+		return nil, err
+	}
+	return signHash(key, h)
+}
+
+// SignMessageWithPassphrase signs the off-chain message with the account
+// key decrypted by passphrase, without unlocking the account.
+func (am *AccountManager) SignMessageWithPassphrase(address *address.Address, passphrase []byte, message []byte) ([]byte, error) {
+	key, err := am.ks.GetKey(address.String(), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return signHash(key, MessageHash(message))
+}
+
+// RecoverMessageSigner returns the address which signed the message.
+func RecoverMessageSigner(message []byte, signature []byte) (*address.Address, error) {
+	h := MessageHash(message)
+	sig := &crypto.Signature{
+		Algorithm: crypto.ALG_SECP256K1,
+		Signature: signature,
+	}
+	signer := secp256k1.NewSecp256k1Signer()
+	pubkey, err := signer.RecoverPublicKey(h[:], sig)
+	if err != nil {
+		return nil, err
+	}
+	if !signer.Verify(pubkey, h[:], sig) {
+		return nil, ErrMessageSignerMismatch
+	}
+	return address.NewAddressFromPublicKey(pubkey)
+}
+
+// VerifyMessage checks that the message was signed by address.
+func VerifyMessage(addr *address.Address, message []byte, signature []byte) error {
+	signer, err := RecoverMessageSigner(message, signature)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(signer.Raw, addr.Raw) {
+		return ErrMessageSignerMismatch
+	}
+	return nil
+}
+
+func signHash(key []byte, hash common.Hash) ([]byte, error) {
+	signer := secp256k1.NewSecp256k1Signer()
+	if err := signer.InitSigner(key); err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return sig.Signature, nil
+}
@@ -0,0 +1,108 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  The gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package accounts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+)
+
+func TestMessageHash(t *testing.T) {
+	// sha3256("\x19Gyee Signed Message:\n" || length || message), fixed for
+	// external verifiers
+	for _, c := range []struct {
+		message string
+		hash    string
+	}{
+		{"hello", "0x90f9c31177baaf4a7ffc97a74505a4180b91864a5885e356f050e75d8b82f0fc"},
+		{"", "0x13f91f5dbf6f17452c527bc96582690f241e822e9a45fa9f06c0393ee3b0a235"},
+	} {
+		if h := MessageHash([]byte(c.message)); h != common.HexToHash(c.hash) {
+			t.Errorf("MessageHash(%q) %v want %v", c.message, h.Hex(), c.hash)
+		}
+	}
+}
+
+func TestSignMessage(t *testing.T) {
+	am, addr, cleanup := newTestAccountManager(t)
+	defer cleanup()
+	message := []byte("message to sign")
+
+	// locked account
+	if _, err := am.SignMessage(addr, message); err != ErrAccountIsLocked {
+		t.Fatalf("SignMessage() locked %v", err)
+	}
+	if err := am.Unlock(addr, []byte(passphrase), time.Minute); err != nil {
+		t.Fatalf("Unlock() %v", err)
+	}
+	sig, err := am.SignMessage(addr, message)
+	if err != nil {
+		t.Fatalf("SignMessage() %v", err)
+	}
+
+	// round trip
+	signer, err := RecoverMessageSigner(message, sig)
+	if err != nil {
+		t.Fatalf("RecoverMessageSigner() %v", err)
+	}
+	if signer.String() != addr.String() {
+		t.Fatalf("signer %v want %v", signer, addr)
+	}
+	if err := VerifyMessage(addr, message, sig); err != nil {
+		t.Fatalf("VerifyMessage() %v", err)
+	}
+
+	// same signature without unlocking
+	if err := am.Revoke(addr); err != nil {
+		t.Fatalf("Revoke() %v", err)
+	}
+	sig2, err := am.SignMessageWithPassphrase(addr, []byte(passphrase), message)
+	if err != nil {
+		t.Fatalf("SignMessageWithPassphrase() %v", err)
+	}
+	if err := VerifyMessage(addr, message, sig2); err != nil {
+		t.Fatalf("VerifyMessage() with passphrase %v", err)
+	}
+	if _, err := am.SignMessageWithPassphrase(addr, []byte("wrong"), message); err == nil {
+		t.Fatalf("SignMessageWithPassphrase() with wrong passphrase")
+	}
+
+	// wrong address
+	other, err := address.NewAddressFromPublicKey(secp256k1.GenerateKey().PublicKey())
+	if err != nil {
+		t.Fatalf("NewAddressFromPublicKey() %v", err)
+	}
+	if err := VerifyMessage(other, message, sig); err != ErrMessageSignerMismatch {
+		t.Fatalf("VerifyMessage() wrong address %v", err)
+	}
+	// tampered message
+	if err := VerifyMessage(addr, []byte("message to sigN"), sig); err == nil {
+		t.Fatalf("VerifyMessage() tampered message")
+	}
+	// truncated signature
+	if err := VerifyMessage(addr, message, sig[:len(sig)-1]); err == nil {
+		t.Fatalf("VerifyMessage() truncated signature")
+	}
+}
@@ -0,0 +1,311 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  The gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/log"
+)
+
+var (
+	// ErrUnlockScope signing not allowed by the unlock scope
+	ErrUnlockScope = errors.New("signing not allowed by unlock scope")
+
+	// ErrUnlockAmountExceeded total amount of the unlock scope exceeded
+	ErrUnlockAmountExceeded = errors.New("unlock scope amount exceeded")
+
+	// ErrUnlockTxsExceeded tx count of the unlock scope exceeded
+	ErrUnlockTxsExceeded = errors.New("unlock scope tx count exceeded")
+
+	// ErrUnlockRecipient recipient not in the unlock scope allowlist
+	ErrUnlockRecipient = errors.New("recipient not allowed by unlock scope")
+
+	// ErrInvalidAmount tx amount missing or negative
+	ErrInvalidAmount = errors.New("invalid tx amount")
+)
+
+// UnlockScope restricts the transactions signed with an unlocked account.
+// The zero value allows everything, as a plain unlock does.
+type UnlockScope struct {
+	// max total amount of all txs signed during the unlock, nil for no limit
+	MaxAmount *big.Int
+
+	// allowed tx recipients, empty for any recipient
+	Recipients []common.Address
+
+	// max number of txs signed during the unlock, 0 for no limit
+	MaxTxs uint64
+}
+
+func (s *UnlockScope) restricted() bool {
+	return s.MaxAmount != nil || len(s.Recipients) > 0 || s.MaxTxs > 0
+}
+
+// UnlockInfo is the state of an active unlock.
+type UnlockInfo struct {
+	Address *address.Address
+	Expire  time.Time
+	Scope   UnlockScope
+
+	// amount and number of txs signed so far
+	Spent *big.Int
+	Txs   uint64
+}
+
+type unlockState struct {
+	scope UnlockScope
+	spent *big.Int
+	txs   uint64
+}
+
+// check returns the error if a tx of amount to recipient is out of scope
+func (st *unlockState) check(to *common.Address, amount *big.Int) error {
+	// tx encoding drops the sign, a negative amount would move |amount|
+	if amount == nil || amount.Sign() < 0 {
+		return ErrInvalidAmount
+	}
+	scope := &st.scope
+	if scope.MaxTxs > 0 && st.txs >= scope.MaxTxs {
+		return ErrUnlockTxsExceeded
+	}
+	if scope.MaxAmount != nil && new(big.Int).Add(st.spent, amount).Cmp(scope.MaxAmount) > 0 {
+		return ErrUnlockAmountExceeded
+	}
+	if len(scope.Recipients) > 0 {
+		if to == nil {
+			return ErrUnlockRecipient
+		}
+		for _, r := range scope.Recipients {
+			if r == *to {
+				return nil
+			}
+		}
+		return ErrUnlockRecipient
+	}
+	return nil
+}
+
+// AuditRecord is one signing operation made with an unlocked account.
+type AuditRecord struct {
+	Time    time.Time `json:"time"`
+	Address string    `json:"address"`
+	Kind    string    `json:"kind"`
+	To      string    `json:"to,omitempty"`
+	Amount  string    `json:"amount,omitempty"`
+	Hash    string    `json:"hash,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+const (
+	AuditKindTx      = "tx"
+	AuditKindMessage = "message"
+	AuditKindHash    = "hash"
+	AuditKindUnlock  = "unlock"
+	AuditKindRevoke  = "revoke"
+)
+
+// auditLog appends audit records as json lines to a file
+type auditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+func newAuditLog(path string) *auditLog {
+	return &auditLog{path: path}
+}
+
+func (al *auditLog) record(r *AuditRecord) {
+	r.Time = time.Now()
+	log.Info("account audit", "kind", r.Kind, "address", r.Address,
+		"to", r.To, "amount", r.Amount, "hash", r.Hash, "err", r.Error)
+	if al == nil || len(al.path) == 0 {
+		return
+	}
+	enc, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	f, err := os.OpenFile(al.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		log.Warn("failed to open audit log", "path", al.path, "err", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(enc, '\n')); err != nil {
+		log.Warn("failed to write audit log", "path", al.path, "err", err)
+	}
+}
+
+// UnlockWithScope unlocks the account for duration, restricting the txs
+// it may sign to scope. Unlocking again before the unlock expires replaces
+// the previous scope, but keeps the txs and amount already signed.
+func (am *AccountManager) UnlockWithScope(address *address.Address, passphrase []byte, duration time.Duration, scope UnlockScope) error {
+	// decrypt outside the lock, the kdf would block signing for its duration
+	key, err := am.ks.GetKey(address.String(), passphrase)
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	r := &AuditRecord{Address: address.String(), Kind: AuditKindUnlock}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	am.audit.record(r)
+	if err != nil {
+		return err
+	}
+	st, ok := am.unlocks[address.String()]
+	if _, unlocked := am.ks.Unlocked()[address.String()]; !ok || !unlocked {
+		st = &unlockState{spent: new(big.Int)}
+	}
+	st.scope = scope
+	// key and scope installed together, never signing unscoped in between
+	am.ks.UnlockKey(address.String(), key, duration)
+	am.unlocks[address.String()] = st
+	return nil
+}
+
+// Unlocks returns the active unlocks.
+func (am *AccountManager) Unlocks() []*UnlockInfo {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	unlocked := am.ks.Unlocked()
+	ret := make([]*UnlockInfo, 0, len(unlocked))
+	for addrStr, expire := range unlocked {
+		addr, err := address.AddressParse(addrStr)
+		if err != nil {
+			continue
+		}
+		info := &UnlockInfo{
+			Address: addr,
+			Expire:  expire,
+			Spent:   new(big.Int),
+		}
+		if st, ok := am.unlocks[addrStr]; ok {
+			info.Scope = st.scope
+			info.Spent.Set(st.spent)
+			info.Txs = st.txs
+		}
+		ret = append(ret, info)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Expire.Before(ret[j].Expire) })
+	return ret
+}
+
+// Revoke locks the account before its unlock expires.
+func (am *AccountManager) Revoke(address *address.Address) error {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	delete(am.unlocks, address.String())
+	err := am.ks.Lock(address.String())
+	if err == nil {
+		am.audit.record(&AuditRecord{Address: address.String(), Kind: AuditKindRevoke})
+	}
+	return err
+}
+
+// SignTx checks that a tx of amount to recipient is in the unlock scope of
+// from, then calls sign with the unlocked key. sign returns the tx hash for
+// the audit log. The scope is charged only if sign succeeds.
+func (am *AccountManager) SignTx(from *address.Address, to *common.Address, amount *big.Int,
+	sign func(key []byte) (*common.Hash, error)) error {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	r := &AuditRecord{
+		Address: from.String(),
+		Kind:    AuditKindTx,
+		Amount:  amount.String(),
+	}
+	if to != nil {
+		r.To = to.Hex()
+	}
+	err := func() error {
+		if amount == nil || amount.Sign() < 0 {
+			return ErrInvalidAmount
+		}
+		key, err := am.ks.GetUnlocked(from.String())
+		if err != nil {
+			delete(am.unlocks, from.String())
+			return ErrAccountIsLocked
+		}
+		st, ok := am.unlocks[from.String()]
+		if ok {
+			if err := st.check(to, amount); err != nil {
+				return err
+			}
+		}
+		h, err := sign(key)
+		if err != nil {
+			return err
+		}
+		r.Hash = h.Hex()
+		if ok {
+			st.spent.Add(st.spent, amount)
+			st.txs++
+		}
+		return nil
+	}()
+	if err != nil {
+		r.Error = err.Error()
+	}
+	am.audit.record(r)
+	return err
+}
+
+// getUnlockedForHash returns the unlocked key to sign a raw hash, which is
+// refused under a restricted scope since the hash may be of any tx.
+func (am *AccountManager) getUnlockedForHash(address *address.Address, kind string, hash common.Hash) ([]byte, error) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	r := &AuditRecord{
+		Address: address.String(),
+		Kind:    kind,
+		Hash:    hash.Hex(),
+	}
+	key, err := am.ks.GetUnlocked(address.String())
+	if err != nil {
+		delete(am.unlocks, address.String())
+		err = ErrAccountIsLocked
+	} else if st, ok := am.unlocks[address.String()]; ok && kind == AuditKindHash && st.scope.restricted() {
+		key, err = nil, ErrUnlockScope
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	am.audit.record(r)
+	return key, err
+}
@@ -0,0 +1,343 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  The gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package accounts
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/config"
+)
+
+func newTestAccountManager(t *testing.T) (*AccountManager, *address.Address, func()) {
+	dir, err := ioutil.TempDir("", "yee-accounts-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	conf := &config.Config{
+		NodeDir: dir,
+		Chain: &config.ChainConfig{
+			KeyKDF:       "scrypt",
+			KeyKDFParams: map[string]int{"n": 1 << 10, "r": 8, "p": 1},
+		},
+	}
+	am, err := NewAccountManager(conf)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("NewAccountManager() %v", err)
+	}
+	addr, err := am.CreateNewAccount([]byte(passphrase))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("CreateNewAccount() %v", err)
+	}
+	return am, addr, func() { os.RemoveAll(dir) }
+}
+
+// sign func of SignTx, counting calls
+func testTxSigner(calls *int, err error) func(key []byte) (*common.Hash, error) {
+	return func(key []byte) (*common.Hash, error) {
+		*calls++
+		if err != nil {
+			return nil, err
+		}
+		h := common.BytesToHash(key)
+		return &h, nil
+	}
+}
+
+func TestUnlockScope(t *testing.T) {
+	am, addr, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	allowed := common.HexToAddress("0x01")
+	other := common.HexToAddress("0x02")
+	scope := UnlockScope{
+		MaxAmount:  big.NewInt(10),
+		Recipients: []common.Address{allowed},
+		MaxTxs:     2,
+	}
+	if err := am.UnlockWithScope(addr, []byte(passphrase), time.Minute, scope); err != nil {
+		t.Fatalf("UnlockWithScope() %v", err)
+	}
+
+	calls := 0
+	sign := testTxSigner(&calls, nil)
+	for _, c := range []struct {
+		to     *common.Address
+		amount int64
+		err    error
+	}{
+		{&other, 1, ErrUnlockRecipient},
+		{nil, 1, ErrUnlockRecipient},
+		{&allowed, 11, ErrUnlockAmountExceeded},
+		{&allowed, -1000000, ErrInvalidAmount},
+		{&allowed, 6, nil},
+		{&allowed, 5, ErrUnlockAmountExceeded},
+		{&allowed, 4, nil},
+		{&allowed, 0, ErrUnlockTxsExceeded},
+	} {
+		if err := am.SignTx(addr, c.to, big.NewInt(c.amount), sign); err != c.err {
+			t.Fatalf("SignTx(%v, %d) %v want %v", c.to, c.amount, err, c.err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("signed %d txs, want 2", calls)
+	}
+
+	// raw hash may be of any tx, message is not
+	if _, err := am.SignHash(addr, common.Hash{1}); err != ErrUnlockScope {
+		t.Errorf("SignHash() %v", err)
+	}
+	if _, err := am.SignMessage(addr, []byte("message")); err != nil {
+		t.Errorf("SignMessage() %v", err)
+	}
+
+	unlocks := am.Unlocks()
+	if len(unlocks) != 1 || unlocks[0].Address.String() != addr.String() {
+		t.Fatalf("Unlocks() %v", unlocks)
+	}
+	if u := unlocks[0]; u.Txs != 2 || u.Spent.Int64() != 10 || u.Scope.MaxTxs != 2 {
+		t.Errorf("unlock txs %d spent %v scope %+v", u.Txs, u.Spent, u.Scope)
+	}
+
+	// unlocking again keeps what was signed under the unlock
+	if err := am.UnlockWithScope(addr, []byte(passphrase), time.Minute, scope); err != nil {
+		t.Fatalf("UnlockWithScope() again %v", err)
+	}
+	if err := am.SignTx(addr, &allowed, big.NewInt(0), sign); err != ErrUnlockTxsExceeded {
+		t.Errorf("SignTx() after unlocking again %v", err)
+	}
+	scope.MaxTxs = 0
+	if err := am.UnlockWithScope(addr, []byte(passphrase), time.Minute, scope); err != nil {
+		t.Fatalf("UnlockWithScope() again %v", err)
+	}
+	if err := am.SignTx(addr, &allowed, big.NewInt(1), sign); err != ErrUnlockAmountExceeded {
+		t.Errorf("SignTx() after unlocking again %v", err)
+	}
+
+	// unlocking again replaces the scope
+	if err := am.Unlock(addr, []byte(passphrase), time.Minute); err != nil {
+		t.Fatalf("Unlock() %v", err)
+	}
+	if err := am.SignTx(addr, &other, big.NewInt(100), sign); err != nil {
+		t.Errorf("SignTx() after plain unlock %v", err)
+	}
+	if _, err := am.SignHash(addr, common.Hash{1}); err != nil {
+		t.Errorf("SignHash() after plain unlock %v", err)
+	}
+}
+
+func TestUnlockNegativeAmount(t *testing.T) {
+	am, addr, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	to := common.HexToAddress("0x01")
+	if err := am.UnlockWithScope(addr, []byte(passphrase), time.Minute, UnlockScope{MaxAmount: big.NewInt(10)}); err != nil {
+		t.Fatalf("UnlockWithScope() %v", err)
+	}
+	calls := 0
+	sign := testTxSigner(&calls, nil)
+	amount, _ := new(big.Int).SetString("-1000000", 10)
+	for i := 0; i < 3; i++ {
+		if err := am.SignTx(addr, &to, amount, sign); err != ErrInvalidAmount {
+			t.Fatalf("SignTx(%v) %v", amount, err)
+		}
+	}
+	if err := am.SignTx(addr, &to, nil, sign); err != ErrInvalidAmount {
+		t.Fatalf("SignTx(nil) %v", err)
+	}
+	if err := am.SignTx(addr, &to, big.NewInt(11), sign); err != ErrUnlockAmountExceeded {
+		t.Fatalf("SignTx() after negative amounts %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("signed %d txs", calls)
+	}
+
+	// refused under a plain unlock as well
+	if err := am.Unlock(addr, []byte(passphrase), time.Minute); err != nil {
+		t.Fatalf("Unlock() %v", err)
+	}
+	if err := am.SignTx(addr, &to, amount, sign); err != ErrInvalidAmount {
+		t.Fatalf("SignTx() after plain unlock %v", err)
+	}
+}
+
+func TestUnlockSignFailed(t *testing.T) {
+	am, addr, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	to := common.HexToAddress("0x01")
+	if err := am.UnlockWithScope(addr, []byte(passphrase), time.Minute, UnlockScope{MaxTxs: 1}); err != nil {
+		t.Fatalf("UnlockWithScope() %v", err)
+	}
+	calls, errSign := 0, errors.New("sign failed")
+	if err := am.SignTx(addr, &to, big.NewInt(1), testTxSigner(&calls, errSign)); err != errSign {
+		t.Fatalf("SignTx() %v", err)
+	}
+
+	// not charged to scope
+	if err := am.SignTx(addr, &to, big.NewInt(1), testTxSigner(&calls, nil)); err != nil {
+		t.Fatalf("SignTx() after failed sign %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("sign called %d times", calls)
+	}
+}
+
+func TestUnlockExpire(t *testing.T) {
+	am, addr, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	if err := am.UnlockWithScope(addr, []byte("wrong"), time.Minute, UnlockScope{}); err == nil {
+		t.Fatal("unlocked with wrong passphrase")
+	}
+	if err := am.UnlockWithScope(addr, []byte(passphrase), 50*time.Millisecond, UnlockScope{MaxTxs: 1}); err != nil {
+		t.Fatalf("UnlockWithScope() %v", err)
+	}
+	if _, err := am.SignMessage(addr, []byte("message")); err != nil {
+		t.Fatalf("SignMessage() %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	calls := 0
+	if err := am.SignTx(addr, nil, big.NewInt(0), testTxSigner(&calls, nil)); err != ErrAccountIsLocked || calls != 0 {
+		t.Errorf("SignTx() after expired %v, signed %d", err, calls)
+	}
+	if _, err := am.SignMessage(addr, []byte("message")); err != ErrAccountIsLocked {
+		t.Errorf("SignMessage() after expired %v", err)
+	}
+	if unlocks := am.Unlocks(); len(unlocks) != 0 {
+		t.Errorf("Unlocks() after expired %d", len(unlocks))
+	}
+
+	// what was signed under an expired unlock is not kept
+	for i := 0; i < 2; i++ {
+		if err := am.UnlockWithScope(addr, []byte(passphrase), 50*time.Millisecond, UnlockScope{MaxTxs: 1}); err != nil {
+			t.Fatalf("UnlockWithScope() %v", err)
+		}
+		if err := am.SignTx(addr, nil, big.NewInt(0), testTxSigner(&calls, nil)); err != nil {
+			t.Fatalf("SignTx() after unlocked again %v", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+func TestUnlockRevoke(t *testing.T) {
+	am, addr, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	if err := am.Revoke(addr); err == nil {
+		t.Error("revoked without unlock")
+	}
+	if err := am.UnlockWithScope(addr, []byte(passphrase), time.Minute, UnlockScope{MaxTxs: 1}); err != nil {
+		t.Fatalf("UnlockWithScope() %v", err)
+	}
+	if err := am.Revoke(addr); err != nil {
+		t.Fatalf("Revoke() %v", err)
+	}
+
+	// locked at once, not left unlocked without scope
+	calls := 0
+	if err := am.SignTx(addr, nil, big.NewInt(0), testTxSigner(&calls, nil)); err != ErrAccountIsLocked || calls != 0 {
+		t.Errorf("SignTx() after revoke %v, signed %d", err, calls)
+	}
+	if _, err := am.SignHash(addr, common.Hash{1}); err != ErrAccountIsLocked {
+		t.Errorf("SignHash() after revoke %v", err)
+	}
+	if unlocks := am.Unlocks(); len(unlocks) != 0 {
+		t.Errorf("Unlocks() after revoke %d", len(unlocks))
+	}
+
+	// unlocked again, not ended by the revoked one
+	if err := am.Unlock(addr, []byte(passphrase), time.Minute); err != nil {
+		t.Fatalf("Unlock() %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, err := am.SignHash(addr, common.Hash{1}); err != nil {
+		t.Errorf("SignHash() after unlocked again %v", err)
+	}
+}
+
+func TestUnlockAudit(t *testing.T) {
+	am, addr, cleanup := newTestAccountManager(t)
+	defer cleanup()
+
+	to := common.HexToAddress("0x01")
+	am.UnlockWithScope(addr, []byte("wrong"), time.Minute, UnlockScope{})
+	am.UnlockWithScope(addr, []byte(passphrase), time.Minute, UnlockScope{MaxTxs: 1})
+	calls := 0
+	am.SignTx(addr, &to, big.NewInt(3), testTxSigner(&calls, nil))
+	am.SignTx(addr, &to, big.NewInt(3), testTxSigner(&calls, nil))
+	am.SignHash(addr, common.Hash{1})
+	am.SignMessage(addr, []byte("message"))
+	am.Revoke(addr)
+
+	f, err := os.Open(am.audit.path)
+	if err != nil {
+		t.Fatalf("audit log %v", err)
+	}
+	defer f.Close()
+	var records []AuditRecord
+	for sc := bufio.NewScanner(f); sc.Scan(); {
+		var r AuditRecord
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("audit record %q %v", sc.Text(), err)
+		}
+		records = append(records, r)
+	}
+
+	want := []struct {
+		kind string
+		err  bool
+	}{
+		{AuditKindUnlock, true},
+		{AuditKindUnlock, false},
+		{AuditKindTx, false},
+		{AuditKindTx, true},
+		{AuditKindHash, true},
+		{AuditKindMessage, false},
+		{AuditKindRevoke, false},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("%d audit records, want %d", len(records), len(want))
+	}
+	for i, w := range want {
+		r := records[i]
+		if r.Kind != w.kind || (r.Error != "") != w.err || r.Address != addr.String() || r.Time.IsZero() {
+			t.Errorf("record %d %+v, want kind %s error %t", i, r, w.kind, w.err)
+		}
+	}
+	if tx := records[2]; tx.To != to.Hex() || tx.Amount != "3" || tx.Hash == "" {
+		t.Errorf("tx record %+v", tx)
+	}
+	if tx := records[3]; tx.Error != ErrUnlockTxsExceeded.Error() || tx.Hash != "" {
+		t.Errorf("refused tx record %+v", tx)
+	}
+}
@@ -21,10 +21,14 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"strconv"
+	"strings"
 
 	"github.com/urfave/cli"
+	"github.com/yeeco/gyee/accounts"
 	"github.com/yeeco/gyee/cmd/gyee/console"
 	"github.com/yeeco/gyee/common/address"
 	"github.com/yeeco/gyee/config"
@@ -52,7 +56,13 @@ var (
 				Usage:       "List all existing accounts",
 				ArgsUsage:   "[passphrase]",
 				Description: "",
-				Action:      config.MergeFlags(accountList),
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "verbose",
+						Usage: "show the cipher and kdf settings of each key file",
+					},
+				},
+				Action: config.MergeFlags(accountList),
 			},
 			{
 				Name:        "resetPassword",
@@ -68,6 +78,57 @@ var (
 				Description: "",
 				Action:      config.MergeFlags(accountImport),
 			},
+			{
+				Name:      "upgrade",
+				Usage:     "Re-encrypt account keys with another kdf or cost params",
+				ArgsUsage: "<address>...",
+				Description: `
+Re-encrypt the key files of the given accounts, or of all accounts with weak
+settings if --weak is given. Without --kdf the configured key_kdf is used.`,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "kdf",
+						Usage: "kdf of the new key file: scrypt, argon2id or balloon",
+					},
+					cli.StringSliceFlag{
+						Name:  "kdfparam",
+						Usage: "kdf cost param as name=value, e.g. n=262144",
+					},
+					cli.BoolFlag{
+						Name:  "weak",
+						Usage: "upgrade all accounts with weak key settings",
+					},
+				},
+				Action: config.MergeFlags(accountUpgrade),
+			},
+			{
+				Name:      "sign",
+				Usage:     "Sign a message with account key",
+				ArgsUsage: "<address> <message>",
+				Description: `
+Sign an off-chain message with the local key of the account, no running node
+is needed. The message is hashed with a gyee specific prefix, so the signature
+can not be used as a transaction signature.`,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "hex",
+						Usage: "message is given as hex string",
+					},
+				},
+				Action: config.MergeFlags(accountSign),
+			},
+			{
+				Name:      "verify",
+				Usage:     "Verify a message signature of account",
+				ArgsUsage: "<address> <message> <signature>",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "hex",
+						Usage: "message is given as hex string",
+					},
+				},
+				Action: config.MergeFlags(accountVerify),
+			},
 		},
 	}
 )
@@ -89,8 +150,22 @@ func accountCreate(ctx *cli.Context) error {
 func accountList(ctx *cli.Context) error {
 	node := makeNode(ctx)
 
+	verbose := ctx.Bool("verbose")
 	for i, addr := range node.AccountManager().Accounts() {
-		fmt.Printf("Account #%d: %s\n", i, addr.String())
+		if !verbose {
+			fmt.Printf("Account #%d: %s\n", i, addr.String())
+			continue
+		}
+		info, err := node.AccountManager().KeyInfo(addr)
+		if err != nil {
+			fmt.Printf("Account #%d: %s (%s)\n", i, addr.String(), err)
+			continue
+		}
+		weak := ""
+		if info.IsWeak() {
+			weak = " [weak]"
+		}
+		fmt.Printf("Account #%d: %s %s%s\n", i, addr.String(), info, weak)
 	}
 	return nil
 }
@@ -141,6 +216,113 @@ func accountImport(ctx *cli.Context) error {
 	return nil
 }
 
+func accountUpgrade(ctx *cli.Context) error {
+	params := make(map[string]int)
+	for _, param := range ctx.StringSlice("kdfparam") {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			logging.Logger.Fatalf("kdf param %s should be name=value", param)
+		}
+		v, err := strconv.Atoi(kv[1])
+		if err != nil {
+			logging.Logger.Fatalf("kdf param %s parse failed:%s", param, err)
+		}
+		params[kv[0]] = v
+	}
+
+	node := makeNode(ctx)
+	am := node.AccountManager()
+
+	var addrs []*address.Address
+	if ctx.Bool("weak") {
+		for _, addr := range am.Accounts() {
+			if info, err := am.KeyInfo(addr); err == nil && info.IsWeak() {
+				addrs = append(addrs, addr)
+			}
+		}
+	} else {
+		if len(ctx.Args()) == 0 {
+			logging.Logger.Fatal("No accounts specified")
+		}
+		for _, addrStr := range ctx.Args() {
+			addr, err := address.AddressParse(addrStr)
+			if err != nil {
+				logging.Logger.Fatalf("address %s parse failed:%s", addrStr, err)
+			}
+			addrs = append(addrs, addr)
+		}
+	}
+
+	for _, addr := range addrs {
+		pass := getPassPhrase(fmt.Sprintf("Please input passphrase for %s", addr.String()), false)
+		if err := am.Upgrade(addr, []byte(pass), ctx.String("kdf"), params); err != nil {
+			logging.Logger.Fatalf("upgrade failed:%s,%s", addr.String(), err)
+		}
+		info, _ := am.KeyInfo(addr)
+		fmt.Printf("Key upgraded for address:%s %s\n", addr.String(), info)
+	}
+	return nil
+}
+
+func accountSign(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		logging.Logger.Fatal("Need address and message")
+	}
+	addrStr := ctx.Args().Get(0)
+	addr, err := address.AddressParse(addrStr)
+	if err != nil {
+		logging.Logger.Fatalf("address %s parse failed:%s", addrStr, err)
+	}
+	message := messageArg(ctx, ctx.Args().Get(1))
+
+	am, err := accounts.NewAccountManager(config.GetConfig(ctx))
+	if err != nil {
+		logging.Logger.Fatal(err)
+	}
+	pass := getPassPhrase("Please input passphrase", false)
+	sig, err := am.SignMessageWithPassphrase(addr, []byte(pass), message)
+	if err != nil {
+		logging.Logger.Fatalf("sign failed:%s", err)
+	}
+
+	fmt.Printf("Signature: %s\n", hex.EncodeToString(sig))
+	return nil
+}
+
+func accountVerify(ctx *cli.Context) error {
+	if len(ctx.Args()) != 3 {
+		logging.Logger.Fatal("Need address, message and signature")
+	}
+	addrStr := ctx.Args().Get(0)
+	addr, err := address.AddressParse(addrStr)
+	if err != nil {
+		logging.Logger.Fatalf("address %s parse failed:%s", addrStr, err)
+	}
+	message := messageArg(ctx, ctx.Args().Get(1))
+	sig, err := hex.DecodeString(ctx.Args().Get(2))
+	if err != nil {
+		logging.Logger.Fatalf("signature parse failed:%s", err)
+	}
+
+	if err := accounts.VerifyMessage(addr, message, sig); err != nil {
+		fmt.Printf("Signature invalid: %s\n", err)
+		return err
+	}
+	fmt.Printf("Signature valid for address:%s\n", addr.String())
+	return nil
+}
+
+func messageArg(ctx *cli.Context, arg string) []byte {
+	if !ctx.Bool("hex") {
+		return []byte(arg)
+	}
+	message, err := hex.DecodeString(arg)
+	if err != nil {
+		logging.Logger.Fatalf("message parse failed:%s", err)
+	}
+	return message
+}
+
 func makeNode(ctx *cli.Context) *node.Node {
 	config := config.GetConfig(ctx)
 	node, err := node.NewNode(config)
@@ -143,6 +143,16 @@ func (b *jsBridge) getLastBlock(call otto.FunctionCall) otto.Value {
 	return value
 }
 
+func (b *jsBridge) getFinalizedBlock(call otto.FunctionCall) otto.Value {
+	response, err := b.svcApi.GetFinalizedBlock(b.ctx,
+		&rpcpb.NonParamsRequest{})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
 func (b *jsBridge) getTxByHash(call otto.FunctionCall) otto.Value {
 	hash := call.Argument(0)
 	if !hash.IsString() {
@@ -157,6 +167,20 @@ func (b *jsBridge) getTxByHash(call otto.FunctionCall) otto.Value {
 	return value
 }
 
+func (b *jsBridge) getTxReceipt(call otto.FunctionCall) otto.Value {
+	hash := call.Argument(0)
+	if !hash.IsString() {
+		return jsError(call.Otto, errors.New("not hash hex str"))
+	}
+	response, err := b.svcApi.GetTxReceipt(b.ctx,
+		&rpcpb.GetTxByHashRequest{Hash: hash.String()})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
 func (b *jsBridge) getAccountState(call otto.FunctionCall) otto.Value {
 	addr := call.Argument(0)
 	if !addr.IsString() {
@@ -171,6 +195,32 @@ func (b *jsBridge) getAccountState(call otto.FunctionCall) otto.Value {
 	return value
 }
 
+func (b *jsBridge) getEvidence(call otto.FunctionCall) otto.Value {
+	var addr string
+	if arg := call.Argument(0); arg.IsString() {
+		addr = arg.String()
+	} else if !arg.IsUndefined() {
+		return jsError(call.Otto, errors.New("not addr str"))
+	}
+	response, err := b.svcApi.GetEvidence(b.ctx,
+		&rpcpb.GetEvidenceRequest{Address: addr})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
+func (b *jsBridge) getConsensusParams(call otto.FunctionCall) otto.Value {
+	response, err := b.svcApi.GetConsensusParams(b.ctx,
+		&rpcpb.NonParamsRequest{})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
 // request handle http request
 func (b *jsBridge) request(call otto.FunctionCall) otto.Value {
 	method := call.Argument(0)
@@ -304,11 +354,92 @@ func (b *jsBridge) unlockAccount(call otto.FunctionCall) otto.Value {
 	//	fmt.Fprintln(b.writer, err)
 	//	return otto.NullValue()
 	//}
-	response, err := b.svcAdmin.UnlockAccount(b.ctx,
-		&rpcpb.UnlockAccountRequest{
-			Address:    address.String(),
-			Passphrase: passphrase.String(),
-			Duration:   uint64(300 * time.Second),
+	req := &rpcpb.UnlockAccountRequest{
+		Duration: uint64(300 * time.Second),
+	}
+	// optional unlock scope: {duration, max_amount, recipients, max_txs}
+	if scope := call.Argument(2); scope.IsObject() {
+		jsonStr, err := jsonStr(call.Otto, scope)
+		if err != nil {
+			return jsError(call.Otto, err)
+		}
+		if err := json.Unmarshal([]byte(jsonStr.String()), req); err != nil {
+			return jsError(call.Otto, err)
+		}
+	}
+	req.Address = address.String()
+	req.Passphrase = passphrase.String()
+	response, err := b.svcAdmin.UnlockAccount(b.ctx, req)
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.Result)
+	return value
+}
+
+func (b *jsBridge) listUnlocked(call otto.FunctionCall) otto.Value {
+	response, err := b.svcAdmin.ListUnlocked(b.ctx, &rpcpb.NonParamsRequest{})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
+func (b *jsBridge) revokeUnlock(call otto.FunctionCall) otto.Value {
+	req := new(rpcpb.RevokeUnlockRequest)
+	if call.Argument(0).IsString() {
+		req.Address = call.Argument(0).String()
+	}
+	response, err := b.svcAdmin.RevokeUnlock(b.ctx, req)
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
+func (b *jsBridge) listBans(call otto.FunctionCall) otto.Value {
+	response, err := b.svcAdmin.ListBans(b.ctx, &rpcpb.NonParamsRequest{})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
+func (b *jsBridge) clearBans(call otto.FunctionCall) otto.Value {
+	req := new(rpcpb.ClearBansRequest)
+	if call.Argument(0).IsString() {
+		req.NodeId = call.Argument(0).String()
+	}
+	response, err := b.svcAdmin.ClearBans(b.ctx, req)
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.Cleared)
+	return value
+}
+
+func (b *jsBridge) addPeer(call otto.FunctionCall) otto.Value {
+	req := &rpcpb.AddPeerRequest{
+		Node: call.Argument(0).String(),
+	}
+	if call.Argument(1).IsBoolean() {
+		req.Trusted, _ = call.Argument(1).ToBoolean()
+	}
+	response, err := b.svcAdmin.AddPeer(b.ctx, req)
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.Result)
+	return value
+}
+
+func (b *jsBridge) removePeer(call otto.FunctionCall) otto.Value {
+	response, err := b.svcAdmin.RemovePeer(b.ctx,
+		&rpcpb.RemovePeerRequest{
+			NodeId: call.Argument(0).String(),
 		})
 	if err != nil {
 		return jsError(call.Otto, err)
@@ -317,6 +448,24 @@ func (b *jsBridge) unlockAccount(call otto.FunctionCall) otto.Value {
 	return value
 }
 
+func (b *jsBridge) listPeers(call otto.FunctionCall) otto.Value {
+	response, err := b.svcAdmin.ListPeers(b.ctx, &rpcpb.NonParamsRequest{})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
+func (b *jsBridge) consensusState(call otto.FunctionCall) otto.Value {
+	response, err := b.svcAdmin.ConsensusState(b.ctx, &rpcpb.NonParamsRequest{})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.String())
+	return value
+}
+
 func (b *jsBridge) lockAccount(call otto.FunctionCall) otto.Value {
 	response, err := b.svcAdmin.LockAccount(b.ctx,
 		&rpcpb.LockAccountRequest{
@@ -355,6 +504,39 @@ func (b *jsBridge) sendTransaction(call otto.FunctionCall) otto.Value {
 	return v
 }
 
+func (b *jsBridge) signMessage(call otto.FunctionCall) otto.Value {
+	if !call.Argument(0).IsString() || !call.Argument(1).IsString() {
+		return jsError(call.Otto, errors.New("address/message arg must be string"))
+	}
+	response, err := b.svcAdmin.SignMessage(b.ctx,
+		&rpcpb.SignMessageRequest{
+			Address: call.Argument(0).String(),
+			Message: []byte(call.Argument(1).String()),
+		})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.Signature)
+	return value
+}
+
+func (b *jsBridge) verifyMessage(call otto.FunctionCall) otto.Value {
+	if !call.Argument(0).IsString() || !call.Argument(1).IsString() || !call.Argument(2).IsString() {
+		return jsError(call.Otto, errors.New("address/message/signature arg must be string"))
+	}
+	response, err := b.svcApi.VerifyMessage(b.ctx,
+		&rpcpb.VerifyMessageRequest{
+			Address:   call.Argument(0).String(),
+			Message:   []byte(call.Argument(1).String()),
+			Signature: call.Argument(2).String(),
+		})
+	if err != nil {
+		return jsError(call.Otto, err)
+	}
+	value, _ := otto.ToValue(response.Result)
+	return value
+}
+
 // sendTransactionWithPassphrase handle the transaction send with passphrase input
 func (b *jsBridge) sendTransactionWithPassphrase(call otto.FunctionCall) otto.Value {
 	if !call.Argument(0).IsString() || !call.Argument(1).IsString() {
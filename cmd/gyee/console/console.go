@@ -113,16 +113,31 @@ func (c *Console) setupBridge() error {
 	_ = obj.Set("newAccount", c.bridge.newAccount)
 	_ = obj.Set("unlockAccount", c.bridge.unlockAccount)
 	_ = obj.Set("lockAccount", c.bridge.lockAccount)
+	_ = obj.Set("listUnlocked", c.bridge.listUnlocked)
+	_ = obj.Set("revokeUnlock", c.bridge.revokeUnlock)
+	_ = obj.Set("listBans", c.bridge.listBans)
+	_ = obj.Set("clearBans", c.bridge.clearBans)
+	_ = obj.Set("addPeer", c.bridge.addPeer)
+	_ = obj.Set("removePeer", c.bridge.removePeer)
+	_ = obj.Set("listPeers", c.bridge.listPeers)
+	_ = obj.Set("consensusState", c.bridge.consensusState)
 
 	_ = obj.Set("sendTransaction", c.bridge.sendTransaction)
 
+	_ = obj.Set("signMessage", c.bridge.signMessage)
+	_ = obj.Set("verifyMessage", c.bridge.verifyMessage)
+
 	// temporary bridge api, should switch to js binding later
 	if true {
 		_ = obj.Set("getBlockByHash", c.bridge.getBlockByHash)
 		_ = obj.Set("getBlockByHeight", c.bridge.getBlockByHeight)
 		_ = obj.Set("getLastBlock", c.bridge.getLastBlock)
+		_ = obj.Set("getFinalizedBlock", c.bridge.getFinalizedBlock)
 		_ = obj.Set("getTxByHash", c.bridge.getTxByHash)
+		_ = obj.Set("getTxReceipt", c.bridge.getTxReceipt)
 		_ = obj.Set("getAccountState", c.bridge.getAccountState)
+		_ = obj.Set("getEvidence", c.bridge.getEvidence)
+		_ = obj.Set("getConsensusParams", c.bridge.getConsensusParams)
 
 	}
 
@@ -60,15 +60,7 @@ func consoleStart(ctx *cli.Context) error {
 }
 
 func consoleAttach(ctx *cli.Context) error {
-	conf := config.GetConfig(ctx)
-	target := conf.IPCEndpoint()
-
-	// grpc connection
-	conn, err := grpc.Dial(target, grpc.WithInsecure(),
-		grpc.WithContextDialer(func(ctx context.Context, addr string) (conn net.Conn, e error) {
-			return node.NewIPCConn(ctx, addr)
-		}),
-	)
+	conn, err := dialIPC(ctx)
 	if err != nil {
 		return err
 	}
@@ -82,3 +74,15 @@ func consoleAttach(ctx *cli.Context) error {
 
 	return nil
 }
+
+// dialIPC creates a grpc connection to the running node on its ipc endpoint
+func dialIPC(ctx *cli.Context) (*grpc.ClientConn, error) {
+	conf := config.GetConfig(ctx)
+	target := conf.IPCEndpoint()
+
+	return grpc.Dial(target, grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (conn net.Conn, e error) {
+			return node.NewIPCConn(ctx, addr)
+		}),
+	)
+}
@@ -0,0 +1,281 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+	"github.com/yeeco/gyee/config"
+	"github.com/yeeco/gyee/consensus/tetris2"
+	"github.com/yeeco/gyee/crypto/keystore"
+	"github.com/yeeco/gyee/rpc/pb"
+	"github.com/yeeco/gyee/utils/logging"
+)
+
+var (
+	debugCommand = cli.Command{
+		Name:     "debug",
+		Usage:    "Inspect internals of the running node",
+		Category: "DEBUG COMMANDS",
+
+		Subcommands: []cli.Command{
+			{
+				Name:      "consensus-dag",
+				Usage:     "Export the tetris event DAG of the running node",
+				ArgsUsage: " ",
+				Description: `
+Export events above the current block height of the last rounds, with parent
+edges, witness and committable flags and fork markers. The dot output renders
+with graphviz, e.g. "gyee debug consensus-dag | dot -Tsvg > dag.svg".`,
+				Flags: []cli.Flag{
+					cli.StringFlag{Name: "format", Value: "dot", Usage: "output format: dot or json"},
+					cli.UintFlag{Name: "rounds", Value: 4, Usage: "last rounds to export, 0 for all"},
+				},
+				Action: config.MergeFlags(debugConsensusDag),
+			},
+			{
+				Name:      "consensus-replay",
+				Usage:     "Replay a tetris recording and check the outputs are reproduced",
+				ArgsUsage: "<recording>",
+				Description: `
+Feed inputs recorded by a validator running with --tetris_record into a fresh
+tetris on the recorded clock, and compare the outputs with the recorded ones.
+Own events are signed again, so the key of the recorded validator is loaded
+from the keystore, with the passphrase in --pwdfile or prompted.`,
+				Action: config.MergeFlags(debugConsensusReplay),
+			},
+		},
+	}
+)
+
+// dagEventJSON is the json form of DAG events, zero values kept
+type dagEventJSON struct {
+	Hash        string   `json:"hash"`
+	Vid         string   `json:"vid"`
+	Height      uint64   `json:"height"`
+	N           uint64   `json:"n"`
+	Time        int64    `json:"time"`
+	Round       int32    `json:"round"`
+	Witness     bool     `json:"witness"`
+	Committable int32    `json:"committable"`
+	Ready       bool     `json:"ready"`
+	Txs         uint32   `json:"txs"`
+	Parents     []string `json:"parents"`
+	Forks       []string `json:"forks,omitempty"`
+}
+
+type dagJSON struct {
+	Vid    string          `json:"vid"`
+	Height uint64          `json:"height"`
+	Rounds uint32          `json:"rounds"`
+	Events []*dagEventJSON `json:"events"`
+}
+
+func debugConsensusDag(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != "dot" && format != "json" {
+		logging.Logger.Fatalf("unknown format %s", format)
+	}
+
+	conn, err := dialIPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	dag, err := rpcpb.NewAdminServiceClient(conn).ConsensusDag(context.Background(),
+		&rpcpb.ConsensusDagRequest{Rounds: uint32(ctx.Uint("rounds"))})
+	if err != nil {
+		logging.Logger.Fatalf("consensus dag failed:%s", err)
+	}
+	if format == "json" {
+		return printDagJSON(os.Stdout, dag)
+	}
+	printDagDot(os.Stdout, dag)
+	return nil
+}
+
+func debugConsensusReplay(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		logging.Logger.Fatal("No recording specified")
+	}
+	file := ctx.Args().First()
+	f, err := os.Open(file)
+	if err != nil {
+		logging.Logger.Fatalf("recording open failed:%s", err)
+	}
+	defer f.Close()
+
+	// recorded validator from the header
+	var header tetris2.Record
+	if err := json.NewDecoder(f).Decode(&header); err != nil || header.Kind != tetris2.RecordHeader {
+		logging.Logger.Fatalf("recording %s has no header", file)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		logging.Logger.Fatal(err)
+	}
+
+	conf := config.GetConfig(ctx)
+	ks, err := keystore.NewKeystoreWithConfig(conf)
+	if err != nil {
+		logging.Logger.Fatal(err)
+	}
+	var pass string
+	if len(conf.Chain.PwdFile) > 0 {
+		content, err := ioutil.ReadFile(conf.Chain.PwdFile)
+		if err != nil {
+			logging.Logger.Fatalf("pwdfile read failed:%s", err)
+		}
+		pass = strings.Split(string(content), "\n")[0]
+	} else {
+		pass = getPassPhrase(fmt.Sprintf("Please input passphrase for %s", header.Vid), false)
+	}
+	key, err := ks.GetKey(header.Vid, []byte(pass))
+	if err != nil {
+		logging.Logger.Fatalf("key load failed:%s", err)
+	}
+
+	res, err := tetris2.Replay(f, tetris2.NewKeyCore(key))
+	if err != nil {
+		logging.Logger.Fatal(err)
+	}
+	fmt.Printf("replayed %d sessions, %d inputs, %d outputs reproduced\n", res.Sessions, res.Inputs, res.Outputs)
+	return nil
+}
+
+func printDagJSON(w io.Writer, dag *rpcpb.ConsensusDagResponse) error {
+	j := &dagJSON{
+		Vid:    dag.Vid,
+		Height: dag.Height,
+		Rounds: dag.Rounds,
+		Events: make([]*dagEventJSON, 0, len(dag.Events)),
+	}
+	for _, ev := range dag.Events {
+		parents := ev.Parents
+		if parents == nil {
+			parents = []string{}
+		}
+		j.Events = append(j.Events, &dagEventJSON{
+			Hash:        ev.Hash,
+			Vid:         ev.Vid,
+			Height:      ev.Height,
+			N:           ev.N,
+			Time:        ev.Time,
+			Round:       ev.Round,
+			Witness:     ev.Witness,
+			Committable: ev.Committable,
+			Ready:       ev.Ready,
+			Txs:         ev.Txs,
+			Parents:     parents,
+			Forks:       ev.Forks,
+		})
+	}
+	out, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
+
+// events of a validator in a cluster, edges point to parents,
+// the self parent edge solid, others dashed. Witnesses are boxes,
+// committable ones filled, forked events red.
+func printDagDot(w io.Writer, dag *rpcpb.ConsensusDagResponse) {
+	known := make(map[string]bool, len(dag.Events))
+	clusters := make(map[string][]*rpcpb.DagEvent)
+	vids := make([]string, 0)
+	for _, ev := range dag.Events {
+		known[ev.Hash] = true
+		if _, ok := clusters[ev.Vid]; !ok {
+			vids = append(vids, ev.Vid)
+		}
+		clusters[ev.Vid] = append(clusters[ev.Vid], ev)
+	}
+
+	fmt.Fprintf(w, "digraph tetris {\n")
+	fmt.Fprintf(w, "  label=\"%s height %d rounds %d\";\n", shortVid(dag.Vid), dag.Height, dag.Rounds)
+	fmt.Fprintf(w, "  rankdir=BT;\n  node [shape=ellipse, fontsize=10];\n")
+	for i, vid := range vids {
+		fmt.Fprintf(w, "  subgraph cluster_%d {\n    label=\"%s\";\n", i, shortVid(vid))
+		for _, ev := range clusters[vid] {
+			fmt.Fprintf(w, "    \"%s\" [label=\"%d r%s\\n%d txs\"%s];\n",
+				ev.Hash, ev.N, roundLabel(ev.Round), ev.Txs, dotAttrs(ev))
+		}
+		fmt.Fprintf(w, "  }\n")
+	}
+	for _, ev := range dag.Events {
+		for i, p := range ev.Parents {
+			if !known[p] {
+				continue
+			}
+			style := "dashed"
+			if i == 0 {
+				style = "solid"
+			}
+			fmt.Fprintf(w, "  \"%s\" -> \"%s\" [style=%s];\n", ev.Hash, p, style)
+		}
+		for _, f := range ev.Forks {
+			fmt.Fprintf(w, "  \"%s\" [label=\"fork %s\", color=red];\n", f, f[:8])
+			fmt.Fprintf(w, "  \"%s\" -> \"%s\" [color=red, dir=none];\n", ev.Hash, f)
+		}
+	}
+	fmt.Fprintf(w, "}\n")
+}
+
+func dotAttrs(ev *rpcpb.DagEvent) string {
+	attrs := make([]string, 0, 3)
+	if ev.Witness {
+		attrs = append(attrs, "shape=box")
+	}
+	if ev.Committable == 1 {
+		attrs = append(attrs, "style=filled", "fillcolor=palegreen")
+	}
+	if len(ev.Forks) > 0 {
+		attrs = append(attrs, "color=red")
+	}
+	if !ev.Ready {
+		attrs = append(attrs, "fontcolor=gray")
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(attrs, ", ")
+}
+
+func roundLabel(round int32) string {
+	if round < 0 {
+		return "?"
+	}
+	return fmt.Sprint(round)
+}
+
+// address strings share prefix, the tail tells validators apart
+func shortVid(vid string) string {
+	if len(vid) <= 8 {
+		return vid
+	}
+	return vid[len(vid)-8:]
+}
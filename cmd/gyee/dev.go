@@ -0,0 +1,121 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yeeco/gyee/accounts"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/config"
+	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/node"
+	"github.com/yeeco/gyee/p2p/simnet"
+)
+
+const (
+	devPassphrase = "dev"
+	devUnlock     = 365 * 24 * time.Hour
+)
+
+// balance of the dev account in genesis
+var devBalance = new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil)
+
+// devNode is a single node chain with the dev engine,
+// data is kept in a temp dir removed on exit
+type devNode struct {
+	*node.Node
+	dir     string
+	account *address.Address
+}
+
+func newDevNode(conf *config.Config) (*devNode, error) {
+	dir, err := ioutil.TempDir("", "gyee-dev-")
+	if err != nil {
+		return nil, err
+	}
+	conf.NodeDir = dir
+	if conf.Chain == nil {
+		conf.Chain = &config.ChainConfig{}
+	}
+	conf.Chain.ChainID = uint32(core.DevNetID)
+	conf.Chain.Engine = core.EngineDev
+	conf.Chain.Mine = true
+	// key of a throwaway account needs no strong kdf
+	conf.Chain.KeyKDF = ""
+	conf.Chain.KeyKDFParams = map[string]int{"n": 4096}
+
+	dn := &devNode{dir: dir}
+	if err := dn.prepare(conf); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return dn, nil
+}
+
+// prepare the funded coinbase account, genesis and node
+func (dn *devNode) prepare(conf *config.Config) error {
+	am, err := accounts.NewAccountManager(conf)
+	if err != nil {
+		return err
+	}
+	dn.account, err = am.CreateNewAccount([]byte(devPassphrase))
+	if err != nil {
+		return err
+	}
+	pwdFile := filepath.Join(dn.dir, "dev.pwd")
+	if err := ioutil.WriteFile(pwdFile, []byte(devPassphrase), 0600); err != nil {
+		return err
+	}
+	conf.Chain.Coinbase = dn.account.String()
+	conf.Chain.PwdFile = pwdFile
+
+	genesis, err := core.NewGenesis(core.DevNetID,
+		map[string]*big.Int{dn.account.String(): devBalance},
+		[]string{dn.account.String()})
+	if err != nil {
+		return err
+	}
+	// no peers, p2p of a single node simulated network
+	net := simnet.New(simnet.Config{Realtime: true})
+	dn.Node, err = node.NewNodeWithGenesis(conf, genesis, net.NewService("dev"))
+	return err
+}
+
+func (dn *devNode) Start() error {
+	if err := dn.Node.Start(); err != nil {
+		return err
+	}
+	if err := dn.AccountManager().Unlock(dn.account, []byte(devPassphrase), devUnlock); err != nil {
+		return err
+	}
+	log.Warn("dev chain started", "account", dn.account.String(),
+		"passphrase", devPassphrase, "balance", devBalance, "dir", dn.dir)
+	return nil
+}
+
+func (dn *devNode) cleanup() {
+	if err := os.RemoveAll(dn.dir); err != nil {
+		log.Error("failed to remove dev chain dir", "dir", dn.dir, "err", err)
+	}
+}
@@ -46,6 +46,8 @@ func init() {
 	app.Copyright = "Copyright 2017-2018 The gyee Authors"
 	app.Flags = []cli.Flag{
 		config.TestnetFlag,
+		config.DevFlag,
+		config.LightFlag,
 		config.NodeConfigFlag,
 		config.NodeNameFlag,
 		config.NodeDirFlag,
@@ -63,6 +65,8 @@ func init() {
 		attachCommand,
 		configCommand,
 		accountCommand,
+		txCommand,
+		debugCommand,
 		licenseCommand,
 		versionCommand,
 	}
@@ -95,6 +99,10 @@ func gyee(ctx *cli.Context) error {
 		log.Error("failed to update fd limit", err)
 	}
 
+	if ctx.GlobalBool(config.FlagName(config.DevFlag.Name)) {
+		return gyeeDev(conf)
+	}
+
 	n, err := node.NewNode(conf)
 	if err != nil {
 		logging.Logger.Fatal(err)
@@ -107,3 +115,19 @@ func gyee(ctx *cli.Context) error {
 	n.WaitForShutdown()
 	return nil
 }
+
+//gyeeDev runs a single node development chain
+func gyeeDev(conf *config.Config) error {
+	n, err := newDevNode(conf)
+	if err != nil {
+		logging.Logger.Fatal(err)
+	}
+	defer n.cleanup()
+
+	if err := n.Start(); err != nil {
+		return err
+	}
+
+	n.WaitForShutdown()
+	return nil
+}
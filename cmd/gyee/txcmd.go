@@ -0,0 +1,318 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  The gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/config"
+	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/crypto/keystore"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+	"github.com/yeeco/gyee/rpc/pb"
+	"github.com/yeeco/gyee/utils/logging"
+)
+
+var (
+	txCommand = cli.Command{
+		Name:     "tx",
+		Usage:    "Build, sign and send transactions",
+		Category: "TRANSACTION COMMANDS",
+		Description: `
+Construct and sign transactions offline with the local keystore, decode raw
+transactions and submit signed ones to a running node.`,
+
+		Subcommands: []cli.Command{
+			{
+				Name:      "build",
+				Usage:     "Build an unsigned transaction json",
+				ArgsUsage: " ",
+				Flags: []cli.Flag{
+					cli.StringFlag{Name: "from", Usage: "sender address"},
+					cli.StringFlag{Name: "to", Usage: "recipient address"},
+					cli.StringFlag{Name: "amount", Usage: "amount decimal string"},
+					cli.Uint64Flag{Name: "nonce", Usage: "sender account nonce"},
+					cli.StringFlag{Name: "type", Usage: "tx type: transfer, join, leave or vote on the validator in to"},
+					cli.Uint64Flag{Name: "valid-after", Usage: "block height or time in milli seconds tx valid after"},
+					cli.Uint64Flag{Name: "valid-until", Usage: "block height or time in milli seconds tx expires after"},
+					config.ChainIDFlag,
+				},
+				Action: config.MergeFlags(txBuild),
+			},
+			{
+				Name:      "sign",
+				Usage:     "Sign a transaction json with the local keystore",
+				ArgsUsage: "<file>",
+				Description: `
+Sign the transaction json built by "tx build" with the key of its from address,
+read from file or stdin if file is "-". No running node is needed.`,
+				Action: config.MergeFlags(txSign),
+			},
+			{
+				Name:      "decode",
+				Usage:     "Decode a raw transaction hex to json",
+				ArgsUsage: "<raw>",
+				Description: `
+Decode a raw transaction, signed or not. The from address is only shown if the
+signature is valid.`,
+				Action: config.MergeFlags(txDecode),
+			},
+			{
+				Name:      "send",
+				Usage:     "Send a signed raw transaction to the running node",
+				ArgsUsage: "<raw>",
+				Action:    config.MergeFlags(txSend),
+			},
+		},
+	}
+)
+
+// txJSON is the json form of transactions used by tx commands,
+// addresses in it are the full address strings with checksum.
+type txJSON struct {
+	ChainID uint32 `json:"chainId"`
+	Nonce   uint64 `json:"nonce"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to"`
+	Amount  string `json:"amount"`
+	Type    string `json:"type,omitempty"`
+	Hash    string `json:"hash,omitempty"`
+	Raw     string `json:"raw,omitempty"`
+
+	// block height, or block time in milli seconds if not below
+	// core.TxValidityTimeThreshold
+	ValidAfter uint64 `json:"validAfter,omitempty"`
+	ValidUntil uint64 `json:"validUntil,omitempty"`
+}
+
+func (j *txJSON) transaction() (*core.Transaction, error) {
+	to, err := address.AddressParse(j.To)
+	if err != nil {
+		return nil, err
+	}
+	txType, err := parseTxType(j.Type)
+	if err != nil {
+		return nil, err
+	}
+	var tx *core.Transaction
+	if txType != core.TxTypeTransfer {
+		// validator txs carry no amount
+		tx = core.NewValidatorTransaction(j.ChainID, j.Nonce, txType, to.CommonAddress())
+	} else {
+		amount, ok := new(big.Int).SetString(j.Amount, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, errors.New("failed to parse amount")
+		}
+		tx = core.NewTransaction(j.ChainID, j.Nonce, to.CommonAddress(), amount)
+	}
+	tx.SetValidity(j.ValidAfter, j.ValidUntil)
+	return tx, nil
+}
+
+func parseTxType(s string) (core.TxType, error) {
+	for _, t := range []core.TxType{
+		core.TxTypeTransfer, core.TxTypeValidatorJoin,
+		core.TxTypeValidatorLeave, core.TxTypeValidatorVote,
+	} {
+		if s == t.String() {
+			return t, nil
+		}
+	}
+	if len(s) == 0 {
+		return core.TxTypeTransfer, nil
+	}
+	return 0, fmt.Errorf("unknown tx type %s", s)
+}
+
+// newTxJSON fails if verify is set and the signature of tx is invalid,
+// otherwise from is left empty for tx not signed or signed wrongly
+func newTxJSON(tx *core.Transaction, verify bool) (*txJSON, error) {
+	var from string
+	if err := tx.VerifySig(); err == nil {
+		from = address.NewAddressFromCommonAddress(*tx.From()).String()
+	} else if verify {
+		return nil, err
+	}
+	raw, err := tx.Encode()
+	if err != nil {
+		return nil, err
+	}
+	j := &txJSON{
+		ChainID: tx.ChainID(),
+		Nonce:   tx.Nonce(),
+		From:    from,
+		Amount:  tx.Amount().String(),
+		Hash:    tx.Hash().Hex(),
+		Raw:     hex.EncodeToString(raw),
+
+		ValidAfter: tx.ValidAfter(),
+		ValidUntil: tx.ValidUntil(),
+	}
+	if tx.Recipient() != nil {
+		j.To = address.NewAddressFromCommonAddress(*tx.Recipient()).String()
+	}
+	if tx.Type() != core.TxTypeTransfer {
+		j.Type = tx.Type().String()
+	}
+	return j, nil
+}
+
+func printTxJSON(j *txJSON) error {
+	out, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func txBuild(ctx *cli.Context) error {
+	conf := config.GetConfig(ctx)
+	j := &txJSON{
+		ChainID: conf.Chain.ChainID,
+		Nonce:   ctx.Uint64("nonce"),
+		From:    ctx.String("from"),
+		To:      ctx.String("to"),
+		Amount:  ctx.String("amount"),
+		Type:    ctx.String("type"),
+
+		ValidAfter: ctx.Uint64("valid-after"),
+		ValidUntil: ctx.Uint64("valid-until"),
+	}
+	if _, err := address.AddressParse(j.From); err != nil {
+		logging.Logger.Fatalf("from address %s parse failed:%s", j.From, err)
+	}
+	if _, err := j.transaction(); err != nil {
+		logging.Logger.Fatalf("build tx failed:%s", err)
+	}
+	return printTxJSON(j)
+}
+
+func txSign(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		logging.Logger.Fatal("No tx file specified")
+	}
+	var (
+		content []byte
+		err     error
+	)
+	if file := ctx.Args().First(); file == "-" {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		logging.Logger.Fatalf("file read failed:%s", err)
+	}
+	j := new(txJSON)
+	if err := json.Unmarshal(content, j); err != nil {
+		logging.Logger.Fatalf("tx json parse failed:%s", err)
+	}
+	tx, err := j.transaction()
+	if err != nil {
+		logging.Logger.Fatalf("tx json parse failed:%s", err)
+	}
+	from, err := address.AddressParse(j.From)
+	if err != nil {
+		logging.Logger.Fatalf("from address %s parse failed:%s", j.From, err)
+	}
+
+	ks, err := keystore.NewKeystoreWithConfig(config.GetConfig(ctx))
+	if err != nil {
+		logging.Logger.Fatal(err)
+	}
+	pass := getPassPhrase(fmt.Sprintf("Please input passphrase for %s", from.String()), false)
+	key, err := ks.GetKey(from.String(), []byte(pass))
+	if err != nil {
+		logging.Logger.Fatalf("key load failed:%s", err)
+	}
+	signer := secp256k1.NewSecp256k1Signer()
+	if err := signer.InitSigner(key); err != nil {
+		logging.Logger.Fatal(err)
+	}
+	if err := tx.Sign(signer); err != nil {
+		logging.Logger.Fatalf("sign failed:%s", err)
+	}
+
+	signed, err := newTxJSON(tx, true)
+	if err != nil {
+		logging.Logger.Fatalf("sign failed:%s", err)
+	}
+	if signed.From != from.String() {
+		logging.Logger.Fatalf("signer mismatch:%s", signed.From)
+	}
+	return printTxJSON(signed)
+}
+
+func txDecode(ctx *cli.Context) error {
+	tx, _ := decodeRawTx(ctx)
+	j, err := newTxJSON(tx, false)
+	if err != nil {
+		logging.Logger.Fatalf("tx decode failed:%s", err)
+	}
+	return printTxJSON(j)
+}
+
+func txSend(ctx *cli.Context) error {
+	tx, raw := decodeRawTx(ctx)
+	if err := tx.VerifySig(); err != nil {
+		logging.Logger.Fatalf("tx signature invalid:%s", err)
+	}
+
+	conn, err := dialIPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	response, err := rpcpb.NewApiServiceClient(conn).SendRawTransaction(context.Background(),
+		&rpcpb.SendRawTransactionRequest{Data: raw})
+	if err != nil {
+		logging.Logger.Fatalf("tx send failed:%s", err)
+	}
+	fmt.Printf("Tx hash: %s\n", response.Hash)
+	return nil
+}
+
+func decodeRawTx(ctx *cli.Context) (*core.Transaction, []byte) {
+	if len(ctx.Args()) == 0 {
+		logging.Logger.Fatal("No raw tx specified")
+	}
+	raw, err := hex.DecodeString(strings.TrimPrefix(ctx.Args().First(), "0x"))
+	if err != nil {
+		logging.Logger.Fatalf("raw tx parse failed:%s", err)
+	}
+	tx := new(core.Transaction)
+	if err := tx.Decode(raw); err != nil {
+		logging.Logger.Fatalf("raw tx decode failed:%s", err)
+	}
+	return tx, raw
+}
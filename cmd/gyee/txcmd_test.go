@@ -0,0 +1,125 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  The gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+)
+
+func testTxKey(t *testing.T) (*secp256k1.Key, *address.Address) {
+	key := secp256k1.GenerateKey()
+	addr, err := address.NewAddressFromPublicKey(key.PublicKey())
+	if err != nil {
+		t.Fatalf("NewAddressFromPublicKey() %v", err)
+	}
+	return key, addr
+}
+
+// run gyee with args, the tx json printed is returned
+func runTxCommand(t *testing.T, args ...string) *txJSON {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe() %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	err = app.Run(append([]string{"gyee"}, args...))
+	os.Stdout = stdout
+	w.Close()
+	out, _ := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("%v: %v", args, err)
+	}
+	j := new(txJSON)
+	if err := json.Unmarshal(out, j); err != nil {
+		t.Fatalf("%v: output %s: %v", args, out, err)
+	}
+	return j
+}
+
+func TestTxBuildChainID(t *testing.T) {
+	_, from := testTxKey(t)
+	_, to := testTxKey(t)
+	args := []string{"tx", "build", "--from", from.String(), "--to", to.String(),
+		"--amount", "100", "--nonce", "3"}
+
+	j := runTxCommand(t, append(args, "--chainid", "7")...)
+	if j.ChainID != 7 || j.Nonce != 3 || j.From != from.String() || j.To != to.String() || j.Amount != "100" {
+		t.Errorf("built %+v", j)
+	}
+	tx, err := j.transaction()
+	if err != nil {
+		t.Fatalf("transaction() %v", err)
+	}
+	if tx.ChainID() != 7 {
+		t.Errorf("tx chain id %d, want 7", tx.ChainID())
+	}
+
+	// the chain id of config if no flag
+	if j := runTxCommand(t, args...); j.ChainID == 7 {
+		t.Errorf("chain id %d kept from last build", j.ChainID)
+	}
+}
+
+func TestTxDecode(t *testing.T) {
+	key, from := testTxKey(t)
+	_, to := testTxKey(t)
+	tx := core.NewTransaction(7, 1, to.CommonAddress(), big.NewInt(100))
+	tx.SetValidity(10, 20)
+
+	// not signed, decoded without from
+	raw, err := tx.Encode()
+	if err != nil {
+		t.Fatalf("Encode() %v", err)
+	}
+	j := runTxCommand(t, "tx", "decode", hex.EncodeToString(raw))
+	if j.From != "" || j.To != to.String() || j.ChainID != 7 || j.ValidAfter != 10 || j.ValidUntil != 20 {
+		t.Errorf("unsigned decoded %+v", j)
+	}
+	if _, err := newTxJSON(tx, true); err == nil {
+		t.Error("unsigned tx verified")
+	}
+
+	signer := secp256k1.NewSecp256k1Signer()
+	if err := signer.InitSigner(key.PrivateKey()); err != nil {
+		t.Fatalf("InitSigner() %v", err)
+	}
+	if err := tx.Sign(signer); err != nil {
+		t.Fatalf("Sign() %v", err)
+	}
+	raw, err = tx.Encode()
+	if err != nil {
+		t.Fatalf("Encode() %v", err)
+	}
+	j = runTxCommand(t, "tx", "decode", "0x"+hex.EncodeToString(raw))
+	if j.From != from.String() || j.Amount != "100" || j.Hash != tx.Hash().Hex() || j.Raw != hex.EncodeToString(raw) {
+		t.Errorf("signed decoded %+v", j)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestProof(t *testing.T) {
+	trie := newEmpty()
+	vals := make(map[string][]byte)
+	for i := 0; i < 200; i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		val := bytes.Repeat([]byte{byte(i)}, i%40+1)
+		trie.Update(key, val)
+		vals[string(key)] = val
+	}
+	root, err := trie.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// proofs from a trie reopened from database
+	trie, _ = New(root, trie.db)
+	for key, val := range vals {
+		proof, err := trie.Prove([]byte(key))
+		if err != nil {
+			t.Fatalf("prove %s: %v", key, err)
+		}
+		got, err := VerifyProof(root, []byte(key), proof)
+		if err != nil {
+			t.Fatalf("verify %s: %v", key, err)
+		}
+		if !bytes.Equal(got, val) {
+			t.Fatalf("verify %s: got %x, want %x", key, got, val)
+		}
+	}
+
+	// absent key
+	proof, err := trie.Prove([]byte("missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := VerifyProof(root, []byte("missing"), proof); err != nil || got != nil {
+		t.Fatalf("verify absent key: got %x, %v", got, err)
+	}
+
+	// tampered proof
+	proof, _ = trie.Prove([]byte("key-1"))
+	proof[len(proof)-1] = append([]byte{}, proof[len(proof)-1]...)
+	proof[len(proof)-1][len(proof[len(proof)-1])-1] ^= 1
+	if _, err := VerifyProof(root, []byte("key-1"), proof); err == nil {
+		t.Fatal("tampered proof verified")
+	}
+	if _, err := VerifyProof(root, []byte("key-1"), nil); err == nil {
+		t.Fatal("empty proof verified")
+	}
+}
@@ -64,11 +64,15 @@ type P2pConfig struct {
 	BootstrapNode     bool     `toml:"bootstrap_node"`
 	BootstrapNodes    []string `toml:"bootstrap_nodes"`
 	DhtBootstrapNodes []string `toml:"dht_bootstrap_nodes"`
+	ReservedNodes     []string `toml:"reserved_nodes"`
+	TrustedNodes      []string `toml:"trusted_nodes"`
 	LocalNodeIp       string   `toml:"local_node_ip"`
 	LocalUdpPort      uint16   `toml:"local_udp_port"`
 	LocalTcpPort      uint16   `toml:"local_tcp_port"`
+	LocalAddrs        []string `toml:"local_addrs"`
 	LocalDhtIp        string   `toml:"local_dht_ip"`
 	LocalDhtPort      uint16   `toml:"local_dht_port"`
+	LocalDhtAddrs     []string `toml:"local_dht_addrs"`
 	NodeDataDir       string   `toml:"node_data_path"`
 	NodeDatabase      string   `toml:"node_database"`
 	SubNetMaskBits    int      `toml:"subnet_mask_bits"`
@@ -77,6 +81,19 @@ type P2pConfig struct {
 	BootstrapTime     int      `toml:"bootstrap_time"`
 	NatType           string   `toml:"nat_type"`
 	GatewayIp         string   `toml:"gateway_ip"`
+	Encryption        string   `toml:"encryption"`
+
+	// rate limits with a peer keyed by message type: tx, ev, blkH, blk, or
+	// peer for all traffic with a peer
+	RateLimits map[string]RateLimitConfig `toml:"rate_limits"`
+}
+
+//Messages and bytes per second in each direction, 0 for unlimited
+type RateLimitConfig struct {
+	RxMsgs  int `toml:"rx_msgs"`
+	RxBytes int `toml:"rx_bytes"`
+	TxMsgs  int `toml:"tx_msgs"`
+	TxBytes int `toml:"tx_bytes"`
 }
 
 //Listen addr, modules, access right
@@ -93,9 +110,38 @@ type ChainConfig struct {
 	KeyDir   string `toml:"key_dir"`
 	Genesis  string `toml:"genesis"`
 	Mine     bool   `toml:"mine"`
+	Light    bool   `toml:"light"` // follow headers only, state queried with proofs from peers
 	Coinbase string `toml:"coinbase"`
 	PwdFile  string `toml:"pwdfile"`
 	Key      []byte // raw private key used in unit test
+
+	// kdf used to encrypt new keys: scrypt, argon2id or balloon,
+	// with optional cost params overriding the standard ones, e.g. n, r, p for scrypt
+	KeyKDF       string         `toml:"key_kdf"`
+	KeyKDFParams map[string]int `toml:"key_kdf_params"`
+
+	// consensus engine: tetris2 (default, also as tetris) or dev, the single
+	// node engine sealing a block per tx, or the txs pending every dev_period
+	// milliseconds if set; tetris1, the first version, is not supported
+	Engine    string `toml:"engine"`
+	DevPeriod int    `toml:"dev_period"`
+
+	// local tuning of tetris2 engine, event timing is set in genesis
+	Tetris *TetrisConfig `toml:"tetris"`
+}
+
+// zero values for defaults, tick in milliseconds follows genesis
+// max tx delay if not set
+type TetrisConfig struct {
+	Tick              int `toml:"tick"`
+	EventCache        int `toml:"event_cache"`
+	EventRequestCache int `toml:"event_request_cache"`
+	TxsCache          int `toml:"txs_cache"`
+	TxsCommittedCache int `toml:"txs_committed_cache"`
+
+	// file recording inputs of tetris, for replaying with
+	// "gyee debug consensus-replay", not recorded if empty
+	Record string `toml:"record"`
 }
 
 //cpu, mem, disk profile,
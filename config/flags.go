@@ -33,6 +33,16 @@ var (
 		Usage: "test network: pre-configured test network",
 	}
 
+	DevFlag = cli.BoolFlag{
+		Name:  "dev",
+		Usage: "development chain: single node with a funded ephemeral account",
+	}
+
+	LightFlag = cli.BoolFlag{
+		Name:  "light",
+		Usage: "light node: verify block headers only, query state with proofs from peers",
+	}
+
 	NodeConfigFlag = cli.StringFlag{
 		Name:  "config, c",
 		Usage: "load configuration from `FILE`",
@@ -82,6 +92,7 @@ var (
 		NetworkBootNodeFlag,
 		NetworkListenFlag,
 		NetworkIPFlag,
+		NetworkEncryptionFlag,
 	}
 
 	NetworkBootNodeFlag = cli.StringSliceFlag{
@@ -94,6 +105,11 @@ var (
 		Usage: "local IP",
 	}
 
+	NetworkEncryptionFlag = cli.StringFlag{
+		Name:  "p2p_encryption",
+		Usage: "p2p transport encryption: off, optional or required (default)",
+	}
+
 	NetworkListenFlag = cli.StringSliceFlag{
 		Name:  "p2p_listen",
 		Usage: "p2p netowrk listen port",
@@ -130,6 +146,11 @@ var (
 		ChainMineFlag,
 		ChainCoinbaseFlag,
 		ChainPwdFileFlag,
+		ChainKeyKDFFlag,
+		ChainEngineFlag,
+		ChainDevPeriodFlag,
+		ChainTetrisTickFlag,
+		ChainTetrisRecordFlag,
 	}
 
 	ChainIDFlag = cli.IntFlag{
@@ -167,6 +188,31 @@ var (
 		Usage: "pwdfile for coinbase keystore",
 	}
 
+	ChainKeyKDFFlag = cli.StringFlag{
+		Name:  "keykdf",
+		Usage: "kdf for new keys: scrypt, argon2id or balloon",
+	}
+
+	ChainEngineFlag = cli.StringFlag{
+		Name:  "engine",
+		Usage: "consensus engine: tetris2 (or tetris) or dev",
+	}
+
+	ChainDevPeriodFlag = cli.IntFlag{
+		Name:  "dev_period",
+		Usage: "block period in milliseconds of dev engine, no block if no txs, 0 to seal on every tx",
+	}
+
+	ChainTetrisTickFlag = cli.IntFlag{
+		Name:  "tetris_tick",
+		Usage: "interval in milliseconds tetris checks whether to send event",
+	}
+
+	ChainTetrisRecordFlag = cli.StringFlag{
+		Name:  "tetris_record",
+		Usage: "file to record tetris inputs for replay, appended on restart",
+	}
+
 	//MetricsConfig Flags
 	MetricsFlags = []cli.Flag{
 		MetricsEnableFlag,
@@ -233,6 +279,10 @@ func getNetworkConfig(ctx *cli.Context, cfg *Config) {
 		cfg.P2p.LocalNodeIp = ctx.GlobalString(FlagName(NetworkIPFlag.Name))
 		cfg.P2p.LocalDhtIp = ctx.GlobalString(FlagName(NetworkIPFlag.Name))
 	}
+
+	if ctx.GlobalIsSet(FlagName(NetworkEncryptionFlag.Name)) {
+		cfg.P2p.Encryption = ctx.GlobalString(FlagName(NetworkEncryptionFlag.Name))
+	}
 }
 
 func getRpcConfig(ctx *cli.Context, cfg *Config) {
@@ -278,6 +328,10 @@ func getChainConfig(ctx *cli.Context, cfg *Config) {
 		cfg.Chain.Mine = ctx.GlobalBool(FlagName(ChainMineFlag.Name))
 	}
 
+	if ctx.GlobalIsSet(FlagName(LightFlag.Name)) {
+		cfg.Chain.Light = ctx.GlobalBool(FlagName(LightFlag.Name))
+	}
+
 	if ctx.GlobalIsSet(FlagName(ChainCoinbaseFlag.Name)) {
 		cfg.Chain.Coinbase = ctx.GlobalString(FlagName(ChainCoinbaseFlag.Name))
 	}
@@ -285,6 +339,32 @@ func getChainConfig(ctx *cli.Context, cfg *Config) {
 	if ctx.GlobalIsSet(FlagName(ChainPwdFileFlag.Name)) {
 		cfg.Chain.PwdFile = ctx.GlobalString(FlagName(ChainPwdFileFlag.Name))
 	}
+
+	if ctx.GlobalIsSet(FlagName(ChainKeyKDFFlag.Name)) {
+		cfg.Chain.KeyKDF = ctx.GlobalString(FlagName(ChainKeyKDFFlag.Name))
+	}
+
+	if ctx.GlobalIsSet(FlagName(ChainEngineFlag.Name)) {
+		cfg.Chain.Engine = ctx.GlobalString(FlagName(ChainEngineFlag.Name))
+	}
+
+	if ctx.GlobalIsSet(FlagName(ChainDevPeriodFlag.Name)) {
+		cfg.Chain.DevPeriod = ctx.GlobalInt(FlagName(ChainDevPeriodFlag.Name))
+	}
+
+	if ctx.GlobalIsSet(FlagName(ChainTetrisTickFlag.Name)) {
+		if cfg.Chain.Tetris == nil {
+			cfg.Chain.Tetris = &TetrisConfig{}
+		}
+		cfg.Chain.Tetris.Tick = ctx.GlobalInt(FlagName(ChainTetrisTickFlag.Name))
+	}
+
+	if ctx.GlobalIsSet(FlagName(ChainTetrisRecordFlag.Name)) {
+		if cfg.Chain.Tetris == nil {
+			cfg.Chain.Tetris = &TetrisConfig{}
+		}
+		cfg.Chain.Tetris.Record = ctx.GlobalString(FlagName(ChainTetrisRecordFlag.Name))
+	}
 }
 
 func getMetricsConfig(ctx *cli.Context, cfg *Config) {
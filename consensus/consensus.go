@@ -56,6 +56,9 @@ type Engine interface {
 	OnTxSealed(uint64, []common.Hash)
 	// inform engine txs has been dropped
 	OnTxDropped([]common.Hash)
+
+	// inform engine validator set changes at block height
+	RotateValidators(height uint64, joins, quits []string)
 }
 
 func (o Output) String() string {
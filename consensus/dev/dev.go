@@ -0,0 +1,168 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package dev implements a single node consensus engine for local development,
+// it orders txs as they arrive without exchanging events with other validators.
+package dev
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/consensus"
+	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/utils"
+)
+
+// Engine outputs a block for every tx received if period is 0,
+// or a block with all pending txs every period otherwise, no block is
+// output for a period without txs
+type Engine struct {
+	h      uint64 // height of last output
+	period time.Duration
+
+	txsCh    chan common.Hash
+	dropCh   chan []common.Hash
+	outputCh chan *consensus.Output
+
+	// never fired, there are no events exchanged
+	eventSendCh chan []byte
+	eventReqCh  chan common.Hash
+
+	txsCache *utils.LRU // dedup for received txs
+	pending  []common.Hash
+
+	lock   sync.Mutex
+	quitCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewEngine(blockHeight uint64, period time.Duration) *Engine {
+	return &Engine{
+		h:           blockHeight,
+		period:      period,
+		txsCh:       make(chan common.Hash, 2000),
+		dropCh:      make(chan []common.Hash, 100),
+		outputCh:    make(chan *consensus.Output, 10),
+		eventSendCh: make(chan []byte),
+		eventReqCh:  make(chan common.Hash),
+		txsCache:    utils.NewLRU(10000, nil),
+		pending:     make([]common.Hash, 0),
+		quitCh:      make(chan struct{}),
+	}
+}
+
+func (e *Engine) Start() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	log.Info("dev engine start", "height", e.h, "period", e.period)
+	e.wg.Add(1)
+	go e.loop()
+	return nil
+}
+
+func (e *Engine) Stop() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	close(e.quitCh)
+	e.wg.Wait()
+	return nil
+}
+
+func (e *Engine) ChanEventSend() <-chan []byte {
+	return e.eventSendCh
+}
+
+func (e *Engine) ChanEventReq() <-chan common.Hash {
+	return e.eventReqCh
+}
+
+func (e *Engine) Output() <-chan *consensus.Output {
+	return e.outputCh
+}
+
+func (e *Engine) SendEvent([]byte) {}
+
+func (e *Engine) SendParentEvent([]byte) {}
+
+func (e *Engine) SendTx(hash common.Hash) {
+	e.txsCh <- hash
+}
+
+func (e *Engine) OnTxSealed(uint64, []common.Hash) {}
+
+func (e *Engine) OnTxDropped(txs []common.Hash) {
+	e.dropCh <- txs
+}
+
+// validator set of a dev chain is fixed
+func (e *Engine) RotateValidators(height uint64, joins, quits []string) {
+	log.Warn("dev engine ignores validator changes", "height", height)
+}
+
+func (e *Engine) loop() {
+	defer e.wg.Done()
+
+	var tick <-chan time.Time
+	if e.period > 0 {
+		ticker := time.NewTicker(e.period)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-e.quitCh:
+			return
+		case hash := <-e.txsCh:
+			if e.txsCache.Contains(hash) {
+				continue
+			}
+			e.txsCache.Add(hash, true)
+			if e.period == 0 {
+				e.output([]common.Hash{hash})
+			} else {
+				e.pending = append(e.pending, hash)
+			}
+		case txs := <-e.dropCh:
+			// dropped txs may be sent again
+			for _, hash := range txs {
+				e.txsCache.Remove(hash)
+			}
+		case <-tick:
+			if len(e.pending) == 0 {
+				continue
+			}
+			e.output(e.pending)
+			e.pending = make([]common.Hash, 0)
+		}
+	}
+}
+
+func (e *Engine) output(txs []common.Hash) {
+	e.h++
+	o := &consensus.Output{
+		Txs:    txs,
+		H:      e.h,
+		T:      time.Now(),
+		Output: "dev",
+	}
+	select {
+	case e.outputCh <- o:
+	case <-e.quitCh:
+	}
+}
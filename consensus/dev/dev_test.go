@@ -0,0 +1,98 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package dev
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+)
+
+func TestEngineEveryTx(t *testing.T) {
+	e := NewEngine(5, 0)
+	if err := e.Start(); err != nil {
+		t.Fatalf("Start() %v", err)
+	}
+	defer e.Stop()
+
+	tx := common.BytesToHash([]byte("tx"))
+	e.SendTx(tx)
+	e.SendTx(tx)
+	select {
+	case o := <-e.Output():
+		if o.H != 6 || len(o.Txs) != 1 || o.Txs[0] != tx {
+			t.Errorf("output h %d txs %v", o.H, o.Txs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no output for tx")
+	}
+	// duplicated tx is not sealed again, unless dropped
+	select {
+	case o := <-e.Output():
+		t.Fatalf("output h %d for duplicated tx", o.H)
+	case <-time.After(50 * time.Millisecond):
+	}
+	e.OnTxDropped([]common.Hash{tx})
+	e.SendTx(tx)
+	select {
+	case o := <-e.Output():
+		if o.H != 7 {
+			t.Errorf("output h %d, want 7", o.H)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no output for dropped tx sent again")
+	}
+}
+
+func TestEnginePeriod(t *testing.T) {
+	period := 20 * time.Millisecond
+	e := NewEngine(0, period)
+	if err := e.Start(); err != nil {
+		t.Fatalf("Start() %v", err)
+	}
+	defer e.Stop()
+
+	// no empty blocks while idle
+	select {
+	case o := <-e.Output():
+		t.Fatalf("output h %d without txs", o.H)
+	case <-time.After(5 * period):
+	}
+
+	txs := []common.Hash{common.BytesToHash([]byte("a")), common.BytesToHash([]byte("b"))}
+	for _, tx := range txs {
+		e.SendTx(tx)
+	}
+	sealed := make([]common.Hash, 0, len(txs))
+	deadline := time.After(time.Second)
+	for len(sealed) < len(txs) {
+		select {
+		case o := <-e.Output():
+			if len(o.Txs) == 0 {
+				t.Fatalf("empty output h %d", o.H)
+			}
+			sealed = append(sealed, o.Txs...)
+		case <-deadline:
+			t.Fatalf("sealed %d txs, want %d", len(sealed), len(txs))
+		}
+	}
+	if sealed[0] != txs[0] || sealed[1] != txs[1] {
+		t.Errorf("sealed %v, want %v", sealed, txs)
+	}
+}
@@ -178,7 +178,9 @@ func (em *EventMessage) Unmarshal(data []byte) error {
 	}
 
 	em.Body = &EventBody{}
-	em.Body.Unmarshal(data[p : p+int(ebl)])
+	if err := em.Body.Unmarshal(data[p : p+int(ebl)]); err != nil {
+		return err
+	}
 	p += int(ebl)
 
 	if dl < 4+int(ebl)+4 {
@@ -188,7 +190,7 @@ func (em *EventMessage) Unmarshal(data []byte) error {
 	sl := binary.BigEndian.Uint32(data[p : p+4])
 	p += 4
 
-	if dl < 4+int(ebl)+4+int(sl) {
+	if sl < 1 || dl < 4+int(ebl)+4+int(sl) {
 		return errors.New("error with data length")
 	}
 
@@ -221,11 +223,11 @@ type Event struct {
 	fork     []*Event //Fork Events, as Invalid
 }
 
-func NewEvent(vid string, height uint64, sequenceNumber uint64) *Event {
+func NewEvent(vid string, height uint64, sequenceNumber uint64, t time.Time) *Event {
 	body := &EventBody{
 		H: height,
 		N: sequenceNumber,
-		T: time.Now().UnixNano(),
+		T: t.UnixNano(),
 	}
 
 	event := Event{
@@ -244,9 +246,9 @@ func NewEvent(vid string, height uint64, sequenceNumber uint64) *Event {
 	return &event
 }
 
-func NewPulse() *Event {
+func NewPulse(t time.Time) *Event {
 	body := &EventBody{
-		T: time.Now().UnixNano(),
+		T: t.UnixNano(),
 		P: true,
 	}
 
@@ -0,0 +1,140 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tetris2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/crypto"
+)
+
+var (
+	ErrEvidenceLength    = errors.New("error with evidence data length")
+	ErrEvidenceNotFork   = errors.New("evidence events are not forked")
+	ErrEvidenceSignature = errors.New("evidence event signature verification failed")
+	ErrEvidenceSigner    = errors.New("evidence events signed by different validators")
+)
+
+//Evidence of equivocation: two different events signed by one validator with the same sequence number.
+//It is self-contained, anyone can verify it without the tetris state.
+type Evidence struct {
+	First  *EventMessage
+	Second *EventMessage
+
+	vid string //validator derived from signatures, set after Verify
+}
+
+//Events are ordered by body hash, so validators detecting the same fork produce the same evidence.
+func NewEvidence(a, b *Event) *Evidence {
+	first := &EventMessage{Body: a.Body, Signature: a.signature}
+	second := &EventMessage{Body: b.Body, Signature: b.signature}
+	ha, hb := a.Body.Hash(), b.Body.Hash()
+	if bytes.Compare(ha[:], hb[:]) > 0 {
+		first, second = second, first
+	}
+	return &Evidence{
+		First:  first,
+		Second: second,
+		vid:    a.vid,
+	}
+}
+
+//Marshal format: [4 bytes length][first event message][4 bytes length][second event message]
+func (ev *Evidence) Marshal() []byte {
+	first, second := ev.First.Marshal(), ev.Second.Marshal()
+	buf := make([]byte, 8+len(first)+len(second))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(first)))
+	copy(buf[4:], first)
+	p := 4 + len(first)
+	binary.BigEndian.PutUint32(buf[p:p+4], uint32(len(second)))
+	copy(buf[p+4:], second)
+	return buf
+}
+
+func (ev *Evidence) Unmarshal(data []byte) error {
+	msgs := make([]*EventMessage, 0, 2)
+	p := 0
+	for i := 0; i < 2; i++ {
+		if len(data) < p+4 {
+			return ErrEvidenceLength
+		}
+		l := int(binary.BigEndian.Uint32(data[p : p+4]))
+		p += 4
+		if l < 0 || len(data) < p+l {
+			return ErrEvidenceLength
+		}
+		em := &EventMessage{}
+		if err := em.Unmarshal(data[p : p+l]); err != nil {
+			return err
+		}
+		msgs = append(msgs, em)
+		p += l
+	}
+	if p != len(data) {
+		return ErrEvidenceLength
+	}
+	ev.First, ev.Second = msgs[0], msgs[1]
+	ev.vid = ""
+	return nil
+}
+
+//Verify the two events are signed by the same validator with the same sequence number but differ.
+func (ev *Evidence) Verify(signer crypto.Signer) error {
+	if ev.First.Body.N != ev.Second.Body.N {
+		return ErrEvidenceNotFork
+	}
+	h1, h2 := ev.First.Body.Hash(), ev.Second.Body.Hash()
+	if h1 == h2 {
+		return ErrEvidenceNotFork
+	}
+	vids := make([]string, 0, 2)
+	for _, em := range []*EventMessage{ev.First, ev.Second} {
+		h := em.Body.Hash()
+		pk, err := signer.RecoverPublicKey(h[:], em.Signature)
+		if err != nil {
+			return err
+		}
+		if !signer.Verify(pk, h[:], em.Signature) {
+			return ErrEvidenceSignature
+		}
+		addr, err := address.NewAddressFromPublicKey(pk)
+		if err != nil {
+			return err
+		}
+		vids = append(vids, hex.EncodeToString(addr.Raw))
+	}
+	if vids[0] != vids[1] {
+		return ErrEvidenceSigner
+	}
+	ev.vid = vids[0]
+	return nil
+}
+
+//Validator who equivocated, valid after Verify
+func (ev *Evidence) Vid() string {
+	return ev.vid
+}
+
+//Sequence number of the forked events
+func (ev *Evidence) N() uint64 {
+	return ev.First.Body.N
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tetris2
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+)
+
+const StateRecentRounds = 8 //rounds of witnesses reported in State
+
+var ErrTetrisStopped = errors.New("tetris stopped")
+
+//ValidatorState is the progress of a validator seen by this tetris.
+type ValidatorState struct {
+	Vid       string
+	Height    uint64    //sequence number of the highest ready event
+	Pending   uint64    //sequence number of the highest event received
+	Heartbeat time.Time //last event or pulse received, zero if none
+}
+
+//DagEvent is an event of the DAG with its consensus flags.
+type DagEvent struct {
+	Hash        common.Hash
+	Vid         string
+	H           uint64
+	N           uint64
+	T           int64
+	Round       int //ROUND_UNDECIDED if not decided
+	Witness     bool
+	Committable int //COMMITTABLE_UNDECIDED, 0 or 1
+	Ready       bool
+	Txs         int
+	Parents     []common.Hash //self parent first
+	Forks       []common.Hash //events of the same sequence number with different hash
+}
+
+//RoundState lists the witnesses of a round at current height.
+type RoundState struct {
+	Round     int
+	Witnesses []*DagEvent
+}
+
+//State is a snapshot of tetris, for diagnosing.
+type State struct {
+	Vid         string
+	H           uint64
+	N           uint64
+	Validators  []*ValidatorState
+	TxsAccepted int //txs waiting for own event
+	TxsPending  int //txs in events, waiting for consensus
+	TxsQueued   int //txs not yet received by loop
+	Rounds      []*RoundState
+}
+
+//Dag is the event DAG above current height, for the last rounds.
+type Dag struct {
+	Vid    string
+	H      uint64
+	Rounds int //rounds decided at current height
+	Events []*DagEvent
+}
+
+//State returns a snapshot taken in tetris loop.
+func (t *Tetris) State() (*State, error) {
+	var s *State
+	if !t.inspect(func() { s = t.state() }) {
+		return nil, ErrTetrisStopped
+	}
+	return s, nil
+}
+
+//Dag returns the events of the last rounds rounds at current height, with events of undecided round.
+//All decided rounds are included if rounds <= 0.
+func (t *Tetris) Dag(rounds int) (*Dag, error) {
+	var d *Dag
+	if !t.inspect(func() { d = t.dag(rounds) }) {
+		return nil, ErrTetrisStopped
+	}
+	return d, nil
+}
+
+//run f in loop, so that no lock is needed on tetris states
+func (t *Tetris) inspect(f func()) bool {
+	done := make(chan struct{})
+	select {
+	case t.InspectCh <- func() { f(); close(done) }:
+	case <-t.quitCh:
+		return false
+	}
+	select {
+	case <-done:
+		return true
+	case <-t.quitCh:
+		return false
+	}
+}
+
+func (t *Tetris) sortedVids() []string {
+	vids := make([]string, 0, len(t.validators))
+	for vid := range t.validators {
+		vids = append(vids, vid)
+	}
+	sort.Strings(vids)
+	return vids
+}
+
+func (t *Tetris) state() *State {
+	s := &State{
+		Vid:         t.vid,
+		H:           t.h,
+		N:           t.n,
+		Validators:  make([]*ValidatorState, 0, len(t.validators)),
+		TxsAccepted: len(t.txsAccepted),
+		TxsPending:  len(t.txsPending),
+		TxsQueued:   len(t.TxsCh),
+	}
+	for _, vid := range t.sortedVids() {
+		s.Validators = append(s.Validators, &ValidatorState{
+			Vid:       vid,
+			Height:    t.validatorsHeight[vid],
+			Pending:   t.pendingHeight[vid],
+			Heartbeat: t.heartBeat[vid],
+		})
+	}
+	from := len(t.witness) - StateRecentRounds
+	if from < 0 {
+		from = 0
+	}
+	for r := from; r < len(t.witness); r++ {
+		rs := &RoundState{Round: r, Witnesses: make([]*DagEvent, 0, len(t.witness[r]))}
+		for _, w := range t.witness[r] {
+			rs.Witnesses = append(rs.Witnesses, newDagEvent(w))
+		}
+		s.Rounds = append(s.Rounds, rs)
+	}
+	return s
+}
+
+func (t *Tetris) dag(rounds int) *Dag {
+	d := &Dag{
+		Vid:    t.vid,
+		H:      t.h,
+		Rounds: len(t.witness),
+		Events: make([]*DagEvent, 0),
+	}
+	from := 0
+	if rounds > 0 && len(t.witness) > rounds {
+		from = len(t.witness) - rounds
+	}
+	for _, vid := range t.sortedVids() {
+		events := t.validators[vid]
+		ns := make([]uint64, 0, len(events))
+		for n := range events {
+			ns = append(ns, n)
+		}
+		sort.Slice(ns, func(i, j int) bool { return ns[i] < ns[j] })
+		for _, n := range ns {
+			ev := events[n]
+			if ev == nil || n <= t.h {
+				continue
+			}
+			if ev.round != ROUND_UNDECIDED && ev.round < from {
+				continue
+			}
+			d.Events = append(d.Events, newDagEvent(ev))
+		}
+	}
+	return d
+}
+
+func newDagEvent(ev *Event) *DagEvent {
+	de := &DagEvent{
+		Hash:        ev.Hash(),
+		Vid:         ev.vid,
+		H:           ev.Body.H,
+		N:           ev.Body.N,
+		T:           ev.Body.T,
+		Round:       ev.round,
+		Witness:     ev.witness,
+		Committable: ev.committable,
+		Ready:       ev.ready,
+		Txs:         len(ev.Body.Tx),
+		Parents:     append([]common.Hash{}, ev.Body.E...),
+	}
+	for _, f := range ev.fork {
+		de.Forks = append(de.Forks, f.Hash())
+	}
+	return de
+}
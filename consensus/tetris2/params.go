@@ -18,9 +18,63 @@
 package tetris2
 
 import (
+	"errors"
 	"time"
 )
 
+var (
+	ErrConfigTxPerEvent = errors.New("tetris config: max tx per event must be positive")
+	ErrConfigPeriod     = errors.New("tetris config: event periods must satisfy 0 < min period <= max tx delay <= max period")
+	ErrConfigTick       = errors.New("tetris config: tick must be positive and not above max tx delay")
+	ErrConfigCache      = errors.New("tetris config: cache sizes must be positive")
+)
+
+//Config of tetris, event timing is consensus-critical and agreed in genesis,
+//tick and cache sizes are local tuning.
+type Config struct {
+	MaxTxPerEvent     int
+	MaxTxDelay        time.Duration //an event is sent if txs waited this long
+	MinPeriodForEvent time.Duration //full events are not sent more often than this
+	MaxPeriodForEvent time.Duration //an event is sent at least this often
+
+	Tick             time.Duration //interval of checking whether to send event
+	EventCacheSize   int
+	EventRequestSize int
+	TxsCacheSize     int
+	TxsCommittedSize int
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		MaxTxPerEvent:     2000,
+		MaxTxDelay:        2000 * time.Millisecond,
+		MinPeriodForEvent: 2000 * time.Millisecond,
+		MaxPeriodForEvent: 60 * time.Second,
+
+		Tick:             1 * time.Second,
+		EventCacheSize:   10000,
+		EventRequestSize: 1000,
+		TxsCacheSize:     10000,
+		TxsCommittedSize: 100000,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.MaxTxPerEvent <= 0 {
+		return ErrConfigTxPerEvent
+	}
+	if c.MinPeriodForEvent <= 0 || c.MinPeriodForEvent > c.MaxTxDelay || c.MaxTxDelay > c.MaxPeriodForEvent {
+		return ErrConfigPeriod
+	}
+	if c.Tick <= 0 || c.Tick > c.MaxTxDelay {
+		return ErrConfigTick
+	}
+	if c.EventCacheSize <= 0 || c.EventRequestSize <= 0 || c.TxsCacheSize <= 0 || c.TxsCommittedSize <= 0 {
+		return ErrConfigCache
+	}
+	return nil
+}
+
 type Params struct {
 	f                 int
 	superMajority     int
@@ -0,0 +1,219 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tetris2
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/consensus"
+)
+
+//Kinds of records, a header starts a session of a tetris, followed by its inputs and the outputs they produced.
+const (
+	InputEvent       = "event"
+	InputParentEvent = "parent"
+	InputReload      = "reload" //event reloaded from store after restart
+	InputTx          = "tx"
+	InputSeal        = "seal"
+	InputDrop        = "drop"
+	InputRotate      = "rotate"
+	InputTick        = "tick"
+
+	RecordHeader = "header"
+	RecordOutput = "output"
+)
+
+var ErrRecordKind = errors.New("unknown record kind")
+
+//input of tetris loop, with the time it is received
+type input struct {
+	kind   string
+	t      time.Time
+	data   []byte
+	tx     common.Hash
+	seal   *SealEvent
+	drop   *DropEvent
+	rotate *RotateEvent
+}
+
+//Record is a line of a recording, in json.
+type Record struct {
+	Kind string `json:"kind"`
+	T    int64  `json:"t"` //unix nano, time the input received, or T of output
+
+	//header
+	Vid        string   `json:"vid,omitempty"`
+	Validators []string `json:"validators,omitempty"`
+	N          uint64   `json:"n,omitempty"`
+	Config     *Config  `json:"config,omitempty"`
+
+	Data   []byte   `json:"data,omitempty"` //event message
+	H      uint64   `json:"h,omitempty"`    //height of header, seal, rotate and output
+	Txs    []string `json:"txs,omitempty"`  //tx hashes in hex
+	Joins  []string `json:"joins,omitempty"`
+	Quits  []string `json:"quits,omitempty"`
+	Output string   `json:"output,omitempty"`
+}
+
+//Recorder writes inputs and outputs of a tetris, so that they can be replayed.
+//It is only written in tetris loop.
+type Recorder struct {
+	w   *bufio.Writer
+	c   io.Closer //nil if writer not closable
+	err error     //first write error, nothing recorded after it
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	r := &Recorder{w: bufio.NewWriter(w)}
+	if c, ok := w.(io.Closer); ok {
+		r.c = c
+	}
+	return r
+}
+
+//SetRecorder records inputs and outputs from now on, it must be called before Start or any input.
+func (t *Tetris) SetRecorder(r *Recorder) {
+	t.recorder = r
+	r.write(&Record{
+		Kind:       RecordHeader,
+		T:          t.now.UnixNano(),
+		Vid:        t.vid,
+		Validators: t.sortedVids(),
+		H:          t.h,
+		N:          t.n,
+		Config:     t.config,
+	}, true)
+}
+
+//Err returns the first write error.
+func (r *Recorder) Err() error {
+	return r.err
+}
+
+func (r *Recorder) Close() error {
+	if err := r.w.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if r.c != nil {
+		if err := r.c.Close(); err != nil && r.err == nil {
+			r.err = err
+		}
+	}
+	return r.err
+}
+
+func (r *Recorder) recordInput(in *input) {
+	rec := &Record{Kind: in.kind, T: in.t.UnixNano(), Data: in.data}
+	switch in.kind {
+	case InputTx:
+		rec.Txs = hashesHex([]common.Hash{in.tx})
+	case InputSeal:
+		rec.H = in.seal.height
+		rec.Txs = hashesHex(in.seal.txs)
+	case InputDrop:
+		rec.Txs = hashesHex(in.drop.txs)
+	case InputRotate:
+		rec.H = in.rotate.height
+		rec.Joins = in.rotate.joins
+		rec.Quits = in.rotate.quits
+	}
+	//txs come in bulk, they are flushed with the next tick at latest
+	r.write(rec, in.kind != InputTx)
+}
+
+func (r *Recorder) recordOutput(o *consensus.Output) {
+	r.write(&Record{
+		Kind:   RecordOutput,
+		T:      o.T.UnixNano(),
+		H:      o.H,
+		Txs:    hashesHex(o.Txs),
+		Output: o.Output,
+	}, true)
+}
+
+func (r *Recorder) write(rec *Record, flush bool) {
+	if r.err != nil {
+		return
+	}
+	enc, err := json.Marshal(rec)
+	if err != nil {
+		r.err = err
+		return
+	}
+	if _, err := r.w.Write(append(enc, '\n')); err != nil {
+		r.err = err
+		return
+	}
+	if flush {
+		r.err = r.w.Flush()
+	}
+}
+
+//input of the record, or nil for header and output
+func (rec *Record) input() (*input, error) {
+	in := &input{kind: rec.Kind, t: time.Unix(0, rec.T), data: rec.Data}
+	switch rec.Kind {
+	case InputEvent, InputParentEvent, InputReload, InputTick:
+	case InputTx:
+		if len(rec.Txs) != 1 {
+			return nil, ErrRecordKind
+		}
+		in.tx = common.HexToHash(rec.Txs[0])
+	case InputSeal:
+		in.seal = &SealEvent{height: rec.H, txs: hexHashes(rec.Txs)}
+	case InputDrop:
+		in.drop = &DropEvent{txs: hexHashes(rec.Txs)}
+	case InputRotate:
+		in.rotate = &RotateEvent{height: rec.H, joins: rec.Joins, quits: rec.Quits}
+	case RecordHeader, RecordOutput:
+		return nil, nil
+	default:
+		return nil, ErrRecordKind
+	}
+	return in, nil
+}
+
+func (rec *Record) output() *consensus.Output {
+	return &consensus.Output{
+		T:      time.Unix(0, rec.T),
+		H:      rec.H,
+		Output: rec.Output,
+		Txs:    hexHashes(rec.Txs),
+	}
+}
+
+func hashesHex(hashes []common.Hash) []string {
+	hs := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		hs = append(hs, h.Hex())
+	}
+	return hs
+}
+
+func hexHashes(hs []string) []common.Hash {
+	hashes := make([]common.Hash, 0, len(hs))
+	for _, h := range hs {
+		hashes = append(hashes, common.HexToHash(h))
+	}
+	return hashes
+}
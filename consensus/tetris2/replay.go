@@ -0,0 +1,278 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tetris2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/consensus"
+	"github.com/yeeco/gyee/crypto"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+)
+
+var (
+	ErrReplayNoHeader = errors.New("replay: recording does not start with a header")
+	ErrReplayKey      = errors.New("replay: key is not of the recorded validator")
+)
+
+//Divergence is the error of replay producing outputs different from the recorded ones.
+type Divergence struct {
+	Session  int               //session of the recording, a session for every start of tetris
+	Record   int               //index of the record where divergence found
+	Index    int               //index of the output in the session
+	Recorded *consensus.Output //nil if not recorded
+	Replayed *consensus.Output //nil if not replayed
+}
+
+func (d *Divergence) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "replay diverged at output %d of session %d, record %d", d.Index, d.Session, d.Record)
+	if d.Recorded == nil {
+		fmt.Fprintf(&b, "\n+ %s", outputLine(d.Replayed))
+		return b.String()
+	}
+	if d.Replayed == nil {
+		fmt.Fprintf(&b, "\n- %s", outputLine(d.Recorded))
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\n- %s\n+ %s", outputLine(d.Recorded), outputLine(d.Replayed))
+	recorded, replayed := hashSet(d.Recorded.Txs), hashSet(d.Replayed.Txs)
+	for _, tx := range d.Recorded.Txs {
+		if !replayed[tx] {
+			fmt.Fprintf(&b, "\n- tx %s", tx.Hex())
+		}
+	}
+	for _, tx := range d.Replayed.Txs {
+		if !recorded[tx] {
+			fmt.Fprintf(&b, "\n+ tx %s", tx.Hex())
+		}
+	}
+	return b.String()
+}
+
+func outputLine(o *consensus.Output) string {
+	return fmt.Sprintf("h %d t %d txs %d output %q", o.H, o.T.UnixNano(), len(o.Txs), o.Output)
+}
+
+func hashSet(hashes []common.Hash) map[common.Hash]bool {
+	set := make(map[common.Hash]bool, len(hashes))
+	for _, h := range hashes {
+		set[h] = true
+	}
+	return set
+}
+
+func sameOutput(x, y *consensus.Output) bool {
+	if x.H != y.H || !x.T.Equal(y.T) || x.Output != y.Output || len(x.Txs) != len(y.Txs) {
+		return false
+	}
+	for i := range x.Txs {
+		if x.Txs[i] != y.Txs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//ReplayResult counts what a replay went through.
+type ReplayResult struct {
+	Sessions int
+	Inputs   int
+	Outputs  int
+}
+
+//Replay feeds the inputs of a recording into fresh tetris on the recorded clock, a tetris for every session,
+//and checks that the outputs are the same as recorded. A *Divergence is returned if they are not.
+//Own events are not recorded but signed again, core must sign with the key of the recorded validator.
+func Replay(r io.Reader, core ICore) (*ReplayResult, error) {
+	res := &ReplayResult{}
+	dec := json.NewDecoder(r)
+	var s *replaySession
+	i := 0
+	for ; ; i++ {
+		var rec Record
+		if err := dec.Decode(&rec); err == io.EOF {
+			break
+		} else if err != nil {
+			return res, fmt.Errorf("replay: record %d: %v", i, err)
+		}
+		if rec.Kind != RecordHeader && s == nil {
+			return res, ErrReplayNoHeader
+		}
+
+		switch rec.Kind {
+		case RecordHeader:
+			if s != nil {
+				if err := s.finish(i); err != nil {
+					return res, err
+				}
+			}
+			var err error
+			if s, err = newReplaySession(core, &rec, res.Sessions); err != nil {
+				return res, err
+			}
+			res.Sessions++
+		case RecordOutput:
+			if err := s.expect(i, rec.output()); err != nil {
+				return res, err
+			}
+			res.Outputs++
+		default:
+			in, err := rec.input()
+			if err != nil {
+				return res, fmt.Errorf("replay: record %d: %v", i, err)
+			}
+			if err := s.step(i, in); err != nil {
+				return res, err
+			}
+			res.Inputs++
+		}
+	}
+	if s == nil {
+		return res, ErrReplayNoHeader
+	}
+	return res, s.finish(i)
+}
+
+type replaySession struct {
+	index    int
+	t        *Tetris
+	recorded []*consensus.Output
+	replayed []*consensus.Output
+}
+
+func newReplaySession(core ICore, header *Record, index int) (*replaySession, error) {
+	t, err := NewTetris(core, header.Vid, header.Validators, header.H, nil, header.Config)
+	if err != nil {
+		return nil, err
+	}
+	t.ticker.Stop()
+	if vid, err := t.signerVid(); err != nil {
+		return nil, err
+	} else if vid != header.Vid {
+		return nil, ErrReplayKey
+	}
+	t.n = header.N
+	t.now = time.Unix(0, header.T)
+	t.lastSendTime = t.now
+
+	s := &replaySession{index: index, t: t}
+	t.emitEvent = func([]byte) {}
+	t.emitRequest = func(common.Hash) {}
+	t.emitOutput = func(o *consensus.Output) { s.replayed = append(s.replayed, o) }
+	return s, nil
+}
+
+//outputs of an input are recorded right after it, replayed ones not recorded before next input diverge
+func (s *replaySession) step(record int, in *input) error {
+	if err := s.extra(record); err != nil {
+		return err
+	}
+	s.t.input(in)
+	return nil
+}
+
+func (s *replaySession) expect(record int, o *consensus.Output) error {
+	index := len(s.recorded)
+	s.recorded = append(s.recorded, o)
+	if index >= len(s.replayed) {
+		return &Divergence{Session: s.index, Record: record, Index: index, Recorded: o}
+	}
+	if !sameOutput(o, s.replayed[index]) {
+		return &Divergence{Session: s.index, Record: record, Index: index, Recorded: o, Replayed: s.replayed[index]}
+	}
+	return nil
+}
+
+func (s *replaySession) finish(record int) error {
+	return s.extra(record)
+}
+
+func (s *replaySession) extra(record int) error {
+	if index := len(s.recorded); index < len(s.replayed) {
+		return &Divergence{Session: s.index, Record: record, Index: index, Replayed: s.replayed[index]}
+	}
+	return nil
+}
+
+//vid of the signer, derived the way checkEvent does for received events
+func (t *Tetris) signerVid() (string, error) {
+	h := sha256.Sum256([]byte(t.vid))
+	sig, err := t.signer.Sign(h[:])
+	if err != nil {
+		return "", err
+	}
+	pk, err := t.signer.RecoverPublicKey(h[:], sig)
+	if err != nil {
+		return "", err
+	}
+	addr, err := t.core.AddressFromPublicKey(pk)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(addr), nil
+}
+
+//KeyCore is the core of tetris run without node, in replay and scenarios, signing with a raw key.
+type KeyCore struct {
+	key []byte
+
+	Validators [][]string //validator changes reported
+	Evidences  [][]byte   //equivocation evidences reported
+}
+
+func NewKeyCore(key []byte) *KeyCore {
+	return &KeyCore{key: key}
+}
+
+func (c *KeyCore) GetMinerSigner() (crypto.Signer, error) {
+	signer := secp256k1.NewSecp256k1Signer()
+	if err := signer.InitSigner(c.key); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+func (c *KeyCore) GetPrivateKeyOfDefaultAccount() ([]byte, error) {
+	return c.key, nil
+}
+
+func (c *KeyCore) AddressFromPublicKey(publicKey []byte) ([]byte, error) {
+	addr, err := address.NewAddressFromPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return addr.Raw, nil
+}
+
+func (c *KeyCore) OnValidatorsChanged(validators []string) {
+	c.Validators = append(c.Validators, validators)
+}
+
+func (c *KeyCore) OnEquivocation(evidence []byte) {
+	c.Evidences = append(c.Evidences, evidence)
+}
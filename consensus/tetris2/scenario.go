@@ -0,0 +1,255 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tetris2
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/consensus"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+)
+
+//ScenarioStart is the clock of a scenario at beginning.
+var ScenarioStart = time.Unix(1546300800, 0)
+
+//Scenario runs tetris of several validators in one goroutine on a virtual clock, for scripted tests.
+//Events sent are delivered to other validators at once in the order sent, unless they are partitioned or down.
+//Parents requested are answered from the events sent before.
+type Scenario struct {
+	Nodes []*ScenarioNode
+
+	now    time.Time
+	tick   time.Duration
+	events map[common.Hash]*message //events sent, key: hash of event
+	queue  []*message
+	group  []int //nodes of different groups can not reach each other
+}
+
+//ScenarioNode is a validator of scenario.
+type ScenarioNode struct {
+	Vid     string
+	Tetris  *Tetris
+	Core    *KeyCore
+	Outputs []*consensus.Output
+	down    bool
+}
+
+type message struct {
+	from int
+	to   int
+	kind string //InputEvent or InputParentEvent
+	data []byte
+}
+
+//NewScenario creates n validators at height 0, with new keys. Default config is used if cfg is nil.
+func NewScenario(n int, cfg *Config) (*Scenario, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	s := &Scenario{
+		Nodes:  make([]*ScenarioNode, 0, n),
+		now:    ScenarioStart,
+		tick:   cfg.Tick,
+		events: make(map[common.Hash]*message),
+		group:  make([]int, n),
+	}
+	cores := make([]*KeyCore, 0, n)
+	vids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		key := secp256k1.GenerateKey()
+		addr, err := address.NewAddressFromPublicKey(key.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		cores = append(cores, NewKeyCore(key.PrivateKey()))
+		vids = append(vids, addr.String())
+	}
+	for i := 0; i < n; i++ {
+		t, err := NewTetris(cores[i], vids[i], vids, 0, nil, cfg)
+		if err != nil {
+			return nil, err
+		}
+		t.ticker.Stop()
+		t.now = s.now
+		t.lastSendTime = s.now
+
+		from := i
+		t.emitEvent = func(eb []byte) { s.broadcast(from, eb) }
+		t.emitRequest = func(hash common.Hash) { s.request(from, hash) }
+		node := &ScenarioNode{Vid: vids[i], Tetris: t, Core: cores[i]}
+		t.emitOutput = func(o *consensus.Output) { node.Outputs = append(node.Outputs, o) }
+		s.Nodes = append(s.Nodes, node)
+	}
+	return s, nil
+}
+
+//Now returns the clock of scenario.
+func (s *Scenario) Now() time.Time {
+	return s.now
+}
+
+//Record inputs and outputs of node i to w, before anything run.
+func (s *Scenario) Record(i int, w io.Writer) {
+	s.Nodes[i].Tetris.SetRecorder(NewRecorder(w))
+}
+
+//SendTx sends tx to the nodes listed, or all nodes if none listed.
+func (s *Scenario) SendTx(tx common.Hash, nodes ...int) {
+	if len(nodes) == 0 {
+		for i := range s.Nodes {
+			nodes = append(nodes, i)
+		}
+	}
+	for _, i := range nodes {
+		if !s.Nodes[i].down {
+			s.Nodes[i].Tetris.input(&input{kind: InputTx, t: s.now, tx: tx})
+			s.deliver()
+		}
+	}
+}
+
+//DropTx tells the nodes listed, or all nodes if none listed, that txs output are dropped from block.
+func (s *Scenario) DropTx(txs []common.Hash, nodes ...int) {
+	if len(nodes) == 0 {
+		for i := range s.Nodes {
+			nodes = append(nodes, i)
+		}
+	}
+	for _, i := range nodes {
+		if !s.Nodes[i].down {
+			s.Nodes[i].Tetris.input(&input{kind: InputDrop, t: s.now, drop: &DropEvent{txs: txs}})
+			s.deliver()
+		}
+	}
+}
+
+//Run advances the clock by d. Nodes tick every tick of config, at phases evenly spread in the tick,
+//as validators ticking at once do not exchange much but placeholder events.
+func (s *Scenario) Run(d time.Duration) {
+	end := s.now.Add(d)
+	phase := s.tick / time.Duration(len(s.Nodes))
+	for {
+		//the next tick of nodes after now
+		base := s.now.Sub(ScenarioStart) / s.tick * s.tick
+		next, who := time.Time{}, -1
+		for i := range s.Nodes {
+			at := ScenarioStart.Add(base + time.Duration(i)*phase)
+			if !at.After(s.now) {
+				at = at.Add(s.tick)
+			}
+			if who < 0 || at.Before(next) {
+				next, who = at, i
+			}
+		}
+		if next.After(end) {
+			break
+		}
+		s.now = next
+		if node := s.Nodes[who]; !node.down {
+			node.Tetris.input(&input{kind: InputTick, t: s.now})
+			s.deliver()
+		}
+	}
+	s.now = end
+}
+
+//Partition nodes into groups, nodes not listed are isolated.
+func (s *Scenario) Partition(groups ...[]int) {
+	for i := range s.group {
+		s.group[i] = -1 - i
+	}
+	for g, nodes := range groups {
+		for _, i := range nodes {
+			s.group[i] = g
+		}
+	}
+}
+
+//Heal the partition.
+func (s *Scenario) Heal() {
+	for i := range s.group {
+		s.group[i] = 0
+	}
+}
+
+//Down stops node i, it receives nothing and does not tick until up.
+func (s *Scenario) Down(i int) {
+	s.Nodes[i].down = true
+}
+
+func (s *Scenario) Up(i int) {
+	s.Nodes[i].down = false
+}
+
+//CheckAgreement returns an error if nodes output differently at a height.
+func (s *Scenario) CheckAgreement() error {
+	for i, node := range s.Nodes {
+		for j, o := range node.Outputs {
+			for k := 0; k < i; k++ {
+				outputs := s.Nodes[k].Outputs
+				if j < len(outputs) && !sameOutput(o, outputs[j]) {
+					return fmt.Errorf("node %d and %d disagree at output %d:\n- %s\n+ %s",
+						k, i, j, outputLine(outputs[j]), outputLine(o))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Scenario) reachable(from, to int) bool {
+	return s.group[from] == s.group[to] && !s.Nodes[from].down && !s.Nodes[to].down
+}
+
+func (s *Scenario) broadcast(from int, eb []byte) {
+	var event Event
+	event.Unmarshal(eb)
+	if event.Body != nil && !event.Body.P {
+		s.events[event.Hash()] = &message{from: from, kind: InputParentEvent, data: eb}
+	}
+	for to := range s.Nodes {
+		if to != from && s.reachable(from, to) {
+			s.queue = append(s.queue, &message{from: from, to: to, kind: InputEvent, data: eb})
+		}
+	}
+}
+
+//answered by the node sent the event
+func (s *Scenario) request(from int, hash common.Hash) {
+	sent, ok := s.events[hash]
+	if !ok || !s.reachable(sent.from, from) {
+		return
+	}
+	s.queue = append(s.queue, &message{from: sent.from, to: from, kind: InputParentEvent, data: sent.data})
+}
+
+//deliver messages until none is left, messages sent on delivery are delivered too
+func (s *Scenario) deliver() {
+	for len(s.queue) > 0 {
+		m := s.queue[0]
+		s.queue = s.queue[1:]
+		if s.Nodes[m.to].down {
+			continue
+		}
+		s.Nodes[m.to].Tetris.input(&input{kind: m.kind, t: s.now, data: m.data})
+	}
+}
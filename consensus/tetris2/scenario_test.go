@@ -0,0 +1,195 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tetris2
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+)
+
+func testScenarioConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.MaxTxDelay = 200 * time.Millisecond
+	cfg.MinPeriodForEvent = 100 * time.Millisecond
+	cfg.MaxPeriodForEvent = 2 * time.Second
+	cfg.Tick = 100 * time.Millisecond
+	return cfg
+}
+
+func testTx(i int) common.Hash {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(i))
+	return sha256.Sum256(b[:])
+}
+
+// send txs for a while and let consensus catch up
+func runTestTxs(s *Scenario, txs int) {
+	runTestTxsFrom(s, 0, txs)
+}
+
+func runTestTxsFrom(s *Scenario, from, txs int) {
+	for i := from; i < from+txs; i++ {
+		s.SendTx(testTx(i))
+		s.Run(300 * time.Millisecond)
+	}
+	s.Run(10 * time.Second)
+}
+
+func TestScenarioAgreement(t *testing.T) {
+	s, err := NewScenario(4, testScenarioConfig())
+	if err != nil {
+		t.Fatalf("NewScenario() %v", err)
+	}
+	runTestTxs(s, 20)
+
+	for i, node := range s.Nodes {
+		if len(node.Outputs) == 0 {
+			t.Fatalf("node %d no output", i)
+		}
+		committed := make(map[common.Hash]bool)
+		for j, o := range node.Outputs {
+			if o.H != uint64(j+1) {
+				t.Fatalf("node %d output %d at height %d", i, j, o.H)
+			}
+			for _, tx := range o.Txs {
+				if committed[tx] {
+					t.Fatalf("node %d committed tx twice %v", i, tx)
+				}
+				committed[tx] = true
+			}
+		}
+	}
+	if err := s.CheckAgreement(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	s, err := NewScenario(4, testScenarioConfig())
+	if err != nil {
+		t.Fatalf("NewScenario() %v", err)
+	}
+	var recording bytes.Buffer
+	s.Record(1, &recording)
+	runTestTxs(s, 20)
+	node := s.Nodes[1]
+	if len(node.Outputs) == 0 {
+		t.Fatalf("no output")
+	}
+
+	res, err := Replay(bytes.NewReader(recording.Bytes()), NewKeyCore(node.Core.key))
+	if err != nil {
+		t.Fatalf("Replay() %v", err)
+	}
+	if res.Sessions != 1 || res.Outputs != len(node.Outputs) {
+		t.Fatalf("unexpected replay %+v, outputs %d", res, len(node.Outputs))
+	}
+
+	// replayed by another validator
+	if _, err := Replay(bytes.NewReader(recording.Bytes()), s.Nodes[0].Core); err != ErrReplayKey {
+		t.Fatalf("replay with other key %v", err)
+	}
+
+	// a tx dropped from recorded output
+	var tampered bytes.Buffer
+	enc := json.NewEncoder(&tampered)
+	dec := json.NewDecoder(bytes.NewReader(recording.Bytes()))
+	done := false
+	for {
+		var rec Record
+		if err := dec.Decode(&rec); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Decode() %v", err)
+		}
+		if !done && rec.Kind == RecordOutput && len(rec.Txs) > 0 {
+			rec.Txs = rec.Txs[1:]
+			done = true
+		}
+		enc.Encode(&rec)
+	}
+	if !done {
+		t.Fatalf("no tx output")
+	}
+	_, err = Replay(&tampered, NewKeyCore(node.Core.key))
+	d, ok := err.(*Divergence)
+	if !ok {
+		t.Fatalf("tampered replay %v", err)
+	}
+	if d.Recorded == nil || d.Replayed == nil || len(d.Replayed.Txs) != len(d.Recorded.Txs)+1 ||
+		!strings.Contains(d.Error(), "+ tx "+d.Replayed.Txs[0].Hex()) {
+		t.Fatalf("unexpected divergence %v", d)
+	}
+}
+
+func countOutputTx(node *ScenarioNode, tx common.Hash) int {
+	n := 0
+	for _, o := range node.Outputs {
+		for _, h := range o.Txs {
+			if h == tx {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// tx dropped from block, e.g. not yet valid, is output again when sent again
+func TestScenarioDropResend(t *testing.T) {
+	s, err := NewScenario(4, testScenarioConfig())
+	if err != nil {
+		t.Fatalf("NewScenario() %v", err)
+	}
+	tx := testTx(1000)
+	s.SendTx(tx)
+	runTestTxsFrom(s, 0, 20)
+	for i, node := range s.Nodes {
+		if n := countOutputTx(node, tx); n != 1 {
+			t.Fatalf("node %d output tx %d times", i, n)
+		}
+	}
+
+	//sent again without drop, still committed
+	s.SendTx(tx)
+	runTestTxsFrom(s, 20, 20)
+	for i, node := range s.Nodes {
+		if n := countOutputTx(node, tx); n != 1 {
+			t.Fatalf("node %d output committed tx again, %d times", i, n)
+		}
+	}
+
+	s.DropTx([]common.Hash{tx})
+	s.SendTx(tx)
+	runTestTxsFrom(s, 40, 20)
+	for i, node := range s.Nodes {
+		if n := countOutputTx(node, tx); n != 2 {
+			t.Fatalf("node %d output dropped tx %d times", i, n)
+		}
+	}
+	if err := s.CheckAgreement(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tetris2
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/yeeco/gyee/persistent"
+)
+
+const (
+	keyStoreVids   = "vids" //validators ever stored, joined by ","
+	keyStoreLow    = "low"  //events with sequence number below are pruned
+	keyPrefixTop   = "top-" //vid => highest sequence number stored
+	keyPrefixEvent = "ev-"  //vid + sequence number => event message
+	storePruneKeep = 128    //sequence numbers kept under base, parents of events above base
+)
+
+var ErrConflictSign = errors.New("refuse to sign event conflicting with a signed one")
+
+//Store persists own signed events and accepted events of the DAG,
+//so that a restarted validator resumes its sequence number and never signs twice.
+type Store struct {
+	db   persistent.Storage
+	tops map[string]uint64
+	low  uint64
+}
+
+func NewStore(db persistent.Storage) (*Store, error) {
+	s := &Store{
+		db:   db,
+		tops: make(map[string]uint64),
+	}
+	if enc, err := db.Get([]byte(keyStoreLow)); err == nil {
+		s.low = binary.BigEndian.Uint64(enc)
+	} else if err != persistent.ErrKeyNotFound {
+		return nil, err
+	}
+	enc, err := db.Get([]byte(keyStoreVids))
+	if err == persistent.ErrKeyNotFound {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, vid := range strings.Split(string(enc), ",") {
+		top, err := db.Get(keyTop(vid))
+		if err != nil {
+			return nil, err
+		}
+		s.tops[vid] = binary.BigEndian.Uint64(top)
+	}
+	return s, nil
+}
+
+//Top returns the highest sequence number of vid stored, 0 if none.
+func (s *Store) Top(vid string) uint64 {
+	return s.tops[vid]
+}
+
+//Get returns the stored event of vid with sequence number n, nil if not found.
+func (s *Store) Get(vid string, n uint64) []byte {
+	enc, err := s.db.Get(keyEvent(vid, n))
+	if err != nil {
+		return nil
+	}
+	return enc
+}
+
+//Put stores event of vid, event already stored for the sequence number is kept.
+func (s *Store) Put(vid string, n uint64, event []byte) error {
+	if n <= s.low {
+		return nil
+	}
+	key := keyEvent(vid, n)
+	if has, err := s.db.Has(key); err != nil || has {
+		return err
+	}
+	batch := s.db.NewBatch()
+	if err := batch.Put(key, event); err != nil {
+		return err
+	}
+	top, ok := s.tops[vid]
+	if !ok {
+		vids := make([]string, 0, len(s.tops)+1)
+		for v := range s.tops {
+			vids = append(vids, v)
+		}
+		vids = append(vids, vid)
+		sort.Strings(vids)
+		if err := batch.Put([]byte(keyStoreVids), []byte(strings.Join(vids, ","))); err != nil {
+			return err
+		}
+	}
+	if n > top {
+		if err := batch.Put(keyTop(vid), encodeUint64(n)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if n > top {
+		s.tops[vid] = n
+	}
+	return nil
+}
+
+//Load returns stored events with sequence number above low, ordered by sequence number.
+func (s *Store) Load() [][]byte {
+	type item struct {
+		n   uint64
+		enc []byte
+	}
+	items := make([]item, 0)
+	for vid, top := range s.tops {
+		for n := s.low + 1; n <= top; n++ {
+			if enc := s.Get(vid, n); enc != nil {
+				items = append(items, item{n: n, enc: enc})
+			}
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].n < items[j].n
+	})
+	events := make([][]byte, 0, len(items))
+	for _, it := range items {
+		events = append(events, it.enc)
+	}
+	return events
+}
+
+//Prune events far below base at height h, tops are kept to guard the sequence numbers.
+func (s *Store) Prune(h uint64) error {
+	if h <= storePruneKeep || h-storePruneKeep <= s.low {
+		return nil
+	}
+	low := h - storePruneKeep
+	batch := s.db.NewBatch()
+	for vid, top := range s.tops {
+		for n := s.low + 1; n <= low && n <= top; n++ {
+			if err := batch.Del(keyEvent(vid, n)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := batch.Put([]byte(keyStoreLow), encodeUint64(low)); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	s.low = low
+	return nil
+}
+
+func encodeUint64(v uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	return buf
+}
+
+func keyTop(vid string) []byte {
+	return []byte(keyPrefixTop + vid)
+}
+
+func keyEvent(vid string, n uint64) []byte {
+	return append([]byte(keyPrefixEvent+vid), encodeUint64(n)...)
+}
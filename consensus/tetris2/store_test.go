@@ -0,0 +1,184 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tetris2
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+	"github.com/yeeco/gyee/persistent"
+)
+
+func testStoreEvent(vid string, n uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", vid, n))
+}
+
+func newTestStore(t *testing.T, db persistent.Storage) *Store {
+	s, err := NewStore(db)
+	if err != nil {
+		t.Fatalf("NewStore() %v", err)
+	}
+	return s
+}
+
+//validator with store on db, key kept over restarts
+func newTestStoreTetris(t *testing.T, key []byte, db persistent.Storage) *Tetris {
+	pub, err := secp256k1.GetPublicKey(key)
+	if err != nil {
+		t.Fatalf("GetPublicKey() %v", err)
+	}
+	addr, err := address.NewAddressFromPublicKey(pub)
+	if err != nil {
+		t.Fatalf("NewAddressFromPublicKey() %v", err)
+	}
+	vid := addr.String()
+	tetris, err := NewTetris(NewKeyCore(key), vid, []string{vid}, 0, db, nil)
+	if err != nil {
+		t.Fatalf("NewTetris() %v", err)
+	}
+	tetris.ticker.Stop()
+	return tetris
+}
+
+func TestStoreReopen(t *testing.T) {
+	db := persistent.NewMemoryStorage()
+	s := newTestStore(t, db)
+	for n := uint64(1); n <= 3; n++ {
+		if err := s.Put("a", n, testStoreEvent("a", n)); err != nil {
+			t.Fatalf("Put() %v", err)
+		}
+	}
+	if err := s.Put("b", 2, testStoreEvent("b", 2)); err != nil {
+		t.Fatalf("Put() %v", err)
+	}
+	//event stored first is kept
+	if err := s.Put("a", 2, []byte("conflict")); err != nil {
+		t.Fatalf("Put() %v", err)
+	}
+
+	s = newTestStore(t, db)
+	if s.Top("a") != 3 || s.Top("b") != 2 || s.Top("c") != 0 {
+		t.Errorf("tops a %d b %d c %d", s.Top("a"), s.Top("b"), s.Top("c"))
+	}
+	if got := s.Get("a", 2); !bytes.Equal(got, testStoreEvent("a", 2)) {
+		t.Errorf("Get(a, 2) %s", got)
+	}
+	if got := s.Get("b", 1); got != nil {
+		t.Errorf("Get(b, 1) %s, want nil", got)
+	}
+	events := s.Load()
+	if len(events) != 4 {
+		t.Fatalf("loaded %d events, want 4", len(events))
+	}
+	//ordered by sequence number
+	if !bytes.Equal(events[0], testStoreEvent("a", 1)) || !bytes.Equal(events[3], testStoreEvent("a", 3)) {
+		t.Errorf("loaded first %s last %s", events[0], events[3])
+	}
+}
+
+func TestStorePrune(t *testing.T) {
+	db := persistent.NewMemoryStorage()
+	s := newTestStore(t, db)
+	for n := uint64(1); n <= 200; n++ {
+		if err := s.Put("a", n, testStoreEvent("a", n)); err != nil {
+			t.Fatalf("Put() %v", err)
+		}
+	}
+	//nothing is pruned within the events kept under base
+	if err := s.Prune(storePruneKeep); err != nil {
+		t.Fatalf("Prune() %v", err)
+	}
+	if s.Get("a", 1) == nil {
+		t.Fatal("event pruned within kept range")
+	}
+
+	h := uint64(storePruneKeep + 50)
+	if err := s.Prune(h); err != nil {
+		t.Fatalf("Prune() %v", err)
+	}
+	if s.Get("a", 50) != nil || s.Get("a", 51) == nil {
+		t.Errorf("pruned to %d, events 50 %v 51 %v", h-storePruneKeep, s.Get("a", 50) != nil, s.Get("a", 51) != nil)
+	}
+	//below low is not stored again
+	if err := s.Put("a", 10, testStoreEvent("a", 10)); err != nil {
+		t.Fatalf("Put() %v", err)
+	}
+	if s.Get("a", 10) != nil {
+		t.Error("event stored below low")
+	}
+
+	s = newTestStore(t, db)
+	if s.Top("a") != 200 {
+		t.Errorf("top %d after prune, want 200", s.Top("a"))
+	}
+	if events := s.Load(); len(events) != 150 {
+		t.Errorf("loaded %d events after prune, want 150", len(events))
+	}
+}
+
+func TestStoreResumeSequence(t *testing.T) {
+	key := secp256k1.GenerateKey().PrivateKey()
+	db := persistent.NewMemoryStorage()
+	tetris := newTestStoreTetris(t, key, db)
+	if tetris.n != 1 {
+		t.Fatalf("n %d, want 1", tetris.n)
+	}
+	for n := uint64(1); n <= 3; n++ {
+		if !tetris.signEvent(NewEvent(tetris.vid, 0, n, tetris.now)) {
+			t.Fatalf("event %d not signed", n)
+		}
+	}
+
+	//restarted validator goes on after the events it signed
+	tetris = newTestStoreTetris(t, key, db)
+	if tetris.n != 4 {
+		t.Errorf("n %d after restart, want 4", tetris.n)
+	}
+}
+
+func TestSignEventRefuseUsed(t *testing.T) {
+	db := persistent.NewMemoryStorage()
+	tetris := newTestStoreTetris(t, secp256k1.GenerateKey().PrivateKey(), db)
+	signed := NewEvent(tetris.vid, 0, 1, tetris.now)
+	if !tetris.signEvent(signed) {
+		t.Fatal("event not signed")
+	}
+	if !bytes.Equal(tetris.store.Get(tetris.vid, 1), signed.Marshal()) {
+		t.Error("signed event not stored")
+	}
+
+	//another event on the same sequence number would fork the validator
+	conflict := NewEvent(tetris.vid, 0, 1, tetris.now.Add(1))
+	conflict.AddTransactions([]common.Hash{testTx(1)})
+	if tetris.signEvent(conflict) {
+		t.Fatal("signed event on used sequence number")
+	}
+	if conflict.signature != nil {
+		t.Error("conflicting event has signature")
+	}
+	if tetris.n != 2 {
+		t.Errorf("n %d after refusal, want 2", tetris.n)
+	}
+	if !bytes.Equal(tetris.store.Get(tetris.vid, 1), signed.Marshal()) {
+		t.Error("signed event overwritten")
+	}
+}
@@ -19,7 +19,6 @@ package tetris2
 
 import (
 	"encoding/hex"
-	"fmt"
 	"math"
 	"sort"
 	"strings"
@@ -32,6 +31,7 @@ import (
 	"github.com/yeeco/gyee/consensus"
 	"github.com/yeeco/gyee/crypto"
 	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/persistent"
 	"github.com/yeeco/gyee/utils"
 	"github.com/yeeco/gyee/utils/logging"
 )
@@ -56,6 +56,12 @@ type DropEvent struct {
 	txs []common.Hash
 }
 
+type RotateEvent struct {
+	height uint64
+	joins  []string
+	quits  []string
+}
+
 type Tetris struct {
 	core   ICore
 	signer crypto.Signer
@@ -75,6 +81,8 @@ type Tetris struct {
 	TxsCh         chan common.Hash
 	SealCh        chan *SealEvent
 	DropCh        chan *DropEvent
+	RotateCh      chan *RotateEvent
+	InspectCh     chan func() //inspection run in loop
 
 	//Output Channel
 	OutputCh       chan *consensus.Output
@@ -90,6 +98,18 @@ type Tetris struct {
 	txsCommitted *utils.LRU                        //dedup for committed txs
 	txsPending   map[common.Hash]map[string]uint64 //tx appear at which validator's which height, key1: hash of tx, key2:vid, value:height
 
+	rotations map[uint64]*RotateEvent //validator changes waiting for height, key: height
+
+	store *Store //persisted events for restart, nil if not persisted
+
+	config   *Config
+	recorder *Recorder //inputs recorded for replay, nil if not recording
+
+	//where outputs go, the output channels unless stepped without loop by replay or scenario
+	emitEvent   func([]byte)
+	emitRequest func(common.Hash)
+	emitOutput  func(*consensus.Output)
+
 	ticker    *time.Ticker
 	heartBeat map[string]time.Time //time of receive event from every validators，key:vid
 
@@ -104,11 +124,20 @@ type Tetris struct {
 	wg     sync.WaitGroup
 
 	//time
+	now              time.Time //time of the input in process, the virtual clock in replay
 	lastSendTime     time.Time
 	possibleNewReady bool
 }
 
-func NewTetris(core ICore, vid string, validatorList []string, blockHeight uint64) (*Tetris, error) {
+//Events are persisted in db if it is not nil, a restarted tetris reloads them and resumes its sequence number.
+//Default config is used if cfg is nil.
+func NewTetris(core ICore, vid string, validatorList []string, blockHeight uint64, db persistent.Storage, cfg *Config) (*Tetris, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	tetris := Tetris{
 		core:             core,
 		vid:              vid,
@@ -124,28 +153,38 @@ func NewTetris(core ICore, vid string, validatorList []string, blockHeight uint6
 		TxsCh:         make(chan common.Hash, 2000),
 		SealCh:        make(chan *SealEvent, 100),
 		DropCh:        make(chan *DropEvent, 100),
+		RotateCh:      make(chan *RotateEvent, 10),
+		InspectCh:     make(chan func()),
 
 		OutputCh:       make(chan *consensus.Output, 10),
 		SendEventCh:    make(chan []byte, 10),
 		RequestEventCh: make(chan common.Hash, 10),
 
-		eventCache:    utils.NewLRU(10000, nil),
+		eventCache:    utils.NewLRU(cfg.EventCacheSize, nil),
 		eventAccepted: make([]*Event, 0),
-		eventRequest:  utils.NewLRU(1000, nil),
+		eventRequest:  utils.NewLRU(cfg.EventRequestSize, nil),
 
-		txsCache:     utils.NewLRU(10000, nil),
+		txsCache:     utils.NewLRU(cfg.TxsCacheSize, nil),
 		txsAccepted:  make([]common.Hash, 0),
 		txsPending:   make(map[common.Hash]map[string]uint64),
-		txsCommitted: utils.NewLRU(100000, nil),
+		txsCommitted: utils.NewLRU(cfg.TxsCommittedSize, nil),
+
+		rotations: make(map[uint64]*RotateEvent),
 
-		ticker:    time.NewTicker(1 * time.Second),
+		config: cfg,
+
+		ticker:    time.NewTicker(cfg.Tick),
 		heartBeat: make(map[string]time.Time),
 
 		quitCh: make(chan struct{}),
 
-		lastSendTime: time.Now(),
-		Metrics:      NewMetrics(),
+		now:     time.Now(),
+		Metrics: NewMetrics(),
 	}
+	tetris.lastSendTime = tetris.now
+	tetris.emitEvent = func(eb []byte) { tetris.SendEventCh <- eb }
+	tetris.emitRequest = func(hash common.Hash) { tetris.RequestEventCh <- hash }
+	tetris.emitOutput = func(o *consensus.Output) { tetris.OutputCh <- o }
 
 	if signer, err := core.GetMinerSigner(); err != nil {
 		return nil, err
@@ -159,16 +198,28 @@ func NewTetris(core ICore, vid string, validatorList []string, blockHeight uint6
 		tetris.pendingHeight[value] = blockHeight
 	}
 
+	if db != nil {
+		store, err := NewStore(db)
+		if err != nil {
+			return nil, err
+		}
+		tetris.store = store
+		//never sign again on sequence numbers used before restart
+		if top := store.Top(vid); top >= tetris.n {
+			tetris.n = top + 1
+		}
+	}
+
 	tetris.params = &Params{
 		f:                 (len(validatorList) - 1) / 3,
 		superMajority:     2*len(validatorList)/3 + 1,
-		maxTxPerEvent:     2000,
+		maxTxPerEvent:     cfg.MaxTxPerEvent,
 		minTxPerEvent:     1,
 		maxEventPerEvent:  len(validatorList),
 		minEventPerEvent:  2,
-		maxTxDelay:        2000 * time.Millisecond,
-		maxPeriodForEvent: 60 * time.Second,
-		minPeriodForEvent: 2000 * time.Millisecond,
+		maxTxDelay:        cfg.MaxTxDelay,
+		maxPeriodForEvent: cfg.MaxPeriodForEvent,
+		minPeriodForEvent: cfg.MinPeriodForEvent,
 	}
 
 	//tetris.prepare()
@@ -230,52 +281,110 @@ func (t *Tetris) OnTxDropped(txs []common.Hash) {
 	}
 }
 
+//Validator changes approved on chain, members rotate after consensus reached height
+func (t *Tetris) RotateValidators(height uint64, joins, quits []string) {
+	t.RotateCh <- &RotateEvent{
+		height: height,
+		joins:  joins,
+		quits:  quits,
+	}
+}
+
 func (t *Tetris) loop() {
 	t.wg.Add(1)
 	defer t.wg.Done()
 	//log.Info("Tetris loop...")
+	t.reload()
 	for {
 		select {
 		case <-t.quitCh:
 			//log.Info("Tetris loop end.")
+			if t.recorder != nil {
+				if err := t.recorder.Close(); err != nil {
+					log.Warn("tetris close recorder", "err", err)
+				}
+			}
 			return
 
 		case eventMsg := <-t.EventCh:
-			var event Event
-			t.Metrics.AddTrafficIn(uint64(len(eventMsg)))
-			event.Unmarshal(eventMsg)
-			event.isParent = false
-			if t.checkEvent(&event) {
-				t.heartBeat[event.vid] = time.Now()
-				if event.Body.P {
-					//it is a heartbeat event
-				} else {
-					t.receiveEvent(&event)
-				}
-			}
+			t.input(&input{kind: InputEvent, t: time.Now(), data: eventMsg})
 
 		case eventMsg := <-t.ParentEventCh:
-			var event Event
-			t.Metrics.AddTrafficIn(uint64(len(eventMsg)))
-			event.Unmarshal(eventMsg)
-			event.isParent = true
-			if t.checkEvent(&event) {
-				t.receiveParentEvent(&event)
-			}
+			t.input(&input{kind: InputParentEvent, t: time.Now(), data: eventMsg})
 
 		case tx := <-t.TxsCh:
-			t.Metrics.AddTrafficIn(uint64(len(tx)))
-			t.receiveTx(tx)
+			t.input(&input{kind: InputTx, t: time.Now(), tx: tx})
 
 		case seal := <-t.SealCh:
-			t.receiveSeal(seal)
+			t.input(&input{kind: InputSeal, t: time.Now(), seal: seal})
 
 		case drop := <-t.DropCh:
-			t.receiveDrop(drop)
+			t.input(&input{kind: InputDrop, t: time.Now(), drop: drop})
+
+		case rotate := <-t.RotateCh:
+			t.input(&input{kind: InputRotate, t: time.Now(), rotate: rotate})
 
 		case time := <-t.ticker.C:
-			t.receiveTicker(time)
+			t.input(&input{kind: InputTick, t: time})
+
+		case f := <-t.InspectCh:
+			f()
+		}
+	}
+}
+
+//Every input goes here, the clock of tetris is set to the time input received.
+//Tetris is deterministic given the inputs in order and their times, which is what replay relies on.
+func (t *Tetris) input(in *input) {
+	t.now = in.t
+	if t.recorder != nil {
+		t.recorder.recordInput(in)
+	}
+
+	switch in.kind {
+	case InputEvent:
+		var event Event
+		t.Metrics.AddTrafficIn(uint64(len(in.data)))
+		event.Unmarshal(in.data)
+		event.isParent = false
+		if t.checkEvent(&event) {
+			t.heartBeat[event.vid] = t.now
+			if event.Body.P {
+				//it is a heartbeat event
+			} else {
+				t.storeEvent(&event)
+				t.receiveEvent(&event)
+			}
+		}
+
+	case InputParentEvent:
+		var event Event
+		t.Metrics.AddTrafficIn(uint64(len(in.data)))
+		event.Unmarshal(in.data)
+		event.isParent = true
+		if t.checkEvent(&event) {
+			t.storeEvent(&event)
+			t.receiveParentEvent(&event)
 		}
+
+	case InputReload:
+		t.reloadEvent(in.data)
+
+	case InputTx:
+		t.Metrics.AddTrafficIn(uint64(len(in.tx)))
+		t.receiveTx(in.tx)
+
+	case InputSeal:
+		t.receiveSeal(in.seal)
+
+	case InputDrop:
+		t.receiveDrop(in.drop)
+
+	case InputRotate:
+		t.receiveRotate(in.rotate)
+
+	case InputTick:
+		t.receiveTicker(in.t)
 	}
 }
 
@@ -284,12 +393,14 @@ func (t *Tetris) sendPlaceholderEvent() {
 		return
 	}
 
-	event := NewEvent(t.vid, t.h, t.n)
+	event := NewEvent(t.vid, t.h, t.n, t.now)
 	event.AddSelfParent(t.validators[t.vid][t.n-1])
-	event.Sign(t.signer)
+	if !t.signEvent(event) {
+		return
+	}
 	eb := event.Marshal()
 	t.Metrics.AddTrafficOut(uint64(len(eb)))
-	t.SendEventCh <- eb
+	t.emitEvent(eb)
 
 	event.ready = true
 	t.validators[t.vid][t.n] = event
@@ -297,7 +408,7 @@ func (t *Tetris) sendPlaceholderEvent() {
 	t.pendingHeight[t.vid] = t.n
 	t.eventCache.Add(event.Hash(), event)
 
-	t.lastSendTime = time.Now()
+	t.lastSendTime = t.now
 
 	t.n++
 
@@ -312,14 +423,16 @@ func (t *Tetris) sendEvent() {
 
 	//todo: eventAccepted should filter old parents?
 
-	event := NewEvent(t.vid, t.h, t.n)
+	event := NewEvent(t.vid, t.h, t.n, t.now)
 	event.AddSelfParent(t.validators[t.vid][t.n-1])
 	event.AddParents(t.eventAccepted)
 	event.AddTransactions(t.txsAccepted)
-	event.Sign(t.signer)
+	if !t.signEvent(event) {
+		return
+	}
 	eb := event.Marshal()
 	t.Metrics.AddTrafficOut(uint64(len(eb)))
-	t.SendEventCh <- eb
+	t.emitEvent(eb)
 
 	event.ready = true
 	t.validators[t.vid][t.n] = event
@@ -327,7 +440,7 @@ func (t *Tetris) sendEvent() {
 	t.pendingHeight[t.vid] = t.n
 	t.eventCache.Add(event.Hash(), event)
 
-	t.lastSendTime = time.Now()
+	t.lastSendTime = t.now
 	t.eventAccepted = make([]*Event, 0)
 	t.txsAccepted = make([]common.Hash, 0)
 
@@ -336,12 +449,72 @@ func (t *Tetris) sendEvent() {
 	t.update(event, false)
 }
 
+//Sign own event and persist it before sending, refuse to sign on a sequence number already signed.
+func (t *Tetris) signEvent(event *Event) bool {
+	if t.store != nil && t.store.Get(t.vid, event.Body.N) != nil {
+		log.Error("tetris refuse to sign conflicting event", "N", event.Body.N, "err", ErrConflictSign)
+		//skip the used sequence numbers
+		t.n = t.store.Top(t.vid) + 1
+		return false
+	}
+	if err := event.Sign(t.signer); err != nil {
+		log.Error("tetris sign event", "N", event.Body.N, "err", err)
+		return false
+	}
+	if t.store != nil {
+		if err := t.store.Put(t.vid, event.Body.N, event.Marshal()); err != nil {
+			//a validator not able to remember what it signed must not go on
+			log.Crit("tetris persist own event", "N", event.Body.N, "err", err)
+		}
+	}
+	return true
+}
+
+//Persist event accepted from peers
+func (t *Tetris) storeEvent(event *Event) {
+	if t.store == nil {
+		return
+	}
+	if err := t.store.Put(event.vid, event.Body.N, event.Marshal()); err != nil {
+		log.Warn("tetris persist event", "vid", event.vid, "N", event.Body.N, "err", err)
+	}
+}
+
+//Reload persisted events after restart, own events above base are sent again
+//in case they were lost with the crash.
+func (t *Tetris) reload() {
+	if t.store == nil {
+		return
+	}
+	events := t.store.Load()
+	for _, eb := range events {
+		t.input(&input{kind: InputReload, t: time.Now(), data: eb})
+	}
+	log.Info("tetris events reloaded", "vid", vidSignature(t.vid), "events", len(events), "h", t.h, "n", t.n)
+}
+
+func (t *Tetris) reloadEvent(eb []byte) {
+	var event Event
+	event.Unmarshal(eb)
+	if event.Body == nil || event.signature == nil {
+		return
+	}
+	event.isParent = true
+	if !t.checkEvent(&event) {
+		return
+	}
+	t.addReceivedEventToTetris(&event)
+	if event.vid == t.vid && event.Body.N > t.h {
+		t.emitEvent(eb)
+	}
+}
+
 func (t *Tetris) sendHeartbeat() {
-	pulse := NewPulse()
+	pulse := NewPulse(t.now)
 	pulse.Sign(t.signer)
 	pb := pulse.Marshal()
 	t.Metrics.AddTrafficOut(uint64(len(pb)))
-	t.SendEventCh <- pb
+	t.emitEvent(pb)
 }
 
 func (t *Tetris) receiveTicker(ttime time.Time) {
@@ -386,7 +559,7 @@ func (t *Tetris) receiveTx(tx common.Hash) {
 	}
 	var eventFull = len(t.txsAccepted) >= t.params.maxTxPerEvent
 	if eventFull {
-		timeSinceLast := time.Now().Sub(t.lastSendTime)
+		timeSinceLast := t.now.Sub(t.lastSendTime)
 		if timeSinceLast < t.params.minPeriodForEvent {
 			// too many tx in a short time, drop
 			// TODO: metrics
@@ -406,12 +579,25 @@ func (t *Tetris) receiveSeal(event *SealEvent) {
 	// TODO:
 }
 
+//txs dropped from block may be sent again, e.g. held till valid
 func (t *Tetris) receiveDrop(drop *DropEvent) {
 	for _, h := range drop.txs {
 		t.txsCache.Remove(h)
+		t.txsCommitted.Remove(h)
 	}
 }
 
+func (t *Tetris) receiveRotate(rotate *RotateEvent) {
+	if rotate.height <= t.h {
+		//consensus already passed the height, rotate as soon as possible
+		log.Warn("validator rotate late", "height", rotate.height, "h", t.h)
+		t.validatorRotate(rotate.joins, rotate.quits)
+		t.possibleNewReady = true
+		return
+	}
+	t.rotations[rotate.height] = rotate
+}
+
 func (t *Tetris) checkEvent(event *Event) bool {
 	if t.eventCache.Contains(event.Hash()) {
 		logging.Logger.WithFields(logrus.Fields{
@@ -500,6 +686,10 @@ func (t *Tetris) addReceivedEventToTetris(event *Event) {
 				"me":    me.Hash(),
 			}).Warn("Receive event with different hash, fork detected.")
 			//TODO: fork process
+			if len(me.fork) == 0 && me.Body.Hash() != event.Body.Hash() {
+				//the validator signed two different events of one sequence number, report the evidence
+				t.core.OnEquivocation(NewEvidence(me, event).Marshal())
+			}
 			me.fork = append(me.fork, event)
 		}
 		return
@@ -542,7 +732,8 @@ func (t *Tetris) addReceivedEventToTetris(event *Event) {
 	}
 
 	if len(newReady) == 0 {
-		for k, v := range t.validatorsHeight {
+		for _, k := range t.sortedVids() {
+			v := t.validatorsHeight[k]
 			if v <= t.h {
 				v = t.h
 			}
@@ -565,9 +756,8 @@ func (t *Tetris) addReceivedEventToTetris(event *Event) {
 							eri, ok := t.eventRequest.Get(peh)
 							if ok {
 								er := eri.(*SyncRequest)
-								now := time.Now()
-								if now.Sub(er.time) > time.Duration(er.count*500)*time.Millisecond && er.count < 10 {
-									t.RequestEventCh <- peh
+								if t.now.Sub(er.time) > time.Duration(er.count*500)*time.Millisecond && er.count < 10 {
+									t.emitRequest(peh)
 									t.Metrics.AddTrafficOut(32)
 									t.Metrics.AddEventRequest(1)
 									er.count++
@@ -575,10 +765,10 @@ func (t *Tetris) addReceivedEventToTetris(event *Event) {
 								}
 
 							} else {
-								t.RequestEventCh <- peh
+								t.emitRequest(peh)
 								t.Metrics.AddTrafficOut(32)
 								t.Metrics.AddEventRequest(1)
-								t.eventRequest.Add(peh, &SyncRequest{count: 1, time: time.Now()})
+								t.eventRequest.Add(peh, &SyncRequest{count: 1, time: t.now})
 							}
 						}
 					}
@@ -624,7 +814,11 @@ loop:
 	for {
 		newReadyThisRound := false
 		minReadyHeight := uint64(math.MaxUint64)
-		for k, v := range searchHeight {
+		for _, k := range sortedKeys(searchHeight) {
+			v, ok := searchHeight[k]
+			if !ok {
+				continue
+			}
 			//It is not possible for an event to be ready if it's parent not exist. so stop search any more.
 			if t.validators[k][v] == nil {
 				delete(searchHeight, k)
@@ -700,7 +894,8 @@ loop:
 //consensus computing
 //prepare for every stage
 func (t *Tetris) prepare() {
-	for _, value := range t.validators {
+	for _, vid := range t.sortedVids() {
+		value := t.validators[vid]
 		delete(value, t.h)
 		be := value[t.h+1]
 		if be != nil && !be.ready {
@@ -721,18 +916,20 @@ func (t *Tetris) prepare() {
 	}
 	t.witness = make([][]*Event, 1)
 
-	//test validator quit, this is controlled by consensus output in the future
-	//if t.h == 20 {
-	//	//t.validatorRotate(nil, []string{"30373037303730373037303730373037303730373037303730373037303730373037303730373037303730373037303730373037303730373037303730373037"})
-	//	t.validatorRotate([]string{"30393039303930393039303930393039303930393039303930393039303930393039303930393039303930393039303930393039303930393039303930393039"},
-	//		[]string{"30373037303730373037303730373037303730373037303730373037303730373037303730373037303730373037303730373037303730373037303730373037"})
-	//	//todo: pending txs need clear then member rotate joins
-	//	t.txsPending = make(map[common.Hash]map[string]uint64)
-	//}
+	//validator changes approved on chain take effect from the next height
+	if rotate, ok := t.rotations[t.h]; ok {
+		delete(t.rotations, t.h)
+		t.validatorRotate(rotate.joins, rotate.quits)
+		//todo: pending txs need clear then member rotate joins
+	}
 
 	t.possibleNewReady = true
 
-	//todo: remain issue: self parent of crash-recovery situation
+	if t.store != nil {
+		if err := t.store.Prune(t.h); err != nil {
+			log.Warn("tetris prune events", "h", t.h, "err", err)
+		}
+	}
 }
 
 func (t *Tetris) update(me *Event, fromAll bool) (foundNew bool) {
@@ -853,8 +1050,9 @@ func (t *Tetris) updateAll() {
 			maxh = vh
 		}
 	}
+	vids := t.sortedVids()
 	for n := t.h + 1; n <= maxh; n++ {
-		for m, _ := range t.validators {
+		for _, m := range vids {
 			me := t.validators[m][n]
 			if me != nil {
 				if t.update(me, true) {
@@ -875,7 +1073,7 @@ func (t *Tetris) consensusComputing() {
 		return
 	}
 
-	for m, _ := range t.validators {
+	for _, m := range t.sortedVids() {
 		me := t.validators[m][t.h+1]
 		if me == nil {
 			continue
@@ -1007,7 +1205,10 @@ func (t *Tetris) consensusComputing() {
 		H:      t.h + 1,
 		Output: css,
 		Txs:    txc}
-	t.OutputCh <- o
+	if t.recorder != nil {
+		t.recorder.recordOutput(o)
+	}
+	t.emitOutput(o)
 	t.h++
 
 	t.prepare() //start next stage
@@ -1061,12 +1262,11 @@ func (t *Tetris) MajorityBeatTime() (ok bool, duration time.Duration) {
 		return times[i].Before(times[j])
 	})
 
-	now := time.Now()
-	return true, now.Sub(times[t.params.superMajority-2])
+	return true, t.now.Sub(times[t.params.superMajority-2])
 }
 
-//In release version, member rotate is fired by consensus output depend on the upper level protocol.
-//That protocol need performance metrics of the current members and new request of candidates.
+//Member rotate is fired by validator changes on chain, see RotateValidators.
+//The upper level protocol may need performance metrics of the current members and new request of candidates.
 func (t *Tetris) validatorRotate(joins []string, quits []string) bool {
 	if len(joins) == 0 && len(quits) == 0 {
 		return false
@@ -1130,106 +1330,28 @@ func (t *Tetris) validatorRotate(joins []string, quits []string) bool {
 	t.params.superMajority = 2*len(t.validators)/3 + 1
 	t.params.maxEventPerEvent = len(t.validators)
 
-	return true
-}
-
-//Print info for debug
-func (t *Tetris) DebugPrint() {
-	fmt.Println()
-	fmt.Println("t.vid:", vidSignature(t.vid), "t.h:", t.h, "t.n", t.n)
-	fmt.Println("tx:", t.Metrics.TxIn, "event:", t.Metrics.EventIn, "parent:", t.Metrics.ParentEventIn, "requst", t.Metrics.EventRequest)
-	fmt.Println("txCh:", len(t.TxsCh), "eventCh:", len(t.EventCh), "sendCh:", len(t.SendEventCh))
-
-	keys := []string{}
-	for k := range t.validators {
-		keys = append(keys, k)
+	//let core reconfigure validators' membership in p2p
+	members := make([]string, 0, len(t.validators))
+	for vid := range t.validators {
+		members = append(members, vid)
 	}
-	sort.Strings(keys)
+	sort.Strings(members)
+	t.core.OnValidatorsChanged(members)
 
-	for _, key := range keys {
-		fmt.Print(key[2:4], ":")
-		for h := t.h + 1; h <= t.validatorsHeight[key]; h++ {
-			if t.validators[key][h] != nil {
-				ev := t.validators[key][h]
-
-				if len(ev.Body.E) > 1 {
-					if ev.witness {
-						fmt.Print("W")
-					} else {
-						fmt.Print("m")
-					}
-				} else {
-					if ev.witness {
-						fmt.Print("$")
-					} else if len(ev.Body.Tx) == 0 {
-						fmt.Print("s")
-					} else {
-						fmt.Print("S")
-					}
-				}
-
-			} else {
-				fmt.Print("-")
-			}
-		}
-		pstart := t.validatorsHeight[key] + 1
-		if pstart < t.h+1 {
-			pstart = t.h + 1
-		}
-		for p := pstart; p <= t.pendingHeight[key]; p++ {
-			if t.validators[key][p] != nil {
-				ev := t.validators[key][p]
+	return true
+}
 
-				if len(ev.Body.E) > 1 {
-					fmt.Print("X")
-				} else {
-					fmt.Print("x")
-				}
-			} else {
-				fmt.Print(".")
-			}
-		}
-		fmt.Print("(", t.pendingHeight[key], t.validatorsHeight[key], ")")
-		fmt.Println()
-	}
-	fmt.Println()
+func vidSignature(vid string) string {
+	return vid[VidStrStart : VidStrStart+3]
 }
 
-func (t *Tetris) DebugPrintDetail() {
-	keys := []string{}
-	for k := range t.validators {
+func sortedKeys(m map[string]uint64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
-
-	for _, key := range keys {
-		fmt.Println(key[2:4], ": ")
-		for h := t.h + 1; h <= t.validatorsHeight[key]+1; h++ {
-			if t.validators[key][h] != nil {
-				ev := t.validators[key][h]
-				fmt.Print(ev.Body.N, "(")
-				for _, peh := range ev.Body.E {
-					pei, ok := t.eventCache.Get(peh)
-					if ok {
-						pe := pei.(*Event)
-						fmt.Print(vidSignature(pe.vid), ":", pe.Body.N, ", ")
-					} else {
-						fmt.Print("*, ")
-					}
-				}
-				fmt.Print(") ")
-
-			} else {
-				fmt.Print("-")
-			}
-		}
-		fmt.Println()
-	}
-	fmt.Println()
-}
-
-func vidSignature(vid string) string {
-	return vid[VidStrStart : VidStrStart+3]
+	return keys
 }
 
 /*
@@ -1240,4 +1362,17 @@ How to handle fork events
 4. current event's F list, add the forked event when it is ready.
 5. modify updateKnow function, know[vid of Fs]=-1, and this -1 is prior to others
 6. if consensus base event include F, then all vid for F quit at next stage
+7. the first fork of an event is reported to core as evidence, core puts it on chain to jail the validator
+
+Crash recovery
+1. own events are persisted before sent, accepted events of peers are persisted on receive.
+2. restarted tetris resumes sequence number after the highest one it has signed, never signs one twice.
+3. persisted events are reloaded as parents, own events above base are sent again.
+4. events far below base are pruned as consensus goes on.
+
+Replay
+1. every input goes through t.input with the time received, tetris never reads the clock elsewhere.
+2. validators are iterated in sorted order wherever it changes own events or outputs.
+3. so a recording of inputs replays to the same outputs, own events are signed again with the same key (rfc6979).
+4. scenarios step several tetris in one goroutine on a virtual clock, see Scenario.
 */
@@ -25,4 +25,6 @@ type ICore interface {
 	GetMinerSigner() (crypto.Signer, error)
 	GetPrivateKeyOfDefaultAccount() ([]byte, error)
 	AddressFromPublicKey(publicKey []byte) ([]byte, error)
+	OnValidatorsChanged(validators []string)
+	OnEquivocation(evidence []byte)
 }
@@ -41,7 +41,10 @@ import (
 var (
 	EmptyRootHash = DeriveHash(Transactions{})
 
-	ErrBlockBodyTxsMismatch = errors.New("block body txs mismatch")
+	ErrBlockBodyTxsMismatch      = errors.New("block body txs mismatch")
+	ErrBlockBodyReceiptsMismatch = errors.New("block body receipts mismatch")
+	ErrBlockExpiredTxInvalid     = errors.New("block body expired tx invalid")
+	ErrBlockEvidenceInvalid      = errors.New("block body evidence invalid")
 )
 
 // Block Header of yee chain
@@ -116,7 +119,9 @@ type Block struct {
 	stateTrie     state.AccountTrie
 	consensusTrie state.ConsensusTrie
 	transactions  Transactions
+	evidences     Evidences
 	receipts      Receipts
+	expired       Transactions // txs dropped as expired, one receipt each
 
 	// cache
 	hash       atomic.Value
@@ -212,6 +217,27 @@ func (b *Block) updateBody() error {
 		rawTxs = append(rawTxs, encoded)
 	}
 	b.body.RawTransactions = rawTxs
+	rawEvidences := make([][]byte, 0, len(b.evidences))
+	for _, e := range b.evidences {
+		rawEvidences = append(rawEvidences, common.CopyBytes(e.raw))
+	}
+	b.body.Evidences = rawEvidences
+	if err := b.receipts.encode(); err != nil {
+		return err
+	}
+	rawReceipts := make([][]byte, 0, len(b.receipts))
+	for _, r := range b.receipts {
+		rawReceipts = append(rawReceipts, common.CopyBytes(r.raw))
+	}
+	b.body.Receipts = rawReceipts
+	if err := b.expired.encode(); err != nil {
+		return err
+	}
+	rawExpired := make([][]byte, 0, len(b.expired))
+	for _, tx := range b.expired {
+		rawExpired = append(rawExpired, common.CopyBytes(tx.raw))
+	}
+	b.body.ExpiredTransactions = rawExpired
 	return nil
 }
 
@@ -320,6 +346,21 @@ func (b *Block) VerifyBody() error {
 			return err
 		}
 	}
+	if DeriveHash(b.receipts) != b.header.ReceiptsRoot {
+		return ErrBlockBodyReceiptsMismatch
+	}
+	// expired txs are checked against receipts when stored
+	for _, tx := range b.expired {
+		if err := tx.VerifySig(); err != nil {
+			return ErrBlockExpiredTxInvalid
+		}
+	}
+	// evidences are covered by ConsensusRoot when replayed, only check signatures here
+	for _, e := range b.evidences {
+		if err := e.verify(); err != nil {
+			return ErrBlockEvidenceInvalid
+		}
+	}
 	return nil
 }
 
@@ -360,6 +401,27 @@ func (b *Block) setBytes(enc []byte) error {
 		tx.raw = raw
 		b.transactions = append(b.transactions, tx)
 	}
+	b.evidences = make(Evidences, 0, len(b.body.Evidences))
+	for _, raw := range b.body.Evidences {
+		b.evidences = append(b.evidences, NewEvidence(raw))
+	}
+	b.receipts = make(Receipts, 0, len(b.body.Receipts))
+	for _, raw := range b.body.Receipts {
+		r := new(Receipt)
+		if err := r.Decode(raw); err != nil {
+			return err
+		}
+		b.receipts = append(b.receipts, r)
+	}
+	b.expired = make(Transactions, 0, len(b.body.ExpiredTransactions))
+	for _, raw := range b.body.ExpiredTransactions {
+		tx := new(Transaction)
+		if err := tx.Decode(raw); err != nil {
+			return err
+		}
+		tx.raw = raw
+		b.expired = append(b.expired, tx)
+	}
 	return nil
 }
 
@@ -399,6 +461,14 @@ func (b *Block) Write(putter persistent.Putter) error {
 	if err := b.transactions.Write(putter); err != nil {
 		return err
 	}
+	// add block evidences to storage, key "evi"+evidence.hash
+	if err := b.evidences.Write(putter); err != nil {
+		return err
+	}
+	// add receipts of txs dropped from block, key "rcp-"+tx.hash
+	if err := b.receipts.Write(putter); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -410,3 +480,22 @@ func ParseBlock(enc []byte) (*Block, error) {
 	}
 	return b, nil
 }
+
+// HeaderBytes returns the signed header, encoded as headers answered to peers
+func (b *Block) HeaderBytes() ([]byte, error) {
+	return proto.Marshal(b.pbHeader)
+}
+
+// ParseHeader decodes a signed header encoded by HeaderBytes, into a block
+// of the header only, without body and tries, for light nodes
+func ParseHeader(enc []byte) (*Block, error) {
+	pbHeader := new(corepb.SignedBlockHeader)
+	if err := proto.Unmarshal(enc, pbHeader); err != nil {
+		return nil, err
+	}
+	header := new(BlockHeader)
+	if err := rlp.DecodeBytes(pbHeader.Header, header); err != nil {
+		return nil, err
+	}
+	return &Block{header: header, pbHeader: pbHeader}, nil
+}
@@ -27,11 +27,14 @@ import (
 	"github.com/yeeco/gyee/log"
 )
 
-func organizeTxs(state state.AccountTrie, txs Transactions) (out Transactions, dropped Transactions) {
+// organizeTxs orders txs by nonce for block of height and time t,
+// txs not valid in the block or of nonce not possible are dropped
+func organizeTxs(state state.AccountTrie, height, t uint64, txs Transactions) (out Transactions, dropped Transactions) {
 	txsRoot := DeriveHash(txs)
 	log.Info("organizeTxs", "cnt", len(txs), "txsRoot", txsRoot)
 	var (
 		output   Transactions
+		invalid  Transactions
 		nonceMap = make(map[common.Address]uint64)
 	)
 	for {
@@ -45,6 +48,11 @@ func organizeTxs(state state.AccountTrie, txs Transactions) (out Transactions, d
 				log.Warn("tx ignored due to nil from")
 				continue
 			}
+			if err := tx.checkValidity(height, t); err != nil {
+				log.Info("tx not valid in block", "H", height, "tx", tx, "err", err)
+				invalid = append(invalid, tx)
+				continue
+			}
 			from := *tx.from
 			nonce, ok := nonceMap[from]
 			if !ok {
@@ -98,5 +106,5 @@ func organizeTxs(state state.AccountTrie, txs Transactions) (out Transactions, d
 			break
 		}
 	}
-	return output, txs
+	return output, append(invalid, txs...)
 }
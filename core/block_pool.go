@@ -30,10 +30,13 @@
 package core
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/golang-lru"
@@ -45,19 +48,23 @@ import (
 
 const TooFarBlocks = 120
 
+// timeout for each chain request to peer while syncing
+const syncRequestTimeout = 8 * time.Second
+
 var (
 	ErrBlockChainID        = errors.New("block chainID mismatch")
 	ErrBlockTooFarForChain = errors.New("block too far for chain head")
 )
 
 type sealRequest struct {
-	h   uint64
-	t   uint64
-	txs Transactions
+	h         uint64
+	t         uint64
+	txs       Transactions
+	evidences Evidences
 }
 
 func (sr *sealRequest) String() string {
-	return fmt.Sprintf("sealReq{H %d txs %d}", sr.h, len(sr.txs))
+	return fmt.Sprintf("sealReq{H %d txs %d evidences %d}", sr.h, len(sr.txs), len(sr.evidences))
 }
 
 type BlockPool struct {
@@ -124,10 +131,12 @@ func (bp *BlockPool) Stop() {
 	bp.wg.Wait()
 }
 
-func (bp *BlockPool) AddSealRequest(h, t uint64, txs Transactions) {
+func (bp *BlockPool) AddSealRequest(h, t uint64, txs Transactions, evidences Evidences) {
 	req := &sealRequest{
-		h:   h,
-		txs: txs,
+		h:         h,
+		t:         t,
+		txs:       txs,
+		evidences: evidences,
 	}
 	bp.sealChan <- req
 }
@@ -170,7 +179,7 @@ func (bp *BlockPool) processMsgHeader(msg p2p.Message) {
 
 	var h = new(corepb.SignedBlockHeader)
 	if err := proto.Unmarshal(msg.Data, h); err != nil {
-		bp.markBadPeer(msg)
+		bp.markBadPeer(msg, p2p.PeerOffenceUndecodable)
 		return
 	}
 	// TODO:
@@ -183,22 +192,28 @@ func (bp *BlockPool) processMsgBlock(msg p2p.Message) {
 	var b = new(Block)
 	if err := b.setBytes(msg.Data); err != nil {
 		log.Warn("block decode failure", "msg", msg)
-		bp.markBadPeer(msg)
+		bp.markBadPeer(msg, p2p.PeerOffenceUndecodable)
 		return
 	}
-	bp.processBlock(b)
+	if err := bp.processBlock(b); err == ErrBlockSignatureMismatch {
+		bp.markBadPeer(msg, p2p.PeerOffenceBadSignature)
+	}
 }
 
-func (bp *BlockPool) processBlock(blk *Block) {
+// processBlock returns error if block fails to be verified
+func (bp *BlockPool) processBlock(blk *Block) error {
 	if err := bp.chain.verifyBlock(blk, false); err != nil {
 		log.Warn("processBlock() verify fails", "err", err)
 		if err == ErrBlockTooFarForChain {
 			bp.startFullSync()
 		}
-		// TODO: mark bad peer?
-		return
+		return err
+	}
+	select {
+	case bp.blockChan <- blk:
+	case <-bp.quitCh:
 	}
-	bp.blockChan <- blk
+	return nil
 }
 
 func (bp *BlockPool) processVerifiedBlock(blk *Block) {
@@ -259,6 +274,8 @@ func (bp *BlockPool) processVerifiedBlock(blk *Block) {
 			log.Warn("processBlock() add fail", "err", err)
 			return
 		}
+		bp.core.onBlockAdded(blk)
+		bp.chain.updateFinalized(blk)
 		bp.cacheNum2Hash.Add(blk.Number(), blk.Hash())
 		bp.cacheHash2Blk.Add(blk.Hash(), blk)
 		delete(bp.blockMap, blk.Number())
@@ -297,16 +314,24 @@ func (bp *BlockPool) handleSealRequest(req *sealRequest) {
 			break
 		}
 		// engine output not ordered by nonce
-		txs, txDrop := organizeTxs(currState, req.txs)
+		txs, txDrop := organizeTxs(currState, req.h, req.t, req.txs)
 		if len(txDrop) > 0 {
 			var drop = make([]common.Hash, 0, len(txDrop))
 			for i := range txDrop {
 				drop = append(drop, *(txDrop[i].Hash()))
+				switch txDrop[i].checkValidity(req.h, req.t) {
+				case ErrTxExpired:
+					// replayed after txs in block, dropped there with receipts
+					txs = append(txs, txDrop[i])
+				case ErrTxNotYetValid:
+					// hold in pool, sent to engine again when valid
+					bp.core.txPool.holdTx(txDrop[i])
+				}
 			}
 			bp.core.engine.OnTxDropped(drop)
 		}
 		// build next block
-		nextBlock, err := bp.chain.BuildNextBlock(currBlock, req.t, txs)
+		nextBlock, err := bp.chain.BuildNextBlock(currBlock, req.t, txs, req.evidences)
 		if err != nil {
 			log.Crit("failed to build next block", "parent", currBlock,
 				"err", err)
@@ -331,6 +356,8 @@ func (bp *BlockPool) handleSealRequest(req *sealRequest) {
 			log.Warn("failed to seal block", "err", err)
 			break
 		}
+		bp.core.onBlockAdded(nextBlock)
+		bp.chain.updateFinalized(nextBlock)
 		bp.cacheNum2Hash.Add(nextBlock.Number(), nextBlock.Hash())
 		bp.cacheHash2Blk.Add(nextBlock.Hash(), nextBlock)
 		delete(bp.sealMap, currHeight)
@@ -382,11 +409,16 @@ func (bp *BlockPool) handleNewSignature(blk *Block) {
 		bp.cacheHash2Blk.Add(currBlock.Hash(), currBlock)
 		// TODO: less disk write
 		putHeader(bp.chain.storage, currBlock.pbHeader)
+		bp.chain.updateFinalized(currBlock)
 	}
 }
 
-func (bp *BlockPool) markBadPeer(msg p2p.Message) {
-	// TODO: inform bad peed msg.From to p2p module
+// markBadPeer reports msg.From at minor severity, as gossip is forwarded
+// by p2p before core validated it and msg.From may only have relayed it.
+func (bp *BlockPool) markBadPeer(msg p2p.Message, offence string) {
+	if err := bp.core.node.P2pService().ReportPeer(msg.From, offence, p2p.PeerSeverityMinor); err != nil {
+		log.Warn("failed to report bad peer", "from", msg.From, "err", err)
+	}
 }
 
 func (bp *BlockPool) isSyncing() bool {
@@ -411,23 +443,91 @@ func (bp *BlockPool) syncLoop() {
 	defer atomic.StoreInt32(&bp.syncing, 0)
 	log.Info("[sync] block pool sync started", "localH", bp.chain.CurrentBlockHeight())
 
-	remoteHeight, err := bp.core.GetRemoteLatestNumber()
+	// sync from one peer: headers of blocks after local head in batches, then
+	// blocks of those header hashes, all from the peer answered status first
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-bp.quitCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, syncRequestTimeout)
+	status, err := bp.core.GetRemoteStatus(reqCtx, "")
+	reqCancel()
 	if err != nil {
-		log.Warn("failed to get remote height", "err", err)
+		log.Warn("failed to get remote status", "err", err)
 		return
 	}
-	log.Info("[sync] remote height", remoteHeight)
+	peer := status.From
+	if !bytes.Equal(status.Status.Genesis, bp.chain.genesis.Hash().Bytes()) {
+		log.Warn("[sync] remote genesis mismatch", "peer", peer)
+		return
+	}
+	remoteHeight := status.Status.Height
+	log.Info("[sync] remote height", "peer", peer, "H", remoteHeight)
 	h := bp.chain.CurrentBlockHeight() + 1
 	for h <= remoteHeight {
-		b, err := bp.core.GetRemoteBlockByNumber(h)
+		count := uint64(p2p.ChainMaxHeaders)
+		if remoteHeight-h+1 < count {
+			count = remoteHeight - h + 1
+		}
+		reqCtx, reqCancel = context.WithTimeout(ctx, syncRequestTimeout)
+		_, hashes, err := bp.core.GetRemoteHeaders(reqCtx, peer, h, int(count))
+		reqCancel()
 		if err != nil {
-			log.Warn("failed to get remote block", "err", err)
+			log.Warn("failed to get remote headers", "peer", peer, "err", err)
+			return
+		}
+		if len(hashes) == 0 {
+			log.Warn("[sync] no remote headers", "peer", peer, "H", h)
 			return
 		}
-		log.Info("[sync] got remote block", "H", b.Number(),
-			"txs", len(b.body.RawTransactions), "hash", b.Hash())
-		bp.processBlock(b)
-		h++
+		for len(hashes) > 0 {
+			batch := hashes
+			if len(batch) > p2p.ChainMaxBlocks {
+				batch = batch[:p2p.ChainMaxBlocks]
+			}
+			hashes = hashes[len(batch):]
+			reqCtx, reqCancel = context.WithTimeout(ctx, syncRequestTimeout)
+			blocks, err := bp.core.GetRemoteBlocks(reqCtx, peer, batch)
+			reqCancel()
+			if err != nil {
+				log.Warn("failed to get remote blocks", "peer", peer, "err", err)
+				return
+			}
+			if len(blocks) == 0 {
+				log.Warn("[sync] no remote blocks", "peer", peer, "H", h)
+				return
+			}
+			// blocks must be those asked, in order of height from h, or the
+			// batch is dropped before any processed
+			for i, b := range blocks {
+				if i >= len(batch) || b.Hash() != batch[i] || b.Number() != h+uint64(i) {
+					log.Warn("[sync] remote blocks mismatch", "peer", peer, "H", h+uint64(i))
+					bp.core.reportPeer(peer, p2p.PeerOffenceInvalid)
+					return
+				}
+			}
+			for _, b := range blocks {
+				log.Info("[sync] got remote block", "H", b.Number(),
+					"txs", len(b.body.RawTransactions), "hash", b.Hash())
+				if err := bp.processBlock(b); err != nil {
+					if err == ErrBlockSignatureMismatch {
+						bp.core.reportPeer(peer, p2p.PeerOffenceBadSignature)
+					}
+					return
+				}
+				h++
+			}
+			if len(blocks) < len(batch) {
+				// peer answered part of blocks, ask headers again from here
+				break
+			}
+		}
 	}
 	log.Info("[sync] block pool sync finished")
 }
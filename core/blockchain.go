@@ -36,21 +36,15 @@ import (
 	"github.com/yeeco/gyee/persistent"
 )
 
-// chainData types used to query from peers
-const (
-	ChainDataTypeLatestH = "latestH" // latest block hash
-	ChainDataTypeLatestN = "latestN" // latest block number
-	ChainDataTypeBlockH  = "blkH"    // block for given hash
-	ChainDataTypeBlockN  = "blkN"    // block for given number
-)
-
 var (
 	ErrBlockChainNoStorage    = errors.New("core.chain: must provide block chain storage")
 	ErrBlockChainIDMismatch   = errors.New("core.chain: chainID mismatch")
 	ErrBlockStateTrieMismatch = errors.New("core.chain: trie root hash mismatch")
+	ErrBlockConsensusMismatch = errors.New("core.chain: consensus trie root hash mismatch")
 	ErrBlockParentMissing     = errors.New("core.chain: block parent missing")
 	ErrBlockParentMismatch    = errors.New("core.chain: block parent mismatch")
 	ErrBlockSignatureMismatch = errors.New("core.chain: block signature mismatch")
+	ErrBlockRewindFinalized   = errors.New("core.chain: rewinding below finalized block")
 )
 
 // BlockChain is a Data Manager that
@@ -69,6 +63,12 @@ type BlockChain struct {
 
 	lastBlock atomic.Value
 
+	// highest block signed by 2/3 validators of its parent, never rewound,
+	// subscribers get heights finalized, only the latest kept if not received
+	finalizedHeight uint64
+	finalmu         sync.Mutex
+	finalizedSubs   map[chan uint64]struct{}
+
 	chainmu sync.RWMutex
 
 	stopped int32          // state
@@ -92,10 +92,11 @@ func NewBlockChain(chainID ChainID, storage persistent.Storage, engine consensus
 	}
 
 	bc := &BlockChain{
-		chainID: chainID,
-		storage: storage,
-		stateDB: GetStateDB(storage),
-		engine:  engine,
+		chainID:       chainID,
+		storage:       storage,
+		stateDB:       GetStateDB(storage),
+		engine:        engine,
+		finalizedSubs: make(map[chan uint64]struct{}),
 	}
 
 	bc.genesis = bc.GetBlockByNumber(0)
@@ -110,6 +111,7 @@ func NewBlockChain(chainID ChainID, storage persistent.Storage, engine consensus
 		}
 	}
 
+	bc.loadFinalized()
 	if err := bc.loadLastBlock(); err != nil {
 		return nil, err
 	}
@@ -159,6 +161,11 @@ func (bc *BlockChain) repair(head **Block) error {
 			log.Warn("genesis trie broken, resetting")
 			return bc.Reset()
 		}
+		if (*head).Number()-1 < bc.FinalizedHeight() {
+			log.Error("trie of finalized block broken", "number", (*head).Number()-1,
+				"finalized", bc.FinalizedHeight())
+			return ErrBlockRewindFinalized
+		}
 		b := bc.GetBlockByHash(parentHash)
 		if b == nil {
 			return fmt.Errorf("broken chain %d %x", (*head).Number()-1, parentHash)
@@ -187,6 +194,9 @@ func (bc *BlockChain) Stop() {
 // reset chain to genesis block
 func (bc *BlockChain) Reset() error {
 	genesis := bc.genesis
+	if bc.FinalizedHeight() > 0 {
+		return ErrBlockRewindFinalized
+	}
 
 	bc.chainmu.Lock()
 	defer bc.chainmu.Unlock()
@@ -221,11 +231,18 @@ func (bc *BlockChain) storeBlock(b *Block) error {
 			if err != nil {
 				return err
 			}
+			consensusTrie, err := state.NewConsensusTrie(prevBlk.header.ConsensusRoot, bc.stateDB)
+			if err != nil {
+				return err
+			}
 			// replay txs from prev block
-			_, err = bc.replayTxs(stateTrie, b.transactions)
+			_, _, err = bc.replayTxs(stateTrie, consensusTrie, b.header.Number, b.header.Time, b.transactions)
 			if err != nil {
 				return err
 			}
+			if applied := bc.replayEvidences(consensusTrie, b.header.Number, b.evidences); len(applied) != len(b.evidences) {
+				return ErrBlockEvidenceInvalid
+			}
 			// check state root hash
 			h, err := stateTrie.Commit()
 			if err != nil {
@@ -234,11 +251,31 @@ func (bc *BlockChain) storeBlock(b *Block) error {
 			if h != b.header.StateRoot {
 				return ErrBlockStateTrieMismatch
 			}
+			if h, err = consensusTrie.Commit(); err != nil {
+				return err
+			}
+			if h != b.header.ConsensusRoot {
+				return ErrBlockConsensusMismatch
+			}
 			// all set
 			b.stateTrie = stateTrie
+			b.consensusTrie = consensusTrie
 		}
 	}
 
+	// receipts recorded are those replayed from expired txs, not from body
+	expired, receipts := bc.expireTxs(b.stateTrie, b.header.Number, b.header.Time, b.expired)
+	if len(expired) != len(b.expired) {
+		return ErrBlockExpiredTxInvalid
+	}
+	if err := receipts.encode(); err != nil {
+		return err
+	}
+	if DeriveHash(receipts) != b.header.ReceiptsRoot {
+		return ErrBlockBodyReceiptsMismatch
+	}
+	b.receipts = receipts
+
 	batch := bc.storage.NewBatch()
 
 	if err := b.Write(batch); err != nil {
@@ -287,6 +324,107 @@ func (bc *BlockChain) AddBlock(b *Block) error {
 	return nil
 }
 
+// load finalized height, genesis is final
+func (bc *BlockChain) loadFinalized() {
+	hash := getFinalizedBlock(bc.storage)
+	if hash == common.EmptyHash {
+		return
+	}
+	num := getBlockHash2Num(bc.storage, hash)
+	if num == nil {
+		log.Warn("finalized block missing", "hash", hash)
+		return
+	}
+	atomic.StoreUint64(&bc.finalizedHeight, *num)
+	log.Info("Loaded finalized block", "number", *num, "hash", hash)
+}
+
+// FinalizedHeight returns height of the highest finalized block, blocks
+// of the chain at or below it are final
+func (bc *BlockChain) FinalizedHeight() uint64 {
+	return atomic.LoadUint64(&bc.finalizedHeight)
+}
+
+// GetFinalizedBlock returns the highest block signed by 2/3 validators of
+// its parent, genesis if none
+func (bc *BlockChain) GetFinalizedBlock() *Block {
+	return bc.GetBlockByNumber(bc.FinalizedHeight())
+}
+
+// IsFinalized tells if block is final, i.e. the canonical block at its
+// height, at or below the finalized height
+func (bc *BlockChain) IsFinalized(b *Block) bool {
+	return b != nil && b.Number() <= bc.FinalizedHeight() &&
+		getBlockNum2Hash(bc.storage, b.Number()) == b.Hash()
+}
+
+// SubscribeFinalized returns channel of heights finalized, only the latest
+// is kept if not received in time, so heights may be skipped. Unsubscribe
+// with the function returned.
+func (bc *BlockChain) SubscribeFinalized() (<-chan uint64, func()) {
+	ch := make(chan uint64, 1)
+	bc.finalmu.Lock()
+	bc.finalizedSubs[ch] = struct{}{}
+	bc.finalmu.Unlock()
+	return ch, func() {
+		bc.finalmu.Lock()
+		delete(bc.finalizedSubs, ch)
+		bc.finalmu.Unlock()
+	}
+}
+
+// updateFinalized marks block of the chain finalized if signed by 2/3
+// validators of its parent, the threshold of block pool adding block.
+// Called when block added or its signatures merged.
+func (bc *BlockChain) updateFinalized(b *Block) {
+	if b.Number() <= bc.FinalizedHeight() {
+		return
+	}
+	if hash := getBlockNum2Hash(bc.storage, b.Number()); hash != b.Hash() {
+		return
+	}
+	parent := bc.GetBlockByNumber(b.Number() - 1)
+	if parent == nil {
+		return
+	}
+	validatorList := parent.ValidatorAddr()
+	validators := make(map[common.Address]bool, len(validatorList))
+	for _, addr := range validatorList {
+		validators[addr] = true
+	}
+	signers, err := b.Signers()
+	if err != nil {
+		return
+	}
+	matched := 0
+	for signer := range signers {
+		if validators[signer] {
+			matched++
+		}
+	}
+	if matched == 0 || matched*3 < len(validatorList)*2 {
+		return
+	}
+
+	bc.finalmu.Lock()
+	defer bc.finalmu.Unlock()
+	if b.Number() <= bc.FinalizedHeight() {
+		return
+	}
+	putFinalizedBlock(bc.storage, b.Hash())
+	atomic.StoreUint64(&bc.finalizedHeight, b.Number())
+	log.Debug("block finalized", "H", b.Number(), "hash", b.Hash(),
+		"sCnt", matched, "vCnt", len(validatorList))
+	for ch := range bc.finalizedSubs {
+		// replace height not yet received
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- b.Number()
+	}
+}
+
 func (bc *BlockChain) GetBlockByNumber(number uint64) *Block {
 	hash := getBlockNum2Hash(bc.storage, number)
 	if hash == common.EmptyHash {
@@ -339,8 +477,8 @@ func (bc *BlockChain) GetTxByHash(hash common.Hash) *Transaction {
 	return tx
 }
 
-// Build Next block from parent block, with transactions
-func (bc *BlockChain) BuildNextBlock(parent *Block, t uint64, txs Transactions) (*Block, error) {
+// Build Next block from parent block, with transactions and evidences
+func (bc *BlockChain) BuildNextBlock(parent *Block, t uint64, txs Transactions, evidences Evidences) (*Block, error) {
 	var err error
 	next := &Block{
 		header:       CopyHeader(parent.header),
@@ -358,10 +496,13 @@ func (bc *BlockChain) BuildNextBlock(parent *Block, t uint64, txs Transactions)
 	}
 
 	// iterate txs for state changes
-	next.transactions, err = bc.replayTxs(next.stateTrie, txs)
+	var expired Transactions
+	next.transactions, expired, err = bc.replayTxs(next.stateTrie, next.consensusTrie, next.header.Number, next.header.Time, txs)
 	if err != nil {
 		log.Crit("replayTxs", "err", err)
 	}
+	next.expired, next.receipts = bc.expireTxs(next.stateTrie, next.header.Number, next.header.Time, expired)
+	next.evidences = bc.replayEvidences(next.consensusTrie, next.header.Number, evidences)
 
 	if err := next.updateBody(); err != nil {
 		return nil, err
@@ -373,12 +514,27 @@ func (bc *BlockChain) BuildNextBlock(parent *Block, t uint64, txs Transactions)
 	return next, nil
 }
 
-func (bc *BlockChain) replayTxs(stateTrie state.AccountTrie, txs Transactions) (Transactions, error) {
+// replay txs of block at height and time t on tries of its parent, returns
+// txs included and those dropped as expired
+func (bc *BlockChain) replayTxs(stateTrie state.AccountTrie, consensusTrie state.ConsensusTrie,
+	height, t uint64, txs Transactions) (Transactions, Transactions, error) {
+	// validator changes approved before take effect first
+	if activateValidatorChanges(consensusTrie, height) {
+		log.Info("validator changes activated", "height", height,
+			"validators", consensusTrie.GetValidators())
+	}
 	inBlockTxs := make(Transactions, 0, len(txs))
+	expired := make(Transactions, 0)
 	for _, tx := range txs {
 		if tx.from == nil {
 			continue
 		}
+		if err := tx.checkValidity(height, t); err != nil {
+			if err == ErrTxExpired {
+				expired = append(expired, tx)
+			}
+			continue
+		}
 		accountFrom := stateTrie.GetAccount(*tx.from, false)
 		if accountFrom == nil {
 			// TODO: mark tx failure
@@ -388,6 +544,16 @@ func (bc *BlockChain) replayTxs(stateTrie state.AccountTrie, txs Transactions) (
 			// TODO: mark tx failure
 			continue
 		}
+		if tx.txType != TxTypeTransfer {
+			if err := applyValidatorTx(consensusTrie, height, *tx.from, tx); err != nil {
+				// TODO: mark tx failure
+				log.Info("validator tx rejected", "tx", tx, "err", err)
+				continue
+			}
+			accountFrom.AddNonce(1)
+			inBlockTxs = append(inBlockTxs, tx)
+			continue
+		}
 		if accountFrom.Balance().Cmp(tx.amount) < 0 {
 			// TODO: mark tx failure
 			continue
@@ -400,7 +566,33 @@ func (bc *BlockChain) replayTxs(stateTrie state.AccountTrie, txs Transactions) (
 
 		inBlockTxs = append(inBlockTxs, tx)
 	}
-	return inBlockTxs, nil
+	return inBlockTxs, expired, nil
+}
+
+// expireTxs returns txs dropped as expired from block of height and time t
+// which get receipts, with the receipts. stateTrie is the state after the
+// block, a tx gets receipt only if its nonce not used yet, decided from chain
+// state alone so that every node replays the same receipts.
+func (bc *BlockChain) expireTxs(stateTrie state.AccountTrie, height, t uint64, txs Transactions) (Transactions, Receipts) {
+	expired := make(Transactions, 0, len(txs))
+	receipts := make(Receipts, 0, len(txs))
+	seen := make(map[common.Hash]bool)
+	for _, tx := range txs {
+		if tx.from == nil && tx.VerifySig() != nil {
+			continue
+		}
+		hash := *tx.Hash()
+		if seen[hash] || ChainID(tx.chainID) != bc.chainID || tx.checkValidity(height, t) != ErrTxExpired {
+			continue
+		}
+		if account := stateTrie.GetAccount(*tx.from, false); account != nil && account.Nonce() > tx.nonce {
+			continue
+		}
+		seen[hash] = true
+		expired = append(expired, tx)
+		receipts = append(receipts, newReceipt(hash, height, ReceiptReasonExpired))
+	}
+	return expired, receipts
 }
 
 func (bc *BlockChain) LastBlock() *Block {
@@ -425,6 +617,40 @@ func (bc *BlockChain) GetValidators() []string {
 	return b.consensusTrie.GetValidators()
 }
 
+// approved validator changes waiting for activation
+func (bc *BlockChain) GetValidatorChanges() []*state.ValidatorChange {
+	b := bc.LastBlock()
+	return b.consensusTrie.GetValidatorChanges()
+}
+
+// validators jailed on evidences of equivocation
+func (bc *BlockChain) GetJailed() []*state.JailRecord {
+	b := bc.LastBlock()
+	return b.consensusTrie.GetJailed()
+}
+
+// tetris params agreed in genesis, nil for defaults
+func (bc *BlockChain) GetTetrisParams() *state.TetrisParams {
+	b := bc.LastBlock()
+	return b.consensusTrie.GetTetrisParams()
+}
+
+// GetReceipt returns receipt of tx dropped from block, nil if not found
+func (bc *BlockChain) GetReceipt(txHash common.Hash) *Receipt {
+	pb := getReceipt(bc.storage, txHash)
+	if pb == nil {
+		return nil
+	}
+	r := new(Receipt)
+	r.FromProto(pb)
+	return r
+}
+
+// GetEvidenceByHash returns encoded evidence included in chain, nil if not found
+func (bc *BlockChain) GetEvidenceByHash(hash common.Hash) []byte {
+	return getEvidence(bc.storage, hash)
+}
+
 // check if block header is valid and belongs to chain
 func (bc *BlockChain) verifyHeader(h *BlockHeader) error {
 	if ChainID(h.ChainID) != bc.chainID {
@@ -516,7 +742,10 @@ func (bc *BlockChain) verifyTx(tx *Transaction) error {
 	if ChainID(tx.chainID) != bc.chainID {
 		return ErrTxChainID
 	}
-	return nil
+	if err := tx.verifyValidity(); err != nil {
+		return err
+	}
+	return verifyTxType(tx)
 }
 
 func GetStateDB(storage persistent.Storage) state.Database {
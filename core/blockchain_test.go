@@ -26,6 +26,7 @@ import (
 
 	"github.com/yeeco/gyee/common"
 	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/crypto/secp256k1"
 	"github.com/yeeco/gyee/persistent"
 )
 
@@ -102,7 +103,7 @@ func TestBlockChainGrow(t *testing.T) {
 
 			txs = append(txs, tx)
 		}
-		lastBlock, err = chain.BuildNextBlock(lastBlock, 0, txs)
+		lastBlock, err = chain.BuildNextBlock(lastBlock, 0, txs, nil)
 		if err != nil {
 			t.Fatalf("BuildNextBlock() %v", err)
 		}
@@ -173,3 +174,245 @@ func Benchmark_Write_100k(b *testing.B) {
 // TODO: test for blockchain rejects storage with wrong genesis block
 
 // TODO: test for blockchain generate genesis block if none found in storage
+
+func TestBlockChainFinalizedReload(t *testing.T) {
+	storage := persistent.NewMemoryStorage()
+	chain, err := NewBlockChain(TestNetID, storage, nil)
+	if err != nil {
+		t.Fatalf("newChain() %v", err)
+	}
+	if chain.FinalizedHeight() != 0 || !chain.IsFinalized(chain.LastBlock()) {
+		t.Fatalf("genesis not finalized")
+	}
+	block, err := chain.BuildNextBlock(chain.LastBlock(), 0, nil, nil)
+	if err != nil {
+		t.Fatalf("BuildNextBlock() %v", err)
+	}
+	if err := chain.AddBlock(block); err != nil {
+		t.Fatalf("AddBlock() %v", err)
+	}
+	// unsigned block not finalized
+	chain.updateFinalized(block)
+	if chain.IsFinalized(block) {
+		t.Fatalf("unsigned block finalized")
+	}
+	putFinalizedBlock(storage, block.Hash())
+	chain.Stop()
+
+	chain, err = NewBlockChain(TestNetID, storage, nil)
+	if err != nil {
+		t.Fatalf("reopen chain %v", err)
+	}
+	defer chain.Stop()
+	if h := chain.FinalizedHeight(); h != 1 {
+		t.Fatalf("finalized height %d", h)
+	}
+	if b := chain.GetFinalizedBlock(); b == nil || b.Hash() != block.Hash() {
+		t.Fatalf("finalized block mismatch")
+	}
+	if !chain.IsFinalized(block) {
+		t.Fatalf("finalized block not final")
+	}
+	// block of same height off the chain is not
+	sibling, err := chain.BuildNextBlock(chain.GetBlockByNumber(0), 1, nil, nil)
+	if err != nil {
+		t.Fatalf("BuildNextBlock() %v", err)
+	}
+	if sibling.Hash() == block.Hash() || chain.IsFinalized(sibling) {
+		t.Fatalf("non-canonical block finalized")
+	}
+	if err := chain.Reset(); err != ErrBlockRewindFinalized {
+		t.Fatalf("Reset() %v", err)
+	}
+}
+
+func TestBuildBlockTxValidity(t *testing.T) {
+	key := secp256k1.GenerateKey()
+	signer := secp256k1.NewSecp256k1Signer()
+	if err := signer.InitSigner(key.PrivateKey()); err != nil {
+		t.Fatalf("InitSigner() %v", err)
+	}
+	addr, err := address.NewAddressFromPublicKey(key.PublicKey())
+	if err != nil {
+		t.Fatalf("NewAddressFromPublicKey() %v", err)
+	}
+	storage := persistent.NewMemoryStorage()
+	if err := prepareStorage(storage, TestNetID); err != nil {
+		t.Fatalf("prepareStorage() %v", err)
+	}
+	genesis, err := NewGenesis(TestNetID, map[string]*big.Int{
+		addr.String(): big.NewInt(100),
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewGenesis() %v", err)
+	}
+	if _, err := genesis.Commit(GetStateDB(storage), storage); err != nil {
+		t.Fatalf("Commit() %v", err)
+	}
+	chain, err := NewBlockChain(TestNetID, storage, nil)
+	if err != nil {
+		t.Fatalf("newChain() %v", err)
+	}
+	defer chain.Stop()
+	// full node importing the blocks, never seeing the txs
+	importStorage := persistent.NewMemoryStorage()
+	if err := prepareStorage(importStorage, TestNetID); err != nil {
+		t.Fatalf("prepareStorage() %v", err)
+	}
+	if _, err := genesis.Commit(GetStateDB(importStorage), importStorage); err != nil {
+		t.Fatalf("Commit() %v", err)
+	}
+	importChain, err := NewBlockChain(TestNetID, importStorage, nil)
+	if err != nil {
+		t.Fatalf("newChain() %v", err)
+	}
+	defer importChain.Stop()
+
+	to := common.HexToAddress(txTestAddress)
+	newTx := func(nonce, after, until uint64) *Transaction {
+		tx := NewTransaction(uint32(TestNetID), nonce, &to, big.NewInt(1))
+		tx.SetValidity(after, until)
+		if err := tx.Sign(signer); err != nil {
+			t.Fatalf("Sign() %v", err)
+		}
+		if err := tx.VerifySig(); err != nil {
+			t.Fatalf("VerifySig() %v", err)
+		}
+		if tx.raw, err = tx.Encode(); err != nil {
+			t.Fatalf("Encode() %v", err)
+		}
+		return tx
+	}
+	var (
+		future  = newTx(0, 2, 0)
+		expired = newTx(0, 0, 1)
+	)
+	for _, c := range []struct {
+		txs      Transactions
+		included int
+		receipts int
+	}{
+		{Transactions{future}, 0, 0},
+		{Transactions{expired, expired}, 0, 1},
+		{Transactions{future}, 1, 0},
+	} {
+		last := chain.LastBlock()
+		state, err := chain.StateAt(last.StateRoot())
+		if err != nil {
+			t.Fatalf("StateAt() %v", err)
+		}
+		txs, dropped := organizeTxs(state, last.Number()+1, 0, c.txs)
+		if len(txs) != c.included || len(dropped) != len(c.txs)-c.included {
+			t.Fatalf("H %d organized %d dropped %d", last.Number()+1, len(txs), len(dropped))
+		}
+		// replay rejects txs not valid in block
+		block, err := chain.BuildNextBlock(last, 0, c.txs, nil)
+		if err != nil {
+			t.Fatalf("BuildNextBlock() %v", err)
+		}
+		if len(block.transactions) != c.included || len(block.receipts) != c.receipts {
+			t.Fatalf("H %d included %d receipts %d", block.Number(), len(block.transactions), len(block.receipts))
+		}
+		if err := chain.AddBlock(block); err != nil {
+			t.Fatalf("AddBlock() %v", err)
+		}
+
+		enc, err := block.ToBytes()
+		if err != nil {
+			t.Fatalf("ToBytes() %v", err)
+		}
+		imported, err := ParseBlock(enc)
+		if err != nil {
+			t.Fatalf("ParseBlock() %v", err)
+		}
+		if err := imported.VerifyBody(); err != nil {
+			t.Fatalf("H %d VerifyBody() %v", imported.Number(), err)
+		}
+		if err := importChain.AddBlock(imported); err != nil {
+			t.Fatalf("import AddBlock() %v", err)
+		}
+	}
+	// receipts of txs dropped from block are recorded by all nodes storing it
+	for _, c := range []*BlockChain{chain, importChain} {
+		receipt := c.GetReceipt(*expired.Hash())
+		if receipt == nil || receipt.Reason != ReceiptReasonExpired || receipt.Height != 2 {
+			t.Fatalf("receipt of tx expired in block %v", receipt)
+		}
+	}
+	// receipts are covered by header
+	enc, err := chain.GetBlockByNumber(2).ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() %v", err)
+	}
+	stripped, err := ParseBlock(enc)
+	if err != nil {
+		t.Fatalf("ParseBlock() %v", err)
+	}
+	stripped.receipts = nil
+	if err := stripped.VerifyBody(); err != ErrBlockBodyReceiptsMismatch {
+		t.Fatalf("VerifyBody() without receipts %v", err)
+	}
+
+	// receipts replayed from chain state alone, none for a used nonce
+	used, pending := newTx(0, 0, 2), newTx(1, 0, 2)
+	block, err := chain.BuildNextBlock(chain.LastBlock(), 0, Transactions{used, pending}, nil)
+	if err != nil {
+		t.Fatalf("BuildNextBlock() %v", err)
+	}
+	if len(block.expired) != 1 || len(block.receipts) != 1 || block.receipts[0].TxHash != *pending.Hash() {
+		t.Fatalf("expired %d receipts %v", len(block.expired), block.receipts)
+	}
+	enc, err = block.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() %v", err)
+	}
+	// receipts not replayed from expired txs in body are rejected
+	for _, c := range []struct {
+		forge func(b *Block)
+		err   error
+	}{
+		{func(b *Block) { b.expired, b.receipts = nil, nil }, ErrBlockBodyReceiptsMismatch},
+		{func(b *Block) { b.expired = Transactions{used} }, ErrBlockExpiredTxInvalid},
+		{func(b *Block) { b.expired = Transactions{pending, pending} }, ErrBlockExpiredTxInvalid},
+	} {
+		forged, err := ParseBlock(enc)
+		if err != nil {
+			t.Fatalf("ParseBlock() %v", err)
+		}
+		c.forge(forged)
+		if err := importChain.AddBlock(forged); err != c.err {
+			t.Fatalf("forged AddBlock() %v want %v", err, c.err)
+		}
+	}
+	imported, err := ParseBlock(enc)
+	if err != nil {
+		t.Fatalf("ParseBlock() %v", err)
+	}
+	if err := imported.VerifyBody(); err != nil {
+		t.Fatalf("VerifyBody() %v", err)
+	}
+	if err := importChain.AddBlock(imported); err != nil {
+		t.Fatalf("import AddBlock() %v", err)
+	}
+	if importChain.GetReceipt(*pending.Hash()) == nil || importChain.GetReceipt(*used.Hash()) != nil {
+		t.Fatalf("receipts of expired txs mismatch")
+	}
+
+	// txs expired in pool, held or received, leave receipts
+	tp, _ := NewTransactionPool(&Core{storage: storage, blockChain: chain})
+	height := chain.CurrentBlockHeight()
+	held, received := newTx(1, 0, height), newTx(2, 0, height)
+	tp.holdTx(held)
+	if released := tp.releaseFuture(); len(released) != 0 || len(tp.futurePool) != 0 {
+		t.Fatalf("expired tx released %d, held %d", len(released), len(tp.futurePool))
+	}
+	if err := tp.processTx(received); err != nil {
+		t.Fatalf("processTx() %v", err)
+	}
+	for _, tx := range []*Transaction{held, received} {
+		receipt := chain.GetReceipt(*tx.Hash())
+		if receipt == nil || receipt.Reason != ReceiptReasonExpired || receipt.Height != height+1 {
+			t.Fatalf("receipt of expired tx %v", receipt)
+		}
+	}
+}
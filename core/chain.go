@@ -22,4 +22,5 @@ type ChainID uint32
 const (
 	MainNetID ChainID = 0
 	TestNetID ChainID = 1
+	DevNetID  ChainID = 1337 // single node chain of gyee --dev, genesis made on start
 )
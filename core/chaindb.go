@@ -33,13 +33,17 @@ import (
 const (
 	KeyChainID = "ChainID"
 
-	KeyLastBlock = "LastBlock"
+	KeyLastBlock      = "LastBlock"
+	KeyFinalizedBlock = "FinalizedBlock" // highest block signed by 2/3 validators of parent
 
 	KeyPrefixStateTrie = "sTrie-" // stateTrie Hash => trie node
+	KeyPrefixTetris    = "tts-"   // tetris events store
 
-	KeyPrefixTx     = "tx-"   // txHash => encodedTx
-	KeyPrefixHeader = "blkH-" // blockHash => encodedBlockHeader
-	KeyPrefixBody   = "blkB-" // blockHash => encodedBlockBody
+	KeyPrefixTx       = "tx-"   // txHash => encodedTx
+	KeyPrefixReceipt  = "rcp-"  // txHash => encodedReceipt
+	KeyPrefixEvidence = "evi-"  // evidenceHash => encodedEvidence
+	KeyPrefixHeader   = "blkH-" // blockHash => encodedBlockHeader
+	KeyPrefixBody     = "blkB-" // blockHash => encodedBlockBody
 
 	KeyPrefixBlockNum2Hash = "bn2h-" // blockNum => blockHash
 	KeyPrefixBlockHash2Num = "bh2n-" // blockHash => blockNum
@@ -88,6 +92,24 @@ func putLastBlock(putter persistent.Putter, hash common.Hash) {
 	}
 }
 
+// genesis hash is not stored, empty hash returned before any block finalized
+func getFinalizedBlock(getter persistent.Getter) common.Hash {
+	enc, err := getter.Get(keyFinalizedBlock())
+	if err != nil {
+		if err != persistent.ErrKeyNotFound {
+			log.Error("getFinalizedBlock()", err)
+		}
+		return common.EmptyHash
+	}
+	return common.BytesToHash(enc)
+}
+
+func putFinalizedBlock(putter persistent.Putter, hash common.Hash) {
+	if err := putter.Put(keyFinalizedBlock(), hash[:]); err != nil {
+		log.Crit("putFinalizedBlock()", err)
+	}
+}
+
 func getHeader(getter persistent.Getter, hash common.Hash) *corepb.SignedBlockHeader {
 	msg := new(corepb.SignedBlockHeader)
 	if err := getProtoMsg(getter, keyHeader(hash), msg); err != nil {
@@ -175,6 +197,46 @@ func putTransaction(putter persistent.Putter, hash common.Hash, tx *corepb.Trans
 	putProtoMsg(putter, keyTx(hash), tx)
 }
 
+func getReceipt(getter persistent.Getter, hash common.Hash) *corepb.Receipt {
+	msg := new(corepb.Receipt)
+	if err := getProtoMsg(getter, keyReceipt(hash), msg); err != nil {
+		if err != persistent.ErrKeyNotFound {
+			log.Error("getReceipt()", "hash", hash, "err", err)
+		}
+		return nil
+	}
+	return msg
+}
+
+func putReceipt(putter persistent.Putter, hash common.Hash, receipt *corepb.Receipt) {
+	putProtoMsg(putter, keyReceipt(hash), receipt)
+}
+
+func hasEvidence(getter persistent.Getter, hash common.Hash) bool {
+	has, err := getter.Has(keyEvidence(hash))
+	if err != nil {
+		log.Crit("hasEvidence() failed", "hash", hash, "err", err)
+	}
+	return has
+}
+
+func getEvidence(getter persistent.Getter, hash common.Hash) []byte {
+	enc, err := getter.Get(keyEvidence(hash))
+	if err != nil {
+		if err != persistent.ErrKeyNotFound {
+			log.Error("getEvidence()", "hash", hash, "err", err)
+		}
+		return nil
+	}
+	return enc
+}
+
+func putEvidence(putter persistent.Putter, hash common.Hash, evidence []byte) {
+	if err := putter.Put(keyEvidence(hash), evidence); err != nil {
+		log.Crit("putEvidence()", "hash", hash, "err", err)
+	}
+}
+
 func getProtoMsg(getter persistent.Getter, key []byte, message proto.Message) error {
 	enc, err := getter.Get(key)
 	if err != nil {
@@ -206,6 +268,10 @@ func keyLastBlock() []byte {
 	return []byte(KeyLastBlock)
 }
 
+func keyFinalizedBlock() []byte {
+	return []byte(KeyFinalizedBlock)
+}
+
 func keyHeader(hash common.Hash) []byte {
 	return append([]byte(KeyPrefixHeader), hash[:]...)
 }
@@ -227,3 +293,11 @@ func keyBlockNum2Hash(num uint64) []byte {
 func keyTx(hash common.Hash) []byte {
 	return append([]byte(KeyPrefixTx), hash[:]...)
 }
+
+func keyReceipt(hash common.Hash) []byte {
+	return append([]byte(KeyPrefixReceipt), hash[:]...)
+}
+
+func keyEvidence(hash common.Hash) []byte {
+	return append([]byte(KeyPrefixEvidence), hash[:]...)
+}
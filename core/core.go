@@ -40,23 +40,29 @@ package core
 
 */
 import (
+	"context"
 	"crypto/sha256"
 	"errors"
-	"fmt"
 	"io/ioutil"
-	"math/big"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/protobuf/proto"
 	"github.com/yeeco/gyee/common"
 	"github.com/yeeco/gyee/common/address"
 	"github.com/yeeco/gyee/config"
 	"github.com/yeeco/gyee/consensus"
+	"github.com/yeeco/gyee/consensus/dev"
 	"github.com/yeeco/gyee/consensus/tetris2"
+	"github.com/yeeco/gyee/core/pb"
+	"github.com/yeeco/gyee/core/state"
 	"github.com/yeeco/gyee/core/yvm"
 	"github.com/yeeco/gyee/crypto"
+	sha3 "github.com/yeeco/gyee/crypto/hash"
 	"github.com/yeeco/gyee/crypto/keystore"
 	"github.com/yeeco/gyee/crypto/secp256k1"
 	"github.com/yeeco/gyee/log"
@@ -69,12 +75,22 @@ const (
 	EventReqDhtGetTimeout   = 60 * time.Second
 	OutputTxsDhtGetMaxRetry = 1024
 	OutputTxsDhtGetTimeout  = 60 * time.Second
+
+	// consensus engines selected by config.Chain.Engine
+	EngineTetris2 = "tetris2"
+	EngineTetris  = "tetris"  // alias of tetris2, the default
+	EngineTetris1 = "tetris1" // first version, kept for simulation only
+	EngineDev     = "dev"
 )
 
 var (
 	ErrNoCoinbase          = errors.New("coinbase not provided")
 	ErrNoCoinbasePwdFile   = errors.New("coinbase keystore password file not provided")
 	ErrCoinbaseKeyNotFound = errors.New("coinbase not found in keystore")
+	ErrRemoteChainData     = errors.New("invalid chain data from peer")
+	ErrUnknownEngine       = errors.New("unknown consensus engine")
+	ErrEngineUnsupported   = errors.New("tetris1 does not implement consensus.Engine, use tetris2")
+	ErrNoTetris            = errors.New("tetris2 engine not running")
 )
 
 type Core struct {
@@ -83,14 +99,22 @@ type Core struct {
 	engine  consensus.Engine
 	storage persistent.Storage
 
-	blockChain *BlockChain
-	blockPool  *BlockPool
-	txPool     *TransactionPool
+	blockChain   *BlockChain
+	blockPool    *BlockPool
+	txPool       *TransactionPool
+	evidencePool *EvidencePool
 
 	yvm        yvm.YVM
 	subscriber *p2p.Subscriber
 	subsChan   chan p2p.Message
 
+	tetrisConfig *tetris2.Config
+
+	// latest validator set from engine, applied to p2p by validatorLoop
+	valsMu      sync.Mutex
+	valsPending []string
+	valsCh      chan struct{}
+
 	// miner
 	keystore  *keystore.Keystore
 	minerKey  []byte
@@ -132,12 +156,24 @@ func NewCoreWithGenesis(node INode, conf *config.Config, genesis *Genesis) (*Cor
 		config:  conf,
 		storage: storage,
 		metrics: newCoreMetrics(),
+		valsCh:  make(chan struct{}, 1),
 		quitCh:  make(chan struct{}),
 	}
 	core.blockChain, err = NewBlockChainWithCore(core)
 	if err != nil {
 		return nil, err
 	}
+	switch core.EngineName() {
+	case EngineTetris2, EngineDev:
+	case EngineTetris1:
+		return nil, ErrEngineUnsupported
+	default:
+		return nil, ErrUnknownEngine
+	}
+	core.tetrisConfig, err = tetrisConfig(core.blockChain.GetTetrisParams(), conf.Chain.Tetris)
+	if err != nil {
+		return nil, err
+	}
 	core.blockPool, err = NewBlockPool(core)
 	if err != nil {
 		return nil, err
@@ -146,6 +182,10 @@ func NewCoreWithGenesis(node INode, conf *config.Config, genesis *Genesis) (*Cor
 	if err != nil {
 		return nil, err
 	}
+	core.evidencePool, err = NewEvidencePool(core)
+	if err != nil {
+		return nil, err
+	}
 
 	return core, nil
 }
@@ -161,6 +201,7 @@ func (c *Core) Start() error {
 
 	c.blockPool.Start()
 	c.txPool.Start()
+	c.evidencePool.Start()
 	c.node.P2pService().RegChainProvider(c)
 
 	//如果开启挖矿
@@ -169,16 +210,20 @@ func (c *Core) Start() error {
 			return err
 		}
 
-		members := c.blockChain.GetValidators()
-		blockHeight := c.blockChain.CurrentBlockHeight()
-		tetris, err := tetris2.NewTetris(c, c.minerAddr.String(), members, blockHeight)
+		engine, err := c.newEngine()
 		if err != nil {
 			return err
 		}
-		c.engine = tetris
+		c.engine = engine
+		c.wg.Add(1)
+		go c.validatorLoop()
 		if err := c.engine.Start(); err != nil {
 			return err
 		}
+		// validator changes approved but not yet activated
+		for _, change := range c.blockChain.GetValidatorChanges() {
+			c.engine.RotateValidators(change.Height, change.Joins, change.Quits)
+		}
 
 		log.Info("start mining", "coinbase", c.minerAddr.String())
 
@@ -188,6 +233,11 @@ func (c *Core) Start() error {
 		c.subsChan = c.subscriber.MsgChan
 	}
 
+	// validators' membership in p2p follows the consensus trie
+	if err := c.setP2pValidators(c.blockChain.GetValidators()); err != nil {
+		log.Warn("core: set p2p validators", "err", err)
+	}
+
 	go c.loop()
 
 	c.running = true
@@ -211,23 +261,24 @@ func (c *Core) Stop() error {
 
 	// stop tx pool and wait
 	c.txPool.Stop()
+	c.evidencePool.Stop()
 
 	// stop block pool and wait
 	c.blockPool.Stop()
 
-	// stop chain also wait for cache flush
-	c.blockChain.Stop()
-	if err := c.storage.Close(); err != nil {
-		log.Error("core: storage.Close():", err)
-	}
-
-	// stop tetris
+	// stop tetris, before storage closed as it persists events
 	if c.engine != nil {
 		if err := c.engine.Stop(); err != nil {
 			log.Error("core: engine.Stop", "err", err)
 		}
 	}
 
+	// stop chain also wait for cache flush
+	c.blockChain.Stop()
+	if err := c.storage.Close(); err != nil {
+		log.Error("core: storage.Close():", err)
+	}
+
 	// notify loop and wait
 	close(c.quitCh)
 	c.wg.Wait()
@@ -359,22 +410,32 @@ func (c *Core) handleEngineOutput(o *consensus.Output) {
 		defer c.wg.Done()
 		retry := 0
 		knownTxs := make(map[common.Hash]*Transaction)
+		knownEvidences := make(map[common.Hash]*Evidence)
 		for {
 			if !c.running {
 				return
 			}
 
-			var txs = func(txHash []common.Hash) Transactions {
+			// engine output hashes of txs and evidences alike
+			var txs, evidences, ok = func(txHash []common.Hash) (Transactions, Evidences, bool) {
 				// short cut for empty output
 				if len(txHash) == 0 {
-					return make(Transactions, 0)
+					return make(Transactions, 0), make(Evidences, 0), true
 				}
 
 				var unknownHash [][]byte
 				for _, h := range txHash {
-					if _, ok := knownTxs[h]; !ok {
-						unknownHash = append(unknownHash, h.Copy().Bytes())
+					if _, ok := knownTxs[h]; ok {
+						continue
+					}
+					if _, ok := knownEvidences[h]; ok {
+						continue
+					}
+					if e := c.evidencePool.GetEvidenceByHash(h); e != nil {
+						knownEvidences[h] = e
+						continue
 					}
+					unknownHash = append(unknownHash, h.Copy().Bytes())
 				}
 				if fetchCnt := len(unknownHash); fetchCnt > 0 {
 					var output = make(chan []byte, fetchCnt)
@@ -382,7 +443,7 @@ func (c *Core) handleEngineOutput(o *consensus.Output) {
 					if err := c.node.P2pService().DhtGetValues(unknownHash, output, OutputTxsDhtGetTimeout); err != nil {
 						log.Error("DhtGetValues start failed", "err", err)
 						c.metrics.p2pDhtMissMeter.Mark(int64(fetchCnt))
-						return nil
+						return nil, nil, false
 					}
 					// failsafe timer, p2p layer should have timeout done by closing channel
 					var timer = time.NewTimer(OutputTxsDhtGetTimeout + 5*time.Second)
@@ -392,13 +453,21 @@ func (c *Core) handleEngineOutput(o *consensus.Output) {
 						select {
 						case <-timer.C:
 							log.Error("dht get timeout", "retry", retry,
-								"got", len(knownTxs), "total", len(txHash))
-							return nil
+								"got", len(knownTxs)+len(knownEvidences), "total", len(txHash))
+							return nil, nil, false
 						case enc, ok := <-output:
 							if !ok { // closed
 								break Loop
 							}
 							c.metrics.p2pDhtHitMeter.Mark(1)
+							if e := evidenceFromDhtValue(enc); e != nil {
+								if err := e.verify(); err != nil {
+									log.Error("failed to verify evidence", "err", err)
+									continue
+								}
+								knownEvidences[e.Hash()] = e
+								continue
+							}
 							tx := &Transaction{}
 							if err := tx.Decode(enc); err != nil {
 								log.Error("failed to decode tx", "err", err)
@@ -415,32 +484,37 @@ func (c *Core) handleEngineOutput(o *consensus.Output) {
 				}
 				// engine ensured no dup tx hash in output,
 				// if numbers not adds up, fail with cache and wait for another round
-				if len(knownTxs) != len(txHash) {
-					return nil
+				if len(knownTxs)+len(knownEvidences) != len(txHash) {
+					return nil, nil, false
 				}
 				txs := make(Transactions, 0, len(txHash))
+				evidences := make(Evidences, 0, len(knownEvidences))
 				for _, hash := range txHash {
+					if e, exists := knownEvidences[hash]; exists {
+						evidences = append(evidences, e)
+						continue
+					}
 					tx, exists := knownTxs[hash]
 					if !exists {
 						// SHALL NOT HAPPEN
 						log.Error("tx count reached but tx not around", "hash", hash)
-						return nil
+						return nil, nil, false
 					}
 					txs = append(txs, tx)
 				}
-				return txs
+				return txs, evidences, true
 			}(o.Txs)
 
-			if len(txs) == len(o.Txs) {
+			if ok {
 				// all tx fetched
 				c.blockPool.AddSealRequest(o.H,
 					uint64(o.T.UTC().UnixNano()/int64(time.Millisecond/time.Nanosecond)),
-					txs)
+					txs, evidences)
 				return
 			}
 
 			log.Warn("engine output getTx not finished", "retry", retry,
-				"got", len(knownTxs), "total", len(o.Txs), "output", o)
+				"got", len(knownTxs)+len(knownEvidences), "total", len(o.Txs), "output", o)
 			retry++
 			if retry >= OutputTxsDhtGetMaxRetry {
 				log.Error("engine output getTx failed", "output", o)
@@ -467,7 +541,11 @@ func (c *Core) loadCoinbaseKey() error {
 	if len(conf.Chain.PwdFile) == 0 {
 		return ErrNoCoinbasePwdFile
 	}
-	c.keystore = keystore.NewKeystoreWithConfig(conf)
+	ks, err := keystore.NewKeystoreWithConfig(conf)
+	if err != nil {
+		return err
+	}
+	c.keystore = ks
 	if contains, _ := c.keystore.Contains(coinbase); !contains {
 		return ErrCoinbaseKeyNotFound
 	}
@@ -484,6 +562,78 @@ func (c *Core) loadCoinbaseKey() error {
 	return nil
 }
 
+func (c *Core) newEngine() (consensus.Engine, error) {
+	blockHeight := c.blockChain.CurrentBlockHeight()
+	switch c.EngineName() {
+	case EngineTetris2:
+		members := c.blockChain.GetValidators()
+		t, err := tetris2.NewTetris(c, c.minerAddr.String(), members, blockHeight,
+			persistent.NewTable(c.storage, KeyPrefixTetris), c.tetrisConfig)
+		if err != nil {
+			return nil, err
+		}
+		if local := c.config.Chain.Tetris; local != nil && len(local.Record) > 0 {
+			// a session for every start, closed by tetris on stop
+			f, err := os.OpenFile(local.Record, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+			if err != nil {
+				return nil, err
+			}
+			t.SetRecorder(tetris2.NewRecorder(f))
+			log.Info("recording tetris inputs", "file", local.Record)
+		}
+		return t, nil
+	case EngineDev:
+		period := time.Duration(c.config.Chain.DevPeriod) * time.Millisecond
+		return dev.NewEngine(blockHeight, period), nil
+	case EngineTetris1:
+		return nil, ErrEngineUnsupported
+	default:
+		return nil, ErrUnknownEngine
+	}
+}
+
+// EngineName returns the consensus engine configured, with
+// empty and "tetris" resolved to tetris2
+func (c *Core) EngineName() string {
+	switch c.config.Chain.Engine {
+	case "", EngineTetris:
+		return EngineTetris2
+	}
+	return c.config.Chain.Engine
+}
+
+// TetrisConfig returns tetris params from genesis and local tuning
+func (c *Core) TetrisConfig() *tetris2.Config {
+	return c.tetrisConfig
+}
+
+func (c *Core) tetris() (*tetris2.Tetris, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if t, ok := c.engine.(*tetris2.Tetris); ok {
+		return t, nil
+	}
+	return nil, ErrNoTetris
+}
+
+// ConsensusState returns a snapshot of the running tetris
+func (c *Core) ConsensusState() (*tetris2.State, error) {
+	t, err := c.tetris()
+	if err != nil {
+		return nil, err
+	}
+	return t.State()
+}
+
+// ConsensusDag returns the tetris event DAG of the last rounds
+func (c *Core) ConsensusDag(rounds int) (*tetris2.Dag, error) {
+	t, err := c.tetris()
+	if err != nil {
+		return nil, err
+	}
+	return t.Dag(rounds)
+}
+
 func (c *Core) prepareCoinbase() error {
 	if err := c.loadCoinbaseKey(); err != nil {
 		return err
@@ -499,6 +649,55 @@ func (c *Core) prepareCoinbase() error {
 	return nil
 }
 
+// install validators into p2p, with the miner key to prove in handshakes if
+// mining
+func (c *Core) setP2pValidators(validators []string) error {
+	var (
+		self string
+		sign func(hash []byte) ([]byte, error)
+	)
+	if c.minerAddr != nil && c.minerKey != nil {
+		self = c.minerAddr.String()
+		key := c.minerKey
+		sign = func(hash []byte) ([]byte, error) {
+			return secp256k1.Sign(hash, key)
+		}
+	}
+	return c.node.P2pService().SetValidators(validators, self, sign)
+}
+
+// follow validator changes in block just added to chain
+//   changes approved in b are handed to engine, rotating at activation height
+//   non-mining nodes update p2p membership when changes activated in b
+func (c *Core) onBlockAdded(b *Block) {
+	if c.engine != nil {
+		for _, change := range b.consensusTrie.GetValidatorChanges() {
+			if change.Height == b.Number()+ValidatorActivationDelay {
+				c.engine.RotateValidators(change.Height, change.Joins, change.Quits)
+			}
+		}
+		return
+	}
+	if b.Number() == 0 {
+		return
+	}
+	parent := c.blockChain.GetBlockByNumber(b.Number() - 1)
+	if parent == nil || parent.ConsensusRoot() == b.ConsensusRoot() {
+		return
+	}
+	validators := b.consensusTrie.GetValidators()
+	prev := parent.consensusTrie.GetValidators()
+	changed := len(validators) != len(prev)
+	for i := 0; !changed && i < len(prev); i++ {
+		changed = validators[i] != prev[i]
+	}
+	if changed {
+		if err := c.setP2pValidators(validators); err != nil {
+			log.Warn("core: set p2p validators", "err", err)
+		}
+	}
+}
+
 func (c *Core) Chain() *BlockChain {
 	return c.blockChain
 }
@@ -557,6 +756,55 @@ func (c *Core) GetPrivateKeyOfDefaultAccount() ([]byte, error) {
 	return c.minerKey, nil
 }
 
+// OnValidatorsChanged handles validator rotation in engine, called from
+// engine loop, p2p is updated asynchronously as it may wait for peers
+func (c *Core) OnValidatorsChanged(validators []string) {
+	c.valsMu.Lock()
+	c.valsPending = validators
+	c.valsMu.Unlock()
+	select {
+	case c.valsCh <- struct{}{}:
+	default:
+	}
+}
+
+// apply validator sets from engine in order, skipping outdated ones
+func (c *Core) validatorLoop() {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-c.quitCh:
+			return
+		case <-c.valsCh:
+			c.valsMu.Lock()
+			validators := c.valsPending
+			c.valsPending = nil
+			c.valsMu.Unlock()
+			if validators == nil {
+				continue
+			}
+			if err := c.setP2pValidators(validators); err != nil {
+				log.Warn("core: set p2p validators", "err", err)
+			}
+		}
+	}
+}
+
+// OnEquivocation handles evidence of forked events detected by engine,
+// called from engine loop, evidence is sent back to engine asynchronously
+func (c *Core) OnEquivocation(evidence []byte) {
+	e := NewEvidence(evidence)
+	go func() {
+		if err := c.evidencePool.processEvidence(e); err != nil {
+			log.Error("core: invalid evidence from engine", "err", err)
+			return
+		}
+		if err := c.evidencePool.EvidenceBroadcast(e); err != nil {
+			log.Warn("core: evidence broadcast", "err", err)
+		}
+	}()
+}
+
 func (c *Core) AddressFromPublicKey(publicKey []byte) ([]byte, error) {
 	ad, err := address.NewAddressFromPublicKey(publicKey)
 	if err != nil {
@@ -584,88 +832,185 @@ func getSigner(algorithm crypto.Algorithm) crypto.Signer {
 	}
 }
 
-func (c *Core) GetChainData(kind string, key []byte) []byte {
+// ChainStatus answers chain status request from peers
+func (c *Core) ChainStatus() *p2p.ChainStatus {
 	c.metrics.p2pChainInfoAnswer.Mark(1)
-	switch kind {
-	case ChainDataTypeLatestH:
-		return c.blockChain.LastBlock().Hash().Bytes()
-	case ChainDataTypeLatestN:
-		h := c.blockChain.CurrentBlockHeight()
-		if h > 0 {
-			return new(big.Int).SetUint64(c.blockChain.CurrentBlockHeight()).Bytes()
-		} else {
-			a := [1]byte{0}
-			return a[:]
-		}
-	case ChainDataTypeBlockH:
-		b := c.blockPool.GetBlockByHash(common.BytesToHash(key))
-		if b != nil {
-			enc, err := b.ToBytes()
-			if err != nil {
-				log.Warn("block encode failed", "blk", b, "err", err)
-			}
-			return enc
+	return &p2p.ChainStatus{
+		Genesis: c.blockChain.genesis.Hash().Bytes(),
+		Head:    c.blockChain.LastBlock().Hash().Bytes(),
+		Height:  c.blockChain.CurrentBlockHeight(),
+	}
+}
+
+// ChainHeaders answers headers request from peers with encoded
+// SignedBlockHeader, stops at the first block not found
+func (c *Core) ChainHeaders(from uint64, count int) [][]byte {
+	c.metrics.p2pChainInfoAnswer.Mark(1)
+	items := make([][]byte, 0, count)
+	for n := from; n < from+uint64(count); n++ {
+		hash := c.blockPool.GetBlockNum2Hash(n)
+		if hash == nil {
+			break
 		}
-	case ChainDataTypeBlockN:
-		n := new(big.Int).SetBytes(key).Uint64()
-		b := c.blockPool.GetBlockByNumber(n)
-		if b != nil {
-			enc, err := b.ToBytes()
-			if err != nil {
-				log.Warn("block encode failed", "blk", b, "err", err)
-			}
-			return enc
+		header := getHeader(c.storage, *hash)
+		if header == nil {
+			break
+		}
+		enc, err := proto.Marshal(header)
+		if err != nil {
+			log.Warn("header encode failed", "H", n, "err", err)
+			break
 		}
+		items = append(items, enc)
 	}
-	return nil
+	return items
 }
 
-func (c *Core) getRemoteChainInfo(kind string, key []byte) (encoded []byte, err error) {
-	for retry := 3; retry > 0; retry-- {
-		c.metrics.p2pChainInfoGet.Mark(1)
-		encoded, err = c.node.P2pService().GetChainInfo(kind, key)
-		if err == nil {
-			c.metrics.p2pChainInfoHit.Mark(1)
-			return encoded, nil
+// ChainBlocks answers blocks request from peers, blocks not found are skipped
+func (c *Core) ChainBlocks(hashes [][]byte) [][]byte {
+	c.metrics.p2pChainInfoAnswer.Mark(1)
+	items := make([][]byte, 0, len(hashes))
+	for _, h := range hashes {
+		b := c.blockPool.GetBlockByHash(common.BytesToHash(h))
+		if b == nil {
+			continue
+		}
+		enc, err := b.ToBytes()
+		if err != nil {
+			log.Warn("block encode failed", "blk", b, "err", err)
+			continue
 		}
-		log.Warn("getRemoteChainInfo temporary failure", "kind", kind, "key", key, "err", err)
+		items = append(items, enc)
+	}
+	return items
+}
+
+// ChainTxs answers txs request from peers with txs pending or sealed, txs
+// not found are skipped
+func (c *Core) ChainTxs(hashes [][]byte) [][]byte {
+	c.metrics.p2pChainInfoAnswer.Mark(1)
+	items := make([][]byte, 0, len(hashes))
+	for _, h := range hashes {
+		hash := common.BytesToHash(h)
+		tx := c.txPool.GetTxByHash(hash)
+		if tx == nil {
+			tx = c.blockChain.GetTxByHash(hash)
+		}
+		if tx == nil {
+			continue
+		}
+		enc, err := tx.Encode()
+		if err != nil {
+			log.Warn("tx encode failed", "tx", tx, "err", err)
+			continue
+		}
+		items = append(items, enc)
+	}
+	return items
+}
+
+// ChainProof answers proof request from peers with trie nodes proving key in
+// the account or consensus trie of root, nothing if root is unknown
+func (c *Core) ChainProof(root, key []byte) [][]byte {
+	c.metrics.p2pChainInfoAnswer.Mark(1)
+	if len(root) != common.HashLength {
+		return nil
+	}
+	proof, err := state.Prove(c.blockChain.stateDB, common.BytesToHash(root), key)
+	if err != nil {
+		log.Debug("prove failed", "root", common.BytesToHash(root), "err", err)
+		return nil
 	}
-	return nil, err
+	return proof
 }
 
-func (c *Core) GetRemoteLatestHash() (*common.Hash, error) {
-	encoded, err := c.getRemoteChainInfo(ChainDataTypeLatestH, []byte(""))
+// GetRemoteStatus gets chain status of peer, or of a random peer if peer is
+// empty, the peer answered is returned in response
+func (c *Core) GetRemoteStatus(ctx context.Context, peer string) (*p2p.ChainResponse, error) {
+	c.metrics.p2pChainInfoGet.Mark(1)
+	rsp, err := c.node.P2pService().GetStatus(ctx, peer)
 	if err != nil {
 		return nil, err
 	}
-	if len(encoded) != common.HashLength {
-		return nil, errors.New(fmt.Sprintf("latest hash length mismatch, got %v", encoded))
+	if rsp.Status == nil || len(rsp.Status.Head) != common.HashLength {
+		c.reportPeer(rsp.From, p2p.PeerOffenceInvalid)
+		return nil, ErrRemoteChainData
 	}
-	return new(common.Hash).SetBytes(encoded), nil
+	c.metrics.p2pChainInfoHit.Mark(1)
+	return rsp, nil
 }
 
-func (c *Core) GetRemoteLatestNumber() (uint64, error) {
-	encoded, err := c.getRemoteChainInfo(ChainDataTypeLatestN, []byte(""))
+// GetRemoteHeaders gets headers of consecutive blocks from peer, from block
+// number from, less than count headers are returned if peer has not so many
+func (c *Core) GetRemoteHeaders(ctx context.Context, peer string, from uint64, count int) ([]*BlockHeader, []common.Hash, error) {
+	c.metrics.p2pChainInfoGet.Mark(1)
+	rsp, err := c.node.P2pService().GetHeaders(ctx, peer, from, count)
 	if err != nil {
-		return 0, err
+		return nil, nil, err
+	}
+	if len(rsp.Items) > count {
+		c.reportPeer(rsp.From, p2p.PeerOffenceInvalid)
+		return nil, nil, ErrRemoteChainData
+	}
+	headers := make([]*BlockHeader, 0, len(rsp.Items))
+	hashes := make([]common.Hash, 0, len(rsp.Items))
+	for i, item := range rsp.Items {
+		signed := new(corepb.SignedBlockHeader)
+		header := new(BlockHeader)
+		if err := proto.Unmarshal(item, signed); err != nil {
+			c.reportPeer(rsp.From, p2p.PeerOffenceUndecodable)
+			return nil, nil, err
+		}
+		if err := rlp.DecodeBytes(signed.Header, header); err != nil {
+			c.reportPeer(rsp.From, p2p.PeerOffenceUndecodable)
+			return nil, nil, err
+		}
+		if header.Number != from+uint64(i) {
+			c.reportPeer(rsp.From, p2p.PeerOffenceInvalid)
+			return nil, nil, ErrRemoteChainData
+		}
+		headers = append(headers, header)
+		hashes = append(hashes, common.BytesToHash(sha3.Sha3256(signed.Header)))
 	}
-	n := new(big.Int).SetBytes(encoded).Uint64()
-	return n, nil
+	c.metrics.p2pChainInfoHit.Mark(1)
+	return headers, hashes, nil
 }
 
-func (c *Core) GetRemoteBlockByHash(hash common.Hash) (*Block, error) {
-	encoded, err := c.getRemoteChainInfo(ChainDataTypeBlockH, hash[:])
+// GetRemoteBlocks gets blocks of hashes from peer, each block returned is
+// checked against the hash asked, in the same order
+func (c *Core) GetRemoteBlocks(ctx context.Context, peer string, hashes []common.Hash) ([]*Block, error) {
+	c.metrics.p2pChainInfoGet.Mark(1)
+	keys := make([][]byte, 0, len(hashes))
+	for _, h := range hashes {
+		keys = append(keys, h.Bytes())
+	}
+	rsp, err := c.node.P2pService().GetBlocks(ctx, peer, keys)
 	if err != nil {
 		return nil, err
 	}
-	return ParseBlock(encoded)
+	if len(rsp.Items) > len(hashes) {
+		c.reportPeer(rsp.From, p2p.PeerOffenceInvalid)
+		return nil, ErrRemoteChainData
+	}
+	blocks := make([]*Block, 0, len(rsp.Items))
+	for i, item := range rsp.Items {
+		b, err := ParseBlock(item)
+		if err != nil {
+			c.reportPeer(rsp.From, p2p.PeerOffenceUndecodable)
+			return nil, err
+		}
+		if b.Hash() != hashes[i] {
+			c.reportPeer(rsp.From, p2p.PeerOffenceInvalid)
+			return nil, ErrRemoteChainData
+		}
+		blocks = append(blocks, b)
+	}
+	c.metrics.p2pChainInfoHit.Mark(1)
+	return blocks, nil
 }
 
-func (c *Core) GetRemoteBlockByNumber(n uint64) (*Block, error) {
-	key := new(big.Int).SetUint64(n).Bytes()
-	encoded, err := c.getRemoteChainInfo(ChainDataTypeBlockN, key)
-	if err != nil {
-		return nil, err
+func (c *Core) reportPeer(peer string, offence string) {
+	if err := c.node.P2pService().ReportPeer(peer, offence, p2p.PeerSeverityMajor); err != nil {
+		log.Warn("failed to report bad peer", "from", peer, "err", err)
 	}
-	return ParseBlock(encoded)
 }
@@ -19,3 +19,267 @@
  */
 
 package core
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/accounts"
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/config"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+	"github.com/yeeco/gyee/p2p"
+	"github.com/yeeco/gyee/persistent"
+)
+
+func TestEngineName(t *testing.T) {
+	for _, c := range []struct {
+		conf, name string
+	}{
+		{"", EngineTetris2},
+		{EngineTetris, EngineTetris2},
+		{EngineTetris2, EngineTetris2},
+		{EngineTetris1, EngineTetris1},
+		{EngineDev, EngineDev},
+	} {
+		core := &Core{config: &config.Config{Chain: &config.ChainConfig{Engine: c.conf}}}
+		if name := core.EngineName(); name != c.name {
+			t.Errorf("engine %q: got %q want %q", c.conf, name, c.name)
+		}
+	}
+}
+
+// p2p service blocking in SetValidators until released
+type blockingP2p struct {
+	p2p.Service
+	release chan struct{}
+	applied chan []string
+}
+
+func (s *blockingP2p) SetValidators(validators []string, self string, sign func(hash []byte) ([]byte, error)) error {
+	<-s.release
+	s.applied <- validators
+	return nil
+}
+
+type testNode struct {
+	p2p p2p.Service
+}
+
+func (n *testNode) NodeID() string                           { return "test" }
+func (n *testNode) AccountManager() *accounts.AccountManager { return nil }
+func (n *testNode) Core() *Core                              { return nil }
+func (n *testNode) P2pService() p2p.Service                  { return n.p2p }
+
+func TestOnValidatorsChangedAsync(t *testing.T) {
+	service := &blockingP2p{
+		release: make(chan struct{}),
+		applied: make(chan []string, 4),
+	}
+	c := &Core{
+		node:   &testNode{p2p: service},
+		valsCh: make(chan struct{}, 1),
+		quitCh: make(chan struct{}),
+	}
+	c.wg.Add(1)
+	go c.validatorLoop()
+	defer func() {
+		close(c.quitCh)
+		c.wg.Wait()
+	}()
+
+	sets := [][]string{{"a"}, {"a", "b"}, {"a", "b", "c"}}
+	done := make(chan struct{})
+	go func() {
+		c.OnValidatorsChanged(sets[0])
+		// wait for loop to take first set, blocking in p2p
+		for {
+			c.valsMu.Lock()
+			taken := c.valsPending == nil
+			c.valsMu.Unlock()
+			if taken {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		c.OnValidatorsChanged(sets[1])
+		c.OnValidatorsChanged(sets[2])
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("OnValidatorsChanged blocked by p2p")
+	}
+
+	// outdated set skipped, latest applied after the first
+	close(service.release)
+	for _, want := range [][]string{sets[0], sets[2]} {
+		select {
+		case got := <-service.applied:
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("applied %v want %v", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("validators %v not applied", want)
+		}
+	}
+	select {
+	case got := <-service.applied:
+		t.Errorf("unexpected validators applied %v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// p2p service recording messages broadcast
+type broadcastP2p struct {
+	p2p.Service
+	sent chan p2p.Message
+}
+
+func (s *broadcastP2p) BroadcastMessage(message p2p.Message) error {
+	s.sent <- message
+	return nil
+}
+
+func TestTxBroadcastLocal(t *testing.T) {
+	signer := secp256k1.NewSecp256k1Signer()
+	if err := signer.InitSigner(secp256k1.GenerateKey().PrivateKey()); err != nil {
+		t.Fatalf("InitSigner() %v", err)
+	}
+	for _, engine := range []string{EngineTetris2, EngineDev} {
+		service := &broadcastP2p{sent: make(chan p2p.Message, 1)}
+		c := &Core{
+			config:  &config.Config{Chain: &config.ChainConfig{Engine: engine}},
+			node:    &testNode{p2p: service},
+			metrics: newCoreMetrics(),
+		}
+		tp, _ := NewTransactionPool(c)
+		to := common.BytesToAddress([]byte("to"))
+		tx := NewTransaction(1, 0, &to, big.NewInt(1))
+		if err := tx.Sign(signer); err != nil {
+			t.Fatalf("Sign() %v", err)
+		}
+		if err := tp.TxBroadcast(tx); err != nil {
+			t.Fatalf("%s: TxBroadcast() %v", engine, err)
+		}
+		select {
+		case msg := <-service.sent:
+			if msg.MsgType != p2p.MessageTypeTx {
+				t.Errorf("%s: broadcast message type %s", engine, msg.MsgType)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: tx not broadcast", engine)
+		}
+		// only the dev engine has no peers to get the tx from
+		if local := len(tp.localCh) == 1; local != (engine == EngineDev) {
+			t.Errorf("%s: tx fed to local pool %v", engine, local)
+		}
+	}
+}
+
+// p2p service answering chain requests of sync from a fixed peer, recording
+// offences reported above minor severity
+type syncP2p struct {
+	p2p.Service
+	status  *p2p.ChainStatus
+	headers [][]byte
+	blocks  [][]byte
+	reports []string
+}
+
+func (s *syncP2p) GetStatus(ctx context.Context, peer string) (*p2p.ChainResponse, error) {
+	return &p2p.ChainResponse{From: "peer", Status: s.status}, nil
+}
+
+func (s *syncP2p) GetHeaders(ctx context.Context, peer string, from uint64, count int) (*p2p.ChainResponse, error) {
+	return &p2p.ChainResponse{From: peer, Items: s.headers[from-1:]}, nil
+}
+
+func (s *syncP2p) GetBlocks(ctx context.Context, peer string, hashes [][]byte) (*p2p.ChainResponse, error) {
+	return &p2p.ChainResponse{From: peer, Items: s.blocks}, nil
+}
+
+func (s *syncP2p) ReportPeer(nodeID string, offence string, severity int) error {
+	if severity > p2p.PeerSeverityMinor {
+		s.reports = append(s.reports, nodeID+":"+offence)
+	}
+	return nil
+}
+
+func TestSyncRemoteBlocksMismatch(t *testing.T) {
+	remote, err := NewBlockChain(TestNetID, persistent.NewMemoryStorage(), nil)
+	if err != nil {
+		t.Fatalf("NewBlockChain() %v", err)
+	}
+	defer remote.Stop()
+	var blocks [3]*Block
+	for i := 1; i <= 2; i++ {
+		if blocks[i], err = remote.BuildNextBlock(remote.LastBlock(), uint64(i), nil, nil); err != nil {
+			t.Fatalf("BuildNextBlock() %v", err)
+		}
+		if err := remote.AddBlock(blocks[i]); err != nil {
+			t.Fatalf("AddBlock() %v", err)
+		}
+	}
+	sibling, err := remote.BuildNextBlock(blocks[1], 100, nil, nil)
+	if err != nil {
+		t.Fatalf("BuildNextBlock() %v", err)
+	}
+	encode := func(bs ...*Block) [][]byte {
+		items := make([][]byte, 0, len(bs))
+		for _, b := range bs {
+			enc, err := b.ToBytes()
+			if err != nil {
+				t.Fatalf("ToBytes() %v", err)
+			}
+			items = append(items, enc)
+		}
+		return items
+	}
+	var headers [][]byte
+	for _, b := range blocks[1:] {
+		enc, err := b.HeaderBytes()
+		if err != nil {
+			t.Fatalf("HeaderBytes() %v", err)
+		}
+		headers = append(headers, enc)
+	}
+
+	for name, items := range map[string][][]byte{
+		"out of order": encode(blocks[2], blocks[1]),
+		"substituted":  encode(blocks[1], sibling),
+		"missing":      encode(blocks[2]),
+	} {
+		local, err := NewBlockChain(TestNetID, persistent.NewMemoryStorage(), nil)
+		if err != nil {
+			t.Fatalf("NewBlockChain() %v", err)
+		}
+		service := &syncP2p{
+			status: &p2p.ChainStatus{
+				Genesis: local.genesis.Hash().Bytes(),
+				Head:    blocks[2].Hash().Bytes(),
+				Height:  2,
+			},
+			headers: headers,
+			blocks:  items,
+		}
+		c := &Core{
+			node:       &testNode{p2p: service},
+			blockChain: local,
+			metrics:    newCoreMetrics(),
+		}
+		bp, _ := NewBlockPool(c)
+		bp.syncLoop()
+		if len(service.reports) != 1 || service.reports[0] != "peer:"+p2p.PeerOffenceInvalid {
+			t.Errorf("%s: reports %v", name, service.reports)
+		}
+		if h := local.CurrentBlockHeight(); h != 0 {
+			t.Errorf("%s: blocks processed to height %d", name, h)
+		}
+		local.Stop()
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/consensus/tetris2"
+	"github.com/yeeco/gyee/core/state"
+	sha3 "github.com/yeeco/gyee/crypto/hash"
+	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/p2p/dht"
+	"github.com/yeeco/gyee/persistent"
+)
+
+// Equivocation evidence
+//   reported by the consensus engine when a validator signed two different
+//   events of the same sequence number, gossiped as p2p.MessageTypeEvidence,
+//   ordered by the engine like txs, then put in the evidence section of
+//   block body. Applying it on chain jails the validator: it is removed from
+//   validator set at activation height and can not join again.
+//   Stake is not slashed as the chain has no staking yet, balances are left
+//   untouched.
+
+// evidence value put into DHT is prefixed, so that engine output hashes can
+// be told from txs when fetched. 0xee is an invalid protobuf tag, never the
+// first byte of an encoded tx.
+var evidenceDhtPrefix = []byte{0xee, 'e', 'v', 'i'}
+
+// the prefixed value is not content addressed, so it's put in the evidence
+// namespace of DHT, whose validator checks the key against the unprefixed
+var evidenceDhtExtra = []byte{dht.RecNsEvidence}
+
+var (
+	ErrEvidenceNotValidator = errors.New("evidence on non-validator")
+	ErrEvidenceDhtValue     = errors.New("not an evidence dht value")
+)
+
+func init() {
+	dht.RegisterRecordValidator(dht.RecNsEvidence, evidenceRecordValidator{})
+}
+
+// evidenceRecordValidator accepts evidence DHT record keyed by its hash
+type evidenceRecordValidator struct{}
+
+func (evidenceRecordValidator) Validate(key []byte, val []byte, extra []byte) error {
+	if !bytes.Equal(extra, evidenceDhtExtra) {
+		return dht.ErrRecExtra
+	}
+	e := evidenceFromDhtValue(val)
+	if e == nil {
+		return ErrEvidenceDhtValue
+	}
+	if h := e.Hash(); !bytes.Equal(key, h[:]) {
+		return dht.ErrRecHash
+	}
+	return nil
+}
+
+type Evidence struct {
+	raw []byte
+
+	// caches
+	hash      *common.Hash
+	validator string // offending validator, set after verify
+	n         uint64 // forked sequence number, set after verify
+}
+
+func NewEvidence(raw []byte) *Evidence {
+	return &Evidence{raw: common.CopyBytes(raw)}
+}
+
+func (e *Evidence) Hash() common.Hash {
+	if e.hash == nil {
+		e.hash = new(common.Hash).SetBytes(sha3.Sha3256(e.raw))
+	}
+	return *e.hash
+}
+
+func (e *Evidence) Raw() []byte {
+	return e.raw
+}
+
+// Validator returns the offending validator, valid after verified
+func (e *Evidence) Validator() string {
+	return e.validator
+}
+
+func (e *Evidence) String() string {
+	return "evidence{" + e.Hash().Hex() + " " + e.validator + "}"
+}
+
+// verify evidence signatures, cached once succeeded
+func (e *Evidence) verify() error {
+	if len(e.validator) > 0 {
+		return nil
+	}
+	ev := new(tetris2.Evidence)
+	if err := ev.Unmarshal(e.raw); err != nil {
+		return err
+	}
+	signer := getSigner(ev.First.Signature.Algorithm)
+	if signer == nil {
+		return ErrNoSigner
+	}
+	if err := ev.Verify(signer); err != nil {
+		return err
+	}
+	e.validator = ev.Vid()
+	e.n = ev.N()
+	return nil
+}
+
+func (e *Evidence) dhtValue() []byte {
+	return append(common.CopyBytes(evidenceDhtPrefix), e.raw...)
+}
+
+// parse evidence from DHT value, nil if value is not an evidence
+func evidenceFromDhtValue(value []byte) *Evidence {
+	if !bytes.HasPrefix(value, evidenceDhtPrefix) {
+		return nil
+	}
+	return NewEvidence(value[len(evidenceDhtPrefix):])
+}
+
+type Evidences []*Evidence
+
+func (evs Evidences) Write(putter persistent.Putter) error {
+	for _, e := range evs {
+		putEvidence(putter, e.Hash(), e.raw)
+	}
+	return nil
+}
+
+func isJailed(records []*state.JailRecord, validator string) bool {
+	for _, r := range records {
+		if r.Validator == validator {
+			return true
+		}
+	}
+	return false
+}
+
+// apply a verified evidence in block of height to consensus trie
+func applyEvidence(ct state.ConsensusTrie, height uint64, e *Evidence) error {
+	if err := e.verify(); err != nil {
+		return err
+	}
+	var (
+		validators = ct.GetValidators()
+		jailed     = ct.GetJailed()
+		changes    = ct.GetValidatorChanges()
+		target     = e.validator
+	)
+	if !containsStr(validators, target) {
+		return ErrEvidenceNotValidator
+	}
+	if isJailed(jailed, target) {
+		return ErrValidatorJailed
+	}
+	quitting := 0
+	scheduled := false
+	for _, change := range changes {
+		quitting += len(change.Quits)
+		if containsStr(change.Quits, target) {
+			scheduled = true
+		}
+	}
+	if !scheduled && len(validators)-quitting <= 1 {
+		return ErrValidatorLastOne
+	}
+
+	jailed = append(jailed, &state.JailRecord{
+		Validator: target,
+		Height:    height,
+		Evidence:  e.Hash(),
+	})
+	ct.SetJailed(jailed)
+
+	// drop requests on the jailed validator
+	proposals := ct.GetValidatorProposals()
+	remain := make([]*state.ValidatorProposal, 0, len(proposals))
+	for _, p := range proposals {
+		if p.Target != target {
+			remain = append(remain, p)
+		}
+	}
+	ct.SetValidatorProposals(remain)
+
+	// remove from validator set at activation height, as other changes
+	if !scheduled {
+		activation := height + ValidatorActivationDelay
+		var change *state.ValidatorChange
+		for _, c := range changes {
+			if c.Height == activation {
+				change = c
+				break
+			}
+		}
+		if change == nil {
+			change = &state.ValidatorChange{Height: activation}
+			changes = append(changes, change)
+		}
+		change.Quits = append(change.Quits, target)
+		ct.SetValidatorChanges(changes)
+	}
+	// TODO: slash stake of the validator once staking exists
+	return nil
+}
+
+// replay evidences of block at height on consensus trie, returns the applied ones
+func (bc *BlockChain) replayEvidences(consensusTrie state.ConsensusTrie, height uint64, evs Evidences) Evidences {
+	inBlock := make(Evidences, 0, len(evs))
+	for _, e := range evs {
+		if err := applyEvidence(consensusTrie, height, e); err != nil {
+			log.Info("evidence rejected", "evidence", e, "err", err)
+			continue
+		}
+		log.Warn("validator jailed", "validator", e.validator, "height", height, "evidence", e.Hash())
+		inBlock = append(inBlock, e)
+	}
+	return inBlock
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"sync"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/p2p"
+)
+
+// EvidencePool receives equivocation evidences from engine and peers,
+// and hands them to engine for ordering as txs
+type EvidencePool struct {
+	core       *Core
+	subscriber *p2p.Subscriber
+
+	// pending evidence pool
+	pendingPool map[common.Hash]*Evidence
+	pendingLock sync.RWMutex
+
+	lock   sync.RWMutex
+	quitCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewEvidencePool(core *Core) (*EvidencePool, error) {
+	log.Info("Create New EvidencePool")
+	ep := &EvidencePool{
+		core:        core,
+		pendingPool: make(map[common.Hash]*Evidence),
+		quitCh:      make(chan struct{}),
+	}
+	return ep, nil
+}
+
+func (ep *EvidencePool) Start() {
+	ep.lock.Lock()
+	defer ep.lock.Unlock()
+	log.Info("EvidencePool Start...")
+
+	ep.subscriber = p2p.NewSubscriber(ep, make(chan p2p.Message), p2p.MessageTypeEvidence)
+	ep.core.node.P2pService().Register(ep.subscriber)
+
+	go ep.loop()
+}
+
+func (ep *EvidencePool) Stop() {
+	ep.lock.Lock()
+	defer ep.lock.Unlock()
+	log.Info("EvidencePool Stop...")
+
+	ep.core.node.P2pService().UnRegister(ep.subscriber)
+
+	close(ep.quitCh)
+	ep.wg.Wait()
+}
+
+func (ep *EvidencePool) loop() {
+	log.Trace("EvidencePool loop...")
+	ep.wg.Add(1)
+	defer ep.wg.Done()
+
+	for {
+		select {
+		case <-ep.quitCh:
+			log.Info("EvidencePool loop end.")
+			return
+		case msg := <-ep.subscriber.MsgChan:
+			ep.processMsg(msg)
+		}
+	}
+}
+
+func (ep *EvidencePool) processMsg(msg p2p.Message) {
+	ep.core.metrics.p2pMsgRecv.Mark(1)
+	switch msg.MsgType {
+	case p2p.MessageTypeEvidence:
+		if err := ep.processEvidence(NewEvidence(msg.Data)); err != nil {
+			ep.markBadPeer(msg, p2p.PeerOffenceBadSignature)
+		}
+	default:
+		log.Crit("unhandled msg sent to evidencePool", "msg", msg)
+	}
+}
+
+// processEvidence returns error only if evidence fails to be verified
+func (ep *EvidencePool) processEvidence(e *Evidence) error {
+	if err := e.verify(); err != nil {
+		log.Warn("processEvidence() verify fails", "err", err)
+		return err
+	}
+	hash := e.Hash()
+
+	ep.pendingLock.Lock()
+	_, pending := ep.pendingPool[hash]
+	if !pending {
+		ep.pendingPool[hash] = e
+	}
+	ep.pendingLock.Unlock()
+	if pending {
+		return nil
+	}
+
+	// already on chain, or validator jailed by another evidence
+	if hasEvidence(ep.core.storage, hash) {
+		return nil
+	}
+	if isJailed(ep.core.blockChain.LastBlock().consensusTrie.GetJailed(), e.validator) {
+		return nil
+	}
+	log.Warn("equivocation evidence", "validator", e.validator, "n", e.n, "hash", hash)
+
+	// put evidence to DHT, fetched by peers on engine output
+	_ = ep.core.node.P2pService().DhtSetRecord(hash[:], e.dhtValue(), evidenceDhtExtra)
+
+	// send evidence to consensus
+	if ep.core.engine != nil {
+		ep.core.engine.SendTx(hash)
+	}
+	return nil
+}
+
+func (ep *EvidencePool) EvidenceBroadcast(e *Evidence) error {
+	log.Debug("EvidenceBroadcast", "validator", e.validator, "hash", e.Hash())
+	go func(msg p2p.Message) {
+		ep.core.metrics.p2pMsgSent.Mark(1)
+		if err := ep.core.node.P2pService().BroadcastMessage(msg); err != nil {
+			log.Error("EvidenceBroadcast", "err", err)
+			ep.core.metrics.p2pMsgSendFail.Mark(1)
+		}
+	}(p2p.Message{
+		MsgType: p2p.MessageTypeEvidence,
+		From:    ep.core.node.NodeID(),
+		Data:    e.raw,
+	})
+	return nil
+}
+
+// GetEvidenceByHash returns evidence pending in pool, nil if not found
+func (ep *EvidencePool) GetEvidenceByHash(hash common.Hash) *Evidence {
+	ep.pendingLock.RLock()
+	defer ep.pendingLock.RUnlock()
+	return ep.pendingPool[hash]
+}
+
+// markBadPeer reports msg.From at minor severity, as gossip is forwarded
+// by p2p before core validated it and msg.From may only have relayed it.
+func (ep *EvidencePool) markBadPeer(msg p2p.Message, offence string) {
+	if err := ep.core.node.P2pService().ReportPeer(msg.From, offence, p2p.PeerSeverityMinor); err != nil {
+		log.Warn("failed to report bad peer", "from", msg.From, "err", err)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/consensus/tetris2"
+	"github.com/yeeco/gyee/core/state"
+	sha3 "github.com/yeeco/gyee/crypto/hash"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+	"github.com/yeeco/gyee/p2p/dht"
+	"github.com/yeeco/gyee/persistent"
+)
+
+func testSignedEvent(t *testing.T, key []byte, body *tetris2.EventBody) *tetris2.EventMessage {
+	signer := secp256k1.NewSecp256k1Signer()
+	if err := signer.InitSigner(key); err != nil {
+		t.Fatalf("InitSigner() %v", err)
+	}
+	h := body.Hash()
+	sig, err := signer.Sign(h[:])
+	if err != nil {
+		t.Fatalf("Sign() %v", err)
+	}
+	return &tetris2.EventMessage{Body: body, Signature: sig}
+}
+
+func TestEvidenceJail(t *testing.T) {
+	key := secp256k1.GenerateKey()
+	addr, err := address.NewAddressFromPublicKey(key.PublicKey())
+	if err != nil {
+		t.Fatalf("NewAddressFromPublicKey() %v", err)
+	}
+	offender := addr.String()
+
+	// same sequence number, different bodies
+	first := testSignedEvent(t, key.PrivateKey(), &tetris2.EventBody{H: 5, N: 7, T: 1})
+	second := testSignedEvent(t, key.PrivateKey(), &tetris2.EventBody{H: 5, N: 7, T: 2})
+	e := NewEvidence((&tetris2.Evidence{First: first, Second: second}).Marshal())
+	if err := e.verify(); err != nil {
+		t.Fatalf("verify() %v", err)
+	}
+	if e.Validator() != offender {
+		t.Fatalf("offender mismatch %v %v", e.Validator(), offender)
+	}
+
+	// not forked
+	same := NewEvidence((&tetris2.Evidence{First: first, Second: first}).Marshal())
+	if err := same.verify(); err != tetris2.ErrEvidenceNotFork {
+		t.Fatalf("same event %v", err)
+	}
+	// signed by another validator
+	other := testSignedEvent(t, secp256k1.GenerateKey().PrivateKey(), &tetris2.EventBody{H: 5, N: 7, T: 3})
+	mixed := NewEvidence((&tetris2.Evidence{First: first, Second: other}).Marshal())
+	if err := mixed.verify(); err != tetris2.ErrEvidenceSigner {
+		t.Fatalf("mixed signers %v", err)
+	}
+
+	// survives dht encoding
+	if decoded := evidenceFromDhtValue(e.dhtValue()); decoded == nil || decoded.Hash() != e.Hash() {
+		t.Fatalf("dht value decode failed")
+	}
+	if evidenceFromDhtValue([]byte("tx bytes")) != nil {
+		t.Fatalf("tx decoded as evidence")
+	}
+
+	ct, err := state.NewConsensusTrie(common.EmptyHash, GetStateDB(persistent.NewMemoryStorage()))
+	if err != nil {
+		t.Fatalf("NewConsensusTrie() %v", err)
+	}
+	others := []string{validatorStr(testValidatorAddr(0)), validatorStr(testValidatorAddr(1))}
+	ct.SetValidators(append([]string{offender}, others...))
+
+	if err := applyEvidence(ct, 20, e); err != nil {
+		t.Fatalf("applyEvidence() %v", err)
+	}
+	if err := applyEvidence(ct, 21, e); err != ErrValidatorJailed {
+		t.Fatalf("jailed twice %v", err)
+	}
+	jailed := ct.GetJailed()
+	if len(jailed) != 1 || jailed[0].Validator != offender ||
+		jailed[0].Height != 20 || jailed[0].Evidence != e.Hash() {
+		t.Fatalf("unexpected jail records %v", jailed)
+	}
+	if !activateValidatorChanges(ct, 20+ValidatorActivationDelay) {
+		t.Fatalf("jailed validator not removed")
+	}
+	if validators := ct.GetValidators(); len(validators) != 2 || containsStr(validators, offender) {
+		t.Fatalf("unexpected validators %v", validators)
+	}
+
+	// jailed validator can not join again
+	join := NewValidatorTransaction(uint32(TestNetID), 0, TxTypeValidatorJoin, addr.CommonAddress())
+	if err := applyValidatorTx(ct, 40, *addr.CommonAddress(), join); err != ErrValidatorJailed {
+		t.Fatalf("jailed join %v", err)
+	}
+}
+
+// evidence record passes the validator applied by OSN peers
+func TestEvidenceDhtRecord(t *testing.T) {
+	key := secp256k1.GenerateKey()
+	first := testSignedEvent(t, key.PrivateKey(), &tetris2.EventBody{H: 5, N: 7, T: 1})
+	second := testSignedEvent(t, key.PrivateKey(), &tetris2.EventBody{H: 5, N: 7, T: 2})
+	e := NewEvidence((&tetris2.Evidence{First: first, Second: second}).Marshal())
+	hash := e.Hash()
+
+	if err := dht.ValidateRecord(hash[:], e.dhtValue(), evidenceDhtExtra); err != nil {
+		t.Fatalf("evidence record rejected %v", err)
+	}
+	// prefixed value is not content addressed
+	if err := dht.ValidateRecord(hash[:], e.dhtValue(), nil); err != dht.ErrRecHash {
+		t.Fatalf("content namespace got %v", err)
+	}
+	other := sha3.Sha3256(e.dhtValue())
+	if err := dht.ValidateRecord(other, e.dhtValue(), evidenceDhtExtra); err != dht.ErrRecHash {
+		t.Fatalf("wrong key got %v", err)
+	}
+	if err := dht.ValidateRecord(hash[:], e.raw, evidenceDhtExtra); err != ErrEvidenceDhtValue {
+		t.Fatalf("unprefixed value got %v", err)
+	}
+}
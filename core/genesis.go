@@ -43,6 +43,11 @@ type Genesis struct {
 	Consensus struct {
 		Tetris struct {
 			Validators []string
+			// event timing, durations in milliseconds, 0 for default
+			MaxTxPerEvent     uint64
+			MaxTxDelay        uint64
+			MinPeriodForEvent uint64
+			MaxPeriodForEvent uint64
 		}
 	}
 	InitYeeDist []InitYeeDist
@@ -88,7 +93,20 @@ func loadGenesis(id ChainID, fn string) (*Genesis, error) {
 	return genesis, nil
 }
 
+func (g *Genesis) tetrisParams() *state.TetrisParams {
+	t := &g.Consensus.Tetris
+	return &state.TetrisParams{
+		MaxTxPerEvent:     t.MaxTxPerEvent,
+		MaxTxDelay:        t.MaxTxDelay,
+		MinPeriodForEvent: t.MinPeriodForEvent,
+		MaxPeriodForEvent: t.MaxPeriodForEvent,
+	}
+}
+
 func (g *Genesis) genBlock(stateDB state.Database) (*Block, error) {
+	if _, err := tetrisConfig(g.tetrisParams(), nil); err != nil {
+		return nil, err
+	}
 	if stateDB == nil {
 		// mem storage needs no cache in state.Database
 		stateDB = state.NewDatabase(persistent.NewMemoryStorage())
@@ -114,6 +132,9 @@ func (g *Genesis) genBlock(stateDB state.Database) (*Block, error) {
 		return nil, err
 	}
 	consensusTrie.SetValidators(g.Consensus.Tetris.Validators)
+	if params := g.tetrisParams(); !params.IsZero() {
+		consensusTrie.SetTetrisParams(params)
+	}
 	h := &BlockHeader{
 		ChainID: uint32(g.ChainID),
 	}
@@ -126,6 +147,12 @@ func (g *Genesis) genBlock(stateDB state.Database) (*Block, error) {
 	return b, nil
 }
 
+// Block returns the genesis block with tries in memory, light nodes trust
+// the header and validators of it without storing the state
+func (g *Genesis) Block() (*Block, error) {
+	return g.genBlock(nil)
+}
+
 // commit genesis to stateDB, assuming service not started, no lock required
 func (g *Genesis) Commit(stateDB state.Database, putter persistent.Putter) (*Block, error) {
 	b, err := g.genBlock(stateDB)
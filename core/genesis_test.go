@@ -30,6 +30,8 @@ import (
 
 	"github.com/yeeco/gyee/common"
 	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/config"
+	"github.com/yeeco/gyee/consensus/tetris2"
 	"github.com/yeeco/gyee/core/state"
 	"github.com/yeeco/gyee/log"
 	"github.com/yeeco/gyee/persistent"
@@ -135,3 +137,48 @@ func TestGenesisStateTrie(t *testing.T) {
 	}
 	log.Info("done")
 }
+
+func TestGenesisTetrisParams(t *testing.T) {
+	genesis, err := NewGenesis(TestNetID, nil, []string{validatorStr(testValidatorAddr(0))})
+	if err != nil {
+		t.Fatalf("NewGenesis() %v", err)
+	}
+	// sub-second events
+	genesis.Consensus.Tetris.MaxTxDelay = 200
+	genesis.Consensus.Tetris.MinPeriodForEvent = 100
+	block, err := genesis.genBlock(nil)
+	if err != nil {
+		t.Fatalf("genBlock() %v", err)
+	}
+	params := block.consensusTrie.GetTetrisParams()
+	if params == nil || params.MaxTxDelay != 200 || params.MinPeriodForEvent != 100 || params.MaxTxPerEvent != 0 {
+		t.Fatalf("unexpected params %v", params)
+	}
+	cfg, err := tetrisConfig(params, nil)
+	if err != nil {
+		t.Fatalf("tetrisConfig() %v", err)
+	}
+	if cfg.MaxTxDelay != 200*time.Millisecond || cfg.Tick != cfg.MaxTxDelay || cfg.MaxTxPerEvent != 2000 {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+	// local tick must not exceed tx delay
+	if _, err := tetrisConfig(params, &config.TetrisConfig{Tick: 500}); err != tetris2.ErrConfigTick {
+		t.Fatalf("slow tick %v", err)
+	}
+
+	// rejected at load
+	genesis.Consensus.Tetris.MinPeriodForEvent = 300
+	if _, err := genesis.genBlock(nil); err != tetris2.ErrConfigPeriod {
+		t.Fatalf("min period above tx delay %v", err)
+	}
+
+	// defaults are not stored
+	genesis.Consensus.Tetris.MaxTxDelay = 0
+	genesis.Consensus.Tetris.MinPeriodForEvent = 0
+	if block, err = genesis.genBlock(nil); err != nil {
+		t.Fatalf("genBlock() %v", err)
+	}
+	if params := block.consensusTrie.GetTetrisParams(); params != nil {
+		t.Fatalf("default params stored %v", params)
+	}
+}
@@ -32,7 +32,7 @@ func (m *Account) Reset()         { *m = Account{} }
 func (m *Account) String() string { return proto.CompactTextString(m) }
 func (*Account) ProtoMessage()    {}
 func (*Account) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_dc06e4ac52b7100d, []int{0}
+	return fileDescriptor_block_0de6c224f0379d36, []int{0}
 }
 func (m *Account) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Account.Unmarshal(m, b)
@@ -85,7 +85,7 @@ func (m *Signature) Reset()         { *m = Signature{} }
 func (m *Signature) String() string { return proto.CompactTextString(m) }
 func (*Signature) ProtoMessage()    {}
 func (*Signature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_dc06e4ac52b7100d, []int{1}
+	return fileDescriptor_block_0de6c224f0379d36, []int{1}
 }
 func (m *Signature) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Signature.Unmarshal(m, b)
@@ -135,6 +135,14 @@ type Transaction struct {
 	Recipient []byte `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	// transaction amount
 	Amount []byte `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// transaction type, 0 for plain transfer
+	Type uint32 `protobuf:"varint,5,opt,name=type,proto3" json:"type,omitempty"`
+	// tx not included in blocks before, 0 for no limit
+	// block height if below 500000000000, or block time in milli seconds
+	ValidAfter uint64 `protobuf:"varint,6,opt,name=validAfter,proto3" json:"validAfter,omitempty"`
+	// tx not included in blocks after, dropped as expired, 0 for no limit
+	// block height or block time as validAfter
+	ValidUntil uint64 `protobuf:"varint,7,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
 	// signature with LAST MESSAGE TAG of one byte
 	Signature            *Signature `protobuf:"bytes,15,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -146,7 +154,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_dc06e4ac52b7100d, []int{2}
+	return fileDescriptor_block_0de6c224f0379d36, []int{2}
 }
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Transaction.Unmarshal(m, b)
@@ -194,6 +202,27 @@ func (m *Transaction) GetAmount() []byte {
 	return nil
 }
 
+func (m *Transaction) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *Transaction) GetValidAfter() uint64 {
+	if m != nil {
+		return m.ValidAfter
+	}
+	return 0
+}
+
+func (m *Transaction) GetValidUntil() uint64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
 func (m *Transaction) GetSignature() *Signature {
 	if m != nil {
 		return m.Signature
@@ -221,7 +250,7 @@ func (m *SignedBlockHeader) Reset()         { *m = SignedBlockHeader{} }
 func (m *SignedBlockHeader) String() string { return proto.CompactTextString(m) }
 func (*SignedBlockHeader) ProtoMessage()    {}
 func (*SignedBlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_dc06e4ac52b7100d, []int{3}
+	return fileDescriptor_block_0de6c224f0379d36, []int{3}
 }
 func (m *SignedBlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedBlockHeader.Unmarshal(m, b)
@@ -266,7 +295,13 @@ func (m *SignedBlockHeader) GetSignatures() []*Signature {
 //   block body = block - header
 type BlockBody struct {
 	// encoded transaction bytes
-	RawTransactions      [][]byte `protobuf:"bytes,1,rep,name=raw_transactions,json=rawTransactions,proto3" json:"raw_transactions,omitempty"`
+	RawTransactions [][]byte `protobuf:"bytes,1,rep,name=raw_transactions,json=rawTransactions,proto3" json:"raw_transactions,omitempty"`
+	// encoded evidences of validator misbehaviour
+	Evidences [][]byte `protobuf:"bytes,2,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	// encoded receipts of txs dropped from the block as expired
+	Receipts [][]byte `protobuf:"bytes,3,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	// encoded txs dropped from the block as expired, receipts replayed from
+	ExpiredTransactions  [][]byte `protobuf:"bytes,4,rep,name=expired_transactions,json=expiredTransactions,proto3" json:"expired_transactions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -276,7 +311,7 @@ func (m *BlockBody) Reset()         { *m = BlockBody{} }
 func (m *BlockBody) String() string { return proto.CompactTextString(m) }
 func (*BlockBody) ProtoMessage()    {}
 func (*BlockBody) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_dc06e4ac52b7100d, []int{4}
+	return fileDescriptor_block_0de6c224f0379d36, []int{4}
 }
 func (m *BlockBody) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockBody.Unmarshal(m, b)
@@ -303,6 +338,27 @@ func (m *BlockBody) GetRawTransactions() [][]byte {
 	return nil
 }
 
+func (m *BlockBody) GetEvidences() [][]byte {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
+func (m *BlockBody) GetReceipts() [][]byte {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
+func (m *BlockBody) GetExpiredTransactions() [][]byte {
+	if m != nil {
+		return m.ExpiredTransactions
+	}
+	return nil
+}
+
 type Block struct {
 	Header               *SignedBlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Body                 *BlockBody         `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
@@ -315,7 +371,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_dc06e4ac52b7100d, []int{5}
+	return fileDescriptor_block_0de6c224f0379d36, []int{5}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Block.Unmarshal(m, b)
@@ -349,6 +405,64 @@ func (m *Block) GetBody() *BlockBody {
 	return nil
 }
 
+// receipt of tx, only txs dropped from block for now
+type Receipt struct {
+	// hash of the tx
+	TxHash []byte `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	// height of block the tx dropped from
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// reason of tx failure
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Receipt) Reset()         { *m = Receipt{} }
+func (m *Receipt) String() string { return proto.CompactTextString(m) }
+func (*Receipt) ProtoMessage()    {}
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_block_0de6c224f0379d36, []int{6}
+}
+func (m *Receipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Receipt.Unmarshal(m, b)
+}
+func (m *Receipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Receipt.Marshal(b, m, deterministic)
+}
+func (dst *Receipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Receipt.Merge(dst, src)
+}
+func (m *Receipt) XXX_Size() int {
+	return xxx_messageInfo_Receipt.Size(m)
+}
+func (m *Receipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_Receipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Receipt proto.InternalMessageInfo
+
+func (m *Receipt) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *Receipt) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Receipt) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Account)(nil), "corepb.Account")
 	proto.RegisterType((*Signature)(nil), "corepb.Signature")
@@ -356,32 +470,40 @@ func init() {
 	proto.RegisterType((*SignedBlockHeader)(nil), "corepb.SignedBlockHeader")
 	proto.RegisterType((*BlockBody)(nil), "corepb.BlockBody")
 	proto.RegisterType((*Block)(nil), "corepb.Block")
-}
-
-func init() { proto.RegisterFile("block.proto", fileDescriptor_block_dc06e4ac52b7100d) }
-
-var fileDescriptor_block_dc06e4ac52b7100d = []byte{
-	// 346 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x4f, 0x6b, 0xe3, 0x30,
-	0x10, 0xc5, 0x71, 0x9c, 0x3f, 0x78, 0xec, 0x90, 0x8d, 0x58, 0x16, 0x2f, 0xe4, 0x60, 0x0c, 0x05,
-	0xf7, 0x92, 0x92, 0x14, 0x0a, 0x3d, 0x26, 0xf4, 0xd0, 0x5e, 0xd5, 0xde, 0x8b, 0x2c, 0x0b, 0x47,
-	0xd4, 0x91, 0x82, 0xa4, 0x10, 0xf2, 0x7d, 0xfa, 0x41, 0x8b, 0xe4, 0xd8, 0x51, 0xa0, 0x37, 0xbf,
-	0xb1, 0xe7, 0xcd, 0xef, 0x3d, 0x0c, 0x71, 0xd9, 0x48, 0xfa, 0xb5, 0x3c, 0x28, 0x69, 0x24, 0x1a,
-	0x53, 0xa9, 0xd8, 0xa1, 0xcc, 0x9f, 0x61, 0xb2, 0xa1, 0x54, 0x1e, 0x85, 0x41, 0x7f, 0x61, 0x24,
-	0xa4, 0xa0, 0x2c, 0x0d, 0xb2, 0xa0, 0x18, 0xe2, 0x56, 0xa0, 0x14, 0x26, 0x25, 0x69, 0x88, 0x9d,
-	0x0f, 0xb2, 0xa0, 0x48, 0x70, 0x27, 0x73, 0x06, 0xd1, 0x3b, 0xaf, 0x05, 0x31, 0x47, 0xc5, 0xd0,
-	0x3f, 0x18, 0x6b, 0x5e, 0x0b, 0xa6, 0xdc, 0x76, 0x82, 0x2f, 0x0a, 0xe5, 0x90, 0x68, 0x5e, 0x6f,
-	0x9a, 0x5a, 0x2a, 0x6e, 0x76, 0x7b, 0xe7, 0x31, 0xc5, 0x37, 0x33, 0xb4, 0x80, 0x48, 0x77, 0x46,
-	0x69, 0xe8, 0xd6, 0xaf, 0x83, 0xfc, 0x3b, 0x80, 0xf8, 0x43, 0x11, 0xa1, 0x09, 0x35, 0x5c, 0x0a,
-	0x0b, 0x44, 0x77, 0x84, 0x8b, 0xb7, 0x17, 0x77, 0x6a, 0x8a, 0x3b, 0x79, 0x0d, 0x30, 0xf0, 0x03,
-	0x2c, 0x20, 0x52, 0x8c, 0xf2, 0x03, 0x67, 0xc2, 0x74, 0xee, 0xfd, 0xc0, 0x72, 0x93, 0xbd, 0x8d,
-	0x9f, 0x0e, 0x5b, 0xee, 0x56, 0xa1, 0x07, 0x9f, 0x69, 0x96, 0x05, 0x45, 0xbc, 0x9e, 0x2f, 0xdb,
-	0xce, 0x96, 0x7d, 0x6a, 0x1f, 0xd3, 0xc0, 0xdc, 0xce, 0x59, 0xb5, 0xb5, 0x2d, 0xbf, 0x32, 0x52,
-	0x31, 0x65, 0xdd, 0x77, 0xee, 0xa9, 0x6b, 0xa5, 0x55, 0x96, 0xb4, 0x6c, 0xa4, 0xdc, 0x5f, 0x2a,
-	0x6d, 0x05, 0x5a, 0x01, 0xf4, 0x7e, 0x3a, 0x0d, 0xb3, 0xf0, 0xf7, 0xa3, 0xde, 0x47, 0xf9, 0x13,
-	0x44, 0xee, 0xde, 0x56, 0x56, 0x67, 0x74, 0x0f, 0x7f, 0x14, 0x39, 0x7d, 0x9a, 0x6b, 0x59, 0x3a,
-	0x0d, 0xb2, 0xb0, 0x48, 0xf0, 0x4c, 0x91, 0x93, 0xd7, 0xa1, 0xce, 0x09, 0x8c, 0xdc, 0x1e, 0x5a,
-	0xdd, 0x10, 0xc6, 0xeb, 0xff, 0xfe, 0xbd, 0x9b, 0x30, 0x3d, 0xfc, 0x1d, 0x0c, 0x4b, 0x59, 0x9d,
-	0x1d, 0xbb, 0x07, 0xd8, 0x73, 0x60, 0xf7, 0xba, 0x1c, 0xbb, 0x1f, 0xed, 0xf1, 0x27, 0x00, 0x00,
-	0xff, 0xff, 0xb0, 0xfd, 0x05, 0xd1, 0x77, 0x02, 0x00, 0x00,
+	proto.RegisterType((*Receipt)(nil), "corepb.Receipt")
+}
+
+func init() { proto.RegisterFile("block.proto", fileDescriptor_block_0de6c224f0379d36) }
+
+var fileDescriptor_block_0de6c224f0379d36 = []byte{
+	// 464 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x4f, 0x8f, 0xd3, 0x3e,
+	0x10, 0x55, 0xfa, 0xf7, 0xd7, 0x69, 0x57, 0xfb, 0x5b, 0xb3, 0x42, 0x06, 0xad, 0x50, 0x15, 0x09,
+	0xa9, 0x5c, 0x8a, 0xba, 0x9c, 0x38, 0x76, 0xc5, 0x61, 0x39, 0x62, 0xe0, 0x8c, 0x1c, 0x67, 0x48,
+	0x2c, 0x52, 0x3b, 0xb2, 0xbd, 0x7f, 0xfa, 0x79, 0xf8, 0x92, 0x1c, 0x91, 0xed, 0x24, 0x75, 0x25,
+	0x6e, 0x79, 0x6f, 0xec, 0x99, 0xf7, 0xde, 0x38, 0xb0, 0x2c, 0x1a, 0x2d, 0x7e, 0x6d, 0x5b, 0xa3,
+	0x9d, 0x26, 0x33, 0xa1, 0x0d, 0xb6, 0x45, 0xfe, 0x11, 0xe6, 0x7b, 0x21, 0xf4, 0x83, 0x72, 0xe4,
+	0x1a, 0xa6, 0x4a, 0x2b, 0x81, 0x34, 0x5b, 0x67, 0x9b, 0x09, 0x8b, 0x80, 0x50, 0x98, 0x17, 0xbc,
+	0xe1, 0x9e, 0x1f, 0xad, 0xb3, 0xcd, 0x8a, 0xf5, 0x30, 0x47, 0x58, 0x7c, 0x95, 0x95, 0xe2, 0xee,
+	0xc1, 0x20, 0x79, 0x09, 0x33, 0x2b, 0x2b, 0x85, 0x26, 0xdc, 0x5e, 0xb1, 0x0e, 0x91, 0x1c, 0x56,
+	0x56, 0x56, 0xfb, 0xa6, 0xd2, 0x46, 0xba, 0xfa, 0x10, 0x7a, 0x5c, 0xb0, 0x33, 0x8e, 0xdc, 0xc0,
+	0xc2, 0xf6, 0x8d, 0xe8, 0x38, 0x5c, 0x3f, 0x11, 0xf9, 0x9f, 0x0c, 0x96, 0xdf, 0x0c, 0x57, 0x96,
+	0x0b, 0x27, 0xb5, 0xf2, 0x82, 0x44, 0xcd, 0xa5, 0xfa, 0xfc, 0x29, 0x8c, 0xba, 0x60, 0x3d, 0x3c,
+	0x19, 0x18, 0xa5, 0x06, 0x6e, 0x60, 0x61, 0x50, 0xc8, 0x56, 0xa2, 0x72, 0x7d, 0xf7, 0x81, 0xf0,
+	0xba, 0xf9, 0xc1, 0xdb, 0xa7, 0x93, 0xa8, 0x3b, 0x22, 0x42, 0x60, 0xe2, 0x8e, 0x2d, 0xd2, 0x69,
+	0x18, 0x11, 0xbe, 0xc9, 0x1b, 0x80, 0x47, 0xde, 0xc8, 0x72, 0xff, 0xd3, 0xa1, 0xa1, 0xb3, 0x30,
+	0x24, 0x61, 0x86, 0xfa, 0x77, 0xe5, 0x64, 0x43, 0xe7, 0x49, 0x3d, 0x30, 0xe4, 0x7d, 0xea, 0xf3,
+	0x72, 0x9d, 0x6d, 0x96, 0xb7, 0x57, 0xdb, 0xb8, 0x87, 0xed, 0x90, 0x64, 0x6a, 0xdd, 0xc1, 0x95,
+	0xe7, 0xb1, 0xbc, 0xf3, 0x9b, 0xbb, 0x47, 0x5e, 0xa2, 0xf1, 0x8a, 0xeb, 0xf0, 0xd5, 0x27, 0x1d,
+	0x91, 0x77, 0x5f, 0x34, 0x5a, 0x1f, 0xba, 0x35, 0x45, 0x40, 0x76, 0x00, 0x43, 0x3f, 0x4b, 0xc7,
+	0xeb, 0xf1, 0xbf, 0x87, 0x26, 0x87, 0xf2, 0xdf, 0x19, 0x2c, 0xc2, 0xc0, 0x3b, 0x5d, 0x1e, 0xc9,
+	0x3b, 0xf8, 0xdf, 0xf0, 0xa7, 0x1f, 0xee, 0xb4, 0x01, 0x4b, 0xb3, 0xf5, 0x78, 0xb3, 0x62, 0x97,
+	0x86, 0x3f, 0x25, 0x8b, 0xb1, 0x3e, 0x69, 0x7c, 0x94, 0x25, 0x2a, 0x81, 0x96, 0x8e, 0xc2, 0x99,
+	0x13, 0x41, 0x5e, 0xc3, 0x7f, 0x06, 0x05, 0xca, 0xd6, 0x45, 0x1d, 0x2b, 0x36, 0x60, 0xb2, 0x83,
+	0x6b, 0x7c, 0x6e, 0xa5, 0xc1, 0xf2, 0x7c, 0xd0, 0x24, 0x9c, 0x7b, 0xd1, 0xd5, 0xd2, 0x61, 0x39,
+	0x87, 0x69, 0x10, 0x49, 0x76, 0x67, 0x79, 0x2c, 0x6f, 0x5f, 0xa5, 0xee, 0xce, 0xa2, 0x1b, 0xa2,
+	0x7a, 0x0b, 0x93, 0x42, 0x97, 0xc7, 0x90, 0x54, 0x12, 0xc7, 0x60, 0x9a, 0x85, 0x72, 0xfe, 0x05,
+	0xe6, 0x2c, 0x2a, 0xf4, 0xa1, 0xbb, 0xe7, 0x7b, 0x6e, 0xeb, 0x3e, 0xf4, 0x88, 0xe2, 0x32, 0x64,
+	0x55, 0xbb, 0xee, 0xcd, 0x75, 0xc8, 0xf3, 0x06, 0xb9, 0xd5, 0x2a, 0xbc, 0xb8, 0x05, 0xeb, 0x50,
+	0x31, 0x0b, 0x7f, 0xdf, 0x87, 0xbf, 0x03, 0x00, 0xd6, 0x24, 0xd9, 0x9f, 0x8c, 0x03, 0x00, 0x00,
 }
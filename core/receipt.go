@@ -18,22 +18,87 @@
 package core
 
 import (
+	"github.com/golang/protobuf/proto"
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/core/pb"
 	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/persistent"
 )
 
+// reasons of tx failure recorded in receipt
+const (
+	ReceiptReasonExpired = "expired"
+)
+
+// Receipt records result of tx, only txs expired for now. txs dropped from
+// a block are carried in its body, and every node storing the block replays
+// their receipts against receipts root in header; nodes holding a tx in pool
+// record its receipt when it expires there too
 type Receipt struct {
+	TxHash common.Hash
+	Height uint64
+	Reason string
+
 	// caches
 	raw []byte
 }
 
-func newReceipt() *Receipt {
+func newReceipt(txHash common.Hash, height uint64, reason string) *Receipt {
 	r := &Receipt{
+		TxHash: txHash,
+		Height: height,
+		Reason: reason,
 	}
 	return r
 }
 
+func (r *Receipt) ToProto() *corepb.Receipt {
+	return &corepb.Receipt{
+		TxHash: common.CopyBytes(r.TxHash[:]),
+		Height: r.Height,
+		Reason: r.Reason,
+	}
+}
+
+func (r *Receipt) FromProto(pb *corepb.Receipt) {
+	r.TxHash = common.BytesToHash(pb.TxHash)
+	r.Height = pb.Height
+	r.Reason = pb.Reason
+}
+
+func (r *Receipt) Decode(enc []byte) error {
+	pb := new(corepb.Receipt)
+	if err := proto.Unmarshal(enc, pb); err != nil {
+		return err
+	}
+	r.FromProto(pb)
+	r.raw = enc
+	return nil
+}
+
 type Receipts []*Receipt
 
+func (rs Receipts) encode() error {
+	for _, r := range rs {
+		if r.raw != nil {
+			continue
+		}
+		enc, err := proto.Marshal(r.ToProto())
+		if err != nil {
+			return err
+		}
+		r.raw = enc
+	}
+	return nil
+}
+
+func (rs Receipts) Write(putter persistent.Putter) error {
+	for _, r := range rs {
+		putReceipt(putter, r.TxHash, r.ToProto())
+	}
+	return nil
+}
+
 func (rs Receipts) Len() int { return len(rs) }
 
 func (rs Receipts) GetEncoded(index int) []byte {
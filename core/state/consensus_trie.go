@@ -24,7 +24,13 @@ import (
 	"github.com/yeeco/gyee/log"
 )
 
-const TrieKeyValidators = "Validators"
+const (
+	TrieKeyValidators         = "Validators"
+	TrieKeyValidatorProposals = "ValidatorProposals"
+	TrieKeyValidatorChanges   = "ValidatorChanges"
+	TrieKeyJailed             = "Jailed"
+	TrieKeyTetrisParams       = "TetrisParams"
+)
 
 type consensusTrie struct {
 	db      Database
@@ -113,3 +119,92 @@ func (ct *consensusTrie) SetValidators(validators []string) {
 	}
 	ct.setTrieErr(ct.trie.TryUpdate([]byte(TrieKeyValidators), enc))
 }
+
+func (ct *consensusTrie) GetValidatorProposals() []*ValidatorProposal {
+	var result []*ValidatorProposal
+	ct.getList(TrieKeyValidatorProposals, &result)
+	return result
+}
+
+func (ct *consensusTrie) SetValidatorProposals(proposals []*ValidatorProposal) {
+	ct.setList(TrieKeyValidatorProposals, len(proposals), proposals)
+}
+
+func (ct *consensusTrie) GetValidatorChanges() []*ValidatorChange {
+	var result []*ValidatorChange
+	ct.getList(TrieKeyValidatorChanges, &result)
+	return result
+}
+
+func (ct *consensusTrie) SetValidatorChanges(changes []*ValidatorChange) {
+	ct.setList(TrieKeyValidatorChanges, len(changes), changes)
+}
+
+func (ct *consensusTrie) GetJailed() []*JailRecord {
+	var result []*JailRecord
+	ct.getList(TrieKeyJailed, &result)
+	return result
+}
+
+func (ct *consensusTrie) SetJailed(records []*JailRecord) {
+	ct.setList(TrieKeyJailed, len(records), records)
+}
+
+func (ct *consensusTrie) GetTetrisParams() *TetrisParams {
+	enc, err := ct.trie.TryGet([]byte(TrieKeyTetrisParams))
+	if err != nil {
+		ct.setTrieErr(err)
+		return nil
+	}
+	if len(enc) == 0 {
+		return nil
+	}
+	result := new(TetrisParams)
+	if err := rlp.DecodeBytes(enc, result); err != nil {
+		ct.setTrieErr(err)
+		return nil
+	}
+	return result
+}
+
+// default params are not stored, keeping trie root of existing genesis
+func (ct *consensusTrie) SetTetrisParams(params *TetrisParams) {
+	if params.IsZero() {
+		ct.setTrieErr(ct.trie.TryDelete([]byte(TrieKeyTetrisParams)))
+		return
+	}
+	enc, err := rlp.EncodeToBytes(params)
+	if err != nil {
+		log.Crit("SetTetrisParams()", "params", params, "err", err)
+	}
+	ct.setTrieErr(ct.trie.TryUpdate([]byte(TrieKeyTetrisParams), enc))
+}
+
+// decode rlp list under key, leaving result untouched if key missing
+func (ct *consensusTrie) getList(key string, result interface{}) {
+	enc, err := ct.trie.TryGet([]byte(key))
+	if err != nil {
+		ct.setTrieErr(err)
+		return
+	}
+	if len(enc) == 0 {
+		return
+	}
+	if err := rlp.DecodeBytes(enc, result); err != nil {
+		ct.setTrieErr(err)
+	}
+}
+
+// encode list under key, an empty list removes the key,
+// so trie root stays the same for chains that never changed validators
+func (ct *consensusTrie) setList(key string, n int, list interface{}) {
+	if n == 0 {
+		ct.setTrieErr(ct.trie.TryDelete([]byte(key)))
+		return
+	}
+	enc, err := rlp.EncodeToBytes(list)
+	if err != nil {
+		log.Crit("setList()", "key", key, "err", err)
+	}
+	ct.setTrieErr(ct.trie.TryUpdate([]byte(key), enc))
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+package state
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/trie"
+)
+
+var ErrProofNoValidators = errors.New("validators proven missing")
+
+// Prove returns merkle proof of key in the trie of root, either an account
+// trie or a consensus trie, as they share the database
+func Prove(db Database, root common.Hash, key []byte) ([][]byte, error) {
+	tr, err := db.OpenTrie(root)
+	if err != nil {
+		return nil, err
+	}
+	return tr.Prove(key)
+}
+
+// VerifyAccount returns the account of address proven in the account trie
+// of root, nil if proven missing, which is an account of zero balance
+func VerifyAccount(root common.Hash, addr common.Address, proof [][]byte) (Account, error) {
+	enc, err := trie.VerifyProof(root, addr[:], proof)
+	if err != nil || len(enc) == 0 {
+		return nil, err
+	}
+	account := newAccount(nil, addr)
+	if err := account.setBytes(enc); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// VerifyValidators returns validators proven in the consensus trie of root,
+// a consensus trie always has validators
+func VerifyValidators(root common.Hash, proof [][]byte) ([]string, error) {
+	enc, err := trie.VerifyProof(root, []byte(TrieKeyValidators), proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, ErrProofNoValidators
+	}
+	var result []string
+	if err := rlp.DecodeBytes(enc, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
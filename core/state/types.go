@@ -77,4 +77,54 @@ type ConsensusTrie interface {
 	// Get / Set validator address str list
 	GetValidators() []string
 	SetValidators([]string)
+
+	// Get / Set validator requests waiting for votes
+	GetValidatorProposals() []*ValidatorProposal
+	SetValidatorProposals([]*ValidatorProposal)
+
+	// Get / Set approved validator changes waiting for activation height
+	GetValidatorChanges() []*ValidatorChange
+	SetValidatorChanges([]*ValidatorChange)
+
+	// Get / Set validators jailed for misbehaviour
+	GetJailed() []*JailRecord
+	SetJailed([]*JailRecord)
+
+	// Get / Set tetris params agreed in genesis, nil if defaults used
+	GetTetrisParams() *TetrisParams
+	SetTetrisParams(*TetrisParams)
+}
+
+// a join / leave request on a validator, with approval votes
+type ValidatorProposal struct {
+	Type   uint32   // tx type of the request
+	Target string   // validator address str
+	Votes  []string // validators approved
+	Height uint64   // block height of the request
+}
+
+// validator set change taking effect at block Height
+type ValidatorChange struct {
+	Height uint64
+	Joins  []string
+	Quits  []string
+}
+
+// validator jailed by evidence in block Height
+type JailRecord struct {
+	Validator string
+	Height    uint64
+	Evidence  common.Hash
+}
+
+// tetris event timing from genesis, durations in milliseconds, 0 for default
+type TetrisParams struct {
+	MaxTxPerEvent     uint64
+	MaxTxDelay        uint64
+	MinPeriodForEvent uint64
+	MaxPeriodForEvent uint64
+}
+
+func (p *TetrisParams) IsZero() bool {
+	return p == nil || *p == TetrisParams{}
 }
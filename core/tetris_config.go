@@ -0,0 +1,67 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/yeeco/gyee/config"
+	"github.com/yeeco/gyee/consensus/tetris2"
+	"github.com/yeeco/gyee/core/state"
+)
+
+// build tetris config from params agreed in genesis and local tuning,
+// zero values leave defaults, the result is validated
+func tetrisConfig(params *state.TetrisParams, local *config.TetrisConfig) (*tetris2.Config, error) {
+	cfg := tetris2.DefaultConfig()
+	if params != nil {
+		if params.MaxTxPerEvent != 0 {
+			cfg.MaxTxPerEvent = int(params.MaxTxPerEvent)
+		}
+		setMillis(&cfg.MaxTxDelay, int64(params.MaxTxDelay))
+		setMillis(&cfg.MinPeriodForEvent, int64(params.MinPeriodForEvent))
+		setMillis(&cfg.MaxPeriodForEvent, int64(params.MaxPeriodForEvent))
+	}
+	// tick follows a short tx delay, unless tuned locally
+	if cfg.Tick > cfg.MaxTxDelay {
+		cfg.Tick = cfg.MaxTxDelay
+	}
+	if local != nil {
+		setMillis(&cfg.Tick, int64(local.Tick))
+		setInt(&cfg.EventCacheSize, local.EventCache)
+		setInt(&cfg.EventRequestSize, local.EventRequestCache)
+		setInt(&cfg.TxsCacheSize, local.TxsCache)
+		setInt(&cfg.TxsCommittedSize, local.TxsCommittedCache)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func setMillis(d *time.Duration, ms int64) {
+	if ms != 0 {
+		*d = time.Duration(ms) * time.Millisecond
+	}
+}
+
+func setInt(v *int, n int) {
+	if n != 0 {
+		*v = n
+	}
+}
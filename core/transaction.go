@@ -41,15 +41,55 @@ var (
 	ErrNoSigner          = errors.New("no signer found")
 	ErrSignatureMismatch = errors.New("signature mismatch")
 	ErrTxFromMismatch    = errors.New("tx sender mismatch")
+	ErrTxNotYetValid     = errors.New("tx not yet valid")
+	ErrTxExpired         = errors.New("tx expired")
+	ErrTxValidity        = errors.New("tx expires before valid")
 )
 
+// TxValidityTimeThreshold tells tx validAfter / validUntil below is block
+// height, otherwise block time in milli seconds
+const TxValidityTimeThreshold uint64 = 500000000000
+
+// TxType distinguishes plain transfers from validator governance txs
+type TxType uint32
+
+const (
+	// plain balance transfer
+	TxTypeTransfer TxType = iota
+	// request from the recipient itself to join the validator set
+	TxTypeValidatorJoin
+	// request to remove the recipient from the validator set
+	TxTypeValidatorLeave
+	// validator vote to approve the pending request on the recipient
+	TxTypeValidatorVote
+)
+
+func (t TxType) String() string {
+	switch t {
+	case TxTypeTransfer:
+		return "transfer"
+	case TxTypeValidatorJoin:
+		return "join"
+	case TxTypeValidatorLeave:
+		return "leave"
+	case TxTypeValidatorVote:
+		return "vote"
+	}
+	return fmt.Sprintf("TxType(%d)", uint32(t))
+}
+
 type Transaction struct {
 	chainID   uint32
 	nonce     uint64
 	to        *common.Address
 	amount    *big.Int
+	txType    TxType
 	signature *crypto.Signature
 
+	// block height or time range tx can be included, 0 for no limit
+	validAfter uint64
+	validUntil uint64
+
 	// caches
 	from *common.Address
 	hash *common.Hash
@@ -71,6 +111,24 @@ func NewTransaction(chainID uint32, nonce uint64, recipient *common.Address, amo
 	return tx
 }
 
+// NewValidatorTransaction creates a governance tx of txType on the target
+// validator, carrying no amount
+func NewValidatorTransaction(chainID uint32, nonce uint64, txType TxType, target *common.Address) *Transaction {
+	tx := NewTransaction(chainID, nonce, target, nil)
+	tx.txType = txType
+	return tx
+}
+
+// SetValidity limits blocks tx can be included to [after, until], in block
+// height if below TxValidityTimeThreshold, or block time in milli seconds.
+// 0 for no limit. Should be set before signing.
+func (t *Transaction) SetValidity(after, until uint64) {
+	t.validAfter = after
+	t.validUntil = until
+	t.hash = nil
+	t.raw = nil
+}
+
 func NewTransactionFromProto(msg proto.Message) (*Transaction, error) {
 	tx := &Transaction{}
 	err := tx.FromProto(msg)
@@ -81,6 +139,9 @@ func NewTransactionFromProto(msg proto.Message) (*Transaction, error) {
 }
 
 func (t *Transaction) String() string {
+	if t.txType != TxTypeTransfer {
+		return fmt.Sprintf("tx{%v f:[%v] n:[%d] t:[%v]}", t.txType, t.from, t.nonce, t.to)
+	}
 	return fmt.Sprintf("tx{f:[%v] n:[%d] t:[%v] a:%v}", t.from, t.nonce, t.to, t.amount)
 }
 
@@ -100,6 +161,48 @@ func (t *Transaction) Amount() *big.Int {
 	return t.amount
 }
 
+func (t *Transaction) Type() TxType {
+	return t.txType
+}
+
+func (t *Transaction) ValidAfter() uint64 {
+	return t.validAfter
+}
+
+func (t *Transaction) ValidUntil() uint64 {
+	return t.validUntil
+}
+
+// verifyValidity checks the validity range not empty
+func (t *Transaction) verifyValidity() error {
+	if t.validAfter == 0 || t.validUntil == 0 {
+		return nil
+	}
+	afterTime := t.validAfter >= TxValidityTimeThreshold
+	untilTime := t.validUntil >= TxValidityTimeThreshold
+	if afterTime == untilTime && t.validUntil < t.validAfter {
+		return ErrTxValidity
+	}
+	return nil
+}
+
+// checkValidity checks if tx can be included in block of height and time
+func (t *Transaction) checkValidity(height, time uint64) error {
+	point := func(v uint64) uint64 {
+		if v < TxValidityTimeThreshold {
+			return height
+		}
+		return time
+	}
+	if t.validUntil > 0 && point(t.validUntil) > t.validUntil {
+		return ErrTxExpired
+	}
+	if t.validAfter > 0 && point(t.validAfter) < t.validAfter {
+		return ErrTxNotYetValid
+	}
+	return nil
+}
+
 func (t *Transaction) contentHash() (*common.Hash, error) {
 	encoded, err := t.encode(true)
 	if err != nil {
@@ -179,6 +282,10 @@ func (t *Transaction) ToProto() (*corepb.Transaction, error) {
 	pbTx := &corepb.Transaction{
 		ChainID: t.chainID,
 		Nonce:   t.nonce,
+		Type:    uint32(t.txType),
+
+		ValidAfter: t.validAfter,
+		ValidUntil: t.validUntil,
 	}
 	if t.to != nil {
 		pbTx.Recipient = common.CopyBytes(t.to[:])
@@ -206,6 +313,9 @@ func (t *Transaction) FromProto(msg proto.Message) error {
 	// copy value
 	t.chainID = pbt.ChainID
 	t.nonce = pbt.Nonce
+	t.txType = TxType(pbt.Type)
+	t.validAfter = pbt.ValidAfter
+	t.validUntil = pbt.ValidUntil
 	if pbt.Recipient != nil {
 		t.to = new(common.Address)
 		t.to.SetBytes(pbt.Recipient)
@@ -236,7 +346,7 @@ func (t *Transaction) encode(withoutSig bool) ([]byte, error) {
 	if withoutSig {
 		pb.Signature = nil
 	} else {
-		if len(pb.Signature.Signature) == 0 {
+		if len(pb.Signature.GetSignature()) == 0 {
 			log.Error("tx encoded with nil signature", "tx", t)
 		}
 	}
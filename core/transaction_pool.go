@@ -31,13 +31,21 @@ package core
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/yeeco/gyee/common"
 	"github.com/yeeco/gyee/log"
 	"github.com/yeeco/gyee/p2p"
 )
 
-const TooFarTx = 8192
+const (
+	TooFarTx = 8192
+
+	// max txs held in pool before validAfter
+	MaxFutureTxs = 8192
+	// interval checking held txs become valid
+	futureTxInterval = time.Second
+)
 
 var (
 	ErrTxChainID = errors.New("transaction chainID mismatch")
@@ -47,11 +55,19 @@ type TransactionPool struct {
 	core       *Core
 	subscriber *p2p.Subscriber
 
+	// txs submitted to a dev chain, p2p broadcast never comes back to sender
+	localCh chan *Transaction
+
 	// requesting tx hash pool
 	reqPool map[common.Hash]struct{}
 
 	// pending tx pool
 	pendingPool map[common.Hash]*Transaction
+	pendingLock sync.RWMutex
+
+	// txs not yet valid, sent to consensus when valid for next block
+	futurePool map[common.Hash]*Transaction
+	futureLock sync.Mutex
 
 	lock   sync.RWMutex
 	quitCh chan struct{}
@@ -62,8 +78,10 @@ func NewTransactionPool(core *Core) (*TransactionPool, error) {
 	log.Info("Create New TransactionPool")
 	bp := &TransactionPool{
 		core:        core,
+		localCh:     make(chan *Transaction, 100),
 		reqPool:     make(map[common.Hash]struct{}),
 		pendingPool: make(map[common.Hash]*Transaction),
+		futurePool:  make(map[common.Hash]*Transaction),
 		quitCh:      make(chan struct{}),
 	}
 	return bp, nil
@@ -96,14 +114,27 @@ func (tp *TransactionPool) loop() {
 	tp.wg.Add(1)
 	defer tp.wg.Done()
 
+	futureTicker := time.NewTicker(futureTxInterval)
+	defer futureTicker.Stop()
+
 	for {
 		select {
 		case <-tp.quitCh:
 			log.Info("TransactionPool loop end.")
 			return
+		case <-futureTicker.C:
+			for _, tx := range tp.releaseFuture() {
+				if err := tp.processTx(tx); err != nil {
+					log.Warn("held tx rejected", "err", err, "tx", tx)
+				}
+			}
 		case msg := <-tp.subscriber.MsgChan:
 			//log.Info("tx pool receive ", msg.MsgType, " ", msg.From)
 			tp.processMsg(msg)
+		case tx := <-tp.localCh:
+			if err := tp.processTx(tx); err != nil {
+				log.Warn("local tx rejected", "err", err, "tx", tx)
+			}
 		}
 	}
 }
@@ -115,35 +146,40 @@ func (tp *TransactionPool) processMsg(msg p2p.Message) {
 		tp.core.metrics.p2pMsgRecvTx.Mark(1)
 		var tx = new(Transaction)
 		if err := tx.Decode(msg.Data); err != nil {
-			tp.markBadPeer(msg)
+			tp.markBadPeer(msg, p2p.PeerOffenceUndecodable)
 			break
 		}
-		tp.processTx(tx)
+		// chain id, validity and type errors are no offence of the
+		// relaying peer, only a broken signature is
+		if err := tp.processTx(tx); err == ErrNoSignature || err == ErrSignatureMismatch {
+			tp.markBadPeer(msg, p2p.PeerOffenceBadSignature)
+		}
 	default:
 		log.Crit("unhandled msg sent to txPool", "msg", msg)
 	}
 }
 
-func (tp *TransactionPool) processTx(tx *Transaction) {
+// processTx returns error only if tx fails to be verified
+func (tp *TransactionPool) processTx(tx *Transaction) error {
 	// validate tx integrity
 	if err := tp.core.blockChain.verifyTx(tx); err != nil {
 		log.Warn("processTx() verify fails", "err", err, "tx", tx)
-		// TODO: mark bad peer?
-		return
+		return err
 	}
 	if err := tx.VerifySig(); err != nil {
 		log.Warn("tx sig verify failed", "err", err)
-		// TODO: mark bad peer?
-		return
+		return err
 	}
 
 	// search in-mem request, if we are requesting for this tx
 	if _, ok := tp.reqPool[*tx.Hash()]; ok {
 		delete(tp.reqPool, *tx.Hash())
+		tp.pendingLock.Lock()
 		tp.pendingPool[*tx.Hash()] = tx
+		tp.pendingLock.Unlock()
 
 		// TODO: check if block can be sealed
-		return
+		return nil
 	}
 
 	// search chain, if tx has been sealed
@@ -151,7 +187,7 @@ func (tp *TransactionPool) processTx(tx *Transaction) {
 	// in such cases a nonce check would cover
 	if hasTransaction(tp.core.storage, *tx.Hash()) {
 		// TODO: mark bad peer?
-		return
+		return nil
 	}
 
 	// basic check tx
@@ -160,13 +196,25 @@ func (tp *TransactionPool) processTx(tx *Transaction) {
 	if account == nil {
 		log.Warn("ignore tx for non-exist account", "tx", tx)
 		// TODO: mark bad peer?
-		return
+		return nil
 	}
 	currNonce := account.Nonce()
 	if (currNonce > tx.nonce) || (currNonce+TooFarTx < tx.nonce) {
 		log.Warn("tx nonce too far", "nonce", currNonce, "tx", tx)
 		// TODO: mark bad peer?
-		return
+		return nil
+	}
+
+	// hold tx till valid for next block
+	height, t := tp.nextBlockPoint()
+	switch tx.checkValidity(height, t) {
+	case ErrTxExpired:
+		log.Info("ignore expired tx", "tx", tx)
+		tp.expireTx(tx, height)
+		return nil
+	case ErrTxNotYetValid:
+		tp.holdTx(tx)
+		return nil
 	}
 
 	// put tx to DHT
@@ -176,7 +224,7 @@ func (tp *TransactionPool) processTx(tx *Transaction) {
 		data, err = tx.Encode()
 		if err != nil {
 			log.Warn("failed to encode tx", "err", err)
-			return
+			return nil
 		}
 	}
 	_ = tp.core.node.P2pService().DhtSetValue(tx.Hash()[:], data)
@@ -185,6 +233,7 @@ func (tp *TransactionPool) processTx(tx *Transaction) {
 	if tp.core.engine != nil {
 		tp.core.engine.SendTx(*tx.Hash())
 	}
+	return nil
 }
 
 func (tp *TransactionPool) TxBroadcast(tx *Transaction) error {
@@ -195,6 +244,14 @@ func (tp *TransactionPool) TxBroadcast(tx *Transaction) error {
 	}
 	log.Debug("TxBroadcast", "from", tx.From().Hex(), "N", tx.Nonce(),
 		"to", tx.To().Hex(), "a", tx.Amount().String())
+	// a dev chain has no peers to relay txs to its engine, they are fed to
+	// the local pool, validators of other engines get them from peers
+	if tp.core.EngineName() == EngineDev {
+		select {
+		case tp.localCh <- tx:
+		case <-tp.quitCh:
+		}
+	}
 	go func(msg p2p.Message) {
 		tp.core.metrics.p2pMsgSent.Mark(1)
 		err = tp.core.node.P2pService().BroadcastMessage(msg)
@@ -210,6 +267,77 @@ func (tp *TransactionPool) TxBroadcast(tx *Transaction) error {
 	return nil
 }
 
-func (tp *TransactionPool) markBadPeer(msg p2p.Message) {
-	// TODO: inform bad peed msg.From to p2p module
+// holdTx keeps tx not yet valid, till it would be valid for next block
+func (tp *TransactionPool) holdTx(tx *Transaction) {
+	tp.futureLock.Lock()
+	defer tp.futureLock.Unlock()
+	if _, ok := tp.futurePool[*tx.Hash()]; ok {
+		return
+	}
+	if len(tp.futurePool) >= MaxFutureTxs {
+		log.Warn("future tx pool full, tx ignored", "tx", tx)
+		return
+	}
+	log.Debug("tx held till valid", "tx", tx, "validAfter", tx.ValidAfter())
+	tp.futurePool[*tx.Hash()] = tx
+}
+
+// releaseFuture removes txs held but valid for next block now, expired
+// ones discarded
+func (tp *TransactionPool) releaseFuture() Transactions {
+	tp.futureLock.Lock()
+	defer tp.futureLock.Unlock()
+	if len(tp.futurePool) == 0 {
+		return nil
+	}
+	height, t := tp.nextBlockPoint()
+	var released Transactions
+	for hash, tx := range tp.futurePool {
+		switch tx.checkValidity(height, t) {
+		case nil:
+			released = append(released, tx)
+		case ErrTxExpired:
+			log.Info("held tx expired", "tx", tx)
+			tp.expireTx(tx, height)
+		default:
+			continue
+		}
+		delete(tp.futurePool, hash)
+	}
+	return released
+}
+
+// expireTx records receipt of tx expired before sealed, height is the
+// block it would be in
+func (tp *TransactionPool) expireTx(tx *Transaction, height uint64) {
+	if hasTransaction(tp.core.storage, *tx.Hash()) {
+		return
+	}
+	receipt := newReceipt(*tx.Hash(), height, ReceiptReasonExpired)
+	putReceipt(tp.core.storage, receipt.TxHash, receipt.ToProto())
+}
+
+// nextBlockPoint estimates height and time of next block
+func (tp *TransactionPool) nextBlockPoint() (height, t uint64) {
+	last := tp.core.blockChain.LastBlock()
+	t = uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	if last.Time() > t {
+		t = last.Time()
+	}
+	return last.Number() + 1, t
+}
+
+// GetTxByHash returns tx pending in pool, nil if not found
+func (tp *TransactionPool) GetTxByHash(hash common.Hash) *Transaction {
+	tp.pendingLock.RLock()
+	defer tp.pendingLock.RUnlock()
+	return tp.pendingPool[hash]
+}
+
+// markBadPeer reports msg.From at minor severity, as gossip is forwarded
+// by p2p before core validated it and msg.From may only have relayed it.
+func (tp *TransactionPool) markBadPeer(msg p2p.Message, offence string) {
+	if err := tp.core.node.P2pService().ReportPeer(msg.From, offence, p2p.PeerSeverityMinor); err != nil {
+		log.Warn("failed to report bad peer", "from", msg.From, "err", err)
+	}
 }
@@ -83,3 +83,55 @@ func TestTxEncode(t *testing.T) {
 		t.Errorf("tx encoded hex mismatch, got %v", hexStr)
 	}
 }
+
+func TestTxValidity(t *testing.T) {
+	address := common.HexToAddress(txTestAddress)
+	tx := NewTransaction(255, 128, &address, big.NewInt(10000))
+	if err := tx.checkValidity(1, 0); err != nil {
+		t.Errorf("unlimited tx not valid %v", err)
+	}
+
+	// by height
+	tx.SetValidity(10, 20)
+	for _, c := range []struct {
+		height uint64
+		err    error
+	}{{9, ErrTxNotYetValid}, {10, nil}, {20, nil}, {21, ErrTxExpired}} {
+		if err := tx.checkValidity(c.height, 0); err != c.err {
+			t.Errorf("height %d got %v, expect %v", c.height, err, c.err)
+		}
+	}
+
+	// by block time
+	after := TxValidityTimeThreshold + 1000
+	tx.SetValidity(after, 0)
+	if err := tx.checkValidity(100, after-1); err != ErrTxNotYetValid {
+		t.Errorf("time before validAfter got %v", err)
+	}
+	if err := tx.checkValidity(1, after); err != nil {
+		t.Errorf("time at validAfter got %v", err)
+	}
+
+	// kept in encoding
+	tx.SetValidity(10, after)
+	pbTx, err := tx.ToProto()
+	if err != nil {
+		t.Fatalf("tx ToProto failed %v", err)
+	}
+	decoded, err := NewTransactionFromProto(pbTx)
+	if err != nil {
+		t.Fatalf("tx FromProto failed %v", err)
+	}
+	if decoded.ValidAfter() != 10 || decoded.ValidUntil() != after {
+		t.Errorf("validity mismatch, got %d %d", decoded.ValidAfter(), decoded.ValidUntil())
+	}
+
+	tx.SetValidity(20, 10)
+	if err := tx.verifyValidity(); err != ErrTxValidity {
+		t.Errorf("empty validity range got %v", err)
+	}
+	tx.SetValidity(20, after)
+	if err := tx.verifyValidity(); err != nil {
+		t.Errorf("validity of height and time got %v", err)
+	}
+}
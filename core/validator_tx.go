@@ -0,0 +1,289 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/core/state"
+)
+
+// Validator set governance
+//   join:  candidate sends TxTypeValidatorJoin with itself as recipient
+//   leave: a validator sends TxTypeValidatorLeave on itself or another validator
+//   vote:  validators send TxTypeValidatorVote on the requested recipient
+// A request approved by super majority of current validators takes effect
+// ValidatorActivationDelay blocks after the approving block, giving engines
+// of all validators time to reach the same height before rotating.
+// Requests not approved in ValidatorProposalExpiry blocks are dropped, and
+// at most MaxValidatorJoinProposals join requests are pending at a time.
+
+const (
+	// blocks between approval and activation of a validator change
+	ValidatorActivationDelay = 16
+
+	// blocks a request waits for approval before dropped
+	ValidatorProposalExpiry = 1024
+
+	// max pending join requests, as anyone may send one without fee
+	MaxValidatorJoinProposals = 16
+)
+
+var (
+	ErrTxTypeUnknown           = errors.New("unknown transaction type")
+	ErrTxValidatorRecipient    = errors.New("validator tx without recipient")
+	ErrTxValidatorAmount       = errors.New("validator tx with amount")
+	ErrValidatorJoinNotSelf    = errors.New("validator join request not from recipient")
+	ErrValidatorAlready        = errors.New("already a validator")
+	ErrValidatorNotFound       = errors.New("not a validator")
+	ErrValidatorPending        = errors.New("validator request pending")
+	ErrValidatorProposalsFull  = errors.New("too many validator join requests pending")
+	ErrValidatorNoRequest      = errors.New("no validator request to vote")
+	ErrValidatorVoteDuplicated = errors.New("validator vote duplicated")
+	ErrValidatorLastOne        = errors.New("can not remove the last validator")
+	ErrValidatorJailed         = errors.New("validator jailed")
+)
+
+// check tx type related fields, before verifying against chain state
+func verifyTxType(tx *Transaction) error {
+	switch tx.txType {
+	case TxTypeTransfer:
+		return nil
+	case TxTypeValidatorJoin, TxTypeValidatorLeave, TxTypeValidatorVote:
+		if tx.to == nil {
+			return ErrTxValidatorRecipient
+		}
+		if tx.amount != nil && tx.amount.Sign() != 0 {
+			return ErrTxValidatorAmount
+		}
+		return nil
+	}
+	return ErrTxTypeUnknown
+}
+
+// approval votes needed with n validators
+func validatorQuorum(n int) int {
+	return 2*n/3 + 1
+}
+
+func validatorStr(addr common.Address) string {
+	return address.NewAddressFromCommonAddress(addr).String()
+}
+
+func containsStr(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// drop validator requests expired at height from consensus trie
+func expireValidatorProposals(ct state.ConsensusTrie, height uint64) {
+	proposals := ct.GetValidatorProposals()
+	remain := make([]*state.ValidatorProposal, 0, len(proposals))
+	for _, p := range proposals {
+		if p.Height+ValidatorProposalExpiry > height {
+			remain = append(remain, p)
+		}
+	}
+	if len(remain) < len(proposals) {
+		ct.SetValidatorProposals(remain)
+	}
+}
+
+// activate validator changes reaching height in consensus trie, dropping
+// expired requests, returns true if validator set changed
+func activateValidatorChanges(ct state.ConsensusTrie, height uint64) bool {
+	expireValidatorProposals(ct, height)
+	changes := ct.GetValidatorChanges()
+	if len(changes) == 0 {
+		return false
+	}
+	var (
+		validators = ct.GetValidators()
+		remain     = make([]*state.ValidatorChange, 0, len(changes))
+		activated  = false
+	)
+	for _, change := range changes {
+		if change.Height > height {
+			remain = append(remain, change)
+			continue
+		}
+		next := make([]string, 0, len(validators)+len(change.Joins))
+		for _, v := range validators {
+			if !containsStr(change.Quits, v) {
+				next = append(next, v)
+			}
+		}
+		for _, v := range change.Joins {
+			if !containsStr(next, v) {
+				next = append(next, v)
+			}
+		}
+		validators = next
+		activated = true
+	}
+	if activated {
+		ct.SetValidators(validators)
+		ct.SetValidatorChanges(remain)
+	}
+	return activated
+}
+
+// apply a validator tx from sender in block of height to consensus trie
+func applyValidatorTx(ct state.ConsensusTrie, height uint64, from common.Address, tx *Transaction) error {
+	if err := verifyTxType(tx); err != nil {
+		return err
+	}
+	var (
+		validators = ct.GetValidators()
+		proposals  = ct.GetValidatorProposals()
+		changes    = ct.GetValidatorChanges()
+		sender     = validatorStr(from)
+		target     = validatorStr(*tx.to)
+	)
+	// locate request on target, either voting or waiting for activation
+	var proposal *state.ValidatorProposal
+	for _, p := range proposals {
+		if p.Target == target {
+			proposal = p
+			break
+		}
+	}
+	scheduled := false
+	for _, change := range changes {
+		if containsStr(change.Joins, target) || containsStr(change.Quits, target) {
+			scheduled = true
+			break
+		}
+	}
+
+	switch tx.txType {
+	case TxTypeValidatorJoin:
+		if sender != target {
+			return ErrValidatorJoinNotSelf
+		}
+		if containsStr(validators, target) {
+			return ErrValidatorAlready
+		}
+		if proposal != nil || scheduled {
+			return ErrValidatorPending
+		}
+		if isJailed(ct.GetJailed(), target) {
+			return ErrValidatorJailed
+		}
+		joins := 0
+		for _, p := range proposals {
+			if TxType(p.Type) == TxTypeValidatorJoin {
+				joins++
+			}
+		}
+		if joins >= MaxValidatorJoinProposals {
+			return ErrValidatorProposalsFull
+		}
+		proposal = &state.ValidatorProposal{
+			Type:   uint32(TxTypeValidatorJoin),
+			Target: target,
+			Height: height,
+		}
+		proposals = append(proposals, proposal)
+	case TxTypeValidatorLeave:
+		if !containsStr(validators, target) {
+			return ErrValidatorNotFound
+		}
+		if sender != target && !containsStr(validators, sender) {
+			return ErrValidatorNotFound
+		}
+		if proposal != nil || scheduled {
+			return ErrValidatorPending
+		}
+		quitting := 0
+		for _, change := range changes {
+			quitting += len(change.Quits)
+		}
+		if len(validators)-quitting <= 1 {
+			return ErrValidatorLastOne
+		}
+		proposal = &state.ValidatorProposal{
+			Type:   uint32(TxTypeValidatorLeave),
+			Target: target,
+			Height: height,
+		}
+		// request from a validator counts as its approval
+		if containsStr(validators, sender) {
+			proposal.Votes = []string{sender}
+		}
+		proposals = append(proposals, proposal)
+	case TxTypeValidatorVote:
+		if !containsStr(validators, sender) {
+			return ErrValidatorNotFound
+		}
+		if proposal == nil {
+			return ErrValidatorNoRequest
+		}
+		if containsStr(proposal.Votes, sender) {
+			return ErrValidatorVoteDuplicated
+		}
+		proposal.Votes = append(proposal.Votes, sender)
+	}
+
+	// count votes from current validators only, members may have rotated
+	// since the request
+	votes := 0
+	for _, v := range proposal.Votes {
+		if containsStr(validators, v) {
+			votes++
+		}
+	}
+	if votes < validatorQuorum(len(validators)) {
+		ct.SetValidatorProposals(proposals)
+		return nil
+	}
+
+	// approved, schedule change at activation height
+	remain := make([]*state.ValidatorProposal, 0, len(proposals))
+	for _, p := range proposals {
+		if p != proposal {
+			remain = append(remain, p)
+		}
+	}
+	ct.SetValidatorProposals(remain)
+	activation := height + ValidatorActivationDelay
+	var change *state.ValidatorChange
+	for _, c := range changes {
+		if c.Height == activation {
+			change = c
+			break
+		}
+	}
+	if change == nil {
+		change = &state.ValidatorChange{Height: activation}
+		changes = append(changes, change)
+	}
+	if TxType(proposal.Type) == TxTypeValidatorJoin {
+		change.Joins = append(change.Joins, target)
+	} else {
+		change.Quits = append(change.Quits, target)
+	}
+	ct.SetValidatorChanges(changes)
+	return nil
+}
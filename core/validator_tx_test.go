@@ -0,0 +1,218 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/core/state"
+	"github.com/yeeco/gyee/persistent"
+)
+
+func testValidatorAddr(i byte) common.Address {
+	var addr common.Address
+	addr[0] = 0xee
+	addr[1] = i
+	return addr
+}
+
+func TestValidatorTxJoinLeave(t *testing.T) {
+	ct, err := state.NewConsensusTrie(common.EmptyHash, GetStateDB(persistent.NewMemoryStorage()))
+	if err != nil {
+		t.Fatalf("NewConsensusTrie() %v", err)
+	}
+	addrs := make([]common.Address, 5)
+	for i := range addrs {
+		addrs[i] = testValidatorAddr(byte(i))
+	}
+	initial := []string{
+		validatorStr(addrs[0]), validatorStr(addrs[1]),
+		validatorStr(addrs[2]), validatorStr(addrs[3]),
+	}
+	ct.SetValidators(initial)
+	initialRoot := ct.Root()
+	candidate := addrs[4]
+	apply := func(height uint64, from common.Address, txType TxType, target common.Address) error {
+		tx := NewValidatorTransaction(uint32(TestNetID), 0, txType, &target)
+		return applyValidatorTx(ct, height, from, tx)
+	}
+
+	// join request and votes, 3 of 4 validators to approve
+	if err := apply(10, candidate, TxTypeValidatorJoin, candidate); err != nil {
+		t.Fatalf("join %v", err)
+	}
+	if err := apply(10, candidate, TxTypeValidatorJoin, candidate); err != ErrValidatorPending {
+		t.Fatalf("duplicated join %v", err)
+	}
+	if err := apply(10, addrs[0], TxTypeValidatorJoin, addrs[0]); err != ErrValidatorAlready {
+		t.Fatalf("validator join %v", err)
+	}
+	if err := apply(10, candidate, TxTypeValidatorVote, candidate); err != ErrValidatorNotFound {
+		t.Fatalf("vote from candidate %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := apply(11, addrs[i], TxTypeValidatorVote, candidate); err != nil {
+			t.Fatalf("vote %d %v", i, err)
+		}
+	}
+	if err := apply(11, addrs[1], TxTypeValidatorVote, candidate); err != ErrValidatorVoteDuplicated {
+		t.Fatalf("duplicated vote %v", err)
+	}
+	if len(ct.GetValidatorChanges()) != 0 {
+		t.Fatalf("approved without quorum")
+	}
+	if err := apply(12, addrs[2], TxTypeValidatorVote, candidate); err != nil {
+		t.Fatalf("vote 2 %v", err)
+	}
+	changes := ct.GetValidatorChanges()
+	if len(changes) != 1 || changes[0].Height != 12+ValidatorActivationDelay ||
+		len(changes[0].Joins) != 1 || changes[0].Joins[0] != validatorStr(candidate) {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+	if len(ct.GetValidatorProposals()) != 0 {
+		t.Fatalf("proposal not removed after approval")
+	}
+	if err := apply(13, addrs[3], TxTypeValidatorVote, candidate); err != ErrValidatorNoRequest {
+		t.Fatalf("vote after approval %v", err)
+	}
+
+	// activation
+	if activateValidatorChanges(ct, 11+ValidatorActivationDelay) {
+		t.Fatalf("activated before height")
+	}
+	if !activateValidatorChanges(ct, 12+ValidatorActivationDelay) {
+		t.Fatalf("not activated at height")
+	}
+	if validators := ct.GetValidators(); len(validators) != 5 || validators[4] != validatorStr(candidate) {
+		t.Fatalf("unexpected validators %v", validators)
+	}
+
+	// leave request from a validator counts as its vote, 4 of 5 to approve
+	if err := apply(40, addrs[0], TxTypeValidatorLeave, addrs[1]); err != nil {
+		t.Fatalf("leave %v", err)
+	}
+	for _, i := range []int{2, 3, 4} {
+		if err := apply(41, addrs[i], TxTypeValidatorVote, addrs[1]); err != nil {
+			t.Fatalf("vote %d %v", i, err)
+		}
+	}
+	if !activateValidatorChanges(ct, 41+ValidatorActivationDelay) {
+		t.Fatalf("leave not activated")
+	}
+	for _, v := range ct.GetValidators() {
+		if v == validatorStr(addrs[1]) {
+			t.Fatalf("validator not removed")
+		}
+	}
+	if len(ct.GetValidatorChanges()) != 0 || len(ct.GetValidatorProposals()) != 0 {
+		t.Fatalf("governance lists not cleared")
+	}
+
+	// cleared lists leave no trace in trie
+	ct.SetValidators(initial)
+	if ct.Root() != initialRoot {
+		t.Fatalf("trie root mismatch after lists cleared")
+	}
+}
+
+func TestValidatorProposalLimits(t *testing.T) {
+	ct, err := state.NewConsensusTrie(common.EmptyHash, GetStateDB(persistent.NewMemoryStorage()))
+	if err != nil {
+		t.Fatalf("NewConsensusTrie() %v", err)
+	}
+	ct.SetValidators([]string{
+		validatorStr(testValidatorAddr(0)), validatorStr(testValidatorAddr(1)),
+	})
+	join := func(height uint64, i byte) error {
+		candidate := testValidatorAddr(i)
+		tx := NewValidatorTransaction(uint32(TestNetID), 0, TxTypeValidatorJoin, &candidate)
+		return applyValidatorTx(ct, height, candidate, tx)
+	}
+
+	// join requests capped
+	for i := 0; i < MaxValidatorJoinProposals; i++ {
+		if err := join(10, byte(10+i)); err != nil {
+			t.Fatalf("join %d %v", i, err)
+		}
+	}
+	if err := join(10, 200); err != ErrValidatorProposalsFull {
+		t.Fatalf("join over cap %v", err)
+	}
+	// leave from a validator not blocked by pending joins
+	leave := testValidatorAddr(1)
+	tx := NewValidatorTransaction(uint32(TestNetID), 0, TxTypeValidatorLeave, &leave)
+	if err := applyValidatorTx(ct, 20, testValidatorAddr(0), tx); err != nil {
+		t.Fatalf("leave %v", err)
+	}
+
+	// requests expire unapproved, without changing validators
+	if activateValidatorChanges(ct, 10+ValidatorProposalExpiry-1) {
+		t.Fatalf("validators changed before expiry")
+	}
+	if n := len(ct.GetValidatorProposals()); n != MaxValidatorJoinProposals+1 {
+		t.Fatalf("%d proposals before expiry", n)
+	}
+	if activateValidatorChanges(ct, 10+ValidatorProposalExpiry) {
+		t.Fatalf("validators changed on expiry")
+	}
+	proposals := ct.GetValidatorProposals()
+	if len(proposals) != 1 || proposals[0].Target != validatorStr(leave) || proposals[0].Height != 20 {
+		t.Fatalf("unexpected proposals %v", proposals)
+	}
+	if err := join(10+ValidatorProposalExpiry, 200); err != nil {
+		t.Fatalf("join after expiry %v", err)
+	}
+	if len(ct.GetValidators()) != 2 {
+		t.Fatalf("unexpected validators %v", ct.GetValidators())
+	}
+}
+
+func TestValidatorTxVerify(t *testing.T) {
+	target := testValidatorAddr(0)
+	tx := NewValidatorTransaction(uint32(TestNetID), 0, TxTypeValidatorJoin, &target)
+	if err := verifyTxType(tx); err != nil {
+		t.Fatalf("verifyTxType() %v", err)
+	}
+	tx.amount = big.NewInt(1)
+	if err := verifyTxType(tx); err != ErrTxValidatorAmount {
+		t.Fatalf("amount %v", err)
+	}
+	tx = NewValidatorTransaction(uint32(TestNetID), 0, TxTypeValidatorVote, nil)
+	if err := verifyTxType(tx); err != ErrTxValidatorRecipient {
+		t.Fatalf("recipient %v", err)
+	}
+	tx = NewValidatorTransaction(uint32(TestNetID), 0, TxType(100), &target)
+	if err := verifyTxType(tx); err != ErrTxTypeUnknown {
+		t.Fatalf("type %v", err)
+	}
+
+	// type survives encoding
+	enc, err := tx.encode(true)
+	if err != nil {
+		t.Fatalf("encode() %v", err)
+	}
+	decoded := new(Transaction)
+	if err := decoded.Decode(enc); err != nil {
+		t.Fatalf("Decode() %v", err)
+	}
+	if decoded.Type() != TxType(100) {
+		t.Fatalf("type mismatch %v", decoded.Type())
+	}
+}
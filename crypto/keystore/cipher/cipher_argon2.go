@@ -47,6 +47,9 @@ const (
 	Argon2DKLen = 64
 
 	Argon2CipherName = "aes-256-ctr"
+
+	// argon2Version the only key file version written
+	argon2Version = 1
 )
 
 type Argon2 struct {
@@ -82,7 +85,7 @@ func (a *Argon2) EncryptKey(address string, data []byte, passphrase []byte) ([]b
 		Address: address,
 		Crypto:  *crypto,
 		ID:      uuid.String(),
-		Version: 1,
+		Version: argon2Version,
 	}
 	return json.Marshal(encryptedKeyJSON)
 }
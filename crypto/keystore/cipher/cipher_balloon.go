@@ -52,6 +52,9 @@ const (
 	BalloonDKLen = 64
 
 	BalloonCipherName = "aes-256-ctr"
+
+	// balloonVersion the only key file version written
+	balloonVersion = 1
 )
 
 type Balloon struct {
@@ -85,7 +88,7 @@ func (b *Balloon) EncryptKey(address string, data []byte, passphrase []byte) ([]
 		Address: address,
 		Crypto:  *crypto,
 		ID:      uuid.String(),
-		Version: 1,
+		Version: balloonVersion,
 	}
 	return json.Marshal(encryptedKeyJSON)
 }
@@ -95,3 +95,69 @@ func Test_Cipher_Balloon(t *testing.T) {
 		t.Errorf("Decrypt() = %v, data %v", want, data)
 	}
 }
+
+func Test_Cipher_KeyInfo(t *testing.T) {
+	passphrase := []byte("passphrase")
+	data, _ := hex.DecodeString("0eb3be2db3a534c192be5570c6c42f590eb3be2db3a534c192be5570c6c42f59")
+	cipher, err := NewCipher(ScryptKDF, map[string]int{"n": 1 << 12, "p": 6})
+	if err != nil {
+		t.Fatalf("NewCipher() error, %v", err)
+	}
+	got, err := cipher.EncryptKey("address00000", data, passphrase)
+	if err != nil {
+		t.Fatalf("Encrypt() error, %v", err)
+	}
+
+	info, err := ParseKeyInfo(got)
+	if err != nil {
+		t.Fatalf("ParseKeyInfo() error, %v", err)
+	}
+	if info.Address != "address00000" || info.Version != currentVersion || info.Cipher != ScryptCipherName {
+		t.Errorf("ParseKeyInfo() = %v", info)
+	}
+	if info.KDF != ScryptKDF || info.KDFParams["n"] != 1<<12 || info.KDFParams["r"] != StandardScryptR ||
+		info.KDFParams["p"] != 6 {
+		t.Errorf("ParseKeyInfo() = %v", info)
+	}
+	if !info.IsWeak() {
+		t.Errorf("IsWeak() = false, n %d", info.KDFParams["n"])
+	}
+
+	for _, c := range []struct {
+		info *KeyInfo
+		weak bool
+	}{
+		{&KeyInfo{Version: currentVersion, KDF: ScryptKDF, KDFParams: map[string]int{"n": StandardScryptN, "r": StandardScryptR, "p": StandardScryptP}}, false},
+		{&KeyInfo{Version: version3, KDF: ScryptKDF, KDFParams: map[string]int{"n": StandardScryptN, "r": StandardScryptR, "p": StandardScryptP}}, true},
+		{&KeyInfo{Version: argon2Version, KDF: Argon2KDF, KDFParams: map[string]int{"time": StandardArgon2Time, "memory": StandardArgon2Memory}}, false},
+		{&KeyInfo{Version: 0, KDF: Argon2KDF, KDFParams: map[string]int{"time": StandardArgon2Time, "memory": StandardArgon2Memory}}, true},
+		{&KeyInfo{Version: argon2Version, KDF: Argon2KDF, KDFParams: map[string]int{"time": 1, "memory": StandardArgon2Memory}}, true},
+		{&KeyInfo{Version: balloonVersion, KDF: BalloonKDF, KDFParams: map[string]int{"time": StandardBalloonTime, "space": StandardBalloonSpace}}, false},
+		{&KeyInfo{Version: 0, KDF: BalloonKDF, KDFParams: map[string]int{"time": StandardBalloonTime, "space": StandardBalloonSpace}}, true},
+		{&KeyInfo{Version: balloonVersion, KDF: BalloonKDF, KDFParams: map[string]int{"time": StandardBalloonTime, "space": 1024}}, true},
+		{&KeyInfo{Version: currentVersion, KDF: "pbkdf2"}, true},
+	} {
+		if weak := c.info.IsWeak(); weak != c.weak {
+			t.Errorf("IsWeak() = %v, info %v", weak, c.info)
+		}
+	}
+
+	detected, err := CipherForKey(got)
+	if err != nil {
+		t.Fatalf("CipherForKey() error, %v", err)
+	}
+	want, err := detected.DecryptKey(got, passphrase)
+	if err != nil {
+		t.Fatalf("Decrypt() error, %v", err)
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Decrypt() = %v, data %v", want, data)
+	}
+
+	if _, err := NewCipher(ScryptKDF, map[string]int{"n": 1000}); err != ErrKDFParamInvalid {
+		t.Errorf("NewCipher() n not power of 2, err %v", err)
+	}
+	if _, err := NewCipher("pbkdf2", nil); err != ErrKDFInvalid {
+		t.Errorf("NewCipher() unknown kdf, err %v", err)
+	}
+}
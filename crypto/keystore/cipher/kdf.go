@@ -0,0 +1,175 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  The gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package cipher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrKDFParamInvalid kdf param not supported
+	ErrKDFParamInvalid = errors.New("kdf param not supported")
+)
+
+// KeyInfo describes how a key file was encrypted, as read from the file
+// itself, without the need of the passphrase.
+type KeyInfo struct {
+	Address   string
+	Version   int
+	Cipher    string
+	KDF       string
+	KDFParams map[string]int
+}
+
+// ParseKeyInfo reads the version, cipher and kdf settings of a key file.
+func ParseKeyInfo(keyjson []byte) (*KeyInfo, error) {
+	keyJSON := new(encryptedKeyJSON)
+	if err := json.Unmarshal(keyjson, keyJSON); err != nil {
+		return nil, err
+	}
+	info := &KeyInfo{
+		Address:   keyJSON.Address,
+		Version:   keyJSON.Version,
+		Cipher:    keyJSON.Crypto.Cipher,
+		KDF:       keyJSON.Crypto.KDF,
+		KDFParams: make(map[string]int),
+	}
+	for k, v := range keyJSON.Crypto.KDFParams {
+		switch v.(type) {
+		case int, float64:
+			info.KDFParams[k] = ensureInt(v)
+		}
+	}
+	return info, nil
+}
+
+// IsWeak reports whether the key file was encrypted with an outdated
+// format or with cost parameters below the standard ones of its kdf.
+func (ki *KeyInfo) IsWeak() bool {
+	p := ki.KDFParams
+	switch ki.KDF {
+	case ScryptKDF:
+		return ki.Version != currentVersion ||
+			p["n"] < StandardScryptN || p["r"] < StandardScryptR || p["p"] < StandardScryptP
+	case Argon2KDF:
+		return ki.Version != argon2Version ||
+			p["time"] < StandardArgon2Time || p["memory"] < StandardArgon2Memory
+	case BalloonKDF:
+		return ki.Version != balloonVersion ||
+			p["time"] < StandardBalloonTime || p["space"] < StandardBalloonSpace
+	}
+	return true
+}
+
+func (ki *KeyInfo) String() string {
+	names := make([]string, 0, len(ki.KDFParams))
+	for k := range ki.KDFParams {
+		if k == "dklen" {
+			continue
+		}
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	params := make([]string, len(names))
+	for i, k := range names {
+		params[i] = fmt.Sprintf("%s=%d", k, ki.KDFParams[k])
+	}
+	return fmt.Sprintf("version=%d cipher=%s kdf=%s %s",
+		ki.Version, ki.Cipher, ki.KDF, strings.Join(params, " "))
+}
+
+// NewCipher creates the cipher for the kdf name, with the standard cost
+// parameters overridden by params. An empty kdf selects scrypt.
+func NewCipher(kdf string, params map[string]int) (Cipher, error) {
+	switch strings.ToLower(kdf) {
+	case "", ScryptKDF:
+		s := NewScrypt()
+		for k, v := range params {
+			switch k {
+			case "n":
+				s.N = v
+			case "r":
+				s.R = v
+			case "p":
+				s.P = v
+			default:
+				return nil, ErrKDFParamInvalid
+			}
+		}
+		if s.N <= 1 || s.N&(s.N-1) != 0 || s.R <= 0 || s.P <= 0 {
+			return nil, ErrKDFParamInvalid
+		}
+		return s, nil
+	case "argon2", Argon2KDF:
+		a := NewArgon2()
+		for k, v := range params {
+			switch k {
+			case "time":
+				a.Time = uint32(v)
+			case "memory":
+				a.Memory = uint32(v)
+			case "threads":
+				a.Threads = uint8(v)
+			default:
+				return nil, ErrKDFParamInvalid
+			}
+		}
+		if a.Time == 0 || a.Memory == 0 || a.Threads == 0 {
+			return nil, ErrKDFParamInvalid
+		}
+		return a, nil
+	case BalloonKDF:
+		b := NewBalloon()
+		for k, v := range params {
+			switch k {
+			case "time":
+				b.Time = uint64(v)
+			case "space":
+				b.Space = uint64(v)
+			default:
+				return nil, ErrKDFParamInvalid
+			}
+		}
+		if b.Time == 0 || b.Space == 0 {
+			return nil, ErrKDFParamInvalid
+		}
+		return b, nil
+	}
+	return nil, ErrKDFInvalid
+}
+
+// CipherForKey returns the cipher able to decrypt the key file, detected
+// from the kdf recorded in it.
+func CipherForKey(keyjson []byte) (Cipher, error) {
+	info, err := ParseKeyInfo(keyjson)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.KDF) == 0 {
+		return nil, ErrKDFInvalid
+	}
+	// cost parameters used for decryption are read back from the key file
+	return NewCipher(info.KDF, nil)
+}
@@ -53,28 +53,48 @@ var (
 )
 
 type unlocked struct {
-	key   []byte
-	timer *time.Timer
+	key    []byte
+	timer  *time.Timer
+	expire time.Time
 }
 
 type Keystore struct {
 	ksDirPath string
 	cipher    cipher.Cipher
 	entries   map[string][]byte
+	files     map[string]string
 	unlocked  map[string]*unlocked
 
 	mu sync.RWMutex
 }
 
-func NewKeystoreWithConfig(config *config.Config) *Keystore {
+func NewKeystoreWithConfig(config *config.Config) (*Keystore, error) {
+	var (
+		kdf    string
+		params map[string]int
+	)
+	if config.Chain != nil {
+		kdf = config.Chain.KeyKDF
+		params = config.Chain.KeyKDFParams
+	}
+	c, err := cipher.NewCipher(kdf, params)
+	if err != nil {
+		return nil, err
+	}
 	//TODO: 用config里的keydir来拼
-	return NewKeystore(filepath.Join(config.NodeDir, "keystore"))
+	return NewKeystoreWithCipher(filepath.Join(config.NodeDir, "keystore"), c), nil
 }
 
 func NewKeystore(dirPath string) *Keystore {
+	return NewKeystoreWithCipher(dirPath, cipher.NewScrypt())
+}
+
+// NewKeystoreWithCipher creates a keystore encrypting new keys with c.
+// Existing key files are decrypted with the cipher recorded in each file.
+func NewKeystoreWithCipher(dirPath string, c cipher.Cipher) *Keystore {
 	ks := &Keystore{
 		ksDirPath: dirPath,
-		cipher:    cipher.NewScrypt(),
+		cipher:    c,
 		unlocked:  make(map[string]*unlocked),
 	}
 	//load from file dir
@@ -102,6 +122,7 @@ func (ks *Keystore) SetKey(address string, key []byte, passphrase []byte) error
 	ks.mu.Lock()
 	defer ks.mu.Unlock()
 	ks.entries[address] = keyjson
+	ks.files[address] = filename
 
 	return err
 }
@@ -114,14 +135,14 @@ func (ks *Keystore) GetKey(address string, passphrase []byte) ([]byte, error) {
 		return nil, ErrInvalidPassphrase
 	}
 
+	// the kdf runs without holding the lock
 	ks.mu.RLock()
-	defer ks.mu.RUnlock()
-
 	entry, ok := ks.entries[address]
+	ks.mu.RUnlock()
 	if !ok {
 		return nil, ErrNotFound
 	}
-	data, err := ks.cipher.DecryptKey(entry, passphrase)
+	data, err := decryptKey(entry, passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +150,65 @@ func (ks *Keystore) GetKey(address string, passphrase []byte) ([]byte, error) {
 	return data, nil
 }
 
+// KeyInfo returns the version, cipher and kdf settings of the key file.
+func (ks *Keystore) KeyInfo(address string) (*cipher.KeyInfo, error) {
+	if len(address) == 0 {
+		return nil, ErrNeedAddress
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	entry, ok := ks.entries[address]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return cipher.ParseKeyInfo(entry)
+}
+
+// Upgrade re-encrypts the key with c, or with the default cipher of the
+// keystore if c is nil, and replaces the key file in place.
+func (ks *Keystore) Upgrade(address string, passphrase []byte, c cipher.Cipher) error {
+	if len(address) == 0 {
+		return ErrNeedAddress
+	}
+	if len(passphrase) == 0 {
+		return ErrInvalidPassphrase
+	}
+	if c == nil {
+		c = ks.cipher
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	entry, ok := ks.entries[address]
+	if !ok {
+		return ErrNotFound
+	}
+	key, err := decryptKey(entry, passphrase)
+	if err != nil {
+		return err
+	}
+	defer util.ZeroBytes(key)
+
+	keyjson, err := c.EncryptKey(address, key, passphrase)
+	if err != nil {
+		return err
+	}
+
+	filename, ok := ks.files[address]
+	if !ok {
+		filename = filepath.Join(ks.ksDirPath, keyFileName(address))
+	}
+	if err := writeKeyFile(filename, keyjson); err != nil {
+		return err
+	}
+	ks.entries[address] = keyjson
+	ks.files[address] = filename
+	return nil
+}
+
 func (ks *Keystore) Delete(address string) error {
 	if len(address) == 0 {
 		return ErrNeedAddress
@@ -174,7 +254,10 @@ func (ks *Keystore) Lock(address string) error {
 		return ErrNeedAddress
 	}
 
+	// removed at once, the timer fired to end the expire routine
 	if u, ok := ks.unlocked[address]; ok {
+		util.ZeroBytes(u.key)
+		delete(ks.unlocked, address)
 		u.timer.Reset(time.Duration(0))
 		return nil
 	}
@@ -183,47 +266,48 @@ func (ks *Keystore) Lock(address string) error {
 }
 
 func (ks *Keystore) Unlock(address string, passphrase []byte, timeout time.Duration) error {
+	// the passphrase is checked even if already unlocked, so that extending
+	// an unlock needs the passphrase as well
+	key, err := ks.GetKey(address, passphrase)
+	if err != nil {
+		return err
+	}
+	ks.UnlockKey(address, key, timeout)
+	return nil
+}
+
+// UnlockKey unlocks address with key decrypted by GetKey, or extends the
+// unlock if already unlocked. The keystore takes ownership of key.
+func (ks *Keystore) UnlockKey(address string, key []byte, timeout time.Duration) {
 	ks.mu.Lock()
 	defer ks.mu.Unlock()
 
-	if len(address) == 0 {
-		return ErrNeedAddress
-	}
-
 	unlockedKey, ok := ks.unlocked[address]
 	if ok == true {
+		util.ZeroBytes(key)
 		unlockedKey.timer.Reset(timeout)
+		unlockedKey.expire = time.Now().Add(timeout)
 	} else {
-		//key, err := ks.GetKey(address, passphrase)
-		entry, ok := ks.entries[address]
-		if !ok {
-			return ErrNotFound
-		}
-		key, err := ks.cipher.DecryptKey(entry, passphrase)
-		if err != nil {
-			return err
-		}
-		u := &unlocked{key: key, timer: time.NewTimer(timeout)}
+		u := &unlocked{key: key, timer: time.NewTimer(timeout), expire: time.Now().Add(timeout)}
 		ks.unlocked[address] = u
-		go ks.expire(address)
+		go ks.expire(address, u)
 	}
-
-	return nil
 }
 
-func (ks *Keystore) expire(address string) {
-	if u, ok := ks.unlocked[address]; ok == true {
-		defer u.timer.Stop()
-		select {
-		case <-u.timer.C:
-			ks.mu.Lock()
-			util.ZeroBytes(u.key)
-			delete(ks.unlocked, address)
-			ks.mu.Unlock()
-		}
+func (ks *Keystore) expire(address string, u *unlocked) {
+	defer u.timer.Stop()
+	<-u.timer.C
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	util.ZeroBytes(u.key)
+	// not unlocked again after locked
+	if ks.unlocked[address] == u {
+		delete(ks.unlocked, address)
 	}
 }
 
+// GetUnlocked returns a copy of the unlocked key, the unlocked one is zeroed
+// when it expires or is locked.
 func (ks *Keystore) GetUnlocked(address string) ([]byte, error) {
 	if len(address) == 0 {
 		return nil, ErrNeedAddress
@@ -236,7 +320,19 @@ func (ks *Keystore) GetUnlocked(address string) ([]byte, error) {
 	if !ok {
 		return nil, ErrNotUnlocked
 	}
-	return u.key, nil
+	return append([]byte(nil), u.key...), nil
+}
+
+// Unlocked returns the unlocked addresses with the time they expire.
+func (ks *Keystore) Unlocked() map[string]time.Time {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	ret := make(map[string]time.Time, len(ks.unlocked))
+	for address, u := range ks.unlocked {
+		ret[address] = u.expire
+	}
+	return ret
 }
 
 func (ks *Keystore) loadKeyFiles() {
@@ -262,6 +358,7 @@ func (ks *Keystore) loadKeyFiles() {
 	ks.mu.Lock()
 	defer ks.mu.Unlock()
 	ks.entries = make(map[string][]byte)
+	ks.files = make(map[string]string)
 
 	for _, file := range files {
 		filename := filepath.Join(ks.ksDirPath, file.Name())
@@ -299,10 +396,21 @@ func (ks *Keystore) loadKeyFiles() {
 				continue
 			}
 			ks.entries[keyJSON.Address] = content
+			ks.files[keyJSON.Address] = filename
 		}
 	}
 }
 
+// decryptKey decrypts the key file with the cipher recorded in it, so that
+// keys created with a different cipher than the current default still load.
+func decryptKey(keyjson []byte, passphrase []byte) ([]byte, error) {
+	c, err := cipher.CipherForKey(keyjson)
+	if err != nil {
+		return nil, err
+	}
+	return c.DecryptKey(keyjson, passphrase)
+}
+
 func writeKeyFile(file string, content []byte) error {
 	const dirPerm = 0700
 	if err := os.MkdirAll(filepath.Dir(file), dirPerm); err != nil {
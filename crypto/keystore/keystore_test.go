@@ -22,7 +22,13 @@ package keystore
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
+
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/crypto/keystore/cipher"
+	"github.com/yeeco/gyee/crypto/secp256k1"
 )
 
 func TestKeystore_SetKey(t *testing.T) {
@@ -75,3 +81,50 @@ func TestKeystore_Contains(t *testing.T) {
 		fmt.Println("addr00003 true")
 	}
 }
+
+func TestKeystore_Upgrade(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key := secp256k1.GenerateKey()
+	address, err := address.NewAddressFromPublicKey(key.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := address.String()
+
+	weak := &cipher.Scrypt{N: 1 << 12, R: 8, P: 6}
+	ks := NewKeystoreWithCipher(dir, weak)
+	if err := ks.SetKey(addr, key.PrivateKey(), []byte("password1")); err != nil {
+		t.Fatal(err)
+	}
+	info, err := ks.KeyInfo(addr)
+	if err != nil || !info.IsWeak() {
+		t.Fatalf("KeyInfo() = %v, err %v", info, err)
+	}
+
+	argon2 := &cipher.Argon2{Time: cipher.StandardArgon2Time, Memory: cipher.StandardArgon2Memory, Threads: 1}
+	if err := ks.Upgrade(addr, []byte("password2"), argon2); err != cipher.ErrDecrypt {
+		t.Errorf("Upgrade() wrong password, err %v", err)
+	}
+	if err := ks.Upgrade(addr, []byte("password1"), argon2); err != nil {
+		t.Fatal(err)
+	}
+
+	// reload from disk, the upgraded file should replace the old one
+	ks = NewKeystore(dir)
+	info, err = ks.KeyInfo(addr)
+	if err != nil || info.KDF != cipher.Argon2KDF || info.IsWeak() {
+		t.Fatalf("KeyInfo() = %v, err %v", info, err)
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("key files %d", len(files))
+	}
+	if _, err := ks.GetKey(addr, []byte("password1")); err != nil {
+		t.Errorf("GetKey() err %v", err)
+	}
+}
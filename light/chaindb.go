@@ -0,0 +1,108 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+package light
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/persistent"
+)
+
+// Key / KeyPrefix for light chain used in persistent.Storage,
+// headers are stored as answered by peers, with signatures
+const (
+	KeyLastHeader = "LastHeader"
+
+	KeyPrefixHeader     = "lhdr-" // blockNum => encoded signed header
+	KeyPrefixHash2Num   = "lh2n-" // blockHash => blockNum
+	KeyPrefixValidators = "lval-" // consensusRoot => rlp validators
+)
+
+func getLastHeader(getter persistent.Getter) (uint64, bool) {
+	enc, err := getter.Get([]byte(KeyLastHeader))
+	if err != nil || len(enc) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(enc), true
+}
+
+func putLastHeader(putter persistent.Putter, num uint64) error {
+	return putter.Put([]byte(KeyLastHeader), encodeNum(num))
+}
+
+func getHeader(getter persistent.Getter, num uint64) []byte {
+	enc, err := getter.Get(keyHeader(num))
+	if err != nil {
+		return nil
+	}
+	return enc
+}
+
+func putHeader(putter persistent.Putter, num uint64, hash common.Hash, enc []byte) error {
+	if err := putter.Put(keyHeader(num), enc); err != nil {
+		return err
+	}
+	return putter.Put(keyHash2Num(hash), encodeNum(num))
+}
+
+func getHash2Num(getter persistent.Getter, hash common.Hash) (uint64, bool) {
+	enc, err := getter.Get(keyHash2Num(hash))
+	if err != nil || len(enc) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(enc), true
+}
+
+func getValidators(getter persistent.Getter, root common.Hash) []string {
+	enc, err := getter.Get(keyValidators(root))
+	if err != nil {
+		return nil
+	}
+	var validators []string
+	if err := rlp.DecodeBytes(enc, &validators); err != nil {
+		return nil
+	}
+	return validators
+}
+
+func putValidators(putter persistent.Putter, root common.Hash, validators []string) error {
+	enc, err := rlp.EncodeToBytes(validators)
+	if err != nil {
+		return err
+	}
+	return putter.Put(keyValidators(root), enc)
+}
+
+func encodeNum(num uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, num)
+	return enc
+}
+
+func keyHeader(num uint64) []byte {
+	return append([]byte(KeyPrefixHeader), encodeNum(num)...)
+}
+
+func keyHash2Num(hash common.Hash) []byte {
+	return append([]byte(KeyPrefixHash2Num), hash[:]...)
+}
+
+func keyValidators(root common.Hash) []byte {
+	return append([]byte(KeyPrefixValidators), root[:]...)
+}
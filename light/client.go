@@ -0,0 +1,388 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package light implements the light node, following the chain by block
+// headers only. A header is accepted when signed by at least 2/3 of the
+// validators in the consensus trie of its parent, as full nodes do. Changes
+// of validators are learned with merkle proofs of the consensus trie, and
+// accounts are queried with merkle proofs of the state trie, both from full
+// peers, so no state is stored.
+package light
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/config"
+	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/core/state"
+	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/p2p"
+	"github.com/yeeco/gyee/persistent"
+)
+
+const (
+	SyncInterval   = 2 * time.Second
+	RequestTimeout = 10 * time.Second
+	ProofRetry     = 3 // peers asked for a proof, one may not have the state
+)
+
+var (
+	ErrUnknownChain          = errors.New("light: unknown chain, no genesis")
+	ErrGenesisMismatch       = errors.New("light: genesis mismatch with stored chain")
+	ErrHeaderNotNext         = errors.New("light: header not next to head")
+	ErrHeaderChainID         = errors.New("light: header of other chain")
+	ErrSignatureInsufficient = errors.New("light: header signed by less than 2/3 validators")
+	ErrProofUnavailable      = errors.New("light: no proof from peers")
+)
+
+// Client of a light node, with the verified header chain
+type Client struct {
+	chainID core.ChainID
+	p2p     p2p.Service
+	storage persistent.Storage
+	genesis *core.Block
+
+	lock       sync.RWMutex
+	head       *core.Block
+	validators map[common.Address]bool // validators of head, signing the next header
+
+	quitCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewClient(conf *config.Config, p2pSvc p2p.Service) (*Client, error) {
+	return NewClientWithGenesis(conf, nil, p2pSvc)
+}
+
+// NewClientWithGenesis creates client of chain with genesis, the genesis
+// of chain id in config is loaded if nil
+func NewClientWithGenesis(conf *config.Config, genesis *core.Genesis, p2pSvc p2p.Service) (*Client, error) {
+	log.Info("Create new light client")
+	chainID := core.ChainID(conf.Chain.ChainID)
+	if genesis == nil {
+		if chainID != core.MainNetID && chainID != core.TestNetID {
+			return nil, ErrUnknownChain
+		}
+		var err error
+		if genesis, err = core.LoadGenesis(chainID); err != nil {
+			return nil, err
+		}
+	}
+	gb, err := genesis.Block()
+	if err != nil {
+		return nil, err
+	}
+	storage, err := persistent.NewLevelStorage(filepath.Join(conf.NodeDir, "lightdata"))
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		chainID: chainID,
+		p2p:     p2pSvc,
+		storage: storage,
+		genesis: gb,
+		quitCh:  make(chan struct{}),
+	}
+	if err := c.loadHead(); err != nil {
+		storage.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// load head from storage, the genesis is trusted as head of empty storage
+func (c *Client) loadHead() error {
+	num, ok := getLastHeader(c.storage)
+	if !ok {
+		enc, err := c.genesis.HeaderBytes()
+		if err != nil {
+			return err
+		}
+		validators := make([]string, 0, len(c.genesis.ValidatorAddr()))
+		for _, addr := range c.genesis.ValidatorAddr() {
+			validators = append(validators, address.NewAddressFromCommonAddress(addr).String())
+		}
+		return c.writeHead(c.genesis, enc, validators)
+	}
+	if stored := c.HeaderByNumber(0); stored == nil || stored.Hash() != c.genesis.Hash() {
+		return ErrGenesisMismatch
+	}
+	head := c.HeaderByNumber(num)
+	if head == nil {
+		return ErrHeaderNotNext
+	}
+	validators, err := parseValidators(getValidators(c.storage, head.ConsensusRoot()))
+	if err != nil {
+		return err
+	}
+	c.head, c.validators = head, validators
+	return nil
+}
+
+func (c *Client) Start() error {
+	log.Info("Light client start...", "head", c.Head().Number())
+	c.wg.Add(1)
+	go c.loop()
+	return nil
+}
+
+func (c *Client) Stop() error {
+	log.Info("Light client stop...")
+	close(c.quitCh)
+	c.wg.Wait()
+	return c.storage.Close()
+}
+
+func (c *Client) ChainID() core.ChainID {
+	return c.chainID
+}
+
+// Head returns the last verified header
+func (c *Client) Head() *core.Block {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.head
+}
+
+// HeaderByNumber returns verified header of block number, as a block
+// of the header only
+func (c *Client) HeaderByNumber(num uint64) *core.Block {
+	enc := getHeader(c.storage, num)
+	if enc == nil {
+		return nil
+	}
+	b, err := core.ParseHeader(enc)
+	if err != nil {
+		log.Error("stored header decode failed", "H", num, "err", err)
+		return nil
+	}
+	return b
+}
+
+func (c *Client) HeaderByHash(hash common.Hash) *core.Block {
+	num, ok := getHash2Num(c.storage, hash)
+	if !ok {
+		return nil
+	}
+	return c.HeaderByNumber(num)
+}
+
+// GetAccount gets account of address in the state of head, proven by a full
+// peer, nil if the account does not exist
+func (c *Client) GetAccount(ctx context.Context, addr common.Address) (state.Account, error) {
+	root := c.Head().StateRoot()
+	for i := 0; i < ProofRetry; i++ {
+		rsp, err := c.p2p.GetProof(ctx, "", root[:], addr[:])
+		if err != nil {
+			return nil, err
+		}
+		if len(rsp.Items) == 0 {
+			// peer behind, or state pruned
+			continue
+		}
+		account, err := state.VerifyAccount(root, addr, rsp.Items)
+		if err != nil {
+			c.reportPeer(rsp.From, p2p.PeerOffenceInvalid)
+			continue
+		}
+		return account, nil
+	}
+	return nil, ErrProofUnavailable
+}
+
+// TxBroadcast sends tx to peers, light node has no tx pool
+func (c *Client) TxBroadcast(tx *core.Transaction) error {
+	data, err := tx.Encode()
+	if err != nil {
+		return err
+	}
+	return c.p2p.BroadcastMessage(p2p.Message{
+		MsgType: p2p.MessageTypeTx,
+		Data:    data,
+	})
+}
+
+func (c *Client) loop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.sync()
+		case <-c.quitCh:
+			return
+		}
+	}
+}
+
+// sync headers from a random peer up to its head
+func (c *Client) sync() {
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.quitCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	rsp, err := c.p2p.GetStatus(ctx, "")
+	if err != nil {
+		log.Debug("light status failed", "err", err)
+		return
+	}
+	status := rsp.Status
+	if status == nil || common.BytesToHash(status.Genesis) != c.genesis.Hash() {
+		// peer of other chain
+		return
+	}
+	peer := rsp.From
+	for from := c.Head().Number() + 1; from <= status.Height; {
+		count := p2p.ChainMaxHeaders
+		if left := status.Height - from + 1; left < uint64(count) {
+			count = int(left)
+		}
+		rsp, err := c.p2p.GetHeaders(ctx, peer, from, count)
+		if err != nil {
+			log.Debug("light headers failed", "peer", peer, "from", from, "err", err)
+			return
+		}
+		if len(rsp.Items) == 0 {
+			return
+		}
+		for _, item := range rsp.Items {
+			b, err := core.ParseHeader(item)
+			if err != nil {
+				c.reportPeer(peer, p2p.PeerOffenceUndecodable)
+				return
+			}
+			if err := c.insertHeader(ctx, peer, b, item); err != nil {
+				log.Warn("light header rejected", "H", b.Number(), "peer", peer, "err", err)
+				if err != context.Canceled && err != context.DeadlineExceeded {
+					c.reportPeer(peer, p2p.PeerOffenceInvalid)
+				}
+				return
+			}
+			from++
+		}
+	}
+}
+
+// insertHeader verifies header against head and makes it the new head, the
+// validators of it are proven by peer if changed
+func (c *Client) insertHeader(ctx context.Context, peer string, b *core.Block, enc []byte) error {
+	head := c.Head()
+	if b.Number() != head.Number()+1 || b.ParentHash() != head.Hash() {
+		return ErrHeaderNotNext
+	}
+	if core.ChainID(b.ChainID()) != c.chainID {
+		return ErrHeaderChainID
+	}
+	if err := c.verifySignature(b); err != nil {
+		return err
+	}
+	var validators []string
+	if b.ConsensusRoot() != head.ConsensusRoot() {
+		rsp, err := c.p2p.GetProof(ctx, peer, b.ConsensusRoot().Bytes(), []byte(state.TrieKeyValidators))
+		if err != nil {
+			return err
+		}
+		if validators, err = state.VerifyValidators(b.ConsensusRoot(), rsp.Items); err != nil {
+			return err
+		}
+	}
+	return c.writeHead(b, enc, validators)
+}
+
+// at least 2/3 of validators of head signed, the threshold of block pool
+func (c *Client) verifySignature(b *core.Block) error {
+	signers, err := b.Signers()
+	if err != nil {
+		return err
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	matched := 0
+	for signer := range signers {
+		if c.validators[signer] {
+			matched++
+		}
+	}
+	if matched == 0 || matched*3 < len(c.validators)*2 {
+		return ErrSignatureInsufficient
+	}
+	return nil
+}
+
+// write header as head, validators are nil if not changed
+func (c *Client) writeHead(b *core.Block, enc []byte, validators []string) error {
+	batch := c.storage.NewBatch()
+	if err := putHeader(batch, b.Number(), b.Hash(), enc); err != nil {
+		return err
+	}
+	var signers map[common.Address]bool
+	if validators != nil {
+		var err error
+		if signers, err = parseValidators(validators); err != nil {
+			return err
+		}
+		if err := putValidators(batch, b.ConsensusRoot(), validators); err != nil {
+			return err
+		}
+	}
+	if err := putLastHeader(batch, b.Number()); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.head = b
+	if signers != nil {
+		log.Info("light validators proven", "H", b.Number(), "validators", validators)
+		c.validators = signers
+	}
+	return nil
+}
+
+func (c *Client) reportPeer(peer string, offence string) {
+	if err := c.p2p.ReportPeer(peer, offence, p2p.PeerSeverityMajor); err != nil {
+		log.Warn("failed to report bad peer", "from", peer, "err", err)
+	}
+}
+
+func parseValidators(validators []string) (map[common.Address]bool, error) {
+	result := make(map[common.Address]bool, len(validators))
+	for _, str := range validators {
+		addr, err := address.AddressParse(str)
+		if err != nil {
+			return nil, err
+		}
+		result[*addr.CommonAddress()] = true
+	}
+	return result, nil
+}
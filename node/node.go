@@ -50,6 +50,7 @@ import (
 	"github.com/yeeco/gyee/accounts"
 	"github.com/yeeco/gyee/config"
 	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/light"
 	"github.com/yeeco/gyee/log"
 	"github.com/yeeco/gyee/p2p"
 	"github.com/yeeco/gyee/rpc"
@@ -59,6 +60,7 @@ type Node struct {
 	name           string //for test purpose
 	config         *config.Config
 	core           *core.Core
+	light          *light.Client // light node has no core
 	accountManager *accounts.AccountManager
 	p2p            p2p.Service
 	ipc            rpc.RPCServer
@@ -99,9 +101,15 @@ func NewNodeWithGenesis(conf *config.Config, genesis *core.Genesis, p2pSvc p2p.S
 		log.Crit("node: accountMgr: ", err)
 	}
 
-	node.core, err = core.NewCoreWithGenesis(node, conf, genesis)
-	if err != nil {
-		log.Crit("node: core: ", err)
+	if conf.Chain.Light && conf.Chain.Mine {
+		return nil, errors.New("node: light node can not mine")
+	}
+
+	if !conf.Chain.Light {
+		node.core, err = core.NewCoreWithGenesis(node, conf, genesis)
+		if err != nil {
+			log.Crit("node: core: ", err)
+		}
 	}
 
 	if p2pSvc == nil {
@@ -111,6 +119,14 @@ func NewNodeWithGenesis(conf *config.Config, genesis *core.Genesis, p2pSvc p2p.S
 	}
 	node.p2p = p2pSvc
 
+	// light node follows headers instead of running core
+	if conf.Chain.Light {
+		node.light, err = light.NewClientWithGenesis(conf, genesis, p2pSvc)
+		if err != nil {
+			log.Crit("node: light: ", err)
+		}
+	}
+
 	node.stop = make(chan struct{})
 	return node, nil
 }
@@ -126,16 +142,26 @@ func (n *Node) Start() (err error) {
 	}
 
 	//依次启动p2p，rpc, ipc, blockchain, sync service, consensus
-	if err = n.core.Start(); err != nil {
-		return err
+	if n.core != nil {
+		if err = n.core.Start(); err != nil {
+			return err
+		}
+		log.Info("Node Started")
 	}
-	log.Info("Node Started")
 
 	if err = n.p2p.Start(); err != nil {
 		return err
 	}
 	log.Info("p2p Started")
 
+	// light client syncs headers from peers
+	if n.light != nil {
+		if err = n.light.Start(); err != nil {
+			return err
+		}
+		log.Info("Light Node Started")
+	}
+
 	if err = n.startIPC(); err != nil {
 		return err
 	}
@@ -154,9 +180,16 @@ func (n *Node) Stop() error {
 	defer n.lock.Unlock()
 	log.Info("Node Stop...")
 
+	if n.light != nil {
+		if err := n.light.Stop(); err != nil {
+			return err
+		}
+	}
 	n.p2p.Stop()
-	if err := n.core.Stop(); err != nil {
-		return err
+	if n.core != nil {
+		if err := n.core.Stop(); err != nil {
+			return err
+		}
 	}
 
 	if err := n.unlockDataDir(); err != nil {
@@ -231,6 +264,9 @@ func (n *Node) startIPC() error {
 func (n *Node) startRPC() error {
 	// TODO: remove hardcoded listen param after connection security handled
 	rpcListen := "127.0.0.1:7353"
+	if n.config.Rpc != nil && len(n.config.Rpc.RpcListen) > 0 {
+		rpcListen = n.config.Rpc.RpcListen[0]
+	}
 
 	listener, err := net.Listen("tcp", rpcListen)
 	if err != nil {
@@ -264,10 +300,16 @@ func (n *Node) AccountManager() *accounts.AccountManager {
 	return n.accountManager
 }
 
+// Core returns nil for light node
 func (n *Node) Core() *core.Core {
 	return n.core
 }
 
+// Light returns nil for full node
+func (n *Node) Light() *light.Client {
+	return n.light
+}
+
 func (n *Node) P2pService() p2p.Service {
 	return n.p2p
 }
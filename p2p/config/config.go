@@ -77,8 +77,11 @@ func (eno P2pCfgErrno) Error() string {
 
 // Some specific paths
 const (
-	KeyFileName     = "nodekey" // Path within the datadir to the node's private key
-	dirNodeDatabase = "nodes"   // Path within the datadir to store the nodes
+	KeyFileName          = "nodekey"           // Path within the datadir to the node's private key
+	dirNodeDatabase      = "nodes"             // Path within the datadir to store the nodes
+	PeerStoreFileName    = "peers.json"        // Path within the datadir to store good peers
+	DhtPeerStoreFileName = "dhtpeers.json"     // Path within the datadir to store dht route table
+	StaticNodesFileName  = "static-nodes.json" // Path within the datadir to store reserved and trusted nodes
 )
 
 // Bootstrap nodes, in a format like: node-identity-hex-string@ip:udp-port:tcp-port
@@ -125,9 +128,10 @@ var (
 
 // Node
 type Node struct {
-	IP       net.IP // ip address
-	UDP, TCP uint16 // port numbers
-	ID       NodeID // the node's public key
+	IP       net.IP     // ip address
+	UDP, TCP uint16     // port numbers
+	ID       NodeID     // the node's public key
+	Addrs    []Endpoint // more endpoints of a multi-homed node, besides the one above
 }
 
 type Protocol struct {
@@ -171,12 +175,17 @@ type Config struct {
 	StaticMaxInbounds  int                               // max concurrency inbounds
 	StaticNetId        SubNetworkID                      // static network identity
 	StaticNodes        []*Node                           // static nodes
+	ReservedNodes      []*Node                           // reserved nodes, always connected and exempt from caps
+	TrustedNodes       []*Node                           // trusted nodes, exempt from caps
 	NodeDataDir        string                            // node data directory
 	NodeDatabase       string                            // node database
 	NoNdbHistory       bool                              // do not use history of nodes
 	NoDial             bool                              // do not dial out flag
 	NoAccept           bool                              // do not accept incoming dial flag
 	BootstrapNode      bool                              // bootstrap node flag
+	Encryption         string                            // transport encryption mode
+	PeerRateLimit      RateLimit                         // rate limit of all traffic with a peer
+	MsgRateLimits      map[uint32]RateLimit              // rate limits with a peer by message identity
 	Local              Node                              // local node struct
 	CheckAddress       bool                              // check the neighbor reported address with the source ip
 	ProtoNum           uint32                            // local protocol number
@@ -212,6 +221,7 @@ type Cfg4UdpNgbManager struct {
 	UDP            uint16                // udp port numbers
 	TCP            uint16                // tcp port numbers
 	ID             NodeID                // the node's public key
+	Addrs          []Endpoint            // more endpoints of local node
 	NetworkType    int                   // network type
 	SubNetNodeList map[SubNetworkID]Node // sub-node identities
 	SubNetIdList   []SubNetworkID        // sub network identity list
@@ -243,6 +253,7 @@ type Cfg4PeerManager struct {
 	Port               uint16                            // tcp port number
 	UDP                uint16                            // udp port number, used with handshake procedure
 	ID                 NodeID                            // the node's public key
+	Addrs              []Endpoint                        // more endpoints of local node
 	StaticMaxPeers     int                               // max peers would be
 	StaticMaxOutbounds int                               // max concurrency outbounds
 	StaticMaxInBounds  int                               // max concurrency inbounds
@@ -258,8 +269,24 @@ type Cfg4PeerManager struct {
 	NoDial        bool       // do not dial outbound
 	NoAccept      bool       // do not accept inbound
 	BootstrapNode bool       // local is a bootstrap node
-	ProtoNum      uint32     // local protocol number
-	Protocols     []Protocol // local protocol table
+	Encryption    string               // transport encryption mode
+	PeerRateLimit RateLimit            // rate limit of all traffic with a peer
+	MsgRateLimits map[uint32]RateLimit // rate limits with a peer by message identity
+	ProtoNum      uint32               // local protocol number
+	Protocols     []Protocol           // local protocol table
+	PeerStore     string               // file to save good peers, empty if not saved
+	ReservedNodes []*Node              // reserved nodes, always connected and exempt from caps
+	TrustedNodes  []*Node              // trusted nodes, exempt from caps
+	StaticFile    string               // file to save reserved and trusted nodes, empty if not saved
+}
+
+// Rate limit of traffic with a peer, in messages and bytes per second for each
+// direction, zero for unlimited
+type RateLimit struct {
+	RxMsgs  int // inbound messages per second
+	RxBytes int // inbound bytes per second
+	TxMsgs  int // outbound messages per second
+	TxBytes int // outbound bytes per second
 }
 
 // Configuration about table manager
@@ -290,6 +317,7 @@ type Cfg4DhtRouteManager struct {
 	NodeId        NodeID        // local node identity
 	RandomQryNum  int           // times to try query for a random peer identity
 	Period        time.Duration // timer period to fire a bootstrap
+	PeerStore     string        // file to save route table, empty if not saved
 }
 
 // Configuration about dht query manager
@@ -311,11 +339,13 @@ type Cfg4DhtLsnManager struct {
 // Configuration about dht connection manager
 type Cfg4DhtConManager struct {
 	ChainId		  uint32		// chain identity
-	Local         *Node         // pointer to local node specification
-	BootstrapNode bool          // bootstrap node flag
-	MaxCon        int           // max number of connection
-	MinCon        int           // min number of connection
-	HsTimeout     time.Duration // handshake timeout duration
+	Local         *Node             // pointer to local node specification
+	PrivateKey    *ecdsa.PrivateKey // local node private key
+	BootstrapNode bool              // bootstrap node flag
+	MaxCon        int               // max number of connection
+	MinCon        int               // min number of connection
+	HsTimeout     time.Duration     // handshake timeout duration
+	Encryption    string            // transport encryption mode
 }
 
 // configuration about dht file data store
@@ -326,10 +356,13 @@ const (
 )
 
 type Cfg4DhtFileDatastore struct {
-	Path          string // data store path
-	ShardFuncName string // shard function name
-	PadLength     int    // padding length
-	Sync          bool   // sync file store flag
+	Path          string        // data store path
+	ShardFuncName string        // shard function name
+	PadLength     int           // padding length
+	Sync          bool          // sync file store flag
+	MaxRecords    int           // max number of records, for all types of data store, 0 for no limit
+	MaxBytes      int64         // max total size of values, for all types of data store, 0 for no limit
+	SweepPeriod   time.Duration // period to sweep records out of keep time
 }
 
 // Configuration about nat
@@ -340,11 +373,25 @@ const (
 	NATT_ANY  = "any"
 )
 
+//
+// Notice: nat applies to the primary endpoint (Config.Local.IP) only, ipv6 or
+// other endpoints in Node.Addrs are advertised as they are configured.
+//
 type Cfg4NatManager struct {
 	NatType string // "pmp", "upnp", "none"
 	GwIp    net.IP // gateway ip address when "pmp" specified
 }
 
+// Transport encryption modes. "required" is the default, the "optional" mode
+// encrypts connections to those peers supporting it and falls back to
+// plaintext for the others, it should be set explicitly only while rolling
+// out over a network of mixed versions.
+const (
+	ENC_OFF      = "off"      // plaintext only
+	ENC_OPTIONAL = "optional" // encrypt if peer supports, else plaintext
+	ENC_REQUIRED = "required" // refuse peers not supporting encryption
+)
+
 // Default version string, formated as "M.m0.m1.m2"
 const DefaultVersion = "0.1.0.0"
 
@@ -407,6 +454,7 @@ func P2pDefaultConfig(bsUrls []string) *Config {
 		NoDial:             false,
 		NoAccept:           false,
 		BootstrapNode:      false,
+		Encryption:         ENC_REQUIRED,
 		Local:              DefaultLocalNode,
 		CheckAddress:       false,
 		ProtoNum:           1,
@@ -447,6 +495,9 @@ func P2pDefaultConfig(bsUrls []string) *Config {
 			ShardFuncName: sfnNextToLast,
 			PadLength:     2,
 			Sync:          true,
+			MaxRecords:    1024 * 1024,
+			MaxBytes:      1024 * 1024 * 1024,
+			SweepPeriod:   time.Minute,
 		},
 
 		//
@@ -489,6 +540,7 @@ func P2pDefaultBootstrapConfig(bsUrls []string) *Config {
 		NoDial:             true,
 		NoAccept:           true,
 		BootstrapNode:      true,
+		Encryption:         ENC_REQUIRED,
 		Local:              DefaultLocalNode,
 		ProtoNum:           1,
 		Protocols:          []Protocol{{Pid: 0, Ver: [4]byte{0, 1, 0, 0}}},
@@ -526,6 +578,9 @@ func P2pDefaultBootstrapConfig(bsUrls []string) *Config {
 			ShardFuncName: sfnNextToLast,
 			PadLength:     2,
 			Sync:          true,
+			MaxRecords:    1024 * 1024,
+			MaxBytes:      1024 * 1024 * 1024,
+			SweepPeriod:   time.Minute,
 		},
 
 		//
@@ -604,6 +659,13 @@ func P2pSetConfig(name string, cfg *Config) (string, P2pCfgErrno) {
 		log.Debugf("P2pSetConfig: invalid ip address")
 		return name, P2pCfgEnoIpAddr
 	}
+
+	if len(cfg.Encryption) == 0 {
+		cfg.Encryption = ENC_REQUIRED
+	} else if P2pIsValidEncryption(cfg.Encryption) != true {
+		log.Debugf("P2pSetConfig: invalid encryption mode: %s", cfg.Encryption)
+		return name, P2pCfgEnoParameter
+	}
 	log.Debugf("P2pSetConfig: [ip, udp, tcp]=[%s, %d, %d]",
 		cfg.Local.IP.String(), cfg.Local.UDP, cfg.Local.TCP)
 
@@ -748,6 +810,14 @@ func P2pGetUserHomeDir() string {
 	return ""
 }
 
+// Get path of peer store file, empty if no data directory specified
+func p2pPeerStorePath(name string, file string) string {
+	if config[name].NodeDataDir == "" {
+		return ""
+	}
+	return filepath.Join(config[name].NodeDataDir, config[name].Name, file)
+}
+
 // Build private key
 func p2pBuildPrivateKey(cfg *Config) *ecdsa.PrivateKey {
 
@@ -896,6 +966,28 @@ func P2pSetLocalIpAddr(cfg *Config, ip string, udpp uint16, tcpp uint16) P2pCfgE
 	return P2pCfgEnoNone
 }
 
+// Set more local endpoints for chain application, each one of the "ips" is
+// an ipv4 or ipv6 address and the ports are those of the primary endpoint,
+// so this should be called after ports are set.
+func P2pSetLocalAddrs(cfg *Config, ips []string) P2pCfgErrno {
+	eps, eno := p2pParseEndpoints(ips, cfg.Local.UDP, cfg.Local.TCP)
+	if eno != P2pCfgEnoNone {
+		return eno
+	}
+	cfg.Local.Addrs = eps
+	return P2pCfgEnoNone
+}
+
+// Set more local endpoints for dht application, see P2pSetLocalAddrs
+func P2pSetLocalDhtAddrs(cfg *Config, ips []string) P2pCfgErrno {
+	eps, eno := p2pParseEndpoints(ips, 0, cfg.DhtLocal.TCP)
+	if eno != P2pCfgEnoNone {
+		return eno
+	}
+	cfg.DhtLocal.Addrs = eps
+	return P2pCfgEnoNone
+}
+
 // Set local dht port
 func P2pSetLocalDhtPort(cfg *Config, port uint16) P2pCfgErrno {
 	cfg.DhtLocal.UDP = 0
@@ -932,14 +1024,15 @@ func P2pSetupBootstrapNodes(urls []string) []*Node {
 		}
 		strNodeId := strs[0]
 		strs = strings.Split(strs[1], ":")
-		if len(strs) != 3 {
+		if len(strs) < 3 {
 			log.Debugf("P2pSetupBootstrapNodes: invalid bootstrap url: %s", url)
 			return nil
 		}
 
-		strIp := strs[0]
-		strUdpPort := strs[1]
-		strTcpPort := strs[2]
+		// ipv6 address might be given as "[ip]", the last two are ports
+		strIp := strings.Trim(strings.Join(strs[:len(strs)-2], ":"), "[]")
+		strUdpPort := strs[len(strs)-2]
+		strTcpPort := strs[len(strs)-1]
 		pid := P2pHexString2NodeId(strNodeId)
 		if pid == nil {
 			log.Debugf("P2pSetupBootstrapNodes: P2pHexString2NodeId failed, strNodeId: %s", strNodeId)
@@ -974,6 +1067,7 @@ func P2pConfig4UdpNgbManager(name string) *Cfg4UdpNgbManager {
 		UDP:            config[name].Local.UDP,
 		TCP:            config[name].Local.TCP,
 		ID:             config[name].Local.ID,
+		Addrs:          config[name].Local.Addrs,
 		NetworkType:    config[name].NetworkType,
 		SubNetNodeList: config[name].SubNetNodeList,
 		SubNetIdList:   config[name].SubNetIdList,
@@ -1010,6 +1104,7 @@ func P2pConfig4PeerManager(name string) *Cfg4PeerManager {
 		Port:               config[name].Local.TCP,
 		UDP:                config[name].Local.UDP,
 		ID:                 config[name].Local.ID,
+		Addrs:              config[name].Local.Addrs,
 		StaticMaxPeers:     config[name].StaticMaxPeers,
 		StaticMaxOutbounds: config[name].StaticMaxOutbounds,
 		StaticMaxInBounds:  config[name].StaticMaxInbounds,
@@ -1017,6 +1112,9 @@ func P2pConfig4PeerManager(name string) *Cfg4PeerManager {
 		StaticNetId:        config[name].StaticNetId,
 		NoDial:             config[name].NoDial,
 		NoAccept:           config[name].NoAccept,
+		Encryption:         config[name].Encryption,
+		PeerRateLimit:      config[name].PeerRateLimit,
+		MsgRateLimits:      config[name].MsgRateLimits,
 		ProtoNum:           config[name].ProtoNum,
 		Protocols:          config[name].Protocols,
 		SubNetKeyList:      config[name].SubNetKeyList,
@@ -1025,6 +1123,10 @@ func P2pConfig4PeerManager(name string) *Cfg4PeerManager {
 		SubNetMaxOutbounds: config[name].SubNetMaxOutbounds,
 		SubNetMaxInBounds:  config[name].SubNetMaxInBounds,
 		SubNetIdList:       config[name].SubNetIdList,
+		PeerStore:          p2pPeerStorePath(name, PeerStoreFileName),
+		ReservedNodes:      config[name].ReservedNodes,
+		TrustedNodes:       config[name].TrustedNodes,
+		StaticFile:         p2pPeerStorePath(name, StaticNodesFileName),
 	}
 }
 
@@ -1057,6 +1159,7 @@ func P2pConfig4Protocols(name string) *Cfg4Protocols {
 func P2pConfig4DhtRouteManager(name string) *Cfg4DhtRouteManager {
 	config[name].DhtRutCfg.NodeId = config[name].DhtLocal.ID
 	config[name].DhtRutCfg.BootstrapNode = config[name].BootstrapNode
+	config[name].DhtRutCfg.PeerStore = p2pPeerStorePath(name, DhtPeerStoreFileName)
 	return &config[name].DhtRutCfg
 }
 
@@ -1087,7 +1190,9 @@ func P2pConfig4DhtLsnManager(name string) *Cfg4DhtLsnManager {
 func P2pConfig4DhtConManager(name string) *Cfg4DhtConManager {
 	config[name].DhtConCfg.ChainId = config[name].ChainId
 	config[name].DhtConCfg.Local = &config[name].DhtLocal
+	config[name].DhtConCfg.PrivateKey = config[name].PrivateKey
 	config[name].DhtConCfg.BootstrapNode = config[name].BootstrapNode
+	config[name].DhtConCfg.Encryption = config[name].Encryption
 	return &config[name].DhtConCfg
 }
 
@@ -1161,6 +1266,11 @@ func FromECDSA(priK *ecdsa.PrivateKey) []byte {
 	return priK.D.Bytes()
 }
 
+// Check transport encryption mode
+func P2pIsValidEncryption(mode string) bool {
+	return mode == ENC_OFF || mode == ENC_OPTIONAL || mode == ENC_REQUIRED
+}
+
 // Setup nat configuration
 func P2pIsValidNatType(natt string) bool {
 	natt = strings.ToLower(natt)
@@ -0,0 +1,259 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package config
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/yeeco/gyee/log"
+)
+
+// Endpoint, an address where a node can be reached, a multi-homed node
+// might have several, ipv4 and ipv6, public and private.
+type Endpoint struct {
+	IP       net.IP // ip address
+	UDP, TCP uint16 // port numbers
+}
+
+// Max endpoints a node could advertise
+const P2pMaxEndpoints = 8
+
+// Errors about endpoint list
+var (
+	ErrEndpointInvalid  = errors.New("invalid endpoint")
+	ErrEndpointEncoding = errors.New("bad endpoint list encoding")
+)
+
+// Get all endpoints of node, the primary one goes first, duplicated ones
+// and those without valid ip are removed.
+func (n *Node) Endpoints() []Endpoint {
+	eps := make([]Endpoint, 0, len(n.Addrs)+1)
+	add := func(ep Endpoint) {
+		if len(ep.IP) != net.IPv4len && len(ep.IP) != net.IPv6len {
+			return
+		}
+		for _, e := range eps {
+			if e.IP.Equal(ep.IP) && e.UDP == ep.UDP && e.TCP == ep.TCP {
+				return
+			}
+		}
+		eps = append(eps, ep)
+	}
+	add(Endpoint{IP: n.IP, UDP: n.UDP, TCP: n.TCP})
+	for _, ep := range n.Addrs {
+		add(ep)
+	}
+	return eps
+}
+
+// Check if ip is one of the endpoints of node
+func (n *Node) HasIP(ip net.IP) bool {
+	if n.IP.Equal(ip) {
+		return true
+	}
+	for _, ep := range n.Addrs {
+		if ep.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Encode endpoint list to be carried in protobuf messages. for each endpoint,
+// one byte for ip length(4 or 16), the ip, the udp and tcp port in big endian.
+// nil is returned for an empty list, so nothing goes on the wire for nodes
+// with a single endpoint.
+func P2pEncodeEndpoints(eps []Endpoint) []byte {
+	if len(eps) == 0 {
+		return nil
+	}
+	if len(eps) > P2pMaxEndpoints {
+		eps = eps[:P2pMaxEndpoints]
+	}
+	buf := make([]byte, 0, len(eps)*(1+net.IPv6len+4))
+	for _, ep := range eps {
+		ip := ep.IP.To4()
+		if ip == nil {
+			ip = ep.IP.To16()
+		}
+		if ip == nil {
+			continue
+		}
+		buf = append(buf, byte(len(ip)))
+		buf = append(buf, ip...)
+		buf = append(buf, byte(ep.UDP>>8), byte(ep.UDP), byte(ep.TCP>>8), byte(ep.TCP))
+	}
+	return buf
+}
+
+// Decode endpoint list encoded by P2pEncodeEndpoints
+func P2pDecodeEndpoints(buf []byte) ([]Endpoint, error) {
+	var eps []Endpoint
+	for len(buf) > 0 {
+		l := int(buf[0])
+		if l != net.IPv4len && l != net.IPv6len || len(buf) < 1+l+4 {
+			return nil, ErrEndpointEncoding
+		}
+		if len(eps) >= P2pMaxEndpoints {
+			return nil, ErrEndpointEncoding
+		}
+		ep := Endpoint{
+			IP:  append(net.IP(nil), buf[1:1+l]...),
+			UDP: binary.BigEndian.Uint16(buf[1+l:]),
+			TCP: binary.BigEndian.Uint16(buf[3+l:]),
+		}
+		eps = append(eps, ep)
+		buf = buf[1+l+4:]
+	}
+	return eps, nil
+}
+
+func p2pParseEndpoints(ips []string, udp, tcp uint16) ([]Endpoint, P2pCfgErrno) {
+	if len(ips) > P2pMaxEndpoints {
+		return nil, P2pCfgEnoParameter
+	}
+	eps := make([]Endpoint, 0, len(ips))
+	for _, s := range ips {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			log.Debugf("p2pParseEndpoints: invalid ip: %s", s)
+			return nil, P2pCfgEnoIpAddr
+		}
+		eps = append(eps, Endpoint{IP: ip, UDP: udp, TCP: tcp})
+	}
+	return eps, P2pCfgEnoNone
+}
+
+// local interface networks, refreshed in a while since interfaces might
+// come and go
+const localNetsRefresh = time.Minute
+
+var localNets struct {
+	lock    sync.Mutex
+	nets    []*net.IPNet
+	has4    bool
+	has6    bool
+	updated time.Time
+}
+
+func p2pLocalNets() (nets []*net.IPNet, has4, has6 bool) {
+	localNets.lock.Lock()
+	defer localNets.lock.Unlock()
+	if time.Since(localNets.updated) < localNetsRefresh {
+		return localNets.nets, localNets.has4, localNets.has6
+	}
+	localNets.nets, localNets.has4, localNets.has6 = nil, false, false
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok || !ipnet.IP.IsGlobalUnicast() {
+				continue
+			}
+			localNets.nets = append(localNets.nets, ipnet)
+			if ipnet.IP.To4() != nil {
+				localNets.has4 = true
+			} else {
+				localNets.has6 = true
+			}
+		}
+	}
+	if len(localNets.nets) == 0 {
+		// nothing known, do not rule any family out
+		localNets.has4, localNets.has6 = true, true
+	}
+	localNets.updated = time.Now()
+	return localNets.nets, localNets.has4, localNets.has6
+}
+
+// Get the local non-loopback ip addresses, ipv4 and ipv6
+func P2pGetLocalIpAddrs() []net.IP {
+	nets, _, _ := p2pLocalNets()
+	ips := make([]net.IP, 0, len(nets))
+	for _, n := range nets {
+		ips = append(ips, n.IP)
+	}
+	return ips
+}
+
+// rank of endpoint, the less the better
+const (
+	epRankSameNet  = iota // sharing a network with local interface
+	epRankPublic          // public address of a family local node has
+	epRankPrivate         // private address of a family local node has
+	epRankNoFamily        // address of a family local node does not have
+	epRankLoopback        // loopback, link local or unspecified
+)
+
+func p2pEndpointRank(ip net.IP, nets []*net.IPNet, has4, has6 bool) int {
+	if ip.IsLoopback() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+		return epRankLoopback
+	}
+	is4 := ip.To4() != nil
+	if is4 && !has4 || !is4 && !has6 {
+		return epRankNoFamily
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return epRankSameNet
+		}
+	}
+	if ip.IsPrivate() {
+		return epRankPrivate
+	}
+	return epRankPublic
+}
+
+// Sort endpoints by the preference to reach them from local node: those
+// sharing a network with a local interface first, then public ones and
+// private ones of ip families local node has, then the others; loopback
+// and link local ones go last. the order given is kept for endpoints of
+// the same rank, so the primary one is preferred among equals.
+func P2pSortEndpoints(eps []Endpoint) []Endpoint {
+	nets, has4, has6 := p2pLocalNets()
+	return p2pSortEndpoints(eps, nets, has4, has6)
+}
+
+func p2pSortEndpoints(eps []Endpoint, nets []*net.IPNet, has4, has6 bool) []Endpoint {
+	sorted := append([]Endpoint(nil), eps...)
+	ranks := make(map[string]int, len(sorted))
+	for _, ep := range sorted {
+		ranks[ep.IP.String()] = p2pEndpointRank(ep.IP, nets, has4, has6)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return ranks[sorted[i].IP.String()] < ranks[sorted[j].IP.String()]
+	})
+	return sorted
+}
+
+// Get the best endpoint of node to reach it from local node
+func P2pBestEndpoint(n *Node) Endpoint {
+	eps := n.Endpoints()
+	if len(eps) == 0 {
+		return Endpoint{IP: n.IP, UDP: n.UDP, TCP: n.TCP}
+	}
+	return P2pSortEndpoints(eps)[0]
+}
@@ -0,0 +1,163 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package config
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func testEndpoint(ip string, udp, tcp uint16) Endpoint {
+	return Endpoint{IP: net.ParseIP(ip), UDP: udp, TCP: tcp}
+}
+
+func sameEndpoints(a, b []Endpoint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].IP.Equal(b[i].IP) || a[i].UDP != b[i].UDP || a[i].TCP != b[i].TCP {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEndpointsRoundTrip(t *testing.T) {
+	max := make([]Endpoint, 0, P2pMaxEndpoints)
+	for i := 0; i < P2pMaxEndpoints; i++ {
+		max = append(max, testEndpoint("10.0.0.1", uint16(i), uint16(i)))
+	}
+	for _, c := range []struct {
+		name string
+		eps  []Endpoint
+		size int
+	}{
+		{"empty", nil, 0},
+		{"ipv4", []Endpoint{testEndpoint("192.168.1.10", 30303, 30304)}, 1 + 4 + 4},
+		{"ipv6", []Endpoint{testEndpoint("2001:db8::1", 1, 65535)}, 1 + 16 + 4},
+		{"mixed", []Endpoint{
+			testEndpoint("8.8.8.8", 30303, 30303),
+			testEndpoint("fe80::1", 30303, 30303),
+			testEndpoint("::ffff:10.1.2.3", 0, 0),
+		}, 2*(1+4+4) + 1 + 16 + 4},
+		{"max", max, P2pMaxEndpoints * (1 + 4 + 4)},
+	} {
+		buf := P2pEncodeEndpoints(c.eps)
+		if len(buf) != c.size {
+			t.Errorf("%s: encoded %d bytes, want %d", c.name, len(buf), c.size)
+		}
+		eps, err := P2pDecodeEndpoints(buf)
+		if err != nil {
+			t.Errorf("%s: P2pDecodeEndpoints() %v", c.name, err)
+			continue
+		}
+		if !sameEndpoints(eps, c.eps) {
+			t.Errorf("%s: decoded %v want %v", c.name, eps, c.eps)
+		}
+	}
+
+	// encoding keeps the max only
+	over := append(max, testEndpoint("10.0.0.2", 1, 1))
+	if eps, err := P2pDecodeEndpoints(P2pEncodeEndpoints(over)); err != nil || !sameEndpoints(eps, max) {
+		t.Errorf("over max decoded %v, %v", eps, err)
+	}
+}
+
+func TestDecodeEndpointsMalformed(t *testing.T) {
+	good := P2pEncodeEndpoints([]Endpoint{testEndpoint("10.0.0.1", 1, 2)})
+	tooMany := bytes.Repeat(good, P2pMaxEndpoints+1)
+	for _, c := range []struct {
+		name string
+		buf  []byte
+	}{
+		{"bad ip length", []byte{5, 1, 2, 3, 4, 5, 0, 1, 0, 2}},
+		{"zero ip length", []byte{0, 0, 1, 0, 2}},
+		{"truncated ip", good[:3]},
+		{"truncated ports", good[:len(good)-1]},
+		{"trailing byte", append(append([]byte{}, good...), 4)},
+		{"too many", tooMany},
+	} {
+		if eps, err := P2pDecodeEndpoints(c.buf); err != ErrEndpointEncoding || eps != nil {
+			t.Errorf("%s: decoded %v, %v", c.name, eps, err)
+		}
+	}
+}
+
+func TestSortEndpoints(t *testing.T) {
+	_, lan, _ := net.ParseCIDR("192.168.1.0/24")
+	nets := []*net.IPNet{lan}
+	var (
+		sameNet  = testEndpoint("192.168.1.20", 1, 1)
+		public4  = testEndpoint("8.8.8.8", 1, 1)
+		public6  = testEndpoint("2001:4860::8888", 1, 1)
+		private4 = testEndpoint("10.0.0.1", 1, 1)
+		loopback = testEndpoint("127.0.0.1", 1, 1)
+		link6    = testEndpoint("fe80::1", 1, 1)
+		public4b = testEndpoint("1.1.1.1", 2, 2)
+	)
+	eps := []Endpoint{loopback, public4, link6, private4, public6, sameNet, public4b}
+	for _, c := range []struct {
+		name       string
+		has4, has6 bool
+		want       []Endpoint
+	}{
+		{"dual stack", true, true,
+			[]Endpoint{sameNet, public4, public6, public4b, private4, loopback, link6}},
+		{"ipv4 only", true, false,
+			[]Endpoint{sameNet, public4, public4b, private4, public6, loopback, link6}},
+		{"ipv6 only", false, true,
+			[]Endpoint{public6, public4, private4, sameNet, public4b, loopback, link6}},
+	} {
+		var ns []*net.IPNet
+		if c.has4 {
+			ns = nets
+		}
+		sorted := p2pSortEndpoints(eps, ns, c.has4, c.has6)
+		if !sameEndpoints(sorted, c.want) {
+			t.Errorf("%s: sorted %v want %v", c.name, sorted, c.want)
+		}
+	}
+	if !sameEndpoints(eps, []Endpoint{loopback, public4, link6, private4, public6, sameNet, public4b}) {
+		t.Error("input reordered")
+	}
+}
+
+func TestNodeEndpoints(t *testing.T) {
+	n := &Node{
+		IP:  net.ParseIP("8.8.8.8"),
+		UDP: 1,
+		TCP: 2,
+		Addrs: []Endpoint{
+			testEndpoint("8.8.8.8", 1, 2),
+			{IP: net.IP{1, 2, 3}},
+			testEndpoint("2001:db8::1", 1, 2),
+		},
+	}
+	want := []Endpoint{testEndpoint("8.8.8.8", 1, 2), testEndpoint("2001:db8::1", 1, 2)}
+	if eps := n.Endpoints(); !sameEndpoints(eps, want) {
+		t.Errorf("Endpoints() %v want %v", eps, want)
+	}
+	if !n.HasIP(net.ParseIP("2001:db8::1")) || n.HasIP(net.ParseIP("2001:db8::2")) {
+		t.Error("HasIP() wrong")
+	}
+}
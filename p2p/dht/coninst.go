@@ -22,6 +22,7 @@ package dht
 
 import (
 	"container/list"
+	"crypto/ecdsa"
 	"io"
 	"net"
 	"sync"
@@ -33,6 +34,7 @@ import (
 	config "github.com/yeeco/gyee/p2p/config"
 	pb "github.com/yeeco/gyee/p2p/dht/pb"
 	sch "github.com/yeeco/gyee/p2p/scheduler"
+	"github.com/yeeco/gyee/p2p/secure"
 )
 
 
@@ -54,6 +56,8 @@ type ConInst struct {
 	bootstrapNode bool              // bootstrap node flag
 	tep           sch.SchUserTaskEp // task entry
 	local         *config.Node      // pointer to local node specification
+	priKey        *ecdsa.PrivateKey // local node private key
+	encryption    string            // transport encryption mode
 	ptnMe         interface{}       // pointer to myself task node
 	ptnDhtMgr     interface{}       // pointer to dht manager task node
 	ptnRutMgr     interface{}       // pointer to route manager task node
@@ -973,22 +977,30 @@ func (conInst *ConInst) connect2Peer() DhtErrno {
 
 	peer := conInst.hsInfo.peer
 	dialer := &net.Dialer{Timeout: ciConn2PeerTimeout}
-	addr := &net.TCPAddr{IP: peer.IP, Port: int(peer.TCP)}
-
-	log.Debugf("connect2Peer: try to connect, " +
-		"inst: %s, dir: %d, local: %s, remote: %s",
-		conInst.name, conInst.dir,
-		conInst.local.IP.String(),
-		addr.String())
 
 	var conn net.Conn
 	var err error
 
-	if conn, err = dialer.Dial("tcp", addr.String()); err != nil {
+	// a multi-homed peer is dialed at its endpoints in the order of preference
+	// until one of them is connected.
+	for _, ep := range config.P2pSortEndpoints(peer.Endpoints()) {
+		addr := &net.TCPAddr{IP: ep.IP, Port: int(ep.TCP)}
+
+		log.Debugf("connect2Peer: try to connect, " +
+			"inst: %s, dir: %d, local: %s, remote: %s",
+			conInst.name, conInst.dir,
+			conInst.local.IP.String(),
+			addr.String())
+
+		if conn, err = dialer.Dial("tcp", addr.String()); err == nil {
+			break
+		}
 		log.Debugf("connect2Peer: " +
 			"dial failed, inst: %s, dir: %d, local: %s, to: %s, err: %s",
 			conInst.name, conInst.dir, conInst.local.IP.String(),
 			addr.String(), err.Error())
+	}
+	if conn == nil {
 		return DhtEnoOs
 	}
 
@@ -1049,6 +1061,7 @@ func (conInst *ConInst) outboundHandshake() DhtErrno {
 		IP:       conInst.local.IP,
 		UDP:      uint32(conInst.local.UDP),
 		TCP:      uint32(conInst.local.TCP),
+		Addrs:    conInst.local.Addrs,
 		ProtoNum: 1,
 		Protocols: []DhtProtocol{
 			{
@@ -1058,6 +1071,17 @@ func (conInst *ConInst) outboundHandshake() DhtErrno {
 		},
 	}
 
+	var shs *secure.Handshake
+	if conInst.encryption != config.ENC_OFF {
+		var err error
+		if shs, err = secure.NewHandshake(conInst.chainId, conInst.priKey, conInst.local.ID, true); err != nil {
+			log.Debugf("outboundHandshake: NewHandshake failed, inst: %s, error: %s",
+				conInst.name, err.Error())
+			return DhtEnoInternal
+		}
+		dhtMsg.Handshake.SecKey = shs.PublicKey()
+	}
+
 	pbPkg := dhtMsg.GetPbPackage()
 	if pbPkg == nil {
 		log.Debugf("outboundHandshake: GetPbPackage failed, " +
@@ -1155,6 +1179,33 @@ func (conInst *ConInst) outboundHandshake() DhtErrno {
 		return DhtEnoMismatched
 	}
 
+	//
+	// the peer answers with its ephemeral key if it supports encryption, and
+	// the signature must be checked against the node identity we dialed.
+	//
+
+	if shs != nil && len(hs.SecKey) != 0 {
+		conInst.con.SetDeadline(time.Now().Add(conInst.hsTimeout))
+		sc, err := shs.Finish(conInst.con, conInst.hsInfo.peer.ID, hs.SecKey, hs.SecSign)
+		if err != nil {
+			log.Debugf("outboundHandshake: Finish failed, " +
+				"inst: %s, local: %s, remote: %s, error: %s",
+				conInst.name, conInst.con.LocalAddr().String(), conInst.con.RemoteAddr().String(),
+				err.Error())
+			return DhtEnoMismatched
+		}
+		conInst.secureCon(sc)
+	} else if conInst.encryption == config.ENC_REQUIRED {
+		log.Debugf("outboundHandshake: encryption required, " +
+			"inst: %s, local: %s, remote: %s",
+			conInst.name, conInst.con.LocalAddr().String(), conInst.con.RemoteAddr().String())
+		return DhtEnoMismatched
+	} else if conInst.encryption == config.ENC_OPTIONAL {
+		log.Warnf("outboundHandshake: plaintext fallback, " +
+			"inst: %s, local: %s, remote: %s",
+			conInst.name, conInst.con.LocalAddr().String(), conInst.con.RemoteAddr().String())
+	}
+
 	//
 	// notice0: when try outbound, the peer(conInst.hsInfo.peer) is always known
 	// before the handshaking, but here after the handshaking ok, we update the
@@ -1169,10 +1220,11 @@ func (conInst *ConInst) outboundHandshake() DhtErrno {
 	//
 
 	conInst.hsInfo.peer = config.Node{
-		IP:  hs.IP,
-		TCP: uint16(hs.TCP & 0xffff),
-		UDP: uint16(hs.UDP & 0xffff),
-		ID:  hs.NodeId,
+		IP:    hs.IP,
+		TCP:   uint16(hs.TCP & 0xffff),
+		UDP:   uint16(hs.UDP & 0xffff),
+		ID:    hs.NodeId,
+		Addrs: hs.Addrs,
 	}
 
 	log.Debugf("outboundHandshake: end ok, inst: %s, dir: %d, local: %s, remote: %s",
@@ -1263,10 +1315,11 @@ func (conInst *ConInst) inboundHandshake() DhtErrno {
 	}
 
 	conInst.hsInfo.peer = config.Node{
-		IP:  hs.IP,
-		TCP: uint16(hs.TCP & 0xffff),
-		UDP: uint16(hs.UDP & 0xffff),
-		ID:  hs.NodeId,
+		IP:    hs.IP,
+		TCP:   uint16(hs.TCP & 0xffff),
+		UDP:   uint16(hs.UDP & 0xffff),
+		ID:    hs.NodeId,
+		Addrs: hs.Addrs,
 	}
 	conInst.cid.nid = conInst.hsInfo.peer.ID
 
@@ -1279,6 +1332,7 @@ func (conInst *ConInst) inboundHandshake() DhtErrno {
 		IP:       conInst.local.IP,
 		UDP:      uint32(conInst.local.UDP),
 		TCP:      uint32(conInst.local.TCP),
+		Addrs:    conInst.local.Addrs,
 		ProtoNum: 1,
 		Protocols: []DhtProtocol{
 			{
@@ -1288,6 +1342,36 @@ func (conInst *ConInst) inboundHandshake() DhtErrno {
 		},
 	}
 
+	//
+	// key exchange for transport encryption if the peer asks for it, peers
+	// without support are served in plaintext unless encryption is required.
+	//
+
+	var shs *secure.Handshake
+	if len(hs.SecKey) != 0 && conInst.encryption != config.ENC_OFF {
+		var err error
+		if shs, err = secure.NewHandshake(conInst.chainId, conInst.priKey, conInst.local.ID, false); err != nil {
+			log.Debugf("inboundHandshake: NewHandshake failed, inst: %s, error: %s",
+				conInst.name, err.Error())
+			return DhtEnoInternal
+		}
+		if dhtMsg.Handshake.SecSign, err = shs.Respond(hs.NodeId, hs.SecKey); err != nil {
+			log.Debugf("inboundHandshake: Respond failed, inst: %s, error: %s",
+				conInst.name, err.Error())
+			return DhtEnoMismatched
+		}
+		dhtMsg.Handshake.SecKey = shs.PublicKey()
+	} else if conInst.encryption == config.ENC_REQUIRED {
+		log.Debugf("inboundHandshake: encryption required, " +
+			"inst: %s, local: %s, remote: %s",
+			conInst.name, conInst.con.LocalAddr().String(), conInst.con.RemoteAddr().String())
+		return DhtEnoMismatched
+	} else if conInst.encryption == config.ENC_OPTIONAL {
+		log.Warnf("inboundHandshake: plaintext fallback, " +
+			"inst: %s, local: %s, remote: %s",
+			conInst.name, conInst.con.LocalAddr().String(), conInst.con.RemoteAddr().String())
+	}
+
 	pbPkg := dhtMsg.GetPbPackage()
 	if pbPkg == nil {
 		log.Debugf("inboundHandshake: GetPbPackage failed, " +
@@ -1305,12 +1389,36 @@ func (conInst *ConInst) inboundHandshake() DhtErrno {
 		return DhtEnoSerialization
 	}
 
+	if shs != nil {
+		conInst.con.SetDeadline(time.Now().Add(conInst.hsTimeout))
+		sc, err := shs.Accept(conInst.con)
+		if err != nil {
+			log.Debugf("inboundHandshake: Accept failed, " +
+				"inst: %s, local: %s, remote: %s, error: %s",
+				conInst.name, conInst.con.LocalAddr().String(), conInst.con.RemoteAddr().String(),
+				err.Error())
+			return DhtEnoMismatched
+		}
+		conInst.secureCon(sc)
+	}
+
 	log.Debugf("inboundHandshake: end ok, inst: %s, dir: %d, local: %s, remote: %s",
 		conInst.name, conInst.dir, conInst.con.LocalAddr().String(), conInst.con.RemoteAddr().String())
 
 	return DhtEnoNone
 }
 
+//
+// Switch to the encrypted connection after key exchange completed. nothing
+// is buffered by the reader and writer of the handshake, since the peer sends
+// nothing more before the key exchange completed.
+//
+func (conInst *ConInst) secureCon(sc *secure.Conn) {
+	conInst.con = sc
+	conInst.ior = ggio.NewDelimitedReader(sc, ciMaxPackageSize)
+	conInst.iow = ggio.NewDelimitedWriter(sc)
+}
+
 //
 // Tx routine entry
 //
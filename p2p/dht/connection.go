@@ -21,6 +21,7 @@
 package dht
 
 import (
+	"crypto/ecdsa"
 	"fmt"
 	"io"
 	"net"
@@ -33,6 +34,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	config "github.com/yeeco/gyee/p2p/config"
 	nat "github.com/yeeco/gyee/p2p/nat"
+	"github.com/yeeco/gyee/p2p/reputation"
 	sch "github.com/yeeco/gyee/p2p/scheduler"
 )
 
@@ -58,11 +60,13 @@ const (
 //
 type conMgrCfg struct {
 	chainId		  uint32		// chain identity
-	local         *config.Node  // pointer to local node specification
-	bootstarpNode bool          // bootstrap node flag
-	maxCon        int           // max number of connection
-	minCon        int           // min number of connection
-	hsTimeout     time.Duration // handshake timeout duration
+	local         *config.Node      // pointer to local node specification
+	priKey        *ecdsa.PrivateKey // local node private key
+	bootstarpNode bool              // bootstrap node flag
+	maxCon        int               // max number of connection
+	minCon        int               // min number of connection
+	hsTimeout     time.Duration     // handshake timeout duration
+	encryption    string            // transport encryption mode
 }
 
 //
@@ -492,6 +496,11 @@ func (conMgr *ConMgr) handshakeRsp(msg *sch.MsgDhtConInstHandshakeRsp) sch.SchEr
 
 	if msg.Dir == ConInstDirInbound {
 		delete(conMgr.ibInstTemp, ci.name)
+		if reputation.Lookup(conMgr.sdlName).IsBanned(msg.Peer.ID) {
+			log.Debugf("handshakeRsp: done inbound from banned peer, sdl: %s, inst: %s, id: %x",
+				conMgr.sdlName, ci.name, msg.Peer.ID)
+			return conMgr.sdl.SchTaskDone(ci.ptnMe, ci.name, sch.SchEnoKilled)
+		}
 	}
 
 	if _, dup := conMgr.instInClosing[cid]; dup {
@@ -1200,10 +1209,12 @@ func (conMgr *ConMgr) getConfig() DhtErrno {
 	cfg := config.P2pConfig4DhtConManager(conMgr.sdl.SchGetP2pCfgName())
 	conMgr.cfg.chainId = cfg.ChainId
 	conMgr.cfg.local = cfg.Local
+	conMgr.cfg.priKey = cfg.PrivateKey
 	conMgr.cfg.bootstarpNode = cfg.BootstrapNode
 	conMgr.cfg.maxCon = cfg.MaxCon
 	conMgr.cfg.minCon = cfg.MinCon
 	conMgr.cfg.hsTimeout = cfg.HsTimeout
+	conMgr.cfg.encryption = cfg.Encryption
 	return DhtEnoNone
 }
 
@@ -1282,6 +1293,8 @@ func (conMgr *ConMgr) setupConInst(ci *ConInst, srcTask interface{}, peer *confi
 	}
 
 	ci.local = conMgr.cfg.local
+	ci.priKey = conMgr.cfg.priKey
+	ci.encryption = conMgr.cfg.encryption
 	ci.ptnConMgr = conMgr.ptnMe
 	_, ci.ptnDhtMgr = conMgr.sdl.SchGetUserTaskNode(DhtMgrName)
 	_, ci.ptnRutMgr = conMgr.sdl.SchGetUserTaskNode(RutMgrName)
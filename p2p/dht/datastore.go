@@ -21,18 +21,19 @@
 package dht
 
 import (
+	"os"
 	"path"
 	"runtime"
 	"strconv"
 	"strings"
 	"time"
 	"fmt"
-	"container/list"
 
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	config "github.com/yeeco/gyee/p2p/config"
 	sch "github.com/yeeco/gyee/p2p/scheduler"
 	log "github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/persistent"
 )
 
 
@@ -120,7 +121,6 @@ var dsType = dstLevelDB
 // infinite
 //
 const DsMgrDurInf = time.Duration(0)
-const dsMgrApplyCleanupTimer = true
 
 //
 // batch-get
@@ -155,11 +155,8 @@ type DsMgr struct {
 	ptnRutMgr   interface{}            // pointer to route manager task node
 	getfromPeer bool                   // do not try getting value from local store, true for debug/test only
 	ds          Datastore              // data store
-	dsExp       Datastore              // data store for expired time
 	fdsCfg      FileDatastoreConfig    // file data store configuration
 	ldsCfg      LeveldbDatastoreConfig // levelDB stat store configuration
-	tmMgr       *TimerManager          // timer manager
-	tidTick     int                    // tick timer identity
 	bgSeq		int					   // batch-get sequence number
 	bgMap		map[int]*dsMgrBatchGetInst // batch-get map
 }
@@ -174,7 +171,6 @@ func NewDsMgr() *DsMgr {
 		getfromPeer: false,
 		fdsCfg:      FileDatastoreConfig{},
 		ldsCfg:      LeveldbDatastoreConfig{},
-		tidTick:     sch.SchInvalidTid,
 		bgMap:		 make(map[int]*dsMgrBatchGetInst, 0),
 	}
 
@@ -237,9 +233,6 @@ func (dsMgr *DsMgr) dsMgrProc(ptn interface{}, msg *sch.SchMessage) sch.SchErrno
 	case sch.EvSchPoweroff:
 		eno = dsMgr.poweroff(ptn)
 
-	case sch.EvDhtDsMgrTickTimer:
-		eno = dsMgr.tickTimerHandler()
-
 	case sch.EvDhtDsMgrAddValReq:
 		eno = dsMgr.localAddValReq(msg.Body.(*sch.MsgDhtDsMgrAddValReq))
 
@@ -281,49 +274,14 @@ func (dsMgr *DsMgr) dsMgrProc(ptn interface{}, msg *sch.SchMessage) sch.SchErrno
 }
 
 //
-// put
+// put, the (key, value) pair is removed by the data store when it's out of
+// keep time "kt".
 //
 func (dsMgr *DsMgr) Put(k []byte, v DsValue, kt time.Duration) DhtErrno {
-
-	// this function start a timer for (key,value) pair after it is put
-	// ok to local datastore.
-
 	if eno := dsMgr.ds.Put(k, v, kt); eno != DhtEnoNone {
 		log.Errorf("Put: failed, eno: %d", eno)
 		return DhtEnoDatastore
 	}
-
-	// notice following codes does not delete the [key, value] had been stored
-	// even timer failed to be startup.
-
-	if kt != DsMgrDurInf {
-
-		ptm, eno := dsMgr.tmMgr.GetTimer(kt, nil, nil)
-		if eno != TmEnoNone {
-			log.Errorf("Put: GetTimer failed, eno: %d", eno)
-			return DhtEnoTimer
-		}
-
-		tm := ptm.(*timer)
-		dsMgr.tmMgr.SetTimerData(tm, tm)
-		dsMgr.tmMgr.SetTimerKey(tm, k)
-		dsMgr.tmMgr.SetTimerHandler(tm, dsMgr.cleanUpTimerCb)
-
-		tm.to = time.Now().Add(kt)
-		ek := dsMgr.makeExpiredKey(k, tm.to)
-		if eno = dsMgr.dsExp.Put(ek, k, sch.Keep4Ever); eno != DhtEnoNone {
-			log.Errorf("Put: failed, eno: %d", eno)
-			return DhtEnoDatastore
-		}
-
-		if err := dsMgr.tmMgr.StartTimer(tm); err != nil {
-			log.Errorf("Put: StartTimer failed, error: %s", err.Error())
-			dsMgr.ds.Delete(k)
-			dsMgr.dsExp.Delete(ek)
-			return DhtEnoTimer
-		}
-	}
-
 	return DhtEnoNone
 }
 
@@ -338,8 +296,6 @@ func (dsMgr *DsMgr) Get(k []byte) (eno DhtErrno, value DsValue) {
 // delete
 //
 func (dsMgr *DsMgr) Delete(k []byte) DhtErrno {
-	// timer might be in running, and would be removed when expired if any,
-	// just delete [key, val] from the "real" store here.
 	return dsMgr.ds.Delete(k)
 }
 
@@ -356,7 +312,6 @@ func (dsMgr *DsMgr) poweron(ptn interface{}) sch.SchErrno {
 	sdl := sch.SchGetScheduler(ptn)
 	dsMgr.sdl = sdl
 	dsMgr.sdlName = sdl.SchGetP2pCfgName()
-	dsMgr.tmMgr = NewTimerManager(dsMgr.sdlName, DsMgrName)
 
 	if sdl == nil {
 		log.Errorf("poweron: invalid sdl")
@@ -375,10 +330,11 @@ func (dsMgr *DsMgr) poweron(ptn interface{}) sch.SchErrno {
 		return sch.SchEnoInternal
 	}
 
+	dsPath := ""
+
 	if dsType == dstMemoryMap {
 
 		dsMgr.ds = NewMapDatastore()
-		dsMgr.dsExp = NewMapDatastore()
 
 	} else if dsType == dstFileSystem {
 
@@ -389,10 +345,7 @@ func (dsMgr *DsMgr) poweron(ptn interface{}) sch.SchErrno {
 		}
 
 		dsMgr.ds = NewFileDatastore(&fdc)
-
-		fdcExp := fdc
-		fdcExp.path = path.Join(fdcExp.path, "expired")
-		dsMgr.dsExp = NewFileDatastore(&fdcExp)
+		dsPath = fdc.path
 
 	} else if dsType == dstLevelDB {
 
@@ -409,16 +362,7 @@ func (dsMgr *DsMgr) poweron(ptn interface{}) sch.SchErrno {
 			log.Tracef("poweron: dht datastore path: %s", ldc.Path)
 		}
 		dsMgr.ds = NewLeveldbDatastore(&ldc)
-
-		ldcExp := ldc
-		ldcExp.Path = path.Join(ldcExp.Path, "expired")
-		if runtime.GOOS == "windows" {
-			log.Tracef("poweron: dht expiration datastore path: %s",
-				strings.Replace(ldcExp.Path, "/", "\\", -1))
-		} else {
-			log.Tracef("poweron: dht expiration datastore path: %s", ldcExp.Path)
-		}
-		dsMgr.dsExp = NewLeveldbDatastore(&ldcExp)
+		dsPath = ldc.Path
 
 	} else {
 		log.Debugf("poweron: invalid datastore type: %d", dsType)
@@ -430,40 +374,84 @@ func (dsMgr *DsMgr) poweron(ptn interface{}) sch.SchErrno {
 		return sch.SchEnoUserTask
 	}
 
-	if eno := dsMgr.cleanUpReboot(); eno != DhtEnoNone {
-		log.Warnf("poweron: cleanUp failed, eno: %d", eno)
-		return sch.SchEnoUserTask
-	}
-
-	if eno := dsMgr.startTickTimer(); eno != DhtEnoNone {
-		log.Errorf("poweron: startTickTimer failed, eno: %d", eno)
-		return sch.SchEnoUserTask
+	if dsPath != "" {
+		if err := dsMigrateLegacyExpired(dsMgr.ds, dsPath, dsType == dstLevelDB); err != nil {
+			log.Warnf("poweron: dsMigrateLegacyExpired failed, error: %s", err.Error())
+		}
 	}
 
 	return sch.SchEnoNone
 }
 
+//
+// Legacy data stores kept the expired time of pairs in an "expired" sub store
+// under the path of the store, keyed by:
+//
+//	expired time(16 decimal digits, unix seconds) | key
+//
+// the pairs are put again with the keep time left, those out of keep time are
+// deleted, and then the sub store is removed. only the leveldb one is migrated,
+// since expired time in the legacy file store was never applied after reboot.
+//
+const (
+	dsLegacyExpiredDir = "expired"
+	dsLegacyTimeLength = 16
+)
+
+func dsMigrateLegacyExpired(ds Datastore, dsPath string, leveldb bool) error {
+	expPath := path.Join(dsPath, dsLegacyExpiredDir)
+	if _, err := os.Stat(expPath); os.IsNotExist(err) {
+		return nil
+	}
+	if leveldb {
+		ls, err := persistent.NewLevelStorage(expPath)
+		if err != nil {
+			return err
+		}
+		now := time.Now().Unix()
+		migrated, deleted := 0, 0
+		it := ls.GetLevelDB().NewIterator(nil, nil)
+		for it.Next() {
+			ek := it.Key()
+			if len(ek) <= dsLegacyTimeLength {
+				continue
+			}
+			secs, err := strconv.ParseInt(string(ek[:dsLegacyTimeLength]), 10, 64)
+			if err != nil {
+				log.Debugf("dsMigrateLegacyExpired: invalid time string: %s", string(ek[:dsLegacyTimeLength]))
+				continue
+			}
+			k := ek[dsLegacyTimeLength:]
+			if secs <= now {
+				ds.Delete(k)
+				deleted++
+				continue
+			}
+			if eno, v := ds.Get(k); eno == DhtEnoNone {
+				ds.Put(k, v, time.Second*time.Duration(secs-now))
+				migrated++
+			}
+		}
+		it.Release()
+		err = it.Error()
+		ls.Close()
+		if err != nil {
+			return err
+		}
+		log.Debugf("dsMigrateLegacyExpired: migrated: %d, deleted: %d", migrated, deleted)
+	}
+	return os.RemoveAll(expPath)
+}
+
 //
 // poweroff handler
 //
 func (dsMgr *DsMgr) poweroff(ptn interface{}) sch.SchErrno {
 	log.Debugf("poweroff: task will be done ...")
 	dsMgr.ds.Close()
-	dsMgr.dsExp.Close()
 	return dsMgr.sdl.SchTaskDone(dsMgr.ptnMe, dsMgr.name, sch.SchEnoKilled)
 }
 
-//
-// tick timer handler
-//
-func (dsMgr *DsMgr) tickTimerHandler() sch.SchErrno {
-	if err := dsMgr.tmMgr.TickProc(); err != TmEnoNone {
-		log.Debugf("TickProc: error: %s", err.Error())
-		return sch.SchEnoUserTask
-	}
-	return sch.SchEnoNone
-}
-
 //
 // add value request handler
 //
@@ -481,10 +469,10 @@ func (dsMgr *DsMgr) localAddValReq(msg *sch.MsgDhtDsMgrAddValReq) sch.SchErrno {
 	// store it
 	//
 
-	if eno := dsMgr.store(&k, msg.Val, msg.KT); eno != DhtEnoNone {
+	if eno := dsMgr.store(&k, msg.Val, msg.Extra, msg.KT); eno != DhtEnoNone {
 		log.Errorf("localAddValReq: store failed, eno: %d", eno)
 		if schEno := dsMgr.localAddValRsp(sch.EvDhtMgrPutValueRsp, k[0:], nil, eno); schEno != sch.SchEnoNone {
-			log.Errorf("localAddValReq: localAddValRsp failed, eno: %e", schEno)
+			log.Errorf("localAddValReq: localAddValRsp failed, eno: %d", schEno)
 			return schEno
 		}
 		return sch.SchEnoUserTask
@@ -532,7 +520,7 @@ func (dsMgr *DsMgr) localGetValueReq(msg *sch.MsgDhtMgrGetValueReq) sch.SchErrno
 	//
 
 	if !dsMgr.getfromPeer {
-		if val := dsMgr.fromStore(&k); val != nil && len(val) > 0 {
+		if val, _ := dsMgr.fromStore(&k); val != nil && len(val) > 0 {
 			log.Debugf("localGetValueReq: get from local ok, sdl: %s", dsMgr.sdlName)
 			return dsMgr.localGetValRsp(k[0:], val, DhtEnoNone)
 		}
@@ -579,7 +567,7 @@ func (dsMgr *DsMgr)localGetValueBatchReq(msg *sch.MsgDhtMgrGetValueBatchReq) sch
 		dsk := DsKey{}
 		for _, k := range msg.Keys {
 			copy(dsk[0:], k)
-			if val := dsMgr.fromStore(&dsk); val != nil && len(val) > 0 {
+			if val, _ := dsMgr.fromStore(&dsk); val != nil && len(val) > 0 {
 				log.Tracef("localGetValueBatchReq: get from local ok, sdl: %s, key: %x",
 					dsMgr.sdlName, dsk)
 				msg.ValCh<-val
@@ -771,7 +759,11 @@ func (dsMgr *DsMgr) qryMgrQueryResultInd(msg *sch.MsgDhtQryMgrQueryResultInd) sc
 	} else if msg.ForWhat == MID_GETVALUE_REQ {
 
 		if msg.Eno == int(DhtEnoNone) {
-			dsMgr.store(&msg.Target, msg.Val, DsMgrDurInf)
+			if err := dsMgr.selectStored(&msg.Target, msg.ValEx); err != nil {
+				log.Debugf("qryMgrQueryResultInd: not stored, key: %x, error: %s", msg.Target, err.Error())
+			} else {
+				dsMgr.store(&msg.Target, msg.Val, msg.ValEx, DsMgrDurInf)
+			}
 		}
 		return dsMgr.localGetValRsp(msg.Target[0:], msg.Val, DhtErrno(msg.Eno))
 
@@ -792,6 +784,7 @@ func (dsMgr *DsMgr) putValReq(msg *sch.MsgDhtDsMgrPutValReq) sch.SchErrno {
 	//
 
 	pv, _ := msg.Msg.(*PutValue)
+	conInst, _ := msg.ConInst.(*ConInst)
 	dsk := DsKey{}
 
 	for _, v := range pv.Values {
@@ -799,7 +792,35 @@ func (dsMgr *DsMgr) putValReq(msg *sch.MsgDhtDsMgrPutValReq) sch.SchErrno {
 		copy(dsk[0:], v.Key)
 		log.Tracef("putValReq: key: %x", dsk)
 
-		if eno := dsMgr.store(&dsk, v.Val, pv.KT); eno != DhtEnoNone {
+		//
+		// values rejected by validator are discarded, and the peer sent it is
+		// reported to route manager.
+		//
+
+		extra, _ := v.Extra.([]byte)
+		if len(v.Key) != DsKeyLength {
+			log.Debugf("putValReq: invalid key length: %d", len(v.Key))
+			continue
+		}
+		if err := ValidateRecord(v.Key, v.Val, extra); err != nil {
+			log.Warnf("putValReq: rejected, key: %x, error: %s", dsk, err.Error())
+			if conInst != nil {
+				reportBadRecord(dsMgr.sdl, dsMgr.ptnMe, dsMgr.ptnRutMgr, &conInst.hsInfo.peer)
+			}
+			continue
+		}
+
+		//
+		// a value not newer than the stored one is discarded, the peer may
+		// just be behind and is not reported.
+		//
+
+		if err := dsMgr.selectStored(&dsk, extra); err != nil {
+			log.Debugf("putValReq: discarded, key: %x, error: %s", dsk, err.Error())
+			continue
+		}
+
+		if eno := dsMgr.store(&dsk, v.Val, extra, pv.KT); eno != DhtEnoNone {
 			log.Warnf("putValReq: store failed, eno: %d", eno)
 		}
 	}
@@ -863,10 +884,11 @@ func (dsMgr *DsMgr) getValReq(msg *sch.MsgDhtDsMgrGetValReq) sch.SchErrno {
 	// check local data store
 	//
 
-	if val := dsMgr.fromStore(&dsk); len(val) > 0 {
+	if val, extra := dsMgr.fromStore(&dsk); len(val) > 0 {
 		gvRsp.Value = &DhtValue{
-			Key: dsk[0:],
-			Val: val,
+			Key:   dsk[0:],
+			Val:   val,
+			Extra: extra,
 		}
 		return rsp2Peer()
 	}
@@ -992,13 +1014,13 @@ func (dsMgr *DsMgr) qryStartRsp(msg *sch.MsgDhtQryMgrQueryStartRsp) sch.SchErrno
 }
 
 //
-// get value from store by key
+// get value and record extra from store by key
 //
-func (dsMgr *DsMgr) fromStore(k *DsKey) []byte {
+func (dsMgr *DsMgr) fromStore(k *DsKey) ([]byte, []byte) {
 
 	eno, val := dsMgr.ds.Get(k[0:])
 	if eno != DhtEnoNone {
-		return nil
+		return nil, nil
 	}
 
 	dsr := DsRecord{
@@ -1014,21 +1036,29 @@ func (dsMgr *DsMgr) fromStore(k *DsKey) []byte {
 
 	if eno := ddsr.DecDsRecord(&dsr); eno != DhtEnoNone {
 		log.Errorf("fromStore: DecDsRecord failed, eno: %d", eno)
-		return nil
+		return nil, nil
 	}
 
-	return ddsr.Value
+	return ddsr.Value, ddsr.Extra
+}
+
+//
+// check record against the one stored for the key, see SelectRecord
+//
+func (dsMgr *DsMgr) selectStored(k *DsKey, extra []byte) error {
+	_, stored := dsMgr.fromStore(k)
+	return SelectRecord(k[0:], extra, stored)
 }
 
 //
 // store (key, value) pair to data store
 //
-func (dsMgr *DsMgr) store(k *DsKey, v DsValue, kt time.Duration) DhtErrno {
+func (dsMgr *DsMgr) store(k *DsKey, v DsValue, extra []byte, kt time.Duration) DhtErrno {
 
 	ddsr := DhtDatastoreRecord{
 		Key:   k[0:],
 		Value: v.([]byte),
-		Extra: nil,
+		Extra: extra,
 	}
 
 	dsr := new(DsRecord)
@@ -1037,10 +1067,7 @@ func (dsMgr *DsMgr) store(k *DsKey, v DsValue, kt time.Duration) DhtErrno {
 		return eno
 	}
 
-	if dsMgrApplyCleanupTimer {
-		return dsMgr.Put(dsr.Key[0:], dsr.Value, kt)
-	}
-	return dsMgr.ds.Put(dsr.Key[0:], dsr.Value, kt)
+	return dsMgr.Put(dsr.Key[0:], dsr.Value, kt)
 }
 
 //
@@ -1099,6 +1126,9 @@ func (dsMgr *DsMgr) getFileDatastoreConfig(fdc *FileDatastoreConfig) DhtErrno {
 		shardFuncName: cfg.ShardFuncName,
 		padLength:     cfg.PadLength,
 		sync:          cfg.Sync,
+		maxRecords:    cfg.MaxRecords,
+		maxBytes:      cfg.MaxBytes,
+		sweepPeriod:   cfg.SweepPeriod,
 	}
 
 	*fdc = dsMgr.fdsCfg
@@ -1118,145 +1148,12 @@ func (dsMgr *DsMgr) getLeveldbDatastoreConfig(ldc *LeveldbDatastoreConfig) DhtEr
 		BlockCacheCapacity:     8 * opt.MiB,
 		BlockSize:              4 * opt.MiB,
 		FilterBits:             10,
+		MaxRecords:             cfg.MaxRecords,
+		MaxBytes:               cfg.MaxBytes,
+		SweepPeriod:            cfg.SweepPeriod,
 	}
 
 	*ldc = dsMgr.ldsCfg
 
 	return DhtEnoNone
 }
-
-//
-// cleanup in power on stage
-//
-func (dsMgr *DsMgr) cleanUpReboot() DhtErrno {
-
-	// clean up those [key, val] pairs out of keep time. we loop the "expired" database
-	// keys, split them get the "real" key for the value, and then delete the "expired"
-	// keys and the "real" [key, val] pairs.
-
-	if dsType == dstMemoryMap {
-
-		return DhtEnoNone
-
-	} else if dsType == dstFileSystem {
-
-		return DhtEnoNotSup
-
-	} else if dsType == dstLevelDB {
-
-		lds, _ := dsMgr.dsExp.(*LeveldbDatastore)
-		ldb := lds.ls.GetLevelDB()
-		it := ldb.NewIterator(nil, nil)
-
-		for it.First() {
-
-			ek := it.Key()
-			t, k := dsMgr.splitExpiredKey(ek)
-			secondes, err := strconv.ParseInt(string(t), 10, 64)
-
-			if err != nil {
-				log.Warnf("cleanUpReboot: invalid time string: %s", string(t))
-				continue
-			}
-
-			if time.Now().Unix() >= secondes {
-
-				dsMgr.Delete(k)
-				lds.Delete(ek)
-
-			} else {
-
-				kt := time.Second * time.Duration(secondes-time.Now().Unix())
-				tm, err := dsMgr.tmMgr.GetTimer(kt, nil, nil)
-
-				if err != nil {
-					log.Errorf("cleanUpReboot: GetTimer failed, error: %s", err.Error())
-					continue
-				}
-
-				dsMgr.tmMgr.SetTimerData(tm, tm)
-				dsMgr.tmMgr.SetTimerKey(tm, k)
-				dsMgr.tmMgr.SetTimerHandler(tm, dsMgr.cleanUpTimerCb)
-			}
-		}
-	} else {
-
-		log.Debugf("cleanUpReboot: unknown data store type: %d", dsType)
-		return DhtEnoDatastore
-	}
-
-	return DhtEnoNone
-}
-
-func (dsMgr *DsMgr) startTickTimer() DhtErrno {
-	td := sch.TimerDescription{
-		Name:  "_dsMgrTickTimer",
-		Utid:  sch.DhtDsMgrTickTimerId,
-		Tmt:   sch.SchTmTypePeriod,
-		Dur:   oneTick,
-		Extra: nil,
-	}
-
-	eno, tid := dsMgr.sdl.SchSetTimer(dsMgr.ptnMe, &td)
-	if eno != sch.SchEnoNone {
-		log.Errorf("startTickTimer: SchSetTimer failed, eno: %d", eno)
-		return DhtEnoScheduler
-	}
-
-	dsMgr.tidTick = tid
-
-	return DhtEnoNone
-}
-
-//
-// called back to clean [key, val] out of keep time
-//
-func (dsMgr *DsMgr) cleanUpTimerCb(el *list.Element, data interface{}) interface{} {
-
-	// notice: need not to call dsMgr.tmMgr.KillTimer, since the timer would
-	// be removed by timer manager itself.
-
-	err := DhtErrno(DhtEnoNone)
-	tm, ok := data.(*timer)
-	if !ok {
-		log.Debugf("cleanUpTimerCb: invalid timer")
-		return DhtEnoMismatched
-	}
-
-	expKey := dsMgr.makeExpiredKey(tm.k, tm.to)
-	if eno := dsMgr.ds.Delete(tm.k[0:]); eno != DhtEnoNone {
-		err = DhtErrno(DhtEnoDatastore)
-		goto _cleanUpfailed
-	}
-
-	if eno := dsMgr.dsExp.Delete(expKey); eno != DhtEnoNone {
-		err = DhtErrno(DhtEnoDatastore)
-		goto _cleanUpfailed
-	}
-
-_cleanUpfailed:
-
-	if err != DhtEnoNone {
-		log.Debugf("cleanUpTimerCb: Delete failed, error: %s", err.Error())
-		return err
-	}
-
-	return nil
-}
-
-//
-// make a "expired" key for [key, val] which expired
-//
-func (dsMgr *DsMgr) makeExpiredKey(k []byte, to time.Time) []byte {
-	strTime := strconv.FormatInt(to.Unix(), 10)
-	strTime = strings.Repeat("0", 16-len(strTime)) + strTime
-	ek := append([]byte(strTime), k...)
-	return ek
-}
-
-//
-// split a "expired" key into "expired time" and "real key"
-//
-func (dsMgr *DsMgr) splitExpiredKey(expKey []byte) (t []byte, k []byte) {
-	return expKey[0:16], expKey[16:]
-}
@@ -472,9 +472,10 @@ func (dhtMgr *DhtMgr) getProviderRsp(msg *sch.MsgDhtMgrGetProviderRsp) sch.SchEr
 //
 func (dhtMgr *DhtMgr) putValueReq(msg *sch.MsgDhtMgrPutValueReq) sch.SchErrno {
 	req := sch.MsgDhtDsMgrAddValReq{
-		Key: msg.Key,
-		Val: msg.Val,
-		KT:  msg.KeepTime,
+		Key:   msg.Key,
+		Val:   msg.Val,
+		Extra: msg.Extra,
+		KT:    msg.KeepTime,
 	}
 	return dhtMgr.dispMsg(dhtMgr.ptnDsMgr, sch.EvDhtDsMgrAddValReq, &req)
 }
@@ -21,183 +21,266 @@
 package dht
 
 import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
-)
-
-
-//
-// file data store, empty and "unsupported"
-//
-
-type FileDatastoreConfig struct {
-	path          string // data store path
-	shardFuncName string // shard function name
-	padLength     int    // padding length
-	sync          bool   // sync file store flag
-}
-
-type FileDatastore struct{}
-
-func NewFileDatastore(cfg *FileDatastoreConfig) *FileDatastore {
-	return (*FileDatastore)(nil)
-}
-
-func (fds *FileDatastore) Put(k []byte, v DsValue, kt time.Duration) DhtErrno {
-	return DhtEnoNotSup
-}
-
-func (fds *FileDatastore) Get(k []byte) (eno DhtErrno, value DsValue) {
-	return DhtEnoNotSup, nil
-}
-
-func (fds *FileDatastore) Delete(k []byte) DhtErrno {
-	return DhtEnoNotSup
-}
-
-func (fds *FileDatastore) Close() DhtErrno {
-	return DhtEnoNotSup
-}
 
-/*****************************************************************************
- *
- * The following codes implement a data store based on ipfs/go-ds-flatfs, and
- * would not be applied in the yeechain project. to make the codes looked more
- * simple, we comment the implement, and make a "unsupported" data store as
- * shown above.
- *
- *
-
-import (
-	"fmt"
-	"time"
-	ipfsds "github.com/ipfs/go-datastore"
-	ipfsfs "github.com/ipfs/go-ds-flatfs"
-	log "github.com/yeeco/gyee/p2p/logger"
+	log "github.com/yeeco/gyee/log"
 )
 
 //
-// all are based on ipfs-datastore packages, see ipfs (datastore, flatfs, ...)
-// for more please.
+// File data store: each (key, value) pair is stored in a flat file named by
+// the hex string of the key, and the files are sharded into directories by
+// the shard function configured, like that of ipfs/go-ds-flatfs:
 //
+//	path/<shard>/<hex key>.data
+//
+const (
+	sfnPrefix     = "prefix"
+	sfnSuffix     = "suffix"
+	sfnNextToLast = "next-to-last"
+)
 
 const (
-	sfnPrefix		= "prefix"
-	sfnSuffix		= "suffix"
-	sfnNextToLast	= "next-to-last"
+	fdsExtension = ".data"
+	fdsTempExt   = ".temp"
+	fdsPadChar   = "_"
 )
 
-var sfn2ShardIdV1 = map[string]func (padLen int) *ipfsfs.ShardIdV1 {
-	sfnPrefix:		ipfsfs.Prefix,
-	sfnSuffix:		ipfsfs.Suffix,
-	sfnNextToLast:	ipfsfs.NextToLast,
+var sfn2ShardFunc = map[string]func(key string, padLen int) string{
+	sfnPrefix: func(key string, padLen int) string {
+		key = key + strings.Repeat(fdsPadChar, padLen)
+		return key[:padLen]
+	},
+	sfnSuffix: func(key string, padLen int) string {
+		key = strings.Repeat(fdsPadChar, padLen) + key
+		return key[len(key)-padLen:]
+	},
+	sfnNextToLast: func(key string, padLen int) string {
+		key = strings.Repeat(fdsPadChar, padLen+1) + key
+		offset := len(key) - padLen - 1
+		return key[offset : offset+padLen]
+	},
 }
 
 type FileDatastoreConfig struct {
-	path				string				// data store path
-	shardFuncName		string				// shard function name
-	padLength			int					// padding length
-	sync				bool				// sync file store flag
+	path          string        // data store path
+	shardFuncName string        // shard function name
+	padLength     int           // padding length
+	sync          bool          // sync file store flag
+	maxRecords    int           // max number of (key, value) pairs, 0 for no limit
+	maxBytes      int64         // max total size of values, 0 for no limit
+	sweepPeriod   time.Duration // period to sweep pairs out of keep time
 }
 
 type FileDatastore struct {
-	cfg		FileDatastoreConfig				// configuration
-	ffs		*ipfsfs.Datastore				// file datastore pointer
+	cfg   FileDatastoreConfig                 // configuration
+	shard func(key string, padLen int) string // shard function
+	lock  sync.Mutex                          // lock for index and files
+	idx   *dsIndex                            // index for keep time and capacity
 }
 
 //
 // New file data store
 //
 func NewFileDatastore(cfg *FileDatastoreConfig) *FileDatastore {
-
 	if cfg == nil {
-		log.Debug("NewFileDatastore: nil configuration")
+		log.Debugf("NewFileDatastore: nil configuration")
 		return nil
 	}
-
-	sidv1 := sfn2ShardIdV1[cfg.shardFuncName](cfg.padLength)
-	ffs, err := ipfsfs.CreateOrOpen(cfg.path, sidv1, cfg.sync)
-
-	if err != nil {
-		log.Debug("NewFileDatastore: CreateOrOpen faialed, err: %s", err.Error())
+	shard, ok := sfn2ShardFunc[cfg.shardFuncName]
+	if !ok || cfg.padLength <= 0 {
+		log.Debugf("NewFileDatastore: invalid shard function: %s, padLength: %d",
+			cfg.shardFuncName, cfg.padLength)
 		return nil
 	}
-
-	return &FileDatastore {
-		cfg: *cfg,
-		ffs: ffs,
+	if err := os.MkdirAll(cfg.path, 0755); err != nil {
+		log.Debugf("NewFileDatastore: MkdirAll failed, error: %s", err.Error())
+		return nil
+	}
+	fds := FileDatastore{
+		cfg:   *cfg,
+		shard: shard,
+		idx:   newDsIndex(cfg.maxRecords, cfg.maxBytes),
+	}
+	if err := fds.loadIndex(); err != nil {
+		log.Debugf("NewFileDatastore: loadIndex failed, error: %s", err.Error())
+		return nil
 	}
+	fds.idx.startSweeper("FileDatastore", cfg.sweepPeriod, fds.sweepDelete)
+	return &fds
 }
 
 //
-// Put
+// Rebuild index from the files stored, those out of keep time and temporary
+// files left by an interrupted Put are removed.
 //
-func (fds *FileDatastore)Put(k []byte, v DsValue, kt time.Duration) DhtErrno {
+func (fds *FileDatastore) loadIndex() error {
+	now := time.Now().UnixNano()
+	victims := make([]string, 0)
+	err := filepath.Walk(fds.cfg.path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		name := fi.Name()
+		if strings.HasSuffix(name, fdsTempExt) {
+			victims = append(victims, p)
+			return nil
+		}
+		if !strings.HasSuffix(name, fdsExtension) {
+			return nil
+		}
+		k, err := hex.DecodeString(strings.TrimSuffix(name, fdsExtension))
+		if err != nil || len(k) != DsKeyLength {
+			return nil
+		}
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		v, expire := dsDecValue(b)
+		if expire != 0 && expire <= now {
+			victims = append(victims, p)
+			return nil
+		}
+		dsKey := DsKey{}
+		copy(dsKey[0:], k)
+		for _, ek := range fds.idx.add(dsKey, int64(len(v)), expire) {
+			victims = append(victims, fds.keyPath(ek[0:]))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, p := range victims {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
 
-	strKey := fmt.Sprintf("%x", k)
-	dsk := ipfsds.NewKey(strKey)
-	log.Debug("Put: key: %s", dsk)
+func (fds *FileDatastore) keyPath(k []byte) string {
+	strKey := hex.EncodeToString(k)
+	return filepath.Join(fds.cfg.path, fds.shard(strKey, fds.cfg.padLength), strKey+fdsExtension)
+}
 
-	if err := fds.ffs.Put(dsk, v.([]byte)); err != nil {
-		log.Debug("Put: Put failed, err: %s", err.Error())
+//
+// Put
+//
+func (fds *FileDatastore) Put(k []byte, v DsValue, kt time.Duration) DhtErrno {
+	dsKey := DsKey{}
+	copy(dsKey[0:], k)
+	val := v.([]byte)
+	expire := dsExpireTime(kt)
+	fds.lock.Lock()
+	defer fds.lock.Unlock()
+	if err := fds.writeFile(fds.keyPath(k), dsEncValue(val, expire)); err != nil {
+		log.Debugf("Put: failed, error: %s", err.Error())
 		return DhtEnoDatastore
 	}
-
+	for _, ek := range fds.idx.add(dsKey, int64(len(val)), expire) {
+		if err := os.Remove(fds.keyPath(ek[0:])); err != nil && !os.IsNotExist(err) {
+			log.Debugf("Put: evict failed, error: %s", err.Error())
+		}
+	}
 	return DhtEnoNone
 }
 
 //
-// Get
+// Write file through a temporary one and then rename it, so a file is either
+// the old one or the new one even the process is interrupted.
 //
-func (fds *FileDatastore)Get(k []byte) (eno DhtErrno, value DsValue) {
-
-	strKey := fmt.Sprintf("%x", k)
-	dsk := ipfsds.NewKey(strKey)
-	log.Debug("Get: key: %s", dsk)
-
-	val, err := fds.ffs.Get(dsk)
-
+func (fds *FileDatastore) writeFile(p string, b []byte) error {
+	dir := filepath.Dir(p)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, filepath.Base(p)+"-*"+fdsTempExt)
 	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(b); err == nil && fds.cfg.sync {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), p)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
 
-		if err == ipfsds.ErrNotFound {
-			log.Debug("Get: not found")
+//
+// Get
+//
+func (fds *FileDatastore) Get(k []byte) (eno DhtErrno, value DsValue) {
+	dsKey := DsKey{}
+	copy(dsKey[0:], k)
+	fds.lock.Lock()
+	defer fds.lock.Unlock()
+	p := fds.keyPath(k)
+	if found, expired := fds.idx.touch(dsKey); !found {
+		return DhtEnoNotFound, nil
+	} else if expired {
+		os.Remove(p)
+		return DhtEnoNotFound, nil
+	}
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fds.idx.remove(dsKey)
 			return DhtEnoNotFound, nil
 		}
-
-		log.Debug("Get: Get failed, err: %s", err.Error())
+		log.Debugf("Get: failed, error: %s", err.Error())
 		return DhtEnoDatastore, nil
 	}
-
-	return DhtEnoNone, val
+	v, _ := dsDecValue(b)
+	return DhtEnoNone, v
 }
 
 //
 // Delete
 //
-func (fds *FileDatastore)Delete(k []byte) DhtErrno {
-
-	strKey := fmt.Sprintf("%x", k)
-	dsk := ipfsds.NewKey(strKey)
-	log.Debug("Delete: key: %s", dsk)
-
-	if err := fds.ffs.Delete(dsk); err != nil {
-		log.Debug("Delete: Delete failed, err: %s", err.Error())
+func (fds *FileDatastore) Delete(k []byte) DhtErrno {
+	dsKey := DsKey{}
+	copy(dsKey[0:], k)
+	fds.lock.Lock()
+	defer fds.lock.Unlock()
+	fds.idx.remove(dsKey)
+	if err := os.Remove(fds.keyPath(k)); err != nil && !os.IsNotExist(err) {
+		log.Debugf("Delete: failed, error: %s", err.Error())
 		return DhtEnoDatastore
 	}
-
 	return DhtEnoNone
 }
 
 //
 // Close
 //
-func (fds *FileDatastore)Close() DhtErrno {
-	if err := fds.ffs.Close(); err != nil {
-		log.Debug("Close: failed, error: %s", err.Error())
-		return DhtEnoDatastore
-	}
+func (fds *FileDatastore) Close() DhtErrno {
+	fds.idx.stopSweeper()
 	return DhtEnoNone
 }
 
-*
-*
-****************************************************************************/
+func (fds *FileDatastore) sweepDelete(k DsKey) {
+	fds.lock.Lock()
+	defer fds.lock.Unlock()
+	if !fds.idx.has(k) {
+		if err := os.Remove(fds.keyPath(k[0:])); err != nil && !os.IsNotExist(err) {
+			log.Debugf("sweepDelete: failed, error: %s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package dht
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testFileDatastore(t *testing.T, dir string, maxRecords int, maxBytes int64) *FileDatastore {
+	fds := NewFileDatastore(&FileDatastoreConfig{
+		path:          dir,
+		shardFuncName: sfnNextToLast,
+		padLength:     2,
+		maxRecords:    maxRecords,
+		maxBytes:      maxBytes,
+		sweepPeriod:   time.Hour,
+	})
+	if fds == nil {
+		t.Fatal("NewFileDatastore() failed")
+	}
+	return fds
+}
+
+func testDsGet(ds Datastore, i int) []byte {
+	k := testDsKey(i)
+	if eno, v := ds.Get(k[0:]); eno == DhtEnoNone {
+		return v.([]byte)
+	}
+	return nil
+}
+
+func TestFileDatastore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yee-dsfile-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fds := testFileDatastore(t, dir, 3, 0)
+	defer fds.Close()
+	for i := 0; i < 4; i++ {
+		k := testDsKey(i)
+		if eno := fds.Put(k[0:], []byte{byte(i)}, DsMgrDurInf); eno != DhtEnoNone {
+			t.Fatalf("Put() %d", eno)
+		}
+	}
+
+	// evicted by count, with its file
+	if v := testDsGet(fds, 0); v != nil {
+		t.Errorf("evicted value %v", v)
+	}
+	k := testDsKey(0)
+	if _, err := os.Stat(fds.keyPath(k[0:])); !os.IsNotExist(err) {
+		t.Errorf("file of evicted value %v", err)
+	}
+	if v := testDsGet(fds, 3); !bytes.Equal(v, []byte{3}) {
+		t.Errorf("got %v", v)
+	}
+
+	// out of keep time
+	k = testDsKey(1)
+	fds.Put(k[0:], []byte{1}, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if v := testDsGet(fds, 1); v != nil {
+		t.Errorf("expired value %v", v)
+	}
+	if _, err := os.Stat(fds.keyPath(k[0:])); !os.IsNotExist(err) {
+		t.Errorf("file of expired value %v", err)
+	}
+
+	k = testDsKey(2)
+	if eno := fds.Delete(k[0:]); eno != DhtEnoNone || testDsGet(fds, 2) != nil {
+		t.Errorf("Delete() %d", eno)
+	}
+}
+
+func TestFileDatastoreEvictBytes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yee-dsfile-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fds := testFileDatastore(t, dir, 0, 8)
+	defer fds.Close()
+	for i := 0; i < 3; i++ {
+		k := testDsKey(i)
+		fds.Put(k[0:], make([]byte, 4), DsMgrDurInf)
+	}
+	if testDsGet(fds, 0) != nil || testDsGet(fds, 1) == nil || testDsGet(fds, 2) == nil {
+		t.Error("not evicted by bytes")
+	}
+	if cnt, bytes := fds.idx.count(); cnt != 2 || bytes != 8 {
+		t.Errorf("count %d bytes %d", cnt, bytes)
+	}
+}
+
+func TestFileDatastoreReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yee-dsfile-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fds := testFileDatastore(t, dir, 0, 0)
+	for i := 0; i < 4; i++ {
+		k := testDsKey(i)
+		fds.Put(k[0:], bytes.Repeat([]byte{byte(i)}, i+1), DsMgrDurInf)
+	}
+	k := testDsKey(4)
+	fds.Put(k[0:], []byte{4}, time.Millisecond)
+	expired := fds.keyPath(k[0:])
+	fds.Close()
+
+	// a legacy value, and a temporary file left by an interrupted put
+	legacy := testDsKey(5)
+	if err := ioutil.WriteFile(fds.keyPath(legacy[0:]), []byte{5}, 0644); err != nil {
+		t.Fatalf("WriteFile() %v", err)
+	}
+	temp := filepath.Join(filepath.Dir(expired), "interrupted"+fdsTempExt)
+	if err := ioutil.WriteFile(temp, []byte{6}, 0644); err != nil {
+		t.Fatalf("WriteFile() %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	// index rebuilt under a lower capacity
+	fds = testFileDatastore(t, dir, 4, 0)
+	defer fds.Close()
+	if cnt, _ := fds.idx.count(); cnt != 4 {
+		t.Errorf("count %d after reopen", cnt)
+	}
+	for _, p := range []string{expired, temp} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%s not removed on reopen, %v", filepath.Base(p), err)
+		}
+	}
+	if v := testDsGet(fds, 5); !bytes.Equal(v, []byte{5}) {
+		t.Errorf("legacy value %v", v)
+	}
+	found := 0
+	for i := 0; i < 4; i++ {
+		if v := testDsGet(fds, i); v != nil {
+			if !bytes.Equal(v, bytes.Repeat([]byte{byte(i)}, i+1)) {
+				t.Errorf("value %d reloaded %v", i, v)
+			}
+			found++
+		}
+	}
+	if found != 3 {
+		t.Errorf("%d values reloaded, want 3", found)
+	}
+}
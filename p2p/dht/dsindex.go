@@ -0,0 +1,253 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package dht
+
+import (
+	"container/list"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	log "github.com/yeeco/gyee/log"
+)
+
+//
+// Index of (key, value) pairs in a data store, shared by all data store types
+// to apply the keep time(kt) and the capacity of the store in the same way:
+// the expired time and size of each pair are backup in the index, which are
+// checked when the pair is got, and a background sweeper removes the pairs out
+// of keep time periodically, as well as the least recently used ones when the
+// number of pairs or the total size exceeds the capacity.
+//
+type dsIndexEntry struct {
+	key    DsKey // key
+	expire int64 // expired time in unix nano, 0 for keep for ever
+	size   int64 // size of value
+}
+
+type dsIndex struct {
+	lock     sync.Mutex              // lock for index
+	maxCount int                     // max number of pairs, 0 for no limit
+	maxBytes int64                   // max total size, 0 for no limit
+	bytes    int64                   // total size
+	lru      *list.List              // front is the most recently used
+	entries  map[DsKey]*list.Element // entries
+	done     chan bool               // signal the sweeper to be done
+}
+
+//
+// Default capacity and sweeping period
+//
+const (
+	DsDftMaxRecords  = 1024 * 1024
+	DsDftMaxBytes    = int64(1024 * 1024 * 1024)
+	DsDftSweepPeriod = time.Minute
+)
+
+func newDsIndex(maxCount int, maxBytes int64) *dsIndex {
+	return &dsIndex{
+		maxCount: maxCount,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[DsKey]*list.Element, 0),
+	}
+}
+
+//
+// Expired time for keep time
+//
+func dsExpireTime(kt time.Duration) int64 {
+	if kt == DsMgrDurInf {
+		return 0
+	}
+	return time.Now().Add(kt).UnixNano()
+}
+
+//
+// Add or update an entry, the keys evicted for capacity returned, which
+// should be removed from the store by caller.
+//
+func (idx *dsIndex) add(k DsKey, size int64, expire int64) []DsKey {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	if el, ok := idx.entries[k]; ok {
+		e := el.Value.(*dsIndexEntry)
+		idx.bytes += size - e.size
+		e.size = size
+		e.expire = expire
+		idx.lru.MoveToFront(el)
+	} else {
+		e := &dsIndexEntry{key: k, expire: expire, size: size}
+		idx.entries[k] = idx.lru.PushFront(e)
+		idx.bytes += size
+	}
+	return idx.evict()
+}
+
+//
+// Check if an entry is available and make it the most recently used one, an
+// expired entry is removed from index, and should be removed from the store
+// by the caller.
+//
+func (idx *dsIndex) touch(k DsKey) (found bool, expired bool) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	el, ok := idx.entries[k]
+	if !ok {
+		return false, false
+	}
+	e := el.Value.(*dsIndexEntry)
+	if e.expire != 0 && e.expire <= time.Now().UnixNano() {
+		idx.removeElement(el)
+		return true, true
+	}
+	idx.lru.MoveToFront(el)
+	return true, false
+}
+
+func (idx *dsIndex) remove(k DsKey) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	if el, ok := idx.entries[k]; ok {
+		idx.removeElement(el)
+	}
+}
+
+func (idx *dsIndex) has(k DsKey) bool {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	_, ok := idx.entries[k]
+	return ok
+}
+
+func (idx *dsIndex) removeElement(el *list.Element) {
+	e := idx.lru.Remove(el).(*dsIndexEntry)
+	delete(idx.entries, e.key)
+	idx.bytes -= e.size
+}
+
+//
+// Remove entries out of keep time and those for capacity from index, the keys
+// removed returned.
+//
+func (idx *dsIndex) sweep() []DsKey {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	now := time.Now().UnixNano()
+	victims := make([]DsKey, 0)
+	for el := idx.lru.Back(); el != nil; {
+		prev := el.Prev()
+		if e := el.Value.(*dsIndexEntry); e.expire != 0 && e.expire <= now {
+			victims = append(victims, e.key)
+			idx.removeElement(el)
+		}
+		el = prev
+	}
+	return append(victims, idx.evict()...)
+}
+
+func (idx *dsIndex) evict() []DsKey {
+	victims := make([]DsKey, 0)
+	for idx.lru.Len() > 1 {
+		if (idx.maxCount <= 0 || idx.lru.Len() <= idx.maxCount) &&
+			(idx.maxBytes <= 0 || idx.bytes <= idx.maxBytes) {
+			break
+		}
+		el := idx.lru.Back()
+		victims = append(victims, el.Value.(*dsIndexEntry).key)
+		idx.removeElement(el)
+	}
+	return victims
+}
+
+func (idx *dsIndex) count() (int, int64) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	return idx.lru.Len(), idx.bytes
+}
+
+//
+// Start the background sweeper, "del" is called to remove the pairs from the
+// store for keys swept out of index, it should check the key against the index
+// again, since the pair might be put again after it's swept.
+//
+func (idx *dsIndex) startSweeper(name string, period time.Duration, del func(k DsKey)) {
+	if period <= 0 {
+		period = DsDftSweepPeriod
+	}
+	done := make(chan bool)
+	idx.done = done
+	go func() {
+		tk := time.NewTicker(period)
+		defer tk.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-tk.C:
+				victims := idx.sweep()
+				for _, k := range victims {
+					del(k)
+				}
+				if len(victims) > 0 {
+					cnt, bytes := idx.count()
+					log.Debugf("startSweeper: %s, swept: %d, remain: %d, bytes: %d",
+						name, len(victims), cnt, bytes)
+				}
+			}
+		}
+	}()
+}
+
+func (idx *dsIndex) stopSweeper() {
+	if idx.done != nil {
+		close(idx.done)
+		idx.done = nil
+	}
+}
+
+//
+// Value with expired time stored by persistent data stores:
+//
+//	magic(1 byte) | expired time(8 bytes, big endian, unix nano) | value
+//
+// value without the magic is one stored before expired time applied, which
+// is taken as a value keep for ever.
+//
+const (
+	dsValMagic     = byte(0xd5)
+	dsValHdrLength = 9
+)
+
+func dsEncValue(v []byte, expire int64) []byte {
+	b := make([]byte, dsValHdrLength+len(v))
+	b[0] = dsValMagic
+	binary.BigEndian.PutUint64(b[1:dsValHdrLength], uint64(expire))
+	copy(b[dsValHdrLength:], v)
+	return b
+}
+
+func dsDecValue(b []byte) (v []byte, expire int64) {
+	if len(b) < dsValHdrLength || b[0] != dsValMagic {
+		return b, 0
+	}
+	return b[dsValHdrLength:], int64(binary.BigEndian.Uint64(b[1:dsValHdrLength]))
+}
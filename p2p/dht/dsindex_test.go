@@ -0,0 +1,157 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package dht
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func testDsKey(i int) DsKey {
+	k := DsKey{}
+	k[0] = byte(i >> 8)
+	k[1] = byte(i)
+	k[DsKeyLength-1] = 0xd5
+	return k
+}
+
+func testDsKeys(keys []DsKey) map[DsKey]bool {
+	m := make(map[DsKey]bool, len(keys))
+	for _, k := range keys {
+		m[k] = true
+	}
+	return m
+}
+
+func TestDsIndexEvictCount(t *testing.T) {
+	idx := newDsIndex(3, 0)
+	for i := 0; i < 3; i++ {
+		if ev := idx.add(testDsKey(i), 1, 0); len(ev) != 0 {
+			t.Fatalf("evicted %d under capacity", len(ev))
+		}
+	}
+
+	// least recently used evicted, touched one kept
+	if found, _ := idx.touch(testDsKey(0)); !found {
+		t.Fatal("key 0 not found")
+	}
+	ev := idx.add(testDsKey(3), 1, 0)
+	if len(ev) != 1 || ev[0] != testDsKey(1) {
+		t.Fatalf("evicted %v, want key 1", ev)
+	}
+	if idx.has(testDsKey(1)) || !idx.has(testDsKey(0)) {
+		t.Error("index not updated by eviction")
+	}
+
+	// update does not count
+	if ev := idx.add(testDsKey(3), 2, 0); len(ev) != 0 {
+		t.Errorf("evicted %d on update", len(ev))
+	}
+	if cnt, bytes := idx.count(); cnt != 3 || bytes != 4 {
+		t.Errorf("count %d bytes %d", cnt, bytes)
+	}
+}
+
+func TestDsIndexEvictBytes(t *testing.T) {
+	idx := newDsIndex(0, 10)
+	idx.add(testDsKey(0), 4, 0)
+	idx.add(testDsKey(1), 4, 0)
+	ev := idx.add(testDsKey(2), 4, 0)
+	if len(ev) != 1 || ev[0] != testDsKey(0) {
+		t.Fatalf("evicted %v, want key 0", ev)
+	}
+
+	// growing a value evicts others
+	ev = idx.add(testDsKey(2), 8, 0)
+	if len(ev) != 1 || ev[0] != testDsKey(1) {
+		t.Fatalf("evicted %v, want key 1", ev)
+	}
+
+	// the most recent one is kept even over capacity
+	ev = idx.add(testDsKey(3), 16, 0)
+	if len(ev) != 1 || ev[0] != testDsKey(2) || !idx.has(testDsKey(3)) {
+		t.Fatalf("evicted %v, want key 2", ev)
+	}
+	if cnt, bytes := idx.count(); cnt != 1 || bytes != 16 {
+		t.Errorf("count %d bytes %d", cnt, bytes)
+	}
+	idx.remove(testDsKey(3))
+	if cnt, bytes := idx.count(); cnt != 0 || bytes != 0 {
+		t.Errorf("count %d bytes %d after remove", cnt, bytes)
+	}
+}
+
+func TestDsIndexExpire(t *testing.T) {
+	idx := newDsIndex(0, 0)
+	past := time.Now().Add(-time.Second).UnixNano()
+	idx.add(testDsKey(0), 1, past)
+	idx.add(testDsKey(1), 1, past)
+	idx.add(testDsKey(2), 1, dsExpireTime(time.Hour))
+	idx.add(testDsKey(3), 1, dsExpireTime(DsMgrDurInf))
+
+	if found, expired := idx.touch(testDsKey(0)); !found || !expired {
+		t.Fatalf("touch expired: found %t expired %t", found, expired)
+	}
+	if found, _ := idx.touch(testDsKey(0)); found {
+		t.Fatal("expired entry left in index")
+	}
+	if found, expired := idx.touch(testDsKey(2)); !found || expired {
+		t.Fatalf("touch alive: found %t expired %t", found, expired)
+	}
+
+	victims := testDsKeys(idx.sweep())
+	if len(victims) != 1 || !victims[testDsKey(1)] {
+		t.Fatalf("swept %v, want key 1", victims)
+	}
+	if cnt, _ := idx.count(); cnt != 2 {
+		t.Errorf("count %d after sweep", cnt)
+	}
+
+	// sweeper deletes what swept out of index
+	idx.add(testDsKey(4), 1, past)
+	deleted := make(chan DsKey, 1)
+	idx.startSweeper("test", time.Millisecond, func(k DsKey) { deleted <- k })
+	defer idx.stopSweeper()
+	select {
+	case k := <-deleted:
+		if k != testDsKey(4) {
+			t.Errorf("sweeper deleted %x", k)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("sweeper not run")
+	}
+}
+
+func TestDsValueCodec(t *testing.T) {
+	v := []byte("value")
+	expire := dsExpireTime(time.Minute)
+	dv, de := dsDecValue(dsEncValue(v, expire))
+	if !bytes.Equal(dv, v) || de != expire {
+		t.Errorf("decoded %q %d", dv, de)
+	}
+
+	// legacy value without expired time is kept for ever
+	dv, de = dsDecValue(v)
+	if !bytes.Equal(dv, v) || de != 0 {
+		t.Errorf("legacy value decoded %q %d", dv, de)
+	}
+}
@@ -21,6 +21,7 @@
 package dht
 
 import (
+	"sync"
 	"time"
 
 	"github.com/yeeco/gyee/log"
@@ -55,16 +56,22 @@ type LeveldbDatastoreConfig struct {
 	BlockCacheCapacity     int
 	BlockSize              int
 	FilterBits             int
+	MaxRecords             int           // max number of (key, value) pairs, 0 for no limit
+	MaxBytes               int64         // max total size of values, 0 for no limit
+	SweepPeriod            time.Duration // period to sweep pairs out of keep time
 }
 
 type LeveldbDatastore struct {
 	ldsCfg *LeveldbDatastoreConfig
 	ls     *persistent.LevelStorage
+	lock   sync.Mutex // lock for index and store
+	idx    *dsIndex   // index for keep time and capacity
 }
 
 func NewLeveldbDatastore(cfg *LeveldbDatastoreConfig) *LeveldbDatastore {
 	ds := LeveldbDatastore{
 		ldsCfg: cfg,
+		idx:    newDsIndex(cfg.MaxRecords, cfg.MaxBytes),
 	}
 	ls, err := persistent.NewLevelStorage(cfg.Path)
 	if err != nil {
@@ -72,31 +79,92 @@ func NewLeveldbDatastore(cfg *LeveldbDatastoreConfig) *LeveldbDatastore {
 		return nil
 	}
 	ds.ls = ls
+	if err := ds.loadIndex(); err != nil {
+		log.Debugf("NewLeveldbDatastore: loadIndex failed, error: %s", err.Error())
+		ls.Close()
+		return nil
+	}
+	ds.idx.startSweeper("LeveldbDatastore", cfg.SweepPeriod, ds.sweepDelete)
 	return &ds
 }
 
+//
+// Rebuild index from the pairs stored, those out of keep time are removed
+//
+func (lds *LeveldbDatastore) loadIndex() error {
+	now := time.Now().UnixNano()
+	victims := make([][]byte, 0)
+	it := lds.ls.GetLevelDB().NewIterator(nil, nil)
+	for it.Next() {
+		v, expire := dsDecValue(it.Value())
+		if expire != 0 && expire <= now {
+			victims = append(victims, append([]byte(nil), it.Key()...))
+			continue
+		}
+		dsKey := DsKey{}
+		copy(dsKey[0:], it.Key())
+		for _, ek := range lds.idx.add(dsKey, int64(len(v)), expire) {
+			victims = append(victims, append([]byte(nil), ek[0:]...))
+		}
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+	for _, k := range victims {
+		if err := lds.ls.Del(k); err != nil {
+			return err
+		}
+	}
+	dsdbLog.Debug("loadIndex: pairs: %d, removed: %d", lds.idx.lru.Len(), len(victims))
+	return nil
+}
+
 func (lds *LeveldbDatastore) Put(k []byte, v DsValue, kt time.Duration) DhtErrno {
-	if err := lds.ls.Put(k[0:], v.([]byte)); err != nil {
+	dsKey := DsKey{}
+	copy(dsKey[0:], k)
+	val := v.([]byte)
+	expire := dsExpireTime(kt)
+	lds.lock.Lock()
+	defer lds.lock.Unlock()
+	if err := lds.ls.Put(k[0:], dsEncValue(val, expire)); err != nil {
 		log.Debugf("Put: failed, error: %s", err.Error())
 		return DhtEnoDatastore
 	}
+	for _, ek := range lds.idx.add(dsKey, int64(len(val)), expire) {
+		if err := lds.ls.Del(ek[0:]); err != nil {
+			log.Debugf("Put: evict failed, error: %s", err.Error())
+		}
+	}
 	return DhtEnoNone
 }
 
 func (lds *LeveldbDatastore) Get(k []byte) (eno DhtErrno, value DsValue) {
-	err := error(nil)
-	value, err = lds.ls.Get(k[0:])
+	dsKey := DsKey{}
+	copy(dsKey[0:], k)
+	lds.lock.Lock()
+	defer lds.lock.Unlock()
+	if found, expired := lds.idx.touch(dsKey); !found {
+		return DhtEnoNotFound, nil
+	} else if expired {
+		lds.ls.Del(k[0:])
+		return DhtEnoNotFound, nil
+	}
+	b, err := lds.ls.Get(k[0:])
 	if err != nil {
 		log.Debugf("Get: failed, error: %s", err.Error())
-		eno = DhtEnoDatastore
-		value = nil
-	} else {
-		eno = DhtEnoNone
+		return DhtEnoDatastore, nil
 	}
-	return
+	v, _ := dsDecValue(b)
+	return DhtEnoNone, v
 }
 
 func (lds *LeveldbDatastore) Delete(k []byte) DhtErrno {
+	dsKey := DsKey{}
+	copy(dsKey[0:], k)
+	lds.lock.Lock()
+	defer lds.lock.Unlock()
+	lds.idx.remove(dsKey)
 	if err := lds.ls.Del(k[0:]); err != nil {
 		log.Debugf("Delete: failed, error: %s", err.Error())
 		return DhtEnoDatastore
@@ -105,9 +173,22 @@ func (lds *LeveldbDatastore) Delete(k []byte) DhtErrno {
 }
 
 func (lds *LeveldbDatastore) Close() DhtErrno {
+	lds.idx.stopSweeper()
+	lds.lock.Lock()
+	defer lds.lock.Unlock()
 	if err := lds.ls.Close(); err != nil {
 		log.Debugf("Close: failed, error: %s", err.Error())
 		return DhtEnoDatastore
 	}
 	return DhtEnoNone
 }
+
+func (lds *LeveldbDatastore) sweepDelete(k DsKey) {
+	lds.lock.Lock()
+	defer lds.lock.Unlock()
+	if !lds.idx.has(k) {
+		if err := lds.ls.Del(k[0:]); err != nil {
+			log.Debugf("sweepDelete: failed, error: %s", err.Error())
+		}
+	}
+}
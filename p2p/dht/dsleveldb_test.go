@@ -0,0 +1,152 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package dht
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/persistent"
+)
+
+func testLeveldbDatastore(t *testing.T, dir string, maxRecords int) *LeveldbDatastore {
+	lds := NewLeveldbDatastore(&LeveldbDatastoreConfig{
+		Path:        dir,
+		MaxRecords:  maxRecords,
+		SweepPeriod: time.Hour,
+	})
+	if lds == nil {
+		t.Fatal("NewLeveldbDatastore() failed")
+	}
+	return lds
+}
+
+func TestLeveldbDatastoreReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yee-dsleveldb-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	lds := testLeveldbDatastore(t, dir, 0)
+	for i := 0; i < 4; i++ {
+		k := testDsKey(i)
+		lds.Put(k[0:], []byte{byte(i)}, DsMgrDurInf)
+	}
+	k := testDsKey(4)
+	lds.Put(k[0:], []byte{4}, time.Millisecond)
+	k = testDsKey(5)
+	lds.Put(k[0:], []byte{5}, time.Hour)
+	lds.Close()
+	time.Sleep(5 * time.Millisecond)
+
+	// expired removed, and evicted for capacity
+	lds = testLeveldbDatastore(t, dir, 4)
+	defer lds.Close()
+	if cnt, bytes := lds.idx.count(); cnt != 4 || bytes != 4 {
+		t.Errorf("count %d bytes %d after reopen", cnt, bytes)
+	}
+	k = testDsKey(4)
+	if has, _ := lds.ls.Has(k[0:]); has {
+		t.Error("expired pair not removed on reopen")
+	}
+	if v := testDsGet(lds, 5); !bytes.Equal(v, []byte{5}) {
+		t.Errorf("value with keep time %v", v)
+	}
+	found := 0
+	for i := 0; i < 4; i++ {
+		if v := testDsGet(lds, i); v != nil {
+			found++
+		}
+	}
+	if found != 3 {
+		t.Errorf("%d values reloaded, want 3", found)
+	}
+}
+
+func TestDsMigrateLegacyExpired(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yee-dsleveldb-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// legacy values and their expired time in the sub store
+	ls, err := persistent.NewLevelStorage(dir)
+	if err != nil {
+		t.Fatalf("NewLevelStorage() %v", err)
+	}
+	expPath := filepath.Join(dir, dsLegacyExpiredDir)
+	exp, err := persistent.NewLevelStorage(expPath)
+	if err != nil {
+		t.Fatalf("NewLevelStorage() %v", err)
+	}
+	now := time.Now().Unix()
+	for i, secs := range []int64{now - 1, now + 3600, 0} {
+		k := testDsKey(i)
+		ls.Put(k[0:], []byte{byte(i)})
+		if secs != 0 {
+			exp.Put(append([]byte(fmt.Sprintf("%016d", secs)), k[0:]...), k[0:])
+		}
+	}
+	ls.Close()
+	exp.Close()
+
+	lds := testLeveldbDatastore(t, dir, 0)
+	defer lds.Close()
+	if err := dsMigrateLegacyExpired(lds, dir, true); err != nil {
+		t.Fatalf("dsMigrateLegacyExpired() %v", err)
+	}
+	if _, err := os.Stat(expPath); !os.IsNotExist(err) {
+		t.Errorf("legacy sub store not removed, %v", err)
+	}
+	if v := testDsGet(lds, 0); v != nil {
+		t.Errorf("expired value %v", v)
+	}
+	for i := 1; i < 3; i++ {
+		if v := testDsGet(lds, i); !bytes.Equal(v, []byte{byte(i)}) {
+			t.Errorf("value %d migrated %v", i, v)
+		}
+	}
+	k := testDsKey(1)
+	el, ok := lds.idx.entries[k]
+	if !ok {
+		t.Fatal("migrated value not indexed")
+	}
+	if expire := el.Value.(*dsIndexEntry).expire; expire <= time.Now().UnixNano() ||
+		expire > time.Now().Add(time.Hour).UnixNano() {
+		t.Errorf("keep time not migrated, expire %d", expire)
+	}
+	k = testDsKey(2)
+	if expire := lds.idx.entries[k].Value.(*dsIndexEntry).expire; expire != 0 {
+		t.Errorf("value without expired time expires at %d", expire)
+	}
+
+	// nothing to do again
+	if err := dsMigrateLegacyExpired(lds, dir, true); err != nil {
+		t.Errorf("dsMigrateLegacyExpired() again %v", err)
+	}
+}
@@ -21,24 +21,29 @@
 package dht
 
 import (
+	"sync"
 	"time"
 )
 
-
 //
 // Data store based on "map" in memory, for test only
 //
 type MapDatastore struct {
-	ds map[DsKey]DsValue // (key, value) map
+	lock sync.Mutex        // lock for map
+	ds   map[DsKey]DsValue // (key, value) map
+	idx  *dsIndex          // index for keep time and capacity
 }
 
 //
-// New map datastore
+// New map datastore, without capacity limited
 //
 func NewMapDatastore() *MapDatastore {
-	return &MapDatastore{
-		ds: make(map[DsKey]DsValue, 0),
+	mds := MapDatastore{
+		ds:  make(map[DsKey]DsValue, 0),
+		idx: newDsIndex(0, 0),
 	}
+	mds.idx.startSweeper("MapDatastore", DsDftSweepPeriod, mds.sweepDelete)
+	return &mds
 }
 
 //
@@ -47,7 +52,16 @@ func NewMapDatastore() *MapDatastore {
 func (mds *MapDatastore) Put(k []byte, v DsValue, kt time.Duration) DhtErrno {
 	dsKey := DsKey{}
 	copy(dsKey[0:], k)
+	size := int64(0)
+	if b, ok := v.([]byte); ok {
+		size = int64(len(b))
+	}
+	mds.lock.Lock()
+	defer mds.lock.Unlock()
 	mds.ds[dsKey] = v
+	for _, ek := range mds.idx.add(dsKey, size, dsExpireTime(kt)) {
+		delete(mds.ds, ek)
+	}
 	return DhtEnoNone
 }
 
@@ -57,11 +71,19 @@ func (mds *MapDatastore) Put(k []byte, v DsValue, kt time.Duration) DhtErrno {
 func (mds *MapDatastore) Get(k []byte) (eno DhtErrno, value DsValue) {
 	dsKey := DsKey{}
 	copy(dsKey[0:], k)
+	mds.lock.Lock()
+	defer mds.lock.Unlock()
+	if found, expired := mds.idx.touch(dsKey); !found {
+		return DhtEnoNotFound, nil
+	} else if expired {
+		delete(mds.ds, dsKey)
+		return DhtEnoNotFound, nil
+	}
 	v, ok := mds.ds[dsKey]
 	if !ok {
 		return DhtEnoNotFound, nil
 	}
-	return DhtEnoNone, &v
+	return DhtEnoNone, v
 }
 
 //
@@ -70,6 +92,9 @@ func (mds *MapDatastore) Get(k []byte) (eno DhtErrno, value DsValue) {
 func (mds *MapDatastore) Delete(k []byte) DhtErrno {
 	dsKey := DsKey{}
 	copy(dsKey[0:], k)
+	mds.lock.Lock()
+	defer mds.lock.Unlock()
+	mds.idx.remove(dsKey)
 	delete(mds.ds, dsKey)
 	return DhtEnoNone
 }
@@ -78,6 +103,17 @@ func (mds *MapDatastore) Delete(k []byte) DhtErrno {
 // Clsoe
 //
 func (mds *MapDatastore) Close() DhtErrno {
+	mds.idx.stopSweeper()
+	mds.lock.Lock()
+	defer mds.lock.Unlock()
 	mds.ds = nil
 	return DhtEnoNone
 }
+
+func (mds *MapDatastore) sweepDelete(k DsKey) {
+	mds.lock.Lock()
+	defer mds.lock.Unlock()
+	if !mds.idx.has(k) && mds.ds != nil {
+		delete(mds.ds, k)
+	}
+}
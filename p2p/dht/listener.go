@@ -371,9 +371,10 @@ func (lsnMgr *LsnMgr) driveSelf() sch.SchErrno {
 func (lsnMgr *LsnMgr) setupListener() DhtErrno {
 	var err error
 	network := lsnMgr.config.network
-	ip := "0.0.0.0" //lsnMgr.config.ip.String()
+	// listen on all interfaces of both ipv4 and ipv6, a multi-homed node can
+	// be reached at any endpoint it advertises.
 	port := lsnMgr.config.port
-	lsnAddr := fmt.Sprintf("%s:%d", ip, port)
+	lsnAddr := fmt.Sprintf(":%d", port)
 
 	if lsnMgr.listener, err = net.Listen(network, lsnAddr); err != nil {
 		log.Crit("setupListener: listen failed", "addr", lsnAddr, "err", err)
@@ -368,6 +368,7 @@ type DhtMessage_Node struct {
 	NodeId               []byte                     `protobuf:"bytes,4,req,name=NodeId" json:"NodeId,omitempty"`
 	ConnType             *DhtMessage_ConnectionType `protobuf:"varint,5,req,name=ConnType,enum=dhtmsg.pb.DhtMessage_ConnectionType" json:"ConnType,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	Addrs                []byte                     `protobuf:"bytes,6,opt,name=Addrs" json:"Addrs,omitempty"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
 }
@@ -440,6 +441,13 @@ func (m *DhtMessage_Node) GetConnType() DhtMessage_ConnectionType {
 	return DhtMessage_CONT_NO
 }
 
+func (m *DhtMessage_Node) GetAddrs() []byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
 type DhtMessage_Value struct {
 	Key                  []byte   `protobuf:"bytes,1,req,name=Key" json:"Key,omitempty"`
 	Val                  []byte   `protobuf:"bytes,2,req,name=Val" json:"Val,omitempty"`
@@ -632,7 +640,10 @@ type DhtMessage_Handshake struct {
 	Protocols            []*DhtMessage_Protocol `protobuf:"bytes,8,rep,name=Protocols" json:"Protocols,omitempty"`
 	Id                   *uint64                `protobuf:"varint,9,req,name=Id" json:"Id,omitempty"`
 	Extra                []byte                 `protobuf:"bytes,10,opt,name=Extra" json:"Extra,omitempty"`
+	SecKey               []byte                 `protobuf:"bytes,11,opt,name=SecKey" json:"SecKey,omitempty"`
+	SecSign              []byte                 `protobuf:"bytes,12,opt,name=SecSign" json:"SecSign,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	Addrs                []byte                 `protobuf:"bytes,13,opt,name=Addrs" json:"Addrs,omitempty"`
 	XXX_unrecognized     []byte                 `json:"-"`
 	XXX_sizecache        int32                  `json:"-"`
 }
@@ -740,6 +751,27 @@ func (m *DhtMessage_Handshake) GetExtra() []byte {
 	return nil
 }
 
+func (m *DhtMessage_Handshake) GetSecKey() []byte {
+	if m != nil {
+		return m.SecKey
+	}
+	return nil
+}
+
+func (m *DhtMessage_Handshake) GetSecSign() []byte {
+	if m != nil {
+		return m.SecSign
+	}
+	return nil
+}
+
+func (m *DhtMessage_Handshake) GetAddrs() []byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
 type DhtMessage_FindNode struct {
 	From                 *DhtMessage_Node `protobuf:"bytes,1,req,name=From" json:"From,omitempty"`
 	To                   *DhtMessage_Node `protobuf:"bytes,2,req,name=To" json:"To,omitempty"`
@@ -1671,6 +1703,7 @@ type DhtProviderRecord_Node struct {
 	NodeId               []byte   `protobuf:"bytes,4,req,name=NodeId" json:"NodeId,omitempty"`
 	Extra                []byte   `protobuf:"bytes,5,opt,name=Extra" json:"Extra,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	Addrs                []byte   `protobuf:"bytes,6,opt,name=Addrs" json:"Addrs,omitempty"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -1743,6 +1776,13 @@ func (m *DhtProviderRecord_Node) GetExtra() []byte {
 	return nil
 }
 
+func (m *DhtProviderRecord_Node) GetAddrs() []byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
 type DhtProviderRecord_Provider struct {
 	Node                 *DhtProviderRecord_Node `protobuf:"bytes,1,req,name=Node" json:"Node,omitempty"`
 	Extra                []byte                  `protobuf:"bytes,2,opt,name=Extra" json:"Extra,omitempty"`
@@ -2055,6 +2095,12 @@ func (m *DhtMessage_Node) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintDhtmsg(dAtA, i, uint64(*m.ConnType))
 	}
+	if m.Addrs != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintDhtmsg(dAtA, i, uint64(len(m.Addrs)))
+		i += copy(dAtA[i:], m.Addrs)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -2278,6 +2324,24 @@ func (m *DhtMessage_Handshake) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintDhtmsg(dAtA, i, uint64(len(m.Extra)))
 		i += copy(dAtA[i:], m.Extra)
 	}
+	if m.SecKey != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintDhtmsg(dAtA, i, uint64(len(m.SecKey)))
+		i += copy(dAtA[i:], m.SecKey)
+	}
+	if m.SecSign != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintDhtmsg(dAtA, i, uint64(len(m.SecSign)))
+		i += copy(dAtA[i:], m.SecSign)
+	}
+	if m.Addrs != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintDhtmsg(dAtA, i, uint64(len(m.Addrs)))
+		i += copy(dAtA[i:], m.Addrs)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -3117,6 +3181,12 @@ func (m *DhtProviderRecord_Node) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintDhtmsg(dAtA, i, uint64(len(m.Extra)))
 		i += copy(dAtA[i:], m.Extra)
 	}
+	if m.Addrs != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintDhtmsg(dAtA, i, uint64(len(m.Addrs)))
+		i += copy(dAtA[i:], m.Addrs)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -3275,6 +3345,10 @@ func (m *DhtMessage_Node) Size() (n int) {
 	if m.ConnType != nil {
 		n += 1 + sovDhtmsg(uint64(*m.ConnType))
 	}
+	if m.Addrs != nil {
+		l = len(m.Addrs)
+		n += 1 + l + sovDhtmsg(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3392,6 +3466,18 @@ func (m *DhtMessage_Handshake) Size() (n int) {
 		l = len(m.Extra)
 		n += 1 + l + sovDhtmsg(uint64(l))
 	}
+	if m.SecKey != nil {
+		l = len(m.SecKey)
+		n += 1 + l + sovDhtmsg(uint64(l))
+	}
+	if m.SecSign != nil {
+		l = len(m.SecSign)
+		n += 1 + l + sovDhtmsg(uint64(l))
+	}
+	if m.Addrs != nil {
+		l = len(m.Addrs)
+		n += 1 + l + sovDhtmsg(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3792,6 +3878,10 @@ func (m *DhtProviderRecord_Node) Size() (n int) {
 		l = len(m.Extra)
 		n += 1 + l + sovDhtmsg(uint64(l))
 	}
+	if m.Addrs != nil {
+		l = len(m.Addrs)
+		n += 1 + l + sovDhtmsg(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -4558,6 +4648,37 @@ func (m *DhtMessage_Node) Unmarshal(dAtA []byte) error {
 			}
 			m.ConnType = &v
 			hasFields[0] |= uint64(0x00000010)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDhtmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDhtmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addrs == nil {
+				m.Addrs = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDhtmsg(dAtA[iNdEx:])
@@ -5290,6 +5411,99 @@ func (m *DhtMessage_Handshake) Unmarshal(dAtA []byte) error {
 				m.Extra = []byte{}
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDhtmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDhtmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecKey = append(m.SecKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.SecKey == nil {
+				m.SecKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecSign", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDhtmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDhtmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecSign = append(m.SecSign[:0], dAtA[iNdEx:postIndex]...)
+			if m.SecSign == nil {
+				m.SecSign = []byte{}
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDhtmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDhtmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addrs == nil {
+				m.Addrs = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDhtmsg(dAtA[iNdEx:])
@@ -7988,6 +8202,37 @@ func (m *DhtProviderRecord_Node) Unmarshal(dAtA []byte) error {
 				m.Extra = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDhtmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDhtmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addrs == nil {
+				m.Addrs = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDhtmsg(dAtA[iNdEx:])
@@ -8250,78 +8495,80 @@ var (
 func init() { proto.RegisterFile("dhtmsg.proto", fileDescriptor_dhtmsg_b415edf7a3c082b7) }
 
 var fileDescriptor_dhtmsg_b415edf7a3c082b7 = []byte{
-	// 1158 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xcd, 0x6f, 0x1b, 0x45,
-	0x14, 0xef, 0x7e, 0xf9, 0xe3, 0xd9, 0x49, 0xb7, 0xd3, 0x02, 0x23, 0x83, 0x82, 0x6b, 0x15, 0x35,
-	0xea, 0x21, 0x82, 0x20, 0x2e, 0x05, 0x24, 0x5c, 0xef, 0x26, 0x59, 0x9a, 0xac, 0x97, 0xb1, 0x13,
-	0xe8, 0x29, 0xda, 0x66, 0x87, 0xb5, 0x95, 0x64, 0xd7, 0xf1, 0x3a, 0x15, 0x39, 0x73, 0xe3, 0x88,
-	0x38, 0x70, 0x45, 0xea, 0x1f, 0xc1, 0x0d, 0x21, 0x2e, 0x48, 0x5c, 0xf8, 0x13, 0x50, 0x90, 0xf8,
-	0x0b, 0xb8, 0x83, 0x66, 0x76, 0xf6, 0xc3, 0xb1, 0xbd, 0x32, 0x42, 0x96, 0x7a, 0xf2, 0xbc, 0xe7,
-	0xdf, 0xfb, 0x7e, 0xf3, 0xe6, 0x2d, 0xd4, 0xbd, 0xc1, 0xe4, 0x3c, 0xf2, 0xb7, 0x46, 0xe3, 0x70,
-	0x12, 0xa2, 0x6a, 0x42, 0x3d, 0x6f, 0x5d, 0x02, 0x18, 0x83, 0x89, 0xe3, 0x9e, 0x9c, 0xba, 0x3e,
-	0x45, 0x0f, 0x41, 0x71, 0x86, 0x1e, 0x96, 0x9a, 0xf2, 0xe6, 0xfa, 0xf6, 0x6b, 0x5b, 0x29, 0x6c,
-	0xcb, 0x61, 0x72, 0x27, 0xe1, 0x99, 0xe5, 0x11, 0x86, 0x40, 0x0f, 0x60, 0xcd, 0x71, 0xaf, 0xce,
-	0x42, 0xd7, 0xdb, 0xa7, 0x81, 0x3f, 0x19, 0x60, 0xb9, 0x29, 0x6f, 0xae, 0x91, 0x69, 0x26, 0xc2,
-	0x50, 0x16, 0x0c, 0xac, 0x34, 0xa5, 0xcd, 0x3a, 0x49, 0xc8, 0xd6, 0x8f, 0x6f, 0x71, 0xbb, 0x07,
-	0x34, 0x8a, 0x98, 0xdd, 0x0f, 0xa1, 0x7c, 0x1e, 0xf9, 0xfd, 0xab, 0x11, 0x15, 0xb6, 0xef, 0xe7,
-	0x6c, 0x67, 0xb8, 0x2d, 0xf1, 0xcb, 0x80, 0x24, 0x91, 0x40, 0x1f, 0x43, 0x75, 0xe0, 0x06, 0x5e,
-	0x34, 0x70, 0x4f, 0x29, 0x96, 0x9b, 0xd2, 0x66, 0x6d, 0xfb, 0xed, 0xf9, 0xe2, 0x7b, 0x09, 0x8c,
-	0x64, 0x12, 0xe8, 0x31, 0x54, 0xbe, 0x1c, 0x06, 0x9e, 0x1d, 0x7a, 0x94, 0x7b, 0x59, 0xdb, 0xde,
-	0x98, 0x2f, 0xbd, 0x23, 0x50, 0x24, 0xc5, 0x33, 0xd3, 0x01, 0x1d, 0xfa, 0x83, 0xe7, 0xe1, 0x38,
-	0xc2, 0x6a, 0x91, 0x69, 0x3b, 0x81, 0x91, 0x4c, 0x82, 0x99, 0x1e, 0x5d, 0x4e, 0x8e, 0xdc, 0xb3,
-	0x4b, 0x8a, 0xb5, 0x22, 0xd3, 0x8e, 0x40, 0x91, 0x14, 0x8f, 0x3a, 0x50, 0xf3, 0xa9, 0xe0, 0xd2,
-	0x0b, 0x5c, 0xe2, 0xe2, 0x0b, 0xd2, 0xb6, 0x9b, 0x01, 0x49, 0x5e, 0x6a, 0x4a, 0x49, 0x34, 0xc2,
-	0xe5, 0xa5, 0x94, 0x44, 0x23, 0x92, 0x97, 0x62, 0x4a, 0x46, 0x97, 0x13, 0x67, 0x1c, 0xbe, 0x18,
-	0x7a, 0x74, 0x8c, 0x2b, 0x45, 0x4a, 0x9c, 0x0c, 0x48, 0xf2, 0x52, 0x68, 0x1f, 0xd6, 0x7d, 0x9a,
-	0xfd, 0x47, 0x2f, 0x70, 0x95, 0xeb, 0x79, 0xb0, 0xd0, 0x99, 0x1c, 0x96, 0xdc, 0x90, 0xbd, 0xa9,
-	0x2d, 0x1a, 0x61, 0x58, 0x56, 0x5b, 0x34, 0x22, 0x37, 0x64, 0xd1, 0x16, 0xa8, 0xa3, 0x61, 0xe0,
-	0xe3, 0x1a, 0xd7, 0xd1, 0x58, 0x10, 0xd9, 0x30, 0xf0, 0x09, 0xc7, 0x71, 0x7c, 0x18, 0xf8, 0xb8,
-	0x5e, 0x88, 0x0f, 0x39, 0x3e, 0x0c, 0xfc, 0xc6, 0x77, 0x12, 0xa8, 0xbc, 0x9d, 0xd6, 0x41, 0xb6,
-	0x1c, 0x7e, 0x03, 0xea, 0x44, 0xb6, 0x1c, 0xa4, 0x83, 0x72, 0x68, 0x38, 0xe2, 0x6e, 0xb1, 0x23,
-	0xe3, 0xf4, 0x3b, 0x0e, 0x56, 0x62, 0x4e, 0xbf, 0xe3, 0xa0, 0xd7, 0xa1, 0xc4, 0x64, 0x2d, 0x0f,
-	0xab, 0x5c, 0x4e, 0x50, 0xe8, 0x13, 0xa8, 0x74, 0xc2, 0x20, 0xe0, 0x77, 0x4a, 0xe3, 0x77, 0x6a,
-	0x41, 0xf0, 0x0c, 0x45, 0x4f, 0x26, 0xc3, 0x90, 0x63, 0x49, 0x2a, 0xd5, 0x68, 0x83, 0x16, 0xb7,
-	0x9a, 0x0e, 0xca, 0x53, 0x7a, 0x25, 0xfc, 0x62, 0x47, 0xc6, 0x39, 0x72, 0xcf, 0xb8, 0x63, 0x75,
-	0xc2, 0x8e, 0xe8, 0x1e, 0x68, 0xe6, 0x57, 0x93, 0xb1, 0x2b, 0x2e, 0x7a, 0x4c, 0x34, 0x3c, 0xa8,
-	0xa4, 0x15, 0x9e, 0xd5, 0xf2, 0x2e, 0x68, 0xcc, 0xd9, 0x08, 0xcb, 0x4d, 0x65, 0x71, 0xa2, 0xf8,
-	0x95, 0x8b, 0x81, 0x0b, 0xac, 0x98, 0xdc, 0x0a, 0x9f, 0x4f, 0xcb, 0x4f, 0x30, 0x16, 0x02, 0x1d,
-	0xa7, 0x21, 0xd0, 0x71, 0xe3, 0x6b, 0x19, 0xaa, 0xe9, 0x84, 0x60, 0xb3, 0xab, 0x33, 0x70, 0x87,
-	0x81, 0x15, 0x2b, 0x5b, 0x23, 0x09, 0xc9, 0x24, 0x8d, 0x61, 0x2c, 0xa9, 0x11, 0x76, 0xcc, 0xd5,
-	0x40, 0x99, 0xaa, 0x41, 0x5c, 0x4f, 0xf5, 0x66, 0x3d, 0xb5, 0x99, 0x7a, 0x96, 0xb2, 0x7a, 0x36,
-	0x44, 0x30, 0xf6, 0xe5, 0x39, 0x2e, 0x73, 0x76, 0x4a, 0xa3, 0x8f, 0xa0, 0x9a, 0x84, 0x11, 0xe1,
-	0x0a, 0x4f, 0xda, 0xa2, 0x81, 0x21, 0x60, 0x24, 0x13, 0xe0, 0xde, 0x78, 0xb8, 0xda, 0x94, 0x37,
-	0x55, 0x22, 0x5b, 0x5e, 0x96, 0x4c, 0xc8, 0x27, 0xf3, 0xa5, 0x04, 0x95, 0x64, 0xd2, 0xb1, 0x4e,
-	0xde, 0x19, 0x87, 0xe7, 0x3c, 0x03, 0xc5, 0x05, 0xe2, 0x38, 0xf4, 0x08, 0xe4, 0x7e, 0xc8, 0x33,
-	0x53, 0x8c, 0x96, 0xfb, 0x21, 0x4b, 0x5a, 0xdf, 0x1d, 0xfb, 0x74, 0x92, 0x24, 0x2d, 0xa6, 0x84,
-	0x9b, 0xea, 0xac, 0x9b, 0x5a, 0xde, 0xcd, 0x9f, 0x24, 0xa8, 0xa6, 0x33, 0x75, 0xa5, 0x7e, 0xa6,
-	0x5d, 0xaa, 0x2c, 0xdb, 0xa5, 0xcb, 0x45, 0xf0, 0xb3, 0x04, 0x95, 0x64, 0xae, 0xaf, 0x34, 0x80,
-	0xf7, 0xa1, 0xc4, 0x8d, 0x24, 0x11, 0xbc, 0x39, 0x1f, 0x1f, 0xcf, 0x73, 0x01, 0x5d, 0x32, 0x86,
-	0x1f, 0x24, 0xa8, 0xe5, 0x1e, 0x97, 0x95, 0x86, 0x21, 0xe6, 0x87, 0x92, 0xcd, 0x8f, 0xe5, 0x7c,
-	0xfc, 0x3b, 0xef, 0x63, 0x3c, 0xcd, 0x57, 0xe6, 0xe3, 0x7b, 0x62, 0x64, 0x8a, 0x45, 0xa2, 0x30,
-	0xd3, 0x62, 0xb8, 0xa6, 0xed, 0xa5, 0x16, 0xbd, 0x16, 0xb3, 0xed, 0xa5, 0xcd, 0x86, 0x5d, 0xca,
-	0x87, 0xfd, 0x9b, 0x04, 0xb5, 0xdc, 0x6b, 0xbb, 0xd2, 0xb0, 0x1f, 0x67, 0x63, 0x9e, 0xd7, 0xa7,
-	0x68, 0x2c, 0xc5, 0xaf, 0x6a, 0xf6, 0x2c, 0x2c, 0x57, 0xc4, 0x97, 0x12, 0xac, 0x4f, 0xbf, 0xf9,
-	0xaf, 0x64, 0xaf, 0x7d, 0x2b, 0x4f, 0xbb, 0xb9, 0xe2, 0x76, 0xfb, 0x3f, 0x79, 0x17, 0x21, 0xaa,
-	0x73, 0x9e, 0x63, 0xed, 0xbf, 0x0d, 0xba, 0xd2, 0x6c, 0x52, 0xca, 0xf9, 0xa4, 0x7c, 0x23, 0x81,
-	0xea, 0x88, 0xbd, 0x68, 0x95, 0x15, 0xeb, 0xd1, 0x0b, 0x9e, 0x05, 0x95, 0xb0, 0x63, 0xe6, 0x8c,
-	0x3a, 0xe3, 0x4c, 0xf8, 0x6a, 0x38, 0xd3, 0xfa, 0x4b, 0x82, 0x5a, 0xee, 0x93, 0x06, 0xdd, 0x81,
-	0xb5, 0x03, 0xcb, 0x38, 0xde, 0x6b, 0xdb, 0x46, 0x6f, 0xaf, 0xfd, 0xd4, 0xd4, 0x6f, 0x21, 0x1d,
-	0xea, 0x8c, 0xb5, 0x63, 0xd9, 0x86, 0xdd, 0x35, 0x4c, 0x5d, 0x4a, 0x40, 0xb6, 0x69, 0xed, 0xee,
-	0x3d, 0xe9, 0x92, 0x9e, 0x2e, 0x27, 0x20, 0xe7, 0xb0, 0x7f, 0xd4, 0xde, 0x3f, 0x34, 0x75, 0x05,
-	0xdd, 0x03, 0x9d, 0x71, 0x76, 0xcd, 0x98, 0x73, 0x4c, 0xcc, 0xcf, 0x74, 0x75, 0x96, 0xdb, 0x73,
-	0x74, 0x0d, 0xdd, 0x85, 0xdb, 0x42, 0xda, 0x21, 0xdd, 0x23, 0xcb, 0x30, 0x89, 0x5e, 0x42, 0x6f,
-	0xc0, 0x5d, 0x01, 0x4d, 0x98, 0x5c, 0x47, 0x79, 0xee, 0x1f, 0x3d, 0x47, 0xaf, 0xa0, 0x3a, 0x54,
-	0xb8, 0x1a, 0xcb, 0xde, 0xd5, 0xab, 0x29, 0xd5, 0xb5, 0x77, 0x75, 0x68, 0x7d, 0x0a, 0xeb, 0xd3,
-	0x6b, 0x26, 0xaa, 0x41, 0xb9, 0xd3, 0xb5, 0xfb, 0xc7, 0x76, 0x57, 0xbf, 0xc5, 0xc0, 0x9c, 0x78,
-	0x66, 0xf6, 0x74, 0x29, 0xa5, 0x0e, 0xda, 0xcf, 0x74, 0x19, 0xdd, 0x86, 0x1a, 0xa7, 0x76, 0xda,
-	0xd6, 0xbe, 0x69, 0xe8, 0x4a, 0xcb, 0x82, 0xaa, 0x31, 0x98, 0x10, 0x7a, 0x12, 0x8e, 0xf9, 0x2e,
-	0x76, 0x9a, 0x2d, 0x95, 0xa7, 0xf4, 0x8a, 0x65, 0xfa, 0x05, 0x1f, 0xc1, 0xf1, 0x66, 0x17, 0x13,
-	0xf3, 0x17, 0xc7, 0xd6, 0x2f, 0x32, 0xdc, 0x61, 0x5f, 0xbf, 0xe9, 0x54, 0x59, 0xa0, 0xb3, 0x03,
-	0xd5, 0x91, 0xc0, 0x24, 0xcb, 0xea, 0x3b, 0xd3, 0x2d, 0x30, 0xad, 0x22, 0xbb, 0x6f, 0x99, 0xdc,
-	0x82, 0xdd, 0x75, 0xb0, 0x82, 0xd5, 0x7f, 0xfe, 0x6c, 0xfa, 0x3c, 0xb7, 0x8b, 0x7f, 0x10, 0x5b,
-	0x15, 0xcd, 0x7f, 0xbf, 0x30, 0x96, 0xf8, 0x0e, 0x70, 0x27, 0x53, 0xc5, 0x72, 0x4e, 0xf1, 0xa3,
-	0x87, 0x00, 0xd9, 0x72, 0xcd, 0x0a, 0xeb, 0x58, 0xc6, 0xb1, 0xb1, 0xd7, 0xe7, 0x85, 0xe5, 0x84,
-	0xf9, 0x45, 0x5f, 0xff, 0x47, 0x7a, 0xa2, 0xff, 0x7a, 0xbd, 0x21, 0xfd, 0x7e, 0xbd, 0x21, 0xfd,
-	0x71, 0xbd, 0x21, 0x7d, 0xff, 0xe7, 0xc6, 0xad, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x13, 0x50,
-	0x6c, 0xcf, 0x97, 0x10, 0x00, 0x00,
+	// 1196 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0x4b, 0x6f, 0xe3, 0xd4,
+	0x17, 0xaf, 0x5f, 0x79, 0x9c, 0xa4, 0x1d, 0xcf, 0x9d, 0xf9, 0xff, 0xb1, 0x82, 0x28, 0x99, 0x68,
+	0xd0, 0x54, 0xb3, 0xa8, 0xa0, 0x88, 0xcd, 0x00, 0x12, 0x99, 0xd8, 0x6d, 0xcd, 0xb4, 0x8e, 0xb9,
+	0x49, 0x0b, 0xb3, 0xaa, 0x3c, 0xf1, 0xc5, 0x89, 0xda, 0xda, 0xae, 0x9d, 0x8e, 0xe8, 0x86, 0x0f,
+	0xc0, 0x92, 0x05, 0x62, 0x8b, 0x34, 0x2b, 0xbe, 0x04, 0x62, 0x87, 0x34, 0x1b, 0xb6, 0xec, 0x50,
+	0x91, 0xf8, 0x04, 0xec, 0x41, 0xf7, 0xfa, 0xfa, 0x91, 0xe6, 0x41, 0x58, 0x44, 0xea, 0xa6, 0xbd,
+	0xe7, 0xe4, 0x77, 0xde, 0xe7, 0x9e, 0x7b, 0x0c, 0x75, 0x77, 0x38, 0x3e, 0x8f, 0xbd, 0xed, 0x30,
+	0x0a, 0xc6, 0x01, 0xaa, 0xa6, 0xd4, 0x8b, 0xd6, 0x25, 0x80, 0x3e, 0x1c, 0xdb, 0xce, 0xe0, 0xd4,
+	0xf1, 0x08, 0x7a, 0x04, 0x92, 0x3d, 0x72, 0x35, 0xa1, 0x29, 0x6e, 0x6d, 0xec, 0xfc, 0x6f, 0x3b,
+	0x83, 0x6d, 0xdb, 0x54, 0x6e, 0x10, 0x9c, 0x99, 0x2e, 0xa6, 0x08, 0xf4, 0x10, 0xd6, 0x6d, 0xe7,
+	0xea, 0x2c, 0x70, 0xdc, 0x03, 0xe2, 0x7b, 0xe3, 0xa1, 0x26, 0x36, 0xc5, 0xad, 0x75, 0x3c, 0xc9,
+	0x44, 0x1a, 0x94, 0x39, 0x43, 0x93, 0x9a, 0xc2, 0x56, 0x1d, 0xa7, 0x64, 0xeb, 0xbb, 0xb7, 0x98,
+	0xdd, 0x43, 0x12, 0xc7, 0xd4, 0xee, 0x87, 0x50, 0x3e, 0x8f, 0xbd, 0xfe, 0x55, 0x48, 0xb8, 0xed,
+	0x07, 0x05, 0xdb, 0x39, 0x6e, 0x9b, 0xff, 0xa7, 0x40, 0x9c, 0x4a, 0xa0, 0x8f, 0xa1, 0x3a, 0x74,
+	0x7c, 0x37, 0x1e, 0x3a, 0xa7, 0x44, 0x13, 0x9b, 0xc2, 0x56, 0x6d, 0xe7, 0xed, 0xd9, 0xe2, 0xfb,
+	0x29, 0x0c, 0xe7, 0x12, 0xe8, 0x09, 0x54, 0xbe, 0x1c, 0xf9, 0xae, 0x15, 0xb8, 0x84, 0x79, 0x59,
+	0xdb, 0xd9, 0x9c, 0x2d, 0xbd, 0xcb, 0x51, 0x38, 0xc3, 0x53, 0xd3, 0x3e, 0x19, 0x79, 0xc3, 0x17,
+	0x41, 0x14, 0x6b, 0xf2, 0x22, 0xd3, 0x56, 0x0a, 0xc3, 0xb9, 0x04, 0x35, 0x1d, 0x5e, 0x8e, 0x8f,
+	0x9d, 0xb3, 0x4b, 0xa2, 0x29, 0x8b, 0x4c, 0xdb, 0x1c, 0x85, 0x33, 0x3c, 0xea, 0x40, 0xcd, 0x23,
+	0x9c, 0x4b, 0x2e, 0xb4, 0x12, 0x13, 0x9f, 0x93, 0xb6, 0xbd, 0x1c, 0x88, 0x8b, 0x52, 0x13, 0x4a,
+	0xe2, 0x50, 0x2b, 0x2f, 0xa5, 0x24, 0x0e, 0x71, 0x51, 0x8a, 0x2a, 0x09, 0x2f, 0xc7, 0x76, 0x14,
+	0xbc, 0x1c, 0xb9, 0x24, 0xd2, 0x2a, 0x8b, 0x94, 0xd8, 0x39, 0x10, 0x17, 0xa5, 0xd0, 0x01, 0x6c,
+	0x78, 0x24, 0xff, 0x8d, 0x5c, 0x68, 0x55, 0xa6, 0xe7, 0xe1, 0x5c, 0x67, 0x0a, 0x58, 0x7c, 0x43,
+	0xf6, 0xa6, 0xb6, 0x38, 0xd4, 0x60, 0x59, 0x6d, 0x71, 0x88, 0x6f, 0xc8, 0xa2, 0x6d, 0x90, 0xc3,
+	0x91, 0xef, 0x69, 0x35, 0xa6, 0xa3, 0x31, 0x27, 0xb2, 0x91, 0xef, 0x61, 0x86, 0x63, 0xf8, 0xc0,
+	0xf7, 0xb4, 0xfa, 0x42, 0x7c, 0xc0, 0xf0, 0x81, 0xef, 0x35, 0x7e, 0x14, 0x40, 0x66, 0xed, 0xb4,
+	0x01, 0xa2, 0x69, 0xb3, 0x1b, 0x50, 0xc7, 0xa2, 0x69, 0x23, 0x15, 0xa4, 0x23, 0xdd, 0xe6, 0x77,
+	0x8b, 0x1e, 0x29, 0xa7, 0xdf, 0xb1, 0x35, 0x29, 0xe1, 0xf4, 0x3b, 0x36, 0xfa, 0x3f, 0x94, 0xa8,
+	0xac, 0xe9, 0x6a, 0x32, 0x93, 0xe3, 0x14, 0xfa, 0x04, 0x2a, 0x9d, 0xc0, 0xf7, 0xd9, 0x9d, 0x52,
+	0xd8, 0x9d, 0x9a, 0x13, 0x3c, 0x45, 0x91, 0xc1, 0x78, 0x14, 0x30, 0x2c, 0xce, 0xa4, 0xd0, 0x7d,
+	0x50, 0xda, 0xae, 0x1b, 0xc5, 0xac, 0xb7, 0xea, 0x38, 0x21, 0x1a, 0x6d, 0x50, 0x92, 0x06, 0x54,
+	0x41, 0x7a, 0x46, 0xae, 0xb8, 0xb7, 0xf4, 0x48, 0x39, 0xc7, 0xce, 0x19, 0x73, 0xb7, 0x8e, 0xe9,
+	0x91, 0xaa, 0x30, 0xbe, 0x1a, 0x47, 0x0e, 0xbf, 0xfe, 0x09, 0xd1, 0x70, 0xa1, 0x92, 0xd5, 0x7d,
+	0x5a, 0xcb, 0xbb, 0xa0, 0xd0, 0x10, 0x62, 0x4d, 0x6c, 0x4a, 0xf3, 0xd3, 0xc7, 0x2e, 0x62, 0x02,
+	0x9c, 0x63, 0xc5, 0x80, 0x4a, 0x3a, 0xb5, 0x96, 0x9f, 0x6b, 0x34, 0x04, 0x12, 0x65, 0x21, 0x90,
+	0xa8, 0xf1, 0x5a, 0x84, 0x6a, 0x36, 0x37, 0xe8, 0x44, 0xeb, 0x0c, 0x9d, 0x91, 0x6f, 0x26, 0xca,
+	0xd6, 0x71, 0x4a, 0x52, 0x49, 0x7d, 0x94, 0x48, 0x2a, 0x98, 0x1e, 0x0b, 0x95, 0x91, 0x26, 0x2a,
+	0x93, 0x54, 0x59, 0xbe, 0x59, 0x65, 0x65, 0xaa, 0xca, 0xa5, 0xbc, 0xca, 0x0d, 0x1e, 0x8c, 0x75,
+	0x79, 0xae, 0x95, 0x19, 0x3b, 0xa3, 0xd1, 0x47, 0x50, 0x4d, 0xc3, 0x88, 0xb5, 0x4a, 0x53, 0x5a,
+	0x30, 0x46, 0x38, 0x0c, 0xe7, 0x02, 0xcc, 0x1b, 0x57, 0xab, 0x36, 0xc5, 0x2d, 0x19, 0x8b, 0xa6,
+	0x9b, 0x27, 0x13, 0x0a, 0xc9, 0xa4, 0xb1, 0xf4, 0xc8, 0x80, 0x56, 0xaa, 0xc6, 0xd8, 0x9c, 0xa2,
+	0xf9, 0xe8, 0x91, 0x41, 0x6f, 0xe4, 0xf9, 0xac, 0xdb, 0xeb, 0x38, 0x25, 0xf3, 0xee, 0x59, 0x2f,
+	0x76, 0xcf, 0x2b, 0x01, 0x2a, 0xe9, 0x1c, 0xa5, 0xf7, 0x64, 0x37, 0x0a, 0xce, 0x59, 0x26, 0x17,
+	0x17, 0x9a, 0xe1, 0xd0, 0x63, 0x10, 0xfb, 0x81, 0x26, 0xfe, 0x2b, 0x5a, 0xec, 0x07, 0xd4, 0xe1,
+	0xbe, 0x13, 0x79, 0x64, 0x9c, 0x26, 0x3f, 0xa1, 0x78, 0xb8, 0xf2, 0x74, 0xb8, 0x4a, 0xb1, 0x77,
+	0x7e, 0x12, 0xa0, 0x9a, 0x4d, 0xec, 0x95, 0xfa, 0x99, 0x75, 0xbb, 0xb4, 0x6c, 0xb7, 0x2f, 0x17,
+	0xc1, 0xcf, 0x02, 0x54, 0xd2, 0x57, 0x63, 0xa5, 0x01, 0xbc, 0x0f, 0x25, 0x66, 0x24, 0x8d, 0xe0,
+	0xcd, 0xd9, 0x78, 0x86, 0xc1, 0x1c, 0xba, 0x64, 0x0c, 0x3f, 0x08, 0x50, 0x2b, 0x3c, 0x5d, 0x2b,
+	0x0d, 0x83, 0xcf, 0x21, 0x29, 0x9f, 0x43, 0xcb, 0xf9, 0xf8, 0x57, 0xd1, 0xc7, 0xe4, 0xad, 0x58,
+	0x99, 0x8f, 0xef, 0xf1, 0xd1, 0xcb, 0xd7, 0x94, 0x85, 0x99, 0x4e, 0x90, 0x79, 0x7b, 0xc9, 0x8b,
+	0xde, 0xa2, 0xe9, 0xf6, 0x52, 0xa6, 0xc3, 0x2e, 0x15, 0xc3, 0x7e, 0x2d, 0x40, 0xad, 0xf0, 0x96,
+	0xaf, 0x34, 0xec, 0x27, 0xf9, 0x73, 0xc1, 0xea, 0xb3, 0x68, 0xbc, 0x31, 0x14, 0xce, 0xf0, 0x4b,
+	0x16, 0xf1, 0x95, 0x00, 0x1b, 0x93, 0x1b, 0xc5, 0xad, 0xec, 0xb5, 0x6f, 0xc5, 0x49, 0x37, 0xe3,
+	0xf0, 0xd6, 0xe6, 0x9d, 0x87, 0x28, 0xcf, 0x78, 0xd6, 0x95, 0xff, 0x36, 0xe8, 0x4a, 0xd3, 0x49,
+	0x29, 0x17, 0x93, 0xf2, 0x8d, 0x00, 0xb2, 0xcd, 0xb7, 0xae, 0x55, 0x56, 0xac, 0x47, 0x2e, 0x58,
+	0x16, 0x64, 0x4c, 0x8f, 0xb9, 0x33, 0xf2, 0x94, 0x33, 0xc1, 0xed, 0x70, 0xa6, 0xf5, 0xa7, 0x00,
+	0xb5, 0xc2, 0x07, 0x13, 0xba, 0x0b, 0xeb, 0x87, 0xa6, 0x7e, 0xb2, 0xdf, 0xb6, 0xf4, 0xde, 0x7e,
+	0xfb, 0x99, 0xa1, 0xae, 0x21, 0x15, 0xea, 0x94, 0xb5, 0x6b, 0x5a, 0xba, 0xd5, 0xd5, 0x0d, 0x55,
+	0x48, 0x41, 0x96, 0x61, 0xee, 0xed, 0x3f, 0xed, 0xe2, 0x9e, 0x2a, 0xa6, 0x20, 0xfb, 0xa8, 0x7f,
+	0xdc, 0x3e, 0x38, 0x32, 0x54, 0x09, 0xdd, 0x07, 0x95, 0x72, 0xf6, 0x8c, 0x84, 0x73, 0x82, 0x8d,
+	0xcf, 0x54, 0x79, 0x9a, 0xdb, 0xb3, 0x55, 0x05, 0xdd, 0x83, 0x3b, 0x5c, 0xda, 0xc6, 0xdd, 0x63,
+	0x53, 0x37, 0xb0, 0x5a, 0x42, 0x6f, 0xc0, 0x3d, 0x0e, 0x4d, 0x99, 0x4c, 0x47, 0x79, 0xe6, 0x0f,
+	0x3d, 0x5b, 0xad, 0xa0, 0x3a, 0x54, 0x98, 0x1a, 0xd3, 0xda, 0x53, 0xab, 0x19, 0xd5, 0xb5, 0xf6,
+	0x54, 0x68, 0x7d, 0x0a, 0x1b, 0x93, 0x4b, 0x2c, 0xaa, 0x41, 0xb9, 0xd3, 0xb5, 0xfa, 0x27, 0x56,
+	0x57, 0x5d, 0xa3, 0x60, 0x46, 0x3c, 0x37, 0x7a, 0xaa, 0x90, 0x51, 0x87, 0xed, 0xe7, 0xaa, 0x88,
+	0xee, 0x40, 0x8d, 0x51, 0xbb, 0x6d, 0xf3, 0xc0, 0xd0, 0x55, 0xa9, 0x65, 0x42, 0x55, 0x1f, 0x8e,
+	0x31, 0x19, 0x04, 0x11, 0xdb, 0xe9, 0x4e, 0xf3, 0xe5, 0xf4, 0x94, 0x5c, 0xd1, 0x4c, 0xbf, 0x64,
+	0x23, 0x38, 0xd9, 0x10, 0x13, 0x62, 0xf6, 0x02, 0xda, 0xfa, 0x4d, 0x84, 0xbb, 0xf4, 0xdb, 0x3a,
+	0x9b, 0x2a, 0x73, 0x74, 0x76, 0xa0, 0x1a, 0x72, 0x4c, 0xba, 0xf4, 0xbe, 0x33, 0xd9, 0x02, 0x93,
+	0x2a, 0xf2, 0xfb, 0x96, 0xcb, 0xcd, 0xd9, 0x81, 0xbf, 0x5e, 0xc1, 0x87, 0xc5, 0xcc, 0xd9, 0x34,
+	0xe7, 0x63, 0xe1, 0xf3, 0xc2, 0xa6, 0xff, 0x41, 0xe2, 0x0b, 0xbf, 0x12, 0x0f, 0x16, 0x46, 0x98,
+	0xdc, 0x0c, 0xfa, 0x37, 0x37, 0x27, 0x16, 0xcc, 0x3d, 0x7e, 0x04, 0x90, 0xaf, 0xee, 0xb4, 0xdc,
+	0xb6, 0xa9, 0x9f, 0xe8, 0xfb, 0x7d, 0x56, 0x6e, 0x46, 0x18, 0x5f, 0xf4, 0xd5, 0xbf, 0x85, 0xa7,
+	0xea, 0x2f, 0xd7, 0x9b, 0xc2, 0xaf, 0xd7, 0x9b, 0xc2, 0xef, 0xd7, 0x9b, 0xc2, 0xf7, 0x7f, 0x6c,
+	0xae, 0xfd, 0x33, 0x00, 0x46, 0xc2, 0x4d, 0x49, 0x0b, 0x11, 0x00, 0x00,
 }
@@ -120,14 +120,17 @@ type DhtMessage struct {
 
 type Handshake struct {
 	ChainId   uint32		// chain identity
-	Dir       int           // direct
-	NodeId    config.NodeID // node identity
-	IP        net.IP        // ip address
-	UDP       uint32        // udp port number
-	TCP       uint32        // tcp port number
-	ProtoNum  uint32        // number of protocols supported
-	Protocols []DhtProtocol // version of protocol
-	Extra     []byte        // extra info
+	Dir       int               // direct
+	NodeId    config.NodeID     // node identity
+	IP        net.IP            // ip address
+	UDP       uint32            // udp port number
+	TCP       uint32            // tcp port number
+	ProtoNum  uint32            // number of protocols supported
+	Protocols []DhtProtocol     // version of protocol
+	SecKey    []byte            // ephemeral key for transport encryption
+	SecSign   []byte            // signature binds ephemeral keys to node identity
+	Addrs     []config.Endpoint // more endpoints of a multi-homed node
+	Extra     []byte            // extra info
 }
 
 type FindNode struct {
@@ -425,6 +428,11 @@ func (dhtMsg *DhtMessage) getNode(n *pb.DhtMessage_Node) *config.Node {
 		UDP: uint16(*n.UDP & 0xffff),
 	}
 	copy(dn.ID[0:], n.NodeId)
+	if addrs, err := config.P2pDecodeEndpoints(n.Addrs); err == nil {
+		dn.Addrs = addrs
+	} else {
+		log.Debugf("getNode: bad endpoint list, err: %s", err.Error())
+	}
 	return &dn
 }
 
@@ -442,6 +450,7 @@ func (dhtMsg *DhtMessage) setNode(n *config.Node, ct pb.DhtMessage_ConnectionTyp
 	pbn.UDP = new(uint32)
 	*pbn.UDP = uint32(n.UDP)
 	pbn.NodeId = n.ID[0:]
+	pbn.Addrs = config.P2pEncodeEndpoints(n.Addrs)
 	pbn.ConnType = new(pb.DhtMessage_ConnectionType)
 	*pbn.ConnType = ct
 	return pbn
@@ -512,6 +521,16 @@ func (dhtMsg *DhtMessage) GetHandshakeMessage(pbMsg *pb.DhtMessage_Handshake) Dh
 		return DhtEnoNotSup
 	}
 
+	hs.SecKey = pbMsg.SecKey
+	hs.SecSign = pbMsg.SecSign
+
+	addrs, err := config.P2pDecodeEndpoints(pbMsg.Addrs)
+	if err != nil {
+		log.Debugf("GetHandshakeMessage: bad endpoint list, err: %s", err.Error())
+		return DhtEnoSerialization
+	}
+	hs.Addrs = addrs
+
 	dhtMsg.reset()
 	dhtMsg.Mid = MID_HANDSHAKE
 	dhtMsg.Handshake = hs
@@ -589,8 +608,9 @@ func (dhtMsg *DhtMessage) GetPutValueMessage(pbMsg *pb.DhtMessage_PutValue) DhtE
 
 	for _, v := range pbMsg.Values {
 		val := DhtValue{
-			Key: v.Key,
-			Val: v.Val,
+			Key:   v.Key,
+			Val:   v.Val,
+			Extra: v.Extra,
 		}
 		pv.Values = append(pv.Values, val)
 	}
@@ -652,8 +672,9 @@ func (dhtMsg *DhtMessage) GetGetValueRspMessage(pbMsg *pb.DhtMessage_GetValueRsp
 	if pbMsg.Value != nil {
 		v := pbMsg.Value
 		dhtValue := DhtValue{
-			Key: DhtKey(v.Key),
-			Val: DhtVal(v.Val),
+			Key:   DhtKey(v.Key),
+			Val:   DhtVal(v.Val),
+			Extra: v.Extra,
 		}
 		gvr.Value = &dhtValue
 	}
@@ -853,6 +874,9 @@ func (dhtMsg *DhtMessage) GetHandshakePackage(dhtPkg *DhtPackage) DhtErrno {
 
 	pbHs.Id = new(uint64)
 	*pbHs.Id = uint64(time.Now().UnixNano())
+	pbHs.SecKey = hs.SecKey
+	pbHs.SecSign = hs.SecSign
+	pbHs.Addrs = config.P2pEncodeEndpoints(hs.Addrs)
 	pbHs.Extra = hs.Extra
 
 	pl, err := proto.Marshal(&pbMsg)
@@ -974,6 +998,7 @@ func (dhtMsg *DhtMessage) GetPutValuePackage(dhtPkg *DhtPackage) DhtErrno {
 			Key: v.Key,
 			Val: v.Val,
 		}
+		pbV.Extra, _ = v.Extra.([]byte)
 		pbPv.Values = append(pbPv.Values, pbV)
 	}
 
@@ -1060,6 +1085,7 @@ func (dhtMsg *DhtMessage) GetGetValueRspPackage(dhtPkg *DhtPackage) DhtErrno {
 			Key: gvr.Value.Key,
 			Val: gvr.Value.Val,
 		}
+		pbV.Extra, _ = gvr.Value.Extra.([]byte)
 	}
 	pbGvr.Value = pbV
 
@@ -1375,6 +1401,7 @@ func (dhtPsRec *DhtProviderStoreRecord) EncPsRecord(psr *PsRecord) DhtErrno {
 			TCP:    new(uint32),
 			UDP:    new(uint32),
 			Extra:  nil,
+			Addrs:  config.P2pEncodeEndpoints(prd.Addrs),
 		}
 		*pbprd.Node.TCP = uint32(prd.TCP)
 		*pbprd.Node.UDP = uint32(prd.UDP)
@@ -1422,6 +1449,9 @@ func (dhtPsRec *DhtProviderStoreRecord) DecPsRecord(psr *PsRecord) DhtErrno {
 		n.IP = prd.Node.IP
 		n.UDP = uint16(*prd.Node.UDP & 0xffff)
 		n.TCP = uint16(*prd.Node.TCP & 0xffff)
+		if addrs, err := config.P2pDecodeEndpoints(prd.Node.Addrs); err == nil {
+			n.Addrs = addrs
+		}
 		dhtPsRec.Providers = append(dhtPsRec.Providers, n)
 	}
 
@@ -625,6 +625,8 @@ func (qryInst *QryInst) protoMsgInd(msg *sch.MsgDhtQryInstProtoMsgInd) sch.SchEr
 			return sch.SchEnoMismatched
 		}
 
+		var extra []byte
+
 		if gvr.Value != nil {
 
 			if bytes.Equal(gvr.Value.Key, icb.target[0:]) == false {
@@ -634,6 +636,23 @@ func (qryInst *QryInst) protoMsgInd(msg *sch.MsgDhtQryInstProtoMsgInd) sch.SchEr
 				return sch.SchEnoMismatched
 			}
 
+			//
+			// value rejected by validator is taken as nothing got from this
+			// peer, and the peer is reported to route manager.
+			//
+
+			extra, _ = gvr.Value.Extra.([]byte)
+			if err := ValidateRecord(gvr.Value.Key, gvr.Value.Val, extra); err != nil {
+				log.Warnf("protoMsgInd: value rejected, " +
+					"sdl: %s, inst: %s, key: %x, error: %s",
+					icb.sdlName, icb.name, icb.target, err.Error())
+				reportBadRecord(icb.sdl, icb.ptnInst, icb.ptnRutMgr, &icb.to)
+				gvr.Value = nil
+			}
+		}
+
+		if gvr.Value != nil {
+
 			log.Debugf("protoMsgInd: EvDhtConInstGetValRsp, " +
 				"sdl: %s, forWhat: %d, inst: %s, key: %x",
 				icb.sdlName, msg.ForWhat, icb.name, icb.target)
@@ -646,6 +665,7 @@ func (qryInst *QryInst) protoMsgInd(msg *sch.MsgDhtQryInstProtoMsgInd) sch.SchEr
 				Peers:    nil,
 				Provider: nil,
 				Value:    gvr.Value.Val,
+				ValEx:    extra,
 				Pcs:      gvr.Pcs,
 			}
 
@@ -717,14 +737,14 @@ func (qryInst *QryInst) protoMsgInd(msg *sch.MsgDhtQryInstProtoMsgInd) sch.SchEr
 
 	default:
 		log.Debugf("protoMsgInd: mismatched, " +
-			"sdl: %s, inst: %d, ForWhat: %d",
+			"sdl: %s, inst: %s, ForWhat: %d",
 			icb.sdlName, icb.name, msg.ForWhat)
 		return sch.SchEnoMismatched
 	}
 
 	if icb.sdl.SchSendMessage(&msgResult) != sch.SchEnoNone {
 		log.Errorf("protoMsgInd: send EvDhtQryInstResultInd failed, " +
-			"sdl: %s, inst: %d, ForWhat: %d",
+			"sdl: %s, inst: %s, ForWhat: %d",
 			icb.sdlName, icb.name, msg.ForWhat)
 	}
 
@@ -738,7 +758,7 @@ func (qryInst *QryInst) protoMsgInd(msg *sch.MsgDhtQryInstProtoMsgInd) sch.SchEr
 	icb.sdl.SchMakeMessage(&msgInd, icb.ptnInst, icb.ptnQryMgr, sch.EvDhtQryInstStatusInd, &ind)
 	if eno := icb.sdl.SchSendMessage(&msgInd); eno != sch.SchEnoNone {
 		log.Errorf("protoMsgInd: send EvDhtQryInstStatusInd failed, " +
-			"sdl: %s, inst: %d, ForWhat: %d",
+			"sdl: %s, inst: %s, ForWhat: %d",
 			icb.sdlName, icb.name, msg.ForWhat)
 		return eno
 	}
@@ -791,7 +811,7 @@ func (qryInst *QryInst) setupQryPkg() (DhtErrno, *DhtPackage) {
 		pv := PutValue{
 			From:   *icb.local,
 			To:     icb.to,
-			Values: []DhtValue{{Key: msg.Key, Val: msg.Val, Extra: nil}},
+			Values: []DhtValue{{Key: msg.Key, Val: msg.Val, Extra: msg.Extra}},
 			Id:     icb.qryReq.Seq,
 			Extra:  nil,
 		}
@@ -500,7 +500,7 @@ func (qryMgr *QryMgr)dsGvbStopReq(msg *sch.MsgDhtDsGvbStopReq) sch.SchErrno {
 		return sch.SchEnoNotFound
 	}
 	if gvbCb.status != sch.GVBS_WORKING {
-		log.Debugf("dsGvbStopReq: mismatched, sdl: %s, id: %d, status: %s",
+		log.Debugf("dsGvbStopReq: mismatched, sdl: %s, id: %d, status: %d",
 			qryMgr.sdlName, msg.GvbId, gvbCb.status)
 		return sch.SchEnoMismatched
 	}
@@ -755,7 +755,7 @@ func (qryMgr *QryMgr) rutNotificationInd(msg *sch.MsgDhtRutMgrNotificationInd) s
 	}
 
 	if qcb.qryPending.Len() == 0 && len(qcb.qryActived) == 0 {
-		if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNotFound.GetEno(), nil, nil, nil); dhtEno != DhtEnoNone {
+		if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNotFound.GetEno(), nil, nil, nil, nil); dhtEno != DhtEnoNone {
 			log.Debugf("rutNotificationInd: qryMgrResultReport failed, dhtEno: %d", dhtEno)
 			return sch.SchEnoUserTask
 		}
@@ -810,12 +810,12 @@ func (qryMgr *QryMgr) instStatusInd(msg *sch.MsgDhtQryInstStatusInd) sch.SchErrn
 		if qcb.qryPending.Len() == 0 && len(qcb.qryActived) == 0 {
 			log.Debugf("instStatusInd: query done: %x", qcb.target)
 			if qcb.forWhat == MID_PUTVALUE || qcb.forWhat == MID_PUTPROVIDER {
-				if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, nil, nil); dhtEno != DhtEnoNone {
+				if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, nil, nil, nil); dhtEno != DhtEnoNone {
 					log.Debugf("instStatusInd: qryMgrResultReport failed, dhtEno: %d", dhtEno)
 					return sch.SchEnoUserTask
 				}
 			} else {
-				if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNotFound.GetEno(), nil, nil, nil); dhtEno != DhtEnoNone {
+				if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNotFound.GetEno(), nil, nil, nil, nil); dhtEno != DhtEnoNone {
 					log.Debugf("instStatusInd: qryMgrResultReport failed, dhtEno: %d", dhtEno)
 					return sch.SchEnoUserTask
 				}
@@ -945,7 +945,7 @@ func (qryMgr *QryMgr) instResultInd(msg *sch.MsgDhtQryInstResultInd) sch.SchErrn
 		for _, peer := range msg.Peers {
 			key := rutMgrNodeId2Hash(peer.ID)
 			if bytes.Compare((*key)[0:], target[0:]) == 0 {
-				qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, msg.Value, msg.Provider)
+				qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, msg.Value, msg.ValEx, msg.Provider)
 				if dhtEno := qryMgr.qryMgrDelQcb(delQcb4TargetFound, qcb.target); dhtEno != DhtEnoNone {
 					log.Errorf("instResultInd: qryMgrDelQcb failed, " +
 						"sdl: %s, forWhat: %d, eno: %d, target: %x, from: %x",
@@ -957,7 +957,7 @@ func (qryMgr *QryMgr) instResultInd(msg *sch.MsgDhtQryInstResultInd) sch.SchErrn
 		}
 	} else if msg.ForWhat == sch.EvDhtConInstGetValRsp {
 		if msg.Value != nil && len(msg.Value) > 0 {
-			qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, msg.Value, nil)
+			qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, msg.Value, msg.ValEx, nil)
 			if dhtEno := qryMgr.qryMgrDelQcb(delQcb4TargetFound, qcb.target); dhtEno != DhtEnoNone {
 				log.Errorf("instResultInd: qryMgrDelQcb failed, " +
 					"sdl: %s, forWhat: %d, eno: %d, target: %x, from: %x",
@@ -968,7 +968,7 @@ func (qryMgr *QryMgr) instResultInd(msg *sch.MsgDhtQryInstResultInd) sch.SchErrn
 		}
 	} else if msg.ForWhat == sch.EvDhtConInstGetProviderRsp {
 		if msg.Provider != nil {
-			qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, nil, msg.Provider)
+			qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, nil, nil, msg.Provider)
 			if dhtEno := qryMgr.qryMgrDelQcb(delQcb4TargetFound, qcb.target); dhtEno != DhtEnoNone {
 				log.Errorf("instResultInd: qryMgrDelQcb failed, " +
 					"sdl: %s, forWhat: %d, eno: %d, target: %x, from: %x",
@@ -996,7 +996,7 @@ func (qryMgr *QryMgr) instResultInd(msg *sch.MsgDhtQryInstResultInd) sch.SchErrn
 		if qcb.depth > qryMgrQryMaxDepth || len(qcb.qryHistory) >= qryMgrQryMaxWidth {
 			log.Debugf("instResultInd: query limited to stop, forWhat: %d, depth: %d, width: %d",
 				msg.ForWhat, qcb.depth, len(qcb.qryHistory))
-			if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNotFound.GetEno(), nil, nil, nil);
+			if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNotFound.GetEno(), nil, nil, nil, nil);
 				dhtEno != DhtEnoNone {
 				log.Errorf("instResultInd: qryMgrResultReport failed, " +
 					"sdl: %s, forWhat: %d, dhtEno: %d, target: %x, from: %x",
@@ -1039,14 +1039,14 @@ func (qryMgr *QryMgr) instResultInd(msg *sch.MsgDhtQryInstResultInd) sch.SchErrn
 		if msg.ForWhat == sch.EvDhtConInstNeighbors ||
 			msg.ForWhat == sch.EvDhtConInstGetProviderRsp ||
 			msg.ForWhat == sch.EvDhtConInstGetValRsp {
-			if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNotFound.GetEno(), nil, nil, nil);
+			if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNotFound.GetEno(), nil, nil, nil, nil);
 				dhtEno != DhtEnoNone {
 				log.Errorf("instResultInd: qryMgrResultReport failed, " +
 					"sdl: %s, forWhat: %d, dhtEno: %d, target: %x, from: %x",
 					qryMgr.sdlName, msg.ForWhat, dhtEno, qcb.target, from.ID)
 			}
 		} else {
-			if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, nil, nil);
+			if dhtEno := qryMgr.qryMgrResultReport(qcb, DhtEnoNone.GetEno(), nil, nil, nil, nil);
 				dhtEno != DhtEnoNone {
 				log.Errorf("instResultInd: qryMgrResultReport failed, " +
 					"sdl: %s, forWhat: %d, dhtEno: %d, target: %x, from: %x",
@@ -1526,7 +1526,7 @@ func (qryMgr *QryMgr) qcbTimerHandler(qcb *qryCtrlBlock) sch.SchErrno {
 	log.Debugf("qcbTimerHandler: timeout, " +
 		"sdl: %s, status: %d, forWhat: %d, width: %d, depth: %d, target: %x",
 		qryMgr.sdlName,	qcb.status, qcb.forWhat, qcb.width, qcb.depth, qcb.target)
-	qryMgr.qryMgrResultReport(qcb, DhtEnoTimeout.GetEno(), nil, nil, nil)
+	qryMgr.qryMgrResultReport(qcb, DhtEnoTimeout.GetEno(), nil, nil, nil, nil)
 	qryMgr.qryMgrDelQcb(delQcb4Timeout, qcb.target)
 	return sch.SchEnoNone
 }
@@ -1539,6 +1539,7 @@ func (qryMgr *QryMgr) qryMgrResultReport(
 	eno int,
 	peer *config.Node,
 	val []byte,
+	valEx []byte,
 	prd *sch.Provider) DhtErrno {
 
 	//
@@ -1645,6 +1646,7 @@ func (qryMgr *QryMgr) qryMgrResultReport(
 		ForWhat: qcb.forWhat,
 		Target:  qcb.target,
 		Val:     val,
+		ValEx:   valEx,
 		Prds:    nil,
 		Peers:   nil,
 	}
@@ -31,6 +31,8 @@ import (
 	mrand "math/rand"
 
 	config "github.com/yeeco/gyee/p2p/config"
+	"github.com/yeeco/gyee/p2p/peerstore"
+	"github.com/yeeco/gyee/p2p/reputation"
 	sch "github.com/yeeco/gyee/p2p/scheduler"
 	log "github.com/yeeco/gyee/log"
 )
@@ -50,7 +52,10 @@ const (
 	rutMgrUpdate4Handshake = 0                   // update for handshaking
 	rutMgrUpdate4Closed    = 1                   // update for connection instance closed
 	rutMgrUpdate4Query     = 2                   // update for query result
+	rutMgrUpdate4BadRecord = 3                   // update for record rejected by validator
+	rutMgrUpdate4Banned    = 4                   // update for peer banned by reputation table
 	rutMgrMaxFails2Del     = 3                   // max fails to be deleted
+	rutMgrBadRecordFails   = 2                   // fails counted for a bad record
 	rutMgrEwmaHisSize      = 8                   // history sample number
 	rutMgrEwmaMF           = 0.1                 // memorize factor for EWMA filter
 	rutBootstrap4LBS       = true                // if bootstrap for local even it's a bootstrap node
@@ -127,6 +132,9 @@ type RutMgr struct {
 	localNodeId   config.NodeID                      // local node identity
 	rutTab        rutMgrRouteTable                   // route table
 	ntfTab        map[rutMgrNotifeeId]*rutMgrNotifee // notifee table
+	reputation    *reputation.Reputation             // peer reputation table, nil if none
+	storePath     string                             // file to save route table, empty if not saved
+	store         *peerstore.Store                   // peers of route table saved for warm restart
 }
 
 //
@@ -199,6 +207,7 @@ func (rutMgr *RutMgr) rutMgrProc(ptn interface{}, msg *sch.SchMessage) sch.SchEr
 		eno = rutMgr.refreshReq()
 
 	case sch.EvDhtRutBootstrapTimer:
+		rutMgr.rutMgrFlushStore(peerstore.FlushInterval)
 		eno = rutMgr.bootstarpTimerHandler()
 
 	case sch.EvDhtQryMgrQueryStartRsp:
@@ -264,6 +273,9 @@ func (rutMgr *RutMgr) poweron(ptn interface{}) sch.SchErrno {
 		return eno
 	}
 
+	rutMgr.reputation = reputation.Lookup(rutMgr.sdlName)
+	rutMgr.reputation.OnBan(rutMgr.onBanned)
+
 	if dhtEno := rutMgr.rutMgrGetRouteConfig(); dhtEno != DhtEnoNone {
 		log.Errorf("poweron: rutMgrGetRouteConfig failed, dhtEno: %d", dhtEno)
 		return sch.SchEnoUserTask
@@ -279,6 +291,10 @@ func (rutMgr *RutMgr) poweron(ptn interface{}) sch.SchErrno {
 		return sch.SchEnoUserTask
 	}
 
+	// seed the route table with peers saved, so the bootstrap would start from
+	// them than the bootstrap nodes, which are applied only when table empty.
+	rutMgr.rutMgrLoadStore()
+
 	if dhtEno := rutMgr.rutMgrStartBspTimer(); dhtEno != DhtEnoNone {
 		log.Errorf("poweron: rutMgrStartBspTimer failed, dhtEno: %d", dhtEno)
 		return sch.SchEnoUserTask
@@ -292,6 +308,7 @@ func (rutMgr *RutMgr) poweron(ptn interface{}) sch.SchErrno {
 //
 func (rutMgr *RutMgr) poweroff(ptn interface{}) sch.SchErrno {
 	log.Debugf("poweroff: task will be done ...")
+	rutMgr.rutMgrFlushStore(0)
 	return rutMgr.sdl.SchTaskDone(ptn, rutMgr.name, sch.SchEnoKilled)
 }
 
@@ -565,6 +582,7 @@ func (rutMgr *RutMgr) updateReq(req *sch.MsgDhtRutMgrUpdateReq) sch.SchErrno {
 		dist := rutMgr.rutMgrLog2Dist(shaLocal, hash)
 
 		rutMgr.rutMgrMetricSample(n.ID, dur)
+		rutMgr.rutMgrStoreSeen(n, dur)
 
 		bn := rutMgrBucketNode{
 			node:  *n,
@@ -599,6 +617,10 @@ func (rutMgr *RutMgr) updateReq(req *sch.MsgDhtRutMgrUpdateReq) sch.SchErrno {
 				log.Debugf("updateReq: discard unspecified node seen, sdl: %s", rutMgr.sdlName)
 				continue
 			}
+			if rutMgr.reputation.IsBanned(n.ID) {
+				log.Debugf("updateReq: discard banned node seen, sdl: %s, id: %x", rutMgr.sdlName, n.ID)
+				continue
+			}
 			pcs := conInstStatus2PCS(CisHandshook)
 			doUpdate(&rt.shaLocal, &n, req.Duras[idx], pcs)
 			rutMgr.showRoute("rutMgrUpdate4Handshake.DhtEnoNone")
@@ -691,6 +713,11 @@ func (rutMgr *RutMgr) updateReq(req *sch.MsgDhtRutMgrUpdateReq) sch.SchErrno {
 				continue
 			}
 
+			if rutMgr.reputation.IsBanned(n.ID) {
+				log.Debugf("updateReq: discard banned node seen, sdl: %s, id: %x", rutMgr.sdlName, n.ID)
+				continue
+			}
+
 			dur := req.Duras[idx]
 			rutMgr.rutMgrMetricSample(n.ID, dur)
 
@@ -700,6 +727,7 @@ func (rutMgr *RutMgr) updateReq(req *sch.MsgDhtRutMgrUpdateReq) sch.SchErrno {
 			if eno, el := rutMgr.find(p, d); eno == DhtEnoNone {
 				bn := el.Value.(*rutMgrBucketNode)
 				bn.fails = 0
+				rutMgr.rutMgrStoreSeen(&bn.node, dur)
 			} else {
 				rt := &rutMgr.rutTab
 				pcs := conInstStatus2PCS(CisNull)
@@ -729,6 +757,63 @@ func (rutMgr *RutMgr) updateReq(req *sch.MsgDhtRutMgrUpdateReq) sch.SchErrno {
 
 		el.Value.(*rutMgrBucketNode).pcs = int(conInstStatus2PCS(CisClosed))
 
+	} else if why == rutMgrUpdate4BadRecord {
+
+		log.Tracef("updateReq: why: rutMgrUpdate4BadRecord")
+
+		//
+		// peer sent record rejected by validator, it's punished more than a
+		// timeout, and removed from bucket when fail counter exceeded.
+		//
+
+		p := req.Seens[0].ID
+		h := rutMgrNodeId2Hash(p)
+		d := rutMgr.rutMgrLog2Dist(&rutMgr.rutTab.shaLocal, h)
+
+		if rutMgr.reputation.Report(p, reputation.OffenceBadRecord, reputation.SeverityMajor) {
+			// banned, removed from bucket by rutMgrUpdate4Banned
+			return sch.SchEnoNone
+		}
+
+		eno, el := rutMgr.find(p, d)
+		if eno != DhtEnoNone {
+			log.Debugf("updateReq: not found, eno: %d", eno)
+			return sch.SchEnoUserTask
+		}
+
+		bn := el.Value.(*rutMgrBucketNode)
+		if bn.fails += rutMgrBadRecordFails; bn.fails >= rutMgrMaxFails2Del {
+
+			if eno := rutMgr.delete(p); eno != DhtEnoNone {
+
+				log.Debugf("updateReq: delete failed, eno: %d, id: %x", eno, p)
+				return sch.SchEnoUserTask
+			}
+
+			rutMgr.rutMgrRmvNotify(bn)
+			rutMgr.showRoute("rutMgrUpdate4BadRecord")
+		}
+
+	} else if why == rutMgrUpdate4Banned {
+
+		log.Tracef("updateReq: why: rutMgrUpdate4Banned")
+
+		//
+		// peer banned, remove it from bucket, and connection manager is
+		// notified to close the connections to it even it's not in bucket.
+		//
+
+		p := req.Seens[0].ID
+		bn := &rutMgrBucketNode{node: req.Seens[0]}
+		if eno, el := rutMgr.rutMgrFind(p); eno == DhtEnoNone {
+			bn = el.Value.(*rutMgrBucketNode)
+			if eno := rutMgr.delete(p); eno != DhtEnoNone {
+				log.Debugf("updateReq: delete failed, eno: %d, id: %x", eno, p)
+			}
+			rutMgr.showRoute("rutMgrUpdate4Banned")
+		}
+		rutMgr.rutMgrRmvNotify(bn)
+
 	} else {
 
 		log.Debugf("updateReq: invalid (why:%d, eno:%d)", why, eno)
@@ -738,6 +823,24 @@ func (rutMgr *RutMgr) updateReq(req *sch.MsgDhtRutMgrUpdateReq) sch.SchErrno {
 	return sch.SchEnoNone
 }
 
+//
+// Called by the reputation table when a peer is banned, it's not in the task
+// context, so an update request is sent to route manager itself.
+//
+func (rutMgr *RutMgr) onBanned(id config.NodeID) {
+	req := sch.MsgDhtRutMgrUpdateReq{
+		Why:   rutMgrUpdate4Banned,
+		Eno:   DhtEnoNone.GetEno(),
+		Seens: []config.Node{{ID: id}},
+		Duras: []time.Duration{-1},
+	}
+	msg := sch.SchMessage{}
+	rutMgr.sdl.SchMakeMessage(&msg, rutMgr.ptnMe, rutMgr.ptnMe, sch.EvDhtRutMgrUpdateReq, &req)
+	if eno := rutMgr.sdl.SchSendMessage(&msg); eno != sch.SchEnoNone {
+		log.Debugf("onBanned: send EvDhtRutMgrUpdateReq failed, eno: %d, id: %x", eno, id)
+	}
+}
+
 //
 // Stop notify request handler
 //
@@ -773,6 +876,7 @@ func (rutMgr *RutMgr) rutMgrGetRouteConfig() DhtErrno {
 	rutMgr.localNodeId = rutCfg.NodeId
 	rutMgr.bpCfg.randomQryNum = rutCfg.RandomQryNum
 	rutMgr.bpCfg.period = rutCfg.Period
+	rutMgr.storePath = rutCfg.PeerStore
 	return DhtEnoNone
 }
 
@@ -886,6 +990,61 @@ func (rutMgr *RutMgr) rutMgrSetupRouteTable() DhtErrno {
 	return DhtEnoNone
 }
 
+//
+// Load peers saved into route table
+//
+func (rutMgr *RutMgr) rutMgrLoadStore() DhtErrno {
+	rutMgr.store = peerstore.Open(rutMgr.storePath, peerstore.DefaultMaxPeers, peerstore.DefaultMaxAge)
+	rt := &rutMgr.rutTab
+	count := 0
+	for _, p := range rutMgr.store.Peers(config.AnySubNet, 0) {
+		if p.Node.ID == rutMgr.localNodeId || rutMgr.reputation.IsBanned(p.Node.ID) {
+			continue
+		}
+		if p.Latency > 0 {
+			rutMgr.rutMgrMetricSample(p.Node.ID, p.Latency)
+		}
+		hash := rutMgrNodeId2Hash(p.Node.ID)
+		dist := rutMgr.rutMgrLog2Dist(&rt.shaLocal, hash)
+		bn := rutMgrBucketNode{
+			node:  p.Node,
+			hash:  *hash,
+			dist:  dist,
+			fails: 0,
+			pcs:   int(conInstStatus2PCS(CisNull)),
+		}
+		rutMgr.update(&bn, dist)
+		count++
+	}
+	log.Debugf("rutMgrLoadStore: sdl: %s, peers: %d", rutMgr.sdlName, count)
+	return DhtEnoNone
+}
+
+//
+// Peer seen, with latency sampled if it's positive
+//
+func (rutMgr *RutMgr) rutMgrStoreSeen(n *config.Node, latency time.Duration) {
+	if rutMgr.store == nil {
+		return
+	}
+	rutMgr.store.Seen(config.AnySubNet, n)
+	if latency > 0 {
+		rutMgr.store.Sample(config.AnySubNet, n.ID, latency)
+	}
+}
+
+//
+// Save peers of route table if changed and the interval passed since last saved
+//
+func (rutMgr *RutMgr) rutMgrFlushStore(interval time.Duration) {
+	if rutMgr.store == nil {
+		return
+	}
+	if err := rutMgr.store.Flush(interval); err != nil && err != peerstore.ErrNoPath {
+		log.Debugf("rutMgrFlushStore: failed, sdl: %s, error: %s", rutMgr.sdlName, err.Error())
+	}
+}
+
 //
 // Metric sample input
 //
@@ -1046,6 +1205,10 @@ func (rutMgr *RutMgr) delete(id config.NodeID) DhtErrno {
 
 	li := rutMgr.rutTab.bucketTab[dist]
 
+	if rutMgr.store != nil {
+		rutMgr.store.Remove(id)
+	}
+
 	for el := li.Front(); el != nil; el = el.Next() {
 		if el.Value.(*rutMgrBucketNode).node.ID == id {
 			li.Remove(el)
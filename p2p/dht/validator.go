@@ -0,0 +1,287 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package dht
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/yeeco/gyee/log"
+	config "github.com/yeeco/gyee/p2p/config"
+	sch "github.com/yeeco/gyee/p2p/scheduler"
+	"golang.org/x/crypto/sha3"
+)
+
+//
+// Record validation: a (key, value) pair from remote peers is stored or
+// accepted as a query result only if the validator registered for the key
+// namespace of the record accepts it. the namespace is carried by the first
+// byte of the "Extra" of a value, and a value without "Extra" falls into the
+// content addressed namespace, which is what the core applies for transactions
+// and consensus events.
+//
+const (
+	RecNsContent  = byte(0) // key is the hash of value
+	RecNsSigned   = byte(1) // value signed by a publisher
+	RecNsEvidence = byte(2) // consensus evidence, validator registered by core
+)
+
+//
+// Extra of signed record: namespace | publisher identity | sequence | signature(r, s),
+// the sequence is big endian and signed with the key and value, a record stored
+// is replaced only by one of a higher sequence.
+//
+const (
+	recSeqBytes        = 8
+	recSignBytes       = 64
+	recSignedExtraSize = 1 + config.NodeIDBytes + recSeqBytes + recSignBytes
+)
+
+var (
+	ErrRecNamespace = errors.New("dht: no validator for record namespace")
+	ErrRecHash      = errors.New("dht: key is not the hash of value")
+	ErrRecExtra     = errors.New("dht: invalid record extra")
+	ErrRecPublisher = errors.New("dht: unknown record publisher")
+	ErrRecSign      = errors.New("dht: invalid record signature")
+	ErrRecSeq       = errors.New("dht: record sequence not higher than stored")
+)
+
+//
+// Record validator interface
+//
+type RecordValidator interface {
+
+	//
+	// Check (key, value, extra) of a record, nil returned if it's acceptable
+	//
+
+	Validate(key []byte, val []byte, extra []byte) error
+}
+
+//
+// Record selector, implemented by the validator of a namespace whose records
+// may replace the one stored for the same key only conditionally
+//
+type RecordSelector interface {
+
+	//
+	// Check extra of a record against extra of the one stored for the same key,
+	// nil returned if it can replace the stored one
+	//
+
+	Select(key []byte, extra []byte, stored []byte) error
+}
+
+//
+// Validators registered, by namespace
+//
+var recValidatorLock sync.RWMutex
+var recValidators = map[byte]RecordValidator{
+	RecNsContent: ContentHashValidator{},
+}
+
+//
+// Register validator for namespace, a nil validator removes the namespace
+// so that any record of it would be rejected.
+//
+func RegisterRecordValidator(ns byte, v RecordValidator) {
+	recValidatorLock.Lock()
+	defer recValidatorLock.Unlock()
+	if v == nil {
+		delete(recValidators, ns)
+		return
+	}
+	recValidators[ns] = v
+}
+
+//
+// Validate record against the validator of its namespace
+//
+func ValidateRecord(key []byte, val []byte, extra []byte) error {
+	ns := RecNsContent
+	if len(extra) > 0 {
+		ns = extra[0]
+	}
+	recValidatorLock.RLock()
+	v, ok := recValidators[ns]
+	recValidatorLock.RUnlock()
+	if !ok {
+		return ErrRecNamespace
+	}
+	return v.Validate(key, val, extra)
+}
+
+//
+// Select record validated against the one stored for the key, by the selector
+// of its namespace if any, nil returned if nothing stored or no selector.
+//
+func SelectRecord(key []byte, extra []byte, stored []byte) error {
+	ns := RecNsContent
+	if len(extra) > 0 {
+		ns = extra[0]
+	}
+	recValidatorLock.RLock()
+	v, ok := recValidators[ns]
+	recValidatorLock.RUnlock()
+	if sel, is := v.(RecordSelector); ok && is {
+		return sel.Select(key, extra, stored)
+	}
+	return nil
+}
+
+//
+// Content hash validator: the key must be the sha256 or sha3-256 hash of the
+// value, the former is applied to consensus events and the later to txs.
+//
+type ContentHashValidator struct{}
+
+func (ContentHashValidator) Validate(key []byte, val []byte, extra []byte) error {
+	if len(extra) > 1 {
+		return ErrRecExtra
+	}
+	if h := sha256.Sum256(val); bytes.Equal(key, h[0:]) {
+		return nil
+	}
+	if h := sha3.Sum256(val); bytes.Equal(key, h[0:]) {
+		return nil
+	}
+	return ErrRecHash
+}
+
+//
+// Signed record validator: the "Extra" must carry a signature over the key,
+// value and sequence by one of the publishers configured.
+//
+type SignedRecordValidator struct {
+	publishers map[config.NodeID]bool // publishers accepted
+}
+
+func NewSignedRecordValidator(publishers []config.NodeID) *SignedRecordValidator {
+	v := SignedRecordValidator{
+		publishers: make(map[config.NodeID]bool, len(publishers)),
+	}
+	for _, p := range publishers {
+		v.publishers[p] = true
+	}
+	return &v
+}
+
+func (v *SignedRecordValidator) Validate(key []byte, val []byte, extra []byte) error {
+	if len(extra) != recSignedExtraSize || extra[0] != RecNsSigned {
+		return ErrRecExtra
+	}
+	var pid config.NodeID
+	copy(pid[0:], extra[1:1+config.NodeIDBytes])
+	if !v.publishers[pid] {
+		return ErrRecPublisher
+	}
+	pubKey := config.P2pNodeId2Pubkey(pid[0:])
+	if pubKey == nil || pubKey.X == nil {
+		return ErrRecPublisher
+	}
+	seq := extra[1+config.NodeIDBytes : 1+config.NodeIDBytes+recSeqBytes]
+	sig := extra[1+config.NodeIDBytes+recSeqBytes:]
+	digest := recordDigest(key, val, seq)
+	r := config.P2pBigInt(1, sig[:recSignBytes/2])
+	s := config.P2pBigInt(1, sig[recSignBytes/2:])
+	if !config.P2pVerify(pubKey, digest, r, s) {
+		return ErrRecSign
+	}
+	return nil
+}
+
+//
+// Select signed record of a sequence higher than the stored one, the stored
+// extra had been validated and a record not signed is replaced.
+//
+func (v *SignedRecordValidator) Select(key []byte, extra []byte, stored []byte) error {
+	if len(stored) != recSignedExtraSize || stored[0] != RecNsSigned {
+		return nil
+	}
+	if len(extra) != recSignedExtraSize || extra[0] != RecNsSigned {
+		return ErrRecExtra
+	}
+	if RecordSeq(extra) <= RecordSeq(stored) {
+		return ErrRecSeq
+	}
+	return nil
+}
+
+//
+// Sequence of signed record, from its "Extra"
+//
+func RecordSeq(extra []byte) uint64 {
+	if len(extra) != recSignedExtraSize {
+		return 0
+	}
+	return binary.BigEndian.Uint64(extra[1+config.NodeIDBytes:])
+}
+
+//
+// Sign record of sequence seq by publisher, the "Extra" for the value returned.
+// the publisher should increase seq each time it signs a new value for key.
+//
+func SignRecord(priKey *ecdsa.PrivateKey, key []byte, val []byte, seq uint64) ([]byte, error) {
+	extra := make([]byte, recSignedExtraSize)
+	extra[0] = RecNsSigned
+	copy(extra[1:], config.P2pPubkey2NodeId(&priKey.PublicKey)[0:])
+	seqb := extra[1+config.NodeIDBytes : 1+config.NodeIDBytes+recSeqBytes]
+	binary.BigEndian.PutUint64(seqb, seq)
+	r, s, err := config.P2pSign(priKey, recordDigest(key, val, seqb))
+	if err != nil {
+		return nil, err
+	}
+	sig := extra[1+config.NodeIDBytes+recSeqBytes:]
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(sig[recSignBytes/2-len(rb):recSignBytes/2], rb)
+	copy(sig[recSignBytes-len(sb):], sb)
+	return extra, nil
+}
+
+func recordDigest(key []byte, val []byte, seq []byte) []byte {
+	h := sha256.New()
+	h.Write(key)
+	h.Write(val)
+	h.Write(seq)
+	return h.Sum(nil)
+}
+
+//
+// Report peer which sent bad record to route manager
+//
+func reportBadRecord(sdl *sch.Scheduler, ptnFrom interface{}, ptnRutMgr interface{}, peer *config.Node) {
+	req := sch.MsgDhtRutMgrUpdateReq{
+		Why:   rutMgrUpdate4BadRecord,
+		Eno:   DhtEnoMismatched.GetEno(),
+		Seens: []config.Node{*peer},
+		Duras: []time.Duration{-1},
+	}
+	msg := sch.SchMessage{}
+	sdl.SchMakeMessage(&msg, ptnFrom, ptnRutMgr, sch.EvDhtRutMgrUpdateReq, &req)
+	if eno := sdl.SchSendMessage(&msg); eno != sch.SchEnoNone {
+		log.Debugf("reportBadRecord: send EvDhtRutMgrUpdateReq failed, eno: %d, peer: %x", eno, peer.ID)
+	}
+}
@@ -0,0 +1,234 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package dht
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	config "github.com/yeeco/gyee/p2p/config"
+	"github.com/yeeco/gyee/p2p/reputation"
+	sch "github.com/yeeco/gyee/p2p/scheduler"
+	"golang.org/x/crypto/sha3"
+)
+
+type testRecValidator struct {
+	called int
+	err    error
+}
+
+func (v *testRecValidator) Validate(key []byte, val []byte, extra []byte) error {
+	v.called++
+	return v.err
+}
+
+func TestValidateRecordNamespace(t *testing.T) {
+	const ns = byte(0x7f)
+	val := []byte("value")
+	key := sha256.Sum256(val)
+
+	if err := ValidateRecord(key[0:], val, []byte{ns}); err != ErrRecNamespace {
+		t.Errorf("unregistered namespace: %v", err)
+	}
+
+	v := &testRecValidator{err: errors.New("rejected")}
+	RegisterRecordValidator(ns, v)
+	defer RegisterRecordValidator(ns, nil)
+	if err := ValidateRecord(key[0:], val, []byte{ns, 1, 2}); err != v.err || v.called != 1 {
+		t.Errorf("namespace not dispatched: %v, called %d", err, v.called)
+	}
+
+	// nil and empty extra fall into content namespace
+	for _, extra := range [][]byte{nil, {}, {RecNsContent}} {
+		if err := ValidateRecord(key[0:], val, extra); err != nil {
+			t.Errorf("content record with extra %v: %v", extra, err)
+		}
+	}
+	if v.called != 1 {
+		t.Errorf("content record dispatched to %x", ns)
+	}
+
+	RegisterRecordValidator(ns, nil)
+	if err := ValidateRecord(key[0:], val, []byte{ns}); err != ErrRecNamespace {
+		t.Errorf("removed namespace: %v", err)
+	}
+}
+
+func TestContentHashValidator(t *testing.T) {
+	val := []byte("transaction or event")
+	k256 := sha256.Sum256(val)
+	k3 := sha3.Sum256(val)
+	v := ContentHashValidator{}
+
+	for _, c := range []struct {
+		name  string
+		key   []byte
+		val   []byte
+		extra []byte
+		err   error
+	}{
+		{"sha256", k256[0:], val, nil, nil},
+		{"sha3", k3[0:], val, nil, nil},
+		{"namespace byte", k3[0:], val, []byte{RecNsContent}, nil},
+		{"other value", k256[0:], []byte("forged"), nil, ErrRecHash},
+		{"short key", k256[:16], val, nil, ErrRecHash},
+		{"no key", nil, val, nil, ErrRecHash},
+		{"long extra", k256[0:], val, []byte{RecNsContent, 0}, ErrRecExtra},
+	} {
+		if err := v.Validate(c.key, c.val, c.extra); err != c.err {
+			t.Errorf("%s: got %v want %v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestSignedRecordValidator(t *testing.T) {
+	pub, err := config.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() %v", err)
+	}
+	other, _ := config.GenerateKey()
+	key, val := []byte("name"), []byte("published value")
+
+	extra, err := SignRecord(pub, key, val, 1)
+	if err != nil {
+		t.Fatalf("SignRecord() %v", err)
+	}
+	v := NewSignedRecordValidator([]config.NodeID{*config.P2pPubkey2NodeId(&pub.PublicKey)})
+	RegisterRecordValidator(RecNsSigned, v)
+	defer RegisterRecordValidator(RecNsSigned, nil)
+
+	if err := ValidateRecord(key, val, extra); err != nil {
+		t.Errorf("signed record: %v", err)
+	}
+	if err := ValidateRecord(key, []byte("forged"), extra); err != ErrRecSign {
+		t.Errorf("forged value: %v", err)
+	}
+	if err := v.Validate(key, val, extra[:len(extra)-1]); err != ErrRecExtra {
+		t.Errorf("short extra: %v", err)
+	}
+	forged := append([]byte(nil), extra...)
+	forged[1+config.NodeIDBytes+recSeqBytes-1]++
+	if err := ValidateRecord(key, val, forged); err != ErrRecSign {
+		t.Errorf("forged sequence: %v", err)
+	}
+	extra, _ = SignRecord(other, key, val, 1)
+	if err := ValidateRecord(key, val, extra); err != ErrRecPublisher {
+		t.Errorf("unknown publisher: %v", err)
+	}
+}
+
+func TestSignedRecordSelect(t *testing.T) {
+	pub, err := config.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() %v", err)
+	}
+	key := []byte("name")
+	v := NewSignedRecordValidator([]config.NodeID{*config.P2pPubkey2NodeId(&pub.PublicKey)})
+	RegisterRecordValidator(RecNsSigned, v)
+	defer RegisterRecordValidator(RecNsSigned, nil)
+
+	sign := func(val string, seq uint64) []byte {
+		extra, err := SignRecord(pub, key, []byte(val), seq)
+		if err != nil {
+			t.Fatalf("SignRecord() %v", err)
+		}
+		if err := ValidateRecord(key, []byte(val), extra); err != nil {
+			t.Fatalf("ValidateRecord() %v", err)
+		}
+		if seq != RecordSeq(extra) {
+			t.Fatalf("RecordSeq() %d want %d", RecordSeq(extra), seq)
+		}
+		return extra
+	}
+	older, newer := sign("older", 1), sign("newer", 2)
+
+	for _, c := range []struct {
+		name   string
+		extra  []byte
+		stored []byte
+		err    error
+	}{
+		{"nothing stored", older, nil, nil},
+		{"newer over older", newer, older, nil},
+		{"older over newer", older, newer, ErrRecSeq},
+		{"same sequence", sign("other", 2), newer, ErrRecSeq},
+		{"over unsigned", older, []byte{RecNsContent}, nil},
+	} {
+		if err := SelectRecord(key, c.extra, c.stored); err != c.err {
+			t.Errorf("%s: got %v want %v", c.name, err, c.err)
+		}
+	}
+
+	// content records have no selector
+	if err := SelectRecord(key, nil, newer); err != nil {
+		t.Errorf("content record: %v", err)
+	}
+}
+
+func TestBadRecordPenalty(t *testing.T) {
+	rutMgr := NewRutMgr()
+	rutMgrSetupLog2DistLKT(rutMgr.distLookupTab)
+	rutMgr.rutMgrSetupRouteTable()
+	rutMgr.reputation = reputation.NewReputation(&reputation.Config{
+		Threshold:   -reputation.SeverityMajor - 1,
+		HalfLife:    time.Hour,
+		BanDuration: time.Hour,
+	})
+
+	peer := config.Node{}
+	peer.ID[0] = 0x5a
+	hash := rutMgrNodeId2Hash(peer.ID)
+	dist := rutMgr.rutMgrLog2Dist(nil, hash)
+	rutMgr.update(&rutMgrBucketNode{node: peer, hash: *hash, dist: dist}, dist)
+
+	req := sch.MsgDhtRutMgrUpdateReq{
+		Why:   rutMgrUpdate4BadRecord,
+		Eno:   DhtEnoMismatched.GetEno(),
+		Seens: []config.Node{peer},
+		Duras: []time.Duration{-1},
+	}
+
+	// punished more than a timeout, kept in bucket
+	if eno := rutMgr.updateReq(&req); eno != sch.SchEnoNone {
+		t.Fatalf("updateReq() %d", eno)
+	}
+	eno, el := rutMgr.find(peer.ID, dist)
+	if eno != DhtEnoNone {
+		t.Fatal("peer removed on first bad record")
+	}
+	if fails := el.Value.(*rutMgrBucketNode).fails; fails != rutMgrBadRecordFails {
+		t.Errorf("fails %d want %d", fails, rutMgrBadRecordFails)
+	}
+	if score := rutMgr.reputation.Score(peer.ID); math.Abs(score+reputation.SeverityMajor) > 1 {
+		t.Errorf("score %f want %d", score, -reputation.SeverityMajor)
+	}
+
+	// banned by the second, left to rutMgrUpdate4Banned
+	if eno := rutMgr.updateReq(&req); eno != sch.SchEnoNone {
+		t.Fatalf("updateReq() %d", eno)
+	}
+	if !rutMgr.reputation.IsBanned(peer.ID) {
+		t.Error("peer not banned")
+	}
+}
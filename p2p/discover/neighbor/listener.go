@@ -120,7 +120,9 @@ func (lsnMgr *ListenerManager) setupUdpConn() sch.SchErrno {
 	var conn *net.UDPConn = nil
 	var realAddr *net.UDPAddr = nil
 
-	strAddr := fmt.Sprintf("0.0.0.0:%d", /*lsnMgr.cfg.IP.String(),*/ lsnMgr.cfg.UDP)
+	// listen on all interfaces of both ipv4 and ipv6, a multi-homed node can
+	// be reached at any endpoint it advertises.
+	strAddr := fmt.Sprintf(":%d", lsnMgr.cfg.UDP)
 	udpAddr, err := net.ResolveUDPAddr("udp", strAddr)
 	if err != nil {
 		log.Warn("setupUdpConn: ResolveUDPAddr failed", err)
@@ -139,9 +139,10 @@ func (inst *neighborInst) NgbProtoFindNodeReq(ptn interface{}, fn *um.FindNode)
 	pum.Encode(um.UdpMsgTypeFindNode, fn)
 	buf, _ := pum.GetRawMessage()
 
+	var to = fn.To.BestEndpoint()
 	var dst = net.UDPAddr{}
-	dst.IP = append(dst.IP, fn.To.IP...)
-	dst.Port = int(fn.To.UDP)
+	dst.IP = append(dst.IP, to.IP...)
+	dst.Port = int(to.UDP)
 
 	pum.DebugMessageToPeer()
 
@@ -174,9 +175,10 @@ func (inst *neighborInst) NgbProtoPingReq(ptn interface{}, ping *um.Ping) NgbPro
 	pum.Encode(um.UdpMsgTypePing, ping)
 	buf, _ := pum.GetRawMessage()
 
+	to := ping.To.BestEndpoint()
 	dst := net.UDPAddr{}
-	dst.IP = append(dst.IP, ping.To.IP...)
-	dst.Port = int(ping.To.UDP)
+	dst.IP = append(dst.IP, to.IP...)
+	dst.Port = int(to.UDP)
 
 	pum.DebugMessageToPeer()
 	sendUdpMsg(inst.sdl, inst.ngbMgr.ptnLsn, inst.ptn, buf, &dst)
@@ -483,9 +485,10 @@ func (ngbMgr *NeighborManager) PingHandler(ping *um.Ping, from *net.UDPAddr) Ngb
 	if from != nil {
 		toAddr = *from
 	} else {
+		ep := ping.From.BestEndpoint()
 		toAddr = net.UDPAddr{
-			IP:   ping.From.IP,
-			Port: int(ping.From.UDP),
+			IP:   ep.IP,
+			Port: int(ep.UDP),
 			Zone: "",
 		}
 	}
@@ -597,10 +600,11 @@ func (ngbMgr *NeighborManager) FindNodeHandler(findNode *um.FindNode, from *net.
 	}
 
 	cfgNode := config.Node{
-		IP:  ngbMgr.cfg.IP,
-		UDP: ngbMgr.cfg.UDP,
-		TCP: ngbMgr.cfg.TCP,
-		ID:  ngbMgr.cfg.ID,
+		IP:    ngbMgr.cfg.IP,
+		UDP:   ngbMgr.cfg.UDP,
+		TCP:   ngbMgr.cfg.TCP,
+		ID:    ngbMgr.cfg.ID,
+		Addrs: ngbMgr.cfg.Addrs,
 	}
 
 	for idx, n := range nodes {
@@ -635,6 +639,7 @@ func (ngbMgr *NeighborManager) FindNodeHandler(findNode *um.FindNode, from *net.
 			UDP:    n.UDP,
 			TCP:    n.TCP,
 			NodeId: n.ID,
+			Addrs:  n.Addrs,
 		}
 		umNodes = append(umNodes, &umn)
 	}
@@ -654,9 +659,10 @@ func (ngbMgr *NeighborManager) FindNodeHandler(findNode *um.FindNode, from *net.
 	if from != nil {
 		toAddr = *from
 	} else {
+		ep := findNode.From.BestEndpoint()
 		toAddr = net.UDPAddr{
-			IP:   findNode.From.IP,
-			Port: int(findNode.From.UDP),
+			IP:   ep.IP,
+			Port: int(ep.UDP),
 			Zone: "",
 		}
 	}
@@ -926,6 +932,7 @@ func (ngbMgr *NeighborManager) localNode() *um.Node {
 		UDP:    ngbMgr.cfg.UDP,
 		TCP:    ngbMgr.cfg.TCP,
 		NodeId: ngbMgr.cfg.ID,
+		Addrs:  ngbMgr.cfg.Addrs,
 	}
 }
 
@@ -939,6 +946,7 @@ func (ngbMgr *NeighborManager) localSubNode(snid config.SubNetworkID) *um.Node {
 		UDP:    ngbMgr.cfg.UDP,
 		TCP:    ngbMgr.cfg.TCP,
 		NodeId: *id,
+		Addrs:  ngbMgr.cfg.Addrs,
 	}
 }
 
@@ -959,6 +967,7 @@ func (ngbMgr *NeighborManager) setupConfig() sch.SchErrno {
 	ngbMgr.cfg.UDP = ptCfg.UDP
 	ngbMgr.cfg.TCP = ptCfg.TCP
 	ngbMgr.cfg.ID = ptCfg.ID
+	ngbMgr.cfg.Addrs = ptCfg.Addrs
 	ngbMgr.cfg.NetworkType = ptCfg.NetworkType
 	ngbMgr.cfg.SubNetNodeList = ptCfg.SubNetNodeList
 	ngbMgr.cfg.SubNetIdList = ptCfg.SubNetIdList
@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/binary"
+	"net"
 	"os"
 	"time"
 
@@ -52,6 +53,11 @@ type nodeDB struct {
 	self NodeID      // Identity of the owner node of this database
 }
 
+type nodeBan struct {
+	until   time.Time // time the ban expired
+	offence string    // offence the peer banned for
+}
+
 var (
 	// Since it's possible an old database might be present and not suitable fro application,
 	// a "version" field is applied to keep this case off: When a node database is opened, it
@@ -71,6 +77,11 @@ var (
 	pingKey     = rootKey + ":lpi" // last ping
 	pongKey     = rootKey + ":lpo" // last pong
 	findfailKey = rootKey + ":ffa" // find fail
+	// Bans of peers are not bound to any sub network and must survive when the node
+	// entries are expired, so they are keyed by a dedicated prefix:
+	//		key=banPrefix + nodeId
+	// with value: expired time(varint, unix seconds) + offence.
+	banPrefix = "ban:"
 )
 
 func newNodeDB(path string, version int, self NodeID) (*nodeDB, error) {
@@ -266,6 +277,39 @@ func (db *nodeDB) updateFindFails(snid SubNetworkID, id NodeID, fails int) error
 	return db.storeInt64(makeKey(idEx, findfailKey), int64(fails))
 }
 
+func (db *nodeDB) bans() map[NodeID]*nodeBan {
+	bans := make(map[NodeID]*nodeBan, 0)
+	it := db.lvl.NewIterator(util.BytesPrefix([]byte(banPrefix)), nil)
+	defer it.Release()
+	for it.Next() {
+		key, blob := it.Key(), it.Value()
+		if len(key) != len(banPrefix)+config.NodeIDBytes {
+			continue
+		}
+		until, read := binary.Varint(blob)
+		if read <= 0 {
+			continue
+		}
+		var id NodeID
+		copy(id[0:], key[len(banPrefix):])
+		bans[id] = &nodeBan{
+			until:   time.Unix(until, 0),
+			offence: string(blob[read:]),
+		}
+	}
+	return bans
+}
+
+func (db *nodeDB) updateBan(id NodeID, until time.Time, offence string) error {
+	blob := make([]byte, binary.MaxVarintLen64)
+	blob = append(blob[:binary.PutVarint(blob, until.Unix())], offence...)
+	return db.lvl.Put(append([]byte(banPrefix), id[:]...), blob, nil)
+}
+
+func (db *nodeDB) deleteBan(id NodeID) error {
+	return db.lvl.Delete(append([]byte(banPrefix), id[:]...), nil)
+}
+
 func (db *nodeDB) querySeeds(snid SubNetworkID, n int, maxAge time.Duration) []*Node {
 	// this function called to find out nodes with age less than maxAge, and the max
 	// number of these nodes should not exceed n passed in.
@@ -360,10 +404,23 @@ func (db *nodeDB) close() {
 	db.lvl.Close()
 }
 
+// Layout of node record: ip length(4 or 16), ip, udp and tcp ports, node
+// identity, sub network identity, hash, and the packed endpoint list of a
+// multi-homed node at the tail. records written before ipv6 was supported
+// have a 4 bytes ipv4 address without the length byte.
+const nodeRecordLegacySize = net.IPv4len + 4 + config.NodeIDBytes + config.SubNetIdBytes + HashLength
+
 func EncodeToBytes(snid SubNetworkID, node *Node) ([]byte, error) {
-	// Test version, only IPV4 supported
-	blob := make([]byte, 0)
-	blob = append(blob, node.IP...)
+	ip := node.IP.To4()
+	if ip == nil {
+		ip = node.IP.To16()
+	}
+	if ip == nil {
+		return nil, errors.New("EncodeToBytes: invalid ip")
+	}
+	blob := make([]byte, 0, 1+nodeRecordLegacySize+net.IPv6len)
+	blob = append(blob, byte(len(ip)))
+	blob = append(blob, ip...)
 
 	udp := node.UDP
 	hb := byte((udp >> 8) & 0xff)
@@ -378,20 +435,39 @@ func EncodeToBytes(snid SubNetworkID, node *Node) ([]byte, error) {
 	blob = append(blob, node.ID[:]...)
 	blob = append(blob, snid[:]...)
 	blob = append(blob, node.sha[:]...)
+	blob = append(blob, config.P2pEncodeEndpoints(node.Addrs)...)
 
 	return blob, nil
 }
 
 func DecodeBytes(blob []byte, node *Node, snid *SubNetworkID) error {
-	// Test version, only IPV4 supported
-	node.IP = append(node.IP, blob[0:4]...)
-	node.UDP = (uint16(blob[4]) << 8) + uint16(blob[5])
-	node.TCP = (uint16(blob[6]) << 8) + uint16(blob[7])
-	copy(node.ID[0:], blob[8:8+cap(node.ID)])
+	ipLen := net.IPv4len
+	if len(blob) == nodeRecordLegacySize {
+		blob = append([]byte{byte(ipLen)}, blob...)
+	} else if len(blob) > 0 {
+		ipLen = int(blob[0])
+	}
+	if ipLen != net.IPv4len && ipLen != net.IPv6len || len(blob) < 1+ipLen+nodeRecordLegacySize-net.IPv4len {
+		return errors.New("DecodeBytes: bad record")
+	}
+	blob = blob[1:]
+	node.IP = append(node.IP, blob[0:ipLen]...)
+	blob = blob[ipLen:]
+	node.UDP = (uint16(blob[0]) << 8) + uint16(blob[1])
+	node.TCP = (uint16(blob[2]) << 8) + uint16(blob[3])
+	blob = blob[4:]
+	copy(node.ID[0:], blob[:cap(node.ID)])
+	blob = blob[cap(node.ID):]
 	if snid != nil {
-		(*snid)[0] = blob[8+cap(node.ID)]
-		(*snid)[1] = blob[8+cap(node.ID)+1]
+		(*snid)[0] = blob[0]
+		(*snid)[1] = blob[1]
+	}
+	blob = blob[config.SubNetIdBytes:]
+	copy(node.sha[0:], blob[:HashLength])
+	addrs, err := config.P2pDecodeEndpoints(blob[HashLength:])
+	if err != nil {
+		return err
 	}
-	copy(node.sha[0:], blob[8+cap(node.ID)+2:])
+	node.Addrs = addrs
 	return nil
 }
@@ -38,6 +38,7 @@ import (
 	config "github.com/yeeco/gyee/p2p/config"
 	um "github.com/yeeco/gyee/p2p/discover/udpmsg"
 	nat "github.com/yeeco/gyee/p2p/nat"
+	"github.com/yeeco/gyee/p2p/reputation"
 	sch "github.com/yeeco/gyee/p2p/scheduler"
 )
 
@@ -371,6 +372,10 @@ func (tabMgr *TableManager) tabMgrPoweron(ptn interface{}) TabMgrErrno {
 		return eno
 	}
 
+	// the node database backs the bans of the peer reputation table shared by
+	// this p2p instance if any
+	reputation.Lookup(tabMgr.sdl.SchGetP2pCfgName()).SetBanStore(tabMgr)
+
 	if eno = tabMgr.tabSetupLocalHashId(); eno != TabMgrEnoNone {
 		log.Debugf("tabMgrPoweron: tabSetupLocalHash failed, eno: %d", eno)
 		return eno
@@ -528,6 +533,7 @@ func (tabMgr *TableManager) tabMgrPoweroff(ptn interface{}) TabMgrErrno {
 	// close nodeDb and done the task: timers would be killed by scheduler when task done,
 	// and one could also chose to kill them himself before done the task.
 	if tabMgr.nodeDb != nil {
+		reputation.Lookup(tabMgr.sdl.SchGetP2pCfgName()).SetBanStore(nil)
 		tabMgr.nodeDb.close()
 		tabMgr.nodeDb = nil
 	}
@@ -816,10 +822,11 @@ func (tabMgr *TableManager) tabMgrPingpongRsp(msg *sch.NblPingRsp) TabMgrErrno {
 	// Update node database for pingpong related info
 	n := Node{
 		Node: config.Node{
-			IP:  msg.Pong.From.IP,
-			UDP: msg.Pong.From.UDP,
-			TCP: msg.Pong.From.TCP,
-			ID:  msg.Pong.From.NodeId,
+			IP:    msg.Pong.From.IP,
+			UDP:   msg.Pong.From.UDP,
+			TCP:   msg.Pong.From.TCP,
+			ID:    msg.Pong.From.NodeId,
+			Addrs: msg.Pong.From.Addrs,
 		},
 		sha: *TabNodeId2Hash(NodeID(msg.Pong.From.NodeId)),
 	}
@@ -1412,6 +1419,7 @@ func (tabMgr *TableManager) tabRefresh(snid *SubNetworkID, tid *NodeID) TabMgrEr
 						UDP:    dbn.UDP,
 						TCP:    dbn.TCP,
 						NodeId: dbn.ID,
+						Addrs:  dbn.Addrs,
 					}
 
 					if eno := mgr.tabDiscoverResp(&umNode); eno != TabMgrEnoNone {
@@ -1659,6 +1667,7 @@ func (tabMgr *TableManager) tabQuery(target *NodeID, nodes []*Node) TabMgrErrno
 				UDP:    tabMgr.cfg.local.UDP,
 				TCP:    tabMgr.cfg.local.TCP,
 				NodeId: tabMgr.cfg.local.ID,
+				Addrs:  tabMgr.cfg.local.Addrs,
 			}
 
 			msg.To = um.Node{
@@ -1666,6 +1675,7 @@ func (tabMgr *TableManager) tabQuery(target *NodeID, nodes []*Node) TabMgrErrno
 				UDP:    nodes[loop].UDP,
 				TCP:    nodes[loop].TCP,
 				NodeId: nodes[loop].ID,
+				Addrs:  nodes[loop].Addrs,
 			}
 
 			msg.FromSubNetId = tabMgr.cfg.subNetIdList
@@ -1872,10 +1882,11 @@ func (tabMgr *TableManager) tabUpdateBootstarpNode(n *um.Node) TabMgrErrno {
 	snid := tabMgr.snid
 	node := Node{
 		Node: config.Node{
-			IP:  n.IP,
-			UDP: n.UDP,
-			TCP: n.TCP,
-			ID:  n.NodeId,
+			IP:    n.IP,
+			UDP:   n.UDP,
+			TCP:   n.TCP,
+			ID:    n.NodeId,
+			Addrs: n.Addrs,
 		},
 		sha: *TabNodeId2Hash(id),
 	}
@@ -1891,6 +1902,7 @@ func (tabMgr *TableManager) tabUpdateBootstarpNode(n *um.Node) TabMgrErrno {
 		UDP:    node.UDP,
 		TCP:    node.TCP,
 		NodeId: node.ID,
+		Addrs:  node.Addrs,
 	}
 	return tabMgr.TabBucketAddNode(snid, &umn, &time.Time{}, &now, &now)
 }
@@ -2136,10 +2148,11 @@ func (tabMgr *TableManager) tabBucketAddNode(n *um.Node, lastQuery *time.Time, l
 	// if bucket not full, insert node
 	if len(b.nodes) < bucketSize {
 		be.Node = config.Node{
-			IP:  n.IP,
-			UDP: n.UDP,
-			TCP: n.TCP,
-			ID:  n.NodeId,
+			IP:    n.IP,
+			UDP:   n.UDP,
+			TCP:   n.TCP,
+			ID:    n.NodeId,
+			Addrs: n.Addrs,
 		}
 
 		be.sha = *TabNodeId2Hash(id)
@@ -2193,10 +2206,11 @@ func (tabMgr *TableManager) tabBucketAddNode(n *um.Node, lastQuery *time.Time, l
 kickSelected:
 
 	be.Node = config.Node{
-		IP:  n.IP,
-		UDP: n.UDP,
-		TCP: n.TCP,
-		ID:  n.NodeId,
+		IP:    n.IP,
+		UDP:   n.UDP,
+		TCP:   n.TCP,
+		ID:    n.NodeId,
+		Addrs: n.Addrs,
 	}
 	be.sha = *TabNodeId2Hash(id)
 	be.addTime = time.Now()
@@ -2318,10 +2332,11 @@ func (tabMgr *TableManager) tabAddPendingBoundInst(node *um.Node) TabMgrErrno {
 
 	var n = Node{
 		Node: config.Node{
-			IP:  node.IP,
-			UDP: node.UDP,
-			TCP: node.TCP,
-			ID:  node.NodeId,
+			IP:    node.IP,
+			UDP:   node.UDP,
+			TCP:   node.TCP,
+			ID:    node.NodeId,
+			Addrs: node.Addrs,
 		},
 		sha: *TabNodeId2Hash(NodeID(node.NodeId)),
 	}
@@ -2380,6 +2395,7 @@ func (tabMgr *TableManager) tabActiveBoundInst() TabMgrErrno {
 				UDP:    pn.UDP,
 				TCP:    pn.TCP,
 				NodeId: pn.ID,
+				Addrs:  pn.Addrs,
 			}
 			if eno := tabMgr.tabDiscoverResp(&umNode); eno != TabMgrEnoNone {
 				log.Tracef("tabActiveBoundInst: tabDiscoverResp failed, eno: %d", eno)
@@ -2393,12 +2409,14 @@ func (tabMgr *TableManager) tabActiveBoundInst() TabMgrErrno {
 				UDP:    tabMgr.cfg.local.UDP,
 				TCP:    tabMgr.cfg.local.TCP,
 				NodeId: tabMgr.cfg.local.ID,
+				Addrs:  tabMgr.cfg.local.Addrs,
 			},
 			To: um.Node{
 				IP:     pn.Node.IP,
 				UDP:    pn.Node.UDP,
 				TCP:    pn.Node.TCP,
 				NodeId: pn.Node.ID,
+				Addrs:  pn.Node.Addrs,
 			},
 			FromSubNetId: tabMgr.cfg.subNetIdList,
 			SubNetId:     tabMgr.snid,
@@ -2459,10 +2477,11 @@ func (tabMgr *TableManager) tabDiscoverResp(node *um.Node) TabMgrErrno {
 		Snid: tabMgr.snid,
 		Nodes: []*config.Node{
 			&config.Node{
-				IP:  node.IP,
-				UDP: node.UDP,
-				TCP: node.TCP,
-				ID:  node.NodeId,
+				IP:    node.IP,
+				UDP:   node.UDP,
+				TCP:   node.TCP,
+				ID:    node.NodeId,
+				Addrs: node.Addrs,
 			},
 		},
 	}
@@ -2489,6 +2508,43 @@ func (tabMgr *TableManager) tabShouldBoundDbNode(id NodeID) bool {
 	return tabMgr.tabShouldBound(id)
 }
 
+//
+// Ban store of peer reputation, see reputation.BanStore
+//
+var errNodeDbClosed = errors.New("node database closed")
+
+func (tabMgr *TableManager) LoadBans() []*reputation.Ban {
+	db := tabMgr.nodeDb
+	if db == nil {
+		return nil
+	}
+	bans := make([]*reputation.Ban, 0)
+	for id, b := range db.bans() {
+		bans = append(bans, &reputation.Ban{
+			ID:      id,
+			Offence: b.offence,
+			Until:   b.until,
+		})
+	}
+	return bans
+}
+
+func (tabMgr *TableManager) StoreBan(ban *reputation.Ban) error {
+	db := tabMgr.nodeDb
+	if db == nil {
+		return errNodeDbClosed
+	}
+	return db.updateBan(ban.ID, ban.Until, ban.Offence)
+}
+
+func (tabMgr *TableManager) DeleteBan(id config.NodeID) error {
+	db := tabMgr.nodeDb
+	if db == nil {
+		return errNodeDbClosed
+	}
+	return db.deleteBan(id)
+}
+
 func (tabMgr *TableManager) TabBucketAddNode(snid SubNetworkID, n *um.Node, lastQuery *time.Time, lastPing *time.Time, lastPong *time.Time) TabMgrErrno {
 	mgr, ok := tabMgr.subNetMgrList[snid]
 	if !ok {
@@ -2512,10 +2568,11 @@ func (tabMgr *TableManager) TabUpdateNode(snid SubNetworkID, umn *um.Node) TabMg
 
 	n := Node{
 		Node: config.Node{
-			IP:  umn.IP,
-			UDP: umn.UDP,
-			TCP: umn.TCP,
-			ID:  config.NodeID(umn.NodeId),
+			IP:    umn.IP,
+			UDP:   umn.UDP,
+			TCP:   umn.TCP,
+			ID:    config.NodeID(umn.NodeId),
+			Addrs: umn.Addrs,
 		},
 		sha: *TabNodeId2Hash(NodeID(umn.NodeId)),
 	}
@@ -2538,10 +2595,11 @@ func (tabMgr *TableManager) TabClosest(forWhat int, target NodeID, mbs int, size
 func TabBuildNode(pn *config.Node) *Node {
 	return &Node{
 		Node: config.Node{
-			IP:  pn.IP,
-			UDP: pn.UDP,
-			TCP: pn.TCP,
-			ID:  config.NodeID(pn.ID),
+			IP:    pn.IP,
+			UDP:   pn.UDP,
+			TCP:   pn.TCP,
+			ID:    config.NodeID(pn.ID),
+			Addrs: pn.Addrs,
 		},
 		sha: *TabNodeId2Hash(NodeID(pn.ID)),
 	}
@@ -145,6 +145,7 @@ type UdpMessage_Node struct {
 	UDP              *uint32 `protobuf:"varint,2,req,name=UDP" json:"UDP,omitempty"`
 	TCP              *uint32 `protobuf:"varint,3,req,name=TCP" json:"TCP,omitempty"`
 	NodeId           []byte  `protobuf:"bytes,4,req,name=NodeId" json:"NodeId,omitempty"`
+	Addrs            []byte  `protobuf:"bytes,5,opt,name=Addrs" json:"Addrs,omitempty"`
 	XXX_unrecognized []byte  `json:"-"`
 }
 
@@ -181,6 +182,13 @@ func (m *UdpMessage_Node) GetNodeId() []byte {
 	return nil
 }
 
+func (m *UdpMessage_Node) GetAddrs() []byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
 type UdpMessage_Ping struct {
 	From             *UdpMessage_Node           `protobuf:"bytes,1,req,name=From" json:"From,omitempty"`
 	To               *UdpMessage_Node           `protobuf:"bytes,2,req,name=To" json:"To,omitempty"`
@@ -617,6 +625,12 @@ func (m *UdpMessage_Node) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintUdpmsg(dAtA, i, uint64(len(m.NodeId)))
 		i += copy(dAtA[i:], m.NodeId)
 	}
+	if m.Addrs != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintUdpmsg(dAtA, i, uint64(len(m.Addrs)))
+		i += copy(dAtA[i:], m.Addrs)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -1065,6 +1079,10 @@ func (m *UdpMessage_Node) Size() (n int) {
 		l = len(m.NodeId)
 		n += 1 + l + sovUdpmsg(uint64(l))
 	}
+	if m.Addrs != nil {
+		l = len(m.Addrs)
+		n += 1 + l + sovUdpmsg(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1676,6 +1694,37 @@ func (m *UdpMessage_Node) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 			hasFields[0] |= uint64(0x00000008)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUdpmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthUdpmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addrs == nil {
+				m.Addrs = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipUdpmsg(dAtA[iNdEx:])
@@ -2984,40 +3033,41 @@ var (
 func init() { proto.RegisterFile("udpmsg.proto", fileDescriptorUdpmsg) }
 
 var fileDescriptorUdpmsg = []byte{
-	// 559 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x95, 0xdf, 0x8e, 0x93, 0x40,
-	0x14, 0x87, 0x97, 0x61, 0x68, 0xe9, 0x59, 0x76, 0x43, 0x26, 0x66, 0x33, 0xe9, 0x05, 0xe2, 0x5e,
-	0x11, 0x2f, 0x1a, 0xd3, 0x4b, 0x8d, 0x26, 0xdb, 0x85, 0x56, 0x12, 0x97, 0x92, 0x29, 0xfb, 0x00,
-	0x6d, 0x40, 0x24, 0x9b, 0x32, 0x04, 0xd8, 0xb8, 0xbe, 0x89, 0x2f, 0xe0, 0xbd, 0x8f, 0xe1, 0xa5,
-	0x8f, 0x60, 0xea, 0x85, 0x3e, 0x86, 0x61, 0xa0, 0x6c, 0xd5, 0xb6, 0xf1, 0xef, 0x85, 0x89, 0x57,
-	0xe5, 0x9c, 0xfc, 0xbe, 0x19, 0xce, 0x97, 0x99, 0x02, 0xda, 0x75, 0x98, 0x2d, 0x8b, 0x78, 0x90,
-	0xe5, 0xbc, 0xe4, 0xa4, 0xb7, 0xae, 0x16, 0xa7, 0x6f, 0x8e, 0x01, 0x2e, 0xc3, 0xec, 0x22, 0x2a,
-	0x8a, 0x79, 0x1c, 0x91, 0x47, 0xd0, 0x5d, 0x16, 0x71, 0xf0, 0x2a, 0x8b, 0xa8, 0x64, 0x22, 0xeb,
-	0x78, 0x78, 0x6f, 0xd0, 0x66, 0x07, 0xb7, 0xb9, 0x41, 0xf3, 0x5b, 0x05, 0xd9, 0x9a, 0x20, 0x03,
-	0xc0, 0x59, 0x92, 0xc6, 0x14, 0x99, 0x92, 0x75, 0x38, 0xec, 0x6f, 0x27, 0xfd, 0x24, 0x8d, 0x99,
-	0xc8, 0x89, 0x3c, 0x4f, 0x63, 0x2a, 0xef, 0xcd, 0x73, 0x91, 0xe7, 0x69, 0x4c, 0x1e, 0x82, 0xfa,
-	0x3c, 0x49, 0x43, 0x8f, 0x87, 0x11, 0xc5, 0x82, 0x31, 0xb6, 0x33, 0xe3, 0x26, 0xc5, 0xda, 0x3c,
-	0x79, 0x0c, 0xbd, 0x34, 0x4a, 0xe2, 0x17, 0x0b, 0x9e, 0x17, 0x54, 0x11, 0xf0, 0xdd, 0xed, 0xb0,
-	0xb7, 0x8e, 0xb1, 0x5b, 0xa2, 0x6f, 0x80, 0x36, 0xbb, 0x5e, 0x78, 0x51, 0xf9, 0x92, 0xe7, 0x57,
-	0xae, 0x4d, 0x8e, 0x01, 0xb9, 0xa1, 0x50, 0xa4, 0x31, 0xe4, 0x86, 0x7d, 0x06, 0x58, 0x6c, 0x53,
-	0xf5, 0xfd, 0xb6, 0xef, 0x13, 0x1d, 0xe4, 0x4b, 0xdb, 0xa7, 0xc8, 0x44, 0xd6, 0x11, 0xab, 0x1e,
-	0xab, 0x4e, 0x70, 0xee, 0x53, 0xb9, 0xee, 0x04, 0xe7, 0x3e, 0x39, 0x81, 0x4e, 0xc5, 0xba, 0x21,
-	0xc5, 0x82, 0x6b, 0xaa, 0xfe, 0x5b, 0x04, 0xd8, 0x6f, 0x3c, 0x8d, 0x73, 0xbe, 0x14, 0xcb, 0xee,
-	0xf4, 0x24, 0xe6, 0x15, 0x39, 0x72, 0x1f, 0x50, 0xc0, 0xc5, 0x9e, 0xfb, 0xd3, 0x28, 0xe0, 0x64,
-	0x0c, 0x5a, 0xc5, 0xd4, 0xc3, 0xb9, 0x21, 0x95, 0x4d, 0xd9, 0x3a, 0x1c, 0x9e, 0x6e, 0xa7, 0x36,
-	0x15, 0xb0, 0xaf, 0x38, 0xf2, 0x04, 0xd4, 0x76, 0x0d, 0x2c, 0x76, 0xfe, 0x91, 0x35, 0x5a, 0xa6,
-	0x11, 0xaa, 0x98, 0xc8, 0xc2, 0x95, 0x50, 0x62, 0x00, 0x38, 0x37, 0x59, 0x92, 0xcf, 0xcb, 0x84,
-	0xa7, 0xb4, 0x63, 0x4a, 0x16, 0x66, 0x1b, 0x1d, 0x72, 0x07, 0x14, 0xe7, 0xa6, 0xcc, 0xe7, 0xb4,
-	0x6b, 0x4a, 0x96, 0xc6, 0xea, 0xa2, 0x56, 0xc6, 0xff, 0x2b, 0xfb, 0x09, 0x65, 0x9f, 0x11, 0xa8,
-	0xeb, 0xfb, 0xf2, 0x57, 0xb5, 0xf5, 0x41, 0xbd, 0x98, 0x17, 0x57, 0xa3, 0xa4, 0x2c, 0xc4, 0xe9,
-	0x57, 0x58, 0x5b, 0x7f, 0xa7, 0x14, 0xff, 0x01, 0xa5, 0xca, 0x2f, 0x28, 0x3d, 0x81, 0x4e, 0x30,
-	0xcf, 0xe3, 0xa8, 0xa4, 0x9d, 0xfa, 0x2a, 0xd6, 0x55, 0xa3, 0xba, 0xbb, 0x43, 0xb5, 0xba, 0x5b,
-	0x75, 0x6f, 0x53, 0xf5, 0x27, 0x04, 0xbd, 0xf6, 0xdf, 0xe5, 0x9f, 0x38, 0xa2, 0xf5, 0xdc, 0xb8,
-	0x9d, 0xfb, 0x77, 0xfd, 0x3e, 0x00, 0xa5, 0x7a, 0xc7, 0x82, 0x76, 0xc4, 0x0b, 0xed, 0x1b, 0xa3,
-	0x0e, 0x7e, 0x63, 0xba, 0xbb, 0xdb, 0xb4, 0xba, 0x61, 0xfa, 0xd4, 0x87, 0xc3, 0x8d, 0x2f, 0x14,
-	0x51, 0x01, 0xfb, 0xae, 0x37, 0xd1, 0x0f, 0xc4, 0xd3, 0xd4, 0x9b, 0xe8, 0x12, 0xd1, 0x40, 0x1d,
-	0xbb, 0x9e, 0xed, 0x4d, 0x6d, 0x47, 0x47, 0xe4, 0x08, 0x7a, 0x9e, 0xe3, 0x4e, 0x9e, 0x8e, 0xa6,
-	0x6c, 0xa6, 0xcb, 0x44, 0x07, 0x6d, 0x16, 0x9c, 0x3d, 0x73, 0xce, 0x6c, 0x9b, 0x39, 0xb3, 0x99,
-	0x8e, 0x47, 0xfa, 0xbb, 0x95, 0x21, 0xbd, 0x5f, 0x19, 0xd2, 0x87, 0x95, 0x21, 0xbd, 0xfe, 0x68,
-	0x1c, 0x7c, 0x09, 0x00, 0x00, 0xff, 0xff, 0x5f, 0x5d, 0xa5, 0x19, 0x53, 0x07, 0x00, 0x00,
+	// 562 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x95, 0xd1, 0x8e, 0x93, 0x4c,
+	0x14, 0xc7, 0x97, 0x61, 0x68, 0xe9, 0x59, 0x76, 0x43, 0x26, 0x5f, 0x36, 0x93, 0x5e, 0xf0, 0xe1,
+	0x5e, 0x11, 0x2f, 0x1a, 0xd3, 0x4b, 0x8d, 0x26, 0xed, 0x42, 0x2b, 0x89, 0x4b, 0xc9, 0x94, 0x7d,
+	0x80, 0x36, 0x20, 0x4b, 0x36, 0x65, 0x08, 0xb0, 0x71, 0x7d, 0x13, 0x1f, 0xc3, 0x7b, 0x5f, 0xc0,
+	0x4b, 0x1f, 0xc1, 0xd4, 0x0b, 0x7d, 0x0c, 0xc3, 0x40, 0xd9, 0x1a, 0x5b, 0x57, 0x37, 0x7a, 0x61,
+	0xe2, 0x55, 0xe7, 0x9c, 0xfc, 0x7f, 0x33, 0xd3, 0x5f, 0x38, 0x00, 0xda, 0x75, 0x98, 0xad, 0x8a,
+	0x78, 0x90, 0xe5, 0xbc, 0xe4, 0xa4, 0xb7, 0xa9, 0x96, 0xa7, 0xef, 0x8e, 0x01, 0x2e, 0xc2, 0xec,
+	0x3c, 0x2a, 0x8a, 0x45, 0x1c, 0x91, 0x27, 0xd0, 0x5d, 0x15, 0x71, 0xf0, 0x3a, 0x8b, 0xa8, 0x64,
+	0x22, 0xeb, 0x78, 0xf8, 0x60, 0xd0, 0x66, 0x07, 0xb7, 0xb9, 0x41, 0xf3, 0x5b, 0x05, 0xd9, 0x86,
+	0x20, 0x03, 0xc0, 0x59, 0x92, 0xc6, 0x14, 0x99, 0x92, 0x75, 0x38, 0xec, 0xef, 0x26, 0xfd, 0x24,
+	0x8d, 0x99, 0xc8, 0x89, 0x3c, 0x4f, 0x63, 0x2a, 0xff, 0x30, 0xcf, 0x45, 0x9e, 0xa7, 0x31, 0x79,
+	0x0c, 0xea, 0xcb, 0x24, 0x0d, 0x3d, 0x1e, 0x46, 0x14, 0x0b, 0xc6, 0xd8, 0xcd, 0x4c, 0x9a, 0x14,
+	0x6b, 0xf3, 0xe4, 0x29, 0xf4, 0xd2, 0x28, 0x89, 0x2f, 0x97, 0x3c, 0x2f, 0xa8, 0x22, 0xe0, 0xff,
+	0x77, 0xc3, 0xde, 0x26, 0xc6, 0x6e, 0x89, 0xbe, 0x01, 0xda, 0xfc, 0x7a, 0xe9, 0x45, 0xe5, 0x2b,
+	0x9e, 0x5f, 0xb9, 0x36, 0x39, 0x06, 0xe4, 0x86, 0x42, 0x91, 0xc6, 0x90, 0x1b, 0xf6, 0x2f, 0x01,
+	0x8b, 0x63, 0xaa, 0xbe, 0xdf, 0xf6, 0x7d, 0xa2, 0x83, 0x7c, 0x61, 0xfb, 0x14, 0x99, 0xc8, 0x3a,
+	0x62, 0xd5, 0xb2, 0xea, 0x04, 0x67, 0x3e, 0x95, 0xeb, 0x4e, 0x70, 0xe6, 0x93, 0x13, 0xe8, 0x54,
+	0xac, 0x1b, 0x52, 0x2c, 0xb8, 0xa6, 0x22, 0xff, 0x81, 0x32, 0x0a, 0xc3, 0xe6, 0xba, 0x1a, 0xab,
+	0x8b, 0xfe, 0x5b, 0x04, 0xd8, 0x6f, 0xec, 0x4d, 0x72, 0xbe, 0x12, 0x87, 0xed, 0xb5, 0x27, 0x2c,
+	0x88, 0x1c, 0x79, 0x08, 0x28, 0xe0, 0x14, 0xdd, 0x99, 0x46, 0x01, 0x27, 0x13, 0xd0, 0x2a, 0xa6,
+	0xfe, 0xcb, 0x6e, 0x48, 0x65, 0x53, 0xb6, 0x0e, 0x87, 0xa7, 0xbb, 0xa9, 0x6d, 0x31, 0xec, 0x1b,
+	0x8e, 0x3c, 0x03, 0xb5, 0xdd, 0x03, 0x9b, 0xe8, 0x27, 0xf7, 0x68, 0x99, 0x46, 0xb3, 0x62, 0x22,
+	0x0b, 0x57, 0x9a, 0x89, 0x01, 0xe0, 0xdc, 0x64, 0x49, 0xbe, 0x28, 0x13, 0x9e, 0xd2, 0x8e, 0x29,
+	0x59, 0x98, 0x6d, 0x75, 0x2a, 0x65, 0xce, 0x4d, 0x99, 0x2f, 0x68, 0xb7, 0x56, 0x26, 0x8a, 0x5a,
+	0x19, 0xff, 0xa7, 0xec, 0x17, 0x94, 0x7d, 0x41, 0xa0, 0x6e, 0xa6, 0xe8, 0x8f, 0x6a, 0xeb, 0x83,
+	0x7a, 0xbe, 0x28, 0xae, 0xc6, 0x49, 0x59, 0x88, 0x99, 0x50, 0x58, 0x5b, 0x7f, 0xa7, 0x14, 0xff,
+	0x06, 0xa5, 0xca, 0x3d, 0x94, 0x9e, 0x40, 0x27, 0x58, 0xe4, 0x71, 0x54, 0xd2, 0x4e, 0x3d, 0xa0,
+	0x75, 0xd5, 0xa8, 0xee, 0xee, 0x51, 0xad, 0xee, 0x57, 0xdd, 0xdb, 0x56, 0xfd, 0x19, 0x41, 0xaf,
+	0x7d, 0xe7, 0xfc, 0x75, 0x8f, 0xe8, 0x7d, 0x7c, 0x3e, 0x02, 0xa5, 0xba, 0x53, 0x41, 0x3b, 0xa6,
+	0x7c, 0xc7, 0xb5, 0xeb, 0x60, 0x63, 0x1a, 0xef, 0x31, 0xdd, 0xdd, 0x6f, 0x5a, 0xdd, 0x32, 0x7d,
+	0xea, 0xc3, 0xe1, 0xd6, 0x77, 0x8b, 0xa8, 0x80, 0x7d, 0xd7, 0x9b, 0xea, 0x07, 0x62, 0x35, 0xf3,
+	0xa6, 0xba, 0x44, 0x34, 0x50, 0x27, 0xae, 0x67, 0x7b, 0x33, 0xdb, 0xd1, 0x11, 0x39, 0x82, 0x9e,
+	0xe7, 0xb8, 0xd3, 0xe7, 0xe3, 0x19, 0x9b, 0xeb, 0x32, 0xd1, 0x41, 0x9b, 0x07, 0xa3, 0x17, 0xce,
+	0xc8, 0xb6, 0x99, 0x33, 0x9f, 0xeb, 0x78, 0xac, 0xbf, 0x5f, 0x1b, 0xd2, 0x87, 0xb5, 0x21, 0x7d,
+	0x5c, 0x1b, 0xd2, 0x9b, 0x4f, 0xc6, 0xc1, 0xd7, 0x01, 0x00, 0x83, 0x52, 0xac, 0xaa, 0x69, 0x07,
+	0x00, 0x00,
 }
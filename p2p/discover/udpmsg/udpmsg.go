@@ -55,9 +55,10 @@ type (
 
 	// Node: endpoint with node identity
 	Node struct {
-		IP       net.IP        // ip address
-		UDP, TCP uint16        // udp port number
-		NodeId   config.NodeID // node identity
+		IP       net.IP            // ip address
+		UDP, TCP uint16            // udp port number
+		NodeId   config.NodeID     // node identity
+		Addrs    []config.Endpoint // more endpoints of a multi-homed node
 	}
 
 	//
@@ -261,11 +262,13 @@ func (pum *UdpMsg) GetPing() interface{} {
 	ping.From.TCP = uint16(*pbPing.From.TCP)
 	ping.From.UDP = uint16(*pbPing.From.UDP)
 	copy(ping.From.NodeId[:], pbPing.From.NodeId)
+	ping.From.Addrs = decodeAddrs(pbPing.From.Addrs)
 
 	ping.To.IP = append(ping.To.IP, pbPing.To.IP...)
 	ping.To.TCP = uint16(*pbPing.To.TCP)
 	ping.To.UDP = uint16(*pbPing.To.UDP)
 	copy(ping.To.NodeId[:], pbPing.To.NodeId)
+	ping.To.Addrs = decodeAddrs(pbPing.To.Addrs)
 
 	for _, snid := range pbPing.FromSubNetId {
 		var id SubNetworkID
@@ -294,11 +297,13 @@ func (pum *UdpMsg) GetPong() interface{} {
 	pong.From.TCP = uint16(*pbPong.From.TCP)
 	pong.From.UDP = uint16(*pbPong.From.UDP)
 	copy(pong.From.NodeId[:], pbPong.From.NodeId)
+	pong.From.Addrs = decodeAddrs(pbPong.From.Addrs)
 
 	pong.To.IP = append(pong.To.IP, pbPong.To.IP...)
 	pong.To.TCP = uint16(*pbPong.To.TCP)
 	pong.To.UDP = uint16(*pbPong.To.UDP)
 	copy(pong.To.NodeId[:], pbPong.To.NodeId)
+	pong.To.Addrs = decodeAddrs(pbPong.To.Addrs)
 
 	for _, snid := range pbPong.FromSubNetId {
 		var id SubNetworkID
@@ -327,11 +332,13 @@ func (pum *UdpMsg) GetFindNode() interface{} {
 	fn.From.TCP = uint16(*pbFN.From.TCP)
 	fn.From.UDP = uint16(*pbFN.From.UDP)
 	copy(fn.From.NodeId[:], pbFN.From.NodeId)
+	fn.From.Addrs = decodeAddrs(pbFN.From.Addrs)
 
 	fn.To.IP = append(fn.To.IP, pbFN.To.IP...)
 	fn.To.TCP = uint16(*pbFN.To.TCP)
 	fn.To.UDP = uint16(*pbFN.To.UDP)
 	copy(fn.To.NodeId[:], pbFN.To.NodeId)
+	fn.To.Addrs = decodeAddrs(pbFN.To.Addrs)
 
 	for _, snid := range pbFN.FromSubNetId {
 		var id SubNetworkID
@@ -363,11 +370,13 @@ func (pum *UdpMsg) GetNeighbors() interface{} {
 	ngb.From.TCP = uint16(*pbNgb.From.TCP)
 	ngb.From.UDP = uint16(*pbNgb.From.UDP)
 	copy(ngb.From.NodeId[:], pbNgb.From.NodeId)
+	ngb.From.Addrs = decodeAddrs(pbNgb.From.Addrs)
 
 	ngb.To.IP = append(ngb.To.IP, pbNgb.To.IP...)
 	ngb.To.TCP = uint16(*pbNgb.To.TCP)
 	ngb.To.UDP = uint16(*pbNgb.To.UDP)
 	copy(ngb.To.NodeId[:], pbNgb.To.NodeId)
+	ngb.To.Addrs = decodeAddrs(pbNgb.To.Addrs)
 
 	for _, snid := range pbNgb.FromSubNetId {
 		var id SubNetworkID
@@ -388,12 +397,38 @@ func (pum *UdpMsg) GetNeighbors() interface{} {
 		pn.TCP = uint16(*n.TCP)
 		pn.UDP = uint16(*n.UDP)
 		copy(pn.NodeId[:], n.NodeId)
+		pn.Addrs = decodeAddrs(n.Addrs)
 		ngb.Nodes[idx] = pn
 	}
 
 	return ngb
 }
 
+//
+// Decode endpoint list of node, a bad list is ignored since the primary
+// endpoint is still usable
+//
+func decodeAddrs(buf []byte) []config.Endpoint {
+	eps, err := config.P2pDecodeEndpoints(buf)
+	if err != nil {
+		log.Debugf("decodeAddrs: %s", err.Error())
+		return nil
+	}
+	return eps
+}
+
+//
+// Get the best endpoint of node to reach it from local node
+//
+func (n *Node) BestEndpoint() config.Endpoint {
+	return config.P2pBestEndpoint(&config.Node{
+		IP:    n.IP,
+		UDP:   n.UDP,
+		TCP:   n.TCP,
+		Addrs: n.Addrs,
+	})
+}
+
 //
 // Check decoded message with endpoint where the message from
 //
@@ -402,22 +437,27 @@ func (pum *UdpMsg) CheckUdpMsgFromPeer(from *net.UDPAddr, chkAddr bool) UdpMsgEr
 		return UdpMsgEnoNone
 	}
 
-	var ipv4 = net.IPv4zero
+	var reported *pb.UdpMessage_Node
 	if *pum.Msg.MsgType == pb.UdpMessage_PING {
-		ipv4 = net.IP(pum.Msg.Ping.From.IP).To4()
+		reported = pum.Msg.Ping.From
 	} else if *pum.Msg.MsgType == pb.UdpMessage_PONG {
-		ipv4 = net.IP(pum.Msg.Pong.From.IP).To4()
+		reported = pum.Msg.Pong.From
 	} else if *pum.Msg.MsgType == pb.UdpMessage_FINDNODE {
-		ipv4 = net.IP(pum.Msg.FindNode.From.IP).To4()
+		reported = pum.Msg.FindNode.From
 	} else if *pum.Msg.MsgType == pb.UdpMessage_NEIGHBORS {
-		ipv4 = net.IP(pum.Msg.Neighbors.From.IP).To4()
+		reported = pum.Msg.Neighbors.From
 	} else {
 		log.Debugf("CheckUdpMsgFromPeer: invalid message type: %d", *pum.Msg.MsgType)
 		return UdpMsgEnoMessage
 	}
 
-	if !ipv4.Equal(from.IP.To4()) {
-		log.Debugf("CheckUdpMsgFromPeer: address mitched, source: %s, reported: %s", from.IP.String(), ipv4.String())
+	// a multi-homed node might send from any of the endpoints it reports
+	n := config.Node{
+		IP:    reported.IP,
+		Addrs: decodeAddrs(reported.Addrs),
+	}
+	if !n.HasIP(from.IP) {
+		log.Debugf("CheckUdpMsgFromPeer: address mitched, source: %s, reported: %s", from.IP.String(), n.IP.String())
 		return UdpMsgEnoMessage
 	}
 	return UdpMsgEnoNone
@@ -498,6 +538,7 @@ func (pum *UdpMsg) EncodePing(ping *Ping) UdpMsgErrno {
 	*pbPing.From.TCP = uint32(ping.From.TCP)
 	*pbPing.From.UDP = uint32(ping.From.UDP)
 	pbPing.From.NodeId = append(pbPing.From.NodeId, ping.From.NodeId[:]...)
+	pbPing.From.Addrs = config.P2pEncodeEndpoints(ping.From.Addrs)
 
 	pbPing.To = new(pb.UdpMessage_Node)
 	pbPing.To.UDP = new(uint32)
@@ -507,6 +548,7 @@ func (pum *UdpMsg) EncodePing(ping *Ping) UdpMsgErrno {
 	*pbPing.To.TCP = uint32(ping.To.TCP)
 	*pbPing.To.UDP = uint32(ping.To.UDP)
 	pbPing.To.NodeId = append(pbPing.To.NodeId, ping.To.NodeId[:]...)
+	pbPing.To.Addrs = config.P2pEncodeEndpoints(ping.To.Addrs)
 
 	for _, snid := range ping.FromSubNetId {
 		pbSnid := new(pb.UdpMessage_SubNetworkID)
@@ -562,6 +604,7 @@ func (pum *UdpMsg) EncodePong(pong *Pong) UdpMsgErrno {
 	*pbPong.From.TCP = uint32(pong.From.TCP)
 	*pbPong.From.UDP = uint32(pong.From.UDP)
 	pbPong.From.NodeId = append(pbPong.From.NodeId, pong.From.NodeId[:]...)
+	pbPong.From.Addrs = config.P2pEncodeEndpoints(pong.From.Addrs)
 
 	pbPong.To = new(pb.UdpMessage_Node)
 	pbPong.To.UDP = new(uint32)
@@ -571,6 +614,7 @@ func (pum *UdpMsg) EncodePong(pong *Pong) UdpMsgErrno {
 	*pbPong.To.TCP = uint32(pong.To.TCP)
 	*pbPong.To.UDP = uint32(pong.To.UDP)
 	pbPong.To.NodeId = append(pbPong.To.NodeId, pong.To.NodeId[:]...)
+	pbPong.To.Addrs = config.P2pEncodeEndpoints(pong.To.Addrs)
 
 	for _, snid := range pong.FromSubNetId {
 		pbSnid := new(pb.UdpMessage_SubNetworkID)
@@ -653,11 +697,13 @@ func (pum *UdpMsg) EncodeFindNode(fn *FindNode) UdpMsgErrno {
 	*pbFN.From.TCP = uint32(fn.From.TCP)
 	*pbFN.From.UDP = uint32(fn.From.UDP)
 	pbFN.From.NodeId = append(pbFN.From.NodeId, fn.From.NodeId[:]...)
+	pbFN.From.Addrs = config.P2pEncodeEndpoints(fn.From.Addrs)
 
 	pbFN.To.IP = append(pbFN.To.IP, fn.To.IP...)
 	*pbFN.To.TCP = uint32(fn.To.TCP)
 	*pbFN.To.UDP = uint32(fn.To.UDP)
 	pbFN.To.NodeId = append(pbFN.To.NodeId, fn.To.NodeId[:]...)
+	pbFN.To.Addrs = config.P2pEncodeEndpoints(fn.To.Addrs)
 
 	for _, snid := range fn.FromSubNetId {
 		pbSnid := new(pb.UdpMessage_SubNetworkID)
@@ -712,6 +758,7 @@ func (pum *UdpMsg) EncodeNeighbors(ngb *Neighbors) UdpMsgErrno {
 	*pbNgb.From.TCP = uint32(ngb.From.TCP)
 	*pbNgb.From.UDP = uint32(ngb.From.UDP)
 	pbNgb.From.NodeId = append(pbNgb.From.NodeId, ngb.From.NodeId[:]...)
+	pbNgb.From.Addrs = config.P2pEncodeEndpoints(ngb.From.Addrs)
 
 	pbNgb.To = new(pb.UdpMessage_Node)
 	pbNgb.To.TCP = new(uint32)
@@ -721,6 +768,7 @@ func (pum *UdpMsg) EncodeNeighbors(ngb *Neighbors) UdpMsgErrno {
 	*pbNgb.To.TCP = uint32(ngb.To.TCP)
 	*pbNgb.To.UDP = uint32(ngb.To.UDP)
 	pbNgb.To.NodeId = append(pbNgb.To.NodeId, ngb.To.NodeId[:]...)
+	pbNgb.To.Addrs = config.P2pEncodeEndpoints(ngb.To.Addrs)
 
 	for _, snid := range ngb.FromSubNetId {
 		pbSnid := new(pb.UdpMessage_SubNetworkID)
@@ -751,6 +799,7 @@ func (pum *UdpMsg) EncodeNeighbors(ngb *Neighbors) UdpMsgErrno {
 		*nn.TCP = uint32(n.TCP)
 		*nn.UDP = uint32(n.UDP)
 		nn.NodeId = append(nn.NodeId, n.NodeId[:]...)
+		nn.Addrs = config.P2pEncodeEndpoints(n.Addrs)
 
 		pbNgb.Nodes[idx] = nn
 	}
@@ -21,11 +21,13 @@
 package p2p
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/yeeco/gyee/log"
@@ -34,6 +36,7 @@ import (
 )
 
 type InmemService struct {
+	id               string
 	subscribers      *sync.Map
 	hub              *InmemHub
 	receiveMessageCh chan Message
@@ -51,8 +54,11 @@ type InmemService struct {
 	inMiss   int
 }
 
+var inmemNodeSeq uint32
+
 func NewInmemService() (*InmemService, error) {
 	is := &InmemService{
+		id:               fmt.Sprintf("inmem%d", atomic.AddUint32(&inmemNodeSeq, 1)),
 		subscribers:      new(sync.Map),
 		hub:              GetInmemHub(),
 		receiveMessageCh: make(chan Message),
@@ -173,6 +179,11 @@ func (is *InmemService) DhtSetValue(key []byte, value []byte) error {
 	return is.hub.SetValue(key, value)
 }
 
+// records are not validated in memory, extra ignored
+func (is *InmemService) DhtSetRecord(key []byte, value []byte, extra []byte) error {
+	return is.DhtSetValue(key, value)
+}
+
 func (is *InmemService) Reconfig(reCfg *RecfgCommand) error {
 	return nil
 }
@@ -181,8 +192,79 @@ func (is *InmemService) RegChainProvider(cp ChainProvider) {
 	is.cp = cp
 }
 
-func (is *InmemService) GetChainInfo(kind string, key []byte) ([]byte, error) {
-	return is.hub.getChainInfo(is, kind, key)
+func (is *InmemService) GetStatus(ctx context.Context, peer string) (*ChainResponse, error) {
+	return is.hub.chainRequest(ctx, is, peer, func(cp ChainProvider) *ChainResponse {
+		return &ChainResponse{Status: cp.ChainStatus()}
+	})
+}
+
+func (is *InmemService) GetHeaders(ctx context.Context, peer string, from uint64, count int) (*ChainResponse, error) {
+	if count > ChainMaxHeaders {
+		return nil, ErrChainTooMany
+	}
+	return is.hub.chainRequest(ctx, is, peer, func(cp ChainProvider) *ChainResponse {
+		return &ChainResponse{Items: cp.ChainHeaders(from, count)}
+	})
+}
+
+func (is *InmemService) GetBlocks(ctx context.Context, peer string, hashes [][]byte) (*ChainResponse, error) {
+	if len(hashes) > ChainMaxBlocks {
+		return nil, ErrChainTooMany
+	}
+	return is.hub.chainRequest(ctx, is, peer, func(cp ChainProvider) *ChainResponse {
+		return &ChainResponse{Items: cp.ChainBlocks(hashes)}
+	})
+}
+
+func (is *InmemService) GetTxs(ctx context.Context, peer string, hashes [][]byte) (*ChainResponse, error) {
+	if len(hashes) > ChainMaxTxs {
+		return nil, ErrChainTooMany
+	}
+	return is.hub.chainRequest(ctx, is, peer, func(cp ChainProvider) *ChainResponse {
+		return &ChainResponse{Items: cp.ChainTxs(hashes)}
+	})
+}
+
+func (is *InmemService) GetProof(ctx context.Context, peer string, root, key []byte) (*ChainResponse, error) {
+	return is.hub.chainRequest(ctx, is, peer, func(cp ChainProvider) *ChainResponse {
+		return &ChainResponse{Items: cp.ChainProof(root, key)}
+	})
+}
+
+func (is *InmemService) ReportPeer(nodeID string, offence string, severity int) error {
+	return nil
+}
+
+func (is *InmemService) ListBans() []PeerBan {
+	return nil
+}
+
+func (is *InmemService) ClearBans(nodeID string) (int, error) {
+	return 0, nil
+}
+
+func (is *InmemService) PeerBandwidth() []Bandwidth {
+	return nil
+}
+
+func (is *InmemService) SubnetBandwidth() []Bandwidth {
+	return nil
+}
+
+func (is *InmemService) AddPeer(node string, trusted bool) error {
+	return nil
+}
+
+func (is *InmemService) RemovePeer(nodeID string) error {
+	return nil
+}
+
+func (is *InmemService) ListPeers() ([]PeerInfo, error) {
+	return nil, nil
+}
+
+func (is *InmemService) SetValidators(validators []string, self string, sign func(hash []byte) ([]byte, error)) error {
+	return nil
 }
 
 //Inmem Hub for all InmemService
@@ -267,17 +349,23 @@ func (ih *InmemHub) SetValue(key []byte, value []byte) error {
 	return nil
 }
 
-func (ih *InmemHub) getChainInfo(node *InmemService, kind string, key []byte) ([]byte, error) {
+func (ih *InmemHub) chainRequest(ctx context.Context, node *InmemService, peer string,
+	answer func(cp ChainProvider) *ChainResponse) (*ChainResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	ih.lock.RLock()
 	defer ih.lock.RUnlock()
 	for n := range ih.nodes {
-		if node == n {
+		if node == n || n.cp == nil {
 			continue
 		}
-		value := n.cp.GetChainData(kind, key)
-		if len(value) > 0 {
-			return value, nil
+		if len(peer) > 0 && peer != n.id {
+			continue
 		}
+		rsp := answer(n.cp)
+		rsp.From = n.id
+		return rsp, nil
 	}
-	return nil, errors.New("not found")
+	return nil, ErrChainNoPeer
 }
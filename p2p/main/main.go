@@ -26,6 +26,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"net/http"
@@ -1033,9 +1034,40 @@ func testCase17(tc *testCase) {
 type testChainProvider struct {
 }
 
-func (cp testChainProvider)GetChainData(kind string, key []byte) []byte {
-	data := []byte(fmt.Sprintf("kind: %s, key: %x", kind, key))
-	return data
+func (cp testChainProvider)ChainStatus() *yep2p.ChainStatus {
+	head := sha256.Sum256([]byte(fmt.Sprintf("%s", time.Now())))
+	return &yep2p.ChainStatus{
+		Head: head[0:],
+		Height: uint64(time.Now().Unix()),
+	}
+}
+
+func (cp testChainProvider)ChainHeaders(from uint64, count int) [][]byte {
+	items := make([][]byte, 0, count)
+	for n := from; n < from + uint64(count); n++ {
+		items = append(items, []byte(fmt.Sprintf("header: %d", n)))
+	}
+	return items
+}
+
+func (cp testChainProvider)ChainBlocks(hashes [][]byte) [][]byte {
+	items := make([][]byte, 0, len(hashes))
+	for _, h := range hashes {
+		items = append(items, []byte(fmt.Sprintf("block: %x", h)))
+	}
+	return items
+}
+
+func (cp testChainProvider)ChainTxs(hashes [][]byte) [][]byte {
+	items := make([][]byte, 0, len(hashes))
+	for _, h := range hashes {
+		items = append(items, []byte(fmt.Sprintf("tx: %x", h)))
+	}
+	return items
+}
+
+func (cp testChainProvider)ChainProof(root, key []byte) [][]byte {
+	return [][]byte{[]byte(fmt.Sprintf("proof: %x %x", root, key))}
 }
 
 func testCase18(tc *testCase) {
@@ -1048,11 +1080,16 @@ func testCase18(tc *testCase) {
 	yeShMgr.Start()
 
 	quitCh := make(chan bool, 0)
-	getChainInfo := func() ([]byte, error){
-		kind := fmt.Sprintf("%s", time.Now())
-		key := sha256.Sum256([]byte(kind))
-		log.Debug("testCase18: call GetChainInfo ...")
-		return yeShMgr.GetChainInfo(kind, key[0:])
+	getChainInfo := func() (*yep2p.ChainResponse, error){
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second * 8)
+		defer cancel()
+		log.Debug("testCase18: call GetStatus ...")
+		st, err := yeShMgr.GetStatus(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("testCase18: call GetBlocks ...")
+		return yeShMgr.GetBlocks(ctx, st.From, [][]byte{st.Status.Head})
 	}
 	cp := testChainProvider{}
 	yeShMgr.RegChainProvider(&cp)
@@ -1067,7 +1104,7 @@ func testCase18(tc *testCase) {
 				if ci, err := getChainInfo(); err != nil {
 					log.Debug("testCase18: failed, error: %s", err.Error())
 				} else {
-					log.Debug("testCase18: ok, chainInfo: %x", ci)
+					log.Debug("testCase18: ok, peer: %s, items: %d", ci.From, len(ci.Items))
 				}
 				tm.Reset(cycle)
 			case <-quitCh:
@@ -25,6 +25,7 @@
 package p2p
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
@@ -65,16 +66,28 @@ func OsnServiceConfig(cfg *YeShellConfig, cfgFromFie interface{}) error {
 	//
 	// DhtBootstrapNodes	[]string			dht部分的bootstrap节点列表；
 	//
+	// ReservedNodes		[]string			保留节点列表，格式同BootstrapNodes，总是保持连接，
+	//											且不受连接数限制；
+	//
+	// TrustedNodes			[]string			信任节点列表，格式同BootstrapNodes，接受其连接，
+	//											且不受连接数限制；
+	//
 	// LocalNodeIp			string				本地peer部分的IP地址
 	//
 	// LocalUdpPort			uint16				本地peer部分的UDP端口
 	//
 	// LocalTcpPort			uint16				本地peer部分的TCP端口
 	//
+	// LocalAddrs			[]string			本地peer部分的其它IP地址（多宿主节点，IPv4或IPv6），
+	//											端口同LocalUdpPort和LocalTcpPort；
+	//
 	// LocalDhtIp			string				本地dht部分的IP地址
 	//
 	// LocalDhtPort			uint16				本地dht部分的TCP端口
 	//
+	// LocalDhtAddrs		[]string			本地dht部分的其它IP地址（多宿主节点，IPv4或IPv6），
+	//											端口同LocalDhtPort；
+	//
 	// NodeDataDir			string				本次实例的数据目录；
 	//
 	// NodeDatabase			string				本次实例的leveldb数据库名称（数据库所在目录）；
@@ -93,6 +106,15 @@ func OsnServiceConfig(cfg *YeShellConfig, cfgFromFie interface{}) error {
 	//
 	// GatewayIp			string				当nat类型配置为"pmp"的时候相应的网关IP地址
 	//
+	// Encryption			string				传输加密模式："off", "optional", "required"，
+	//											缺省为"required"；"optional"时与不支持加密的
+	//											节点以明文通信，仅用于升级过渡；
+	//
+	// RateLimits			map[string]config.RateLimit
+	//											与单个节点间的流量限制（每秒消息数及字节数，0为不限），
+	//											以消息类型"tx", "ev", "blkH", "blk"为键，"peer"
+	//											表示与该节点间的全部流量；
+	//
 	// 注：如前所述，本函数应由应用根据具体情况（cfgFromFie的结构设计）实现并调用，但这不是必须的，应用
 	// 可以用任何方法构造合理的YeShellConfig结构，然后调用NewOsnService得到服务实例。
 	//
@@ -124,6 +146,8 @@ func OsnServiceConfig(cfg *YeShellConfig, cfgFromFie interface{}) error {
 	cfg.BootstrapNodes = append(cfg.BootstrapNodes, p2p.BootstrapNodes...)
 	cfg.DhtBootstrapNodes = make([]string, 0)
 	cfg.DhtBootstrapNodes = append(cfg.DhtBootstrapNodes, p2p.DhtBootstrapNodes...)
+	cfg.ReservedNodes = append([]string{}, p2p.ReservedNodes...)
+	cfg.TrustedNodes = append([]string{}, p2p.TrustedNodes...)
 
 	if len(p2p.LocalNodeIp) > 0 {
 		cfg.LocalNodeIp = p2p.LocalNodeIp
@@ -137,6 +161,8 @@ func OsnServiceConfig(cfg *YeShellConfig, cfgFromFie interface{}) error {
 		cfg.LocalTcpPort = p2p.LocalTcpPort
 	}
 
+	cfg.LocalAddrs = append([]string{}, p2p.LocalAddrs...)
+
 	if len(p2p.LocalDhtIp) > 0 {
 		cfg.LocalDhtIp = p2p.LocalDhtIp
 	}
@@ -145,6 +171,8 @@ func OsnServiceConfig(cfg *YeShellConfig, cfgFromFie interface{}) error {
 		cfg.LocalDhtPort = p2p.LocalDhtPort
 	}
 
+	cfg.LocalDhtAddrs = append([]string{}, p2p.LocalDhtAddrs...)
+
 	if len(p2p.NodeDataDir) == 0 {
 		yeelog.Logger.Infof("OsnServiceConfig: default NodeDataDir: %s", cfg.NodeDataDir)
 	} else {
@@ -157,6 +185,33 @@ func OsnServiceConfig(cfg *YeShellConfig, cfgFromFie interface{}) error {
 		cfg.NodeDatabase = p2p.NodeDatabase
 	}
 
+	if len(p2p.Encryption) == 0 {
+		yeelog.Logger.Infof("OsnServiceConfig: default Encryption: %s", cfg.Encryption)
+	} else if !config.P2pIsValidEncryption(p2p.Encryption) {
+		log.Errorf("OsnServiceConfig: invalid encryption mode: %s", p2p.Encryption)
+		return errors.New("OsnServiceConfig: invalid encryption mode")
+	} else {
+		cfg.Encryption = p2p.Encryption
+	}
+
+	rateLimits := make(map[string]config.RateLimit, 0)
+	for mt, rl := range cfg.RateLimits {
+		rateLimits[mt] = rl
+	}
+	for mt, rl := range p2p.RateLimits {
+		if _, ok := yesMtAtoi[mt]; !ok && mt != RateLimitPeer {
+			log.Errorf("OsnServiceConfig: invalid rate limit type: %s", mt)
+			return errors.New("OsnServiceConfig: invalid rate limit type")
+		}
+		rateLimits[mt] = config.RateLimit{
+			RxMsgs:  rl.RxMsgs,
+			RxBytes: rl.RxBytes,
+			TxMsgs:  rl.TxMsgs,
+			TxBytes: rl.TxBytes,
+		}
+	}
+	cfg.RateLimits = rateLimits
+
 	yeelog.Logger.Infof("OsnServiceConfig: node[%s:%d:%d]", cfg.LocalNodeIp, cfg.LocalUdpPort, cfg.LocalTcpPort)
 	yeelog.Logger.Infof("OsnServiceConfig: dht[%s:%d]", cfg.LocalDhtIp, cfg.LocalDhtPort)
 
@@ -252,12 +307,68 @@ func (osns *OsnService) DhtSetValue(key []byte, value []byte) error {
 	return osns.yeShMgr.DhtSetValue(key, value)
 }
 
+func (osns *OsnService) DhtSetRecord(key []byte, value []byte, extra []byte) error {
+	return osns.yeShMgr.DhtSetRecord(key, value, extra)
+}
+
 func (osns *OsnService) RegChainProvider(cp ChainProvider) {
 	osns.yeShMgr.RegChainProvider(cp)
 }
 
-func (osns *OsnService) GetChainInfo(kind string, key []byte) ([]byte, error) {
-	return osns.yeShMgr.GetChainInfo(kind, key)
+func (osns *OsnService) GetStatus(ctx context.Context, peer string) (*ChainResponse, error) {
+	return osns.yeShMgr.GetStatus(ctx, peer)
+}
+
+func (osns *OsnService) GetHeaders(ctx context.Context, peer string, from uint64, count int) (*ChainResponse, error) {
+	return osns.yeShMgr.GetHeaders(ctx, peer, from, count)
+}
+
+func (osns *OsnService) GetBlocks(ctx context.Context, peer string, hashes [][]byte) (*ChainResponse, error) {
+	return osns.yeShMgr.GetBlocks(ctx, peer, hashes)
+}
+
+func (osns *OsnService) GetTxs(ctx context.Context, peer string, hashes [][]byte) (*ChainResponse, error) {
+	return osns.yeShMgr.GetTxs(ctx, peer, hashes)
+}
+
+func (osns *OsnService) GetProof(ctx context.Context, peer string, root, key []byte) (*ChainResponse, error) {
+	return osns.yeShMgr.GetProof(ctx, peer, root, key)
+}
+
+func (osns *OsnService) PeerBandwidth() []Bandwidth {
+	return osns.yeShMgr.PeerBandwidth()
+}
+
+func (osns *OsnService) SubnetBandwidth() []Bandwidth {
+	return osns.yeShMgr.SubnetBandwidth()
+}
+
+func (osns *OsnService) AddPeer(node string, trusted bool) error {
+	return osns.yeShMgr.AddPeer(node, trusted)
+}
+
+func (osns *OsnService) RemovePeer(nodeID string) error {
+	return osns.yeShMgr.RemovePeer(nodeID)
+}
+
+func (osns *OsnService) ListPeers() ([]PeerInfo, error) {
+	return osns.yeShMgr.ListPeers()
+}
+
+func (osns *OsnService) SetValidators(validators []string, self string, sign func(hash []byte) ([]byte, error)) error {
+	return osns.yeShMgr.SetValidators(validators, self, sign)
+}
+
+func (osns *OsnService) ReportPeer(nodeID string, offence string, severity int) error {
+	return osns.yeShMgr.ReportPeer(nodeID, offence, severity)
+}
+
+func (osns *OsnService) ListBans() []PeerBan {
+	return osns.yeShMgr.ListBans()
+}
+
+func (osns *OsnService) ClearBans(nodeID string) (int, error) {
+	return osns.yeShMgr.ClearBans(nodeID)
 }
 
 func (osns *OsnService) GetLocalNode() *config.Node {
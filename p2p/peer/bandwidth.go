@@ -0,0 +1,155 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package peer
+
+import (
+	config "github.com/yeeco/gyee/p2p/config"
+	"github.com/yeeco/gyee/p2p/ratelimit"
+)
+
+//
+// Flow control and bandwidth accounting of peer instances. the limit of all
+// traffic with a peer is applied by pacing the rx and tx routines of instance,
+// so a noisy peer is pushed back by tcp; the limits by message identity are
+// applied by dropping messages over the limits, so that messages of one kind
+// would not starve those of the others. packages of the p2p protocol, say ping
+// and pong, are accounted but never dropped.
+//
+
+//
+// Bandwidth of a peer instance, counters by message identity
+//
+type PeerBandwidth struct {
+	Snid   SubNetworkID                 // sub network identity
+	Dir    int                          // direction
+	NodeId config.NodeID                // peer node identity
+	Rx     map[uint32]ratelimit.Traffic // inbound traffic
+	Tx     map[uint32]ratelimit.Traffic // outbound traffic
+}
+
+//
+// Bandwidth of a sub network, counters by message identity, including those of
+// peers had been closed
+//
+type SubnetBandwidth struct {
+	Snid SubNetworkID                 // sub network identity
+	Rx   map[uint32]ratelimit.Traffic // inbound traffic
+	Tx   map[uint32]ratelimit.Traffic // outbound traffic
+}
+
+//
+// Size of package accounted for rate limits
+//
+func (upkg *P2pPackage) size() int {
+	return len(upkg.Key) + len(upkg.Payload)
+}
+
+func newMsgLimiters(limits map[uint32]config.RateLimit, rx bool) map[uint32]*ratelimit.Limiter {
+	lms := make(map[uint32]*ratelimit.Limiter, 0)
+	for mid, rl := range limits {
+		if lm := newLimiter(rl, rx); lm != nil {
+			lms[mid] = lm
+		}
+	}
+	return lms
+}
+
+func newLimiter(rl config.RateLimit, rx bool) *ratelimit.Limiter {
+	if rx {
+		return ratelimit.NewLimiter(ratelimit.Limit{Msgs: rl.RxMsgs, Bytes: rl.RxBytes})
+	}
+	return ratelimit.NewLimiter(ratelimit.Limit{Msgs: rl.TxMsgs, Bytes: rl.TxBytes})
+}
+
+//
+// Setup limiters and meter for instance, called before its rx and tx routines
+// started, when the sub network is known.
+//
+func (pi *PeerInstance) setupFlowControl() {
+	cfg := &pi.peMgr.cfg
+	pi.rxLimiter = newLimiter(cfg.peerRateLimit, true)
+	pi.txLimiter = newLimiter(cfg.peerRateLimit, false)
+	pi.rxMsgLimiters = newMsgLimiters(cfg.msgRateLimits, true)
+	pi.txMsgLimiters = newMsgLimiters(cfg.msgRateLimits, false)
+	pi.meter = pi.peMgr.bwAttach(pi)
+}
+
+//
+// Check if an inbound package could pass, it's accounted anyway
+//
+func (pi *PeerInstance) rxAllow(upkg *P2pPackage) bool {
+	pass := upkg.Pid != uint32(PID_EXT) || pi.rxMsgLimiters[upkg.Mid].Allow(upkg.size())
+	pi.meter.Rx(upkg.Mid, upkg.size(), !pass)
+	return pass
+}
+
+//
+// Check if an outbound package could pass, it's accounted anyway
+//
+func (pi *PeerInstance) txAllow(upkg *P2pPackage) bool {
+	pass := upkg.Pid != uint32(PID_EXT) || pi.txMsgLimiters[upkg.Mid].Allow(upkg.size())
+	pi.meter.Tx(upkg.Mid, upkg.size(), !pass)
+	return pass
+}
+
+func (peMgr *PeerManager) bwAttach(pi *PeerInstance) *ratelimit.Meter {
+	peMgr.bwLock.Lock()
+	defer peMgr.bwLock.Unlock()
+	snm, ok := peMgr.bwSubnets[pi.snid]
+	if !ok {
+		snm = ratelimit.NewMeter(nil)
+		peMgr.bwSubnets[pi.snid] = snm
+	}
+	m := ratelimit.NewMeter(snm)
+	peMgr.bwPeers[pi] = m
+	return m
+}
+
+func (peMgr *PeerManager) bwDetach(pi *PeerInstance) {
+	peMgr.bwLock.Lock()
+	delete(peMgr.bwPeers, pi)
+	peMgr.bwLock.Unlock()
+}
+
+//
+// Get bandwidth counters of active peers and of sub networks
+//
+func (peMgr *PeerManager) Bandwidth() ([]PeerBandwidth, []SubnetBandwidth) {
+	peMgr.bwLock.Lock()
+	defer peMgr.bwLock.Unlock()
+	peers := make([]PeerBandwidth, 0, len(peMgr.bwPeers))
+	for pi, m := range peMgr.bwPeers {
+		pb := PeerBandwidth{
+			Snid:   pi.snid,
+			Dir:    pi.dir,
+			NodeId: pi.node.ID,
+		}
+		pb.Rx, pb.Tx = m.Snapshot()
+		peers = append(peers, pb)
+	}
+	subnets := make([]SubnetBandwidth, 0, len(peMgr.bwSubnets))
+	for snid, m := range peMgr.bwSubnets {
+		sb := SubnetBandwidth{Snid: snid}
+		sb.Rx, sb.Tx = m.Snapshot()
+		subnets = append(subnets, sb)
+	}
+	return peers, subnets
+}
@@ -111,7 +111,9 @@ func (lsnMgr *ListenerManager) lsnMgrPoweron(ptn interface{}) sch.SchErrno {
 
 func (lsnMgr *ListenerManager) lsnMgrSetupListener() sch.SchErrno {
 	var err error
-	lsnAddr := fmt.Sprintf("0.0.0.0:%d", /*lsnMgr.cfg.IP.String(), */lsnMgr.cfg.Port)
+	// listen on all interfaces of both ipv4 and ipv6, a multi-homed node can
+	// be reached at any endpoint it advertises.
+	lsnAddr := fmt.Sprintf(":%d", lsnMgr.cfg.Port)
 	if lsnMgr.listener, err = net.Listen("tcp", lsnAddr); err != nil {
 		log.Crit("lsnMgrSetupListener: listen failed", "addr", lsnAddr, "err", err)
 		return sch.SchEnoOS
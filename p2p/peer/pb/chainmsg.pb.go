@@ -0,0 +1,1157 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: chainmsg.proto
+
+package tcpmsg_pb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	io "io"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ChainKind int32
+
+const (
+	ChainKind_CK_STATUS  ChainKind = 0
+	ChainKind_CK_HEADERS ChainKind = 1
+	ChainKind_CK_BLOCKS  ChainKind = 2
+	ChainKind_CK_TXS     ChainKind = 3
+	ChainKind_CK_PROOF   ChainKind = 4
+)
+
+var ChainKind_name = map[int32]string{
+	0: "CK_STATUS",
+	1: "CK_HEADERS",
+	2: "CK_BLOCKS",
+	3: "CK_TXS",
+	4: "CK_PROOF",
+}
+
+var ChainKind_value = map[string]int32{
+	"CK_STATUS":  0,
+	"CK_HEADERS": 1,
+	"CK_BLOCKS":  2,
+	"CK_TXS":     3,
+	"CK_PROOF":   4,
+}
+
+func (x ChainKind) String() string {
+	return proto.EnumName(ChainKind_name, int32(x))
+}
+
+func (ChainKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8380b8d99192274c, []int{0}
+}
+
+type ChainRequest struct {
+	Version              uint32    `protobuf:"varint,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	Seq                  uint64    `protobuf:"varint,2,opt,name=Seq,proto3" json:"Seq,omitempty"`
+	Kind                 ChainKind `protobuf:"varint,3,opt,name=Kind,proto3,enum=tcpmsg.pb.ChainKind" json:"Kind,omitempty"`
+	From                 uint64    `protobuf:"varint,4,opt,name=From,proto3" json:"From,omitempty"`
+	Count                uint32    `protobuf:"varint,5,opt,name=Count,proto3" json:"Count,omitempty"`
+	Hashes               [][]byte  `protobuf:"bytes,6,rep,name=Hashes,proto3" json:"Hashes,omitempty"`
+	Root                 []byte    `protobuf:"bytes,7,opt,name=Root,proto3" json:"Root,omitempty"`
+	Key                  []byte    `protobuf:"bytes,8,opt,name=Key,proto3" json:"Key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ChainRequest) Reset()         { *m = ChainRequest{} }
+func (m *ChainRequest) String() string { return proto.CompactTextString(m) }
+func (*ChainRequest) ProtoMessage()    {}
+func (*ChainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8380b8d99192274c, []int{0}
+}
+func (m *ChainRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChainRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainRequest.Merge(m, src)
+}
+func (m *ChainRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainRequest proto.InternalMessageInfo
+
+func (m *ChainRequest) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ChainRequest) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *ChainRequest) GetKind() ChainKind {
+	if m != nil {
+		return m.Kind
+	}
+	return ChainKind_CK_STATUS
+}
+
+func (m *ChainRequest) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ChainRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ChainRequest) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func (m *ChainRequest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *ChainRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type ChainResponse struct {
+	Version              uint32    `protobuf:"varint,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	Seq                  uint64    `protobuf:"varint,2,opt,name=Seq,proto3" json:"Seq,omitempty"`
+	Kind                 ChainKind `protobuf:"varint,3,opt,name=Kind,proto3,enum=tcpmsg.pb.ChainKind" json:"Kind,omitempty"`
+	Genesis              []byte    `protobuf:"bytes,4,opt,name=Genesis,proto3" json:"Genesis,omitempty"`
+	Head                 []byte    `protobuf:"bytes,5,opt,name=Head,proto3" json:"Head,omitempty"`
+	Height               uint64    `protobuf:"varint,6,opt,name=Height,proto3" json:"Height,omitempty"`
+	Items                [][]byte  `protobuf:"bytes,7,rep,name=Items,proto3" json:"Items,omitempty"`
+	Error                string    `protobuf:"bytes,8,opt,name=Error,proto3" json:"Error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ChainResponse) Reset()         { *m = ChainResponse{} }
+func (m *ChainResponse) String() string { return proto.CompactTextString(m) }
+func (*ChainResponse) ProtoMessage()    {}
+func (*ChainResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8380b8d99192274c, []int{1}
+}
+func (m *ChainResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChainResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChainResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainResponse.Merge(m, src)
+}
+func (m *ChainResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChainResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainResponse proto.InternalMessageInfo
+
+func (m *ChainResponse) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ChainResponse) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *ChainResponse) GetKind() ChainKind {
+	if m != nil {
+		return m.Kind
+	}
+	return ChainKind_CK_STATUS
+}
+
+func (m *ChainResponse) GetGenesis() []byte {
+	if m != nil {
+		return m.Genesis
+	}
+	return nil
+}
+
+func (m *ChainResponse) GetHead() []byte {
+	if m != nil {
+		return m.Head
+	}
+	return nil
+}
+
+func (m *ChainResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ChainResponse) GetItems() [][]byte {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *ChainResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("tcpmsg.pb.ChainKind", ChainKind_name, ChainKind_value)
+	proto.RegisterType((*ChainRequest)(nil), "tcpmsg.pb.ChainRequest")
+	proto.RegisterType((*ChainResponse)(nil), "tcpmsg.pb.ChainResponse")
+}
+
+func init() { proto.RegisterFile("chainmsg.proto", fileDescriptor_8380b8d99192274c) }
+
+var fileDescriptor_8380b8d99192274c = []byte{
+	// 354 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0x3f, 0x6e, 0xea, 0x40,
+	0x10, 0x87, 0x59, 0x6c, 0x6c, 0x3c, 0x32, 0xc8, 0x5a, 0xa1, 0xa7, 0xad, 0x2c, 0x8b, 0xca, 0x7a,
+	0x05, 0xc5, 0x7b, 0x27, 0x00, 0x07, 0x42, 0xe4, 0x48, 0x44, 0x6b, 0x12, 0xa5, 0xb3, 0xf8, 0xb3,
+	0x02, 0x17, 0x78, 0xc1, 0xbb, 0x14, 0xb9, 0x49, 0x8e, 0x94, 0x22, 0x45, 0xba, 0xb4, 0x11, 0xb9,
+	0x48, 0xb4, 0x83, 0xe1, 0x06, 0xe9, 0x7e, 0xdf, 0x8c, 0x3d, 0x33, 0x9f, 0x65, 0xe8, 0xae, 0xb6,
+	0x8b, 0xa2, 0xdc, 0xa9, 0xcd, 0x60, 0x5f, 0x49, 0x2d, 0xa9, 0xa7, 0x57, 0x7b, 0xa4, 0x65, 0xff,
+	0x9d, 0x80, 0x9f, 0x98, 0x2e, 0x17, 0x87, 0xa3, 0x50, 0x9a, 0x32, 0x70, 0x9f, 0x44, 0xa5, 0x0a,
+	0x59, 0x32, 0x12, 0x91, 0xb8, 0xc3, 0x2f, 0x48, 0x03, 0xb0, 0x32, 0x71, 0x60, 0xcd, 0x88, 0xc4,
+	0x36, 0x37, 0x91, 0xc6, 0x60, 0xa7, 0x45, 0xb9, 0x66, 0x56, 0x44, 0xe2, 0xee, 0xbf, 0xde, 0xe0,
+	0x3a, 0x76, 0x80, 0x23, 0x4d, 0x8f, 0xe3, 0x13, 0x94, 0x82, 0x3d, 0xa9, 0xe4, 0x8e, 0xd9, 0xf8,
+	0x32, 0x66, 0xda, 0x83, 0x56, 0x22, 0x8f, 0xa5, 0x66, 0x2d, 0xdc, 0x73, 0x06, 0xfa, 0x07, 0x9c,
+	0xe9, 0x42, 0x6d, 0x85, 0x62, 0x4e, 0x64, 0xc5, 0x3e, 0xaf, 0xc9, 0x4c, 0xe0, 0x52, 0x6a, 0xe6,
+	0x46, 0x24, 0xf6, 0x39, 0x66, 0x73, 0x51, 0x2a, 0x5e, 0x58, 0x1b, 0x4b, 0x26, 0xf6, 0x3f, 0x09,
+	0x74, 0x6a, 0x1d, 0xb5, 0x97, 0xa5, 0x12, 0xbf, 0xe4, 0xc3, 0xc0, 0xbd, 0x15, 0xa5, 0x50, 0x85,
+	0x42, 0x25, 0x9f, 0x5f, 0xd0, 0xdc, 0x39, 0x15, 0x8b, 0x35, 0x4a, 0xf9, 0x1c, 0x33, 0x3a, 0x89,
+	0x62, 0xb3, 0xd5, 0xcc, 0xc1, 0x65, 0x35, 0x99, 0x2f, 0x70, 0xa7, 0xc5, 0x4e, 0x31, 0x17, 0x55,
+	0xcf, 0x60, 0xaa, 0xe3, 0xaa, 0x92, 0x15, 0x7a, 0x79, 0xfc, 0x0c, 0x7f, 0x33, 0xf0, 0xae, 0x47,
+	0xd0, 0x0e, 0x78, 0x49, 0x9a, 0x67, 0xf3, 0xe1, 0xfc, 0x31, 0x0b, 0x1a, 0xb4, 0x0b, 0x90, 0xa4,
+	0xf9, 0x74, 0x3c, 0xbc, 0x19, 0xf3, 0x2c, 0x20, 0x75, 0x7b, 0x74, 0x3f, 0x4b, 0xd2, 0x2c, 0x68,
+	0x52, 0x00, 0x27, 0x49, 0xf3, 0xf9, 0x73, 0x16, 0x58, 0xd4, 0x87, 0x76, 0x92, 0xe6, 0x0f, 0x7c,
+	0x36, 0x9b, 0x04, 0xf6, 0x28, 0x78, 0x3b, 0x85, 0xe4, 0xe3, 0x14, 0x92, 0xaf, 0x53, 0x48, 0x5e,
+	0xbf, 0xc3, 0xc6, 0xd2, 0xc1, 0x3f, 0xe4, 0xff, 0xcf, 0x00, 0x45, 0x52, 0x35, 0x56, 0x33, 0x02,
+	0x00, 0x00,
+}
+
+func (m *ChainRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.Version))
+	}
+	if m.Seq != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.Seq))
+	}
+	if m.Kind != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.Kind))
+	}
+	if m.From != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.From))
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.Count))
+	}
+	if len(m.Hashes) > 0 {
+		for _, b := range m.Hashes {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintChainmsg(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Root) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(len(m.Root)))
+		i += copy(dAtA[i:], m.Root)
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ChainResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.Version))
+	}
+	if m.Seq != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.Seq))
+	}
+	if m.Kind != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.Kind))
+	}
+	if len(m.Genesis) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(len(m.Genesis)))
+		i += copy(dAtA[i:], m.Genesis)
+	}
+	if len(m.Head) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(len(m.Head)))
+		i += copy(dAtA[i:], m.Head)
+	}
+	if m.Height != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(m.Height))
+	}
+	if len(m.Items) > 0 {
+		for _, b := range m.Items {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintChainmsg(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintChainmsg(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintChainmsg(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ChainRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovChainmsg(uint64(m.Version))
+	}
+	if m.Seq != 0 {
+		n += 1 + sovChainmsg(uint64(m.Seq))
+	}
+	if m.Kind != 0 {
+		n += 1 + sovChainmsg(uint64(m.Kind))
+	}
+	if m.From != 0 {
+		n += 1 + sovChainmsg(uint64(m.From))
+	}
+	if m.Count != 0 {
+		n += 1 + sovChainmsg(uint64(m.Count))
+	}
+	if len(m.Hashes) > 0 {
+		for _, b := range m.Hashes {
+			l = len(b)
+			n += 1 + l + sovChainmsg(uint64(l))
+		}
+	}
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovChainmsg(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovChainmsg(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChainResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovChainmsg(uint64(m.Version))
+	}
+	if m.Seq != 0 {
+		n += 1 + sovChainmsg(uint64(m.Seq))
+	}
+	if m.Kind != 0 {
+		n += 1 + sovChainmsg(uint64(m.Kind))
+	}
+	l = len(m.Genesis)
+	if l > 0 {
+		n += 1 + l + sovChainmsg(uint64(l))
+	}
+	l = len(m.Head)
+	if l > 0 {
+		n += 1 + l + sovChainmsg(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovChainmsg(uint64(m.Height))
+	}
+	if len(m.Items) > 0 {
+		for _, b := range m.Items {
+			l = len(b)
+			n += 1 + l + sovChainmsg(uint64(l))
+		}
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovChainmsg(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovChainmsg(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozChainmsg(x uint64) (n int) {
+	return sovChainmsg(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ChainRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChainmsg
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= ChainKind(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			m.From = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.From |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hashes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hashes = append(m.Hashes, make([]byte, postIndex-iNdEx))
+			copy(m.Hashes[len(m.Hashes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Root = append(m.Root[:0], dAtA[iNdEx:postIndex]...)
+			if m.Root == nil {
+				m.Root = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChainmsg(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChainResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChainmsg
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= ChainKind(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Genesis", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Genesis = append(m.Genesis[:0], dAtA[iNdEx:postIndex]...)
+			if m.Genesis == nil {
+				m.Genesis = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Head = append(m.Head[:0], dAtA[iNdEx:postIndex]...)
+			if m.Head == nil {
+				m.Head = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, make([]byte, postIndex-iNdEx))
+			copy(m.Items[len(m.Items)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChainmsg(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthChainmsg
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipChainmsg(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowChainmsg
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowChainmsg
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthChainmsg
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthChainmsg
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowChainmsg
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipChainmsg(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthChainmsg
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthChainmsg = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowChainmsg   = fmt.Errorf("proto: integer overflow")
+)
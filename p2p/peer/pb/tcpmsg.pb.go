@@ -72,6 +72,9 @@ const (
 	MessageId_MID_RPTK        MessageId = 8
 	MessageId_MID_GCD         MessageId = 9
 	MessageId_MID_PCD         MessageId = 10
+	MessageId_MID_CHREQ       MessageId = 11
+	MessageId_MID_CHRSP       MessageId = 12
+	MessageId_MID_EVIDENCE    MessageId = 13
 	MessageId_MID_INVALID     MessageId = -1
 )
 
@@ -87,6 +90,9 @@ var MessageId_name = map[int32]string{
 	8:  "MID_RPTK",
 	9:  "MID_GCD",
 	10: "MID_PCD",
+	11: "MID_CHREQ",
+	12: "MID_CHRSP",
+	13: "MID_EVIDENCE",
 	-1: "MID_INVALID",
 }
 var MessageId_value = map[string]int32{
@@ -101,6 +107,9 @@ var MessageId_value = map[string]int32{
 	"MID_RPTK":        8,
 	"MID_GCD":         9,
 	"MID_PCD":         10,
+	"MID_CHREQ":       11,
+	"MID_CHRSP":       12,
+	"MID_EVIDENCE":    13,
 	"MID_INVALID":     -1,
 }
 
@@ -379,7 +388,11 @@ type P2PMessage_Handshake struct {
 	SignS                *int32                 `protobuf:"varint,11,req,name=SignS" json:"SignS,omitempty"`
 	S                    []byte                 `protobuf:"bytes,12,req,name=S" json:"S,omitempty"`
 	Extra                []byte                 `protobuf:"bytes,13,opt,name=Extra" json:"Extra,omitempty"`
+	SecKey               []byte                 `protobuf:"bytes,14,opt,name=SecKey" json:"SecKey,omitempty"`
+	SecSign              []byte                 `protobuf:"bytes,15,opt,name=SecSign" json:"SecSign,omitempty"`
+	ValSign              []byte                 `protobuf:"bytes,16,opt,name=ValSign" json:"ValSign,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	Addrs                []byte                 `protobuf:"bytes,17,opt,name=Addrs" json:"Addrs,omitempty"`
 	XXX_unrecognized     []byte                 `json:"-"`
 	XXX_sizecache        int32                  `json:"-"`
 }
@@ -508,6 +521,34 @@ func (m *P2PMessage_Handshake) GetExtra() []byte {
 	return nil
 }
 
+func (m *P2PMessage_Handshake) GetSecKey() []byte {
+	if m != nil {
+		return m.SecKey
+	}
+	return nil
+}
+
+func (m *P2PMessage_Handshake) GetSecSign() []byte {
+	if m != nil {
+		return m.SecSign
+	}
+	return nil
+}
+
+func (m *P2PMessage_Handshake) GetValSign() []byte {
+	if m != nil {
+		return m.ValSign
+	}
+	return nil
+}
+
+func (m *P2PMessage_Handshake) GetAddrs() []byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
 type P2PMessage_Ping struct {
 	Seq                  *uint64  `protobuf:"varint,1,req,name=seq" json:"seq,omitempty"`
 	Extra                []byte   `protobuf:"bytes,2,opt,name=Extra" json:"Extra,omitempty"`
@@ -1213,6 +1254,32 @@ func (m *P2PMessage_Handshake) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintTcpmsg(dAtA, i, uint64(len(m.Extra)))
 		i += copy(dAtA[i:], m.Extra)
 	}
+	if m.SecKey != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintTcpmsg(dAtA, i, uint64(len(m.SecKey)))
+		i += copy(dAtA[i:], m.SecKey)
+	}
+	if m.SecSign != nil {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintTcpmsg(dAtA, i, uint64(len(m.SecSign)))
+		i += copy(dAtA[i:], m.SecSign)
+	}
+	if m.ValSign != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTcpmsg(dAtA, i, uint64(len(m.ValSign)))
+		i += copy(dAtA[i:], m.ValSign)
+	}
+	if m.Addrs != nil {
+		dAtA[i] = 0x8a
+		i++
+		i = encodeVarintTcpmsg(dAtA, i, uint64(len(m.Addrs)))
+		i += copy(dAtA[i:], m.Addrs)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -1650,6 +1717,22 @@ func (m *P2PMessage_Handshake) Size() (n int) {
 		l = len(m.Extra)
 		n += 1 + l + sovTcpmsg(uint64(l))
 	}
+	if m.SecKey != nil {
+		l = len(m.SecKey)
+		n += 1 + l + sovTcpmsg(uint64(l))
+	}
+	if m.SecSign != nil {
+		l = len(m.SecSign)
+		n += 1 + l + sovTcpmsg(uint64(l))
+	}
+	if m.ValSign != nil {
+		l = len(m.ValSign)
+		n += 2 + l + sovTcpmsg(uint64(l))
+	}
+	if m.Addrs != nil {
+		l = len(m.Addrs)
+		n += 1 + l + sovTcpmsg(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2669,6 +2752,133 @@ func (m *P2PMessage_Handshake) Unmarshal(dAtA []byte) error {
 				m.Extra = []byte{}
 			}
 			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTcpmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTcpmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecKey = append(m.SecKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.SecKey == nil {
+				m.SecKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecSign", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTcpmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTcpmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecSign = append(m.SecSign[:0], dAtA[iNdEx:postIndex]...)
+			if m.SecSign == nil {
+				m.SecSign = []byte{}
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValSign", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTcpmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTcpmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTcpmsg
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValSign = append(m.ValSign[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValSign == nil {
+				m.ValSign = []byte{}
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTcpmsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTcpmsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addrs == nil {
+				m.Addrs = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTcpmsg(dAtA[iNdEx:])
@@ -3770,56 +3980,60 @@ var (
 func init() { proto.RegisterFile("tcpmsg.proto", fileDescriptor_tcpmsg_0c95a1be00cf9a74) }
 
 var fileDescriptor_tcpmsg_0c95a1be00cf9a74 = []byte{
-	// 813 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcf, 0x8f, 0xda, 0x46,
-	0x14, 0xc7, 0x63, 0x63, 0x76, 0xf1, 0xc3, 0x6c, 0xdc, 0x69, 0x5a, 0x8d, 0x38, 0x50, 0x6b, 0x55,
-	0x35, 0x08, 0x55, 0x1c, 0x38, 0x56, 0xed, 0x81, 0xd8, 0x0e, 0x58, 0xde, 0x35, 0xa3, 0xb1, 0xbb,
-	0x5a, 0xf5, 0x82, 0x1c, 0x3c, 0x32, 0x68, 0xb3, 0x36, 0x01, 0x23, 0x85, 0xff, 0xa4, 0xff, 0x4b,
-	0x6f, 0x95, 0x2a, 0xb5, 0xb7, 0x9e, 0x7a, 0xae, 0xb6, 0x7f, 0x48, 0xaa, 0x19, 0xff, 0xdc, 0x8a,
-	0x44, 0x69, 0x38, 0xcd, 0xf7, 0xf1, 0x79, 0x6f, 0xde, 0x8f, 0x79, 0x06, 0x2d, 0x5b, 0x6d, 0xef,
-	0xf7, 0xf1, 0x78, 0xbb, 0x4b, 0xb3, 0x14, 0xa9, 0xa5, 0x7a, 0x75, 0xf9, 0x8b, 0x04, 0x40, 0x26,
-	0x84, 0x84, 0xab, 0xbb, 0x30, 0x66, 0xe8, 0x39, 0xb4, 0xc8, 0x26, 0xc2, 0x92, 0x21, 0x0f, 0x2f,
-	0x26, 0x5f, 0x8c, 0x2b, 0x6e, 0x4c, 0xb8, 0xe3, 0x2a, 0x7d, 0xed, 0x44, 0x94, 0x13, 0xe8, 0x5b,
-	0x38, 0xb3, 0xdf, 0x66, 0xd7, 0x9b, 0x08, 0xcb, 0x86, 0x34, 0xbc, 0x98, 0x3c, 0x6b, 0xb0, 0xd7,
-	0x6c, 0xbf, 0x0f, 0x63, 0xe6, 0x44, 0xb4, 0x60, 0xd0, 0x97, 0x82, 0x76, 0xd9, 0x11, 0xb7, 0x0c,
-	0x69, 0xa8, 0xd1, 0x42, 0xa1, 0xaf, 0xa1, 0x47, 0xc2, 0xe3, 0xeb, 0x34, 0x8c, 0xae, 0x58, 0x12,
-	0x67, 0x6b, 0xac, 0x18, 0xf2, 0xb0, 0x47, 0x1f, 0x1b, 0x11, 0x86, 0xf3, 0xc2, 0x80, 0xdb, 0xc2,
-	0xbd, 0x94, 0x97, 0xbf, 0xb6, 0x45, 0xf6, 0xc5, 0x85, 0xe8, 0x1b, 0x68, 0xdd, 0x57, 0xd9, 0x9f,
-	0xce, 0x88, 0x03, 0xe8, 0x07, 0x50, 0xd7, 0x61, 0x12, 0xed, 0xd7, 0xe1, 0x1d, 0x13, 0xf9, 0x77,
-	0x27, 0x5f, 0x35, 0x6b, 0xad, 0x22, 0x8e, 0xe7, 0x25, 0x46, 0x6b, 0x0f, 0x34, 0x06, 0x65, 0xbb,
-	0x49, 0x62, 0x51, 0x4b, 0x77, 0xd2, 0x3f, 0xed, 0x49, 0x36, 0x49, 0x4c, 0x05, 0x27, 0xf8, 0x34,
-	0x89, 0xb1, 0xf2, 0x41, 0x3e, 0x15, 0x7c, 0x9a, 0xc4, 0x7d, 0x1b, 0x3a, 0x65, 0xbb, 0x3f, 0x7e,
-	0x20, 0x3a, 0xb4, 0x6e, 0xd8, 0x0e, 0xcb, 0x86, 0x3c, 0xd4, 0x28, 0x3f, 0xf6, 0x7f, 0x93, 0x41,
-	0xad, 0xf2, 0xe7, 0x4d, 0x34, 0xd7, 0xe1, 0x26, 0x71, 0xf2, 0x60, 0x3d, 0x5a, 0x4a, 0xd4, 0x87,
-	0x8e, 0x7f, 0x78, 0xe5, 0xb1, 0xcc, 0x89, 0x0a, 0xf7, 0x4a, 0xf3, 0xc1, 0x79, 0x69, 0xc4, 0x9c,
-	0x08, 0xb7, 0xc4, 0x3f, 0x85, 0x42, 0x17, 0x20, 0x3b, 0x44, 0x4c, 0x4b, 0xa3, 0xb2, 0x43, 0xf8,
-	0xed, 0x3f, 0x5a, 0x04, 0xb7, 0x45, 0x64, 0x7e, 0xe4, 0x96, 0xc0, 0x24, 0xf8, 0x2c, 0xb7, 0x04,
-	0x26, 0xe1, 0xf7, 0x88, 0xa4, 0xbd, 0xc3, 0x3d, 0x3e, 0x17, 0xe6, 0x4a, 0xa3, 0xef, 0x41, 0x2d,
-	0x0b, 0xda, 0xe3, 0x8e, 0xd1, 0x1a, 0x76, 0x27, 0x83, 0xf7, 0xf4, 0xa9, 0xc0, 0x68, 0xed, 0x80,
-	0x9e, 0x41, 0xdb, 0xdf, 0xc4, 0x09, 0xc5, 0xaa, 0x21, 0x0f, 0xdb, 0x34, 0x17, 0x48, 0x03, 0x89,
-	0x62, 0x10, 0x29, 0x4a, 0xb4, 0x64, 0x7c, 0xdc, 0xad, 0x19, 0x9f, 0x33, 0x3e, 0xd6, 0x72, 0xc6,
-	0xe7, 0x8c, 0xfd, 0x36, 0xdb, 0x85, 0xb8, 0x27, 0x9e, 0x59, 0x2e, 0xfa, 0x63, 0x50, 0xf8, 0x30,
-	0x79, 0x45, 0x7b, 0xf6, 0x46, 0x74, 0x4f, 0xa1, 0xfc, 0x58, 0xf3, 0xf2, 0x7f, 0xf9, 0xf4, 0xe3,
-	0xf9, 0xcb, 0xbf, 0x14, 0x00, 0xbe, 0x27, 0xff, 0xf3, 0x11, 0x7f, 0x07, 0x9d, 0xd5, 0x9a, 0xad,
-	0xee, 0xf8, 0x56, 0xe5, 0x6f, 0xb8, 0xd9, 0xb1, 0x3a, 0xe0, 0xd8, 0x2c, 0x28, 0x5a, 0xf1, 0x7c,
-	0x01, 0x76, 0x6c, 0x9b, 0xee, 0xaa, 0x95, 0x7c, 0xbc, 0x00, 0x0d, 0x67, 0x5a, 0x62, 0xb4, 0xf6,
-	0x40, 0x2f, 0x41, 0x8b, 0x59, 0x26, 0xde, 0x8f, 0x15, 0x66, 0x61, 0xf1, 0xb0, 0x2f, 0x4f, 0x47,
-	0x98, 0x35, 0x48, 0xfa, 0xc8, 0x8f, 0xc7, 0xd9, 0x1e, 0x1a, 0x71, 0xda, 0x1f, 0x8a, 0x43, 0x0e,
-	0xcd, 0x38, 0x4d, 0xbf, 0xbe, 0x01, 0x9d, 0xb2, 0xc8, 0xba, 0xc7, 0x52, 0x73, 0x26, 0x0b, 0x50,
-	0xab, 0x4a, 0xf8, 0xb7, 0xcb, 0xcf, 0xc2, 0xec, 0xb0, 0x3f, 0xd1, 0x64, 0x97, 0x1d, 0xf3, 0xff,
-	0x68, 0xc1, 0xbc, 0x67, 0xc8, 0x2f, 0x41, 0x6b, 0x16, 0xc6, 0x87, 0xed, 0xd7, 0xc3, 0xf6, 0xd9,
-	0x1b, 0x84, 0x40, 0x71, 0x37, 0x49, 0xb9, 0x52, 0xe2, 0xcc, 0xa9, 0xbc, 0xe3, 0x62, 0x49, 0x5d,
-	0x76, 0xec, 0xff, 0x04, 0x5a, 0xb3, 0xb0, 0x4f, 0x8d, 0xc3, 0xa9, 0x28, 0x1f, 0x85, 0xa0, 0xf8,
-	0x79, 0xf4, 0x1c, 0xa0, 0xfe, 0x4a, 0xa0, 0x2e, 0x9c, 0x13, 0xc7, 0x5a, 0x92, 0x09, 0xd1, 0x9f,
-	0x20, 0x2d, 0x17, 0xf6, 0x6d, 0xa0, 0xbf, 0x93, 0x46, 0x7f, 0x48, 0xa0, 0x56, 0xaf, 0x0b, 0x7d,
-	0x06, 0xbd, 0x6b, 0xc7, 0x5a, 0xce, 0xa7, 0x9e, 0xe5, 0xcf, 0xa7, 0xae, 0x2d, 0xf0, 0x0e, 0x37,
-	0x11, 0xc7, 0x9b, 0xe9, 0x52, 0xa5, 0x16, 0xde, 0x4c, 0x97, 0x11, 0xc0, 0x19, 0x57, 0xc1, 0xad,
-	0xde, 0x42, 0x3d, 0x50, 0xf9, 0xd9, 0xbe, 0xb1, 0xbd, 0x40, 0x57, 0xd0, 0xe7, 0xf0, 0x94, 0xcb,
-	0x17, 0x57, 0x0b, 0xd3, 0x9d, 0xdb, 0x53, 0xcb, 0xa6, 0x7a, 0xbb, 0x64, 0x84, 0x51, 0x3f, 0x2b,
-	0x83, 0x99, 0x73, 0xd7, 0xd5, 0xcf, 0x4b, 0x45, 0x49, 0xe0, 0xea, 0x1d, 0x9e, 0x32, 0x57, 0x33,
-	0xd3, 0xd2, 0xd5, 0x52, 0x10, 0xd3, 0xd2, 0x01, 0x61, 0xe8, 0x72, 0xe1, 0x78, 0x37, 0xd3, 0x2b,
-	0xc7, 0xd2, 0xdf, 0x95, 0x3f, 0x69, 0x34, 0x02, 0xb5, 0x9a, 0x21, 0x7a, 0x0a, 0x5d, 0xd7, 0x5f,
-	0x7a, 0x8b, 0xc0, 0xbe, 0x75, 0xfc, 0x20, 0x2f, 0xc4, 0xf5, 0x97, 0xb9, 0x92, 0x5e, 0xe8, 0xbf,
-	0x3f, 0x0c, 0xa4, 0x3f, 0x1f, 0x06, 0xd2, 0xdf, 0x0f, 0x03, 0xe9, 0xe7, 0x7f, 0x06, 0x4f, 0xfe,
-	0x0d, 0x00, 0x00, 0xff, 0xff, 0x32, 0xf6, 0x48, 0x82, 0x28, 0x07, 0x00, 0x00,
+	// 879 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0xc7, 0x43, 0xea, 0xc3, 0xe2, 0x88, 0xb2, 0x37, 0xdb, 0xb4, 0x58, 0xe8, 0xa0, 0x0a, 0x46,
+	0xd1, 0x08, 0x46, 0xa1, 0x83, 0x8e, 0x45, 0x7b, 0x50, 0x48, 0xc6, 0x22, 0x68, 0xd3, 0xdb, 0xa5,
+	0x6a, 0x18, 0xbd, 0x18, 0x8c, 0x48, 0x50, 0x82, 0x6d, 0x51, 0x91, 0x28, 0x20, 0x7e, 0x93, 0x3e,
+	0x40, 0x81, 0x3e, 0x44, 0x5f, 0xa0, 0xc7, 0x9e, 0x7a, 0x2e, 0xdc, 0xa7, 0xe8, 0x29, 0xc5, 0xec,
+	0xf2, 0xcb, 0x80, 0x13, 0xa4, 0xd5, 0x69, 0xff, 0xc3, 0xdf, 0xcc, 0xce, 0xc7, 0x8e, 0xc0, 0xcc,
+	0x16, 0x9b, 0xbb, 0x5d, 0x32, 0xde, 0x6c, 0xd3, 0x2c, 0xa5, 0x46, 0xa1, 0xde, 0x1c, 0xff, 0xa6,
+	0x01, 0xf0, 0x09, 0xe7, 0xe1, 0xe2, 0x26, 0x4c, 0x62, 0xfa, 0x12, 0x1a, 0x7c, 0x15, 0x31, 0x6d,
+	0xa8, 0x8f, 0x0e, 0x27, 0x9f, 0x8f, 0x4b, 0x6e, 0xcc, 0xd1, 0x71, 0x91, 0xde, 0xba, 0x91, 0x40,
+	0x82, 0x7e, 0x03, 0x6d, 0xe7, 0x5d, 0x76, 0xbe, 0x8a, 0x98, 0x3e, 0xd4, 0x46, 0x87, 0x93, 0x17,
+	0x35, 0xf6, 0x3c, 0xde, 0xed, 0xc2, 0x24, 0x76, 0x23, 0x91, 0x33, 0xf4, 0x0b, 0x49, 0x7b, 0xf1,
+	0x3d, 0x6b, 0x0c, 0xb5, 0x91, 0x29, 0x72, 0x45, 0xbf, 0x82, 0x1e, 0x0f, 0xef, 0x6f, 0xd3, 0x30,
+	0x3a, 0x8b, 0xd7, 0x49, 0xb6, 0x64, 0xcd, 0xa1, 0x3e, 0xea, 0x89, 0xc7, 0x46, 0xca, 0xe0, 0x20,
+	0x37, 0xb0, 0x96, 0x74, 0x2f, 0xe4, 0xf1, 0x2f, 0x6d, 0x99, 0x7d, 0x7e, 0x21, 0xfd, 0x1a, 0x1a,
+	0x77, 0x65, 0xf6, 0x4f, 0x67, 0x84, 0x00, 0xfd, 0x1e, 0x8c, 0x65, 0xb8, 0x8e, 0x76, 0xcb, 0xf0,
+	0x26, 0x96, 0xf9, 0x77, 0x27, 0x5f, 0xd6, 0x6b, 0x2d, 0x23, 0x8e, 0x67, 0x05, 0x26, 0x2a, 0x0f,
+	0x3a, 0x86, 0xe6, 0x66, 0xb5, 0x4e, 0x64, 0x2d, 0xdd, 0x49, 0xff, 0x69, 0x4f, 0xbe, 0x5a, 0x27,
+	0x42, 0x72, 0x92, 0x4f, 0xd7, 0x09, 0x6b, 0x7e, 0x94, 0x4f, 0x25, 0x9f, 0xae, 0x93, 0xbe, 0x03,
+	0x9d, 0xa2, 0xdd, 0x9f, 0x3e, 0x10, 0x02, 0x8d, 0xcb, 0x78, 0xcb, 0xf4, 0xa1, 0x3e, 0x32, 0x05,
+	0x1e, 0xfb, 0xbf, 0x36, 0xc0, 0x28, 0xf3, 0xc7, 0x26, 0x5a, 0xcb, 0x70, 0xb5, 0x76, 0x55, 0xb0,
+	0x9e, 0x28, 0x24, 0xed, 0x43, 0x27, 0xd8, 0xbf, 0xf1, 0xe3, 0xcc, 0x8d, 0x72, 0xf7, 0x52, 0xe3,
+	0xe0, 0xfc, 0x34, 0x8a, 0xdd, 0x88, 0x35, 0xe4, 0x97, 0x5c, 0xd1, 0x43, 0xd0, 0x5d, 0x2e, 0xa7,
+	0x65, 0x0a, 0xdd, 0xe5, 0x78, 0xfb, 0x8f, 0x36, 0x67, 0x2d, 0x19, 0x19, 0x8f, 0x68, 0x99, 0x5b,
+	0x9c, 0xb5, 0x95, 0x65, 0x6e, 0x71, 0xbc, 0x47, 0x26, 0xed, 0xef, 0xef, 0xd8, 0x81, 0x34, 0x97,
+	0x9a, 0x7e, 0x07, 0x46, 0x51, 0xd0, 0x8e, 0x75, 0x86, 0x8d, 0x51, 0x77, 0x32, 0xf8, 0x40, 0x9f,
+	0x72, 0x4c, 0x54, 0x0e, 0xf4, 0x05, 0xb4, 0x82, 0x55, 0xb2, 0x16, 0xcc, 0x18, 0xea, 0xa3, 0x96,
+	0x50, 0x82, 0x9a, 0xa0, 0x09, 0x06, 0x32, 0x45, 0x4d, 0x14, 0x4c, 0xc0, 0xba, 0x15, 0x13, 0x20,
+	0x13, 0x30, 0x53, 0x31, 0x01, 0x32, 0xce, 0xbb, 0x6c, 0x1b, 0xb2, 0x9e, 0x7c, 0x66, 0x4a, 0x60,
+	0x0f, 0x82, 0x78, 0x81, 0x8f, 0xf7, 0x50, 0x3d, 0x5e, 0xa5, 0xb0, 0xa3, 0x41, 0xbc, 0xc0, 0x38,
+	0xec, 0x48, 0x3d, 0xcb, 0x5c, 0xe2, 0x97, 0xcb, 0xf0, 0x56, 0x7e, 0x21, 0xea, 0x4b, 0x2e, 0xf1,
+	0x86, 0x69, 0x14, 0x6d, 0x77, 0xec, 0xb9, 0xba, 0x41, 0x8a, 0xfe, 0x18, 0x9a, 0xf8, 0x5c, 0xb0,
+	0x67, 0xbb, 0xf8, 0xad, 0x9c, 0x4f, 0x53, 0xe0, 0xb1, 0xca, 0x48, 0xaf, 0x65, 0x24, 0xf9, 0xf4,
+	0xd3, 0xf9, 0xe3, 0x3f, 0x9b, 0x00, 0xb8, 0x89, 0xff, 0x71, 0x4d, 0xbe, 0x85, 0xce, 0x62, 0x19,
+	0x2f, 0x6e, 0xb0, 0x74, 0xb5, 0x25, 0xf5, 0x99, 0x54, 0x01, 0xc7, 0x56, 0x4e, 0x89, 0x92, 0xc7,
+	0x15, 0xdb, 0xc6, 0x9b, 0x74, 0x5b, 0x2e, 0xfd, 0xe3, 0x15, 0xab, 0x39, 0x8b, 0x02, 0x13, 0x95,
+	0x07, 0x7d, 0x0d, 0x66, 0x12, 0x67, 0xf2, 0x85, 0xda, 0x61, 0x16, 0xe6, 0xab, 0x73, 0xfc, 0x74,
+	0x84, 0xd3, 0x1a, 0x29, 0x1e, 0xf9, 0x61, 0x9c, 0xcd, 0xbe, 0x16, 0xa7, 0xf5, 0xb1, 0x38, 0x7c,
+	0x5f, 0x8f, 0x53, 0xf7, 0xeb, 0x0f, 0xa1, 0x53, 0x14, 0x59, 0xf5, 0x58, 0xab, 0xcf, 0xe4, 0x02,
+	0x8c, 0xb2, 0x12, 0xfc, 0x77, 0x0c, 0xb2, 0x30, 0xdb, 0xef, 0x9e, 0x68, 0xb2, 0x17, 0xdf, 0xab,
+	0x6f, 0x22, 0x67, 0x3e, 0x30, 0xe4, 0xd7, 0x60, 0xd6, 0x0b, 0xc3, 0x61, 0x07, 0xd5, 0xb0, 0x83,
+	0xf8, 0x2d, 0xa5, 0xd0, 0xf4, 0x56, 0xeb, 0x62, 0x69, 0xe5, 0x19, 0x29, 0xd5, 0x71, 0x34, 0xe1,
+	0xb1, 0xff, 0x13, 0x98, 0xf5, 0xc2, 0xfe, 0x6f, 0x1c, 0xa4, 0x22, 0x35, 0x0a, 0x49, 0xe1, 0xf9,
+	0xe4, 0x25, 0x40, 0xf5, 0x3f, 0x44, 0xbb, 0x70, 0xc0, 0x5d, 0xfb, 0x9a, 0x4f, 0x38, 0x79, 0x46,
+	0x4d, 0x25, 0x9c, 0xab, 0x39, 0x79, 0xaf, 0x9d, 0xfc, 0xa3, 0x81, 0x51, 0xbe, 0x2e, 0xfa, 0x1c,
+	0x7a, 0xe7, 0xae, 0x7d, 0x3d, 0x9b, 0xfa, 0x76, 0x30, 0x9b, 0x7a, 0x8e, 0xc4, 0x3b, 0x68, 0xe2,
+	0xae, 0x7f, 0x4a, 0xb4, 0x52, 0x5d, 0xf8, 0xa7, 0x44, 0xa7, 0x00, 0x6d, 0x54, 0xf3, 0x2b, 0xd2,
+	0xa0, 0x3d, 0x30, 0xf0, 0xec, 0x5c, 0x3a, 0xfe, 0x9c, 0x34, 0xe9, 0x67, 0x70, 0x84, 0xf2, 0xd5,
+	0xd9, 0x85, 0xe5, 0xcd, 0x9c, 0xa9, 0xed, 0x08, 0xd2, 0x2a, 0x18, 0x69, 0x24, 0xed, 0x22, 0x98,
+	0x35, 0xf3, 0x3c, 0x72, 0x50, 0x28, 0xc1, 0xe7, 0x1e, 0xe9, 0x60, 0xca, 0xa8, 0x4e, 0x2d, 0x9b,
+	0x18, 0x85, 0xe0, 0x96, 0x4d, 0xa0, 0x08, 0x62, 0xcd, 0x84, 0xf3, 0x03, 0xe9, 0xd6, 0x64, 0xc0,
+	0x89, 0x49, 0x09, 0x98, 0x2a, 0x0d, 0xd7, 0x76, 0x7c, 0xcb, 0x21, 0x3d, 0xca, 0xa0, 0x8b, 0x16,
+	0xd7, 0xbf, 0x9c, 0x9e, 0xb9, 0x36, 0x79, 0x5f, 0xfc, 0xb4, 0x93, 0x13, 0x30, 0xca, 0x99, 0xd3,
+	0x23, 0xe8, 0x7a, 0xc1, 0xb5, 0x7f, 0x31, 0x77, 0xae, 0xdc, 0x60, 0xae, 0x0a, 0xf7, 0x82, 0x6b,
+	0xa5, 0xb4, 0x57, 0xe4, 0xf7, 0x87, 0x81, 0xf6, 0xc7, 0xc3, 0x40, 0xfb, 0xeb, 0x61, 0xa0, 0xfd,
+	0xfc, 0xf7, 0xe0, 0xd9, 0xbf, 0x03, 0x00, 0x9c, 0x59, 0x46, 0x99, 0xba, 0x07, 0x00, 0x00,
 }
@@ -28,6 +28,7 @@ import (
 	"net"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	ggio "github.com/gogo/protobuf/io"
@@ -37,6 +38,10 @@ import (
 	um "github.com/yeeco/gyee/p2p/discover/udpmsg"
 	nat "github.com/yeeco/gyee/p2p/nat"
 	sch "github.com/yeeco/gyee/p2p/scheduler"
+	"github.com/yeeco/gyee/p2p/peerstore"
+	"github.com/yeeco/gyee/p2p/ratelimit"
+	"github.com/yeeco/gyee/p2p/reputation"
+	"github.com/yeeco/gyee/p2p/secure"
 )
 
 
@@ -58,6 +63,7 @@ const (
 	PeMgrEnoRecofig
 	PeMgrEnoSign
 	PeMgrEnoVerify
+	PeMgrEnoBanned
 	PeMgrEnoUnknown
 )
 
@@ -126,6 +132,8 @@ const (
 	conflictAccessDelayUpper = 2000 // conflict delay upper bounder in time.Millisecond
 
 	reconfigDelay = time.Second * 4 // reconfiguration delay time duration
+
+	maxPingpongRtt = time.Second * 8 // max round trip time of pingpong sampled as latency
 )
 
 // peer status
@@ -143,9 +151,13 @@ type peMgrConfig struct {
 	ip                 net.IP                            // ip address
 	port               uint16                            // tcp port number
 	udp                uint16                            // udp port number, used with handshake procedure
+	nodeId             PeerId                            // local node identity
 	noDial             bool                              // do not dial outbound
 	noAccept           bool                              // do not accept inbound
 	bootstrapNode      bool                              // local is a bootstrap node
+	encryption         string                            // transport encryption mode
+	peerRateLimit      config.RateLimit                  // rate limit of all traffic with a peer
+	msgRateLimits      map[uint32]config.RateLimit       // rate limits with a peer by message identity
 	defaultCto         time.Duration                     // default connect outbound timeout
 	defaultHto         time.Duration                     // default handshake timeout
 	defaultAto         time.Duration                     // default active read/write timeout
@@ -230,6 +242,14 @@ type PeerManager struct {
 	pubTcpPort    int                                         // public tcp port
 	pasStatus     int                                         // public addr switching status
 	pasBackup     []pasBackupItem                             // backup list for nat public address switching
+	reputation    *reputation.Reputation                      // peer reputation table, nil if none
+	bwLock        sync.Mutex                                  // lock for bandwidth meters
+	bwPeers       map[*PeerInstance]*ratelimit.Meter          // meters of active peer instances
+	bwSubnets     map[SubNetworkID]*ratelimit.Meter           // meters of sub networks
+	store         *peerstore.Store                            // good peers saved for warm restart
+	peerSet       map[PeerId]*peerSetEntry                    // reserved and trusted peers
+	staticFile    string                                      // file to save reserved and trusted peers
+	valSet        validatorSet                                // current validators
 }
 
 func NewPeerMgr() *PeerManager {
@@ -252,6 +272,8 @@ func NewPeerMgr() *PeerManager {
 		ocrTid:        sch.SchInvalidTid,
 		tmLastOCR:     make(map[SubNetworkID]map[PeerId]time.Time, 0),
 		tmLastFNR:     make(map[SubNetworkID]time.Time, 0),
+		bwPeers:       make(map[*PeerInstance]*ratelimit.Meter, 0),
+		bwSubnets:     make(map[SubNetworkID]*ratelimit.Meter, 0),
 		reCfg: PeerReconfig{
 			delList: make(map[config.SubNetworkID]interface{}, 0),
 			addList: make(map[config.SubNetworkID]interface{}, 0),
@@ -298,10 +320,21 @@ func (peMgr *PeerManager) peerMgrProc(ptn interface{}, msg *sch.SchMessage) sch.
 
 	case sch.EvPeOcrCleanupTimer:
 		peMgr.ocrTimestampCleanup()
+		peMgr.peerSetOutbound()
+		peMgr.flushPeerStore(peerstore.FlushInterval)
 
 	case sch.EvPeMgrStartReq:
 		eno = peMgr.peMgrStartReq(msg.Body)
 
+	case sch.EvPePeerSetReq:
+		eno = peMgr.peMgrPeerSetReq(msg.Body.(*sch.MsgPePeerSetReq))
+
+	case sch.EvPePeerListReq:
+		eno = peMgr.peMgrPeerListReq(msg.Body.(*sch.MsgPePeerListReq))
+
+	case sch.EvPeValidatorSetReq:
+		eno = peMgr.peMgrValidatorSetReq(msg.Body.(*sch.MsgPeValidatorSetReq))
+
 	case sch.EvDcvFindNodeRsp:
 		eno = peMgr.peMgrDcvFindNodeRsp(msg.Body)
 
@@ -378,6 +411,10 @@ func (peMgr *PeerManager) peMgrPoweron(ptn interface{}) PeMgrErrno {
 		_, peMgr.ptnDcv = peMgr.sdl.SchGetUserTaskNode(sch.DcvMgrName)
 	}
 
+	peMgr.reputation = reputation.Lookup(peMgr.sdl.SchGetP2pCfgName())
+	peMgr.reputation.OnBan(peMgr.closeBanned)
+	peMgr.store = peerstore.Open(cfg.PeerStore, peerstore.DefaultMaxPeers, peerstore.DefaultMaxAge)
+
 	var ok sch.SchErrno
 	ok, peMgr.ptnShell = peMgr.sdl.SchGetUserTaskNode(sch.ShMgrName)
 	if ok != sch.SchEnoNone || peMgr.ptnShell == nil {
@@ -394,6 +431,10 @@ func (peMgr *PeerManager) peMgrPoweron(ptn interface{}) PeMgrErrno {
 		noDial:        cfg.NoDial,
 		noAccept:      cfg.NoAccept,
 		bootstrapNode: cfg.BootstrapNode,
+		encryption:    cfg.Encryption,
+		nodeId:        cfg.ID,
+		peerRateLimit: cfg.PeerRateLimit,
+		msgRateLimits: cfg.MsgRateLimits,
 		defaultCto:    defaultConnectTimeout,
 		defaultHto:    defaultHandshakeTimeout,
 		defaultAto:    defaultActivePeerTimeout,
@@ -416,6 +457,8 @@ func (peMgr *PeerManager) peMgrPoweron(ptn interface{}) PeMgrErrno {
 		ibpNumTotal:        0,
 	}
 
+	peMgr.peerSetSetup(cfg)
+
 	peMgr.cfg.ibpNumTotal = peMgr.cfg.staticMaxInBounds
 	for _, ibpNum := range peMgr.cfg.subNetMaxInBounds {
 		peMgr.cfg.ibpNumTotal += ibpNum
@@ -502,6 +545,7 @@ func (peMgr *PeerManager) PeMgrStart() PeMgrErrno {
 
 func (peMgr *PeerManager) peMgrPoweroff(ptn interface{}) PeMgrErrno {
 	log.Debugf("peMgrPoweroff: task will be done, name: %s", sch.PeerMgrName)
+	peMgr.flushPeerStore(0)
 	close(peMgr.indChan)
 	for _, pi := range peMgr.peers {
 		log.Debugf("peMgrPoweroff: send EvSchPoweroff to inst: %s, dir: %d, state: %d",
@@ -734,8 +778,9 @@ func (peMgr *PeerManager) peMgrLsnConnAcceptedInd(msg interface{}) PeMgrErrno {
 
 	// Pause inbound peer accepter if necessary:
 	// we stop accepter simply, a duration of delay should be apply before pausing,
-	// this should be improved later.
-	if peMgr.ibpTotalNum++; peMgr.ibpTotalNum >= peMgr.cfg.ibpNumTotal {
+	// this should be improved later. the accepter goes beyond the inbound cap to
+	// leave room for reserved and trusted peers, see peerSetAcceptLimit.
+	if peMgr.ibpTotalNum++; peMgr.ibpTotalNum >= peMgr.peerSetAcceptLimit() {
 		if !peMgr.cfg.noAccept {
 			schMsg = sch.SchMessage{}
 			peMgr.sdl.SchMakeMessage(&schMsg, peMgr.ptnMe, peMgr.ptnLsn, sch.EvPeLsnStopReq, nil)
@@ -748,7 +793,8 @@ func (peMgr *PeerManager) peMgrLsnConnAcceptedInd(msg interface{}) PeMgrErrno {
 
 func (peMgr *PeerManager) peMgrOutboundReq(msg interface{}) PeMgrErrno {
 	if peMgr.cfg.noDial || peMgr.cfg.bootstrapNode {
-		log.Debugf("PeerManager: no outbound for noDial or boostrapNode: %t, %t")
+		log.Debugf("PeerManager: no outbound for noDial or boostrapNode: %t, %t",
+			peMgr.cfg.noDial, peMgr.cfg.bootstrapNode)
 		return PeMgrEnoNone
 	}
 	// if sub network identity is not specified, means all are wanted
@@ -808,7 +854,7 @@ func (peMgr *PeerManager) peMgrStaticSubNetOutbound() PeMgrErrno {
 	for _, n := range peMgr.cfg.staticNodes {
 		idEx.Id = n.ID
 		_, dup := peMgr.nodes[snid][idEx]
-		if !dup && peMgr.staticsStatus[idEx] == peerIdle {
+		if !dup && peMgr.staticsStatus[idEx] == peerIdle && !peMgr.reputation.IsBanned(n.ID) {
 			candidates = append(candidates, n)
 			count++
 		}
@@ -862,6 +908,9 @@ func (peMgr *PeerManager) peMgrDynamicSubNetOutbound(snid *SubNetworkID) PeMgrEr
 	var candidates = make([]*config.Node, 0)
 	var idEx PeerIdEx
 	for _, n := range peMgr.randoms[*snid] {
+		if peMgr.reputation.IsBanned(n.ID) {
+			continue
+		}
 		idEx.Id = n.ID
 		idEx.Dir = PeInstDirOutbound
 		if _, ok := peMgr.nodes[*snid][idEx]; !ok {
@@ -1034,6 +1083,14 @@ func (peMgr *PeerManager) peMgrHandshakeRsp(msg interface{}) PeMgrErrno {
 		return PeMgrEnoRecofig
 	}
 
+	if peMgr.reputation.IsBanned(rsp.peNode.ID) {
+		log.Debugf("peMgrHandshakeRsp: kill for banned, inst: %s, snid: %x, dir: %d",
+			inst.name, inst.snid, inst.dir)
+		peMgr.updateStaticStatus(snid, idEx, peerKilling)
+		peMgr.peMgrKillInst(&kip, PKI_FOR_BANNED)
+		return PeMgrEnoBanned
+	}
+
 	if peMgr.cfg.networkType == config.P2pNetworkTypeStatic &&
 		peMgr.staticSubNetIdExist(&snid) == true {
 
@@ -1057,7 +1114,20 @@ func (peMgr *PeerManager) peMgrHandshakeRsp(msg interface{}) PeMgrErrno {
 		}
 	}
 
-	if peMgr.wrkNum[snid] >= maxPeers {
+	// reserved and trusted peers are exempt from caps, but only for sub networks
+	// known, for which the maps for peers and workers are setup.
+	exempt := peMgr.peerSetExempt(rsp.peNode.ID) && maxPeers > 0
+
+	// total inbound cap is checked here but not when accepted, since the identity
+	// of peer is not known there.
+	if inst.dir == PeInstDirInbound && !peMgr.peerSetInboundAllowed(rsp.peNode.ID) {
+		log.Debugf("peMgrHandshakeRsp: total inbound too much, inst: %s, snid: %x, dir: %d",
+			inst.name, inst.snid, inst.dir)
+		peMgr.peMgrKillInst(&kip, PKI_FOR_TOOMUCH_INBOUNDS)
+		return PeMgrEnoResource
+	}
+
+	if peMgr.wrkNum[snid] >= maxPeers && !exempt {
 		log.Debugf("peMgrHandshakeRsp: too much workers, inst: %s, snid: %x, dir: %d",
 			inst.name, inst.snid, inst.dir)
 		peMgr.updateStaticStatus(snid, idEx, peerKilling)
@@ -1088,7 +1158,7 @@ func (peMgr *PeerManager) peMgrHandshakeRsp(msg interface{}) PeMgrErrno {
 		if peMgr.isStaticSubNetId(snid) {
 			peMgr.workers[snid][idEx] = inst
 		} else {
-			if peMgr.ibpNum[snid] >= maxInbound {
+			if peMgr.ibpNum[snid] >= maxInbound && !exempt {
 				log.Debugf("peMgrHandshakeRsp: inbound too much, " +
 					"inst: %s, snid: %x, dir: %d",
 					inst.name, inst.snid, inst.dir)
@@ -1115,7 +1185,7 @@ func (peMgr *PeerManager) peMgrHandshakeRsp(msg interface{}) PeMgrErrno {
 		if peMgr.isStaticSubNetId(snid) {
 			peMgr.workers[snid][idEx] = inst
 		} else {
-			if peMgr.obpNum[snid] >= maxOutbound {
+			if peMgr.obpNum[snid] >= maxOutbound && !exempt {
 				log.Debugf("peMgrHandshakeRsp: outbound, too much workers, " +
 					"inst: %s, snid: %x, dir: %d",
 					inst.name, inst.snid, inst.dir)
@@ -1166,6 +1236,9 @@ func (peMgr *PeerManager) peMgrHandshakeRsp(msg interface{}) PeMgrErrno {
 	peMgr.workers[snid][idEx] = inst
 	peMgr.wrkNum[snid]++
 	peMgr.updateStaticStatus(snid, idEx, peerActivated)
+	if peMgr.store != nil && snid != peMgr.cfg.staticSubNetId {
+		peMgr.store.Seen(snid, &inst.node)
+	}
 
 	if inst.dir == PeInstDirInbound &&
 		inst.networkType != config.P2pNetworkTypeStatic {
@@ -1181,6 +1254,7 @@ func (peMgr *PeerManager) peMgrHandshakeRsp(msg interface{}) PeMgrErrno {
 			UDP:    rsp.peNode.UDP,
 			TCP:    rsp.peNode.TCP,
 			NodeId: rsp.peNode.ID,
+			Addrs:  rsp.peNode.Addrs,
 		}
 		tabEno := peMgr.tabMgr.TabBucketAddNode(snid, &n, &lastQuery, &lastPing, &lastPong)
 		if tabEno != tab.TabMgrEnoNone {
@@ -1211,6 +1285,7 @@ func (peMgr *PeerManager) peMgrHandshakeRsp(msg interface{}) PeMgrErrno {
 			TCP:       uint32(inst.node.TCP),
 			ProtoNum:  inst.protoNum,
 			Protocols: inst.protocols,
+			ValAddr:   inst.valAddr,
 		},
 	}
 	i.PeerInfo.IP = append(i.PeerInfo.IP, inst.node.IP...)
@@ -1483,6 +1558,8 @@ func (peMgr *PeerManager) start() PeMgrErrno {
 	}
 	log.Debugf("start: ocrTid start ok")
 
+	peMgr.seedFromPeerStore()
+
 	msg := sch.SchMessage{}
 	peMgr.sdl.SchMakeMessage(&msg, peMgr.ptnMe, peMgr.ptnMe, sch.EvPeOutboundReq, nil)
 	peMgr.sdl.SchSendMessage(&msg)
@@ -1514,6 +1591,7 @@ func (peMgr *PeerManager) stop(why interface{}) PeMgrErrno {
 
 	log.Debugf("stop: randoms cleared")
 	peMgr.randoms = make(map[SubNetworkID][]*config.Node, 0)
+	peMgr.flushPeerStore(0)
 
 	log.Debugf("stop: kill ocrTid")
 	if peMgr.ocrTid != sch.SchInvalidTid {
@@ -1597,6 +1675,9 @@ func (peMgr *PeerManager) msgFilter(msg *sch.SchMessage) PeMgrErrno {
 		case sch.EvNatMgrReadyInd:
 		case sch.EvNatMgrMakeMapRsp:
 		case sch.EvPeMgrStartReq:
+		case sch.EvPePeerSetReq:
+		case sch.EvPePeerListReq:
+		case sch.EvPeValidatorSetReq:
 		default:
 			log.Debugf("msgFilter: filtered out for peMgrInNull, msg.Id: %d", msg.Id)
 			eno = PeMgrEnoMismatched
@@ -1607,6 +1688,9 @@ func (peMgr *PeerManager) msgFilter(msg *sch.SchMessage) PeMgrErrno {
 		case sch.EvPeCloseReq:
 		case sch.EvPeCloseCfm:
 		case sch.EvPeCloseInd:
+		case sch.EvPePeerSetReq:
+		case sch.EvPePeerListReq:
+		case sch.EvPeValidatorSetReq:
 		default:
 			log.Debugf("msgFilter: filtered out for inStartup: %d, msg.Id: %d", peMgr.inStartup, msg.Id)
 			eno = PeMgrEnoMismatched
@@ -1624,7 +1708,7 @@ func (peMgr *PeerManager) pubAddrSwitchPrepare() PeMgrErrno {
 					snid: pi.snid,
 					node: pi.node,
 				}
-				log.Debugf("pubAddrSwitchPrepare: peer backup, name, snid: %x, ip: %s",
+				log.Debugf("pubAddrSwitchPrepare: peer backup, name: %s, snid: %x, ip: %s",
 					pi.name, pi.snid, pi.node.IP.String())
 				peMgr.pasBackup = append(peMgr.pasBackup, item)
 			}
@@ -1658,10 +1742,11 @@ func (peMgr *PeerManager) pubAddrSwitch() PeMgrErrno {
 	peMgr.cfg.udp = uint16(peMgr.pubTcpPort)
 	for k, old := range peMgr.cfg.subNetNodeList {
 		n := config.Node{
-			ID:  old.ID,
-			IP:  peMgr.pubTcpIp,
-			TCP: uint16(peMgr.pubTcpPort),
-			UDP: uint16(peMgr.pubTcpPort),
+			ID:    old.ID,
+			IP:    peMgr.pubTcpIp,
+			TCP:   uint16(peMgr.pubTcpPort),
+			UDP:   uint16(peMgr.pubTcpPort),
+			Addrs: old.Addrs,
 		}
 		peMgr.cfg.subNetNodeList[k] = n
 	}
@@ -1801,6 +1886,7 @@ const (
 	PKI_FOR_OBW_DUPLICATED    = "dup2OutboundWorker"
 	PKI_FOR_IB2OB_DUPLICATED  = "inBoundDup2OutBound"
 	PKI_FOR_OB2IB_DUPLICATED  = "outBoundDup2InBound"
+	PKI_FOR_BANNED            = "banned"
 )
 
 type kiParameters struct {
@@ -1913,7 +1999,7 @@ func (peMgr *PeerManager) peMgrKillInst(kip *kiParameters, why interface{}) PeMg
 	}
 
 	if peMgr.cfg.noAccept == false &&
-		peMgr.ibpTotalNum < peMgr.cfg.ibpNumTotal {
+		peMgr.ibpTotalNum < peMgr.peerSetAcceptLimit() {
 		schMsg := sch.SchMessage{}
 		peMgr.sdl.SchMakeMessage(&schMsg, peMgr.ptnMe, peMgr.ptnLsn, sch.EvPeLsnStartReq, nil)
 		peMgr.sdl.SchSendMessage(&schMsg)
@@ -2303,6 +2389,7 @@ type PeerInstance struct {
 	node        config.Node        // peer "node" information
 	protoNum    uint32             // peer protocol number
 	protocols   []Protocol         // peer protocol table
+	valAddr     string             // validator address proven in handshake, "" if none
 	maxPkgSize  int                // max size of tcpmsg package
 	ppTid       int                // pingpong timer identity
 	rxChan      chan *P2pPackageRx // rx pending channel
@@ -2314,13 +2401,20 @@ type PeerInstance struct {
 	txFailedCnt int64              // tx failed counter
 	rxDone      chan PeMgrErrno    // RX chan
 	rxtxRuning  bool               // indicating that rx and tx routines are running
-	ppSeq       uint64             // pingpong sequence no.
+	ppSeq       uint64             // pingpong sequence no., 0 if no ping pending
+	ppSent      int64              // local time in nanoseconds the pending ping sent
 	ppCnt       int                // pingpong counter
 	rxEno       PeMgrErrno         // rx errno
 	txEno       PeMgrErrno         // tx errno
 	ppEno       PeMgrErrno         // pingpong errno
 	rxDiscard   int64              // number of rx messages discarded
 	rxOkCnt     int64              // number of rx messages accepted
+
+	rxLimiter     *ratelimit.Limiter            // limiter of all inbound traffic
+	txLimiter     *ratelimit.Limiter            // limiter of all outbound traffic
+	rxMsgLimiters map[uint32]*ratelimit.Limiter // inbound limiters by message identity
+	txMsgLimiters map[uint32]*ratelimit.Limiter // outbound limiters by message identity
+	meter         *ratelimit.Meter              // bandwidth meter
 }
 
 var peerInstDefault = PeerInstance{
@@ -2470,20 +2564,27 @@ func (pi *PeerInstance) piConnOutReq(_ interface{}) PeMgrErrno {
 	}
 
 	var (
-		addr          = &net.TCPAddr{IP: pi.node.IP, Port: int(pi.node.TCP)}
+		addr *net.TCPAddr
 		conn net.Conn = nil
 		err  error
 		eno  PeMgrErrno = PeMgrEnoNone
 	)
 
-	log.Tracef("piConnOutReq: outbound inst: %s, snid: %x, try to dial target: %s",
-		pi.name, pi.snid, addr.String())
-
+	// a multi-homed peer is dialed at its endpoints in the order of preference
+	// until one of them is connected.
 	pi.dialer.Timeout = pi.cto
-	if conn, err = pi.dialer.Dial("tcp", addr.String()); err != nil {
+	for _, ep := range config.P2pSortEndpoints(pi.node.Endpoints()) {
+		addr = &net.TCPAddr{IP: ep.IP, Port: int(ep.TCP)}
+		log.Tracef("piConnOutReq: outbound inst: %s, snid: %x, try to dial target: %s",
+			pi.name, pi.snid, addr.String())
+		if conn, err = pi.dialer.Dial("tcp", addr.String()); err == nil {
+			break
+		}
 		log.Debugf("piConnOutReq: dial failed, local: %s, to: %s, err: %s",
 			fmt.Sprintf("%s:%d", pi.node.IP.String(), pi.node.TCP),
 			addr.String(), err.Error())
+	}
+	if conn == nil {
 		eno = PeMgrEnoOs
 	} else {
 		pi.conn = conn
@@ -2584,9 +2685,12 @@ func (pi *PeerInstance) piPingpongReq(msg interface{}) PeMgrErrno {
 		log.Debugf("piPingpongReq: queue full, inst: %s, dir: %d", pi.name, pi.dir)
 		return PeMgrEnoResource
 	}
-	pi.ppSeq = msg.(*MsgPingpongReq).seq
+	// accessed by piRx for pong, send time set first for the sequence
+	seq := msg.(*MsgPingpongReq).seq
+	atomic.StoreInt64(&pi.ppSent, time.Now().UnixNano())
+	atomic.StoreUint64(&pi.ppSeq, seq)
 	ping := Pingpong{
-		Seq:   pi.ppSeq,
+		Seq:   seq,
 		Extra: nil,
 	}
 	upkg := new(P2pPackage)
@@ -2664,6 +2768,7 @@ func (pi *PeerInstance) piEstablishedInd(msg interface{}) PeMgrErrno {
 		return PeMgrEnoOs
 	}
 
+	pi.setupFlowControl()
 	go piTx(pi)
 	go piRx(pi)
 
@@ -2759,6 +2864,7 @@ func (pi *PeerInstance) piHandshakeInbound(inst *PeerInstance) PeMgrErrno {
 	inst.node.IP = append(inst.node.IP, hs.IP...)
 	inst.node.TCP = uint16(hs.TCP)
 	inst.node.UDP = uint16(hs.UDP)
+	inst.node.Addrs = hs.Addrs
 	inst.protoNum = hs.ProtoNum
 	inst.protocols = hs.Protocols
 
@@ -2770,14 +2876,56 @@ func (pi *PeerInstance) piHandshakeInbound(inst *PeerInstance) PeMgrErrno {
 	hs2peer.IP = append(hs2peer.IP, inst.localNode.IP...)
 	hs2peer.UDP = uint32(inst.localNode.UDP)
 	hs2peer.TCP = uint32(inst.localNode.TCP)
+	hs2peer.Addrs = inst.localNode.Addrs
 	hs2peer.ProtoNum = inst.localProtoNum
 	hs2peer.Protocols = inst.localProtocols
 
+	// key exchange for transport encryption if the peer asks for it, peers
+	// without support are served in plaintext unless encryption is required.
+	var shs *secure.Handshake
+	if len(hs.SecKey) != 0 && pi.peMgr.cfg.encryption != config.ENC_OFF {
+		var err error
+		if shs, err = secure.NewHandshake(inst.chainId, &inst.priKey, inst.localNode.ID, false); err != nil {
+			log.Debugf("piHandshakeInbound: NewHandshake failed, err: %s", err.Error())
+			return PeMgrEnoSign
+		}
+		if hs2peer.SecSign, err = shs.Respond(hs.NodeId, hs.SecKey); err != nil {
+			log.Debugf("piHandshakeInbound: Respond failed, err: %s", err.Error())
+			return PeMgrEnoVerify
+		}
+		hs2peer.SecKey = shs.PublicKey()
+	} else if pi.peMgr.cfg.encryption == config.ENC_REQUIRED {
+		log.Debugf("piHandshakeInbound: encryption required, peer: %s", hs.IP.String())
+		return PeMgrEnoVerify
+	} else if pi.peMgr.cfg.encryption == config.ENC_OPTIONAL {
+		log.Warnf("piHandshakeInbound: plaintext fallback, peer: %s", hs.IP.String())
+	}
+
+	pi.peMgr.validatorProve(&hs2peer)
+
 	if eno = pkg.putHandshakeOutbound(inst, &hs2peer); eno != PeMgrEnoNone {
 		log.Debugf("piHandshakeInbound: write outbound Handshake message failed, eno: %d", eno)
 		return eno
 	}
 
+	if shs != nil {
+		inst.setHandshakeDeadline()
+		sc, err := shs.Accept(inst.conn)
+		if err != nil {
+			log.Debugf("piHandshakeInbound: Accept failed, peer: %s, err: %s", hs.IP.String(), err.Error())
+			return PeMgrEnoVerify
+		}
+		inst.secureConn(sc)
+	}
+
+	// the validator proof counts only when the key exchange completed
+	if pi.peMgr.validatorVerify(inst.chainId, hs, shs != nil) != true {
+		log.Debugf("piHandshakeInbound: validatorVerify failed, snid: %x, peer: %s",
+			hs.Snid, hs.IP.String())
+		return PeMgrEnoVerify
+	}
+	inst.valAddr = hs.ValAddr
+
 	return PeMgrEnoNone
 }
 
@@ -2793,9 +2941,22 @@ func (pi *PeerInstance) piHandshakeOutbound(inst *PeerInstance) PeMgrErrno {
 	hs.IP = append(hs.IP, pi.localNode.IP...)
 	hs.UDP = uint32(pi.localNode.UDP)
 	hs.TCP = uint32(pi.localNode.TCP)
+	hs.Addrs = pi.localNode.Addrs
 	hs.ProtoNum = pi.localProtoNum
 	hs.Protocols = append(hs.Protocols, pi.localProtocols...)
 
+	var shs *secure.Handshake
+	if pi.peMgr.cfg.encryption != config.ENC_OFF {
+		var err error
+		if shs, err = secure.NewHandshake(inst.chainId, &inst.priKey, pi.localNode.ID, true); err != nil {
+			log.Debugf("piHandshakeOutbound: NewHandshake failed, err: %s", err.Error())
+			return PeMgrEnoSign
+		}
+		hs.SecKey = shs.PublicKey()
+	}
+
+	pi.peMgr.validatorProve(hs)
+
 	if eno = pkg.putHandshakeOutbound(inst, hs); eno != PeMgrEnoNone {
 		log.Debugf("piHandshakeOutbound: write outbound Handshake message failed, eno: %d", eno)
 		return eno
@@ -2830,11 +2991,54 @@ func (pi *PeerInstance) piHandshakeOutbound(inst *PeerInstance) PeMgrErrno {
 		return PeMgrEnoMessage
 	}
 
+	// the peer answers with its ephemeral key if it supports encryption, the
+	// signature must be checked against the node identity we dialed.
+	secured := shs != nil && len(hs.SecKey) != 0
+	if secured {
+		inst.setHandshakeDeadline()
+		sc, err := shs.Finish(inst.conn, inst.node.ID, hs.SecKey, hs.SecSign)
+		if err != nil {
+			log.Debugf("piHandshakeOutbound: Finish failed, peer: %s, err: %s", hs.IP.String(), err.Error())
+			return PeMgrEnoVerify
+		}
+		inst.secureConn(sc)
+	} else if pi.peMgr.cfg.encryption == config.ENC_REQUIRED {
+		log.Debugf("piHandshakeOutbound: encryption required, peer: %s", hs.IP.String())
+		return PeMgrEnoVerify
+	} else if pi.peMgr.cfg.encryption == config.ENC_OPTIONAL {
+		log.Warnf("piHandshakeOutbound: plaintext fallback, peer: %s", hs.IP.String())
+	}
+
+	// the validator proof counts only when the key exchange completed
+	if pi.peMgr.validatorVerify(inst.chainId, hs, secured) != true {
+		log.Debugf("piHandshakeOutbound: validatorVerify failed, snid: %x, peer: %s",
+			hs.Snid, hs.IP.String())
+		return PeMgrEnoVerify
+	}
+
+	inst.node.Addrs = hs.Addrs
 	inst.protoNum = hs.ProtoNum
 	inst.protocols = hs.Protocols
+	inst.valAddr = hs.ValAddr
 	return PeMgrEnoNone
 }
 
+func (pi *PeerInstance) setHandshakeDeadline() {
+	if pi.hto != 0 {
+		pi.conn.SetDeadline(time.Now().Add(pi.hto))
+	} else {
+		pi.conn.SetDeadline(time.Time{})
+	}
+}
+
+func (pi *PeerInstance) secureConn(sc *secure.Conn) {
+	// nothing is buffered by the readers and writers of the handshake, since
+	// the peer sends nothing more before the key exchange completed.
+	pi.conn = sc
+	pi.ior = ggio.NewDelimitedReader(sc, pi.maxPkgSize)
+	pi.iow = ggio.NewDelimitedWriter(sc)
+}
+
 func SendPackage(pkg *P2pPackage2Peer) PeMgrErrno {
 	// this function exported for user to send messages to specific peers, please
 	// notice that it plays with the "messaging" based on scheduler. it's not the
@@ -2875,7 +3079,7 @@ func (peMgr *PeerManager) ClosePeer(snid *SubNetworkID, id *PeerId) PeMgrErrno {
 	idExList := []PeerIdEx{idExOut, idExIn}
 	why := sch.PEC_FOR_COMMAND
 	for _, idEx := range idExList {
-		log.Debugf("ClosePeer: why: %d, snid: %x, dir: %d, id: %x",
+		log.Debugf("ClosePeer: why: %s, snid: %x, dir: %d, id: %x",
 			why, *snid, idEx.Dir, idEx.Id)
 		var req = sch.MsgPeCloseReq{
 			Ptn:  nil,
@@ -2893,6 +3097,21 @@ func (peMgr *PeerManager) ClosePeer(snid *SubNetworkID, id *PeerId) PeMgrErrno {
 	return PeMgrEnoNone
 }
 
+//
+// Called by the reputation table when a peer is banned, close it in all sub
+// networks, those not connected to it are ignored by peMgrCloseReq.
+//
+func (peMgr *PeerManager) closeBanned(id config.NodeID) {
+	if peMgr.store != nil {
+		peMgr.store.Remove(id)
+	}
+	snids := append([]SubNetworkID{peMgr.cfg.staticSubNetId}, peMgr.cfg.subNetIdList...)
+	for _, snid := range snids {
+		snid := snid
+		peMgr.ClosePeer(&snid, &id)
+	}
+}
+
 type txPkgKey struct {
 	pid	uint32
 	mid	uint32
@@ -2987,11 +3206,13 @@ _txLoop:
 			if okPP && ppkg != nil {
 
 				pi.txSeq += 1
+				pi.txAllow(ppkg)
 
 				if eno := ppkg.SendPackage(pi); eno == PeMgrEnoNone {
 
 					pi.txOkCnt++
 					stat.txppOkCnt++
+					piTxPace(pi, ppkg)
 
 				} else {
 
@@ -3026,6 +3247,12 @@ _txLoop:
 			}
 
 			pi.txPendNum -= 1
+			if !pi.txAllow(upkg) {
+				log.Tracef("piTx: discarded for rate limit, " +
+					"sdl: %s, inst: %s, snid: %x, dir: %d, mid: %d, key: %x",
+					pi.sdlName, pi.name, pi.snid, pi.dir, upkg.Mid, upkg.Key)
+				continue
+			}
 			pi.txSeq += 1
 
 			if eno := upkg.SendPackage(pi); eno == PeMgrEnoNone {
@@ -3038,6 +3265,7 @@ _txLoop:
 
 				pi.txOkCnt++
 				stat.txdatOkCnt++
+				piTxPace(pi, upkg)
 			} else {
 
 				log.Debugf("piTx: SendPackage failed, " +
@@ -3077,6 +3305,24 @@ _txLoop:
 	return PeMgrEnoNone
 }
 
+//
+// Pace the tx routine when over the rate limit of peer
+//
+func piTxPace(pi *PeerInstance, upkg *P2pPackage) {
+	if d := pi.txLimiter.Reserve(upkg.size()); d > 0 {
+		time.Sleep(d)
+	}
+}
+
+//
+// Ready channel for piRx not to wait
+//
+var piRxNoWait = func() <-chan time.Time {
+	ch := make(chan time.Time)
+	close(ch)
+	return ch
+}()
+
 type rxPkgKey = txPkgKey
 type rxStat struct {
 	rxTryCnt		int64
@@ -3105,9 +3351,18 @@ func piRx(pi *PeerInstance) PeMgrErrno {
 	var stat = rxStat{
 		rxPkgCnt: make(map[rxPkgKey]int64, 0),
 	}
+	var rxWait time.Duration
 
 _rxLoop:
 	for {
+		// when over the rate limit of peer, do not read before the deficit paid
+		// back, so the peer is pushed back by tcp.
+		pace := piRxNoWait
+		if rxWait > 0 {
+			pace = time.After(rxWait)
+			rxWait = 0
+		}
+
 		select {
 		case done, ok = <-pi.rxDone:
 			log.Debugf("piRx: done, " +
@@ -3117,7 +3372,7 @@ _rxLoop:
 				close(pi.rxDone)
 			}
 			break _rxLoop
-		default:
+		case <-pace:
 		}
 
 		// if in errors, sleep then continue to check done
@@ -3181,6 +3436,15 @@ _rxLoop:
 
 		upkg.DebugPeerPackage()
 
+		rxWait = pi.rxLimiter.Reserve(upkg.size())
+		if !pi.rxAllow(upkg) {
+			log.Tracef("piRx: discarded for rate limit, " +
+				"sdl: %s, inst: %s, snid: %x, dir: %d, mid: %d, key: %x",
+				pi.sdlName, pi.name, pi.snid, pi.dir, upkg.Mid, upkg.Key)
+			stat.rxDiscardCnt++
+			continue
+		}
+
 		rxpk := rxPkgKey{
 			pid: upkg.Pid,
 			mid: upkg.Mid,
@@ -3350,10 +3614,55 @@ func (pi *PeerInstance) piP2pPingProc(ping *Pingpong) PeMgrErrno {
 func (pi *PeerInstance) piP2pPongProc(pong *Pingpong) PeMgrErrno {
 	// Currently, the heartbeat checking does not apply pong messages from
 	// peer, instead, a counter for ping messages and a timer are invoked,
-	// see it pls.
+	// see it pls. the round trip time of a pong answering the ping pending
+	// is sampled as the latency of the peer, once, from the local send time,
+	// so that a peer can not fake it with unsolicited pongs.
+	if pong == nil || pong.Seq == 0 || !atomic.CompareAndSwapUint64(&pi.ppSeq, pong.Seq, 0) {
+		log.Debugf("piP2pPongProc: not pending, discarded, inst: %s", pi.name)
+		return PeMgrEnoNone
+	}
+	rtt := time.Since(time.Unix(0, atomic.LoadInt64(&pi.ppSent)))
+	if rtt > 0 && rtt < maxPingpongRtt && pi.peMgr.store != nil {
+		pi.peMgr.store.Sample(pi.snid, pi.node.ID, rtt)
+	}
 	return PeMgrEnoNone
 }
 
+//
+// Seed the random nodes of dynamic sub networks with peers saved in the peer
+// store, so that they would be tried before those from the discover task.
+//
+func (peMgr *PeerManager) seedFromPeerStore() {
+	if peMgr.store == nil || peMgr.cfg.networkType != config.P2pNetworkTypeDynamic {
+		return
+	}
+	for _, snid := range peMgr.cfg.subNetIdList {
+		local := peMgr.cfg.subNetNodeList[snid]
+		for _, p := range peMgr.store.Peers(snid, peMgr.cfg.subNetMaxPeers[snid]) {
+			if p.Node.ID == local.ID || peMgr.reputation.IsBanned(p.Node.ID) {
+				continue
+			}
+			node := p.Node
+			peMgr.randoms[snid] = append(peMgr.randoms[snid], &node)
+		}
+		if n := len(peMgr.randoms[snid]); n > 0 {
+			log.Debugf("seedFromPeerStore: snid: %x, peers: %d", snid, n)
+		}
+	}
+}
+
+//
+// Save the peer store if it's changed and the interval passed since last saved
+//
+func (peMgr *PeerManager) flushPeerStore(interval time.Duration) {
+	if peMgr.store == nil {
+		return
+	}
+	if err := peMgr.store.Flush(interval); err != nil && err != peerstore.ErrNoPath {
+		log.Debugf("flushPeerStore: failed, error: %s", err.Error())
+	}
+}
+
 func (pis peerInstState) compare(s peerInstState) int {
 	return int(pis - s)
 }
@@ -3487,6 +3796,7 @@ func (pi *PeerInstance) stopRxTx() {
 	}
 	cleanCh := func() {
 		pi.rxtxRuning = false
+		pi.peMgr.bwDetach(pi)
 		if pi.conn != nil {
 			cleanIo()
 		}
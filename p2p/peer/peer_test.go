@@ -0,0 +1,68 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	config "github.com/yeeco/gyee/p2p/config"
+	"github.com/yeeco/gyee/p2p/peerstore"
+)
+
+func TestPongLatency(t *testing.T) {
+	peMgr := NewPeerMgr()
+	peMgr.store = peerstore.Open("", 0, 0)
+	node := testPeerSetNode(1)
+	peMgr.store.Seen(config.ZeroSubNet, node)
+	pi := &PeerInstance{peMgr: peMgr, snid: config.ZeroSubNet, node: *node}
+	latency := func() time.Duration {
+		return peMgr.store.Peers(config.AnySubNet, 0)[0].Latency
+	}
+
+	// unsolicited pong with a recent sequence
+	pi.piP2pPongProc(&Pingpong{Seq: uint64(time.Now().UnixNano())})
+	if l := latency(); l != 0 {
+		t.Fatalf("unsolicited pong sampled %v", l)
+	}
+
+	// pending ping sent 50ms ago, sampled from the local send time
+	seq := uint64(time.Now().Add(-time.Hour).UnixNano())
+	pi.ppSeq, pi.ppSent = seq, time.Now().Add(-50*time.Millisecond).UnixNano()
+	pi.piP2pPongProc(&Pingpong{Seq: seq + 1})
+	if l := latency(); l != 0 {
+		t.Fatalf("mismatched pong sampled %v", l)
+	}
+	pi.piP2pPongProc(&Pingpong{Seq: seq})
+	if l := latency(); l < 50*time.Millisecond || l >= maxPingpongRtt {
+		t.Fatalf("unexpected latency %v", l)
+	}
+	if pi.ppSeq != 0 {
+		t.Fatalf("pending sequence not cleared")
+	}
+
+	// sampled once
+	first := latency()
+	pi.piP2pPongProc(&Pingpong{Seq: seq})
+	if l := latency(); l != first {
+		t.Fatalf("pong sampled twice %v %v", first, l)
+	}
+}
@@ -0,0 +1,342 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package peer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yeeco/gyee/log"
+	config "github.com/yeeco/gyee/p2p/config"
+	tab "github.com/yeeco/gyee/p2p/discover/table"
+	sch "github.com/yeeco/gyee/p2p/scheduler"
+)
+
+//
+// Reserved and trusted peers: reserved peers are dialed and redialed by the
+// peer manager to keep them always connected, while trusted peers are just
+// accepted. both are exempt from the caps of peers, inbounds and outbounds of
+// sub networks, so validators could keep direct links to each other however
+// crowded they are; they are not exempt from bans. the sets come from the
+// configuration and the static nodes file, and changes made at runtime with
+// EvPePeerSetReq are saved into the file.
+//
+
+const durReservedRetry = time.Second * 8 // min duration to redial a reserved peer
+
+//
+// Entry of reserved or trusted peer
+//
+type peerSetEntry struct {
+	node     config.Node // peer node
+	reserved bool        // reserved, dialed to be always connected
+	trusted  bool        // trusted, exempt from caps only
+	lastDial time.Time   // time of last dial
+}
+
+//
+// Static nodes file, nodes in format as bootstrap nodes
+//
+type staticNodesFile struct {
+	Reserved []string `json:"reserved"`
+	Trusted  []string `json:"trusted"`
+}
+
+//
+// Setup sets with configuration and the static nodes file
+//
+func (peMgr *PeerManager) peerSetSetup(cfg *config.Cfg4PeerManager) {
+	peMgr.peerSet = make(map[PeerId]*peerSetEntry, 0)
+	peMgr.staticFile = cfg.StaticFile
+	for _, n := range cfg.ReservedNodes {
+		peMgr.peerSetAdd(n, false)
+	}
+	for _, n := range cfg.TrustedNodes {
+		peMgr.peerSetAdd(n, true)
+	}
+	if len(peMgr.staticFile) == 0 {
+		return
+	}
+	data, err := ioutil.ReadFile(peMgr.staticFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debugf("peerSetSetup: read failed, file: %s, error: %s", peMgr.staticFile, err.Error())
+		}
+		return
+	}
+	snf := staticNodesFile{}
+	if err := json.Unmarshal(data, &snf); err != nil {
+		log.Debugf("peerSetSetup: decode failed, file: %s, error: %s", peMgr.staticFile, err.Error())
+		return
+	}
+	for _, n := range config.P2pSetupBootstrapNodes(snf.Reserved) {
+		peMgr.peerSetAdd(n, false)
+	}
+	for _, n := range config.P2pSetupBootstrapNodes(snf.Trusted) {
+		peMgr.peerSetAdd(n, true)
+	}
+	log.Debugf("peerSetSetup: peers: %d", len(peMgr.peerSet))
+}
+
+func (peMgr *PeerManager) peerSetAdd(n *config.Node, trusted bool) {
+	if n == nil || n.ID == peMgr.cfg.nodeId {
+		return
+	}
+	pse, ok := peMgr.peerSet[n.ID]
+	if !ok {
+		pse = &peerSetEntry{}
+		peMgr.peerSet[n.ID] = pse
+	}
+	pse.node = *n
+	if trusted {
+		pse.trusted = true
+	} else {
+		pse.reserved = true
+	}
+}
+
+//
+// Save sets to the static nodes file
+//
+func (peMgr *PeerManager) peerSetSave() error {
+	if len(peMgr.staticFile) == 0 {
+		return nil
+	}
+	snf := staticNodesFile{
+		Reserved: make([]string, 0),
+		Trusted:  make([]string, 0),
+	}
+	for _, pse := range peMgr.peerSet {
+		url := fmt.Sprintf("%x@%s:%d:%d", pse.node.ID, pse.node.IP.String(), pse.node.UDP, pse.node.TCP)
+		if pse.reserved {
+			snf.Reserved = append(snf.Reserved, url)
+		}
+		if pse.trusted {
+			snf.Trusted = append(snf.Trusted, url)
+		}
+	}
+	data, err := json.MarshalIndent(&snf, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(peMgr.staticFile), 0700); err != nil {
+		return err
+	}
+	tmp := peMgr.staticFile + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, peMgr.staticFile)
+}
+
+//
+// Check if peer is exempt from caps
+//
+func (peMgr *PeerManager) peerSetExempt(id config.NodeID) bool {
+	pse, ok := peMgr.peerSet[id]
+	return ok && (pse.reserved || pse.trusted)
+}
+
+//
+// Number of inbounds the accepter goes to before paused, beyond the total
+// inbound cap by the number of reserved and trusted peers, so they are always
+// accepted while others beyond the cap are killed after handshake.
+//
+func (peMgr *PeerManager) peerSetAcceptLimit() int {
+	return peMgr.cfg.ibpNumTotal + len(peMgr.peerSet)
+}
+
+//
+// Check if an inbound peer handshaked could be kept under the total inbound
+// cap, the instance itself is counted in ibpTotalNum already.
+//
+func (peMgr *PeerManager) peerSetInboundAllowed(id config.NodeID) bool {
+	return peMgr.ibpTotalNum <= peMgr.cfg.ibpNumTotal || peMgr.peerSetExempt(id)
+}
+
+//
+// Get the sub network to dial a reserved peer: the one its identity is masked
+// into if it's a local one, else the AnySubNet if it's a local one.
+//
+func (peMgr *PeerManager) peerSetSnid(id config.NodeID) (SubNetworkID, bool) {
+	if peMgr.cfg.networkType == config.P2pNetworkTypeStatic {
+		return peMgr.cfg.staticSubNetId, peMgr.nodes[peMgr.cfg.staticSubNetId] != nil
+	}
+	mbs := peMgr.sdl.SchGetP2pConfig().SnidMaskBits
+	if snid, err := tab.GetSubnetIdentity(id, mbs); err == nil {
+		if peMgr.dynamicSubNetIdExist(&snid) && peMgr.nodes[snid] != nil {
+			return snid, true
+		}
+	}
+	snid := config.AnySubNet
+	if peMgr.dynamicSubNetIdExist(&snid) && peMgr.nodes[snid] != nil {
+		return snid, true
+	}
+	return SubNetworkID{}, false
+}
+
+//
+// Check if peer is connected in any sub network
+//
+func (peMgr *PeerManager) peerSetConnected(id config.NodeID) bool {
+	for _, nodes := range peMgr.nodes {
+		if _, ok := nodes[PeerIdEx{Id: id, Dir: PeInstDirOutbound}]; ok {
+			return true
+		}
+		if _, ok := nodes[PeerIdEx{Id: id, Dir: PeInstDirInbound}]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// Reserved peers to be redialed: not connected, not banned, and not dialed
+// within durReservedRetry
+//
+func (peMgr *PeerManager) peerSetRedials(now time.Time) []PeerId {
+	ids := make([]PeerId, 0)
+	for id, pse := range peMgr.peerSet {
+		if !pse.reserved || now.Sub(pse.lastDial) < durReservedRetry {
+			continue
+		}
+		if peMgr.peerSetConnected(id) || peMgr.reputation.IsBanned(id) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+//
+// Dial reserved peers not connected, regardless of the caps
+//
+func (peMgr *PeerManager) peerSetOutbound() PeMgrErrno {
+	if peMgr.cfg.noDial || peMgr.inStartup != peMgrInStartup {
+		return PeMgrEnoNone
+	}
+	now := time.Now()
+	for _, id := range peMgr.peerSetRedials(now) {
+		pse := peMgr.peerSet[id]
+		snid, ok := peMgr.peerSetSnid(id)
+		if !ok {
+			log.Debugf("peerSetOutbound: no sub network, id: %x", id)
+			continue
+		}
+		pse.lastDial = now
+		node := pse.node
+		if eno := peMgr.peMgrCreateOutboundInst(&snid, &node); eno != PeMgrEnoNone {
+			log.Debugf("peerSetOutbound: peMgrCreateOutboundInst failed, snid: %x, id: %x, eno: %d",
+				snid, id, eno)
+		}
+	}
+	return PeMgrEnoNone
+}
+
+//
+// Add or remove reserved and trusted peers
+//
+func (peMgr *PeerManager) peMgrPeerSetReq(req *sch.MsgPePeerSetReq) PeMgrErrno {
+	var eno PeMgrErrno = PeMgrEnoNone
+	defer func() {
+		if req != nil && req.Result != nil {
+			req.Result <- int(eno)
+		}
+	}()
+	if req == nil {
+		eno = PeMgrEnoParameter
+		return eno
+	}
+	switch req.Op {
+	case sch.PSR_OP_ADD:
+		if req.Node.ID == peMgr.cfg.nodeId || req.Node.IP == nil || req.Node.TCP == 0 {
+			eno = PeMgrEnoParameter
+			return eno
+		}
+		peMgr.peerSetAdd(&req.Node, req.Trusted)
+	case sch.PSR_OP_REMOVE:
+		if _, ok := peMgr.peerSet[req.Node.ID]; !ok {
+			eno = PeMgrEnoNotfound
+			return eno
+		}
+		delete(peMgr.peerSet, req.Node.ID)
+	default:
+		eno = PeMgrEnoParameter
+		return eno
+	}
+	if err := peMgr.peerSetSave(); err != nil {
+		log.Debugf("peMgrPeerSetReq: save failed, file: %s, error: %s", peMgr.staticFile, err.Error())
+		eno = PeMgrEnoOs
+	}
+	// accepter might be paused before the limit raised
+	if req.Op == sch.PSR_OP_ADD && !peMgr.cfg.noAccept &&
+		peMgr.ibpTotalNum < peMgr.peerSetAcceptLimit() {
+		schMsg := sch.SchMessage{}
+		peMgr.sdl.SchMakeMessage(&schMsg, peMgr.ptnMe, peMgr.ptnLsn, sch.EvPeLsnStartReq, nil)
+		peMgr.sdl.SchSendMessage(&schMsg)
+	}
+	peMgr.peerSetOutbound()
+	return eno
+}
+
+//
+// List peers connected and those reserved or trusted
+//
+func (peMgr *PeerManager) peMgrPeerListReq(req *sch.MsgPePeerListReq) PeMgrErrno {
+	if req == nil || req.Result == nil {
+		return PeMgrEnoParameter
+	}
+	list := make([]sch.PePeerEntry, 0)
+	listed := make(map[config.NodeID]bool, 0)
+	for snid, workers := range peMgr.workers {
+		for _, inst := range workers {
+			pe := sch.PePeerEntry{
+				Node:      inst.node,
+				Snid:      snid,
+				Dir:       inst.dir,
+				Connected: true,
+			}
+			if pse, ok := peMgr.peerSet[inst.node.ID]; ok {
+				pe.Reserved = pse.reserved
+				pe.Trusted = pse.trusted
+			}
+			list = append(list, pe)
+			listed[inst.node.ID] = true
+		}
+	}
+	for id, pse := range peMgr.peerSet {
+		if listed[id] {
+			continue
+		}
+		list = append(list, sch.PePeerEntry{
+			Node:     pse.node,
+			Dir:      PeInstDirNull,
+			Reserved: pse.reserved,
+			Trusted:  pse.trusted,
+		})
+	}
+	req.Result <- list
+	return PeMgrEnoNone
+}
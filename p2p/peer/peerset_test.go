@@ -0,0 +1,162 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package peer
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	config "github.com/yeeco/gyee/p2p/config"
+	"github.com/yeeco/gyee/p2p/reputation"
+)
+
+func testPeerSetNode(i byte) *config.Node {
+	n := &config.Node{
+		IP:  net.ParseIP("127.0.0.1"),
+		UDP: 30300 + uint16(i),
+		TCP: 30300 + uint16(i),
+	}
+	n.ID[0] = i
+	n.ID[config.NodeIDBytes-1] = i
+	return n
+}
+
+func TestPeerSetStaticFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yee-peerset-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "sub", "static.json")
+
+	self := testPeerSetNode(9)
+	peMgr := NewPeerMgr()
+	peMgr.cfg.nodeId = self.ID
+	peMgr.peerSetSetup(&config.Cfg4PeerManager{
+		ReservedNodes: []*config.Node{testPeerSetNode(1), self},
+		TrustedNodes:  []*config.Node{testPeerSetNode(2)},
+		StaticFile:    file,
+	})
+	if len(peMgr.peerSet) != 2 {
+		t.Fatalf("self not skipped, peers %d", len(peMgr.peerSet))
+	}
+	if err := peMgr.peerSetSave(); err != nil {
+		t.Fatalf("peerSetSave() %v", err)
+	}
+
+	// reloaded from file only
+	loaded := NewPeerMgr()
+	loaded.peerSetSetup(&config.Cfg4PeerManager{StaticFile: file})
+	for i, reserved := range map[byte]bool{1: true, 2: false} {
+		n := testPeerSetNode(i)
+		pse, ok := loaded.peerSet[n.ID]
+		if !ok {
+			t.Fatalf("peer %d not loaded", i)
+		}
+		if pse.reserved != reserved || pse.trusted == reserved {
+			t.Errorf("peer %d reserved %t trusted %t", i, pse.reserved, pse.trusted)
+		}
+		if !pse.node.IP.Equal(n.IP) || pse.node.UDP != n.UDP || pse.node.TCP != n.TCP {
+			t.Errorf("peer %d address mismatch %v", i, pse.node)
+		}
+	}
+
+	// malformed file leaves configured peers only
+	if err := ioutil.WriteFile(file, []byte("{reserved"), 0600); err != nil {
+		t.Fatalf("WriteFile() %v", err)
+	}
+	broken := NewPeerMgr()
+	broken.peerSetSetup(&config.Cfg4PeerManager{
+		TrustedNodes: []*config.Node{testPeerSetNode(3)},
+		StaticFile:   file,
+	})
+	if len(broken.peerSet) != 1 || !broken.peerSetExempt(testPeerSetNode(3).ID) {
+		t.Fatalf("malformed file, peers %d", len(broken.peerSet))
+	}
+}
+
+func TestPeerSetCapExempt(t *testing.T) {
+	peMgr := NewPeerMgr()
+	peMgr.cfg.ibpNumTotal = 2
+	peMgr.peerSetSetup(&config.Cfg4PeerManager{
+		ReservedNodes: []*config.Node{testPeerSetNode(1)},
+		TrustedNodes:  []*config.Node{testPeerSetNode(2)},
+	})
+	if limit := peMgr.peerSetAcceptLimit(); limit != 4 {
+		t.Fatalf("accept limit %d", limit)
+	}
+
+	other := testPeerSetNode(3).ID
+	// the instance handshaked is counted in
+	peMgr.ibpTotalNum = 2
+	if !peMgr.peerSetInboundAllowed(other) {
+		t.Errorf("inbound under cap refused")
+	}
+	peMgr.ibpTotalNum = 3
+	if peMgr.peerSetInboundAllowed(other) {
+		t.Errorf("inbound beyond cap allowed")
+	}
+	for _, i := range []byte{1, 2} {
+		if !peMgr.peerSetInboundAllowed(testPeerSetNode(i).ID) {
+			t.Errorf("exempt peer %d refused beyond cap", i)
+		}
+	}
+}
+
+func TestPeerSetRedial(t *testing.T) {
+	peMgr := NewPeerMgr()
+	peMgr.reputation = reputation.NewReputation(nil)
+	peMgr.peerSetSetup(&config.Cfg4PeerManager{
+		ReservedNodes: []*config.Node{testPeerSetNode(1), testPeerSetNode(2), testPeerSetNode(3)},
+		TrustedNodes:  []*config.Node{testPeerSetNode(4)},
+	})
+	// 2 connected, 3 banned, 4 trusted only
+	snid := config.AnySubNet
+	peMgr.nodes[snid] = map[PeerIdEx]*PeerInstance{
+		{Id: testPeerSetNode(2).ID, Dir: PeInstDirInbound}: nil,
+	}
+	peMgr.reputation.Ban(testPeerSetNode(3).ID, "test", time.Minute)
+
+	now := time.Now()
+	redials := peMgr.peerSetRedials(now)
+	if len(redials) != 1 || redials[0] != testPeerSetNode(1).ID {
+		t.Fatalf("redials %v", redials)
+	}
+
+	// not redialed within durReservedRetry
+	peMgr.peerSet[testPeerSetNode(1).ID].lastDial = now
+	if redials := peMgr.peerSetRedials(now.Add(durReservedRetry / 2)); len(redials) != 0 {
+		t.Fatalf("redialed too soon %v", redials)
+	}
+	if redials := peMgr.peerSetRedials(now.Add(durReservedRetry)); len(redials) != 1 {
+		t.Fatalf("not redialed after retry duration %v", redials)
+	}
+
+	// disconnected peer redialed
+	delete(peMgr.nodes[snid], PeerIdEx{Id: testPeerSetNode(2).ID, Dir: PeInstDirInbound})
+	if redials := peMgr.peerSetRedials(now.Add(durReservedRetry)); len(redials) != 2 {
+		t.Fatalf("disconnected peer not redialed %v", redials)
+	}
+}
@@ -59,14 +59,15 @@ const (
 	MID_PONG      = pb.MessageId_MID_PONG      // pong
 	MID_CHKK      = pb.MessageId_MID_CHKK      // check key
 	MID_RPTK      = pb.MessageId_MID_RPTK      // report key
-	MID_GCD       = pb.MessageId_MID_GCD       // get chain data
-	MID_PCD       = pb.MessageId_MID_PCD       // put chain data
+	MID_CHREQ     = pb.MessageId_MID_CHREQ     // chain request
+	MID_CHRSP     = pb.MessageId_MID_CHRSP     // chain response
 
 	// external MID for PID_EXT
 	MID_TX          = pb.MessageId_MID_TX
 	MID_EVENT       = pb.MessageId_MID_EVENT
 	MID_BLOCKHEADER = pb.MessageId_MID_BLOCKHEADER
 	MID_BLOCK       = pb.MessageId_MID_BLOCK
+	MID_EVIDENCE    = pb.MessageId_MID_EVIDENCE
 
 	// invalid MID
 	MID_INVALID = pb.MessageId_MID_INVALID
@@ -94,14 +95,19 @@ type Protocol struct {
 //
 type Handshake struct {
 	ChainId   uint32		// chain identity
-	Snid      SubNetworkID  // sub network identity
-	Dir       int           // direct
-	NodeId    config.NodeID // node identity
-	IP        net.IP        // ip address
-	UDP       uint32        // udp port number
-	TCP       uint32        // tcp port number
-	ProtoNum  uint32        // number of protocols supported
-	Protocols []Protocol    // version of protocol
+	Snid      SubNetworkID      // sub network identity
+	Dir       int               // direct
+	NodeId    config.NodeID     // node identity
+	IP        net.IP            // ip address
+	UDP       uint32            // udp port number
+	TCP       uint32            // tcp port number
+	ProtoNum  uint32            // number of protocols supported
+	Protocols []Protocol        // version of protocol
+	SecKey    []byte            // ephemeral key for transport encryption
+	SecSign   []byte            // signature binds ephemeral keys to node identity
+	ValSign   []byte            // signature by validator key, proves validator membership
+	ValAddr   string            // validator address proven, not on wire
+	Addrs     []config.Endpoint // more endpoints of a multi-homed node
 }
 
 //
@@ -130,22 +136,42 @@ type ReportKey struct {
 }
 
 //
-// Get chain data
+// Chain request kinds
 //
-type GetChainData struct {
-	Seq		uint64	// sequence number
-	Name	string	// name
-	Key		[]byte	// key
+const (
+	CK_STATUS  = int32(pb.ChainKind_CK_STATUS)  // head hash, height and genesis hash
+	CK_HEADERS = int32(pb.ChainKind_CK_HEADERS) // headers from a block number
+	CK_BLOCKS  = int32(pb.ChainKind_CK_BLOCKS)  // blocks of hashes
+	CK_TXS     = int32(pb.ChainKind_CK_TXS)     // transactions of hashes
+	CK_PROOF   = int32(pb.ChainKind_CK_PROOF)   // merkle proof of key in state or consensus trie
+)
+
+//
+// Chain request
+//
+type ChainRequest struct {
+	Version	uint32		// protocol version
+	Seq		uint64		// sequence number
+	Kind	int32		// kind, CK_XXX
+	From	uint64		// first block number, for CK_HEADERS
+	Count	uint32		// number of headers, for CK_HEADERS
+	Hashes	[][]byte	// hashes, for CK_BLOCKS and CK_TXS
+	Root	[]byte		// trie root, for CK_PROOF
+	Key		[]byte		// trie key, for CK_PROOF
 }
 
 //
-// Put chain data
+// Chain response
 //
-type PutChainData struct {
-	Seq		uint64	// sequence number
-	Name	string	// name
-	Key		[]byte	// key
-	Data	[]byte	// data
+type ChainResponse struct {
+	Version	uint32		// protocol version
+	Seq		uint64		// sequence number of request
+	Kind	int32		// kind of request
+	Genesis	[]byte		// genesis hash, for CK_STATUS
+	Head	[]byte		// head hash, for CK_STATUS
+	Height	uint64		// head height, for CK_STATUS
+	Items	[][]byte	// encoded headers, blocks, transactions or proof nodes
+	Error	string		// error, empty if ok
 }
 
 //
@@ -178,8 +204,6 @@ type ExtMessage struct {
 	Mid		uint32     		// message identity
 	Chkk	*CheckKey  		// check key message
 	Rptk	*ReportKey		// report key message
-	Gcd		*GetChainData	// get chain data
-	Pcd		*PutChainData	// put chain data
 }
 
 //
@@ -295,6 +319,15 @@ func (upkg *P2pPackage) getHandshakeInbound(inst *PeerInstance) (*Handshake, PeM
 		copy(ptrMsg.Protocols[i].Ver[:], p.Ver)
 	}
 
+	ptrMsg.SecKey = append(ptrMsg.SecKey, pbHS.SecKey...)
+	ptrMsg.SecSign = append(ptrMsg.SecSign, pbHS.SecSign...)
+	ptrMsg.ValSign = append(ptrMsg.ValSign, pbHS.ValSign...)
+	var err error
+	if ptrMsg.Addrs, err = config.P2pDecodeEndpoints(pbHS.Addrs); err != nil {
+		log.Debugf("getHandshakeInbound: bad endpoint list, err: %s", err.Error())
+		return nil, PeMgrEnoMessage
+	}
+
 	return ptrMsg, PeMgrEnoNone
 }
 
@@ -321,6 +354,11 @@ func (upkg *P2pPackage) putHandshakeOutbound(inst *PeerInstance, hs *Handshake)
 		pbProto.Ver = append(pbProto.Ver, p.Ver[:]...)
 	}
 
+	pbHandshakeMsg.SecKey = hs.SecKey
+	pbHandshakeMsg.SecSign = hs.SecSign
+	pbHandshakeMsg.ValSign = hs.ValSign
+	pbHandshakeMsg.Addrs = config.P2pEncodeEndpoints(hs.Addrs)
+
 	if upkg.signOutbound(inst, pbHandshakeMsg) != true {
 		log.Debugf("putHandshakeOutbound: signOutbound failed")
 		return PeMgrEnoSign
@@ -584,93 +622,57 @@ func (upkg *P2pPackage) ReportKey(inst *PeerInstance, rptk *ReportKey, write boo
 }
 
 //
-// Get chain data
+// Chain request
 //
-func (upkg *P2pPackage) GetChainData(inst *PeerInstance, gcd *GetChainData, write bool) PeMgrErrno {
-	pbGcd := pb.ExtMessage{
-		Mid: new(pb.MessageId),
-		GetChainData: &pb.ExtMessage_GetChainData{
-			Seq: new(uint64),
-			Kind: []byte(gcd.Name),
-			Key: make([]byte, 0),
-		},
+func (upkg *P2pPackage) ChainRequest(inst *PeerInstance, req *ChainRequest, write bool) PeMgrErrno {
+	pbReq := pb.ChainRequest{
+		Version: req.Version,
+		Seq: req.Seq,
+		Kind: pb.ChainKind(req.Kind),
+		From: req.From,
+		Count: req.Count,
+		Hashes: req.Hashes,
+		Root: req.Root,
+		Key: req.Key,
 	}
-	*pbGcd.Mid = MID_GCD
-	*pbGcd.GetChainData.Seq = gcd.Seq
-	pbGcd.GetChainData.Key = append(pbGcd.GetChainData.Key, gcd.Key...)
-
-	payload, err := proto.Marshal(&pbGcd)
-	if len(payload) == 0 || err != nil {
-		log.Debugf("GetChainData: empty payload")
+	payload, err := proto.Marshal(&pbReq)
+	if err != nil {
+		log.Debugf("ChainRequest: Marshal failed, err: %s", err.Error())
 		return PeMgrEnoMessage
 	}
-
-	if !write {
-
-		upkg.Pid = uint32(PID_EXT)
-		upkg.Mid = uint32(*pbGcd.Mid)
-		upkg.Key = nil
-		upkg.PayloadLength = uint32(len(payload))
-		upkg.Payload = append(upkg.Payload, payload...)
-
-	} else {
-
-		pbPkg := pb.P2PPackage{
-			Pid:           new(pb.ProtocolId),
-			ExtMid:        new(pb.MessageId),
-			PayloadLength: new(uint32),
-			Payload:       make([]byte, 0),
-		}
-		*pbPkg.Pid = PID_EXT
-		*pbPkg.ExtMid = *pbGcd.Mid
-		pbPkg.ExtKey = nil
-		pbPkg.Payload = append(pbPkg.Payload, payload...)
-		*pbPkg.PayloadLength = uint32(len(payload))
-
-		if inst.ato != time.Duration(0) {
-			inst.conn.SetWriteDeadline(time.Now().Add(inst.ato))
-		} else {
-			inst.conn.SetWriteDeadline(time.Time{})
-		}
-
-		if err := inst.iow.WriteMsg(&pbPkg); err != nil {
-			log.Debugf("GetChainData: Write failed, err: %s", err.Error())
-			return PeMgrEnoOs
-		}
-	}
-
-	return PeMgrEnoNone
+	return upkg.extPayload(inst, MID_CHREQ, payload, write)
 }
 
 //
-// Put chain data
+// Chain response
 //
-func (upkg *P2pPackage) PutChainData(inst *PeerInstance, pcd *PutChainData, write bool) PeMgrErrno {
-	
-	pbPcd := pb.ExtMessage{
-		Mid: new(pb.MessageId),
-		PutChainData: &pb.ExtMessage_PutChainData{
-			Seq: new(uint64),
-			Kind: []byte(pcd.Name),
-			Key: make([]byte, 0),
-			Data: make([]byte, 0),
-		},
+func (upkg *P2pPackage) ChainResponse(inst *PeerInstance, rsp *ChainResponse, write bool) PeMgrErrno {
+	pbRsp := pb.ChainResponse{
+		Version: rsp.Version,
+		Seq: rsp.Seq,
+		Kind: pb.ChainKind(rsp.Kind),
+		Genesis: rsp.Genesis,
+		Head: rsp.Head,
+		Height: rsp.Height,
+		Items: rsp.Items,
+		Error: rsp.Error,
 	}
-	*pbPcd.Mid = MID_PCD
-	*pbPcd.PutChainData.Seq = pcd.Seq
-	pbPcd.PutChainData.Key = append(pbPcd.PutChainData.Key, pcd.Key...)
-	pbPcd.PutChainData.Data = append(pbPcd.PutChainData.Data, pcd.Data...)
-
-	payload, err := proto.Marshal(&pbPcd)
-	if len(payload) == 0 || err != nil {
-		log.Debugf("PutChainData: empty payload")
+	payload, err := proto.Marshal(&pbRsp)
+	if err != nil {
+		log.Debugf("ChainResponse: Marshal failed, err: %s", err.Error())
 		return PeMgrEnoMessage
 	}
+	return upkg.extPayload(inst, MID_CHRSP, payload, write)
+}
 
+//
+// Package payload of external message, or write it to peer
+//
+func (upkg *P2pPackage) extPayload(inst *PeerInstance, mid pb.MessageId, payload []byte, write bool) PeMgrErrno {
 	if !write {
 
 		upkg.Pid = uint32(PID_EXT)
-		upkg.Mid = uint32(*pbPcd.Mid)
+		upkg.Mid = uint32(mid)
 		upkg.Key = nil
 		upkg.PayloadLength = uint32(len(payload))
 		upkg.Payload = append(upkg.Payload, payload...)
@@ -684,7 +686,7 @@ func (upkg *P2pPackage) PutChainData(inst *PeerInstance, pcd *PutChainData, writ
 			Payload:       make([]byte, 0),
 		}
 		*pbPkg.Pid = PID_EXT
-		*pbPkg.ExtMid = *pbPcd.Mid
+		*pbPkg.ExtMid = mid
 		pbPkg.ExtKey = nil
 		pbPkg.Payload = append(pbPkg.Payload, payload...)
 		*pbPkg.PayloadLength = uint32(len(payload))
@@ -696,7 +698,7 @@ func (upkg *P2pPackage) PutChainData(inst *PeerInstance, pcd *PutChainData, writ
 		}
 
 		if err := inst.iow.WriteMsg(&pbPkg); err != nil {
-			log.Debugf("PutChainData: Write failed, err: %s", err.Error())
+			log.Debugf("extPayload: Write failed, mid: %d, err: %s", mid, err.Error())
 			return PeMgrEnoOs
 		}
 	}
@@ -860,8 +862,6 @@ func (upkg *P2pPackage) GetExtMessage(extMsg *ExtMessage) PeMgrErrno {
 	extMsg.Mid = uint32(*pbMsg.Mid)
 	extMsg.Chkk = nil
 	extMsg.Rptk = nil
-	extMsg.Gcd = nil
-	extMsg.Pcd = nil
 	if extMsg.Mid == uint32(MID_CHKK) {
 		chkk := new(CheckKey)
 		chkk.Key = append(chkk.Key, upkg.Key...)
@@ -871,19 +871,6 @@ func (upkg *P2pPackage) GetExtMessage(extMsg *ExtMessage) PeMgrErrno {
 		rptk.Key = append(rptk.Key, upkg.Key...)
 		rptk.Status = int32(*pbMsg.ReportKey.Status)
 		extMsg.Rptk = rptk
-	} else if extMsg.Mid == uint32(MID_GCD) {
-		gcd := new(GetChainData)
-		gcd.Seq = *pbMsg.GetChainData.Seq
-		gcd.Name = string(pbMsg.GetChainData.Kind[:])
-		gcd.Key = append(gcd.Key, pbMsg.GetChainData.Key...)
-		extMsg.Gcd = gcd
-	} else if extMsg.Mid == uint32(MID_PCD) {
-		pcd := new(PutChainData)
-		pcd.Seq = *pbMsg.PutChainData.Seq
-		pcd.Name = string(pbMsg.PutChainData.Kind[:])
-		pcd.Key = append(pcd.Key, pbMsg.PutChainData.Key...)
-		pcd.Data = append(pcd.Data, pbMsg.PutChainData.Data...)
-		extMsg.Pcd = pcd
 	} else {
 		log.Debugf("GetExtMessage: " +
 			"unknown message identity: %d",
@@ -894,6 +881,40 @@ func (upkg *P2pPackage) GetExtMessage(extMsg *ExtMessage) PeMgrErrno {
 	return PeMgrEnoNone
 }
 
+func (upkg *P2pPackage) GetChainRequest(req *ChainRequest) PeMgrErrno {
+	pbReq := new(pb.ChainRequest)
+	if err := proto.Unmarshal(upkg.Payload, pbReq); err != nil {
+		log.Debugf("GetChainRequest: Unmarshal failed, err: %s", err.Error())
+		return PeMgrEnoMessage
+	}
+	req.Version = pbReq.Version
+	req.Seq = pbReq.Seq
+	req.Kind = int32(pbReq.Kind)
+	req.From = pbReq.From
+	req.Count = pbReq.Count
+	req.Hashes = pbReq.Hashes
+	req.Root = pbReq.Root
+	req.Key = pbReq.Key
+	return PeMgrEnoNone
+}
+
+func (upkg *P2pPackage) GetChainResponse(rsp *ChainResponse) PeMgrErrno {
+	pbRsp := new(pb.ChainResponse)
+	if err := proto.Unmarshal(upkg.Payload, pbRsp); err != nil {
+		log.Debugf("GetChainResponse: Unmarshal failed, err: %s", err.Error())
+		return PeMgrEnoMessage
+	}
+	rsp.Version = pbRsp.Version
+	rsp.Seq = pbRsp.Seq
+	rsp.Kind = int32(pbRsp.Kind)
+	rsp.Genesis = pbRsp.Genesis
+	rsp.Head = pbRsp.Head
+	rsp.Height = pbRsp.Height
+	rsp.Items = pbRsp.Items
+	rsp.Error = pbRsp.Error
+	return PeMgrEnoNone
+}
+
 func (upkg *P2pPackage) signOutbound(inst *PeerInstance, hs *pb.P2PMessage_Handshake) bool {
 	r, s, err := config.P2pSign(&inst.priKey, hs.NodeId)
 	if err != nil {
@@ -931,12 +952,14 @@ func (rk *ReportKey) String() string {
 	return fmt.Sprintf("ReportKey: status: %d, key: %x", rk.Status, rk.Key)
 }
 
-func (gcd *GetChainData) String() string {
-	return fmt.Sprintf("GetChainData: seq: %d, name: %s, key: %x", gcd.Seq, gcd.Name, gcd.Key)
+func (req *ChainRequest) String() string {
+	return fmt.Sprintf("ChainRequest: version: %d, seq: %d, kind: %d, from: %d, count: %d, hashes: %d",
+		req.Version, req.Seq, req.Kind, req.From, req.Count, len(req.Hashes))
 }
 
-func (pcd *PutChainData) String() string {
-	return fmt.Sprintf("PutChainData: seq: %d, name: %s, key: %x, data: %x", pcd.Seq, pcd.Name, pcd.Key, pcd.Data)
+func (rsp *ChainResponse) String() string {
+	return fmt.Sprintf("ChainResponse: version: %d, seq: %d, kind: %d, height: %d, items: %d, error: %s",
+		rsp.Version, rsp.Seq, rsp.Kind, rsp.Height, len(rsp.Items), rsp.Error)
 }
 
 func (upkg *P2pPackage) DebugPeerPackage() {
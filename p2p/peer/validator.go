@@ -0,0 +1,199 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package peer
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/crypto/hash"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+	"github.com/yeeco/gyee/log"
+	config "github.com/yeeco/gyee/p2p/config"
+	sch "github.com/yeeco/gyee/p2p/scheduler"
+)
+
+//
+// Validator set: a peer proves in handshake that it holds the key of a
+// validator by signing the hash of chain identity, its node identity and the
+// ephemeral key of the handshake with that key, the address recovered from the
+// signature must be one of the current validators, which come from the
+// consensus trie and are updated with EvPeValidatorSetReq when validators
+// rotate. peers claiming a validator not in the set are refused, and so are
+// peers into the validators' sub network without a proof. the proof is only
+// given and taken over an encrypted session, since the key exchange is what
+// binds it to the ephemeral key, over a plaintext one it could be replayed by
+// anyone. once a set is installed, tetris events are exchanged with proven
+// validators only, see ValidatorPeer.
+//
+type validatorSet struct {
+	lock    sync.RWMutex                      // instances read the set in handshakes
+	members map[string]bool                   // current validators, nil if not installed
+	self    string                            // local validator address, "" if none
+	sign    func(hash []byte) ([]byte, error) // signs with the local validator key
+}
+
+//
+// Hash signed as the validator proof of handshake
+//
+func validatorProofHash(chainId uint32, id config.NodeID, secKey []byte) []byte {
+	cid := make([]byte, 4)
+	binary.BigEndian.PutUint32(cid, chainId)
+	return hash.Sha3256(cid, id[0:], secKey)
+}
+
+//
+// Attach the validator proof to outbound handshake if local node is one of
+// the validators, nothing is proven without an ephemeral key of encryption
+//
+func (peMgr *PeerManager) validatorProve(hs *Handshake) {
+	if len(hs.SecKey) == 0 {
+		return
+	}
+	vs := &peMgr.valSet
+	vs.lock.RLock()
+	defer vs.lock.RUnlock()
+	if vs.sign == nil || !vs.members[vs.self] {
+		return
+	}
+	sig, err := vs.sign(validatorProofHash(hs.ChainId, hs.NodeId, hs.SecKey))
+	if err != nil {
+		log.Debugf("validatorProve: sign failed, error: %s", err.Error())
+		return
+	}
+	hs.ValSign = sig
+}
+
+//
+// Check the validator proof of inbound handshake, the address proven is
+// backup into the handshake. secured tells if the key exchange of session
+// completed, proofs and the validators' sub network are refused if not.
+//
+func (peMgr *PeerManager) validatorVerify(chainId uint32, hs *Handshake, secured bool) bool {
+	hs.ValAddr = ""
+	if !secured && (len(hs.ValSign) != 0 || hs.Snid == config.VSubNet) {
+		log.Debugf("validatorVerify: plaintext session, snid: %x, peer: %x", hs.Snid, hs.NodeId)
+		return false
+	}
+	if len(hs.ValSign) == 0 {
+		return hs.Snid != config.VSubNet
+	}
+	pub, err := secp256k1.RecoverPubkey(validatorProofHash(chainId, hs.NodeId, hs.SecKey), hs.ValSign)
+	if err != nil {
+		log.Debugf("validatorVerify: RecoverPubkey failed, error: %s", err.Error())
+		return false
+	}
+	addr, err := address.NewAddressFromPublicKey(pub)
+	if err != nil {
+		log.Debugf("validatorVerify: NewAddressFromPublicKey failed, error: %s", err.Error())
+		return false
+	}
+	vs := &peMgr.valSet
+	vs.lock.RLock()
+	defer vs.lock.RUnlock()
+	if vs.members != nil && !vs.members[addr.String()] {
+		log.Debugf("validatorVerify: not a validator, addr: %s, peer: %x", addr.String(), hs.NodeId)
+		return false
+	}
+	hs.ValAddr = addr.String()
+	return true
+}
+
+//
+// Is validator set installed
+//
+func (peMgr *PeerManager) ValidatorGated() bool {
+	peMgr.valSet.lock.RLock()
+	defer peMgr.valSet.lock.RUnlock()
+	return peMgr.valSet.members != nil
+}
+
+//
+// Is the address proven by a peer one of current validators, always true if
+// no set installed
+//
+func (peMgr *PeerManager) ValidatorPeer(addr string) bool {
+	peMgr.valSet.lock.RLock()
+	defer peMgr.valSet.lock.RUnlock()
+	return peMgr.valSet.members == nil || (addr != "" && peMgr.valSet.members[addr])
+}
+
+//
+// Update validator set: peers proven for validators quitted are closed, and
+// when local node joins the set, peers proven for validators are closed also
+// so they would be reconnected with our proof.
+//
+func (peMgr *PeerManager) peMgrValidatorSetReq(req *sch.MsgPeValidatorSetReq) PeMgrErrno {
+	var eno PeMgrErrno = PeMgrEnoNone
+	defer func() {
+		if req != nil && req.Result != nil {
+			req.Result <- int(eno)
+		}
+	}()
+	if req == nil || req.Validators == nil {
+		eno = PeMgrEnoParameter
+		return eno
+	}
+
+	members := make(map[string]bool, len(req.Validators))
+	for _, v := range req.Validators {
+		members[v] = true
+	}
+	vs := &peMgr.valSet
+	vs.lock.Lock()
+	joined := req.Self != "" && members[req.Self] && (vs.members == nil || !vs.members[vs.self])
+	vs.members = members
+	vs.self = req.Self
+	vs.sign = req.Sign
+	vs.lock.Unlock()
+
+	log.Debugf("peMgrValidatorSetReq: validators: %d, self: %s, joined: %t",
+		len(members), req.Self, joined)
+
+	for snid, ids := range peMgr.validatorClosing(members, joined) {
+		snid := snid
+		for _, id := range ids {
+			id := id
+			peMgr.ClosePeer(&snid, &id)
+		}
+	}
+	return eno
+}
+
+//
+// Peers to close on validator set update, those proven for validators not in
+// the members, or all proven peers if local node joined
+//
+func (peMgr *PeerManager) validatorClosing(members map[string]bool, joined bool) map[SubNetworkID][]PeerId {
+	closing := make(map[SubNetworkID][]PeerId, 0)
+	for snid, workers := range peMgr.workers {
+		for _, inst := range workers {
+			if inst.valAddr == "" {
+				continue
+			}
+			if !members[inst.valAddr] || joined {
+				closing[snid] = append(closing[snid], inst.node.ID)
+			}
+		}
+	}
+	return closing
+}
@@ -0,0 +1,283 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/crypto/secp256k1"
+	config "github.com/yeeco/gyee/p2p/config"
+	sch "github.com/yeeco/gyee/p2p/scheduler"
+)
+
+const testValChainId = 1
+
+type testValidator struct {
+	addr string
+	sign func(hash []byte) ([]byte, error)
+}
+
+func newTestValidator(t *testing.T) *testValidator {
+	key := secp256k1.GenerateKey()
+	addr, err := address.NewAddressFromPublicKey(key.PublicKey())
+	if err != nil {
+		t.Fatalf("NewAddressFromPublicKey() %v", err)
+	}
+	return &testValidator{
+		addr: addr.String(),
+		sign: func(hash []byte) ([]byte, error) {
+			return secp256k1.Sign(hash, key.PrivateKey())
+		},
+	}
+}
+
+func testValidatorSet(t *testing.T, peMgr *PeerManager, self *testValidator, vals ...*testValidator) {
+	req := &sch.MsgPeValidatorSetReq{
+		Validators: make([]string, 0, len(vals)),
+		Result:     make(chan int, 1),
+	}
+	for _, v := range vals {
+		req.Validators = append(req.Validators, v.addr)
+	}
+	if self != nil {
+		req.Self, req.Sign = self.addr, self.sign
+	}
+	if eno := peMgr.peMgrValidatorSetReq(req); eno != PeMgrEnoNone {
+		t.Fatalf("peMgrValidatorSetReq() %d", eno)
+	}
+	if r := <-req.Result; r != int(PeMgrEnoNone) {
+		t.Fatalf("peMgrValidatorSetReq() result %d", r)
+	}
+}
+
+func testValidatorHandshake(snid SubNetworkID) *Handshake {
+	hs := &Handshake{
+		ChainId: testValChainId,
+		Snid:    snid,
+		SecKey:  []byte("ephemeral key of handshake"),
+	}
+	hs.NodeId[0] = 0x5a
+	return hs
+}
+
+func TestValidatorProofRoundTrip(t *testing.T) {
+	a, b := newTestValidator(t), newTestValidator(t)
+	local, remote := NewPeerMgr(), NewPeerMgr()
+	testValidatorSet(t, local, a, a, b)
+	testValidatorSet(t, remote, b, a, b)
+
+	hs := testValidatorHandshake(config.VSubNet)
+	local.validatorProve(hs)
+	if len(hs.ValSign) == 0 {
+		t.Fatal("validator not proving")
+	}
+	if !remote.validatorVerify(testValChainId, hs, true) {
+		t.Fatal("proof refused")
+	}
+	if hs.ValAddr != a.addr {
+		t.Errorf("proven %s want %s", hs.ValAddr, a.addr)
+	}
+	if !remote.ValidatorGated() || !remote.ValidatorPeer(hs.ValAddr) {
+		t.Error("proven validator not a validator peer")
+	}
+
+	// non-validator proves nothing, refused in validators' sub network only
+	hs = testValidatorHandshake(config.VSubNet)
+	NewPeerMgr().validatorProve(hs)
+	if len(hs.ValSign) != 0 {
+		t.Fatal("proof without validator key")
+	}
+	if remote.validatorVerify(testValChainId, hs, true) {
+		t.Error("unproven peer accepted into validators' sub network")
+	}
+	hs.Snid = config.AnySubNet
+	if !remote.validatorVerify(testValChainId, hs, true) || hs.ValAddr != "" {
+		t.Errorf("unproven peer refused, addr %q", hs.ValAddr)
+	}
+}
+
+func TestValidatorPlaintext(t *testing.T) {
+	a := newTestValidator(t)
+	peMgr := NewPeerMgr()
+	testValidatorSet(t, peMgr, a, a)
+
+	// no proof signed without an ephemeral key
+	hs := testValidatorHandshake(config.AnySubNet)
+	hs.SecKey = nil
+	peMgr.validatorProve(hs)
+	if len(hs.ValSign) != 0 {
+		t.Fatal("proof signed on plaintext session")
+	}
+	if !peMgr.validatorVerify(testValChainId, hs, false) {
+		t.Error("plaintext peer refused out of validators' sub network")
+	}
+	hs.Snid = config.VSubNet
+	if peMgr.validatorVerify(testValChainId, hs, false) {
+		t.Error("plaintext peer accepted into validators' sub network")
+	}
+
+	// a proof is replayable without the key exchange, refused even if valid
+	hs = testValidatorHandshake(config.AnySubNet)
+	peMgr.validatorProve(hs)
+	if len(hs.ValSign) == 0 {
+		t.Fatal("validator not proving")
+	}
+	if peMgr.validatorVerify(testValChainId, hs, false) || hs.ValAddr != "" {
+		t.Errorf("proof accepted on plaintext session, addr %q", hs.ValAddr)
+	}
+	if !peMgr.validatorVerify(testValChainId, hs, true) || hs.ValAddr != a.addr {
+		t.Errorf("proof refused on encrypted session, addr %q", hs.ValAddr)
+	}
+}
+
+func TestValidatorBadSignature(t *testing.T) {
+	a := newTestValidator(t)
+	peMgr := NewPeerMgr()
+	testValidatorSet(t, peMgr, a, a)
+
+	hs := testValidatorHandshake(config.VSubNet)
+	peMgr.validatorProve(hs)
+	good := append([]byte{}, hs.ValSign...)
+
+	// proof bound to chain, node and ephemeral key
+	if peMgr.validatorVerify(testValChainId+1, hs, true) {
+		t.Error("proof for other chain accepted")
+	}
+	hs.NodeId[1] ^= 0xff
+	if peMgr.validatorVerify(testValChainId, hs, true) {
+		t.Error("proof for other node accepted")
+	}
+	hs.NodeId[1] ^= 0xff
+	hs.SecKey = []byte("replayed handshake")
+	if peMgr.validatorVerify(testValChainId, hs, true) {
+		t.Error("proof for other ephemeral key accepted")
+	}
+
+	hs = testValidatorHandshake(config.VSubNet)
+	hs.ValSign = good[:len(good)-1]
+	if peMgr.validatorVerify(testValChainId, hs, true) {
+		t.Error("truncated signature accepted")
+	}
+	hs.ValSign = make([]byte, len(good))
+	if peMgr.validatorVerify(testValChainId, hs, true) {
+		t.Error("zero signature accepted")
+	}
+	if hs.ValAddr != "" {
+		t.Errorf("address %q left by refused proof", hs.ValAddr)
+	}
+}
+
+func TestValidatorNonMember(t *testing.T) {
+	a, b, c := newTestValidator(t), newTestValidator(t), newTestValidator(t)
+
+	// c signs, but is not in the set of the verifier
+	prover := NewPeerMgr()
+	testValidatorSet(t, prover, c, a, c)
+	peMgr := NewPeerMgr()
+	testValidatorSet(t, peMgr, a, a, b)
+
+	hs := testValidatorHandshake(config.VSubNet)
+	prover.validatorProve(hs)
+	if peMgr.validatorVerify(testValChainId, hs, true) {
+		t.Error("non-member accepted")
+	}
+	if peMgr.ValidatorPeer(c.addr) || peMgr.ValidatorPeer("") {
+		t.Error("non-member is a validator peer")
+	}
+
+	// a validator not in its own set does not prove
+	hs = testValidatorHandshake(config.VSubNet)
+	outsider := NewPeerMgr()
+	testValidatorSet(t, outsider, c, a, b)
+	outsider.validatorProve(hs)
+	if len(hs.ValSign) != 0 {
+		t.Error("proof by non-member")
+	}
+
+	// any proof accepted before a set is installed
+	open := NewPeerMgr()
+	if open.ValidatorGated() || !open.ValidatorPeer("") {
+		t.Error("gated without set")
+	}
+	hs = testValidatorHandshake(config.VSubNet)
+	prover.validatorProve(hs)
+	if !open.validatorVerify(testValChainId, hs, true) || hs.ValAddr != c.addr {
+		t.Errorf("proof refused without set, addr %q", hs.ValAddr)
+	}
+}
+
+func TestValidatorSetUpdate(t *testing.T) {
+	a, b, c := newTestValidator(t), newTestValidator(t), newTestValidator(t)
+	peMgr := NewPeerMgr()
+
+	req := &sch.MsgPeValidatorSetReq{Result: make(chan int, 1)}
+	if eno := peMgr.peMgrValidatorSetReq(req); eno != PeMgrEnoParameter || <-req.Result != int(PeMgrEnoParameter) {
+		t.Errorf("nil validators, eno %d", eno)
+	}
+	if peMgr.ValidatorGated() {
+		t.Error("gated by refused set")
+	}
+
+	testValidatorSet(t, peMgr, nil, a, b)
+	hs := testValidatorHandshake(config.VSubNet)
+	peMgr.validatorProve(hs)
+	if len(hs.ValSign) != 0 {
+		t.Error("proof without local validator")
+	}
+
+	// b quits, c joins
+	snid := config.VSubNet
+	proven := map[string]*PeerInstance{}
+	peMgr.workers[snid] = map[PeerIdEx]*PeerInstance{}
+	for i, v := range []*testValidator{a, b, c} {
+		inst := &PeerInstance{valAddr: v.addr}
+		inst.node.ID[0] = byte(i + 1)
+		peMgr.workers[snid][PeerIdEx{Id: inst.node.ID, Dir: PeInstDirInbound}] = inst
+		proven[v.addr] = inst
+	}
+	plain := &PeerInstance{}
+	plain.node.ID[0] = 0xff
+	peMgr.workers[snid][PeerIdEx{Id: plain.node.ID, Dir: PeInstDirInbound}] = plain
+
+	members := map[string]bool{a.addr: true, c.addr: true}
+	closing := peMgr.validatorClosing(members, false)
+	if ids := closing[snid]; len(ids) != 1 || ids[0] != proven[b.addr].node.ID {
+		t.Errorf("closing %v, want quitted validator only", ids)
+	}
+
+	// local node joins, all proven peers reconnect with our proof
+	closing = peMgr.validatorClosing(members, true)
+	if ids := closing[snid]; len(ids) != 3 {
+		t.Errorf("closing %d on join, want 3", len(ids))
+	}
+
+	delete(peMgr.workers, snid)
+	testValidatorSet(t, peMgr, a, a, c)
+	if peMgr.ValidatorPeer(b.addr) || !peMgr.ValidatorPeer(c.addr) {
+		t.Error("set not updated")
+	}
+	hs = testValidatorHandshake(config.VSubNet)
+	peMgr.validatorProve(hs)
+	if len(hs.ValSign) == 0 {
+		t.Error("joined validator not proving")
+	}
+}
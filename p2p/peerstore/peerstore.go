@@ -0,0 +1,332 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package peerstore
+
+//
+// Peer store: peers worked well recently, with the EWMA of their latencies and
+// the time they were last seen, are kept in a file under the node data directory,
+// so that a restarted node could connect to them before going to the bootstrap
+// nodes and the discovery, which might be all down. the store is saved when
+// flushed periodically by the owner task and when the owner is powered off.
+//
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/p2p/config"
+)
+
+const (
+	DefaultMaxPeers = 256               // max peers kept in a store
+	DefaultMaxAge   = time.Hour * 72    // peers not seen for this long are dropped
+	FlushInterval   = time.Minute * 5   // interval for owners to flush a store
+	latencyFactor   = 0.1               // factor for latency EWMA
+	fileMode        = os.FileMode(0600) // mode of store file
+)
+
+// Peer record
+type Peer struct {
+	Snid     config.SubNetworkID // sub network identity
+	Node     config.Node         // node specification
+	Latency  time.Duration       // EWMA of latencies, zero if not sampled
+	LastSeen time.Time           // time the peer was last seen
+}
+
+// Record as saved in file
+type peerJson struct {
+	Snid     string         `json:"snid"`
+	ID       string         `json:"id"`
+	IP       string         `json:"ip"`
+	UDP      uint16         `json:"udp"`
+	TCP      uint16         `json:"tcp"`
+	Addrs    []endpointJson `json:"addrs,omitempty"`
+	Latency  int64          `json:"latency"`
+	LastSeen int64          `json:"lastSeen"`
+}
+
+// More endpoints of a multi-homed peer as saved in file
+type endpointJson struct {
+	IP  string `json:"ip"`
+	UDP uint16 `json:"udp"`
+	TCP uint16 `json:"tcp"`
+}
+
+type peerKey struct {
+	snid config.SubNetworkID
+	id   config.NodeID
+}
+
+// Peer store
+type Store struct {
+	lock   sync.Mutex        // lock for table
+	path   string            // file path, nothing saved if empty
+	max    int               // max peers kept
+	maxAge time.Duration     // max age of peers kept
+	peers  map[peerKey]*Peer // peers
+	dirty  bool              // changed since last saved
+	saved  time.Time         // time last saved
+}
+
+var ErrNoPath = errors.New("peerstore: no file path")
+
+// Open a store, peers saved in the file are loaded if it exists. an empty path
+// makes a store in memory only.
+func Open(path string, max int, maxAge time.Duration) *Store {
+	if max <= 0 {
+		max = DefaultMaxPeers
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	ps := &Store{
+		path:   path,
+		max:    max,
+		maxAge: maxAge,
+		peers:  make(map[peerKey]*Peer, 0),
+		saved:  time.Now(),
+	}
+	if len(path) != 0 {
+		if err := ps.load(); err != nil && !os.IsNotExist(err) {
+			log.Warnf("Open: load failed, path: %s, error: %s", path, err.Error())
+		}
+	}
+	return ps
+}
+
+// Tell that a peer is seen, the record is added if not found
+func (ps *Store) Seen(snid config.SubNetworkID, node *config.Node) {
+	if node == nil {
+		return
+	}
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	key := peerKey{snid: snid, id: node.ID}
+	p, ok := ps.peers[key]
+	if !ok {
+		p = &Peer{Snid: snid}
+		ps.peers[key] = p
+	}
+	p.Node = *node
+	p.Node.IP = append(net.IP{}, node.IP...)
+	p.Node.Addrs = append([]config.Endpoint{}, node.Addrs...)
+	p.LastSeen = time.Now()
+	ps.dirty = true
+	if !ok && len(ps.peers) > ps.max {
+		ps.evict()
+	}
+}
+
+// Sample the latency of a peer already in the store
+func (ps *Store) Sample(snid config.SubNetworkID, id config.NodeID, latency time.Duration) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	p, ok := ps.peers[peerKey{snid: snid, id: id}]
+	if !ok {
+		return
+	}
+	if p.Latency == 0 {
+		p.Latency = latency
+	} else {
+		p.Latency = time.Duration((1-latencyFactor)*float64(p.Latency) + latencyFactor*float64(latency))
+	}
+	p.LastSeen = time.Now()
+	ps.dirty = true
+}
+
+// Remove a peer from the store, for all sub networks
+func (ps *Store) Remove(id config.NodeID) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	for key := range ps.peers {
+		if key.id == id {
+			delete(ps.peers, key)
+			ps.dirty = true
+		}
+	}
+}
+
+// Get peers of a sub network, config.AnySubNet for all. peers not expired are
+// returned, those seen lately and then those with lower latencies first, and
+// at most n peers returned if n is positive.
+func (ps *Store) Peers(snid config.SubNetworkID, n int) []Peer {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	now := time.Now()
+	peers := make([]Peer, 0, len(ps.peers))
+	for key, p := range ps.peers {
+		if snid != config.AnySubNet && key.snid != snid {
+			continue
+		}
+		if now.Sub(p.LastSeen) > ps.maxAge {
+			continue
+		}
+		peers = append(peers, *p)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		ti := peers[i].LastSeen.Truncate(time.Minute)
+		tj := peers[j].LastSeen.Truncate(time.Minute)
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return latencyRank(peers[i].Latency) < latencyRank(peers[j].Latency)
+	})
+	if n > 0 && len(peers) > n {
+		peers = peers[:n]
+	}
+	return peers
+}
+
+// Save the store if it's changed since last saved and interval passed
+func (ps *Store) Flush(interval time.Duration) error {
+	ps.lock.Lock()
+	due := ps.dirty && time.Since(ps.saved) >= interval
+	ps.lock.Unlock()
+	if !due {
+		return nil
+	}
+	return ps.Save()
+}
+
+// Save the store to file, it's written to a temporary file and then renamed,
+// so that a crash while saving would not wreck the file saved before.
+func (ps *Store) Save() error {
+	if len(ps.path) == 0 {
+		return ErrNoPath
+	}
+	ps.lock.Lock()
+	now := time.Now()
+	recs := make([]peerJson, 0, len(ps.peers))
+	for key, p := range ps.peers {
+		if now.Sub(p.LastSeen) > ps.maxAge {
+			delete(ps.peers, key)
+			continue
+		}
+		rec := peerJson{
+			Snid:     hex.EncodeToString(p.Snid[:]),
+			ID:       hex.EncodeToString(p.Node.ID[:]),
+			IP:       p.Node.IP.String(),
+			UDP:      p.Node.UDP,
+			TCP:      p.Node.TCP,
+			Latency:  int64(p.Latency),
+			LastSeen: p.LastSeen.Unix(),
+		}
+		for _, ep := range p.Node.Addrs {
+			rec.Addrs = append(rec.Addrs, endpointJson{IP: ep.IP.String(), UDP: ep.UDP, TCP: ep.TCP})
+		}
+		recs = append(recs, rec)
+	}
+	ps.dirty = false
+	ps.saved = now
+	ps.lock.Unlock()
+
+	data, err := json.MarshalIndent(recs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(ps.path), 0700); err != nil {
+		return err
+	}
+	tmp := ps.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, fileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ps.path)
+}
+
+func (ps *Store) load() error {
+	data, err := ioutil.ReadFile(ps.path)
+	if err != nil {
+		return err
+	}
+	recs := make([]peerJson, 0)
+	if err := json.Unmarshal(data, &recs); err != nil {
+		return err
+	}
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	now := time.Now()
+	for _, r := range recs {
+		p := Peer{
+			Latency:  time.Duration(r.Latency),
+			LastSeen: time.Unix(r.LastSeen, 0),
+		}
+		if now.Sub(p.LastSeen) > ps.maxAge {
+			continue
+		}
+		if b, err := hex.DecodeString(r.Snid); err != nil || len(b) != len(p.Snid) {
+			continue
+		} else {
+			copy(p.Snid[:], b)
+		}
+		if b, err := hex.DecodeString(r.ID); err != nil || len(b) != len(p.Node.ID) {
+			continue
+		} else {
+			copy(p.Node.ID[:], b)
+		}
+		if p.Node.IP = net.ParseIP(r.IP); p.Node.IP == nil {
+			continue
+		}
+		p.Node.UDP = r.UDP
+		p.Node.TCP = r.TCP
+		for _, a := range r.Addrs {
+			if ip := net.ParseIP(a.IP); ip != nil {
+				p.Node.Addrs = append(p.Node.Addrs, config.Endpoint{IP: ip, UDP: a.UDP, TCP: a.TCP})
+			}
+		}
+		ps.peers[peerKey{snid: p.Snid, id: p.Node.ID}] = &p
+	}
+	if len(ps.peers) > ps.max {
+		ps.evict()
+	}
+	return nil
+}
+
+// Evict peers seen earliest till the table is within its capacity
+func (ps *Store) evict() {
+	peers := make([]*Peer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		peers = append(peers, p)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].LastSeen.Before(peers[j].LastSeen)
+	})
+	for _, p := range peers[:len(peers)-ps.max] {
+		delete(ps.peers, peerKey{snid: p.Snid, id: p.Node.ID})
+	}
+}
+
+// Latency not sampled ranks after all sampled
+func latencyRank(latency time.Duration) time.Duration {
+	if latency <= 0 {
+		return time.Duration(1<<63 - 1)
+	}
+	return latency
+}
@@ -0,0 +1,79 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package peerstore
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/p2p/config"
+)
+
+func testNode(b byte) *config.Node {
+	n := &config.Node{IP: net.IPv4(10, 0, 0, b), UDP: 30303, TCP: 30303}
+	n.ID[0] = b
+	return n
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peers.json")
+	ps := Open(path, 0, 0)
+	ps.Seen(config.ZeroSubNet, testNode(1))
+	ps.Seen(config.ZeroSubNet, testNode(2))
+	ps.Sample(config.ZeroSubNet, testNode(2).ID, time.Millisecond*20)
+	if err := ps.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	ps = Open(path, 0, 0)
+	peers := ps.Peers(config.AnySubNet, 0)
+	if len(peers) != 2 {
+		t.Fatalf("expected 2 peers, got %d", len(peers))
+	}
+	if peers[0].Node.ID != testNode(2).ID || peers[0].Latency != time.Millisecond*20 {
+		t.Fatalf("sampled peer should go first: %+v", peers[0])
+	}
+	if !peers[1].Node.IP.Equal(testNode(1).IP) || peers[1].Node.TCP != 30303 {
+		t.Fatalf("bad peer loaded: %+v", peers[1])
+	}
+}
+
+func TestEvict(t *testing.T) {
+	ps := Open("", 2, 0)
+	for b := byte(1); b <= 3; b++ {
+		ps.Seen(config.ZeroSubNet, testNode(b))
+		time.Sleep(time.Millisecond)
+	}
+	peers := ps.Peers(config.ZeroSubNet, 0)
+	if len(peers) != 2 {
+		t.Fatalf("expected 2 peers, got %d", len(peers))
+	}
+	for _, p := range peers {
+		if p.Node.ID == testNode(1).ID {
+			t.Fatalf("earliest peer should be evicted")
+		}
+	}
+	if ps.Save() != ErrNoPath {
+		t.Fatalf("store in memory should not be saved")
+	}
+}
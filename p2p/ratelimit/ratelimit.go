@@ -0,0 +1,285 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package ratelimit
+
+//
+// Flow control of peers: token buckets limiting the messages and bytes sent to
+// or received from a peer, and meters accounting the traffic of peers and sub
+// networks by message identity. a message larger than the burst is let through
+// by a full bucket which then falls into deficit, the deficit is paid back in
+// time before any more is allowed, so such messages are not starved.
+//
+
+import (
+	"sync"
+	"time"
+)
+
+//
+// Token bucket, a nil bucket is unlimited
+//
+type Bucket struct {
+	lock   sync.Mutex // lock for tokens
+	rate   float64    // tokens refilled per second
+	burst  float64    // max tokens the bucket holds
+	tokens float64    // tokens in bucket, negative for deficit
+	last   time.Time  // time tokens last refilled
+}
+
+//
+// Create bucket refilled with rate tokens per second, the burst is one second
+// of rate if not larger. nil is returned for rate not positive.
+//
+func NewBucket(rate int, burst int) *Bucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < rate {
+		burst = rate
+	}
+	return &Bucket{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *Bucket) refill(now time.Time) {
+	if d := now.Sub(b.last).Seconds(); d > 0 {
+		if b.tokens += d * b.rate; b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+func (b *Bucket) enough(n int) bool {
+	need := float64(n)
+	if need > b.burst {
+		need = b.burst
+	}
+	return b.tokens >= need
+}
+
+//
+// Check if n tokens could be taken
+//
+func (b *Bucket) Ready(n int) bool {
+	if b == nil {
+		return true
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill(time.Now())
+	return b.enough(n)
+}
+
+//
+// Take n tokens if there are enough
+//
+func (b *Bucket) Allow(n int) bool {
+	if b == nil {
+		return true
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill(time.Now())
+	if !b.enough(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+//
+// Take n tokens anyway, and return how long it takes to pay back the deficit
+//
+func (b *Bucket) Reserve(n int) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill(time.Now())
+	if b.tokens -= float64(n); b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+//
+// Rate limit, in messages and bytes per second, zero for unlimited
+//
+type Limit struct {
+	Msgs  int // messages per second
+	Bytes int // bytes per second
+}
+
+//
+// Limiter of messages and bytes, a nil limiter is unlimited
+//
+type Limiter struct {
+	msgs  *Bucket // messages bucket
+	bytes *Bucket // bytes bucket
+}
+
+//
+// Create limiter, nil is returned if nothing limited
+//
+func NewLimiter(limit Limit) *Limiter {
+	if limit.Msgs <= 0 && limit.Bytes <= 0 {
+		return nil
+	}
+	return &Limiter{
+		msgs:  NewBucket(limit.Msgs, 0),
+		bytes: NewBucket(limit.Bytes, 0),
+	}
+}
+
+//
+// Let a message of size bytes pass if both buckets have enough tokens
+//
+func (l *Limiter) Allow(size int) bool {
+	if l == nil {
+		return true
+	}
+	if !l.msgs.Ready(1) || !l.bytes.Ready(size) {
+		return false
+	}
+	l.msgs.Allow(1)
+	l.bytes.Allow(size)
+	return true
+}
+
+//
+// Let a message of size bytes pass anyway, and return how long the caller should
+// wait before passing more
+//
+func (l *Limiter) Reserve(size int) time.Duration {
+	if l == nil {
+		return 0
+	}
+	dm := l.msgs.Reserve(1)
+	if db := l.bytes.Reserve(size); db > dm {
+		return db
+	}
+	return dm
+}
+
+//
+// Traffic counters
+//
+type Traffic struct {
+	Msgs    uint64 // messages passed
+	Bytes   uint64 // bytes passed
+	Dropped uint64 // messages dropped for rate limits
+}
+
+func (t *Traffic) count(size int, dropped bool) {
+	if dropped {
+		t.Dropped++
+	} else {
+		t.Msgs++
+		t.Bytes += uint64(size)
+	}
+}
+
+//
+// Merge other traffic into t
+//
+func (t *Traffic) Add(o Traffic) {
+	t.Msgs += o.Msgs
+	t.Bytes += o.Bytes
+	t.Dropped += o.Dropped
+}
+
+//
+// Meter accounting traffic by message identity for both directions. traffic is
+// accounted into the parent meter too if any, for example, the meter of a peer
+// is a child of that of its sub network.
+//
+type Meter struct {
+	lock   sync.Mutex          // lock for counters
+	parent *Meter              // parent meter
+	rx     map[uint32]*Traffic // inbound traffic by message identity
+	tx     map[uint32]*Traffic // outbound traffic by message identity
+}
+
+//
+// Create meter, parent can be nil
+//
+func NewMeter(parent *Meter) *Meter {
+	return &Meter{
+		parent: parent,
+		rx:     make(map[uint32]*Traffic, 0),
+		tx:     make(map[uint32]*Traffic, 0),
+	}
+}
+
+func (m *Meter) count(tm map[uint32]*Traffic, mid uint32, size int, dropped bool) {
+	m.lock.Lock()
+	t, ok := tm[mid]
+	if !ok {
+		t = new(Traffic)
+		tm[mid] = t
+	}
+	t.count(size, dropped)
+	m.lock.Unlock()
+}
+
+//
+// Account inbound message
+//
+func (m *Meter) Rx(mid uint32, size int, dropped bool) {
+	for ; m != nil; m = m.parent {
+		m.count(m.rx, mid, size, dropped)
+	}
+}
+
+//
+// Account outbound message
+//
+func (m *Meter) Tx(mid uint32, size int, dropped bool) {
+	for ; m != nil; m = m.parent {
+		m.count(m.tx, mid, size, dropped)
+	}
+}
+
+//
+// Get copy of counters by message identity
+//
+func (m *Meter) Snapshot() (rx map[uint32]Traffic, tx map[uint32]Traffic) {
+	rx = make(map[uint32]Traffic, 0)
+	tx = make(map[uint32]Traffic, 0)
+	if m == nil {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for mid, t := range m.rx {
+		rx[mid] = *t
+	}
+	for mid, t := range m.tx {
+		tx[mid] = *t
+	}
+	return
+}
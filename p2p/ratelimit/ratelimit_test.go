@@ -0,0 +1,82 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketDeficit(t *testing.T) {
+	b := NewBucket(100, 0)
+	if !b.Allow(100) {
+		t.Fatal("full bucket refused")
+	}
+	if b.Allow(1) {
+		t.Fatal("empty bucket allowed")
+	}
+	// a large message lets the bucket into deficit, paid back in time
+	b = NewBucket(1000, 0)
+	if d := b.Reserve(1500); d < 400*time.Millisecond || d > 500*time.Millisecond {
+		t.Fatalf("unexpected wait %v", d)
+	}
+	if b.Ready(1) {
+		t.Fatal("bucket in deficit ready")
+	}
+	var nb *Bucket
+	if !nb.Allow(1<<30) || nb.Reserve(1<<30) != 0 {
+		t.Fatal("nil bucket limited")
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	if NewLimiter(Limit{}) != nil {
+		t.Fatal("limiter created for no limit")
+	}
+	l := NewLimiter(Limit{Msgs: 2})
+	if !l.Allow(1<<20) || !l.Allow(1<<20) {
+		t.Fatal("limiter refused within limit")
+	}
+	if l.Allow(1) {
+		t.Fatal("limiter allowed over limit")
+	}
+}
+
+func TestMeter(t *testing.T) {
+	subnet := NewMeter(nil)
+	p1 := NewMeter(subnet)
+	p2 := NewMeter(subnet)
+	p1.Rx(3, 100, false)
+	p1.Rx(3, 100, true)
+	p2.Rx(3, 50, false)
+	p2.Tx(4, 10, false)
+
+	rx, tx := subnet.Snapshot()
+	if rx[3] != (Traffic{Msgs: 2, Bytes: 150, Dropped: 1}) {
+		t.Fatalf("unexpected subnet rx %+v", rx[3])
+	}
+	if tx[4] != (Traffic{Msgs: 1, Bytes: 10}) {
+		t.Fatalf("unexpected subnet tx %+v", tx[4])
+	}
+	if rx, _ = p1.Snapshot(); rx[3] != (Traffic{Msgs: 1, Bytes: 100, Dropped: 1}) {
+		t.Fatalf("unexpected peer rx %+v", rx[3])
+	}
+}
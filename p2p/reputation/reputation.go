@@ -0,0 +1,379 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package reputation
+
+//
+// Peer reputation: misbehaviour of peers reported by the core or the p2p modules
+// is accounted in a scoring table shared by the peer manager and the dht route
+// manager of a node. a score starts from zero, each offence reported subtracts
+// its severity from the score, and the score decays towards zero with a half
+// life, so that occasional faults are forgiven in time. when the score of a peer
+// falls below the threshold, the peer is banned for a while: the listeners are
+// told to disconnect it, and connections to or from it are refused until the
+// ban is expired or cleared. bans are saved in a ban store, which is the node
+// database of the discover table manager, to survive a reboot.
+//
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/p2p/config"
+)
+
+//
+// Offences
+//
+const (
+	OffenceUndecodable  = "undecodable message" // message can not be decoded
+	OffenceBadSignature = "bad signature"       // signature verified failed
+	OffenceInvalid      = "invalid message"     // message decoded but invalid
+	OffenceBadRecord    = "bad dht record"      // dht record rejected by validator
+	OffenceManual       = "manual"              // banned by command
+)
+
+//
+// Severities
+//
+const (
+	SeverityMinor = 10
+	SeverityMajor = 40
+	SeverityFatal = 100
+)
+
+//
+// Configuration
+//
+type Config struct {
+	Threshold   float64       // peer is banned when score falls below it
+	HalfLife    time.Duration // score decays by half for each half life
+	BanDuration time.Duration // duration of a ban
+}
+
+var DefaultConfig = Config{
+	Threshold:   -100,
+	HalfLife:    time.Minute * 10,
+	BanDuration: time.Hour,
+}
+
+const (
+	maxTracked    = 4096 // number of scores tracked before pruning
+	negligibleAbs = 1.0  // score taken as zero when pruning
+)
+
+//
+// Ban of peer
+//
+type Ban struct {
+	ID      config.NodeID // peer identity
+	Offence string        // last offence reported
+	Until   time.Time     // time the ban expired
+}
+
+//
+// Ban store, bans are loaded when the store is set, and saved or deleted when
+// peers are banned or unbanned.
+//
+type BanStore interface {
+	LoadBans() []*Ban
+	StoreBan(ban *Ban) error
+	DeleteBan(id config.NodeID) error
+}
+
+type peerScore struct {
+	score float64   // score at stamp
+	stamp time.Time // time the score updated
+}
+
+//
+// Scoring table
+//
+type Reputation struct {
+	lock      sync.Mutex                   // lock for tables
+	cfg       Config                       // configuration
+	scores    map[config.NodeID]*peerScore // scores of peers
+	bans      map[config.NodeID]*Ban       // peers banned
+	store     BanStore                     // ban store
+	listeners []func(id config.NodeID)     // called when peer banned
+}
+
+func NewReputation(cfg *Config) *Reputation {
+	if cfg == nil {
+		cfg = &DefaultConfig
+	}
+	return &Reputation{
+		cfg:    *cfg,
+		scores: make(map[config.NodeID]*peerScore, 0),
+		bans:   make(map[config.NodeID]*Ban, 0),
+	}
+}
+
+//
+// Set the ban store, bans saved in it are merged into the table, and a nil
+// store detaches the current one.
+//
+func (rep *Reputation) SetBanStore(store BanStore) {
+	if rep == nil {
+		return
+	}
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+	rep.store = store
+	if store == nil {
+		return
+	}
+	now := time.Now()
+	for _, b := range store.LoadBans() {
+		if !b.Until.After(now) {
+			store.DeleteBan(b.ID)
+			continue
+		}
+		if old, ok := rep.bans[b.ID]; !ok || old.Until.Before(b.Until) {
+			rep.bans[b.ID] = b
+		}
+	}
+	for _, b := range rep.bans {
+		if err := store.StoreBan(b); err != nil {
+			log.Debugf("SetBanStore: StoreBan failed, id: %x, error: %s", b.ID, err.Error())
+		}
+	}
+}
+
+//
+// Register a function to be called when a peer is banned, it's called out of
+// the lock of the table, and should not block.
+//
+func (rep *Reputation) OnBan(cb func(id config.NodeID)) {
+	if rep == nil || cb == nil {
+		return
+	}
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+	rep.listeners = append(rep.listeners, cb)
+}
+
+//
+// Report an offence of peer, true returned if the peer is banned for it
+//
+func (rep *Reputation) Report(id config.NodeID, offence string, severity int) bool {
+	if rep == nil || severity <= 0 {
+		return false
+	}
+	rep.lock.Lock()
+	now := time.Now()
+	ps, ok := rep.scores[id]
+	if !ok {
+		if len(rep.scores) >= maxTracked {
+			rep.prune(now)
+		}
+		ps = &peerScore{stamp: now}
+		rep.scores[id] = ps
+	}
+	ps.score = rep.decay(ps, now) - float64(severity)
+	ps.stamp = now
+	log.Debugf("Report: id: %x, offence: %s, severity: %d, score: %f", id, offence, severity, ps.score)
+	if ps.score >= rep.cfg.Threshold {
+		rep.lock.Unlock()
+		return false
+	}
+	delete(rep.scores, id)
+	listeners := rep.ban(id, offence, rep.cfg.BanDuration, now)
+	rep.lock.Unlock()
+	for _, cb := range listeners {
+		cb(id)
+	}
+	return true
+}
+
+//
+// Ban peer for duration
+//
+func (rep *Reputation) Ban(id config.NodeID, offence string, dur time.Duration) {
+	if rep == nil {
+		return
+	}
+	rep.lock.Lock()
+	listeners := rep.ban(id, offence, dur, time.Now())
+	rep.lock.Unlock()
+	for _, cb := range listeners {
+		cb(id)
+	}
+}
+
+func (rep *Reputation) ban(id config.NodeID, offence string, dur time.Duration, now time.Time) []func(id config.NodeID) {
+	b := &Ban{
+		ID:      id,
+		Offence: offence,
+		Until:   now.Add(dur),
+	}
+	rep.bans[id] = b
+	log.Infof("ban: id: %x, offence: %s, until: %s", id, offence, b.Until.String())
+	if rep.store != nil {
+		if err := rep.store.StoreBan(b); err != nil {
+			log.Debugf("ban: StoreBan failed, id: %x, error: %s", id, err.Error())
+		}
+	}
+	return append([]func(id config.NodeID){}, rep.listeners...)
+}
+
+//
+// Current score of peer
+//
+func (rep *Reputation) Score(id config.NodeID) float64 {
+	if rep == nil {
+		return 0
+	}
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+	if ps, ok := rep.scores[id]; ok {
+		return rep.decay(ps, time.Now())
+	}
+	return 0
+}
+
+//
+// Check if peer is banned
+//
+func (rep *Reputation) IsBanned(id config.NodeID) bool {
+	if rep == nil {
+		return false
+	}
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+	b, ok := rep.bans[id]
+	if !ok {
+		return false
+	}
+	if b.Until.After(time.Now()) {
+		return true
+	}
+	rep.unban(id)
+	return false
+}
+
+//
+// Bans in effect, sorted by the expired time
+//
+func (rep *Reputation) Bans() []Ban {
+	if rep == nil {
+		return nil
+	}
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+	now := time.Now()
+	bans := make([]Ban, 0, len(rep.bans))
+	for id, b := range rep.bans {
+		if !b.Until.After(now) {
+			rep.unban(id)
+			continue
+		}
+		bans = append(bans, *b)
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Until.Before(bans[j].Until)
+	})
+	return bans
+}
+
+//
+// Clear ban of peer, false returned if it's not banned
+//
+func (rep *Reputation) Unban(id config.NodeID) bool {
+	if rep == nil {
+		return false
+	}
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+	delete(rep.scores, id)
+	if _, ok := rep.bans[id]; !ok {
+		return false
+	}
+	rep.unban(id)
+	return true
+}
+
+//
+// Clear all bans, the number of bans cleared returned
+//
+func (rep *Reputation) ClearBans() int {
+	if rep == nil {
+		return 0
+	}
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+	count := len(rep.bans)
+	for id := range rep.bans {
+		delete(rep.scores, id)
+		rep.unban(id)
+	}
+	return count
+}
+
+func (rep *Reputation) unban(id config.NodeID) {
+	delete(rep.bans, id)
+	if rep.store != nil {
+		if err := rep.store.DeleteBan(id); err != nil {
+			log.Debugf("unban: DeleteBan failed, id: %x, error: %s", id, err.Error())
+		}
+	}
+}
+
+func (rep *Reputation) decay(ps *peerScore, now time.Time) float64 {
+	if rep.cfg.HalfLife <= 0 {
+		return ps.score
+	}
+	halves := float64(now.Sub(ps.stamp)) / float64(rep.cfg.HalfLife)
+	return ps.score * math.Pow(0.5, halves)
+}
+
+func (rep *Reputation) prune(now time.Time) {
+	for id, ps := range rep.scores {
+		if math.Abs(rep.decay(ps, now)) < negligibleAbs {
+			delete(rep.scores, id)
+		}
+	}
+}
+
+//
+// Scoring tables registered by the name of p2p instances(schedulers), so that
+// the tasks of an instance could find the table shared with other instances.
+//
+var registryLock sync.Mutex
+var registry = make(map[string]*Reputation, 0)
+
+func Register(name string, rep *Reputation) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if rep == nil {
+		delete(registry, name)
+		return
+	}
+	registry[name] = rep
+}
+
+func Lookup(name string) *Reputation {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	return registry[name]
+}
@@ -0,0 +1,121 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/p2p/config"
+)
+
+type memBanStore struct {
+	bans map[config.NodeID]Ban
+}
+
+func (s *memBanStore) LoadBans() []*Ban {
+	bans := make([]*Ban, 0, len(s.bans))
+	for _, b := range s.bans {
+		b := b
+		bans = append(bans, &b)
+	}
+	return bans
+}
+
+func (s *memBanStore) StoreBan(b *Ban) error {
+	s.bans[b.ID] = *b
+	return nil
+}
+
+func (s *memBanStore) DeleteBan(id config.NodeID) error {
+	delete(s.bans, id)
+	return nil
+}
+
+func TestReportAndBan(t *testing.T) {
+	rep := NewReputation(&Config{Threshold: -100, HalfLife: time.Hour, BanDuration: time.Hour})
+	store := &memBanStore{bans: make(map[config.NodeID]Ban)}
+	rep.SetBanStore(store)
+	banned := make(chan config.NodeID, 1)
+	rep.OnBan(func(id config.NodeID) { banned <- id })
+
+	id := config.NodeID{1}
+	if rep.Report(id, OffenceUndecodable, SeverityMajor) {
+		t.Fatal("banned by a single major offence")
+	}
+	if s := rep.Score(id); s > -39 || s < -40 {
+		t.Fatalf("unexpected score: %f", s)
+	}
+	rep.Report(id, OffenceUndecodable, SeverityMajor)
+	if !rep.Report(id, OffenceBadSignature, SeverityMajor) {
+		t.Fatal("not banned below threshold")
+	}
+	if got := <-banned; got != id {
+		t.Fatalf("listener got %x", got)
+	}
+	if !rep.IsBanned(id) {
+		t.Fatal("not banned")
+	}
+	if _, ok := store.bans[id]; !ok {
+		t.Fatal("ban not stored")
+	}
+
+	// a new table loads the bans from store
+	rep2 := NewReputation(nil)
+	rep2.SetBanStore(store)
+	if bans := rep2.Bans(); len(bans) != 1 || bans[0].ID != id || bans[0].Offence != OffenceBadSignature {
+		t.Fatalf("unexpected bans: %+v", bans)
+	}
+	if !rep2.Unban(id) || rep2.IsBanned(id) {
+		t.Fatal("unban failed")
+	}
+	if _, ok := store.bans[id]; ok {
+		t.Fatal("ban not deleted from store")
+	}
+}
+
+func TestScoreDecay(t *testing.T) {
+	rep := NewReputation(&Config{Threshold: -100, HalfLife: time.Millisecond * 10, BanDuration: time.Hour})
+	id := config.NodeID{2}
+	for i := 0; i < 3; i++ {
+		if rep.Report(id, OffenceInvalid, SeverityMajor) {
+			t.Fatal("banned although score decayed")
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	if s := rep.Score(id); s < -10 {
+		t.Fatalf("score not decayed: %f", s)
+	}
+}
+
+func TestExpiredBan(t *testing.T) {
+	rep := NewReputation(nil)
+	id := config.NodeID{3}
+	rep.Ban(id, OffenceManual, time.Millisecond)
+	time.Sleep(time.Millisecond * 5)
+	if rep.IsBanned(id) || len(rep.Bans()) != 0 {
+		t.Fatal("ban not expired")
+	}
+	var nilRep *Reputation
+	if nilRep.Report(id, OffenceManual, SeverityFatal) || nilRep.IsBanned(id) {
+		t.Fatal("nil table")
+	}
+}
@@ -83,8 +83,8 @@ const (
 	EvShellReconfigReq       = EvShellBase + 5
 	EvShellBroadcastReq      = EvShellBase + 6
 	EvShellSubnetUpdateReq   = EvShellBase + 7
-	EvShellGetChainInfoReq   = EvShellBase + 8
-	EvShellGetChainInfoRsp   = EvShellBase + 9
+	EvShellChainReq          = EvShellBase + 8
+	EvShellChainRsp          = EvShellBase + 9
 )
 
 // EvShellPeerActiveInd
@@ -137,10 +137,11 @@ type MsgShellReconfigReq struct {
 
 // EvShellBroadcastReq, see tcpmsg.proto please.
 const (
-	MSBR_MT_TX   = 3 // tx type
-	MSBR_MT_EV   = 4 // event type
-	MSBR_MT_BLKH = 5 // block header type
-	MSBR_MT_BLK  = 6 // block type
+	MSBR_MT_TX   = 3  // tx type
+	MSBR_MT_EV   = 4  // event type
+	MSBR_MT_BLKH = 5  // block header type
+	MSBR_MT_BLK  = 6  // block type
+	MSBR_MT_EVI  = 13 // evidence type
 )
 
 type MsgShellBroadcastReq struct {
@@ -152,20 +153,16 @@ type MsgShellBroadcastReq struct {
 	Exclude   *config.NodeID  // node to be excluded
 }
 
-// EvShellGetChainInfoReq
-type MsgShellGetChainInfoReq struct {
-	Seq			uint64		// sequence
-	Kind		string		// kind
-	Key			[]byte		// key
+// EvShellChainReq
+type MsgShellChainReq struct {
+	NodeId		*config.NodeID	// peer to ask, a random active one if nil
+	Req			interface{}		// chain request pointer
 }
 
-// EvShellGetChainInfoRsp
-type MsgShellGetChainInfoRsp struct {
-	Peer		interface{}	// peer info pointer
-	Seq			uint64		// sequence
-	Kind		string		// kind
-	Key			[]byte		// key
-	Data		[]byte		// data
+// EvShellChainRsp
+type MsgShellChainRsp struct {
+	Peer		interface{}	// peer info pointer of requester
+	Rsp			interface{}	// chain response pointer
 }
 
 //
@@ -349,6 +346,9 @@ const (
 	EvPeMgrStartReq         = EvPeerEstBase + 12
 	EvPeTxDataReq           = EvPeerEstBase + 13
 	EvPeRxDataInd           = EvPeerEstBase + 14
+	EvPePeerSetReq          = EvPeerEstBase + 15
+	EvPePeerListReq         = EvPeerEstBase + 16
+	EvPeValidatorSetReq     = EvPeerEstBase + 17
 )
 
 // EvPeCloseReq
@@ -372,6 +372,41 @@ type MsgPeCloseReq struct {
 	Why  interface{}         // cause
 }
 
+// EvPePeerSetReq
+const (
+	PSR_OP_ADD    = 0 // add peer to reserved or trusted set
+	PSR_OP_REMOVE = 1 // remove peer from both sets
+)
+
+type MsgPePeerSetReq struct {
+	Op      int         // PSR_OP_XXX
+	Node    config.Node // peer node, identity only for removing
+	Trusted bool        // trusted only, not dialed as a reserved one
+	Result  chan int    // result code of peer manager
+}
+
+// EvPePeerListReq
+type PePeerEntry struct {
+	Node      config.Node         // peer node
+	Snid      config.SubNetworkID // sub network identity, if connected
+	Dir       int                 // direction, if connected
+	Connected bool                // is peer activated
+	Reserved  bool                // is reserved peer
+	Trusted   bool                // is trusted peer
+}
+
+type MsgPePeerListReq struct {
+	Result chan []PePeerEntry // peers connected, reserved or trusted
+}
+
+// EvPeValidatorSetReq
+type MsgPeValidatorSetReq struct {
+	Validators []string                          // addresses of current validators
+	Self       string                            // local validator address, "" if none
+	Sign       func(hash []byte) ([]byte, error) // signs with the local validator key
+	Result     chan int                          // result code of peer manager
+}
+
 // EvPeTxDataReq
 type MsgPeDataReq struct {
 	SubNetId config.SubNetworkID // sub network identity
@@ -417,6 +452,7 @@ type MsgDhtMgrGetProviderRsp struct {
 type MsgDhtMgrPutValueReq struct {
 	Key      []byte        // key wanted
 	Val      []byte        // value
+	Extra    []byte        // record extra, see dht.ValidateRecord
 	KeepTime time.Duration // duration for the value to be kept
 }
 
@@ -694,6 +730,7 @@ type MsgDhtQryMgrQueryResultInd struct {
 	Target  config.DsKey   // target or key to be looked up
 	Peers   []*config.Node // peers list, if target got, it always be the first one
 	Val     []byte         // value
+	ValEx   []byte         // record extra of value
 	Prds    []*config.Node // providers
 }
 
@@ -737,6 +774,7 @@ type MsgDhtQryInstResultInd struct {
 	Peers    []*config.Node // neighbors of target for find-node
 	Provider *Provider      // providers for get-provider
 	Value    []byte         // value for get-value
+	ValEx    []byte         // record extra of value
 	Pcs      []int          // peer connection status, see dht.conMgrPeerConnStat pls
 }
 
@@ -924,9 +962,10 @@ type MsgDhtDsStatusInd struct {
 const Keep4Ever = time.Duration(-1)
 
 type MsgDhtDsMgrAddValReq struct {
-	Key []byte        // key
-	Val []byte        // value
-	KT  time.Duration // duration to keep this [key, val] pair
+	Key   []byte        // key
+	Val   []byte        // value
+	Extra []byte        // record extra
+	KT    time.Duration // duration to keep this [key, val] pair
 }
 
 // EvDhtDsMgrPutValReq
@@ -0,0 +1,355 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package secure
+
+//
+// Authenticated key exchange and encrypted transport for the peer and dht
+// connections. the exchange follows the XX pattern of Noise, with the static
+// Diffie-Hellman replaced by signatures of the node identity key, since the
+// node identity is an ecdsa public key:
+//
+//	-> e                            in the handshake message of the initiator
+//	<- e, sign(transcript)          in the handshake message of the responder
+//	-> encrypted sign(transcript)   the first record of the encrypted stream
+//
+// the transcript hashes the chain identity, both node identities and both
+// ephemeral keys, and the session keys are derived from the ephemeral x25519
+// shared secret, so a replayed or tampered handshake fails at the latest
+// when the first record is decrypted. after that, each direction of the
+// connection is a stream of AES-GCM records:
+//
+//	length(4 bytes, big endian) | ciphertext(length bytes)
+//
+// with the length as additional data and a per-direction counter as nonce.
+//
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/yeeco/gyee/p2p/config"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	KeyBytes     = 32        // ephemeral public key length
+	SignBytes    = 64        // signature length, r and s
+	MaxRecord    = 64 * 1024 // max plaintext bytes of a record
+	protoLabel   = "gyee-p2p-secure-v1"
+	keyInfoLabel = "gyee-p2p-secure-keys"
+	lengthBytes  = 4
+)
+
+// roles signed with the transcript, so one side's signature can never be
+// reflected as the other side's
+const (
+	roleInitiator = byte(0x01)
+	roleResponder = byte(0x02)
+)
+
+var (
+	ErrPeerKey      = errors.New("secure: invalid peer ephemeral key")
+	ErrPeerSign     = errors.New("secure: invalid peer signature")
+	ErrRecordLength = errors.New("secure: invalid record length")
+	ErrState        = errors.New("secure: invalid handshake state")
+)
+
+//
+// One side of the key exchange
+//
+type Handshake struct {
+	chainId   uint32            // chain identity
+	initiator bool              // outbound side
+	priKey    *ecdsa.PrivateKey // node identity key
+	local     config.NodeID     // local node identity
+	ePri      [KeyBytes]byte    // ephemeral private key
+	ePub      [KeyBytes]byte    // ephemeral public key
+	peerPub   [KeyBytes]byte    // peer ephemeral public key
+	peer      config.NodeID     // peer node identity
+	th        []byte            // transcript hash
+}
+
+//
+// Create handshake state with a fresh ephemeral key
+//
+func NewHandshake(chainId uint32, priKey *ecdsa.PrivateKey, local config.NodeID, initiator bool) (*Handshake, error) {
+	hs := &Handshake{
+		chainId:   chainId,
+		initiator: initiator,
+		priKey:    priKey,
+		local:     local,
+	}
+	if _, err := io.ReadFull(rand.Reader, hs.ePri[:]); err != nil {
+		return nil, err
+	}
+	curve25519.ScalarBaseMult(&hs.ePub, &hs.ePri)
+	return hs, nil
+}
+
+//
+// Ephemeral public key to be carried by the handshake message
+//
+func (hs *Handshake) PublicKey() []byte {
+	return append([]byte{}, hs.ePub[:]...)
+}
+
+//
+// Responder: accept the initiator's ephemeral key and sign the transcript,
+// the signature is carried by the handshake message of the responder.
+//
+func (hs *Handshake) Respond(peer config.NodeID, peerKey []byte) ([]byte, error) {
+	if hs.initiator {
+		return nil, ErrState
+	}
+	if err := hs.setPeer(peer, peerKey); err != nil {
+		return nil, err
+	}
+	return sign(hs.priKey, roleResponder, hs.th)
+}
+
+//
+// Initiator: check the responder's signature, send our signature as the first
+// encrypted record and return the encrypted connection.
+//
+func (hs *Handshake) Finish(conn net.Conn, peer config.NodeID, peerKey []byte, peerSign []byte) (*Conn, error) {
+	if !hs.initiator {
+		return nil, ErrState
+	}
+	if err := hs.setPeer(peer, peerKey); err != nil {
+		return nil, err
+	}
+	if !verify(peer, roleResponder, hs.th, peerSign) {
+		return nil, ErrPeerSign
+	}
+	sc, err := hs.newConn(conn)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := sign(hs.priKey, roleInitiator, hs.th)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sc.Write(sig); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+//
+// Responder: read and check the initiator's signature from the first encrypted
+// record and return the encrypted connection.
+//
+func (hs *Handshake) Accept(conn net.Conn) (*Conn, error) {
+	if hs.initiator || hs.th == nil {
+		return nil, ErrState
+	}
+	sc, err := hs.newConn(conn)
+	if err != nil {
+		return nil, err
+	}
+	sig := make([]byte, SignBytes)
+	if _, err := io.ReadFull(sc, sig); err != nil {
+		return nil, err
+	}
+	if !verify(hs.peer, roleInitiator, hs.th, sig) {
+		return nil, ErrPeerSign
+	}
+	return sc, nil
+}
+
+func (hs *Handshake) setPeer(peer config.NodeID, peerKey []byte) error {
+	if len(peerKey) != KeyBytes {
+		return ErrPeerKey
+	}
+	hs.peer = peer
+	copy(hs.peerPub[:], peerKey)
+	if hs.initiator {
+		hs.th = transcript(hs.chainId, hs.local, hs.ePub[:], hs.peer, hs.peerPub[:])
+	} else {
+		hs.th = transcript(hs.chainId, hs.peer, hs.peerPub[:], hs.local, hs.ePub[:])
+	}
+	return nil
+}
+
+func (hs *Handshake) newConn(conn net.Conn) (*Conn, error) {
+	var secret [KeyBytes]byte
+	var zero [KeyBytes]byte
+	curve25519.ScalarMult(&secret, &hs.ePri, &hs.peerPub)
+	if subtle.ConstantTimeCompare(secret[:], zero[:]) == 1 {
+		return nil, ErrPeerKey
+	}
+	keys := make([]byte, 2*KeyBytes)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret[:], hs.th, []byte(keyInfoLabel)), keys); err != nil {
+		return nil, err
+	}
+	i2r, err := newAEAD(keys[:KeyBytes])
+	if err != nil {
+		return nil, err
+	}
+	r2i, err := newAEAD(keys[KeyBytes:])
+	if err != nil {
+		return nil, err
+	}
+	sc := &Conn{Conn: conn}
+	if hs.initiator {
+		sc.wAead, sc.rAead = i2r, r2i
+	} else {
+		sc.wAead, sc.rAead = r2i, i2r
+	}
+	return sc, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func transcript(chainId uint32, initId config.NodeID, initKey []byte, respId config.NodeID, respKey []byte) []byte {
+	var cid [4]byte
+	binary.BigEndian.PutUint32(cid[:], chainId)
+	h := sha256.New()
+	h.Write([]byte(protoLabel))
+	h.Write(cid[:])
+	h.Write(initId[:])
+	h.Write(initKey)
+	h.Write(respId[:])
+	h.Write(respKey)
+	return h.Sum(nil)
+}
+
+func sign(priKey *ecdsa.PrivateKey, role byte, th []byte) ([]byte, error) {
+	digest := sha256.Sum256(append([]byte{role}, th...))
+	r, s, err := config.P2pSign(priKey, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	sig := make([]byte, SignBytes)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(sig[SignBytes/2-len(rb):SignBytes/2], rb)
+	copy(sig[SignBytes-len(sb):], sb)
+	return sig, nil
+}
+
+func verify(id config.NodeID, role byte, th []byte, sig []byte) bool {
+	if len(sig) != SignBytes {
+		return false
+	}
+	pubKey := config.P2pNodeId2Pubkey(id[:])
+	if pubKey.X == nil {
+		return false
+	}
+	digest := sha256.Sum256(append([]byte{role}, th...))
+	r := config.P2pBigInt(1, sig[:SignBytes/2])
+	s := config.P2pBigInt(1, sig[SignBytes/2:])
+	return config.P2pVerify(pubKey, digest[:], r, s)
+}
+
+//
+// Encrypted connection. Read and Write can be called from different routines
+// at the same time, deadlines and Close are those of the underlying connection.
+//
+type Conn struct {
+	net.Conn
+	rLock sync.Mutex  // lock for reading
+	wLock sync.Mutex  // lock for writing
+	rAead cipher.AEAD // cipher for reading
+	wAead cipher.AEAD // cipher for writing
+	rSeq  uint64      // sequence of records read
+	wSeq  uint64      // sequence of records written
+	rBuf  []byte      // plaintext read but not consumed
+}
+
+func (sc *Conn) Read(b []byte) (int, error) {
+	sc.rLock.Lock()
+	defer sc.rLock.Unlock()
+	for len(sc.rBuf) == 0 {
+		if err := sc.readRecord(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(b, sc.rBuf)
+	sc.rBuf = sc.rBuf[n:]
+	return n, nil
+}
+
+func (sc *Conn) readRecord() error {
+	var hdr [lengthBytes]byte
+	if _, err := io.ReadFull(sc.Conn, hdr[:]); err != nil {
+		return err
+	}
+	size := binary.BigEndian.Uint32(hdr[:])
+	if size < uint32(sc.rAead.Overhead()) || size > uint32(MaxRecord+sc.rAead.Overhead()) {
+		return ErrRecordLength
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(sc.Conn, buf); err != nil {
+		return err
+	}
+	pt, err := sc.rAead.Open(buf[:0], nonce(sc.rAead, sc.rSeq), buf, hdr[:])
+	if err != nil {
+		return err
+	}
+	sc.rSeq++
+	sc.rBuf = pt
+	return nil
+}
+
+func (sc *Conn) Write(b []byte) (int, error) {
+	sc.wLock.Lock()
+	defer sc.wLock.Unlock()
+	n := 0
+	for n < len(b) {
+		size := len(b) - n
+		if size > MaxRecord {
+			size = MaxRecord
+		}
+		var hdr [lengthBytes]byte
+		binary.BigEndian.PutUint32(hdr[:], uint32(size+sc.wAead.Overhead()))
+		rec := make([]byte, lengthBytes, lengthBytes+size+sc.wAead.Overhead())
+		copy(rec, hdr[:])
+		rec = sc.wAead.Seal(rec, nonce(sc.wAead, sc.wSeq), b[n:n+size], hdr[:])
+		if _, err := sc.Conn.Write(rec); err != nil {
+			return n, err
+		}
+		sc.wSeq++
+		n += size
+	}
+	return n, nil
+}
+
+func nonce(aead cipher.AEAD, seq uint64) []byte {
+	n := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(n[len(n)-8:], seq)
+	return n
+}
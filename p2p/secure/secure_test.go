@@ -0,0 +1,183 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package secure
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/yeeco/gyee/p2p/config"
+)
+
+type testNode struct {
+	key *ecdsa.PrivateKey
+	id  config.NodeID
+}
+
+func newTestNode(t *testing.T) *testNode {
+	key, err := config.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testNode{key: key, id: *config.P2pPubkey2NodeId(&key.PublicKey)}
+}
+
+// handshake runs both sides over a pipe, the responder believes the initiator
+// to be claimed, the initiator expects to reach resp.
+func handshake(t *testing.T, init, claimed, resp *testNode) (*Conn, *Conn, error, error) {
+	c0, c1 := net.Pipe()
+	type result struct {
+		sc  *Conn
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		hs, err := NewHandshake(1, resp.key, resp.id, false)
+		if err != nil {
+			ch <- result{nil, err}
+			return
+		}
+		ikey := make([]byte, KeyBytes)
+		if _, err := io.ReadFull(c1, ikey); err != nil {
+			ch <- result{nil, err}
+			return
+		}
+		sig, err := hs.Respond(claimed.id, ikey)
+		if err != nil {
+			ch <- result{nil, err}
+			return
+		}
+		c1.Write(append(hs.PublicKey(), sig...))
+		sc, err := hs.Accept(c1)
+		if err != nil {
+			c1.Close()
+		}
+		ch <- result{sc, err}
+	}()
+
+	hs, err := NewHandshake(1, init.key, init.id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c0.Write(hs.PublicKey())
+	rsp := make([]byte, KeyBytes+SignBytes)
+	if _, err := io.ReadFull(c0, rsp); err != nil {
+		t.Fatal(err)
+	}
+	sc0, err0 := hs.Finish(c0, resp.id, rsp[:KeyBytes], rsp[KeyBytes:])
+	if err0 != nil {
+		c0.Close()
+	}
+	r := <-ch
+	return sc0, r.sc, err0, r.err
+}
+
+func TestSecure_Handshake(t *testing.T) {
+	a, b := newTestNode(t), newTestNode(t)
+	sa, sb, ea, eb := handshake(t, a, a, b)
+	if ea != nil || eb != nil {
+		t.Fatalf("handshake failed: %v, %v", ea, eb)
+	}
+
+	msg := bytes.Repeat([]byte("event"), MaxRecord/2)
+	go func() {
+		sa.Write(msg)
+		sa.Write([]byte("done"))
+	}()
+	got := make([]byte, len(msg)+4)
+	if _, err := io.ReadFull(sb, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got[:len(msg)], msg) || string(got[len(msg):]) != "done" {
+		t.Fatal("payload mismatched")
+	}
+
+	go sb.Write([]byte("pong"))
+	pong := make([]byte, 4)
+	if _, err := io.ReadFull(sa, pong); err != nil || string(pong) != "pong" {
+		t.Fatalf("reverse direction failed: %v", err)
+	}
+}
+
+func TestSecure_Impersonation(t *testing.T) {
+	a, b, c := newTestNode(t), newTestNode(t), newTestNode(t)
+
+	// a claims to be c, its signature must not be accepted by b
+	if _, _, _, eb := handshake(t, a, c, b); eb == nil {
+		t.Fatal("responder accepted a wrong initiator identity")
+	}
+
+	// a expects c but b answers, the responder signature must be refused
+	if _, _, ea, _ := handshake(t, a, a, &testNode{key: b.key, id: c.id}); ea != ErrPeerSign {
+		t.Fatalf("initiator accepted a wrong responder identity: %v", ea)
+	}
+}
+
+func TestSecure_Tamper(t *testing.T) {
+	a, b := newTestNode(t), newTestNode(t)
+	c0, c1 := net.Pipe()
+	ha, _ := NewHandshake(1, a.key, a.id, true)
+	hb, _ := NewHandshake(1, b.key, b.id, false)
+	sig, err := hb.Respond(a.id, ha.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		sc, err := ha.Finish(c0, b.id, hb.PublicKey(), sig)
+		if err == nil {
+			_, err = sc.Write([]byte("block"))
+		}
+		done <- err
+	}()
+
+	// forward the initiator's records to the responder, flipping one bit of
+	// the second record
+	r0, w0 := net.Pipe()
+	go func() {
+		buf := make([]byte, 1024)
+		for i := 0; i < 2; i++ {
+			n, err := c1.Read(buf)
+			if err != nil {
+				return
+			}
+			if i == 1 {
+				buf[n-1] ^= 0x01
+			}
+			w0.Write(buf[:n])
+		}
+	}()
+
+	sb, err := hb.Accept(r0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sb.Read(make([]byte, 16)); err == nil {
+		t.Fatal("tampered record accepted")
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -20,7 +20,10 @@
 
 package p2p
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 const (
 	DhtGetDftTimeout = 60 * time.Second
@@ -47,8 +50,14 @@ type RecfgCommand struct {
 	SubnetMaskBits int  // mask bits for sub network identity
 }
 
+// provider of local chain data answering chain requests from peers, items
+// returned are those found, in the order asked
 type ChainProvider interface {
-	GetChainData(kind string, key []byte) []byte
+	ChainStatus() *ChainStatus
+	ChainHeaders(from uint64, count int) [][]byte
+	ChainBlocks(hashes [][]byte) [][]byte
+	ChainTxs(hashes [][]byte) [][]byte
+	ChainProof(root, key []byte) [][]byte
 }
 
 type Service interface {
@@ -66,10 +75,43 @@ type Service interface {
 	DhtGetValue(key []byte) ([]byte, error)
 	DhtGetValues(keys [][]byte, out chan<- []byte, timeout time.Duration) error
 	DhtSetValue(key []byte, value []byte) error
+	// put value with record extra telling its namespace, see dht.ValidateRecord
+	DhtSetRecord(key []byte, value []byte, extra []byte) error
 
 	// p2p service get chain data from provider
 	RegChainProvider(cp ChainProvider)
 
-	// ask peer for chain info
-	GetChainInfo(kind string, key []byte) ([]byte, error)
+	// chain protocol requests, peer is node identity as that in Message.From,
+	// a random active peer is asked if it's empty
+	GetStatus(ctx context.Context, peer string) (*ChainResponse, error)
+	GetHeaders(ctx context.Context, peer string, from uint64, count int) (*ChainResponse, error)
+	GetBlocks(ctx context.Context, peer string, hashes [][]byte) (*ChainResponse, error)
+	GetTxs(ctx context.Context, peer string, hashes [][]byte) (*ChainResponse, error)
+	GetProof(ctx context.Context, peer string, root, key []byte) (*ChainResponse, error)
+
+	// report misbehaviour of peer, nodeID is that in Message.From. the peer is
+	// disconnected and banned for a while when its score is too low
+	ReportPeer(nodeID string, offence string, severity int) error
+
+	// list bans in effect, and clear ban of peer, all if nodeID is empty
+	ListBans() []PeerBan
+	ClearBans(nodeID string) (int, error)
+
+	// bandwidth counters of active peers, and of sub networks since started
+	PeerBandwidth() []Bandwidth
+	SubnetBandwidth() []Bandwidth
+
+	// add reserved peer, or trusted one, node is in format as bootstrap nodes;
+	// remove peer from both sets; changes are saved to the static nodes file
+	AddPeer(node string, trusted bool) error
+	RemovePeer(nodeID string) error
+
+	// list peers connected and those reserved or trusted
+	ListPeers() ([]PeerInfo, error)
+
+	// install current validators from the consensus trie, self is the local
+	// validator address and sign signs hashes with its key, both empty if not
+	// a validator. only validators in the set are accepted for validators by
+	// handshake, and tetris events are exchanged with them only
+	SetValidators(validators []string, self string, sign func(hash []byte) ([]byte, error)) error
 }
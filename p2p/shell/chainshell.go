@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"container/list"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -92,8 +93,8 @@ const (
 	keyTime  = time.Second * 55
 	MID_CHKK = peer.MID_CHKK
 	MID_RPTK = peer.MID_RPTK
-	MID_GCD  = peer.MID_GCD
-	MID_PCD  = peer.MID_PCD
+	MID_CHREQ = peer.MID_CHREQ
+	MID_CHRSP = peer.MID_CHRSP
 )
 
 type shMgrBcrStat struct {
@@ -102,6 +103,7 @@ type shMgrBcrStat struct {
 	bcrTxCount		int64
 	bcrBhCount		int64
 	bcrBkCount		int64
+	bcrEviCount		int64
 	bcrUnknown		int64
 	bcrSkmFailed	int64
 	bcrSkmOk		int64
@@ -119,10 +121,10 @@ type shMgrRxStat struct {
 	ckFailedCount	int64
 	rkOkCount		int64
 	rkFailedCount	int64
-	gcdOkCount		int64
-	gcdFailedCount	int64
-	pcdOkCount		int64
-	pcdFailedCount	int64
+	chreqOkCount	int64
+	chreqFailedCount	int64
+	chrspOkCount	int64
+	chrspFailedCount	int64
 	rxChainCount	int64
 	skmFailedCount	int64
 	skmOkCount		int64
@@ -211,10 +213,10 @@ func (shMgr *ShellManager) shMgrProc(ptn interface{}, msg *sch.SchMessage) sch.S
 		eno = shMgr.broadcastReq(msg.Body.(*sch.MsgShellBroadcastReq))
 	case sch.EvShellSubnetUpdateReq:
 		eno = shMgr.updateLocalSubnetInfo()
-	case sch.EvShellGetChainInfoReq:
-		eno = shMgr.getChainInfoReq(msg.Body.(*sch.MsgShellGetChainInfoReq))
-	case sch.EvShellGetChainInfoRsp:
-		eno = shMgr.getChainInfoRsp(msg.Body.(*sch.MsgShellGetChainInfoRsp))
+	case sch.EvShellChainReq:
+		eno = shMgr.chainReq(msg.Body.(*sch.MsgShellChainReq))
+	case sch.EvShellChainRsp:
+		eno = shMgr.chainRsp(msg.Body.(*sch.MsgShellChainRsp))
 	default:
 		log.Debugf("shMgrProc: unknown event: %d", msg.Id)
 		eno = sch.SchEnoParameter
@@ -346,32 +348,39 @@ func (shMgr *ShellManager) peerActiveInd(ind *sch.MsgShellPeerActiveInd) sch.Sch
 						stat.rkOkCount++
 					}
 
-				} else if rxPkg.MsgId == int(MID_GCD) {
+				} else if rxPkg.MsgId == int(MID_CHREQ) {
 
-					if eno := shMgr.getChainDataFromPeer(rxPkg); eno != sch.SchEnoNone {
-						log.Debugf("peerActiveInd: rxProc: GCD from peer discarded, " +
-							"sdl: %s, eno: %d, key: %x",
-							shMgr.sdlName, eno, rxPkg.Key)
-						stat.gcdFailedCount++
+					if eno := shMgr.chainMsgFromPeer(rxPkg); eno != sch.SchEnoNone {
+						log.Debugf("peerActiveInd: rxProc: CHREQ from peer discarded, " +
+							"sdl: %s, eno: %d",
+							shMgr.sdlName, eno)
+						stat.chreqFailedCount++
 					} else {
 						shMgr.rxChan <- rxPkg
-						stat.gcdOkCount++
+						stat.chreqOkCount++
 						stat.rxChainCount++
 					}
 
-				} else if rxPkg.MsgId == int(MID_PCD) {
+				} else if rxPkg.MsgId == int(MID_CHRSP) {
 
-					if eno := shMgr.putChainDataFromPeer(rxPkg); eno != sch.SchEnoNone {
-						log.Debugf("peerActiveInd: rxProc: PCD from peer discarded, " +
-							"sdl: %s, eno: %d, key: %x",
-							shMgr.sdlName, eno, rxPkg.Key)
-						stat.pcdFailedCount++
+					if eno := shMgr.chainMsgFromPeer(rxPkg); eno != sch.SchEnoNone {
+						log.Debugf("peerActiveInd: rxProc: CHRSP from peer discarded, " +
+							"sdl: %s, eno: %d",
+							shMgr.sdlName, eno)
+						stat.chrspFailedCount++
 					} else {
 						shMgr.rxChan <- rxPkg
-						stat.pcdOkCount++
+						stat.chrspOkCount++
 						stat.rxChainCount++
 					}
 
+				} else if rxPkg.MsgId == int(sch.MSBR_MT_EV) && !shMgr.ptrPeMgr.ValidatorPeer(peerInfo.ValAddr) {
+
+					log.Debugf("peerActiveInd: rxProc: event from non-validator discarded, " +
+						"sdl: %s, peer: %s, key: %x",
+						shMgr.sdlName, peerInfo.IP.String(), rxPkg.Key)
+					stat.skmFailedCount++
+
 				} else {
 
 					k := config.DsKey{}
@@ -525,6 +534,8 @@ func (shMgr *ShellManager) broadcastReq(req *sch.MsgShellBroadcastReq) sch.SchEr
 			shMgr.bcrStat.bcrBhCount++
 		} else if mt == sch.MSBR_MT_BLK {
 			shMgr.bcrStat.bcrBkCount++
+		} else if mt == sch.MSBR_MT_EVI {
+			shMgr.bcrStat.bcrEviCount++
 		} else {
 			shMgr.bcrStat.bcrUnknown++
 		}
@@ -543,7 +554,7 @@ func (shMgr *ShellManager) broadcastReq(req *sch.MsgShellBroadcastReq) sch.SchEr
 	defer doStat()
 
 	switch req.MsgType {
-	case sch.MSBR_MT_EV, sch.MSBR_MT_TX, sch.MSBR_MT_BLKH, sch.MSBR_MT_BLK:
+	case sch.MSBR_MT_EV, sch.MSBR_MT_TX, sch.MSBR_MT_BLKH, sch.MSBR_MT_BLK, sch.MSBR_MT_EVI:
 		key := config.DsKey{}
 		copy(key[0:], req.Key)
 		if shMgr.deDup {
@@ -564,6 +575,12 @@ func (shMgr *ShellManager) broadcastReq(req *sch.MsgShellBroadcastReq) sch.SchEr
 					"sdl: %s, snid: %x, peer: %s, key: %x",
 					shMgr.sdlName, id.snid, pe.hsInfo.IP.String(), key)
 				unactive++
+			} else if mt == sch.MSBR_MT_EV && !shMgr.ptrPeMgr.ValidatorPeer(pe.hsInfo.ValAddr) {
+				// tetris events are for validators only
+				log.Tracef("broadcastReq: not a validator, " +
+					"sdl: %s, snid: %x, peer: %s, key: %x",
+					shMgr.sdlName, id.snid, pe.hsInfo.IP.String(), key)
+				exclude++
 			} else 	if req.Exclude == nil || (req.Exclude != nil && bytes.Compare(id.nodeId[0:], req.Exclude[0:]) != 0) {
 					if shMgr.deDup == false {
 						eno := shMgr.send2Peer(pe, req)
@@ -822,7 +839,7 @@ func (shMgr *ShellManager) reportKeyFromPeer(rxPkg *peer.P2pPackageRx) sch.SchEr
 	return sch.SchEnoNone
 }
 
-func (shMgr *ShellManager) getChainDataFromPeer(rxPkg *peer.P2pPackageRx) sch.SchErrno {
+func (shMgr *ShellManager) chainMsgFromPeer(rxPkg *peer.P2pPackageRx) sch.SchErrno {
 	shMgr.peerLock.Lock()
 	defer shMgr.peerLock.Unlock()
 
@@ -833,22 +850,18 @@ func (shMgr *ShellManager) getChainDataFromPeer(rxPkg *peer.P2pPackageRx) sch.Sc
 	}
 	pai, ok := shMgr.peerActived[spid]
 	if !ok {
-		log.Debugf("getChainDataFromPeer: active peer not found, sdl: %s, spid: %+v",
+		log.Debugf("chainMsgFromPeer: active peer not found, sdl: %s, spid: %+v",
 			shMgr.sdlName, spid)
 		return sch.SchEnoNotFound
 	}
 	if pai.status != pisActive {
-		log.Debugf("getChainDataFromPeer: peer not active, sdl: %s, spid: %+v",
+		log.Debugf("chainMsgFromPeer: peer not active, sdl: %s, spid: %+v",
 			shMgr.sdlName, spid)
 		return sch.SchEnoNotFound
 	}
 	return sch.SchEnoNone
 }
 
-func (shMgr *ShellManager) putChainDataFromPeer(rxPkg *peer.P2pPackageRx) sch.SchErrno {
-	return sch.SchEnoNone
-}
-
 func (shMgr *ShellManager) deDupTimerCb(el *list.Element, data interface{}) interface{} {
 	// Notice: do not invoke Lock ... Unlock ... on shMgr.deDupLock here
 	// please, since this function is called back within TickProc of timer
@@ -920,54 +933,28 @@ func (shMgr *ShellManager) reportKey2Peer(spi *shellPeerInst, key *config.DsKey,
 	return nil
 }
 
-func (shMgr *ShellManager) getChainData2Peer(spi *shellPeerInst, req *sch.MsgShellGetChainInfoReq) error {
-	if len(spi.txChan) >= cap(spi.txChan) {
-		log.Debugf("getChainData2Peer: discarded, tx queue full, snid: %x, dir: %d, peer: %x",
-			spi.snid, spi.dir, spi.nodeId)
-		if spi.txDiscrd += 1; spi.txDiscrd&0x1f == 0 {
-			log.Debugf("getChainData2Peer：sind: %x, dir: %d, txDiscrd: %d",
-				spi.snid, spi.dir, spi.txDiscrd)
-		}
-		return sch.SchEnoResource
-	}
-	gcd := peer.GetChainData {
-		Seq: req.Seq,
-		Name: req.Kind,
-		Key: req.Key,
-	}
-	upkg := new(peer.P2pPackage)
-	if eno := upkg.GetChainData(spi.pi, &gcd, false); eno != peer.PeMgrEnoNone {
-		log.Debugf("getChainData2Peer: CheckKey failed, eno: %d", eno)
-		return errors.New("getChainData2Peer: ReportKey failed")
-	}
-	spi.txChan <- upkg
-	return nil
-}
-
-func (shMgr *ShellManager) putChainData2Peer(spi *shellPeerInst, rsp *sch.MsgShellGetChainInfoRsp) error {
+func (shMgr *ShellManager) chainMsg2Peer(spi *shellPeerInst, req *peer.ChainRequest, rsp *peer.ChainResponse) error {
 	if len(spi.txChan) >= cap(spi.txChan) {
-		log.Debugf("putChainData2Peer: discarded, tx queue full, " +
+		log.Debugf("chainMsg2Peer: discarded, tx queue full, " +
 			"sdl: %s, snid: %x, dir: %d, peer: %x",
 			shMgr.sdlName, spi.snid, spi.dir, spi.nodeId)
 		if spi.txDiscrd += 1; spi.txDiscrd & 0x1f == 0 {
-			log.Debugf("putChainData2Peer：total lost, " +
+			log.Debugf("chainMsg2Peer：total lost, " +
 				"sdl: %s, sind: %x, dir: %d, txDiscrd: %d",
 				shMgr.sdlName, spi.snid, spi.dir, spi.txDiscrd)
 		}
 		return sch.SchEnoResource
 	}
-	pcd := peer.PutChainData {
-		Seq: rsp.Seq,
-		Name: rsp.Kind,
-		Key: rsp.Key,
-		Data: rsp.Data,
-	}
 	upkg := new(peer.P2pPackage)
-	if eno := upkg.PutChainData(spi.pi, &pcd, false); eno != peer.PeMgrEnoNone {
-		log.Debugf("putChainData2Peer: PutChainData failed, " +
-			"sdl: %s, eno: %d",
-			shMgr.sdlName, eno)
-		return errors.New("putChainData2Peer: ReportKey failed")
+	var eno peer.PeMgrErrno
+	if req != nil {
+		eno = upkg.ChainRequest(spi.pi, req, false)
+	} else {
+		eno = upkg.ChainResponse(spi.pi, rsp, false)
+	}
+	if eno != peer.PeMgrEnoNone {
+		log.Debugf("chainMsg2Peer: package failed, sdl: %s, eno: %d", shMgr.sdlName, eno)
+		return errors.New("chainMsg2Peer: package failed")
 	}
 	spi.txChan <- upkg
 	return nil
@@ -986,36 +973,50 @@ _update_again:
 	return sch.SchEnoNone
 }
 
-func (shMgr *ShellManager)getChainInfoReq(msg *sch.MsgShellGetChainInfoReq) sch.SchErrno {
+func (shMgr *ShellManager)chainReq(msg *sch.MsgShellChainReq) sch.SchErrno {
+	req, ok := msg.Req.(*peer.ChainRequest)
+	if !ok {
+		log.Debugf("chainReq: invalid chain request, sdl: %s", shMgr.sdlName)
+		return sch.SchEnoParameter
+	}
 	shMgr.peerLock.Lock()
 	defer shMgr.peerLock.Unlock()
-	failCount := 0
+	// the target peer might be connected in several sub networks or in both
+	// directions, any of these connections would do.
+	candidates := make([]*shellPeerInst, 0, len(shMgr.peerActived))
 	for _, pe := range shMgr.peerActived {
-		if pe.status == pisActive {
-			if err := shMgr.getChainData2Peer(pe, msg); err != nil {
-				log.Debugf("getChainInfoReq: getChainData2Peer failed, error: %s", err.Error())
-				failCount += 1
-				continue
-			}
-			log.Debugf("getChainInfoReq: put to queue ok, " +
-				"sdl: %s, peer: %v",
-				shMgr.sdlName, *pe.hsInfo)
+		if pe.status != pisActive {
+			continue
 		}
+		if msg.NodeId != nil && pe.nodeId != *msg.NodeId {
+			continue
+		}
+		candidates = append(candidates, pe)
+	}
+	if len(candidates) == 0 {
+		log.Debugf("chainReq: no peer, sdl: %s, target: %v", shMgr.sdlName, msg.NodeId)
+		return sch.SchEnoNotFound
 	}
-	if failCount == len(shMgr.peerActived) {
-		log.Debugf("getChainInfoReq: failed, failCount: %d, peers: %d",
-			failCount, len(shMgr.peerActived))
+	pe := candidates[rand.Intn(len(candidates))]
+	if err := shMgr.chainMsg2Peer(pe, req, nil); err != nil {
+		log.Debugf("chainReq: chainMsg2Peer failed, sdl: %s, error: %s",
+			shMgr.sdlName, err.Error())
 		return sch.SchEnoResource
 	}
 	return sch.SchEnoNone
 }
 
-func (shMgr *ShellManager)getChainInfoRsp(msg *sch.MsgShellGetChainInfoRsp) sch.SchErrno {
+func (shMgr *ShellManager)chainRsp(msg *sch.MsgShellChainRsp) sch.SchErrno {
 	peerInfo, ok := msg.Peer.(*peer.PeerInfo)
 	if !ok {
-		panic("getChainInfoRsp: invalid peer info pointer")
+		panic("chainRsp: invalid peer info pointer")
 		return sch.SchEnoUserTask
 	}
+	rsp, ok := msg.Rsp.(*peer.ChainResponse)
+	if !ok {
+		log.Debugf("chainRsp: invalid chain response, sdl: %s", shMgr.sdlName)
+		return sch.SchEnoParameter
+	}
 	pid := shellPeerID {
 		snid: peerInfo.Snid,
 		dir: peerInfo.Dir,
@@ -1025,11 +1026,11 @@ func (shMgr *ShellManager)getChainInfoRsp(msg *sch.MsgShellGetChainInfoRsp) sch.
 	defer shMgr.peerLock.Unlock()
 	pai, ok := shMgr.peerActived[pid]
 	if !ok || pai == nil {
-		log.Debugf("getChainInfoRsp: peer not found, sdl: %s, %+v", shMgr.sdlName, *peerInfo)
+		log.Debugf("chainRsp: peer not found, sdl: %s, %+v", shMgr.sdlName, *peerInfo)
 		return sch.SchEnoNotFound
 	}
-	if err := shMgr.putChainData2Peer(pai, msg); err != nil {
-		log.Debugf("getChainInfoRsp: putChainData2Peer failed, sdl: %s, error: %s",
+	if err := shMgr.chainMsg2Peer(pai, nil, rsp); err != nil {
+		log.Debugf("chainRsp: chainMsg2Peer failed, sdl: %s, error: %s",
 			shMgr.sdlName, err.Error())
 		return sch.SchEnoResource
 	}
@@ -0,0 +1,394 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package simnet
+
+//
+// Simulated network: services of nodes in one process exchange messages over
+// links with configurable latency, jitter, loss and reordering, and nodes can
+// be partitioned and crashed. every decision on a message is drawn from one
+// random source seeded by the user at the time the message is sent, and
+// messages are delivered in order of virtual time, so a run is reproduced with
+// the same seed as long as messages are sent in the same order. the virtual
+// clock is stepped with Advance in manual mode, or follows the wall clock in
+// realtime mode, which full nodes need since their consensus runs on timers
+// of their own.
+//
+
+import (
+	"container/heap"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Link between two nodes
+type Link struct {
+	Latency time.Duration // one-way delay
+	Jitter  time.Duration // random delay added, in [0, Jitter)
+	Loss    float64       // probability a message is lost
+	Reorder float64       // probability a message is held for one more delay, so later ones overtake it
+}
+
+// Network configuration
+type Config struct {
+	Seed     int64         // seed of the random source
+	Realtime bool          // clock follows the wall clock, else stepped with Advance
+	Tick     time.Duration // step of clock in realtime mode
+	Link     Link          // default link between nodes
+}
+
+// Message counters, Dropped are those sent or in flight to nodes partitioned
+// or crashed, Lost are those lost on links
+type Stats struct {
+	Sent      uint64
+	Delivered uint64
+	Lost      uint64
+	Dropped   uint64
+}
+
+var (
+	Epoch       = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC) // virtual time when a network is created
+	DefaultLink = Link{Latency: time.Millisecond * 10}        // default link if none configured
+	DefaultTick = time.Millisecond * 2                        // default step of clock in realtime mode
+
+	ErrNodeExists = errors.New("simnet: node exists")
+	ErrNoNode     = errors.New("simnet: no such node")
+)
+
+type linkKey struct {
+	from, to string
+}
+
+// Message in flight, handled by the service of the destination node in the
+// time it's delivered, since the node might be restarted meanwhile
+type delivery struct {
+	at       time.Time
+	seq      uint64
+	from, to string
+	fn       func(dst *Service)
+}
+
+type deliveryQueue []*delivery
+
+func (q deliveryQueue) Len() int { return len(q) }
+func (q deliveryQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+func (q deliveryQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *deliveryQueue) Push(x interface{}) { *q = append(*q, x.(*delivery)) }
+func (q *deliveryQueue) Pop() interface{} {
+	old := *q
+	d := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return d
+}
+
+// Simulated network
+type Network struct {
+	lock    sync.Mutex          // lock for all below
+	cfg     Config              // configuration
+	rand    *rand.Rand          // random source for all decisions
+	now     time.Time           // virtual time
+	seq     uint64              // sequence of last message sent
+	queue   deliveryQueue       // messages in flight
+	nodes   map[string]*Service // services by node identity
+	links   map[linkKey]Link    // links configured
+	group   map[string]int      // partition groups, nodes not in map are in group 0
+	crashed map[string]bool     // nodes crashed
+	stats   Stats               // message counters
+	quit    chan struct{}       // closed to stop clock in realtime mode
+	wg      sync.WaitGroup      // wait clock done
+}
+
+// Create network, the clock starts to run in realtime mode
+func New(cfg Config) *Network {
+	if cfg.Link == (Link{}) {
+		cfg.Link = DefaultLink
+	}
+	if cfg.Tick <= 0 {
+		cfg.Tick = DefaultTick
+	}
+	net := &Network{
+		cfg:     cfg,
+		rand:    rand.New(rand.NewSource(cfg.Seed)),
+		now:     Epoch,
+		nodes:   make(map[string]*Service),
+		links:   make(map[linkKey]Link),
+		group:   make(map[string]int),
+		crashed: make(map[string]bool),
+		quit:    make(chan struct{}),
+	}
+	if cfg.Realtime {
+		net.wg.Add(1)
+		go net.clock()
+	}
+	return net
+}
+
+// Stop clock, messages in flight are discarded
+func (net *Network) Close() {
+	close(net.quit)
+	net.wg.Wait()
+	net.lock.Lock()
+	net.queue = nil
+	net.lock.Unlock()
+}
+
+func (net *Network) clock() {
+	defer net.wg.Done()
+	start := time.Now()
+	ticker := time.NewTicker(net.cfg.Tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-net.quit:
+			return
+		case now := <-ticker.C:
+			net.advanceTo(Epoch.Add(now.Sub(start)))
+		}
+	}
+}
+
+// Virtual time
+func (net *Network) Now() time.Time {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	return net.now
+}
+
+// Step clock in manual mode, messages due are delivered in order
+func (net *Network) Advance(d time.Duration) {
+	net.advanceTo(net.Now().Add(d))
+}
+
+// Step clock until no message in flight, or max duration elapsed, returns the
+// duration elapsed
+func (net *Network) RunUntilIdle(max time.Duration) time.Duration {
+	net.lock.Lock()
+	start := net.now
+	net.lock.Unlock()
+	for {
+		net.lock.Lock()
+		if len(net.queue) == 0 || net.queue[0].at.Sub(start) > max {
+			net.lock.Unlock()
+			break
+		}
+		at := net.queue[0].at
+		net.lock.Unlock()
+		net.advanceTo(at)
+	}
+	return net.Now().Sub(start)
+}
+
+func (net *Network) advanceTo(t time.Time) {
+	for {
+		net.lock.Lock()
+		if len(net.queue) == 0 || net.queue[0].at.After(t) {
+			if t.After(net.now) {
+				net.now = t
+			}
+			net.lock.Unlock()
+			return
+		}
+		d := heap.Pop(&net.queue).(*delivery)
+		if d.at.After(net.now) {
+			net.now = d.at
+		}
+		dst := net.nodes[d.to]
+		if !net.reachable(d.from, d.to) || dst == nil {
+			net.stats.Dropped++
+			net.lock.Unlock()
+			continue
+		}
+		net.stats.Delivered++
+		net.lock.Unlock()
+		d.fn(dst)
+	}
+}
+
+// Set default link
+func (net *Network) SetDefaultLink(l Link) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.cfg.Link = l
+}
+
+// Set link between two nodes, both directions
+func (net *Network) SetLink(a, b string, l Link) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.links[linkKey{a, b}] = l
+	net.links[linkKey{b, a}] = l
+}
+
+// Partition nodes into groups, nodes not listed form another group. messages
+// in flight between groups are dropped
+func (net *Network) Partition(groups ...[]string) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.group = make(map[string]int)
+	for i, g := range groups {
+		for _, id := range g {
+			net.group[id] = i + 1
+		}
+	}
+}
+
+// Heal all partitions
+func (net *Network) Heal() {
+	net.Partition()
+}
+
+// Crash node, it sends and receives nothing until recovered, messages in
+// flight to and from it are dropped
+func (net *Network) Crash(id string) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.crashed[id] = true
+}
+
+// Recover node crashed
+func (net *Network) Recover(id string) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	delete(net.crashed, id)
+}
+
+// Message counters
+func (net *Network) Stats() Stats {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	return net.stats
+}
+
+// Identities of nodes, sorted
+func (net *Network) Nodes() []string {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	return net.sortedNodes()
+}
+
+func (net *Network) sortedNodes() []string {
+	ids := make([]string, 0, len(net.nodes))
+	for id := range net.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (net *Network) attach(s *Service) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.nodes[s.id] = s
+}
+
+func (net *Network) detach(s *Service) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	if net.nodes[s.id] == s {
+		delete(net.nodes, s.id)
+	}
+}
+
+func (net *Network) reachable(a, b string) bool {
+	return !net.crashed[a] && !net.crashed[b] && net.group[a] == net.group[b]
+}
+
+func (net *Network) link(a, b string) Link {
+	if l, ok := net.links[linkKey{a, b}]; ok {
+		return l
+	}
+	return net.cfg.Link
+}
+
+// Send message over link, fn is called with the service of destination when
+// it's delivered
+func (net *Network) send(from, to string, fn func(dst *Service)) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.stats.Sent++
+	if !net.reachable(from, to) {
+		net.stats.Dropped++
+		return
+	}
+	l := net.link(from, to)
+	if l.Loss > 0 && net.rand.Float64() < l.Loss {
+		net.stats.Lost++
+		return
+	}
+	delay := net.delay(l)
+	if l.Reorder > 0 && net.rand.Float64() < l.Reorder {
+		delay += net.delay(l)
+	}
+	net.seq++
+	heap.Push(&net.queue, &delivery{
+		at:   net.now.Add(delay),
+		seq:  net.seq,
+		from: from,
+		to:   to,
+		fn:   fn,
+	})
+}
+
+func (net *Network) delay(l Link) time.Duration {
+	d := l.Latency
+	if l.Jitter > 0 {
+		d += time.Duration(net.rand.Int63n(int64(l.Jitter)))
+	}
+	return d
+}
+
+// Peers a node could reach now, sorted
+func (net *Network) peers(id string) []string {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	peers := make([]string, 0, len(net.nodes))
+	for _, p := range net.sortedNodes() {
+		if p != id && net.reachable(id, p) {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
+
+// Pick one of candidates with the random source
+func (net *Network) pick(candidates []string) string {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	return candidates[net.rand.Intn(len(candidates))]
+}
+
+// Roll for loss of a synchronous query between nodes
+func (net *Network) query(from, to string) bool {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	if !net.reachable(from, to) || net.nodes[to] == nil {
+		return false
+	}
+	l := net.link(from, to)
+	return l.Loss <= 0 || net.rand.Float64() >= l.Loss
+}
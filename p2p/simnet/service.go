@@ -0,0 +1,338 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package simnet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yeeco/gyee/p2p"
+)
+
+// Service of a node on simulated network, it implements p2p.Service. messages
+// delivered are queued in the service, and passed to subscribers in order by
+// a routine of the service, so a slow node does not block the network. dht
+// values are stored by nodes, a value set is sent to all nodes reachable over
+// links, and a value not found locally is queried from nodes reachable.
+type Service struct {
+	id          string            // node identity, as From of messages sent
+	net         *Network          // network attached
+	subscribers *sync.Map         // subscribers by message type
+	cp          p2p.ChainProvider // chain data provider
+	lock        sync.Mutex        // lock for inbox and dht
+	inbox       []p2p.Message     // messages delivered not yet passed to subscribers
+	inboxCh     chan struct{}     // signal messages delivered
+	dht         map[string][]byte // dht values stored
+	readyCh     chan struct{}     // closed when started
+	quitCh      chan struct{}     // closed when stopped
+	wg          sync.WaitGroup    // wait routine done
+}
+
+// Create service of node on network, a service created with the identity of
+// a node stopped replaces it when started, as a node restarted
+func (net *Network) NewService(id string) *Service {
+	return &Service{
+		id:          id,
+		net:         net,
+		subscribers: new(sync.Map),
+		inboxCh:     make(chan struct{}, 1),
+		dht:         make(map[string][]byte),
+		readyCh:     make(chan struct{}),
+		quitCh:      make(chan struct{}),
+	}
+}
+
+// Node identity
+func (s *Service) ID() string {
+	return s.id
+}
+
+func (s *Service) Start() error {
+	s.net.attach(s)
+	s.wg.Add(1)
+	go s.loop()
+	close(s.readyCh)
+	return nil
+}
+
+func (s *Service) Stop() {
+	s.net.detach(s)
+	close(s.quitCh)
+	s.wg.Wait()
+}
+
+func (s *Service) Ready() {
+	<-s.readyCh
+}
+
+func (s *Service) loop() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.quitCh:
+			return
+		case <-s.inboxCh:
+		}
+		s.lock.Lock()
+		msgs := s.inbox
+		s.inbox = nil
+		s.lock.Unlock()
+		for _, msg := range msgs {
+			t, _ := s.subscribers.Load(msg.MsgType)
+			if t == nil {
+				continue
+			}
+			t.(*sync.Map).Range(func(key, value interface{}) bool {
+				select {
+				case key.(*p2p.Subscriber).MsgChan <- msg:
+				case <-s.quitCh:
+					return false
+				}
+				return true
+			})
+		}
+	}
+}
+
+func (s *Service) enqueue(msg p2p.Message) {
+	s.lock.Lock()
+	s.inbox = append(s.inbox, msg)
+	s.lock.Unlock()
+	select {
+	case s.inboxCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) Reconfig(reCfg *p2p.RecfgCommand) error {
+	return nil
+}
+
+func (s *Service) BroadcastMessage(message p2p.Message) error {
+	message.From = s.id
+	for _, to := range s.net.peers(s.id) {
+		s.net.send(s.id, to, func(dst *Service) {
+			dst.enqueue(message)
+		})
+	}
+	return nil
+}
+
+func (s *Service) BroadcastMessageOsn(message p2p.Message) error {
+	return s.BroadcastMessage(message)
+}
+
+func (s *Service) Register(subscriber *p2p.Subscriber) {
+	m, _ := s.subscribers.LoadOrStore(subscriber.MsgType, new(sync.Map))
+	m.(*sync.Map).Store(subscriber, true)
+}
+
+func (s *Service) UnRegister(subscriber *p2p.Subscriber) {
+	if subscriber == nil {
+		return
+	}
+	if m, _ := s.subscribers.Load(subscriber.MsgType); m != nil {
+		m.(*sync.Map).Delete(subscriber)
+	}
+}
+
+func (s *Service) DhtGetValue(key []byte) ([]byte, error) {
+	s.lock.Lock()
+	v, ok := s.dht[string(key)]
+	s.lock.Unlock()
+	if ok {
+		return v, nil
+	}
+	for _, p := range s.net.peers(s.id) {
+		if !s.net.query(s.id, p) {
+			continue
+		}
+		s.net.lock.Lock()
+		dst := s.net.nodes[p]
+		s.net.lock.Unlock()
+		if dst == nil {
+			continue
+		}
+		dst.lock.Lock()
+		v, ok := dst.dht[string(key)]
+		dst.lock.Unlock()
+		if ok {
+			return v, nil
+		}
+	}
+	return nil, p2p.ErrDhtNotFound
+}
+
+func (s *Service) DhtGetValues(keys [][]byte, out chan<- []byte, timeout time.Duration) error {
+	if cap(out) < len(keys) {
+		return p2p.ErrInsufficientOutChanCapacity
+	}
+	go func() {
+		for _, key := range keys {
+			if v, err := s.DhtGetValue(key); err == nil {
+				out <- v
+			}
+		}
+		close(out)
+	}()
+	return nil
+}
+
+func (s *Service) DhtSetValue(key []byte, value []byte) error {
+	k := string(key)
+	s.lock.Lock()
+	s.dht[k] = value
+	s.lock.Unlock()
+	for _, to := range s.net.peers(s.id) {
+		s.net.send(s.id, to, func(dst *Service) {
+			dst.lock.Lock()
+			dst.dht[k] = value
+			dst.lock.Unlock()
+		})
+	}
+	return nil
+}
+
+// records are not validated in simnet, extra ignored
+func (s *Service) DhtSetRecord(key []byte, value []byte, extra []byte) error {
+	return s.DhtSetValue(key, value)
+}
+
+func (s *Service) RegChainProvider(cp p2p.ChainProvider) {
+	s.cp = cp
+}
+
+// Chain request over links, the peer answers with its provider when the
+// request is delivered, the response is then sent back
+func (s *Service) chainRequest(ctx context.Context, peer string,
+	answer func(cp p2p.ChainProvider) *p2p.ChainResponse) (*p2p.ChainResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(peer) == 0 {
+		peers := s.net.peers(s.id)
+		if len(peers) == 0 {
+			return nil, p2p.ErrChainNoPeer
+		}
+		peer = s.net.pick(peers)
+	}
+	rspCh := make(chan *p2p.ChainResponse, 1)
+	s.net.send(s.id, peer, func(dst *Service) {
+		if dst.cp == nil {
+			return
+		}
+		rsp := answer(dst.cp)
+		rsp.From = dst.id
+		dst.net.send(dst.id, s.id, func(*Service) {
+			rspCh <- rsp
+		})
+	})
+	select {
+	case rsp := <-rspCh:
+		return rsp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.quitCh:
+		return nil, p2p.ErrChainNoPeer
+	}
+}
+
+func (s *Service) GetStatus(ctx context.Context, peer string) (*p2p.ChainResponse, error) {
+	return s.chainRequest(ctx, peer, func(cp p2p.ChainProvider) *p2p.ChainResponse {
+		return &p2p.ChainResponse{Status: cp.ChainStatus()}
+	})
+}
+
+func (s *Service) GetHeaders(ctx context.Context, peer string, from uint64, count int) (*p2p.ChainResponse, error) {
+	if count > p2p.ChainMaxHeaders {
+		return nil, p2p.ErrChainTooMany
+	}
+	return s.chainRequest(ctx, peer, func(cp p2p.ChainProvider) *p2p.ChainResponse {
+		return &p2p.ChainResponse{Items: cp.ChainHeaders(from, count)}
+	})
+}
+
+func (s *Service) GetBlocks(ctx context.Context, peer string, hashes [][]byte) (*p2p.ChainResponse, error) {
+	if len(hashes) > p2p.ChainMaxBlocks {
+		return nil, p2p.ErrChainTooMany
+	}
+	return s.chainRequest(ctx, peer, func(cp p2p.ChainProvider) *p2p.ChainResponse {
+		return &p2p.ChainResponse{Items: cp.ChainBlocks(hashes)}
+	})
+}
+
+func (s *Service) GetTxs(ctx context.Context, peer string, hashes [][]byte) (*p2p.ChainResponse, error) {
+	if len(hashes) > p2p.ChainMaxTxs {
+		return nil, p2p.ErrChainTooMany
+	}
+	return s.chainRequest(ctx, peer, func(cp p2p.ChainProvider) *p2p.ChainResponse {
+		return &p2p.ChainResponse{Items: cp.ChainTxs(hashes)}
+	})
+}
+
+func (s *Service) GetProof(ctx context.Context, peer string, root, key []byte) (*p2p.ChainResponse, error) {
+	return s.chainRequest(ctx, peer, func(cp p2p.ChainProvider) *p2p.ChainResponse {
+		return &p2p.ChainResponse{Items: cp.ChainProof(root, key)}
+	})
+}
+
+func (s *Service) ReportPeer(nodeID string, offence string, severity int) error {
+	return nil
+}
+
+func (s *Service) ListBans() []p2p.PeerBan {
+	return nil
+}
+
+func (s *Service) ClearBans(nodeID string) (int, error) {
+	return 0, nil
+}
+
+func (s *Service) PeerBandwidth() []p2p.Bandwidth {
+	return nil
+}
+
+func (s *Service) SubnetBandwidth() []p2p.Bandwidth {
+	return nil
+}
+
+func (s *Service) AddPeer(node string, trusted bool) error {
+	return nil
+}
+
+func (s *Service) RemovePeer(nodeID string) error {
+	return nil
+}
+
+// Peers reachable now, as connected
+func (s *Service) ListPeers() ([]p2p.PeerInfo, error) {
+	peers := s.net.peers(s.id)
+	list := make([]p2p.PeerInfo, 0, len(peers))
+	for _, p := range peers {
+		list = append(list, p2p.PeerInfo{NodeID: p, Node: p, Connected: true})
+	}
+	return list, nil
+}
+
+func (s *Service) SetValidators(validators []string, self string, sign func(hash []byte) ([]byte, error)) error {
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  the gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  the gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package simnet
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/p2p"
+)
+
+// network of nodes a, b, c, returns messages received by each node after n
+// broadcasts from a
+func runBroadcasts(t *testing.T, seed int64, n int, link Link) map[string][]string {
+	net := New(Config{Seed: seed, Link: link})
+	defer net.Close()
+	chans := make(map[string]chan p2p.Message)
+	svcs := make(map[string]*Service)
+	for _, id := range []string{"a", "b", "c"} {
+		s := net.NewService(id)
+		ch := make(chan p2p.Message, n)
+		s.Register(p2p.NewSubscriber(nil, ch, p2p.MessageTypeTx))
+		s.Start()
+		defer s.Stop()
+		chans[id] = ch
+		svcs[id] = s
+	}
+	for i := 0; i < n; i++ {
+		svcs["a"].BroadcastMessage(p2p.Message{MsgType: p2p.MessageTypeTx, Data: []byte(fmt.Sprint(i))})
+	}
+	net.RunUntilIdle(time.Minute)
+
+	got := make(map[string][]string)
+	for id, ch := range chans {
+		timeout := time.After(100 * time.Millisecond)
+	loop:
+		for {
+			select {
+			case msg := <-ch:
+				if msg.From != "a" {
+					t.Errorf("message from %s, want a", msg.From)
+				}
+				got[id] = append(got[id], string(msg.Data))
+			case <-timeout:
+				break loop
+			}
+		}
+	}
+	return got
+}
+
+func TestDeterministic(t *testing.T) {
+	link := Link{Latency: 10 * time.Millisecond, Jitter: 20 * time.Millisecond, Loss: 0.2, Reorder: 0.2}
+	first := runBroadcasts(t, 7, 100, link)
+	second := runBroadcasts(t, 7, 100, link)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("runs with same seed differ:\n%v\n%v", first, second)
+	}
+	if len(first["a"]) != 0 {
+		t.Errorf("sender received its own messages")
+	}
+	for _, id := range []string{"b", "c"} {
+		if n := len(first[id]); n == 0 || n == 100 {
+			t.Errorf("node %s received %d of 100 messages with loss", id, n)
+		}
+	}
+}
+
+func TestPartitionCrash(t *testing.T) {
+	net := New(Config{Seed: 1})
+	defer net.Close()
+	a, b := net.NewService("a"), net.NewService("b")
+	ch := make(chan p2p.Message, 8)
+	b.Register(p2p.NewSubscriber(nil, ch, p2p.MessageTypeEvent))
+	a.Start()
+	b.Start()
+	defer a.Stop()
+	defer b.Stop()
+
+	received := func() bool {
+		select {
+		case <-ch:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}
+	ev := p2p.Message{MsgType: p2p.MessageTypeEvent}
+
+	net.Partition([]string{"a"})
+	a.BroadcastMessage(ev)
+	net.RunUntilIdle(time.Second)
+	if received() {
+		t.Fatalf("message crossed partition")
+	}
+
+	// in flight when partitioned
+	net.Heal()
+	a.BroadcastMessage(ev)
+	net.Partition([]string{"b"})
+	net.RunUntilIdle(time.Second)
+	if received() {
+		t.Fatalf("message in flight crossed partition")
+	}
+
+	net.Heal()
+	net.Crash("b")
+	a.BroadcastMessage(ev)
+	net.RunUntilIdle(time.Second)
+	if received() {
+		t.Fatalf("crashed node received message")
+	}
+
+	net.Recover("b")
+	a.BroadcastMessage(ev)
+	net.RunUntilIdle(time.Second)
+	if !received() {
+		t.Fatalf("message lost without loss configured")
+	}
+	if s := net.Stats(); s.Sent != 2 || s.Delivered != 1 || s.Dropped != 1 {
+		t.Errorf("stats %+v", s)
+	}
+}
+
+func TestDht(t *testing.T) {
+	net := New(Config{Seed: 1})
+	defer net.Close()
+	a, b := net.NewService("a"), net.NewService("b")
+	a.Start()
+	b.Start()
+	defer a.Stop()
+	defer b.Stop()
+
+	a.DhtSetValue([]byte("k"), []byte("v"))
+	net.Partition([]string{"a"})
+	if _, err := b.DhtGetValue([]byte("k")); err == nil {
+		t.Fatalf("value got across partition before replicated")
+	}
+	net.Heal()
+	net.Advance(time.Second)
+	net.Partition([]string{"a"})
+	if v, err := b.DhtGetValue([]byte("k")); err != nil || string(v) != "v" {
+		t.Fatalf("value replicated not got: %s, %v", v, err)
+	}
+}
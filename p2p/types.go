@@ -22,6 +22,9 @@ package p2p
 
 import (
 	"errors"
+	"time"
+
+	"github.com/yeeco/gyee/p2p/reputation"
 )
 
 const (
@@ -29,6 +32,7 @@ const (
 	MessageTypeEvent       = "ev"
 	MessageTypeBlockHeader = "blkH"
 	MessageTypeBlock       = "blk"
+	MessageTypeEvidence    = "evi"
 )
 
 type Message struct {
@@ -38,9 +42,93 @@ type Message struct {
 	Data    []byte
 }
 
+// offences and severities for ReportPeer
+const (
+	PeerOffenceUndecodable  = reputation.OffenceUndecodable
+	PeerOffenceBadSignature = reputation.OffenceBadSignature
+	PeerOffenceInvalid      = reputation.OffenceInvalid
+
+	PeerSeverityMinor = reputation.SeverityMinor
+	PeerSeverityMajor = reputation.SeverityMajor
+	PeerSeverityFatal = reputation.SeverityFatal
+)
+
+type PeerBan struct {
+	NodeID  string
+	Offence string
+	Until   time.Time
+}
+
+// peer connected, reserved or trusted, Node is in format as the bootstrap nodes,
+// and Subnet and Inbound are set only when Connected. reserved peers are always
+// connected, trusted peers are accepted, and both are exempt from peer caps
+type PeerInfo struct {
+	NodeID    string
+	Node      string
+	Subnet    string
+	Inbound   bool
+	Connected bool
+	Reserved  bool
+	Trusted   bool
+}
+
+// key in rate limits for all traffic with a peer, others are message types
+const RateLimitPeer = "peer"
+
+// traffic counters, Dropped is number of messages discarded for rate limits
+type Traffic struct {
+	Msgs    uint64
+	Bytes   uint64
+	Dropped uint64
+}
+
+// bandwidth of a peer, or of a sub network when NodeID is empty, counters are
+// keyed by message type
+type Bandwidth struct {
+	NodeID  string
+	Subnet  string
+	Inbound bool
+	Rx      map[string]Traffic
+	Tx      map[string]Traffic
+}
+
+// version of chain protocol, and most items a peer answers in one response
+const (
+	ChainProtoVersion = 1
+	ChainMaxHeaders   = 192
+	ChainMaxBlocks    = 32
+	ChainMaxTxs       = 1024
+	ChainMaxBytes     = 2 * 1024 * 1024
+)
+
+// chain status of a peer, hashes are of the head and the genesis block
+type ChainStatus struct {
+	Genesis []byte
+	Head    []byte
+	Height  uint64
+}
+
+// response of chain protocol, From is the node identity of the peer answered
+// as that in Message.From, Items are encoded headers, blocks, transactions,
+// or trie nodes of a merkle proof
+type ChainResponse struct {
+	From   string
+	Status *ChainStatus
+	Items  [][]byte
+}
+
 var (
 	ErrDhtNotFound                 = errors.New("dht value not found")
 	ErrInsufficientOutChanCapacity = errors.New("output chan capacity insufficient")
 	ErrResourceLimited             = errors.New("underlying resources limited")
 	ErrDhtInternal                 = errors.New("dht internal errors")
+	ErrInvalidNodeID               = errors.New("invalid node identity")
+	ErrPeerNotBanned               = errors.New("peer not banned")
+	ErrInvalidNodeUrl              = errors.New("invalid node url")
+	ErrPeerNotFound                = errors.New("peer not found")
+	ErrPeerSetFailed               = errors.New("peer set request failed")
+	ErrPeerSetTimeout              = errors.New("peer set request timeout")
+	ErrChainNoPeer                 = errors.New("no peer for chain request")
+	ErrChainTooMany                = errors.New("too many items in chain request")
+	ErrChainVersion                = errors.New("chain protocol version mismatch")
 )
@@ -22,10 +22,13 @@ package p2p
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"crypto/ecdsa"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +38,8 @@ import (
 	p2plog "github.com/yeeco/gyee/p2p/logger"
 	"github.com/yeeco/gyee/p2p/peer"
 	sch "github.com/yeeco/gyee/p2p/scheduler"
+	"github.com/yeeco/gyee/p2p/ratelimit"
+	"github.com/yeeco/gyee/p2p/reputation"
 	p2psh "github.com/yeeco/gyee/p2p/shell"
 
 
@@ -78,6 +83,7 @@ const (
 	yesMaxFindNode    = 4                   // max find node commands in pending
 	yesMaxGetProvider = 4                   // max get provider commands in pending
 	yesMaxPutProvider = 4                   // max put provider commands in pending
+	yesPeerSetTimeout = time.Second * 4     // duration to wait peer manager for peer set requests
 )
 
 var yesMtAtoi = map[string]int{
@@ -85,6 +91,7 @@ var yesMtAtoi = map[string]int{
 	MessageTypeEvent:       sch.MSBR_MT_EV,
 	MessageTypeBlockHeader: sch.MSBR_MT_BLKH,
 	MessageTypeBlock:       sch.MSBR_MT_BLK,
+	MessageTypeEvidence:    sch.MSBR_MT_EVI,
 }
 
 var yesMidItoa = map[int]string{
@@ -92,6 +99,7 @@ var yesMidItoa = map[int]string{
 	int(sch.MSBR_MT_EV):   MessageTypeEvent,
 	int(sch.MSBR_MT_BLKH): MessageTypeBlockHeader,
 	int(sch.MSBR_MT_BLK):  MessageTypeBlock,
+	int(sch.MSBR_MT_EVI):  MessageTypeEvidence,
 }
 
 type SubnetDescriptor struct {
@@ -115,8 +123,7 @@ const (
 	GVBS = 256				// get value buffer size
 	PVTO = time.Second * 64	// put value timeout
 	PVBS = 256				// put value buffer size
-	GCITO = time.Second * 8	// duration for get chain information
-	GCIBS = 64				// get chain formation buffer size
+	CHRBS = 64				// chain requests pending buffer size
 	gvkChBufSize = 64		// get value duplicated channel buffer size
 )
 
@@ -135,17 +142,15 @@ type putValueResult struct {
 	key		[]byte		// key
 }
 
-const GCIKEY_LEN = 32
-type getChainInfoKeyEx struct {
-	name	string				// name(kind)
-	key		[GCIKEY_LEN]byte	// key, obtained from a slice, "0"s padding
-	keyLen	int					// ken length
+type chainRspEx struct {
+	from	config.NodeID			// peer answered
+	rsp		*peer.ChainResponse		// response
 }
 
-type getChainInfoValEx struct {
-	gcdChan		chan []byte		// channel to sleep on
-	gcdTimer	*time.Timer		// timer for expiration
-	gcdSeq		uint64			// sequence
+type chainReqVal struct {
+	nodeId	*config.NodeID			// peer asked, responses from others rejected
+	kind	int32					// kind of request
+	rspChan	chan *chainRspEx		// channel to sleep on
 }
 
 type YesErrno int
@@ -158,8 +163,7 @@ const (
 	YesEnoGetValFull
 	YesEnoGetValDup
 	YesEnoGetValDupFull
-	YesEnoGcdFull
-	YesEnoGcdDup
+	YesEnoChainFull
 	YesEnoTimeout
 	YesEnoResource
 	YesEnoScheduler
@@ -177,8 +181,7 @@ var YesErrMsg = []string {
 	"yesmgr: YesEnoGetValFull",
 	"yesmgr: YesEnoGetValDup",
 	"yesmgr: YesEnoGetValDupFull",
-	"yesmgr: YesEnoGcdFull",
-	"yesmgr: YesEnoGcdDup",
+	"yesmgr: YesEnoChainFull",
 	"yesmgr: YesEnoTimeout",
 	"yesmgr: YesEnoResource",
 	"yesmgr: YesEnoScheduler",
@@ -210,6 +213,7 @@ type YeShellManager struct {
 	chainSdlName   string							// chain scheduler name
 	ptnChainShell  interface{}                      // chain shell manager task node pointer
 	ptChainShMgr   *p2psh.ShellManager              // chain shell manager object
+	ptPeMgr        *peer.PeerManager                // chain peer manager object
 	dhtInst        *sch.Scheduler                   // dht scheduler pointer
 	dhtSdlName     string							// dht scheduler name
 	ptnDhtShell    interface{}                      // dht shell manager task node pointer
@@ -237,9 +241,13 @@ type YeShellManager struct {
 	ddtChan        chan bool                        // deduplication ticker channel
 	bsTicker       *time.Ticker                     // bootstrap ticker
 	dhtBsChan      chan bool                        // bootstrap ticker channel
-	cp             ChainProvider                    // interface registered to p2p for "get chain data" message
-	gciLock		   sync.Mutex						// get chain data lock
-	gciMap         map[getChainInfoKeyEx]*getChainInfoValEx // map for get chain information
+	cp             ChainProvider                    // interface registered to p2p for chain requests
+	reputation     *reputation.Reputation           // peer reputation table shared by chain and dht
+	chainReqLock   sync.Mutex                       // lock for chain requests pending
+	chainReqSeq    uint64                           // sequence of last chain request
+	chainReqMap    map[uint64]*chainReqVal          // chain requests pending, keyed by sequence
+	valLock        sync.Mutex                       // lock for validator set
+	valReq         *sch.MsgPeValidatorSetReq        // last validator set, installed when started
 }
 
 const MaxSubNetMaskBits = 15 // max number of mask bits for sub network identity
@@ -254,11 +262,15 @@ type YeShellConfig struct {
 	BootstrapNode     bool                                // bootstrap node flag
 	BootstrapNodes    []string                            // bootstrap nodes
 	DhtBootstrapNodes []string                            // bootstrap nodes for dht
+	ReservedNodes     []string                            // reserved nodes, always connected and exempt from caps
+	TrustedNodes      []string                            // trusted nodes, exempt from caps
 	LocalNodeIp       string                              // local node ip for chain-peers
 	LocalUdpPort      uint16                              // local node udp port
 	LocalTcpPort      uint16                              // local node tcp port
+	LocalAddrs        []string                            // more local node ips of a multi-homed node, ipv4 or ipv6
 	LocalDhtIp        string                              // local dht ip
 	LocalDhtPort      uint16                              // local dht port
+	LocalDhtAddrs     []string                            // more local dht ips of a multi-homed node, ipv4 or ipv6
 	NodeDataDir       string                              // node data directory
 	NodeDatabase      string                              // node database
 	SubNetMaskBits    int                                 // mask bits for sub network identity
@@ -267,6 +279,8 @@ type YeShellConfig struct {
 	BootstrapTime     time.Duration                       // duration for bootstrap blind connection
 	NatType           string                              // nat type, "none"/"pmp"/"upnp"
 	GatewayIp         string                              // gateway ip when nat type is "pmp"
+	Encryption        string                              // transport encryption, "off"/"optional"/"required"
+	RateLimits        map[string]config.RateLimit         // rate limits with a peer by message type, RateLimitPeer for all
 	localSnid         []config.SubNetworkID               // local sub network identities
 	localNode         map[config.SubNetworkID]config.Node // local sub nodes
 	dhtBootstrapNodes []*config.Node                      // dht bootstarp nodes
@@ -280,6 +294,8 @@ const (
 	DftBootstrapTime = time.Second * 4
 	DftNatType       = config.NATT_NONE
 	DftGatewayIp     = "0.0.0.0"
+	DftTxRxMsgRate   = 2000            // inbound tx messages per second from a peer
+	DftTxRxByteRate  = 4 * 1024 * 1024 // inbound tx bytes per second from a peer
 )
 
 // Default yee shell configuration for convenience
@@ -307,6 +323,10 @@ var DefaultYeShellConfig = YeShellConfig{
 	BootstrapTime:     DftBootstrapTime,
 	NatType:           DftNatType,
 	GatewayIp:         DftGatewayIp,
+	Encryption:        config.ENC_REQUIRED,
+	RateLimits: map[string]config.RateLimit{
+		MessageTypeTx: {RxMsgs: DftTxRxMsgRate, RxBytes: DftTxRxByteRate},
+	},
 	localSnid:         make([]config.SubNetworkID, 0),
 	localNode:         make(map[config.SubNetworkID]config.Node, 0),
 	dhtBootstrapNodes: make([]*config.Node, 0),
@@ -346,7 +366,21 @@ func YeShellConfigToP2pCfg(yesCfg *YeShellConfig) ([]*config.Config, *YeShellCon
 	chainCfg.Name = yesCfg.Name
 	chainCfg.ChainId = yesCfg.ChainId
 	chainCfg.NodeDataDir = yesCfg.NodeDataDir
+	chainCfg.Encryption = yesCfg.Encryption
+	chainCfg.PeerRateLimit = yesCfg.RateLimits[RateLimitPeer]
+	chainCfg.MsgRateLimits = make(map[uint32]config.RateLimit, 0)
+	for mt, rl := range yesCfg.RateLimits {
+		if mid, ok := yesMtAtoi[mt]; ok {
+			chainCfg.MsgRateLimits[uint32(mid)] = rl
+		}
+	}
 	chainCfg.DhtFdsCfg.Path = yesCfg.NodeDataDir
+	if chainCfg.ReservedNodes = config.P2pSetupBootstrapNodes(yesCfg.ReservedNodes); chainCfg.ReservedNodes == nil {
+		log.Debugf("YeShellConfigToP2pCfg: invalid reserved nodes: %v", yesCfg.ReservedNodes)
+	}
+	if chainCfg.TrustedNodes = config.P2pSetupBootstrapNodes(yesCfg.TrustedNodes); chainCfg.TrustedNodes == nil {
+		log.Debugf("YeShellConfigToP2pCfg: invalid trusted nodes: %v", yesCfg.TrustedNodes)
+	}
 	if yesCfg.NodeDatabase != "" {
 		chainCfg.NodeDatabase = yesCfg.NodeDatabase
 	}
@@ -360,6 +394,10 @@ func YeShellConfigToP2pCfg(yesCfg *YeShellConfig) ([]*config.Config, *YeShellCon
 		log.Debugf("YeShellConfigToP2pCfg: P2pSetLocalIpAddr failed")
 		return nil, nil
 	}
+	if config.P2pSetLocalAddrs(chainCfg, yesCfg.LocalAddrs) != config.P2pCfgEnoNone {
+		log.Debugf("YeShellConfigToP2pCfg: P2pSetLocalAddrs failed, addrs: %v", yesCfg.LocalAddrs)
+		return nil, nil
+	}
 	if config.P2pSetupLocalNodeId(chainCfg) != config.P2pCfgEnoNone {
 		log.Debugf("YeShellConfigToP2pCfg: P2pSetupLocalNodeId failed")
 		return nil, nil
@@ -382,6 +420,10 @@ func YeShellConfigToP2pCfg(yesCfg *YeShellConfig) ([]*config.Config, *YeShellCon
 		log.Debugf("YeShellConfigToP2pCfg: P2pSetLocalDhtIpAddr failed")
 		return nil, nil
 	}
+	if config.P2pSetLocalDhtAddrs(chainCfg, yesCfg.LocalDhtAddrs) != config.P2pCfgEnoNone {
+		log.Debugf("YeShellConfigToP2pCfg: P2pSetLocalDhtAddrs failed, addrs: %v", yesCfg.LocalDhtAddrs)
+		return nil, nil
+	}
 
 	if chCfgName, eno := config.P2pSetConfig("chain", chainCfg); eno != config.P2pCfgEnoNone {
 		log.Debugf("YeShellConfigToP2pCfg: P2pSetConfig failed")
@@ -422,7 +464,8 @@ func NewYeShellManager(yesCfg *YeShellConfig) *YeShellManager {
 		subscribers:    new(sync.Map),
 		deDupMap:       make(map[yesKey]*deDupMapVal, 0),
 		ddtChan:        make(chan bool, 1),
-		gciMap:			make(map[getChainInfoKeyEx]*getChainInfoValEx, 0),
+		chainReqSeq:	uint64(time.Now().UnixNano()),
+		chainReqMap:	make(map[uint64]*chainReqVal, 0),
 	}
 
 	cfg, shellCfg := YeShellConfigToP2pCfg(yesCfg)
@@ -451,6 +494,10 @@ func NewYeShellManager(yesCfg *YeShellConfig) *YeShellManager {
 	yeShMgr.dhtSdlName = yeShMgr.dhtInst.SchGetP2pCfgName()
 	dht.SetBootstrapNodes(yesCfg.dhtBootstrapNodes, yeShMgr.dhtSdlName)
 
+	yeShMgr.reputation = reputation.NewReputation(nil)
+	reputation.Register(yeShMgr.chainSdlName, yeShMgr.reputation)
+	reputation.Register(yeShMgr.dhtSdlName, yeShMgr.reputation)
+
 	return &yeShMgr
 }
 
@@ -508,6 +555,12 @@ func (yeShMgr *YeShellManager) Start() error {
 		return nil
 	}
 
+	yeShMgr.ptPeMgr, ok = yeShMgr.chainInst.SchGetTaskObject(sch.PeerMgrName).(*peer.PeerManager)
+	if !ok || yeShMgr.ptPeMgr == nil {
+		log.Debugf("Start: failed, eno: %d, error: %s", eno, eno.Error())
+		return nil
+	}
+
 	log.Debugf("Start: go shell routines...")
 
 	yeShMgr.dhtEvChan = yeShMgr.ptDhtShMgr.GetEventChan()
@@ -540,6 +593,16 @@ func (yeShMgr *YeShellManager) Start() error {
 
 	yeShMgr.status = yesChainReady
 
+	// the validator set might be given before started
+	yeShMgr.valLock.Lock()
+	if req := yeShMgr.valReq; req != nil {
+		req.Result = nil
+		if err := yeShMgr.peerSetRequest(sch.EvPeValidatorSetReq, req, nil); err != nil {
+			log.Debugf("Start: install validators failed, error: %s", err.Error())
+		}
+	}
+	yeShMgr.valLock.Unlock()
+
 	log.Debugf("Start: shell ok")
 
 	return nil
@@ -673,6 +736,8 @@ func (yeShMgr *YeShellManager) BroadcastMessage(message Message) error {
 		err = yeShMgr.broadcastBh(&message)
 	case MessageTypeBlock:
 		err = yeShMgr.broadcastBk(&message)
+	case MessageTypeEvidence:
+		err = yeShMgr.broadcastEvi(&message)
 	default:
 		return errors.New(fmt.Sprintf("BroadcastMessage: invalid type: %v", message.MsgType))
 	}
@@ -693,6 +758,8 @@ func (yeShMgr *YeShellManager) BroadcastMessageOsn(message Message) error {
 		err = yeShMgr.broadcastBhOsn(&message, nil)
 	case MessageTypeBlock:
 		err = yeShMgr.broadcastBkOsn(&message, nil)
+	case MessageTypeEvidence:
+		err = yeShMgr.broadcastEviOsn(&message, nil)
 	default:
 		return errors.New(fmt.Sprintf("BroadcastMessageOsn: invalid type: %v", message.MsgType))
 	}
@@ -844,23 +911,27 @@ func (yeShMgr *YeShellManager) DhtGetValues(keys [][]byte, out chan<- []byte, ti
 }
 
 func (yeShMgr *YeShellManager) DhtSetValue(key []byte, value []byte) error {
+	return yeShMgr.DhtSetRecord(key, value, nil)
+}
+
+func (yeShMgr *YeShellManager) DhtSetRecord(key []byte, value []byte, extra []byte) error {
 	sdl := yeShMgr.dhtSdlName
 	if yeShMgr.inStopping {
-		log.Warnf("DhtSetValue: in stopping, sdl: %s", sdl)
+		log.Warnf("DhtSetRecord: in stopping, sdl: %s", sdl)
 		return YesEnoInStopping
 	}
 	if yeShMgr.ptDhtConMgr.IsBusy(){
-		log.Warnf("DhtSetValue: dht busy, sdl: %s", sdl)
+		log.Warnf("DhtSetRecord: dht busy, sdl: %s", sdl)
 		return YesEnoResource
 	}
 	if len(key) != yesKeyBytes || len(value) == 0 {
-		log.Debugf("DhtSetValue: invalid pair or value, sdl: %s, key: %x, value: %x", sdl, key, value)
+		log.Debugf("DhtSetRecord: invalid pair or value, sdl: %s, key: %x, value: %x", sdl, key, value)
 		return YesEnoParameter
 	}
 
 	ch := make(chan bool, 1)
 	if err := yeShMgr.dhtPutValMapKey(key, PVTO, ch); err != YesEnoNone {
-		log.Debugf("DhtSetValue: dhtPutValMapKey failed, sdl: %s, key: %x, err: %s",
+		log.Debugf("DhtSetRecord: dhtPutValMapKey failed, sdl: %s, key: %x, err: %s",
 			sdl, key, err.Error())
 		return err
 	}
@@ -868,12 +939,13 @@ func (yeShMgr *YeShellManager) DhtSetValue(key []byte, value []byte) error {
 	req := sch.MsgDhtMgrPutValueReq{
 		Key:      key,
 		Val:      value,
+		Extra:    extra,
 		KeepTime: time.Duration(0),
 	}
 	msg := sch.SchMessage{}
 	yeShMgr.dhtInst.SchMakeMessage(&msg, &sch.PseudoSchTsk, yeShMgr.ptnDhtShell, sch.EvDhtMgrPutValueReq, &req)
 	if eno := yeShMgr.dhtInst.SchSendMessage(&msg); eno != sch.SchEnoNone {
-		log.Errorf("DhtSetValue: scheduler failed, sdl: %s, key: %x, err: %s",
+		log.Errorf("DhtSetRecord: scheduler failed, sdl: %s, key: %x, err: %s",
 			sdl, key, eno.Error())
 		yk := yesKey{}
 		copy(yk[0:], key)
@@ -884,17 +956,17 @@ func (yeShMgr *YeShellManager) DhtSetValue(key []byte, value []byte) error {
 		return YesEnoScheduler
 	}
 
-	log.Tracef("DhtSetValue: pending, sdl: %s, key: %x", sdl, key)
+	log.Tracef("DhtSetRecord: pending, sdl: %s, key: %x", sdl, key)
 	result, ok := <-ch
 	if !ok {
-		log.Debugf("DhtSetValue: timeout, sdl: %s, key: %x", sdl, key)
+		log.Debugf("DhtSetRecord: timeout, sdl: %s, key: %x", sdl, key)
 		return YesEnoTimeout
 	}
 	if result == false {
-		log.Debugf("DhtSetValue: dht failed, sdl: %s, key: %x", sdl, key)
+		log.Debugf("DhtSetRecord: dht failed, sdl: %s, key: %x", sdl, key)
 		return YesEnoDhtInteral
 	}
-	log.Tracef("DhtSetValue: ok, sdl: %s, key: %x", sdl, key)
+	log.Tracef("DhtSetRecord: ok, sdl: %s, key: %x", sdl, key)
 	return nil
 }
 
@@ -902,80 +974,388 @@ func (yeShMgr *YeShellManager) RegChainProvider(cp ChainProvider) {
 	yeShMgr.cp = cp
 }
 
-func (yeShMgr *YeShellManager) GetChainInfo(kind string, key []byte) ([]byte, error) {
-	if key == nil || len(key) > GCIKEY_LEN || len(kind) == 0 {
-		log.Debugf("GetChainInfo: invalid invalid (kind,key) pair, sdl: %s, kind: %s, key: %x",
-			yeShMgr.chainSdlName, kind, key)
+func (yeShMgr *YeShellManager) GetStatus(ctx context.Context, nodeID string) (*ChainResponse, error) {
+	req := peer.ChainRequest{
+		Kind: peer.CK_STATUS,
+	}
+	return yeShMgr.chainRequest(ctx, nodeID, &req)
+}
+
+func (yeShMgr *YeShellManager) GetHeaders(ctx context.Context, nodeID string, from uint64, count int) (*ChainResponse, error) {
+	if count <= 0 {
 		return nil, YesEnoParameter
+	} else if count > ChainMaxHeaders {
+		return nil, ErrChainTooMany
+	}
+	req := peer.ChainRequest{
+		Kind: peer.CK_HEADERS,
+		From: from,
+		Count: uint32(count),
 	}
-	kex := getChainInfoKeyEx {
-		name: kind,
-		keyLen: len(key),
-	}
-	copy(kex.key[0:], key)
-
-	yeShMgr.gciLock.Lock()
-	if _, dup := yeShMgr.gciMap[kex]; dup {
-		log.Debugf("GetChainInfo: duplicated, sdl: %s, kind: %s, key: %x", yeShMgr.chainSdlName, kind, key)
-		yeShMgr.gciLock.Unlock()
-		return nil, YesEnoGcdDup
-	}
-	if len(yeShMgr.gciMap) > GCIBS {
-		log.Debugf("GetChainInfo: too much, sdl: %s, kind: %s, key: %x", yeShMgr.chainSdlName, kind, key)
-		yeShMgr.gciLock.Unlock()
-		return nil, YesEnoGcdFull
-	}
-	vex := getChainInfoValEx{
-		gcdChan: make(chan []byte, 1),
-		gcdTimer: time.NewTimer(GCITO),
-		gcdSeq: uint64(time.Now().UnixNano()),
-	}
-	yeShMgr.gciMap[kex] = &vex
-	yeShMgr.gciLock.Unlock()
-	defer vex.gcdTimer.Stop()
-
-	// do not use kex.key[0:] for req.Key, since it's an array than a slice,
-	// on which "0"s might have been padded after copy(...) called above.
-	req := sch.MsgShellGetChainInfoReq {
-		Seq: vex.gcdSeq,
-		Kind: kex.name,
+	return yeShMgr.chainRequest(ctx, nodeID, &req)
+}
+
+func (yeShMgr *YeShellManager) GetBlocks(ctx context.Context, nodeID string, hashes [][]byte) (*ChainResponse, error) {
+	if len(hashes) == 0 {
+		return nil, YesEnoParameter
+	} else if len(hashes) > ChainMaxBlocks {
+		return nil, ErrChainTooMany
+	}
+	req := peer.ChainRequest{
+		Kind: peer.CK_BLOCKS,
+		Hashes: hashes,
+	}
+	return yeShMgr.chainRequest(ctx, nodeID, &req)
+}
+
+func (yeShMgr *YeShellManager) GetTxs(ctx context.Context, nodeID string, hashes [][]byte) (*ChainResponse, error) {
+	if len(hashes) == 0 {
+		return nil, YesEnoParameter
+	} else if len(hashes) > ChainMaxTxs {
+		return nil, ErrChainTooMany
+	}
+	req := peer.ChainRequest{
+		Kind: peer.CK_TXS,
+		Hashes: hashes,
+	}
+	return yeShMgr.chainRequest(ctx, nodeID, &req)
+}
+
+func (yeShMgr *YeShellManager) GetProof(ctx context.Context, nodeID string, root, key []byte) (*ChainResponse, error) {
+	if len(root) == 0 || len(key) == 0 {
+		return nil, YesEnoParameter
+	}
+	req := peer.ChainRequest{
+		Kind: peer.CK_PROOF,
+		Root: root,
 		Key: key,
 	}
+	return yeShMgr.chainRequest(ctx, nodeID, &req)
+}
+
+func (yeShMgr *YeShellManager) chainRequest(ctx context.Context, nodeID string, req *peer.ChainRequest) (*ChainResponse, error) {
+	var target *config.NodeID
+	if len(nodeID) > 0 {
+		id, err := yesParseNodeId(nodeID)
+		if err != nil {
+			return nil, err
+		}
+		target = &id
+	}
+	// the peer is picked here rather than by the chain shell, so the request
+	// is bound to it and responses from any other peer are rejected.
+	if target = yeShMgr.chainPickPeer(target); target == nil {
+		log.Debugf("chainRequest: no peer, sdl: %s, peer: %s", yeShMgr.chainSdlName, nodeID)
+		return nil, ErrChainNoPeer
+	}
+
+	val := chainReqVal{
+		nodeId: target,
+		kind: req.Kind,
+		rspChan: make(chan *chainRspEx, 1),
+	}
+	yeShMgr.chainReqLock.Lock()
+	if len(yeShMgr.chainReqMap) >= CHRBS {
+		yeShMgr.chainReqLock.Unlock()
+		log.Debugf("chainRequest: too much, sdl: %s, kind: %d", yeShMgr.chainSdlName, req.Kind)
+		return nil, YesEnoChainFull
+	}
+	yeShMgr.chainReqSeq++
+	req.Version = ChainProtoVersion
+	req.Seq = yeShMgr.chainReqSeq
+	yeShMgr.chainReqMap[req.Seq] = &val
+	yeShMgr.chainReqLock.Unlock()
+
+	// the request is removed from the map when responded, or here when the
+	// context is done, responses come later are discarded then.
+	defer func() {
+		yeShMgr.chainReqLock.Lock()
+		delete(yeShMgr.chainReqMap, req.Seq)
+		yeShMgr.chainReqLock.Unlock()
+	}()
+
+	shReq := sch.MsgShellChainReq{
+		NodeId: target,
+		Req: req,
+	}
 	msg := sch.SchMessage{}
-	yeShMgr.chainInst.SchMakeMessage(&msg, &sch.PseudoSchTsk, yeShMgr.ptnChainShell, sch.EvShellGetChainInfoReq, &req)
+	yeShMgr.chainInst.SchMakeMessage(&msg, &sch.PseudoSchTsk, yeShMgr.ptnChainShell, sch.EvShellChainReq, &shReq)
 	if eno := yeShMgr.chainInst.SchSendMessage(&msg); eno != sch.SchEnoNone {
-		log.Debugf("GetChainInfo: SchSendMessage failed, sdl: %s, kind: %s, key: %x, eno: %d",
-			yeShMgr.chainSdlName, kind, key, eno)
+		log.Debugf("chainRequest: SchSendMessage failed, sdl: %s, %s, eno: %d",
+			yeShMgr.chainSdlName, req.String(), eno)
 		return nil, YesEnoScheduler
 	}
 
-	chainData := ([]byte)(nil)
-	gcdOk := false
 	select {
-	case <-vex.gcdTimer.C:
-		yeShMgr.gciLock.Lock()
-		if _, ok := yeShMgr.gciMap[kex]; ok {
-			delete(yeShMgr.gciMap, kex)
-			close(vex.gcdChan)
+	case <-ctx.Done():
+		log.Debugf("chainRequest: %s, sdl: %s, %s", ctx.Err().Error(), yeShMgr.chainSdlName, req.String())
+		return nil, ctx.Err()
+	case ex := <-val.rspChan:
+		from := fmt.Sprintf("%x", ex.from)
+		if len(ex.rsp.Error) > 0 {
+			log.Debugf("chainRequest: failed, sdl: %s, peer: %s, error: %s",
+				yeShMgr.chainSdlName, from, ex.rsp.Error)
+			return nil, fmt.Errorf("chain request failed by peer %s: %s", from, ex.rsp.Error)
 		}
-		yeShMgr.gciLock.Unlock()
-		log.Debugf("GetChainInfo: timeout, sdl: %s, kind: %s, key: %x", yeShMgr.chainSdlName, kind, key)
-		return nil, YesEnoTimeout
-	case chainData, gcdOk = <-vex.gcdChan:
-		log.Debugf("GetChainInfo: gcdChan got, sdl: %s, kind: %s, key: %x", yeShMgr.chainSdlName, kind, key)
+		rsp := ChainResponse{
+			From: from,
+			Items: ex.rsp.Items,
+		}
+		if ex.rsp.Kind == peer.CK_STATUS {
+			rsp.Status = &ChainStatus{
+				Genesis: ex.rsp.Genesis,
+				Head: ex.rsp.Head,
+				Height: ex.rsp.Height,
+			}
+		}
+		log.Tracef("chainRequest: ok, sdl: %s, peer: %s, %s", yeShMgr.chainSdlName, from, ex.rsp.String())
+		return &rsp, nil
 	}
+}
 
-	if !gcdOk{
-		log.Debugf("GetChainInfo: failed, sdl: %s, kind: %s, key: %x", yeShMgr.chainSdlName, kind, key)
-		return nil, YesEnoChClosed
+//
+// Pick the peer to send chain request to: the target if it's active, or one
+// of active peers at random if no target, nil if none.
+//
+func (yeShMgr *YeShellManager) chainPickPeer(target *config.NodeID) *config.NodeID {
+	if yeShMgr.ptChainShMgr == nil {
+		return nil
 	}
-	if  len(chainData) == 0 {
-		log.Debugf("GetChainInfo: empty, sdl: %s, kind: %s, key: %x", yeShMgr.chainSdlName, kind, key)
-		return nil, YesEnoEmptyVal
+	active := make([]config.NodeID, 0)
+	for _, ps := range *yeShMgr.ptChainShMgr.GetActivePeerSnapshot() {
+		if ps.Status != p2psh.PisActive || ps.HsInfo == nil {
+			continue
+		}
+		if target != nil && ps.HsInfo.NodeId == *target {
+			return target
+		}
+		active = append(active, ps.HsInfo.NodeId)
+	}
+	if target != nil || len(active) == 0 {
+		return nil
+	}
+	id := active[rand.Intn(len(active))]
+	return &id
+}
+
+func (yeShMgr *YeShellManager) ReportPeer(nodeID string, offence string, severity int) error {
+	id, err := yesParseNodeId(nodeID)
+	if err != nil {
+		return err
+	}
+	yeShMgr.reputation.Report(id, offence, severity)
+	return nil
+}
+
+func (yeShMgr *YeShellManager) ListBans() []PeerBan {
+	bans := yeShMgr.reputation.Bans()
+	list := make([]PeerBan, 0, len(bans))
+	for _, b := range bans {
+		list = append(list, PeerBan{
+			NodeID:  fmt.Sprintf("%x", b.ID),
+			Offence: b.Offence,
+			Until:   b.Until,
+		})
+	}
+	return list
+}
+
+func (yeShMgr *YeShellManager) ClearBans(nodeID string) (int, error) {
+	if len(nodeID) == 0 {
+		return yeShMgr.reputation.ClearBans(), nil
+	}
+	id, err := yesParseNodeId(nodeID)
+	if err != nil {
+		return 0, err
+	}
+	if !yeShMgr.reputation.Unban(id) {
+		return 0, ErrPeerNotBanned
+	}
+	return 1, nil
+}
+
+func (yeShMgr *YeShellManager) PeerBandwidth() []Bandwidth {
+	if yeShMgr.ptPeMgr == nil {
+		return nil
+	}
+	peers, _ := yeShMgr.ptPeMgr.Bandwidth()
+	bws := make([]Bandwidth, 0, len(peers))
+	for _, pb := range peers {
+		bws = append(bws, Bandwidth{
+			NodeID:  fmt.Sprintf("%x", pb.NodeId),
+			Subnet:  fmt.Sprintf("%x", pb.Snid),
+			Inbound: pb.Dir == peer.PeInstDirInbound,
+			Rx:      yesTrafficByType(pb.Rx),
+			Tx:      yesTrafficByType(pb.Tx),
+		})
+	}
+	return bws
+}
+
+func (yeShMgr *YeShellManager) SubnetBandwidth() []Bandwidth {
+	if yeShMgr.ptPeMgr == nil {
+		return nil
+	}
+	_, subnets := yeShMgr.ptPeMgr.Bandwidth()
+	bws := make([]Bandwidth, 0, len(subnets))
+	for _, sb := range subnets {
+		bws = append(bws, Bandwidth{
+			Subnet: fmt.Sprintf("%x", sb.Snid),
+			Rx:     yesTrafficByType(sb.Rx),
+			Tx:     yesTrafficByType(sb.Tx),
+		})
+	}
+	return bws
+}
+
+func (yeShMgr *YeShellManager) AddPeer(node string, trusted bool) error {
+	nodes := config.P2pSetupBootstrapNodes([]string{node})
+	if len(nodes) != 1 || nodes[0].IP == nil {
+		return ErrInvalidNodeUrl
+	}
+	req := sch.MsgPePeerSetReq{
+		Op:      sch.PSR_OP_ADD,
+		Node:    *nodes[0],
+		Trusted: trusted,
+		Result:  make(chan int, 1),
+	}
+	return yeShMgr.peerSetRequest(sch.EvPePeerSetReq, &req, req.Result)
+}
+
+func (yeShMgr *YeShellManager) RemovePeer(nodeID string) error {
+	id, err := yesParseNodeId(nodeID)
+	if err != nil {
+		return err
+	}
+	req := sch.MsgPePeerSetReq{
+		Op:     sch.PSR_OP_REMOVE,
+		Node:   config.Node{ID: id},
+		Result: make(chan int, 1),
+	}
+	return yeShMgr.peerSetRequest(sch.EvPePeerSetReq, &req, req.Result)
+}
+
+func (yeShMgr *YeShellManager) ListPeers() ([]PeerInfo, error) {
+	req := sch.MsgPePeerListReq{
+		Result: make(chan []sch.PePeerEntry, 1),
+	}
+	if err := yeShMgr.peerSetRequest(sch.EvPePeerListReq, &req, nil); err != nil {
+		return nil, err
+	}
+	select {
+	case list := <-req.Result:
+		peers := make([]PeerInfo, 0, len(list))
+		for _, pe := range list {
+			pi := PeerInfo{
+				NodeID:    fmt.Sprintf("%x", pe.Node.ID),
+				Node:      fmt.Sprintf("%x@%s:%d:%d", pe.Node.ID, pe.Node.IP.String(), pe.Node.UDP, pe.Node.TCP),
+				Connected: pe.Connected,
+				Reserved:  pe.Reserved,
+				Trusted:   pe.Trusted,
+			}
+			if pe.Connected {
+				pi.Subnet = fmt.Sprintf("%x", pe.Snid)
+				pi.Inbound = pe.Dir == peer.PeInstDirInbound
+			}
+			peers = append(peers, pi)
+		}
+		return peers, nil
+	case <-time.After(yesPeerSetTimeout):
+		return nil, ErrPeerSetTimeout
+	}
+}
+
+//
+// Install the validator set, peers are allowed to claim validators in it only,
+// and tetris events are exchanged with them only. self is the local validator
+// address, and sign signs with its key, for proving in handshakes. the set is
+// kept and installed when started if the shell is not yet.
+//
+func (yeShMgr *YeShellManager) SetValidators(validators []string, self string, sign func(hash []byte) ([]byte, error)) error {
+	req := sch.MsgPeValidatorSetReq{
+		Validators: append(make([]string, 0, len(validators)), validators...),
+		Self:       self,
+		Sign:       sign,
+		Result:     make(chan int, 1),
+	}
+	yeShMgr.valLock.Lock()
+	defer yeShMgr.valLock.Unlock()
+	yeShMgr.valReq = &req
+	if yeShMgr.status != yesChainReady {
+		return nil
+	}
+	return yeShMgr.peerSetRequest(sch.EvPeValidatorSetReq, &req, req.Result)
+}
+
+//
+// Send request to the peer manager task, and wait the result code if a result
+// channel is given
+//
+func (yeShMgr *YeShellManager) peerSetRequest(ev int, req interface{}, result chan int) error {
+	if yeShMgr.inStopping {
+		return YesEnoInStopping
+	}
+	eno, ptnPeMgr := yeShMgr.chainInst.SchGetUserTaskNode(sch.PeerMgrName)
+	if eno != sch.SchEnoNone || ptnPeMgr == nil {
+		return ErrPeerSetFailed
+	}
+	msg := sch.SchMessage{}
+	yeShMgr.chainInst.SchMakeMessage(&msg, &sch.PseudoSchTsk, ptnPeMgr, ev, req)
+	if eno := yeShMgr.chainInst.SchSendMessage(&msg); eno != sch.SchEnoNone {
+		log.Debugf("peerSetRequest: SchSendMessage failed, eno: %d", eno)
+		return ErrPeerSetFailed
+	}
+	if result == nil {
+		return nil
+	}
+	select {
+	case pe := <-result:
+		switch peer.PeMgrErrno(pe) {
+		case peer.PeMgrEnoNone:
+			return nil
+		case peer.PeMgrEnoNotfound:
+			return ErrPeerNotFound
+		case peer.PeMgrEnoParameter:
+			return ErrInvalidNodeUrl
+		default:
+			return ErrPeerSetFailed
+		}
+	case <-time.After(yesPeerSetTimeout):
+		return ErrPeerSetTimeout
+	}
+}
+
+var yesMidName = map[uint32]string{
+	uint32(peer.MID_PING): "ping",
+	uint32(peer.MID_PONG): "pong",
+	uint32(peer.MID_CHKK): "chkk",
+	uint32(peer.MID_RPTK): "rptk",
+	uint32(peer.MID_CHREQ): "chreq",
+	uint32(peer.MID_CHRSP): "chrsp",
+}
+
+func yesTrafficByType(tm map[uint32]ratelimit.Traffic) map[string]Traffic {
+	bt := make(map[string]Traffic, len(tm))
+	for mid, t := range tm {
+		name, ok := yesMidItoa[int(mid)]
+		if !ok {
+			if name, ok = yesMidName[mid]; !ok {
+				name = fmt.Sprintf("mid%d", mid)
+			}
+		}
+		bt[name] = Traffic{Msgs: t.Msgs, Bytes: t.Bytes, Dropped: t.Dropped}
 	}
-	log.Tracef("GetChainInfo: ok, sdl: %s, kind: %s, key: %x, data: %x",
-		yeShMgr.chainSdlName, kind, key, chainData)
-	return chainData, nil
+	return bt
+}
+
+func yesParseNodeId(nodeID string) (config.NodeID, error) {
+	id := config.NodeID{}
+	b, err := hex.DecodeString(strings.TrimPrefix(nodeID, "0x"))
+	if err != nil || len(b) != config.NodeIDBytes {
+		return id, ErrInvalidNodeID
+	}
+	copy(id[0:], b)
+	return id, nil
 }
 
 func (yeShMgr *YeShellManager) DhtFindNode(target *config.NodeID, done chan interface{}) error {
@@ -1254,23 +1634,24 @@ func (yeShMgr *YeShellManager) chainReady4User() {
 
 func (yeShMgr *YeShellManager) chainRxProc() {
 	rxCount := 0
-	gcdCount := 0
-	pcdCount := 0
+	chreqCount := 0
+	chrspCount := 0
 	dupCount := 0
 	txCount := 0
 	evCount := 0
 	bhCount := 0
 	bkCount := 0
+	eviCount := 0
 	xxCount := 0
 	subCount := make(map[string]int64, 0)
 	showStat := func() {
 		log.Infof("chainRxProc: stat, " +
 			"sdl: %s, " +
-			"rxCount:%d, gcdCount:%d, pcdCount:%d, dupCount:%d, " +
-			"txCount:%d, evCount:%d, bhCount:%d, bkCount:%d, xxCount:%d, subCount:%+v",
+			"rxCount:%d, chreqCount:%d, chrspCount:%d, dupCount:%d, " +
+			"txCount:%d, evCount:%d, bhCount:%d, bkCount:%d, eviCount:%d, xxCount:%d, subCount:%+v",
 			yeShMgr.chainSdlName,
-			rxCount, gcdCount, pcdCount, dupCount,
-			txCount, evCount, bhCount, bkCount, xxCount, subCount)
+			rxCount, chreqCount, chrspCount, dupCount,
+			txCount, evCount, bhCount, bkCount, eviCount, xxCount, subCount)
 	}
 
 _rxLoop:
@@ -1302,15 +1683,15 @@ _rxLoop:
 				continue
 			}
 
-			if pkg.MsgId == int(p2psh.MID_GCD) {
+			if pkg.MsgId == int(p2psh.MID_CHREQ) {
 
-				gcdCount++
-				yeShMgr.getChainDataFromPeer(pkg)
+				chreqCount++
+				yeShMgr.chainRequestFromPeer(pkg)
 
-			} else if pkg.MsgId == int(p2psh.MID_PCD) {
+			} else if pkg.MsgId == int(p2psh.MID_CHRSP) {
 
-				pcdCount++
-				yeShMgr.putChainDataFromPeer(pkg)
+				chrspCount++
+				yeShMgr.chainResponseFromPeer(pkg)
 
 			} else {
 
@@ -1319,7 +1700,7 @@ _rxLoop:
 				if dup, old := yeShMgr.checkDupKey(k); dup {
 					log.Tracef("chainRxProc: duplicated, " +
 						"sdl: %s, delta: %f, key: %x, data: %x, old: %x",
-						yeShMgr.chainSdlName, time.Now().Sub(old.stamp).Seconds(), k, pkg.Payload, old)
+						yeShMgr.chainSdlName, time.Now().Sub(old.stamp).Seconds(), k, pkg.Payload, old.value)
 					dupCount++
 					continue
 				}
@@ -1334,6 +1715,8 @@ _rxLoop:
 					bhCount++
 				case MessageTypeBlock:
 					bkCount++
+				case MessageTypeEvidence:
+					eviCount++
 				default:
 					xxCount++
 				}
@@ -1369,6 +1752,8 @@ _rxLoop:
 					forwardError = yeShMgr.broadcastBhOsn(&msg, &exclude)
 				case MessageTypeBlock:
 					forwardError = yeShMgr.broadcastBkOsn(&msg, &exclude)
+				case MessageTypeEvidence:
+					forwardError = yeShMgr.broadcastEviOsn(&msg, &exclude)
 				default:
 					log.Debugf("chainRxProc: invalid message type, "+
 						"sdl: %s, msg: %s, key: %x",
@@ -1466,7 +1851,7 @@ _pvpLoop:
 			}
 			key := result.key
 			if len(key) != yesKeyBytes {
-				log.Warnf("dhtPutValProc: invalid key, sdl: %s")
+				log.Warnf("dhtPutValProc: invalid key, sdl: %s", yeShMgr.dhtSdlName)
 			} else {
 				log.Tracef("dhtPutValProc: got from channel, sdl: %s, eno: %d, key: %x",
 					sdl, result.eno, result.key)
@@ -1724,6 +2109,10 @@ func (yeShMgr *YeShellManager) broadcastBk(msg *Message) error {
 	return yeShMgr.broadcastBkOsn(msg, nil)
 }
 
+func (yeShMgr *YeShellManager) broadcastEvi(msg *Message) error {
+	return yeShMgr.broadcastEviOsn(msg, nil)
+}
+
 func (yeShMgr *YeShellManager) broadcastTxOsn(msg *Message, exclude *config.NodeID) error {
 	// broadcast the tx to peers connected currently;
 	// need not to throw it into dht;
@@ -1739,7 +2128,7 @@ func (yeShMgr *YeShellManager) broadcastTxOsn(msg *Message, exclude *config.Node
 	if dup, old := yeShMgr.checkDupKey(k); dup {
 		remain := old.stamp.Add(yeShMgr.config.DedupTime).Sub(time.Now()).Seconds()
 		log.Infof("broadcastTxOsn: duplicated, sdl: %s, remain: %f, key: %x, data: %x, old: %x",
-			yeShMgr.chainSdlName, remain, k, msg.Data, old)
+			yeShMgr.chainSdlName, remain, k, msg.Data, old.value)
 		return errors.New("broadcastTxOsn: duplicated")
 	}
 
@@ -1782,7 +2171,7 @@ func (yeShMgr *YeShellManager) broadcastEvOsn(msg *Message, exclude *config.Node
 	if dup, old := yeShMgr.checkDupKey(k); dup {
 		remain := old.stamp.Add(yeShMgr.config.DedupTime).Sub(time.Now()).Seconds()
 		log.Infof("broadcastEvOsn: duplicated, sdl: %s, remain: %f, key: %x, data: %x, old: %x",
-			yeShMgr.chainSdlName, remain, k, msg.Data, old)
+			yeShMgr.chainSdlName, remain, k, msg.Data, old.value)
 		return errors.New("broadcastEvOsn: duplicated")
 	}
 
@@ -1840,7 +2229,7 @@ func (yeShMgr *YeShellManager) broadcastBhOsn(msg *Message, exclude *config.Node
 	if dup, old := yeShMgr.checkDupKey(k); dup {
 		remain := old.stamp.Add(yeShMgr.config.DedupTime).Sub(time.Now()).Seconds()
 		log.Infof("broadcastBhOsn: duplicated, sdl: %s, remain: %f, key: %x, data: %x, old: %x",
-			yeShMgr.chainSdlName, remain, k, msg.Data, old)
+			yeShMgr.chainSdlName, remain, k, msg.Data, old.value)
 		return errors.New("broadcastBhOsn: duplicated")
 	}
 
@@ -1867,6 +2256,49 @@ func (yeShMgr *YeShellManager) broadcastBhOsn(msg *Message, exclude *config.Node
 	return nil
 }
 
+func (yeShMgr *YeShellManager) broadcastEviOsn(msg *Message, exclude *config.NodeID) error {
+	// the evidence should be broadcast over the any-subnet, every node
+	// checks it when it's put on chain, not only the validators;
+	// need not to throw it into dht, core would do that for consensus;
+	k := yesKey{}
+	if len(msg.Key) == 0 {
+		h := new(common.Hash).SetBytes(sha3.Sha3256(msg.Data))
+		k  = (yesKey)(*h)
+		msg.Key = append(msg.Key, k[0:]...)
+	} else {
+		copy(k[0:], msg.Key)
+	}
+
+	if dup, old := yeShMgr.checkDupKey(k); dup {
+		remain := old.stamp.Add(yeShMgr.config.DedupTime).Sub(time.Now()).Seconds()
+		log.Infof("broadcastEviOsn: duplicated, sdl: %s, remain: %f, key: %x, old: %x",
+			yeShMgr.chainSdlName, remain, k, old.value)
+		return errors.New("broadcastEviOsn: duplicated")
+	}
+
+	if err := yeShMgr.setDedupTimer(k, msg.Data); err != nil {
+		log.Debugf("broadcastEviOsn: sdl: %s, error: %s",
+			yeShMgr.chainSdlName, err.Error())
+		return err
+	}
+
+	schMsg := sch.SchMessage{}
+	req := sch.MsgShellBroadcastReq{
+		MsgType: yesMtAtoi[msg.MsgType],
+		From:    msg.From,
+		Key:     msg.Key,
+		Data:    msg.Data,
+		Exclude: exclude,
+	}
+	yeShMgr.chainInst.SchMakeMessage(&schMsg, &sch.PseudoSchTsk, yeShMgr.ptnChainShell, sch.EvShellBroadcastReq, &req)
+	if eno := yeShMgr.chainInst.SchSendMessage(&schMsg); eno != sch.SchEnoNone {
+		log.Debugf("broadcastEviOsn: SchSendMessage failed, sdl: %s, eno: %d",
+			yeShMgr.chainSdlName, eno)
+		return eno
+	}
+	return nil
+}
+
 func (yeShMgr *YeShellManager) broadcastBkOsn(msg *Message, exclude *config.NodeID) error {
 	// the old design requires that:
 	// 		the Bk should be stored by DHT and no broadcasting over any subnet.
@@ -1887,7 +2319,7 @@ func (yeShMgr *YeShellManager) broadcastBkOsn(msg *Message, exclude *config.Node
 	if dup, old := yeShMgr.checkDupKey(k); dup {
 		remain := old.stamp.Add(yeShMgr.config.DedupTime).Sub(time.Now()).Seconds()
 		log.Infof("broadcastBkOsn: duplicated, sdl: %s, remain: %f, key: %x, data: %x, old: %x",
-			yeShMgr.chainSdlName, remain, k, msg.Data, old)
+			yeShMgr.chainSdlName, remain, k, msg.Data, old.value)
 		return errors.New("broadcastBkOsn: duplicated")
 	}
 
@@ -2036,103 +2468,135 @@ func (yeShMgr *YeShellManager) checkDupKey(k yesKey) (bool, *deDupMapVal) {
 	return dup, val
 }
 
-func (yeShMgr *YeShellManager) getChainDataFromPeer(rxPkg *peer.P2pPackageRx) sch.SchErrno {
+func yesRxPackage(rxPkg *peer.P2pPackageRx) *peer.P2pPackage {
 	upkg := new(peer.P2pPackage)
 	upkg.Pid = uint32(rxPkg.ProtoId)
 	upkg.Mid = uint32(rxPkg.MsgId)
 	upkg.Key = rxPkg.Key
 	upkg.PayloadLength = uint32(rxPkg.PayloadLength)
 	upkg.Payload = rxPkg.Payload
+	return upkg
+}
 
-	msg := peer.ExtMessage{}
-	if eno := upkg.GetExtMessage(&msg); eno != peer.PeMgrEnoNone {
-		log.Debugf("getChainDataFromPeer: GetExtMessage failed, " +
-			"sdl: %s, eno: %d",
+func (yeShMgr *YeShellManager) chainRequestFromPeer(rxPkg *peer.P2pPackageRx) sch.SchErrno {
+	req := peer.ChainRequest{}
+	if eno := yesRxPackage(rxPkg).GetChainRequest(&req); eno != peer.PeMgrEnoNone {
+		log.Debugf("chainRequestFromPeer: GetChainRequest failed, sdl: %s, eno: %d",
 			yeShMgr.chainSdlName, eno)
+		yeShMgr.reputation.Report(rxPkg.PeerInfo.NodeId, reputation.OffenceUndecodable, reputation.SeverityMinor)
 		return sch.SchEnoUserTask
 	}
 
-	if yeShMgr.cp != nil {
-		data := yeShMgr.cp.GetChainData(msg.Gcd.Name, msg.Gcd.Key)
-
-		log.Debugf("getChainDataFromPeer: get from cp, " +
-			"sdl: %s, kind: %s, key: %x, data: %x",
-			yeShMgr.chainSdlName, msg.Gcd.Name, msg.Gcd.Key, data)
-
-		if len(data) > 0 {
-			rsp := sch.MsgShellGetChainInfoRsp {
-				Peer: rxPkg.PeerInfo,
-				Seq: msg.Gcd.Seq,
-				Kind: msg.Gcd.Name,
-				Key: msg.Gcd.Key,
-				Data: data,
+	rsp := peer.ChainResponse{
+		Version: ChainProtoVersion,
+		Seq: req.Seq,
+		Kind: req.Kind,
+	}
+	if req.Version != ChainProtoVersion {
+		rsp.Error = ErrChainVersion.Error()
+	} else if yeShMgr.cp == nil {
+		rsp.Error = "no chain provider"
+	} else {
+		switch req.Kind {
+		case peer.CK_STATUS:
+			if st := yeShMgr.cp.ChainStatus(); st != nil {
+				rsp.Genesis = st.Genesis
+				rsp.Head = st.Head
+				rsp.Height = st.Height
 			}
-			schMsg := sch.SchMessage{}
-			yeShMgr.chainInst.SchMakeMessage(&schMsg, &sch.PseudoSchTsk, yeShMgr.ptnChainShell,
-				sch.EvShellGetChainInfoRsp, &rsp)
-			if yeShMgr.chainInst.SchSendMessage(&schMsg) != sch.SchEnoNone {
-				log.Errorf("getChainDataFromPeer: get from cp, " +
-					"sdl: %s, kind: %s, key: %x, data: %x",
-					yeShMgr.chainSdlName, msg.Gcd.Name, msg.Gcd.Key, data)
+		case peer.CK_HEADERS:
+			if req.Count > ChainMaxHeaders {
+				rsp.Error = ErrChainTooMany.Error()
+			} else {
+				rsp.Items = yesChainItems(yeShMgr.cp.ChainHeaders(req.From, int(req.Count)))
+			}
+		case peer.CK_BLOCKS:
+			if len(req.Hashes) > ChainMaxBlocks {
+				rsp.Error = ErrChainTooMany.Error()
+			} else {
+				rsp.Items = yesChainItems(yeShMgr.cp.ChainBlocks(req.Hashes))
 			}
-			return sch.SchEnoNone
+		case peer.CK_TXS:
+			if len(req.Hashes) > ChainMaxTxs {
+				rsp.Error = ErrChainTooMany.Error()
+			} else {
+				rsp.Items = yesChainItems(yeShMgr.cp.ChainTxs(req.Hashes))
+			}
+		case peer.CK_PROOF:
+			// nodes of a proof are all needed, never cut
+			rsp.Items = yeShMgr.cp.ChainProof(req.Root, req.Key)
+		default:
+			rsp.Error = fmt.Sprintf("unknown chain request kind %d", req.Kind)
 		}
 	}
-	return sch.SchEnoResource
-}
 
-func (yeShMgr *YeShellManager) putChainDataFromPeer(rxPkg *peer.P2pPackageRx) sch.SchErrno {
-	yeShMgr.gciLock.Lock()
-	defer yeShMgr.gciLock.Unlock()
+	log.Tracef("chainRequestFromPeer: sdl: %s, peer: %x, %s, %s",
+		yeShMgr.chainSdlName, rxPkg.PeerInfo.NodeId, req.String(), rsp.String())
 
-	upkg := new(peer.P2pPackage)
-	upkg.Pid = uint32(rxPkg.ProtoId)
-	upkg.Mid = uint32(rxPkg.MsgId)
-	upkg.Key = rxPkg.Key
-	upkg.PayloadLength = uint32(rxPkg.PayloadLength)
-	upkg.Payload = rxPkg.Payload
+	shRsp := sch.MsgShellChainRsp{
+		Peer: rxPkg.PeerInfo,
+		Rsp: &rsp,
+	}
+	schMsg := sch.SchMessage{}
+	yeShMgr.chainInst.SchMakeMessage(&schMsg, &sch.PseudoSchTsk, yeShMgr.ptnChainShell,
+		sch.EvShellChainRsp, &shRsp)
+	if eno := yeShMgr.chainInst.SchSendMessage(&schMsg); eno != sch.SchEnoNone {
+		log.Errorf("chainRequestFromPeer: SchSendMessage failed, sdl: %s, eno: %d",
+			yeShMgr.chainSdlName, eno)
+		return eno
+	}
+	return sch.SchEnoNone
+}
+
+// yesChainItems cuts items to fit in one response, at least one is kept
+func yesChainItems(items [][]byte) [][]byte {
+	size := 0
+	for idx, item := range items {
+		if size += len(item); size > ChainMaxBytes && idx > 0 {
+			return items[:idx]
+		}
+	}
+	return items
+}
 
-	msg := peer.ExtMessage{}
-	if eno := upkg.GetExtMessage(&msg); eno != peer.PeMgrEnoNone {
-		log.Debugf("putChainDataFromPeer: GetExtMessage failed, " +
-			"sdl: %s, eno: %d",
+func (yeShMgr *YeShellManager) chainResponseFromPeer(rxPkg *peer.P2pPackageRx) sch.SchErrno {
+	rsp := peer.ChainResponse{}
+	if eno := yesRxPackage(rxPkg).GetChainResponse(&rsp); eno != peer.PeMgrEnoNone {
+		log.Debugf("chainResponseFromPeer: GetChainResponse failed, sdl: %s, eno: %d",
 			yeShMgr.chainSdlName, eno)
+		yeShMgr.reputation.Report(rxPkg.PeerInfo.NodeId, reputation.OffenceUndecodable, reputation.SeverityMinor)
 		return sch.SchEnoUserTask
 	}
 
-	kex := getChainInfoKeyEx{
-		name: msg.Pcd.Name,
-		keyLen: len(msg.Pcd.Key),
-	}
-	copy(kex.key[0:], msg.Pcd.Key)
+	yeShMgr.chainReqLock.Lock()
+	defer yeShMgr.chainReqLock.Unlock()
 
-	vex, ok := yeShMgr.gciMap[kex]
+	// responses to requests timed out or cancelled are not found, they are
+	// discarded silently.
+	val, ok := yeShMgr.chainReqMap[rsp.Seq]
 	if !ok {
-		log.Debugf("putChainDataFromPeer: not found, " +
-			"sdl: %s, name: %s, key: %x",
-			yeShMgr.chainSdlName, kex.name, kex.key )
+		log.Debugf("chainResponseFromPeer: not found, sdl: %s, peer: %x, seq: %d",
+			yeShMgr.chainSdlName, rxPkg.PeerInfo.NodeId, rsp.Seq)
 		return sch.SchEnoNotFound
 	}
-	if vex.gcdSeq != msg.Pcd.Seq {
-		log.Debugf("putChainDataFromPeer: sequence mismatch, " +
-			"sdl: %s, seq:[%d,%d], name: %s, key: %x",
-			yeShMgr.chainSdlName, vex.gcdSeq, msg.Pcd.Seq, kex.name, kex.key )
+	if val.nodeId == nil || *val.nodeId != rxPkg.PeerInfo.NodeId {
+		log.Debugf("chainResponseFromPeer: peer mismatched, sdl: %s, peer: %x, seq: %d",
+			yeShMgr.chainSdlName, rxPkg.PeerInfo.NodeId, rsp.Seq)
 		return sch.SchEnoMismatched
 	}
-
-	// notice: when all matched, we should delete the key from the map at once
-	// to discard the possible responses with the same key value, or in the worst
-	// case, deadlock can happen. see function GetChainInfo also please.
-	delete(yeShMgr.gciMap, kex)
-	if vex.gcdChan != nil {
-		vex.gcdChan <- msg.Pcd.Data
-		return sch.SchEnoNone
+	if val.kind != rsp.Kind {
+		log.Debugf("chainResponseFromPeer: kind mismatched, sdl: %s, peer: %x, kind: [%d,%d]",
+			yeShMgr.chainSdlName, rxPkg.PeerInfo.NodeId, val.kind, rsp.Kind)
+		return sch.SchEnoMismatched
 	}
 
-	log.Debugf("putChainDataFromPeer: discarded, " +
-		"sdl: %s, seq: %d, name: %s, key: %x",
-		yeShMgr.chainSdlName, msg.Pcd.Seq, msg.Pcd.Name, msg.Pcd.Key)
-
+	// notice: delete the request at once so a duplicated response would not
+	// be pushed into the channel, which is buffered for only one.
+	delete(yeShMgr.chainReqMap, rsp.Seq)
+	val.rspChan <- &chainRspEx{
+		from: rxPkg.PeerInfo.NodeId,
+		rsp: &rsp,
+	}
 	return sch.SchEnoNone
 }
 
@@ -2182,10 +2646,11 @@ func SetupSubNetwork(cfg *config.Config, mbs int, vdt bool) error {
 			cfg.SubNetKeyList[snid] = *prvKey
 			cfg.SubNetIdList[Snid2Int(snid)] = snid
 			cfg.SubNetNodeList[snid] = config.Node{
-				IP:  cfg.Local.IP,
-				UDP: cfg.Local.UDP,
-				TCP: cfg.Local.TCP,
-				ID:  *id,
+				IP:    cfg.Local.IP,
+				UDP:   cfg.Local.UDP,
+				TCP:   cfg.Local.TCP,
+				ID:    *id,
+				Addrs: cfg.Local.Addrs,
 			}
 			cfg.SubNetMaxPeers[snid] = config.MaxPeers
 			cfg.SubNetMaxOutbounds[snid] = config.MaxOutbounds
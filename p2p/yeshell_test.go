@@ -0,0 +1,103 @@
+/*
+ *  Copyright (C) 2017 gyee authors
+ *
+ *  This file is part of the gyee library.
+ *
+ *  The gyee library is free software: you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License as published by
+ *  the Free Software Foundation, either version 3 of the License, or
+ *  (at your option) any later version.
+ *
+ *  The gyee library is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ *
+ *  You should have received a copy of the GNU General Public License
+ *  along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	config "github.com/yeeco/gyee/p2p/config"
+	"github.com/yeeco/gyee/p2p/peer"
+	pb "github.com/yeeco/gyee/p2p/peer/pb"
+	sch "github.com/yeeco/gyee/p2p/scheduler"
+)
+
+func testChainRsp(t *testing.T, from config.NodeID, seq uint64, kind int32) *peer.P2pPackageRx {
+	payload, err := proto.Marshal(&pb.ChainResponse{
+		Seq:    seq,
+		Kind:   pb.ChainKind(kind),
+		Height: 1,
+	})
+	if err != nil {
+		t.Fatalf("Marshal() %v", err)
+	}
+	return &peer.P2pPackageRx{
+		PeerInfo:      &peer.PeerInfo{NodeId: from},
+		PayloadLength: len(payload),
+		Payload:       payload,
+	}
+}
+
+func TestChainResponseFromPeer(t *testing.T) {
+	asked, other := config.NodeID{1}, config.NodeID{2}
+	val := &chainReqVal{
+		nodeId:  &asked,
+		kind:    peer.CK_STATUS,
+		rspChan: make(chan *chainRspEx, 1),
+	}
+	yeShMgr := &YeShellManager{
+		chainReqMap: map[uint64]*chainReqVal{7: val},
+	}
+
+	if eno := yeShMgr.chainResponseFromPeer(testChainRsp(t, asked, 8, peer.CK_STATUS)); eno != sch.SchEnoNotFound {
+		t.Errorf("unknown seq: eno %d, want %d", eno, sch.SchEnoNotFound)
+	}
+	if eno := yeShMgr.chainResponseFromPeer(testChainRsp(t, other, 7, peer.CK_STATUS)); eno != sch.SchEnoMismatched {
+		t.Errorf("other peer: eno %d, want %d", eno, sch.SchEnoMismatched)
+	}
+	if eno := yeShMgr.chainResponseFromPeer(testChainRsp(t, asked, 7, peer.CK_HEADERS)); eno != sch.SchEnoMismatched {
+		t.Errorf("other kind: eno %d, want %d", eno, sch.SchEnoMismatched)
+	}
+	if len(val.rspChan) != 0 || yeShMgr.chainReqMap[7] == nil {
+		t.Fatal("request answered by mismatched response")
+	}
+
+	if eno := yeShMgr.chainResponseFromPeer(testChainRsp(t, asked, 7, peer.CK_STATUS)); eno != sch.SchEnoNone {
+		t.Fatalf("asked peer: eno %d, want %d", eno, sch.SchEnoNone)
+	}
+	ex := <-val.rspChan
+	if ex.from != asked || ex.rsp.Height != 1 {
+		t.Errorf("response from %x height %d", ex.from, ex.rsp.Height)
+	}
+	if _, ok := yeShMgr.chainReqMap[7]; ok {
+		t.Error("request not removed when responded")
+	}
+	// duplicated response is discarded
+	if eno := yeShMgr.chainResponseFromPeer(testChainRsp(t, asked, 7, peer.CK_STATUS)); eno != sch.SchEnoNotFound {
+		t.Errorf("duplicated: eno %d, want %d", eno, sch.SchEnoNotFound)
+	}
+}
+
+func TestChainRequestNoPeer(t *testing.T) {
+	yeShMgr := &YeShellManager{
+		chainReqMap: make(map[uint64]*chainReqVal),
+	}
+	if id := yeShMgr.chainPickPeer(nil); id != nil {
+		t.Errorf("picked %x without chain shell", *id)
+	}
+	if _, err := yeShMgr.GetStatus(context.Background(), ""); err != ErrChainNoPeer {
+		t.Errorf("GetStatus() %v, want %v", err, ErrChainNoPeer)
+	}
+	if len(yeShMgr.chainReqMap) != 0 {
+		t.Error("request left without peer")
+	}
+}
@@ -22,12 +22,15 @@ package rpc
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"math/big"
 	"time"
 
 	"github.com/yeeco/gyee/accounts"
+	"github.com/yeeco/gyee/common"
 	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/consensus/tetris2"
 	"github.com/yeeco/gyee/core"
 	"github.com/yeeco/gyee/rpc/pb"
 )
@@ -68,7 +71,24 @@ func (s *AdminService) UnlockAccount(ctx context.Context, req *rpcpb.UnlockAccou
 	if err != nil {
 		return nil, err
 	}
-	err = s.am.Unlock(addr, []byte(req.Passphrase), time.Duration(req.Duration))
+	scope := accounts.UnlockScope{
+		MaxTxs: req.MaxTxs,
+	}
+	if len(req.MaxAmount) > 0 {
+		maxAmount, ok := new(big.Int).SetString(req.MaxAmount, 10)
+		if !ok {
+			return nil, errors.New("failed to parse max amount")
+		}
+		scope.MaxAmount = maxAmount
+	}
+	for _, r := range req.Recipients {
+		rAddr, err := address.AddressParse(r)
+		if err != nil {
+			return nil, err
+		}
+		scope.Recipients = append(scope.Recipients, *rAddr.CommonAddress())
+	}
+	err = s.am.UnlockWithScope(addr, []byte(req.Passphrase), time.Duration(req.Duration), scope)
 	return &rpcpb.UnlockAccountResponse{Result: err == nil}, err
 }
 
@@ -82,6 +102,10 @@ func (s *AdminService) LockAccount(ctx context.Context, req *rpcpb.LockAccountRe
 }
 
 func (s *AdminService) SendTransaction(ctx context.Context, req *rpcpb.SendTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+	fromAddr, err := address.AddressParse(req.From)
+	if err != nil {
+		return nil, err
+	}
 	toAddr, err := address.AddressParse(req.To)
 	if err != nil {
 		return nil, err
@@ -90,24 +114,220 @@ func (s *AdminService) SendTransaction(ctx context.Context, req *rpcpb.SendTrans
 	if !ok {
 		return nil, errors.New("failed to parse amount")
 	}
+	if amount.Sign() < 0 {
+		return nil, accounts.ErrInvalidAmount
+	}
 	chainID := s.core.Chain().ChainID()
 	to := toAddr.CommonAddress()
-	key, err := s.am.GetUnlocked(req.From)
+	tx := core.NewTransaction(uint32(chainID), req.Nonce, to, amount)
+	err = s.am.SignTx(fromAddr, to, amount, func(key []byte) (*common.Hash, error) {
+		signer := s.core.GetSigner()
+		if err := signer.InitSigner(key); err != nil {
+			return nil, err
+		}
+		if err := tx.Sign(signer); err != nil {
+			return nil, err
+		}
+		return tx.Hash(), nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	signer := s.core.GetSigner()
-	if err := signer.InitSigner(key); err != nil {
+	if err := s.core.TxBroadcast(tx); err != nil {
 		return nil, err
 	}
-	tx := core.NewTransaction(uint32(chainID), req.Nonce, to, amount)
-	if err := tx.Sign(signer); err != nil {
+	return &rpcpb.SendTransactionResponse{
+		Hash: tx.Hash().Hex(),
+	}, nil
+}
+
+func (s *AdminService) SignMessage(ctx context.Context, req *rpcpb.SignMessageRequest) (*rpcpb.SignMessageResponse, error) {
+	addr, err := address.AddressParse(req.Address)
+	if err != nil {
 		return nil, err
 	}
-	if err := s.core.TxBroadcast(tx); err != nil {
+	sig, err := s.am.SignMessage(addr, req.Message)
+	if err != nil {
 		return nil, err
 	}
-	return &rpcpb.SendTransactionResponse{
-		Hash: tx.Hash().Hex(),
+	return &rpcpb.SignMessageResponse{
+		Signature: hex.EncodeToString(sig),
 	}, nil
 }
+
+func (s *AdminService) ListUnlocked(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ListUnlockedResponse, error) {
+	unlocks := s.am.Unlocks()
+	list := make([]*rpcpb.UnlockedAccount, 0, len(unlocks))
+	for _, u := range unlocks {
+		ua := &rpcpb.UnlockedAccount{
+			Address: u.Address.String(),
+			Expire:  u.Expire.Unix(),
+			MaxTxs:  u.Scope.MaxTxs,
+			Spent:   u.Spent.String(),
+			Txs:     u.Txs,
+		}
+		if u.Scope.MaxAmount != nil {
+			ua.MaxAmount = u.Scope.MaxAmount.String()
+		}
+		for _, r := range u.Scope.Recipients {
+			ua.Recipients = append(ua.Recipients, address.NewAddressFromCommonAddress(r).String())
+		}
+		list = append(list, ua)
+	}
+	return &rpcpb.ListUnlockedResponse{Accounts: list}, nil
+}
+
+func (s *AdminService) RevokeUnlock(ctx context.Context, req *rpcpb.RevokeUnlockRequest) (*rpcpb.RevokeUnlockResponse, error) {
+	var addrs []*address.Address
+	if len(req.Address) > 0 {
+		addr, err := address.AddressParse(req.Address)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	} else {
+		for _, u := range s.am.Unlocks() {
+			addrs = append(addrs, u.Address)
+		}
+	}
+	revoked := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if err := s.am.Revoke(addr); err != nil {
+			if len(req.Address) > 0 {
+				return nil, err
+			}
+			continue
+		}
+		revoked = append(revoked, addr.String())
+	}
+	return &rpcpb.RevokeUnlockResponse{Addresses: revoked}, nil
+}
+
+func (s *AdminService) ListBans(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ListBansResponse, error) {
+	bans := s.server.Node().P2pService().ListBans()
+	list := make([]*rpcpb.BannedPeer, 0, len(bans))
+	for _, b := range bans {
+		list = append(list, &rpcpb.BannedPeer{
+			NodeId:  b.NodeID,
+			Offence: b.Offence,
+			Until:   b.Until.Unix(),
+		})
+	}
+	return &rpcpb.ListBansResponse{Bans: list}, nil
+}
+
+func (s *AdminService) ClearBans(ctx context.Context, req *rpcpb.ClearBansRequest) (*rpcpb.ClearBansResponse, error) {
+	n, err := s.server.Node().P2pService().ClearBans(req.NodeId)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.ClearBansResponse{Cleared: uint32(n)}, nil
+}
+
+func (s *AdminService) AddPeer(ctx context.Context, req *rpcpb.AddPeerRequest) (*rpcpb.AddPeerResponse, error) {
+	if err := s.server.Node().P2pService().AddPeer(req.Node, req.Trusted); err != nil {
+		return nil, err
+	}
+	return &rpcpb.AddPeerResponse{Result: true}, nil
+}
+
+func (s *AdminService) RemovePeer(ctx context.Context, req *rpcpb.RemovePeerRequest) (*rpcpb.RemovePeerResponse, error) {
+	if err := s.server.Node().P2pService().RemovePeer(req.NodeId); err != nil {
+		return nil, err
+	}
+	return &rpcpb.RemovePeerResponse{Result: true}, nil
+}
+
+func (s *AdminService) ListPeers(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ListPeersResponse, error) {
+	peers, err := s.server.Node().P2pService().ListPeers()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*rpcpb.PeerInfo, 0, len(peers))
+	for _, p := range peers {
+		list = append(list, &rpcpb.PeerInfo{
+			NodeId:    p.NodeID,
+			Node:      p.Node,
+			Subnet:    p.Subnet,
+			Inbound:   p.Inbound,
+			Connected: p.Connected,
+			Reserved:  p.Reserved,
+			Trusted:   p.Trusted,
+		})
+	}
+	return &rpcpb.ListPeersResponse{Peers: list}, nil
+}
+
+func (s *AdminService) ConsensusState(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ConsensusStateResponse, error) {
+	state, err := s.core.ConsensusState()
+	if err != nil {
+		return nil, err
+	}
+	resp := &rpcpb.ConsensusStateResponse{
+		Vid:         state.Vid,
+		Height:      state.H,
+		N:           state.N,
+		TxsAccepted: uint64(state.TxsAccepted),
+		TxsPending:  uint64(state.TxsPending),
+		TxsQueued:   uint64(state.TxsQueued),
+	}
+	for _, v := range state.Validators {
+		var heartbeat int64
+		if !v.Heartbeat.IsZero() {
+			heartbeat = v.Heartbeat.UnixNano() / int64(time.Millisecond)
+		}
+		resp.Validators = append(resp.Validators, &rpcpb.ValidatorState{
+			Vid:       v.Vid,
+			Height:    v.Height,
+			Pending:   v.Pending,
+			Heartbeat: heartbeat,
+		})
+	}
+	for _, r := range state.Rounds {
+		rs := &rpcpb.RoundState{Round: int32(r.Round)}
+		for _, w := range r.Witnesses {
+			rs.Witnesses = append(rs.Witnesses, dagEventResponse(w))
+		}
+		resp.Rounds = append(resp.Rounds, rs)
+	}
+	return resp, nil
+}
+
+func (s *AdminService) ConsensusDag(ctx context.Context, req *rpcpb.ConsensusDagRequest) (*rpcpb.ConsensusDagResponse, error) {
+	dag, err := s.core.ConsensusDag(int(req.Rounds))
+	if err != nil {
+		return nil, err
+	}
+	resp := &rpcpb.ConsensusDagResponse{
+		Vid:    dag.Vid,
+		Height: dag.H,
+		Rounds: uint32(dag.Rounds),
+		Events: make([]*rpcpb.DagEvent, 0, len(dag.Events)),
+	}
+	for _, ev := range dag.Events {
+		resp.Events = append(resp.Events, dagEventResponse(ev))
+	}
+	return resp, nil
+}
+
+func dagEventResponse(ev *tetris2.DagEvent) *rpcpb.DagEvent {
+	resp := &rpcpb.DagEvent{
+		Hash:        ev.Hash.Hex(),
+		Vid:         ev.Vid,
+		Height:      ev.H,
+		N:           ev.N,
+		Time:        ev.T,
+		Round:       int32(ev.Round),
+		Witness:     ev.Witness,
+		Committable: int32(ev.Committable),
+		Ready:       ev.Ready,
+		Txs:         uint32(ev.Txs),
+	}
+	for _, p := range ev.Parents {
+		resp.Parents = append(resp.Parents, p.Hex())
+	}
+	for _, f := range ev.Forks {
+		resp.Forks = append(resp.Forks, f.Hex())
+	}
+	return resp
+}
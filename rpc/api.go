@@ -22,8 +22,11 @@ package rpc
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"time"
 
+	"github.com/yeeco/gyee/accounts"
 	"github.com/yeeco/gyee/common"
 	"github.com/yeeco/gyee/common/address"
 	"github.com/yeeco/gyee/core"
@@ -53,17 +56,50 @@ func (s *APIService) NodeInfo(ctx context.Context, req *rpcpb.NonParamsRequest)
 func (s *APIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByHashRequest) (*rpcpb.BlockResponse, error) {
 	bhash := common.HexToHash(req.Hash)
 	b := s.core.Chain().GetBlockByHash(bhash)
-	return blockResponse(b)
+	return blockResponse(b, s.chain.IsFinalized(b))
 }
 
 func (s *APIService) GetBlockByHeight(ctx context.Context, req *rpcpb.GetBlockByHeightRequest) (*rpcpb.BlockResponse, error) {
 	b := s.core.Chain().GetBlockByNumber(req.Height)
-	return blockResponse(b)
+	return blockResponse(b, s.chain.IsFinalized(b))
 }
 
 func (s *APIService) GetLastBlock(ctx context.Context, req *rpcpb.GetLastBlockRequest) (*rpcpb.GetLastBlockResponse, error) {
 	b := s.core.Chain().LastBlock()
-	return lastBlockResponse(b)
+	return lastBlockResponse(b, s.chain.IsFinalized(b))
+}
+
+func (s *APIService) GetFinalizedBlock(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.GetLastBlockResponse, error) {
+	return lastBlockResponse(s.chain.GetFinalizedBlock(), true)
+}
+
+// SubscribeFinalized streams finalized blocks in order, from req.FromHeight
+// if given, or the next block finalized
+func (s *APIService) SubscribeFinalized(req *rpcpb.SubscribeFinalizedRequest, stream rpcpb.ApiService_SubscribeFinalizedServer) error {
+	ch, unsubscribe := s.chain.SubscribeFinalized()
+	defer unsubscribe()
+
+	next := s.chain.FinalizedHeight() + 1
+	if req.FromHeight > 0 {
+		next = req.FromHeight
+	}
+	height := s.chain.FinalizedHeight()
+	for {
+		for ; next <= height; next++ {
+			br, err := blockResponse(s.chain.GetBlockByNumber(next), true)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(br); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case height = <-ch:
+		}
+	}
 }
 
 func (s *APIService) GetTxByHash(ctx context.Context, req *rpcpb.GetTxByHashRequest) (*rpcpb.TransactionResponse, error) {
@@ -72,6 +108,18 @@ func (s *APIService) GetTxByHash(ctx context.Context, req *rpcpb.GetTxByHashRequ
 	return txResponse(tx)
 }
 
+func (s *APIService) GetTxReceipt(ctx context.Context, req *rpcpb.GetTxByHashRequest) (*rpcpb.ReceiptResponse, error) {
+	receipt := s.chain.GetReceipt(common.HexToHash(req.Hash))
+	if receipt == nil {
+		return nil, errors.New("receipt not found")
+	}
+	return &rpcpb.ReceiptResponse{
+		Hash:   receipt.TxHash.Hex(),
+		Height: receipt.Height,
+		Reason: receipt.Reason,
+	}, nil
+}
+
 func (s *APIService) GetAccountState(ctx context.Context, req *rpcpb.GetAccountStateRequest) (*rpcpb.GetAccountStateResponse, error) {
 	addr, err := address.AddressParse(req.Address)
 	if err != nil {
@@ -81,7 +129,82 @@ func (s *APIService) GetAccountState(ctx context.Context, req *rpcpb.GetAccountS
 	return accountStateResponse(account)
 }
 
-func blockResponse(b *core.Block) (*rpcpb.BlockResponse, error) {
+func (s *APIService) VerifyMessage(ctx context.Context, req *rpcpb.VerifyMessageRequest) (*rpcpb.VerifyMessageResponse, error) {
+	addr, err := address.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hex.DecodeString(req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	err = accounts.VerifyMessage(addr, req.Message, sig)
+	return &rpcpb.VerifyMessageResponse{Result: err == nil}, nil
+}
+
+func (s *APIService) SendRawTransaction(ctx context.Context, req *rpcpb.SendRawTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+	tx := new(core.Transaction)
+	if err := tx.Decode(req.Data); err != nil {
+		return nil, err
+	}
+	if tx.ChainID() != uint32(s.chain.ChainID()) {
+		return nil, core.ErrTxChainID
+	}
+	if err := tx.VerifySig(); err != nil {
+		return nil, err
+	}
+	if err := s.core.TxBroadcast(tx); err != nil {
+		return nil, err
+	}
+	return &rpcpb.SendTransactionResponse{
+		Hash: tx.Hash().Hex(),
+	}, nil
+}
+
+func (s *APIService) GetEvidence(ctx context.Context, req *rpcpb.GetEvidenceRequest) (*rpcpb.GetEvidenceResponse, error) {
+	var validator string
+	if len(req.Address) > 0 {
+		addr, err := address.AddressParse(req.Address)
+		if err != nil {
+			return nil, err
+		}
+		validator = addr.String()
+	}
+	resp := &rpcpb.GetEvidenceResponse{}
+	for _, record := range s.chain.GetJailed() {
+		if len(validator) > 0 && record.Validator != validator {
+			continue
+		}
+		resp.Evidences = append(resp.Evidences, &rpcpb.EvidenceResponse{
+			Validator: record.Validator,
+			Height:    record.Height,
+			Hash:      record.Evidence.Hex(),
+			Data:      hex.EncodeToString(s.chain.GetEvidenceByHash(record.Evidence)),
+		})
+	}
+	return resp, nil
+}
+
+func (s *APIService) GetConsensusParams(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ConsensusParamsResponse, error) {
+	cfg := s.core.TetrisConfig()
+	millis := func(d time.Duration) uint64 {
+		return uint64(d / time.Millisecond)
+	}
+	return &rpcpb.ConsensusParamsResponse{
+		Engine:            s.core.EngineName(),
+		MaxTxPerEvent:     uint64(cfg.MaxTxPerEvent),
+		MaxTxDelay:        millis(cfg.MaxTxDelay),
+		MinPeriodForEvent: millis(cfg.MinPeriodForEvent),
+		MaxPeriodForEvent: millis(cfg.MaxPeriodForEvent),
+		Tick:              millis(cfg.Tick),
+		EventCacheSize:    uint64(cfg.EventCacheSize),
+		EventRequestSize:  uint64(cfg.EventRequestSize),
+		TxsCacheSize:      uint64(cfg.TxsCacheSize),
+		TxsCommittedSize:  uint64(cfg.TxsCommittedSize),
+	}, nil
+}
+
+func blockResponse(b *core.Block, finalized bool) (*rpcpb.BlockResponse, error) {
 	if b == nil {
 		return nil, errors.New("block not found")
 	}
@@ -96,11 +219,13 @@ func blockResponse(b *core.Block) (*rpcpb.BlockResponse, error) {
 		StateRoot:     b.StateRoot().Hex(),
 		TxsRoot:       b.TxsRoot().Hex(),
 		ReceiptsRoot:  b.ReceiptsRoot().Hex(),
+
+		Finalized: finalized,
 	}, nil
 }
 
-func lastBlockResponse(b *core.Block) (*rpcpb.GetLastBlockResponse, error) {
-	br, err := blockResponse(b)
+func lastBlockResponse(b *core.Block, finalized bool) (*rpcpb.GetLastBlockResponse, error) {
+	br, err := blockResponse(b, finalized)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +245,9 @@ func txResponse(tx *core.Transaction) (*rpcpb.TransactionResponse, error) {
 		From:      tx.From().Hex(),
 		Recipient: tx.Recipient().Hex(),
 		Amount:    tx.Amount().String(),
+
+		ValidAfter: tx.ValidAfter(),
+		ValidUntil: tx.ValidUntil(),
 	}, nil
 }
 
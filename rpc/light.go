@@ -0,0 +1,132 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+
+	"github.com/yeeco/gyee/accounts"
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/common/address"
+	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/light"
+	"github.com/yeeco/gyee/rpc/pb"
+)
+
+var ErrLightUnsupported = errors.New("not supported by light node")
+
+// LightAPIService answers api of light node, blocks are of headers only,
+// accounts are proven by full peers. Headers are accepted only when signed
+// by 2/3 validators, so all of them are finalized.
+type LightAPIService struct {
+	server RPCServer
+	light  *light.Client
+}
+
+func newLightAPIService(server RPCServer, client *light.Client) *LightAPIService {
+	return &LightAPIService{
+		server: server,
+		light:  client,
+	}
+}
+
+func (s *LightAPIService) NodeInfo(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.NodeInfoResponse, error) {
+	nodeId := s.server.Node().NodeID()
+	return &rpcpb.NodeInfoResponse{Id: nodeId, Version: 1}, nil
+}
+
+func (s *LightAPIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByHashRequest) (*rpcpb.BlockResponse, error) {
+	return blockResponse(s.light.HeaderByHash(common.HexToHash(req.Hash)), true)
+}
+
+func (s *LightAPIService) GetBlockByHeight(ctx context.Context, req *rpcpb.GetBlockByHeightRequest) (*rpcpb.BlockResponse, error) {
+	return blockResponse(s.light.HeaderByNumber(req.Height), true)
+}
+
+func (s *LightAPIService) GetLastBlock(ctx context.Context, req *rpcpb.GetLastBlockRequest) (*rpcpb.GetLastBlockResponse, error) {
+	return lastBlockResponse(s.light.Head(), true)
+}
+
+func (s *LightAPIService) GetFinalizedBlock(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.GetLastBlockResponse, error) {
+	return lastBlockResponse(s.light.Head(), true)
+}
+
+func (s *LightAPIService) SubscribeFinalized(req *rpcpb.SubscribeFinalizedRequest, stream rpcpb.ApiService_SubscribeFinalizedServer) error {
+	return ErrLightUnsupported
+}
+
+func (s *LightAPIService) GetTxByHash(ctx context.Context, req *rpcpb.GetTxByHashRequest) (*rpcpb.TransactionResponse, error) {
+	return nil, ErrLightUnsupported
+}
+
+func (s *LightAPIService) GetTxReceipt(ctx context.Context, req *rpcpb.GetTxByHashRequest) (*rpcpb.ReceiptResponse, error) {
+	return nil, ErrLightUnsupported
+}
+
+func (s *LightAPIService) GetAccountState(ctx context.Context, req *rpcpb.GetAccountStateRequest) (*rpcpb.GetAccountStateResponse, error) {
+	addr, err := address.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.light.GetAccount(ctx, *addr.CommonAddress())
+	if err != nil {
+		return nil, err
+	}
+	return accountStateResponse(account)
+}
+
+func (s *LightAPIService) VerifyMessage(ctx context.Context, req *rpcpb.VerifyMessageRequest) (*rpcpb.VerifyMessageResponse, error) {
+	addr, err := address.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hex.DecodeString(req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	err = accounts.VerifyMessage(addr, req.Message, sig)
+	return &rpcpb.VerifyMessageResponse{Result: err == nil}, nil
+}
+
+func (s *LightAPIService) SendRawTransaction(ctx context.Context, req *rpcpb.SendRawTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+	tx := new(core.Transaction)
+	if err := tx.Decode(req.Data); err != nil {
+		return nil, err
+	}
+	if tx.ChainID() != uint32(s.light.ChainID()) {
+		return nil, core.ErrTxChainID
+	}
+	if err := tx.VerifySig(); err != nil {
+		return nil, err
+	}
+	if err := s.light.TxBroadcast(tx); err != nil {
+		return nil, err
+	}
+	return &rpcpb.SendTransactionResponse{
+		Hash: tx.Hash().Hex(),
+	}, nil
+}
+
+func (s *LightAPIService) GetEvidence(ctx context.Context, req *rpcpb.GetEvidenceRequest) (*rpcpb.GetEvidenceResponse, error) {
+	return nil, ErrLightUnsupported
+}
+
+func (s *LightAPIService) GetConsensusParams(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ConsensusParamsResponse, error) {
+	return nil, ErrLightUnsupported
+}
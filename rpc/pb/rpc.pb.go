@@ -34,7 +34,7 @@ func (m *NonParamsRequest) Reset()         { *m = NonParamsRequest{} }
 func (m *NonParamsRequest) String() string { return proto.CompactTextString(m) }
 func (*NonParamsRequest) ProtoMessage()    {}
 func (*NonParamsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{0}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{0}
 }
 func (m *NonParamsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NonParamsRequest.Unmarshal(m, b)
@@ -72,7 +72,9 @@ type BlockResponse struct {
 	// transactions root hex string
 	TxsRoot string `protobuf:"bytes,8,opt,name=txs_root,json=txsRoot,proto3" json:"txs_root,omitempty"`
 	// receipts root hex string
-	ReceiptsRoot         string   `protobuf:"bytes,9,opt,name=receipts_root,json=receiptsRoot,proto3" json:"receipts_root,omitempty"`
+	ReceiptsRoot string `protobuf:"bytes,9,opt,name=receipts_root,json=receiptsRoot,proto3" json:"receipts_root,omitempty"`
+	// block signed by 2/3 validators of its parent, or below such a block
+	Finalized            bool     `protobuf:"varint,10,opt,name=finalized,proto3" json:"finalized,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -82,7 +84,7 @@ func (m *BlockResponse) Reset()         { *m = BlockResponse{} }
 func (m *BlockResponse) String() string { return proto.CompactTextString(m) }
 func (*BlockResponse) ProtoMessage()    {}
 func (*BlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{1}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{1}
 }
 func (m *BlockResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockResponse.Unmarshal(m, b)
@@ -165,6 +167,13 @@ func (m *BlockResponse) GetReceiptsRoot() string {
 	return ""
 }
 
+func (m *BlockResponse) GetFinalized() bool {
+	if m != nil {
+		return m.Finalized
+	}
+	return false
+}
+
 type GetBlockByHashRequest struct {
 	// block hash hex string
 	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -177,7 +186,7 @@ func (m *GetBlockByHashRequest) Reset()         { *m = GetBlockByHashRequest{} }
 func (m *GetBlockByHashRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockByHashRequest) ProtoMessage()    {}
 func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{2}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{2}
 }
 func (m *GetBlockByHashRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockByHashRequest.Unmarshal(m, b)
@@ -216,7 +225,7 @@ func (m *GetBlockByHeightRequest) Reset()         { *m = GetBlockByHeightRequest
 func (m *GetBlockByHeightRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockByHeightRequest) ProtoMessage()    {}
 func (*GetBlockByHeightRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{3}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{3}
 }
 func (m *GetBlockByHeightRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockByHeightRequest.Unmarshal(m, b)
@@ -257,7 +266,7 @@ func (m *GetLastBlockResponse) Reset()         { *m = GetLastBlockResponse{} }
 func (m *GetLastBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*GetLastBlockResponse) ProtoMessage()    {}
 func (*GetLastBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{4}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{4}
 }
 func (m *GetLastBlockResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetLastBlockResponse.Unmarshal(m, b)
@@ -301,7 +310,7 @@ func (m *GetLastBlockRequest) Reset()         { *m = GetLastBlockRequest{} }
 func (m *GetLastBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*GetLastBlockRequest) ProtoMessage()    {}
 func (*GetLastBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{5}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{5}
 }
 func (m *GetLastBlockRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetLastBlockRequest.Unmarshal(m, b)
@@ -321,6 +330,46 @@ func (m *GetLastBlockRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetLastBlockRequest proto.InternalMessageInfo
 
+type SubscribeFinalizedRequest struct {
+	// first block height sent, finalized blocks from it are sent at once,
+	// 0 for blocks finalized from now on
+	FromHeight           uint64   `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeFinalizedRequest) Reset()         { *m = SubscribeFinalizedRequest{} }
+func (m *SubscribeFinalizedRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeFinalizedRequest) ProtoMessage()    {}
+func (*SubscribeFinalizedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{6}
+}
+func (m *SubscribeFinalizedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeFinalizedRequest.Unmarshal(m, b)
+}
+func (m *SubscribeFinalizedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeFinalizedRequest.Marshal(b, m, deterministic)
+}
+func (dst *SubscribeFinalizedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeFinalizedRequest.Merge(dst, src)
+}
+func (m *SubscribeFinalizedRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeFinalizedRequest.Size(m)
+}
+func (m *SubscribeFinalizedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeFinalizedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeFinalizedRequest proto.InternalMessageInfo
+
+func (m *SubscribeFinalizedRequest) GetFromHeight() uint64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
 type TransactionResponse struct {
 	// tx hash hex string
 	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -331,7 +380,11 @@ type TransactionResponse struct {
 	// tx recipient address
 	Recipient string `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	// transaction amount decimal string
-	Amount               string   `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Amount string `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	// block height or time in milli seconds tx valid after, 0 for no limit
+	ValidAfter uint64 `protobuf:"varint,6,opt,name=valid_after,json=validAfter,proto3" json:"valid_after,omitempty"`
+	// block height or time in milli seconds tx valid until, 0 for no limit
+	ValidUntil           uint64   `protobuf:"varint,7,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -341,7 +394,7 @@ func (m *TransactionResponse) Reset()         { *m = TransactionResponse{} }
 func (m *TransactionResponse) String() string { return proto.CompactTextString(m) }
 func (*TransactionResponse) ProtoMessage()    {}
 func (*TransactionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{6}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{7}
 }
 func (m *TransactionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransactionResponse.Unmarshal(m, b)
@@ -396,6 +449,77 @@ func (m *TransactionResponse) GetAmount() string {
 	return ""
 }
 
+func (m *TransactionResponse) GetValidAfter() uint64 {
+	if m != nil {
+		return m.ValidAfter
+	}
+	return 0
+}
+
+func (m *TransactionResponse) GetValidUntil() uint64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+type ReceiptResponse struct {
+	// tx hash hex string
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// height of block tx dropped from
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// reason of tx failure
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiptResponse) Reset()         { *m = ReceiptResponse{} }
+func (m *ReceiptResponse) String() string { return proto.CompactTextString(m) }
+func (*ReceiptResponse) ProtoMessage()    {}
+func (*ReceiptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{8}
+}
+func (m *ReceiptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptResponse.Unmarshal(m, b)
+}
+func (m *ReceiptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReceiptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptResponse.Merge(dst, src)
+}
+func (m *ReceiptResponse) XXX_Size() int {
+	return xxx_messageInfo_ReceiptResponse.Size(m)
+}
+func (m *ReceiptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptResponse proto.InternalMessageInfo
+
+func (m *ReceiptResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *ReceiptResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReceiptResponse) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 type GetTxByHashRequest struct {
 	// tx hash hex string
 	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -408,7 +532,7 @@ func (m *GetTxByHashRequest) Reset()         { *m = GetTxByHashRequest{} }
 func (m *GetTxByHashRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTxByHashRequest) ProtoMessage()    {}
 func (*GetTxByHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{7}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{9}
 }
 func (m *GetTxByHashRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetTxByHashRequest.Unmarshal(m, b)
@@ -451,7 +575,7 @@ func (m *GetAccountStateResponse) Reset()         { *m = GetAccountStateResponse
 func (m *GetAccountStateResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAccountStateResponse) ProtoMessage()    {}
 func (*GetAccountStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{8}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{10}
 }
 func (m *GetAccountStateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetAccountStateResponse.Unmarshal(m, b)
@@ -504,7 +628,7 @@ func (m *GetAccountStateRequest) Reset()         { *m = GetAccountStateRequest{}
 func (m *GetAccountStateRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAccountStateRequest) ProtoMessage()    {}
 func (*GetAccountStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{9}
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{11}
 }
 func (m *GetAccountStateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetAccountStateRequest.Unmarshal(m, b)
@@ -531,443 +655,2063 @@ func (m *GetAccountStateRequest) GetAddress() string {
 	return ""
 }
 
-// Response message of node info.
-type NodeInfoResponse struct {
-	// the node ID.
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// the node version.
-	Version              uint32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+type VerifyMessageRequest struct {
+	// signer address string
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// raw message, without the signed message prefix
+	Message []byte `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// signature hex string
+	Signature            string   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NodeInfoResponse) Reset()         { *m = NodeInfoResponse{} }
-func (m *NodeInfoResponse) String() string { return proto.CompactTextString(m) }
-func (*NodeInfoResponse) ProtoMessage()    {}
-func (*NodeInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{10}
+func (m *VerifyMessageRequest) Reset()         { *m = VerifyMessageRequest{} }
+func (m *VerifyMessageRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyMessageRequest) ProtoMessage()    {}
+func (*VerifyMessageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{12}
 }
-func (m *NodeInfoResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NodeInfoResponse.Unmarshal(m, b)
+func (m *VerifyMessageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyMessageRequest.Unmarshal(m, b)
 }
-func (m *NodeInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NodeInfoResponse.Marshal(b, m, deterministic)
+func (m *VerifyMessageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyMessageRequest.Marshal(b, m, deterministic)
 }
-func (dst *NodeInfoResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NodeInfoResponse.Merge(dst, src)
+func (dst *VerifyMessageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyMessageRequest.Merge(dst, src)
 }
-func (m *NodeInfoResponse) XXX_Size() int {
-	return xxx_messageInfo_NodeInfoResponse.Size(m)
+func (m *VerifyMessageRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyMessageRequest.Size(m)
 }
-func (m *NodeInfoResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_NodeInfoResponse.DiscardUnknown(m)
+func (m *VerifyMessageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyMessageRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NodeInfoResponse proto.InternalMessageInfo
+var xxx_messageInfo_VerifyMessageRequest proto.InternalMessageInfo
 
-func (m *NodeInfoResponse) GetId() string {
+func (m *VerifyMessageRequest) GetAddress() string {
 	if m != nil {
-		return m.Id
+		return m.Address
 	}
 	return ""
 }
 
-func (m *NodeInfoResponse) GetVersion() uint32 {
+func (m *VerifyMessageRequest) GetMessage() []byte {
 	if m != nil {
-		return m.Version
+		return m.Message
 	}
-	return 0
+	return nil
 }
 
-type AccountsResponse struct {
-	// account address list
-	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+func (m *VerifyMessageRequest) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type VerifyMessageResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AccountsResponse) Reset()         { *m = AccountsResponse{} }
-func (m *AccountsResponse) String() string { return proto.CompactTextString(m) }
-func (*AccountsResponse) ProtoMessage()    {}
-func (*AccountsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{11}
+func (m *VerifyMessageResponse) Reset()         { *m = VerifyMessageResponse{} }
+func (m *VerifyMessageResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyMessageResponse) ProtoMessage()    {}
+func (*VerifyMessageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{13}
 }
-func (m *AccountsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AccountsResponse.Unmarshal(m, b)
+func (m *VerifyMessageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyMessageResponse.Unmarshal(m, b)
 }
-func (m *AccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AccountsResponse.Marshal(b, m, deterministic)
+func (m *VerifyMessageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyMessageResponse.Marshal(b, m, deterministic)
 }
-func (dst *AccountsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AccountsResponse.Merge(dst, src)
+func (dst *VerifyMessageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyMessageResponse.Merge(dst, src)
 }
-func (m *AccountsResponse) XXX_Size() int {
-	return xxx_messageInfo_AccountsResponse.Size(m)
+func (m *VerifyMessageResponse) XXX_Size() int {
+	return xxx_messageInfo_VerifyMessageResponse.Size(m)
 }
-func (m *AccountsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AccountsResponse.DiscardUnknown(m)
+func (m *VerifyMessageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyMessageResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AccountsResponse proto.InternalMessageInfo
+var xxx_messageInfo_VerifyMessageResponse proto.InternalMessageInfo
 
-func (m *AccountsResponse) GetAddresses() []string {
+func (m *VerifyMessageResponse) GetResult() bool {
 	if m != nil {
-		return m.Addresses
+		return m.Result
 	}
-	return nil
+	return false
 }
 
-type NewAccountRequest struct {
-	Passphrase           string   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+type SendRawTransactionRequest struct {
+	// signed transaction encoded bytes
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NewAccountRequest) Reset()         { *m = NewAccountRequest{} }
-func (m *NewAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*NewAccountRequest) ProtoMessage()    {}
-func (*NewAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{12}
+func (m *SendRawTransactionRequest) Reset()         { *m = SendRawTransactionRequest{} }
+func (m *SendRawTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendRawTransactionRequest) ProtoMessage()    {}
+func (*SendRawTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{14}
 }
-func (m *NewAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewAccountRequest.Unmarshal(m, b)
+func (m *SendRawTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendRawTransactionRequest.Unmarshal(m, b)
 }
-func (m *NewAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewAccountRequest.Marshal(b, m, deterministic)
+func (m *SendRawTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendRawTransactionRequest.Marshal(b, m, deterministic)
 }
-func (dst *NewAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewAccountRequest.Merge(dst, src)
+func (dst *SendRawTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendRawTransactionRequest.Merge(dst, src)
 }
-func (m *NewAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_NewAccountRequest.Size(m)
+func (m *SendRawTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SendRawTransactionRequest.Size(m)
 }
-func (m *NewAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewAccountRequest.DiscardUnknown(m)
+func (m *SendRawTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendRawTransactionRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewAccountRequest proto.InternalMessageInfo
+var xxx_messageInfo_SendRawTransactionRequest proto.InternalMessageInfo
 
-func (m *NewAccountRequest) GetPassphrase() string {
+func (m *SendRawTransactionRequest) GetData() []byte {
 	if m != nil {
-		return m.Passphrase
+		return m.Data
 	}
-	return ""
+	return nil
 }
 
-type NewAccountResponse struct {
+type GetEvidenceRequest struct {
+	// jailed validator address string, all jailed validators if empty
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NewAccountResponse) Reset()         { *m = NewAccountResponse{} }
-func (m *NewAccountResponse) String() string { return proto.CompactTextString(m) }
-func (*NewAccountResponse) ProtoMessage()    {}
-func (*NewAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{13}
+func (m *GetEvidenceRequest) Reset()         { *m = GetEvidenceRequest{} }
+func (m *GetEvidenceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEvidenceRequest) ProtoMessage()    {}
+func (*GetEvidenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{15}
 }
-func (m *NewAccountResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewAccountResponse.Unmarshal(m, b)
+func (m *GetEvidenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEvidenceRequest.Unmarshal(m, b)
 }
-func (m *NewAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewAccountResponse.Marshal(b, m, deterministic)
+func (m *GetEvidenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEvidenceRequest.Marshal(b, m, deterministic)
 }
-func (dst *NewAccountResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewAccountResponse.Merge(dst, src)
+func (dst *GetEvidenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEvidenceRequest.Merge(dst, src)
 }
-func (m *NewAccountResponse) XXX_Size() int {
-	return xxx_messageInfo_NewAccountResponse.Size(m)
+func (m *GetEvidenceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEvidenceRequest.Size(m)
 }
-func (m *NewAccountResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewAccountResponse.DiscardUnknown(m)
+func (m *GetEvidenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEvidenceRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewAccountResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetEvidenceRequest proto.InternalMessageInfo
 
-func (m *NewAccountResponse) GetAddress() string {
+func (m *GetEvidenceRequest) GetAddress() string {
 	if m != nil {
 		return m.Address
 	}
 	return ""
 }
 
-type UnlockAccountRequest struct {
-	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Passphrase           string   `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
-	Duration             uint64   `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+type EvidenceResponse struct {
+	// jailed validator address string
+	Validator string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	// height of block including the evidence
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// evidence hash hex string
+	Hash string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	// encoded evidence hex string
+	Data                 string   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UnlockAccountRequest) Reset()         { *m = UnlockAccountRequest{} }
-func (m *UnlockAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*UnlockAccountRequest) ProtoMessage()    {}
-func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{14}
+func (m *EvidenceResponse) Reset()         { *m = EvidenceResponse{} }
+func (m *EvidenceResponse) String() string { return proto.CompactTextString(m) }
+func (*EvidenceResponse) ProtoMessage()    {}
+func (*EvidenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{16}
 }
-func (m *UnlockAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnlockAccountRequest.Unmarshal(m, b)
+func (m *EvidenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvidenceResponse.Unmarshal(m, b)
 }
-func (m *UnlockAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnlockAccountRequest.Marshal(b, m, deterministic)
+func (m *EvidenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvidenceResponse.Marshal(b, m, deterministic)
 }
-func (dst *UnlockAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnlockAccountRequest.Merge(dst, src)
+func (dst *EvidenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvidenceResponse.Merge(dst, src)
 }
-func (m *UnlockAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_UnlockAccountRequest.Size(m)
+func (m *EvidenceResponse) XXX_Size() int {
+	return xxx_messageInfo_EvidenceResponse.Size(m)
 }
-func (m *UnlockAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnlockAccountRequest.DiscardUnknown(m)
+func (m *EvidenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvidenceResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnlockAccountRequest proto.InternalMessageInfo
+var xxx_messageInfo_EvidenceResponse proto.InternalMessageInfo
 
-func (m *UnlockAccountRequest) GetAddress() string {
+func (m *EvidenceResponse) GetValidator() string {
 	if m != nil {
-		return m.Address
+		return m.Validator
 	}
 	return ""
 }
 
-func (m *UnlockAccountRequest) GetPassphrase() string {
+func (m *EvidenceResponse) GetHeight() uint64 {
 	if m != nil {
-		return m.Passphrase
+		return m.Height
+	}
+	return 0
+}
+
+func (m *EvidenceResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
 	}
 	return ""
 }
 
-func (m *UnlockAccountRequest) GetDuration() uint64 {
+func (m *EvidenceResponse) GetData() string {
 	if m != nil {
-		return m.Duration
+		return m.Data
 	}
-	return 0
+	return ""
 }
 
-type UnlockAccountResponse struct {
-	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type GetEvidenceResponse struct {
+	Evidences            []*EvidenceResponse `protobuf:"bytes,1,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *UnlockAccountResponse) Reset()         { *m = UnlockAccountResponse{} }
-func (m *UnlockAccountResponse) String() string { return proto.CompactTextString(m) }
-func (*UnlockAccountResponse) ProtoMessage()    {}
-func (*UnlockAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{15}
+func (m *GetEvidenceResponse) Reset()         { *m = GetEvidenceResponse{} }
+func (m *GetEvidenceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEvidenceResponse) ProtoMessage()    {}
+func (*GetEvidenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{17}
 }
-func (m *UnlockAccountResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnlockAccountResponse.Unmarshal(m, b)
+func (m *GetEvidenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEvidenceResponse.Unmarshal(m, b)
 }
-func (m *UnlockAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnlockAccountResponse.Marshal(b, m, deterministic)
+func (m *GetEvidenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEvidenceResponse.Marshal(b, m, deterministic)
 }
-func (dst *UnlockAccountResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnlockAccountResponse.Merge(dst, src)
+func (dst *GetEvidenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEvidenceResponse.Merge(dst, src)
 }
-func (m *UnlockAccountResponse) XXX_Size() int {
-	return xxx_messageInfo_UnlockAccountResponse.Size(m)
+func (m *GetEvidenceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEvidenceResponse.Size(m)
 }
-func (m *UnlockAccountResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnlockAccountResponse.DiscardUnknown(m)
+func (m *GetEvidenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEvidenceResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnlockAccountResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetEvidenceResponse proto.InternalMessageInfo
 
-func (m *UnlockAccountResponse) GetResult() bool {
+func (m *GetEvidenceResponse) GetEvidences() []*EvidenceResponse {
 	if m != nil {
-		return m.Result
+		return m.Evidences
 	}
-	return false
+	return nil
 }
 
-type LockAccountRequest struct {
-	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+type ConsensusParamsResponse struct {
+	// consensus engine running or to run if mining
+	Engine string `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	// tetris event timing agreed in genesis, durations in milliseconds
+	MaxTxPerEvent     uint64 `protobuf:"varint,2,opt,name=max_tx_per_event,json=maxTxPerEvent,proto3" json:"max_tx_per_event,omitempty"`
+	MaxTxDelay        uint64 `protobuf:"varint,3,opt,name=max_tx_delay,json=maxTxDelay,proto3" json:"max_tx_delay,omitempty"`
+	MinPeriodForEvent uint64 `protobuf:"varint,4,opt,name=min_period_for_event,json=minPeriodForEvent,proto3" json:"min_period_for_event,omitempty"`
+	MaxPeriodForEvent uint64 `protobuf:"varint,5,opt,name=max_period_for_event,json=maxPeriodForEvent,proto3" json:"max_period_for_event,omitempty"`
+	// tetris local tuning, tick in milliseconds
+	Tick                 uint64   `protobuf:"varint,6,opt,name=tick,proto3" json:"tick,omitempty"`
+	EventCacheSize       uint64   `protobuf:"varint,7,opt,name=event_cache_size,json=eventCacheSize,proto3" json:"event_cache_size,omitempty"`
+	EventRequestSize     uint64   `protobuf:"varint,8,opt,name=event_request_size,json=eventRequestSize,proto3" json:"event_request_size,omitempty"`
+	TxsCacheSize         uint64   `protobuf:"varint,9,opt,name=txs_cache_size,json=txsCacheSize,proto3" json:"txs_cache_size,omitempty"`
+	TxsCommittedSize     uint64   `protobuf:"varint,10,opt,name=txs_committed_size,json=txsCommittedSize,proto3" json:"txs_committed_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LockAccountRequest) Reset()         { *m = LockAccountRequest{} }
-func (m *LockAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*LockAccountRequest) ProtoMessage()    {}
-func (*LockAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{16}
+func (m *ConsensusParamsResponse) Reset()         { *m = ConsensusParamsResponse{} }
+func (m *ConsensusParamsResponse) String() string { return proto.CompactTextString(m) }
+func (*ConsensusParamsResponse) ProtoMessage()    {}
+func (*ConsensusParamsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{18}
 }
-func (m *LockAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LockAccountRequest.Unmarshal(m, b)
+func (m *ConsensusParamsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusParamsResponse.Unmarshal(m, b)
 }
-func (m *LockAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LockAccountRequest.Marshal(b, m, deterministic)
+func (m *ConsensusParamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusParamsResponse.Marshal(b, m, deterministic)
 }
-func (dst *LockAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LockAccountRequest.Merge(dst, src)
+func (dst *ConsensusParamsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusParamsResponse.Merge(dst, src)
 }
-func (m *LockAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_LockAccountRequest.Size(m)
+func (m *ConsensusParamsResponse) XXX_Size() int {
+	return xxx_messageInfo_ConsensusParamsResponse.Size(m)
 }
-func (m *LockAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_LockAccountRequest.DiscardUnknown(m)
+func (m *ConsensusParamsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusParamsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LockAccountRequest proto.InternalMessageInfo
+var xxx_messageInfo_ConsensusParamsResponse proto.InternalMessageInfo
 
-func (m *LockAccountRequest) GetAddress() string {
+func (m *ConsensusParamsResponse) GetEngine() string {
 	if m != nil {
-		return m.Address
+		return m.Engine
 	}
 	return ""
 }
 
-type LockAccountResponse struct {
-	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ConsensusParamsResponse) GetMaxTxPerEvent() uint64 {
+	if m != nil {
+		return m.MaxTxPerEvent
+	}
+	return 0
 }
 
-func (m *LockAccountResponse) Reset()         { *m = LockAccountResponse{} }
-func (m *LockAccountResponse) String() string { return proto.CompactTextString(m) }
-func (*LockAccountResponse) ProtoMessage()    {}
-func (*LockAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{17}
-}
-func (m *LockAccountResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LockAccountResponse.Unmarshal(m, b)
+func (m *ConsensusParamsResponse) GetMaxTxDelay() uint64 {
+	if m != nil {
+		return m.MaxTxDelay
+	}
+	return 0
 }
-func (m *LockAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LockAccountResponse.Marshal(b, m, deterministic)
+
+func (m *ConsensusParamsResponse) GetMinPeriodForEvent() uint64 {
+	if m != nil {
+		return m.MinPeriodForEvent
+	}
+	return 0
+}
+
+func (m *ConsensusParamsResponse) GetMaxPeriodForEvent() uint64 {
+	if m != nil {
+		return m.MaxPeriodForEvent
+	}
+	return 0
+}
+
+func (m *ConsensusParamsResponse) GetTick() uint64 {
+	if m != nil {
+		return m.Tick
+	}
+	return 0
+}
+
+func (m *ConsensusParamsResponse) GetEventCacheSize() uint64 {
+	if m != nil {
+		return m.EventCacheSize
+	}
+	return 0
+}
+
+func (m *ConsensusParamsResponse) GetEventRequestSize() uint64 {
+	if m != nil {
+		return m.EventRequestSize
+	}
+	return 0
+}
+
+func (m *ConsensusParamsResponse) GetTxsCacheSize() uint64 {
+	if m != nil {
+		return m.TxsCacheSize
+	}
+	return 0
+}
+
+func (m *ConsensusParamsResponse) GetTxsCommittedSize() uint64 {
+	if m != nil {
+		return m.TxsCommittedSize
+	}
+	return 0
+}
+
+// Response message of node info.
+type NodeInfoResponse struct {
+	// the node ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the node version.
+	Version              uint32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeInfoResponse) Reset()         { *m = NodeInfoResponse{} }
+func (m *NodeInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeInfoResponse) ProtoMessage()    {}
+func (*NodeInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{19}
+}
+func (m *NodeInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeInfoResponse.Unmarshal(m, b)
+}
+func (m *NodeInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeInfoResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeInfoResponse.Merge(dst, src)
+}
+func (m *NodeInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeInfoResponse.Size(m)
+}
+func (m *NodeInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeInfoResponse proto.InternalMessageInfo
+
+func (m *NodeInfoResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NodeInfoResponse) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type AccountsResponse struct {
+	// account address list
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountsResponse) Reset()         { *m = AccountsResponse{} }
+func (m *AccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountsResponse) ProtoMessage()    {}
+func (*AccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{20}
+}
+func (m *AccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsResponse.Unmarshal(m, b)
+}
+func (m *AccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsResponse.Marshal(b, m, deterministic)
+}
+func (dst *AccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsResponse.Merge(dst, src)
+}
+func (m *AccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountsResponse.Size(m)
+}
+func (m *AccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsResponse proto.InternalMessageInfo
+
+func (m *AccountsResponse) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type NewAccountRequest struct {
+	Passphrase           string   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewAccountRequest) Reset()         { *m = NewAccountRequest{} }
+func (m *NewAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*NewAccountRequest) ProtoMessage()    {}
+func (*NewAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{21}
+}
+func (m *NewAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewAccountRequest.Unmarshal(m, b)
+}
+func (m *NewAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewAccountRequest.Marshal(b, m, deterministic)
+}
+func (dst *NewAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewAccountRequest.Merge(dst, src)
+}
+func (m *NewAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_NewAccountRequest.Size(m)
+}
+func (m *NewAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewAccountRequest proto.InternalMessageInfo
+
+func (m *NewAccountRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+type NewAccountResponse struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewAccountResponse) Reset()         { *m = NewAccountResponse{} }
+func (m *NewAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*NewAccountResponse) ProtoMessage()    {}
+func (*NewAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{22}
+}
+func (m *NewAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewAccountResponse.Unmarshal(m, b)
+}
+func (m *NewAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewAccountResponse.Marshal(b, m, deterministic)
+}
+func (dst *NewAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewAccountResponse.Merge(dst, src)
+}
+func (m *NewAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_NewAccountResponse.Size(m)
+}
+func (m *NewAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewAccountResponse proto.InternalMessageInfo
+
+func (m *NewAccountResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type UnlockAccountRequest struct {
+	Address    string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Passphrase string `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Duration   uint64 `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// optional unlock scope
+	// max total amount decimal string of txs signed during the unlock
+	MaxAmount string `protobuf:"bytes,4,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// allowed tx recipient addresses
+	Recipients []string `protobuf:"bytes,5,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	// max number of txs signed during the unlock
+	MaxTxs               uint64   `protobuf:"varint,6,opt,name=max_txs,json=maxTxs,proto3" json:"max_txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockAccountRequest) Reset()         { *m = UnlockAccountRequest{} }
+func (m *UnlockAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlockAccountRequest) ProtoMessage()    {}
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{23}
+}
+func (m *UnlockAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockAccountRequest.Unmarshal(m, b)
+}
+func (m *UnlockAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockAccountRequest.Marshal(b, m, deterministic)
+}
+func (dst *UnlockAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockAccountRequest.Merge(dst, src)
+}
+func (m *UnlockAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlockAccountRequest.Size(m)
+}
+func (m *UnlockAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockAccountRequest proto.InternalMessageInfo
+
+func (m *UnlockAccountRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *UnlockAccountRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+func (m *UnlockAccountRequest) GetDuration() uint64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *UnlockAccountRequest) GetMaxAmount() string {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return ""
+}
+
+func (m *UnlockAccountRequest) GetRecipients() []string {
+	if m != nil {
+		return m.Recipients
+	}
+	return nil
+}
+
+func (m *UnlockAccountRequest) GetMaxTxs() uint64 {
+	if m != nil {
+		return m.MaxTxs
+	}
+	return 0
+}
+
+type UnlockAccountResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockAccountResponse) Reset()         { *m = UnlockAccountResponse{} }
+func (m *UnlockAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*UnlockAccountResponse) ProtoMessage()    {}
+func (*UnlockAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{24}
+}
+func (m *UnlockAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockAccountResponse.Unmarshal(m, b)
+}
+func (m *UnlockAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockAccountResponse.Marshal(b, m, deterministic)
+}
+func (dst *UnlockAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockAccountResponse.Merge(dst, src)
+}
+func (m *UnlockAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_UnlockAccountResponse.Size(m)
+}
+func (m *UnlockAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockAccountResponse proto.InternalMessageInfo
+
+func (m *UnlockAccountResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type LockAccountRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockAccountRequest) Reset()         { *m = LockAccountRequest{} }
+func (m *LockAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*LockAccountRequest) ProtoMessage()    {}
+func (*LockAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{25}
+}
+func (m *LockAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockAccountRequest.Unmarshal(m, b)
+}
+func (m *LockAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockAccountRequest.Marshal(b, m, deterministic)
+}
+func (dst *LockAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockAccountRequest.Merge(dst, src)
+}
+func (m *LockAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_LockAccountRequest.Size(m)
+}
+func (m *LockAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockAccountRequest proto.InternalMessageInfo
+
+func (m *LockAccountRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type LockAccountResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockAccountResponse) Reset()         { *m = LockAccountResponse{} }
+func (m *LockAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*LockAccountResponse) ProtoMessage()    {}
+func (*LockAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{26}
+}
+func (m *LockAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockAccountResponse.Unmarshal(m, b)
+}
+func (m *LockAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockAccountResponse.Marshal(b, m, deterministic)
+}
+func (dst *LockAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockAccountResponse.Merge(dst, src)
+}
+func (m *LockAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_LockAccountResponse.Size(m)
+}
+func (m *LockAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockAccountResponse proto.InternalMessageInfo
+
+func (m *LockAccountResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type SendTransactionRequest struct {
+	// tx from address hex string
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// tx to address hex string
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// tx amount decimal string
+	Amount string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// account nonce
+	Nonce                uint64   `protobuf:"varint,15,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendTransactionRequest) Reset()         { *m = SendTransactionRequest{} }
+func (m *SendTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendTransactionRequest) ProtoMessage()    {}
+func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{27}
+}
+func (m *SendTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendTransactionRequest.Unmarshal(m, b)
+}
+func (m *SendTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendTransactionRequest.Marshal(b, m, deterministic)
+}
+func (dst *SendTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendTransactionRequest.Merge(dst, src)
+}
+func (m *SendTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SendTransactionRequest.Size(m)
+}
+func (m *SendTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendTransactionRequest proto.InternalMessageInfo
+
+func (m *SendTransactionRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *SendTransactionRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *SendTransactionRequest) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *SendTransactionRequest) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+type SendTransactionResponse struct {
+	// tx hash hex string
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendTransactionResponse) Reset()         { *m = SendTransactionResponse{} }
+func (m *SendTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SendTransactionResponse) ProtoMessage()    {}
+func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{28}
+}
+func (m *SendTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendTransactionResponse.Unmarshal(m, b)
+}
+func (m *SendTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendTransactionResponse.Marshal(b, m, deterministic)
+}
+func (dst *SendTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendTransactionResponse.Merge(dst, src)
+}
+func (m *SendTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_SendTransactionResponse.Size(m)
+}
+func (m *SendTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendTransactionResponse proto.InternalMessageInfo
+
+func (m *SendTransactionResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type SignMessageRequest struct {
+	// signer address string, must be unlocked
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// raw message, without the signed message prefix
+	Message              []byte   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignMessageRequest) Reset()         { *m = SignMessageRequest{} }
+func (m *SignMessageRequest) String() string { return proto.CompactTextString(m) }
+func (*SignMessageRequest) ProtoMessage()    {}
+func (*SignMessageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{29}
+}
+func (m *SignMessageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignMessageRequest.Unmarshal(m, b)
+}
+func (m *SignMessageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignMessageRequest.Marshal(b, m, deterministic)
+}
+func (dst *SignMessageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignMessageRequest.Merge(dst, src)
+}
+func (m *SignMessageRequest) XXX_Size() int {
+	return xxx_messageInfo_SignMessageRequest.Size(m)
+}
+func (m *SignMessageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignMessageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignMessageRequest proto.InternalMessageInfo
+
+func (m *SignMessageRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SignMessageRequest) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+type SignMessageResponse struct {
+	// signature hex string
+	Signature            string   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignMessageResponse) Reset()         { *m = SignMessageResponse{} }
+func (m *SignMessageResponse) String() string { return proto.CompactTextString(m) }
+func (*SignMessageResponse) ProtoMessage()    {}
+func (*SignMessageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{30}
+}
+func (m *SignMessageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignMessageResponse.Unmarshal(m, b)
+}
+func (m *SignMessageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignMessageResponse.Marshal(b, m, deterministic)
+}
+func (dst *SignMessageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignMessageResponse.Merge(dst, src)
+}
+func (m *SignMessageResponse) XXX_Size() int {
+	return xxx_messageInfo_SignMessageResponse.Size(m)
+}
+func (m *SignMessageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignMessageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignMessageResponse proto.InternalMessageInfo
+
+func (m *SignMessageResponse) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type UnlockedAccount struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// unix time in seconds the unlock expires
+	Expire int64 `protobuf:"varint,2,opt,name=expire,proto3" json:"expire,omitempty"`
+	// unlock scope, empty for no restriction
+	MaxAmount  string   `protobuf:"bytes,3,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	Recipients []string `protobuf:"bytes,4,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	MaxTxs     uint64   `protobuf:"varint,5,opt,name=max_txs,json=maxTxs,proto3" json:"max_txs,omitempty"`
+	// total amount decimal string and number of txs signed so far
+	Spent                string   `protobuf:"bytes,6,opt,name=spent,proto3" json:"spent,omitempty"`
+	Txs                  uint64   `protobuf:"varint,7,opt,name=txs,proto3" json:"txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockedAccount) Reset()         { *m = UnlockedAccount{} }
+func (m *UnlockedAccount) String() string { return proto.CompactTextString(m) }
+func (*UnlockedAccount) ProtoMessage()    {}
+func (*UnlockedAccount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{31}
+}
+func (m *UnlockedAccount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockedAccount.Unmarshal(m, b)
+}
+func (m *UnlockedAccount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockedAccount.Marshal(b, m, deterministic)
+}
+func (dst *UnlockedAccount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockedAccount.Merge(dst, src)
+}
+func (m *UnlockedAccount) XXX_Size() int {
+	return xxx_messageInfo_UnlockedAccount.Size(m)
+}
+func (m *UnlockedAccount) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockedAccount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockedAccount proto.InternalMessageInfo
+
+func (m *UnlockedAccount) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *UnlockedAccount) GetExpire() int64 {
+	if m != nil {
+		return m.Expire
+	}
+	return 0
+}
+
+func (m *UnlockedAccount) GetMaxAmount() string {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return ""
+}
+
+func (m *UnlockedAccount) GetRecipients() []string {
+	if m != nil {
+		return m.Recipients
+	}
+	return nil
+}
+
+func (m *UnlockedAccount) GetMaxTxs() uint64 {
+	if m != nil {
+		return m.MaxTxs
+	}
+	return 0
+}
+
+func (m *UnlockedAccount) GetSpent() string {
+	if m != nil {
+		return m.Spent
+	}
+	return ""
+}
+
+func (m *UnlockedAccount) GetTxs() uint64 {
+	if m != nil {
+		return m.Txs
+	}
+	return 0
+}
+
+type ListUnlockedResponse struct {
+	Accounts             []*UnlockedAccount `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListUnlockedResponse) Reset()         { *m = ListUnlockedResponse{} }
+func (m *ListUnlockedResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUnlockedResponse) ProtoMessage()    {}
+func (*ListUnlockedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{32}
+}
+func (m *ListUnlockedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnlockedResponse.Unmarshal(m, b)
+}
+func (m *ListUnlockedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnlockedResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListUnlockedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnlockedResponse.Merge(dst, src)
+}
+func (m *ListUnlockedResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUnlockedResponse.Size(m)
+}
+func (m *ListUnlockedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnlockedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnlockedResponse proto.InternalMessageInfo
+
+func (m *ListUnlockedResponse) GetAccounts() []*UnlockedAccount {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+type RevokeUnlockRequest struct {
+	// address to revoke, all unlocked accounts if empty
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeUnlockRequest) Reset()         { *m = RevokeUnlockRequest{} }
+func (m *RevokeUnlockRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeUnlockRequest) ProtoMessage()    {}
+func (*RevokeUnlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{33}
+}
+func (m *RevokeUnlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeUnlockRequest.Unmarshal(m, b)
+}
+func (m *RevokeUnlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeUnlockRequest.Marshal(b, m, deterministic)
+}
+func (dst *RevokeUnlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeUnlockRequest.Merge(dst, src)
+}
+func (m *RevokeUnlockRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeUnlockRequest.Size(m)
+}
+func (m *RevokeUnlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeUnlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeUnlockRequest proto.InternalMessageInfo
+
+func (m *RevokeUnlockRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type RevokeUnlockResponse struct {
+	// revoked addresses
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeUnlockResponse) Reset()         { *m = RevokeUnlockResponse{} }
+func (m *RevokeUnlockResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeUnlockResponse) ProtoMessage()    {}
+func (*RevokeUnlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{34}
+}
+func (m *RevokeUnlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeUnlockResponse.Unmarshal(m, b)
+}
+func (m *RevokeUnlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeUnlockResponse.Marshal(b, m, deterministic)
+}
+func (dst *RevokeUnlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeUnlockResponse.Merge(dst, src)
+}
+func (m *RevokeUnlockResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeUnlockResponse.Size(m)
+}
+func (m *RevokeUnlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeUnlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeUnlockResponse proto.InternalMessageInfo
+
+func (m *RevokeUnlockResponse) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type BannedPeer struct {
+	// node id hex string
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// offence the peer was banned for
+	Offence string `protobuf:"bytes,2,opt,name=offence,proto3" json:"offence,omitempty"`
+	// unix time in seconds the ban expires
+	Until                int64    `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BannedPeer) Reset()         { *m = BannedPeer{} }
+func (m *BannedPeer) String() string { return proto.CompactTextString(m) }
+func (*BannedPeer) ProtoMessage()    {}
+func (*BannedPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{35}
+}
+func (m *BannedPeer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BannedPeer.Unmarshal(m, b)
+}
+func (m *BannedPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BannedPeer.Marshal(b, m, deterministic)
+}
+func (dst *BannedPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BannedPeer.Merge(dst, src)
+}
+func (m *BannedPeer) XXX_Size() int {
+	return xxx_messageInfo_BannedPeer.Size(m)
+}
+func (m *BannedPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_BannedPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BannedPeer proto.InternalMessageInfo
+
+func (m *BannedPeer) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *BannedPeer) GetOffence() string {
+	if m != nil {
+		return m.Offence
+	}
+	return ""
+}
+
+func (m *BannedPeer) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+type ListBansResponse struct {
+	Bans                 []*BannedPeer `protobuf:"bytes,1,rep,name=bans,proto3" json:"bans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListBansResponse) Reset()         { *m = ListBansResponse{} }
+func (m *ListBansResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBansResponse) ProtoMessage()    {}
+func (*ListBansResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{36}
+}
+func (m *ListBansResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBansResponse.Unmarshal(m, b)
+}
+func (m *ListBansResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBansResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListBansResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBansResponse.Merge(dst, src)
+}
+func (m *ListBansResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBansResponse.Size(m)
+}
+func (m *ListBansResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBansResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBansResponse proto.InternalMessageInfo
+
+func (m *ListBansResponse) GetBans() []*BannedPeer {
+	if m != nil {
+		return m.Bans
+	}
+	return nil
+}
+
+type ClearBansRequest struct {
+	// node id to unban, all banned peers if empty
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClearBansRequest) Reset()         { *m = ClearBansRequest{} }
+func (m *ClearBansRequest) String() string { return proto.CompactTextString(m) }
+func (*ClearBansRequest) ProtoMessage()    {}
+func (*ClearBansRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{37}
+}
+func (m *ClearBansRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClearBansRequest.Unmarshal(m, b)
+}
+func (m *ClearBansRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClearBansRequest.Marshal(b, m, deterministic)
+}
+func (dst *ClearBansRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClearBansRequest.Merge(dst, src)
+}
+func (m *ClearBansRequest) XXX_Size() int {
+	return xxx_messageInfo_ClearBansRequest.Size(m)
+}
+func (m *ClearBansRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClearBansRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClearBansRequest proto.InternalMessageInfo
+
+func (m *ClearBansRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type ClearBansResponse struct {
+	// number of bans cleared
+	Cleared              uint32   `protobuf:"varint,1,opt,name=cleared,proto3" json:"cleared,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClearBansResponse) Reset()         { *m = ClearBansResponse{} }
+func (m *ClearBansResponse) String() string { return proto.CompactTextString(m) }
+func (*ClearBansResponse) ProtoMessage()    {}
+func (*ClearBansResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{38}
+}
+func (m *ClearBansResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClearBansResponse.Unmarshal(m, b)
+}
+func (m *ClearBansResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClearBansResponse.Marshal(b, m, deterministic)
+}
+func (dst *ClearBansResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClearBansResponse.Merge(dst, src)
+}
+func (m *ClearBansResponse) XXX_Size() int {
+	return xxx_messageInfo_ClearBansResponse.Size(m)
+}
+func (m *ClearBansResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClearBansResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClearBansResponse proto.InternalMessageInfo
+
+func (m *ClearBansResponse) GetCleared() uint32 {
+	if m != nil {
+		return m.Cleared
+	}
+	return 0
+}
+
+type AddPeerRequest struct {
+	// node in format as bootstrap nodes: node-id-hex@ip:udp-port:tcp-port
+	Node string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// trusted only, accepted but not dialed as a reserved peer
+	Trusted              bool     `protobuf:"varint,2,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddPeerRequest) Reset()         { *m = AddPeerRequest{} }
+func (m *AddPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*AddPeerRequest) ProtoMessage()    {}
+func (*AddPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{39}
+}
+func (m *AddPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddPeerRequest.Unmarshal(m, b)
+}
+func (m *AddPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddPeerRequest.Marshal(b, m, deterministic)
+}
+func (dst *AddPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPeerRequest.Merge(dst, src)
+}
+func (m *AddPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_AddPeerRequest.Size(m)
+}
+func (m *AddPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddPeerRequest proto.InternalMessageInfo
+
+func (m *AddPeerRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
 }
-func (dst *LockAccountResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LockAccountResponse.Merge(dst, src)
+
+func (m *AddPeerRequest) GetTrusted() bool {
+	if m != nil {
+		return m.Trusted
+	}
+	return false
 }
-func (m *LockAccountResponse) XXX_Size() int {
-	return xxx_messageInfo_LockAccountResponse.Size(m)
+
+type AddPeerResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *LockAccountResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_LockAccountResponse.DiscardUnknown(m)
+
+func (m *AddPeerResponse) Reset()         { *m = AddPeerResponse{} }
+func (m *AddPeerResponse) String() string { return proto.CompactTextString(m) }
+func (*AddPeerResponse) ProtoMessage()    {}
+func (*AddPeerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{40}
+}
+func (m *AddPeerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddPeerResponse.Unmarshal(m, b)
+}
+func (m *AddPeerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddPeerResponse.Marshal(b, m, deterministic)
+}
+func (dst *AddPeerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPeerResponse.Merge(dst, src)
+}
+func (m *AddPeerResponse) XXX_Size() int {
+	return xxx_messageInfo_AddPeerResponse.Size(m)
+}
+func (m *AddPeerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPeerResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LockAccountResponse proto.InternalMessageInfo
+var xxx_messageInfo_AddPeerResponse proto.InternalMessageInfo
 
-func (m *LockAccountResponse) GetResult() bool {
+func (m *AddPeerResponse) GetResult() bool {
 	if m != nil {
 		return m.Result
 	}
 	return false
 }
 
-type SendTransactionRequest struct {
-	// tx from address hex string
-	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	// tx to address hex string
-	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
-	// tx amount decimal string
-	Amount string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	// account nonce
-	Nonce                uint64   `protobuf:"varint,15,opt,name=nonce,proto3" json:"nonce,omitempty"`
+type RemovePeerRequest struct {
+	// node id hex string
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemovePeerRequest) Reset()         { *m = RemovePeerRequest{} }
+func (m *RemovePeerRequest) String() string { return proto.CompactTextString(m) }
+func (*RemovePeerRequest) ProtoMessage()    {}
+func (*RemovePeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{41}
+}
+func (m *RemovePeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemovePeerRequest.Unmarshal(m, b)
+}
+func (m *RemovePeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemovePeerRequest.Marshal(b, m, deterministic)
+}
+func (dst *RemovePeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemovePeerRequest.Merge(dst, src)
+}
+func (m *RemovePeerRequest) XXX_Size() int {
+	return xxx_messageInfo_RemovePeerRequest.Size(m)
+}
+func (m *RemovePeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemovePeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemovePeerRequest proto.InternalMessageInfo
+
+func (m *RemovePeerRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type RemovePeerResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemovePeerResponse) Reset()         { *m = RemovePeerResponse{} }
+func (m *RemovePeerResponse) String() string { return proto.CompactTextString(m) }
+func (*RemovePeerResponse) ProtoMessage()    {}
+func (*RemovePeerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{42}
+}
+func (m *RemovePeerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemovePeerResponse.Unmarshal(m, b)
+}
+func (m *RemovePeerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemovePeerResponse.Marshal(b, m, deterministic)
+}
+func (dst *RemovePeerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemovePeerResponse.Merge(dst, src)
+}
+func (m *RemovePeerResponse) XXX_Size() int {
+	return xxx_messageInfo_RemovePeerResponse.Size(m)
+}
+func (m *RemovePeerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemovePeerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemovePeerResponse proto.InternalMessageInfo
+
+func (m *RemovePeerResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type PeerInfo struct {
+	// node id hex string
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// node in format as bootstrap nodes
+	Node string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	// sub network id hex string, if connected
+	Subnet    string `protobuf:"bytes,3,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	Inbound   bool   `protobuf:"varint,4,opt,name=inbound,proto3" json:"inbound,omitempty"`
+	Connected bool   `protobuf:"varint,5,opt,name=connected,proto3" json:"connected,omitempty"`
+	// always connected and exempt from peer caps
+	Reserved bool `protobuf:"varint,6,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	// accepted and exempt from peer caps
+	Trusted              bool     `protobuf:"varint,7,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
+func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
+func (*PeerInfo) ProtoMessage()    {}
+func (*PeerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{43}
+}
+func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerInfo.Unmarshal(m, b)
+}
+func (m *PeerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerInfo.Marshal(b, m, deterministic)
+}
+func (dst *PeerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerInfo.Merge(dst, src)
+}
+func (m *PeerInfo) XXX_Size() int {
+	return xxx_messageInfo_PeerInfo.Size(m)
+}
+func (m *PeerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerInfo proto.InternalMessageInfo
+
+func (m *PeerInfo) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetSubnet() string {
+	if m != nil {
+		return m.Subnet
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetInbound() bool {
+	if m != nil {
+		return m.Inbound
+	}
+	return false
+}
+
+func (m *PeerInfo) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *PeerInfo) GetReserved() bool {
+	if m != nil {
+		return m.Reserved
+	}
+	return false
+}
+
+func (m *PeerInfo) GetTrusted() bool {
+	if m != nil {
+		return m.Trusted
+	}
+	return false
+}
+
+type ListPeersResponse struct {
+	Peers                []*PeerInfo `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListPeersResponse) Reset()         { *m = ListPeersResponse{} }
+func (m *ListPeersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPeersResponse) ProtoMessage()    {}
+func (*ListPeersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{44}
+}
+func (m *ListPeersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPeersResponse.Unmarshal(m, b)
+}
+func (m *ListPeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPeersResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListPeersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPeersResponse.Merge(dst, src)
+}
+func (m *ListPeersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPeersResponse.Size(m)
+}
+func (m *ListPeersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPeersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPeersResponse proto.InternalMessageInfo
+
+func (m *ListPeersResponse) GetPeers() []*PeerInfo {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type ValidatorState struct {
+	// validator address string
+	Vid string `protobuf:"bytes,1,opt,name=vid,proto3" json:"vid,omitempty"`
+	// sequence number of the highest ready event
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// sequence number of the highest event received
+	Pending uint64 `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	// unix time in milliseconds of the last event or pulse received, 0 if none
+	Heartbeat            int64    `protobuf:"varint,4,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatorState) Reset()         { *m = ValidatorState{} }
+func (m *ValidatorState) String() string { return proto.CompactTextString(m) }
+func (*ValidatorState) ProtoMessage()    {}
+func (*ValidatorState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{45}
+}
+func (m *ValidatorState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorState.Unmarshal(m, b)
+}
+func (m *ValidatorState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorState.Marshal(b, m, deterministic)
+}
+func (dst *ValidatorState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorState.Merge(dst, src)
+}
+func (m *ValidatorState) XXX_Size() int {
+	return xxx_messageInfo_ValidatorState.Size(m)
+}
+func (m *ValidatorState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorState proto.InternalMessageInfo
+
+func (m *ValidatorState) GetVid() string {
+	if m != nil {
+		return m.Vid
+	}
+	return ""
+}
+
+func (m *ValidatorState) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ValidatorState) GetPending() uint64 {
+	if m != nil {
+		return m.Pending
+	}
+	return 0
+}
+
+func (m *ValidatorState) GetHeartbeat() int64 {
+	if m != nil {
+		return m.Heartbeat
+	}
+	return 0
+}
+
+type DagEvent struct {
+	// event hash hex string
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// validator address string
+	Vid    string `protobuf:"bytes,2,opt,name=vid,proto3" json:"vid,omitempty"`
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	N      uint64 `protobuf:"varint,4,opt,name=n,proto3" json:"n,omitempty"`
+	// unix time in nanoseconds
+	Time int64 `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
+	// -1 if undecided
+	Round   int32 `protobuf:"varint,6,opt,name=round,proto3" json:"round,omitempty"`
+	Witness bool  `protobuf:"varint,7,opt,name=witness,proto3" json:"witness,omitempty"`
+	// -1 if undecided, 0 or 1
+	Committable int32  `protobuf:"varint,8,opt,name=committable,proto3" json:"committable,omitempty"`
+	Ready       bool   `protobuf:"varint,9,opt,name=ready,proto3" json:"ready,omitempty"`
+	Txs         uint32 `protobuf:"varint,10,opt,name=txs,proto3" json:"txs,omitempty"`
+	// parent event hash hex strings, self parent first
+	Parents []string `protobuf:"bytes,11,rep,name=parents,proto3" json:"parents,omitempty"`
+	// hashes of events forking this one
+	Forks                []string `protobuf:"bytes,12,rep,name=forks,proto3" json:"forks,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SendTransactionRequest) Reset()         { *m = SendTransactionRequest{} }
-func (m *SendTransactionRequest) String() string { return proto.CompactTextString(m) }
-func (*SendTransactionRequest) ProtoMessage()    {}
-func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{18}
+func (m *DagEvent) Reset()         { *m = DagEvent{} }
+func (m *DagEvent) String() string { return proto.CompactTextString(m) }
+func (*DagEvent) ProtoMessage()    {}
+func (*DagEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{46}
+}
+func (m *DagEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DagEvent.Unmarshal(m, b)
+}
+func (m *DagEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DagEvent.Marshal(b, m, deterministic)
+}
+func (dst *DagEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DagEvent.Merge(dst, src)
+}
+func (m *DagEvent) XXX_Size() int {
+	return xxx_messageInfo_DagEvent.Size(m)
+}
+func (m *DagEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DagEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DagEvent proto.InternalMessageInfo
+
+func (m *DagEvent) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *DagEvent) GetVid() string {
+	if m != nil {
+		return m.Vid
+	}
+	return ""
+}
+
+func (m *DagEvent) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *DagEvent) GetN() uint64 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+func (m *DagEvent) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *DagEvent) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *DagEvent) GetWitness() bool {
+	if m != nil {
+		return m.Witness
+	}
+	return false
+}
+
+func (m *DagEvent) GetCommittable() int32 {
+	if m != nil {
+		return m.Committable
+	}
+	return 0
+}
+
+func (m *DagEvent) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *DagEvent) GetTxs() uint32 {
+	if m != nil {
+		return m.Txs
+	}
+	return 0
+}
+
+func (m *DagEvent) GetParents() []string {
+	if m != nil {
+		return m.Parents
+	}
+	return nil
+}
+
+func (m *DagEvent) GetForks() []string {
+	if m != nil {
+		return m.Forks
+	}
+	return nil
+}
+
+type RoundState struct {
+	Round                int32       `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Witnesses            []*DagEvent `protobuf:"bytes,2,rep,name=witnesses,proto3" json:"witnesses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RoundState) Reset()         { *m = RoundState{} }
+func (m *RoundState) String() string { return proto.CompactTextString(m) }
+func (*RoundState) ProtoMessage()    {}
+func (*RoundState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{47}
+}
+func (m *RoundState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoundState.Unmarshal(m, b)
+}
+func (m *RoundState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoundState.Marshal(b, m, deterministic)
+}
+func (dst *RoundState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundState.Merge(dst, src)
+}
+func (m *RoundState) XXX_Size() int {
+	return xxx_messageInfo_RoundState.Size(m)
+}
+func (m *RoundState) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundState proto.InternalMessageInfo
+
+func (m *RoundState) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RoundState) GetWitnesses() []*DagEvent {
+	if m != nil {
+		return m.Witnesses
+	}
+	return nil
 }
-func (m *SendTransactionRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SendTransactionRequest.Unmarshal(m, b)
+
+type ConsensusStateResponse struct {
+	// own validator address string
+	Vid string `protobuf:"bytes,1,opt,name=vid,proto3" json:"vid,omitempty"`
+	// block height reached and own next sequence number
+	Height     uint64            `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	N          uint64            `protobuf:"varint,3,opt,name=n,proto3" json:"n,omitempty"`
+	Validators []*ValidatorState `protobuf:"bytes,4,rep,name=validators,proto3" json:"validators,omitempty"`
+	// txs waiting for own event, in events waiting for consensus, and queued
+	TxsAccepted uint64 `protobuf:"varint,5,opt,name=txs_accepted,json=txsAccepted,proto3" json:"txs_accepted,omitempty"`
+	TxsPending  uint64 `protobuf:"varint,6,opt,name=txs_pending,json=txsPending,proto3" json:"txs_pending,omitempty"`
+	TxsQueued   uint64 `protobuf:"varint,7,opt,name=txs_queued,json=txsQueued,proto3" json:"txs_queued,omitempty"`
+	// recent rounds at current height
+	Rounds               []*RoundState `protobuf:"bytes,8,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
-func (m *SendTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SendTransactionRequest.Marshal(b, m, deterministic)
+
+func (m *ConsensusStateResponse) Reset()         { *m = ConsensusStateResponse{} }
+func (m *ConsensusStateResponse) String() string { return proto.CompactTextString(m) }
+func (*ConsensusStateResponse) ProtoMessage()    {}
+func (*ConsensusStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{48}
 }
-func (dst *SendTransactionRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendTransactionRequest.Merge(dst, src)
+func (m *ConsensusStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusStateResponse.Unmarshal(m, b)
 }
-func (m *SendTransactionRequest) XXX_Size() int {
-	return xxx_messageInfo_SendTransactionRequest.Size(m)
+func (m *ConsensusStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusStateResponse.Marshal(b, m, deterministic)
 }
-func (m *SendTransactionRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendTransactionRequest.DiscardUnknown(m)
+func (dst *ConsensusStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusStateResponse.Merge(dst, src)
+}
+func (m *ConsensusStateResponse) XXX_Size() int {
+	return xxx_messageInfo_ConsensusStateResponse.Size(m)
+}
+func (m *ConsensusStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusStateResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SendTransactionRequest proto.InternalMessageInfo
+var xxx_messageInfo_ConsensusStateResponse proto.InternalMessageInfo
 
-func (m *SendTransactionRequest) GetFrom() string {
+func (m *ConsensusStateResponse) GetVid() string {
 	if m != nil {
-		return m.From
+		return m.Vid
 	}
 	return ""
 }
 
-func (m *SendTransactionRequest) GetTo() string {
+func (m *ConsensusStateResponse) GetHeight() uint64 {
 	if m != nil {
-		return m.To
+		return m.Height
 	}
-	return ""
+	return 0
 }
 
-func (m *SendTransactionRequest) GetAmount() string {
+func (m *ConsensusStateResponse) GetN() uint64 {
 	if m != nil {
-		return m.Amount
+		return m.N
 	}
-	return ""
+	return 0
 }
 
-func (m *SendTransactionRequest) GetNonce() uint64 {
+func (m *ConsensusStateResponse) GetValidators() []*ValidatorState {
 	if m != nil {
-		return m.Nonce
+		return m.Validators
+	}
+	return nil
+}
+
+func (m *ConsensusStateResponse) GetTxsAccepted() uint64 {
+	if m != nil {
+		return m.TxsAccepted
 	}
 	return 0
 }
 
-type SendTransactionResponse struct {
-	// tx hash hex string
-	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+func (m *ConsensusStateResponse) GetTxsPending() uint64 {
+	if m != nil {
+		return m.TxsPending
+	}
+	return 0
+}
+
+func (m *ConsensusStateResponse) GetTxsQueued() uint64 {
+	if m != nil {
+		return m.TxsQueued
+	}
+	return 0
+}
+
+func (m *ConsensusStateResponse) GetRounds() []*RoundState {
+	if m != nil {
+		return m.Rounds
+	}
+	return nil
+}
+
+type ConsensusDagRequest struct {
+	// last rounds at current height, all if 0
+	Rounds               uint32   `protobuf:"varint,1,opt,name=rounds,proto3" json:"rounds,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SendTransactionResponse) Reset()         { *m = SendTransactionResponse{} }
-func (m *SendTransactionResponse) String() string { return proto.CompactTextString(m) }
-func (*SendTransactionResponse) ProtoMessage()    {}
-func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_0da49ab51868ae98, []int{19}
+func (m *ConsensusDagRequest) Reset()         { *m = ConsensusDagRequest{} }
+func (m *ConsensusDagRequest) String() string { return proto.CompactTextString(m) }
+func (*ConsensusDagRequest) ProtoMessage()    {}
+func (*ConsensusDagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{49}
 }
-func (m *SendTransactionResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SendTransactionResponse.Unmarshal(m, b)
+func (m *ConsensusDagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusDagRequest.Unmarshal(m, b)
 }
-func (m *SendTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SendTransactionResponse.Marshal(b, m, deterministic)
+func (m *ConsensusDagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusDagRequest.Marshal(b, m, deterministic)
 }
-func (dst *SendTransactionResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendTransactionResponse.Merge(dst, src)
+func (dst *ConsensusDagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusDagRequest.Merge(dst, src)
 }
-func (m *SendTransactionResponse) XXX_Size() int {
-	return xxx_messageInfo_SendTransactionResponse.Size(m)
+func (m *ConsensusDagRequest) XXX_Size() int {
+	return xxx_messageInfo_ConsensusDagRequest.Size(m)
 }
-func (m *SendTransactionResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendTransactionResponse.DiscardUnknown(m)
+func (m *ConsensusDagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusDagRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SendTransactionResponse proto.InternalMessageInfo
+var xxx_messageInfo_ConsensusDagRequest proto.InternalMessageInfo
 
-func (m *SendTransactionResponse) GetHash() string {
+func (m *ConsensusDagRequest) GetRounds() uint32 {
 	if m != nil {
-		return m.Hash
+		return m.Rounds
+	}
+	return 0
+}
+
+type ConsensusDagResponse struct {
+	Vid    string `protobuf:"bytes,1,opt,name=vid,proto3" json:"vid,omitempty"`
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// rounds decided at current height
+	Rounds               uint32      `protobuf:"varint,3,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	Events               []*DagEvent `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ConsensusDagResponse) Reset()         { *m = ConsensusDagResponse{} }
+func (m *ConsensusDagResponse) String() string { return proto.CompactTextString(m) }
+func (*ConsensusDagResponse) ProtoMessage()    {}
+func (*ConsensusDagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_41b52fd17eb5f7c5, []int{50}
+}
+func (m *ConsensusDagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusDagResponse.Unmarshal(m, b)
+}
+func (m *ConsensusDagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusDagResponse.Marshal(b, m, deterministic)
+}
+func (dst *ConsensusDagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusDagResponse.Merge(dst, src)
+}
+func (m *ConsensusDagResponse) XXX_Size() int {
+	return xxx_messageInfo_ConsensusDagResponse.Size(m)
+}
+func (m *ConsensusDagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusDagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusDagResponse proto.InternalMessageInfo
+
+func (m *ConsensusDagResponse) GetVid() string {
+	if m != nil {
+		return m.Vid
 	}
 	return ""
 }
 
+func (m *ConsensusDagResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ConsensusDagResponse) GetRounds() uint32 {
+	if m != nil {
+		return m.Rounds
+	}
+	return 0
+}
+
+func (m *ConsensusDagResponse) GetEvents() []*DagEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*NonParamsRequest)(nil), "rpcpb.NonParamsRequest")
 	proto.RegisterType((*BlockResponse)(nil), "rpcpb.BlockResponse")
@@ -975,10 +2719,19 @@ func init() {
 	proto.RegisterType((*GetBlockByHeightRequest)(nil), "rpcpb.GetBlockByHeightRequest")
 	proto.RegisterType((*GetLastBlockResponse)(nil), "rpcpb.GetLastBlockResponse")
 	proto.RegisterType((*GetLastBlockRequest)(nil), "rpcpb.GetLastBlockRequest")
+	proto.RegisterType((*SubscribeFinalizedRequest)(nil), "rpcpb.SubscribeFinalizedRequest")
 	proto.RegisterType((*TransactionResponse)(nil), "rpcpb.TransactionResponse")
+	proto.RegisterType((*ReceiptResponse)(nil), "rpcpb.ReceiptResponse")
 	proto.RegisterType((*GetTxByHashRequest)(nil), "rpcpb.GetTxByHashRequest")
 	proto.RegisterType((*GetAccountStateResponse)(nil), "rpcpb.GetAccountStateResponse")
 	proto.RegisterType((*GetAccountStateRequest)(nil), "rpcpb.GetAccountStateRequest")
+	proto.RegisterType((*VerifyMessageRequest)(nil), "rpcpb.VerifyMessageRequest")
+	proto.RegisterType((*VerifyMessageResponse)(nil), "rpcpb.VerifyMessageResponse")
+	proto.RegisterType((*SendRawTransactionRequest)(nil), "rpcpb.SendRawTransactionRequest")
+	proto.RegisterType((*GetEvidenceRequest)(nil), "rpcpb.GetEvidenceRequest")
+	proto.RegisterType((*EvidenceResponse)(nil), "rpcpb.EvidenceResponse")
+	proto.RegisterType((*GetEvidenceResponse)(nil), "rpcpb.GetEvidenceResponse")
+	proto.RegisterType((*ConsensusParamsResponse)(nil), "rpcpb.ConsensusParamsResponse")
 	proto.RegisterType((*NodeInfoResponse)(nil), "rpcpb.NodeInfoResponse")
 	proto.RegisterType((*AccountsResponse)(nil), "rpcpb.AccountsResponse")
 	proto.RegisterType((*NewAccountRequest)(nil), "rpcpb.NewAccountRequest")
@@ -989,6 +2742,28 @@ func init() {
 	proto.RegisterType((*LockAccountResponse)(nil), "rpcpb.LockAccountResponse")
 	proto.RegisterType((*SendTransactionRequest)(nil), "rpcpb.SendTransactionRequest")
 	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
+	proto.RegisterType((*SignMessageRequest)(nil), "rpcpb.SignMessageRequest")
+	proto.RegisterType((*SignMessageResponse)(nil), "rpcpb.SignMessageResponse")
+	proto.RegisterType((*UnlockedAccount)(nil), "rpcpb.UnlockedAccount")
+	proto.RegisterType((*ListUnlockedResponse)(nil), "rpcpb.ListUnlockedResponse")
+	proto.RegisterType((*RevokeUnlockRequest)(nil), "rpcpb.RevokeUnlockRequest")
+	proto.RegisterType((*RevokeUnlockResponse)(nil), "rpcpb.RevokeUnlockResponse")
+	proto.RegisterType((*BannedPeer)(nil), "rpcpb.BannedPeer")
+	proto.RegisterType((*ListBansResponse)(nil), "rpcpb.ListBansResponse")
+	proto.RegisterType((*ClearBansRequest)(nil), "rpcpb.ClearBansRequest")
+	proto.RegisterType((*ClearBansResponse)(nil), "rpcpb.ClearBansResponse")
+	proto.RegisterType((*AddPeerRequest)(nil), "rpcpb.AddPeerRequest")
+	proto.RegisterType((*AddPeerResponse)(nil), "rpcpb.AddPeerResponse")
+	proto.RegisterType((*RemovePeerRequest)(nil), "rpcpb.RemovePeerRequest")
+	proto.RegisterType((*RemovePeerResponse)(nil), "rpcpb.RemovePeerResponse")
+	proto.RegisterType((*PeerInfo)(nil), "rpcpb.PeerInfo")
+	proto.RegisterType((*ListPeersResponse)(nil), "rpcpb.ListPeersResponse")
+	proto.RegisterType((*ValidatorState)(nil), "rpcpb.ValidatorState")
+	proto.RegisterType((*DagEvent)(nil), "rpcpb.DagEvent")
+	proto.RegisterType((*RoundState)(nil), "rpcpb.RoundState")
+	proto.RegisterType((*ConsensusStateResponse)(nil), "rpcpb.ConsensusStateResponse")
+	proto.RegisterType((*ConsensusDagRequest)(nil), "rpcpb.ConsensusDagRequest")
+	proto.RegisterType((*ConsensusDagResponse)(nil), "rpcpb.ConsensusDagResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1008,7 +2783,17 @@ type ApiServiceClient interface {
 	GetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	GetLastBlock(ctx context.Context, in *GetLastBlockRequest, opts ...grpc.CallOption) (*GetLastBlockResponse, error)
 	GetTxByHash(ctx context.Context, in *GetTxByHashRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// receipt of tx dropped from block, e.g. expired
+	GetTxReceipt(ctx context.Context, in *GetTxByHashRequest, opts ...grpc.CallOption) (*ReceiptResponse, error)
 	GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error)
+	VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error)
+	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	GetEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (*GetEvidenceResponse, error)
+	GetConsensusParams(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ConsensusParamsResponse, error)
+	// highest block signed by 2/3 validators of its parent
+	GetFinalizedBlock(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetLastBlockResponse, error)
+	// blocks finalized, in order of height
+	SubscribeFinalized(ctx context.Context, in *SubscribeFinalizedRequest, opts ...grpc.CallOption) (ApiService_SubscribeFinalizedClient, error)
 }
 
 type apiServiceClient struct {
@@ -1064,6 +2849,15 @@ func (c *apiServiceClient) GetTxByHash(ctx context.Context, in *GetTxByHashReque
 	return out, nil
 }
 
+func (c *apiServiceClient) GetTxReceipt(ctx context.Context, in *GetTxByHashRequest, opts ...grpc.CallOption) (*ReceiptResponse, error) {
+	out := new(ReceiptResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetTxReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error) {
 	out := new(GetAccountStateResponse)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetAccountState", in, out, opts...)
@@ -1073,6 +2867,83 @@ func (c *apiServiceClient) GetAccountState(ctx context.Context, in *GetAccountSt
 	return out, nil
 }
 
+func (c *apiServiceClient) VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error) {
+	out := new(VerifyMessageResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/VerifyMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/SendRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (*GetEvidenceResponse, error) {
+	out := new(GetEvidenceResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetConsensusParams(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ConsensusParamsResponse, error) {
+	out := new(ConsensusParamsResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetConsensusParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetFinalizedBlock(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetLastBlockResponse, error) {
+	out := new(GetLastBlockResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetFinalizedBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SubscribeFinalized(ctx context.Context, in *SubscribeFinalizedRequest, opts ...grpc.CallOption) (ApiService_SubscribeFinalizedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ApiService_serviceDesc.Streams[0], "/rpcpb.ApiService/SubscribeFinalized", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apiServiceSubscribeFinalizedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApiService_SubscribeFinalizedClient interface {
+	Recv() (*BlockResponse, error)
+	grpc.ClientStream
+}
+
+type apiServiceSubscribeFinalizedClient struct {
+	grpc.ClientStream
+}
+
+func (x *apiServiceSubscribeFinalizedClient) Recv() (*BlockResponse, error) {
+	m := new(BlockResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ApiServiceServer is the server API for ApiService service.
 type ApiServiceServer interface {
 	NodeInfo(context.Context, *NonParamsRequest) (*NodeInfoResponse, error)
@@ -1080,7 +2951,17 @@ type ApiServiceServer interface {
 	GetBlockByHeight(context.Context, *GetBlockByHeightRequest) (*BlockResponse, error)
 	GetLastBlock(context.Context, *GetLastBlockRequest) (*GetLastBlockResponse, error)
 	GetTxByHash(context.Context, *GetTxByHashRequest) (*TransactionResponse, error)
+	// receipt of tx dropped from block, e.g. expired
+	GetTxReceipt(context.Context, *GetTxByHashRequest) (*ReceiptResponse, error)
 	GetAccountState(context.Context, *GetAccountStateRequest) (*GetAccountStateResponse, error)
+	VerifyMessage(context.Context, *VerifyMessageRequest) (*VerifyMessageResponse, error)
+	SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendTransactionResponse, error)
+	GetEvidence(context.Context, *GetEvidenceRequest) (*GetEvidenceResponse, error)
+	GetConsensusParams(context.Context, *NonParamsRequest) (*ConsensusParamsResponse, error)
+	// highest block signed by 2/3 validators of its parent
+	GetFinalizedBlock(context.Context, *NonParamsRequest) (*GetLastBlockResponse, error)
+	// blocks finalized, in order of height
+	SubscribeFinalized(*SubscribeFinalizedRequest, ApiService_SubscribeFinalizedServer) error
 }
 
 func RegisterApiServiceServer(s *grpc.Server, srv ApiServiceServer) {
@@ -1141,60 +3022,189 @@ func _ApiService_GetBlockByHeight_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetLastBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetLastBlockRequest)
+func _ApiService_GetLastBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetLastBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetLastBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetLastBlock(ctx, req.(*GetLastBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetTxByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTxByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTxByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTxByHash(ctx, req.(*GetTxByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetTxReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTxReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTxReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTxReceipt(ctx, req.(*GetTxByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetAccountState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetAccountState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetAccountState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetAccountState(ctx, req.(*GetAccountStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_VerifyMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).VerifyMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/VerifyMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).VerifyMessage(ctx, req.(*VerifyMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).SendRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/SendRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).SendRawTransaction(ctx, req.(*SendRawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEvidenceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetLastBlock(ctx, in)
+		return srv.(ApiServiceServer).GetEvidence(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetLastBlock",
+		FullMethod: "/rpcpb.ApiService/GetEvidence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetLastBlock(ctx, req.(*GetLastBlockRequest))
+		return srv.(ApiServiceServer).GetEvidence(ctx, req.(*GetEvidenceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetTxByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTxByHashRequest)
+func _ApiService_GetConsensusParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetTxByHash(ctx, in)
+		return srv.(ApiServiceServer).GetConsensusParams(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetTxByHash",
+		FullMethod: "/rpcpb.ApiService/GetConsensusParams",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetTxByHash(ctx, req.(*GetTxByHashRequest))
+		return srv.(ApiServiceServer).GetConsensusParams(ctx, req.(*NonParamsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetAccountState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAccountStateRequest)
+func _ApiService_GetFinalizedBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetAccountState(ctx, in)
+		return srv.(ApiServiceServer).GetFinalizedBlock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetAccountState",
+		FullMethod: "/rpcpb.ApiService/GetFinalizedBlock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetAccountState(ctx, req.(*GetAccountStateRequest))
+		return srv.(ApiServiceServer).GetFinalizedBlock(ctx, req.(*NonParamsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_SubscribeFinalized_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeFinalizedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiServiceServer).SubscribeFinalized(m, &apiServiceSubscribeFinalizedServer{stream})
+}
+
+type ApiService_SubscribeFinalizedServer interface {
+	Send(*BlockResponse) error
+	grpc.ServerStream
+}
+
+type apiServiceSubscribeFinalizedServer struct {
+	grpc.ServerStream
+}
+
+func (x *apiServiceSubscribeFinalizedServer) Send(m *BlockResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _ApiService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.ApiService",
 	HandlerType: (*ApiServiceServer)(nil),
@@ -1219,12 +3229,42 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTxByHash",
 			Handler:    _ApiService_GetTxByHash_Handler,
 		},
+		{
+			MethodName: "GetTxReceipt",
+			Handler:    _ApiService_GetTxReceipt_Handler,
+		},
 		{
 			MethodName: "GetAccountState",
 			Handler:    _ApiService_GetAccountState_Handler,
 		},
+		{
+			MethodName: "VerifyMessage",
+			Handler:    _ApiService_VerifyMessage_Handler,
+		},
+		{
+			MethodName: "SendRawTransaction",
+			Handler:    _ApiService_SendRawTransaction_Handler,
+		},
+		{
+			MethodName: "GetEvidence",
+			Handler:    _ApiService_GetEvidence_Handler,
+		},
+		{
+			MethodName: "GetConsensusParams",
+			Handler:    _ApiService_GetConsensusParams_Handler,
+		},
+		{
+			MethodName: "GetFinalizedBlock",
+			Handler:    _ApiService_GetFinalizedBlock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeFinalized",
+			Handler:       _ApiService_SubscribeFinalized_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
 
@@ -1237,6 +3277,16 @@ type AdminServiceClient interface {
 	UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error)
 	LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*LockAccountResponse, error)
 	SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+	ListUnlocked(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ListUnlockedResponse, error)
+	RevokeUnlock(ctx context.Context, in *RevokeUnlockRequest, opts ...grpc.CallOption) (*RevokeUnlockResponse, error)
+	ListBans(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ListBansResponse, error)
+	ClearBans(ctx context.Context, in *ClearBansRequest, opts ...grpc.CallOption) (*ClearBansResponse, error)
+	AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerResponse, error)
+	RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerResponse, error)
+	ListPeers(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	ConsensusState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ConsensusStateResponse, error)
+	ConsensusDag(ctx context.Context, in *ConsensusDagRequest, opts ...grpc.CallOption) (*ConsensusDagResponse, error)
 }
 
 type adminServiceClient struct {
@@ -1292,6 +3342,96 @@ func (c *adminServiceClient) SendTransaction(ctx context.Context, in *SendTransa
 	return out, nil
 }
 
+func (c *adminServiceClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/SignMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListUnlocked(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ListUnlockedResponse, error) {
+	out := new(ListUnlockedResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/ListUnlocked", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RevokeUnlock(ctx context.Context, in *RevokeUnlockRequest, opts ...grpc.CallOption) (*RevokeUnlockResponse, error) {
+	out := new(RevokeUnlockResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/RevokeUnlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListBans(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ListBansResponse, error) {
+	out := new(ListBansResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/ListBans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClearBans(ctx context.Context, in *ClearBansRequest, opts ...grpc.CallOption) (*ClearBansResponse, error) {
+	out := new(ClearBansResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/ClearBans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerResponse, error) {
+	out := new(AddPeerResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/AddPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerResponse, error) {
+	out := new(RemovePeerResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/RemovePeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListPeers(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	out := new(ListPeersResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/ListPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ConsensusState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ConsensusStateResponse, error) {
+	out := new(ConsensusStateResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/ConsensusState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ConsensusDag(ctx context.Context, in *ConsensusDagRequest, opts ...grpc.CallOption) (*ConsensusDagResponse, error) {
+	out := new(ConsensusDagResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/ConsensusDag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 type AdminServiceServer interface {
 	Accounts(context.Context, *NonParamsRequest) (*AccountsResponse, error)
@@ -1299,6 +3439,16 @@ type AdminServiceServer interface {
 	UnlockAccount(context.Context, *UnlockAccountRequest) (*UnlockAccountResponse, error)
 	LockAccount(context.Context, *LockAccountRequest) (*LockAccountResponse, error)
 	SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error)
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+	ListUnlocked(context.Context, *NonParamsRequest) (*ListUnlockedResponse, error)
+	RevokeUnlock(context.Context, *RevokeUnlockRequest) (*RevokeUnlockResponse, error)
+	ListBans(context.Context, *NonParamsRequest) (*ListBansResponse, error)
+	ClearBans(context.Context, *ClearBansRequest) (*ClearBansResponse, error)
+	AddPeer(context.Context, *AddPeerRequest) (*AddPeerResponse, error)
+	RemovePeer(context.Context, *RemovePeerRequest) (*RemovePeerResponse, error)
+	ListPeers(context.Context, *NonParamsRequest) (*ListPeersResponse, error)
+	ConsensusState(context.Context, *NonParamsRequest) (*ConsensusStateResponse, error)
+	ConsensusDag(context.Context, *ConsensusDagRequest) (*ConsensusDagResponse, error)
 }
 
 func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
@@ -1395,6 +3545,186 @@ func _AdminService_SendTransaction_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListUnlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListUnlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ListUnlocked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListUnlocked(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RevokeUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RevokeUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RevokeUnlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RevokeUnlock(ctx, req.(*RevokeUnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ListBans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListBans(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClearBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearBansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClearBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ClearBans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClearBans(ctx, req.(*ClearBansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/AddPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddPeer(ctx, req.(*AddPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemovePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemovePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RemovePeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemovePeer(ctx, req.(*RemovePeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ListPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListPeers(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ConsensusState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ConsensusState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ConsensusState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ConsensusState(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ConsensusDag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsensusDagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ConsensusDag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ConsensusDag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ConsensusDag(ctx, req.(*ConsensusDagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AdminService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.AdminService",
 	HandlerType: (*AdminServiceServer)(nil),
@@ -1419,65 +3749,192 @@ var _AdminService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendTransaction",
 			Handler:    _AdminService_SendTransaction_Handler,
 		},
+		{
+			MethodName: "SignMessage",
+			Handler:    _AdminService_SignMessage_Handler,
+		},
+		{
+			MethodName: "ListUnlocked",
+			Handler:    _AdminService_ListUnlocked_Handler,
+		},
+		{
+			MethodName: "RevokeUnlock",
+			Handler:    _AdminService_RevokeUnlock_Handler,
+		},
+		{
+			MethodName: "ListBans",
+			Handler:    _AdminService_ListBans_Handler,
+		},
+		{
+			MethodName: "ClearBans",
+			Handler:    _AdminService_ClearBans_Handler,
+		},
+		{
+			MethodName: "AddPeer",
+			Handler:    _AdminService_AddPeer_Handler,
+		},
+		{
+			MethodName: "RemovePeer",
+			Handler:    _AdminService_RemovePeer_Handler,
+		},
+		{
+			MethodName: "ListPeers",
+			Handler:    _AdminService_ListPeers_Handler,
+		},
+		{
+			MethodName: "ConsensusState",
+			Handler:    _AdminService_ConsensusState_Handler,
+		},
+		{
+			MethodName: "ConsensusDag",
+			Handler:    _AdminService_ConsensusDag_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
 
-func init() { proto.RegisterFile("rpc.proto", fileDescriptor_rpc_0da49ab51868ae98) }
-
-var fileDescriptor_rpc_0da49ab51868ae98 = []byte{
-	// 826 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0x4e, 0xdc, 0xe6, 0xc7, 0xa7, 0x4d, 0x5a, 0xa6, 0x69, 0xea, 0x98, 0x6c, 0xa9, 0x06, 0x21,
-	0x45, 0xa0, 0x0d, 0x90, 0xbd, 0x45, 0x48, 0x5d, 0x24, 0x4a, 0x51, 0xb4, 0x42, 0xee, 0xc2, 0x6d,
-	0x35, 0xb1, 0x67, 0x89, 0x21, 0x99, 0x31, 0x33, 0x93, 0xa5, 0x3c, 0x02, 0xcf, 0xc0, 0x03, 0xf1,
-	0x54, 0x48, 0x68, 0xc6, 0xe3, 0x9f, 0x38, 0xce, 0x46, 0xbd, 0xf3, 0xf9, 0x3f, 0xf3, 0x9d, 0x73,
-	0x3e, 0x19, 0x5c, 0x91, 0x84, 0xd3, 0x44, 0x70, 0xc5, 0x51, 0x4b, 0x24, 0x61, 0xb2, 0xc0, 0x08,
-	0xce, 0xdf, 0x70, 0xf6, 0x13, 0x11, 0x64, 0x2d, 0x03, 0xfa, 0xc7, 0x86, 0x4a, 0x85, 0xff, 0x71,
-	0xa0, 0xf7, 0x7a, 0xc5, 0xc3, 0xdf, 0x03, 0x2a, 0x13, 0xce, 0x24, 0x45, 0x08, 0x8e, 0x97, 0x44,
-	0x2e, 0xbd, 0xe6, 0x4d, 0x73, 0xe2, 0x06, 0xe6, 0x1b, 0x7d, 0x02, 0x27, 0x09, 0x11, 0x94, 0xa9,
-	0x47, 0x63, 0x72, 0x8c, 0x09, 0x52, 0xd5, 0x0f, 0xda, 0x61, 0x08, 0xed, 0x25, 0x8d, 0x7f, 0x5d,
-	0x2a, 0xef, 0xe8, 0xa6, 0x39, 0x39, 0x0e, 0xac, 0x84, 0xc6, 0xe0, 0xaa, 0x78, 0x4d, 0xa5, 0x22,
-	0xeb, 0xc4, 0x3b, 0x36, 0xa6, 0x42, 0x81, 0x46, 0xd0, 0x0d, 0x97, 0x24, 0x66, 0x8f, 0x71, 0xe4,
-	0xb5, 0x6e, 0x9a, 0x93, 0x5e, 0xd0, 0x31, 0xf2, 0x7d, 0x84, 0x3e, 0x83, 0x7e, 0xa8, 0xdb, 0x61,
-	0x72, 0x23, 0x1f, 0x05, 0xe7, 0xca, 0x6b, 0x9b, 0xa2, 0xbd, 0x5c, 0x1b, 0x70, 0xae, 0xd0, 0x0b,
-	0x00, 0xa9, 0x88, 0xa2, 0xa9, 0x4b, 0xc7, 0xb8, 0xb8, 0x46, 0x63, 0xcc, 0x23, 0xe8, 0xaa, 0x27,
-	0x1b, 0xdf, 0x35, 0xc6, 0x8e, 0x7a, 0x4a, 0x23, 0x3f, 0x85, 0x9e, 0xa0, 0x21, 0x8d, 0x13, 0x65,
-	0xed, 0xae, 0xb1, 0x9f, 0x66, 0x4a, 0xed, 0x84, 0xbf, 0x80, 0xcb, 0x3b, 0xaa, 0x0c, 0x3e, 0xaf,
-	0xff, 0xd2, 0x0f, 0xb5, 0xb0, 0xd5, 0x81, 0x84, 0xbf, 0x86, 0xab, 0x92, 0xb3, 0x79, 0x7f, 0xe6,
-	0x5e, 0xc0, 0xd3, 0x2c, 0xc3, 0x83, 0x7f, 0x81, 0xc1, 0x1d, 0x55, 0x73, 0x22, 0xd5, 0xe1, 0x19,
-	0x7c, 0x0e, 0xad, 0x85, 0x76, 0x32, 0xe8, 0x9f, 0xcc, 0x06, 0x53, 0x33, 0xd4, 0xe9, 0x56, 0x60,
-	0x90, 0xba, 0xe0, 0x4b, 0xb8, 0xd8, 0xce, 0x9b, 0x0e, 0xfb, 0xef, 0x26, 0x5c, 0xbc, 0x15, 0x84,
-	0x49, 0x12, 0xaa, 0x98, 0xb3, 0x0f, 0x96, 0x1b, 0x40, 0x8b, 0x71, 0x16, 0x52, 0x53, 0xee, 0x38,
-	0x48, 0x05, 0xed, 0xf9, 0x4e, 0xf0, 0xb5, 0x99, 0xb2, 0x1b, 0x98, 0x6f, 0x3d, 0x63, 0x41, 0xc3,
-	0x38, 0x89, 0x29, 0x53, 0x66, 0xc6, 0x6e, 0x50, 0x28, 0xf4, 0xd3, 0xc9, 0x9a, 0x6f, 0x98, 0x32,
-	0x13, 0x76, 0x03, 0x2b, 0xe1, 0x09, 0xa0, 0x3b, 0xaa, 0xde, 0x3e, 0x1d, 0xc6, 0x35, 0x34, 0xb8,
-	0xde, 0x86, 0xa1, 0x8e, 0x7b, 0x30, 0xb3, 0xcd, 0x1a, 0xf7, 0xa0, 0x43, 0xa2, 0x48, 0x50, 0x29,
-	0x6d, 0x44, 0x26, 0xee, 0x69, 0xdf, 0x83, 0xce, 0x82, 0xac, 0x88, 0xd6, 0xa7, 0x2f, 0xc8, 0x44,
-	0x3c, 0x83, 0xe1, 0x4e, 0x91, 0xb4, 0xa5, 0xbd, 0x35, 0xf0, 0x37, 0xfa, 0x9e, 0x22, 0x7a, 0xcf,
-	0xde, 0xf1, 0xbc, 0xa3, 0x3e, 0x38, 0x71, 0x64, 0x1d, 0x9d, 0x38, 0xd2, 0xd1, 0xef, 0xa9, 0x90,
-	0x31, 0x67, 0xa6, 0x93, 0x5e, 0x90, 0x89, 0xf8, 0x2b, 0x38, 0xb7, 0xe5, 0x64, 0x1e, 0x3d, 0x06,
-	0xd7, 0x26, 0xa7, 0xba, 0xda, 0x91, 0x86, 0x32, 0x57, 0xe0, 0x57, 0xf0, 0xd1, 0x1b, 0xfa, 0xa7,
-	0x0d, 0xca, 0xda, 0xbb, 0x06, 0x48, 0x88, 0x94, 0xc9, 0x52, 0x10, 0x49, 0x6d, 0xe1, 0x92, 0x06,
-	0x4f, 0x01, 0x95, 0x83, 0x0e, 0x01, 0x87, 0x57, 0x30, 0xf8, 0x99, 0xe9, 0xa5, 0xa9, 0xd4, 0xd9,
-	0x0f, 0xf5, 0x76, 0x07, 0x4e, 0xb5, 0x03, 0xe4, 0x43, 0x37, 0xda, 0x08, 0xa2, 0x37, 0xce, 0xb2,
-	0x43, 0x2e, 0xe3, 0x2f, 0xe1, 0xb2, 0x52, 0xcd, 0x36, 0x38, 0x84, 0xb6, 0xa0, 0x72, 0xb3, 0x4a,
-	0x2f, 0xa6, 0x1b, 0x58, 0x49, 0x3f, 0x67, 0xfe, 0x8c, 0xe6, 0xf0, 0x4b, 0xb8, 0x98, 0x3f, 0x23,
-	0xfd, 0x6f, 0x30, 0x7c, 0xa0, 0x2c, 0xda, 0x3a, 0x92, 0x7c, 0x33, 0xcd, 0xe6, 0x37, 0x4b, 0x9b,
-	0xdf, 0x07, 0x47, 0x71, 0xfb, 0x62, 0x47, 0xf1, 0xd2, 0xae, 0x1f, 0x95, 0x77, 0xbd, 0x58, 0xc6,
-	0xb3, 0xd2, 0x32, 0xe2, 0x97, 0x70, 0xb5, 0x53, 0x6b, 0xff, 0x41, 0xce, 0xfe, 0x3d, 0x02, 0xb8,
-	0x4d, 0xe2, 0x07, 0x2a, 0xde, 0xc7, 0x21, 0x45, 0xdf, 0x42, 0x37, 0x5b, 0x3e, 0x74, 0x65, 0xb9,
-	0xa0, 0xca, 0xee, 0x7e, 0x61, 0xd8, 0x5e, 0x53, 0xdc, 0x40, 0xdf, 0x43, 0x7f, 0x9b, 0xda, 0xd0,
-	0xd8, 0x3a, 0xd7, 0x32, 0x9e, 0x5f, 0xcb, 0x37, 0xb8, 0x81, 0x7e, 0x84, 0xf3, 0x2a, 0xeb, 0xa1,
-	0xeb, 0xdd, 0x4c, 0x65, 0x3a, 0xdc, 0x9b, 0xeb, 0x1e, 0x4e, 0xcb, 0xb4, 0x85, 0xfc, 0x22, 0x4f,
-	0x95, 0xcb, 0xfc, 0x8f, 0x6b, 0x6d, 0xa5, 0xe7, 0x9d, 0x94, 0xe8, 0x05, 0x8d, 0x0a, 0xef, 0x0a,
-	0xe5, 0xf8, 0x59, 0x91, 0x9a, 0x39, 0xe0, 0x06, 0x0a, 0xe0, 0xac, 0xc2, 0x0b, 0xe8, 0x45, 0x91,
-	0xab, 0x86, 0x2f, 0xfc, 0xeb, 0x7d, 0xe6, 0x2c, 0xe7, 0xec, 0x3f, 0x07, 0x4e, 0x6f, 0xa3, 0x75,
-	0xcc, 0x4a, 0xb3, 0xcc, 0xa8, 0xe0, 0xf0, 0x2c, 0xab, 0xa4, 0x81, 0x1b, 0xe8, 0x3b, 0x80, 0xe2,
-	0xc6, 0x91, 0x97, 0x65, 0xa8, 0x72, 0x85, 0x3f, 0xaa, 0xb1, 0xe4, 0x49, 0xe6, 0xd0, 0xdb, 0x3a,
-	0x45, 0x94, 0x21, 0x5c, 0x47, 0x07, 0xfe, 0xb8, 0xde, 0x58, 0xc6, 0xbf, 0x74, 0x77, 0x39, 0xfe,
-	0xbb, 0xb7, 0x9b, 0xe3, 0x3f, 0xaf, 0xcd, 0x13, 0xc0, 0x59, 0xe5, 0x48, 0x72, 0xfc, 0xeb, 0x0f,
-	0x35, 0xc7, 0x7f, 0xcf, 0x6d, 0xe1, 0xc6, 0xa2, 0x6d, 0xfe, 0x8a, 0x5e, 0xfd, 0x1f, 0x00, 0x00,
-	0xff, 0xff, 0xe0, 0x01, 0xe7, 0xfd, 0x22, 0x09, 0x00, 0x00,
+func init() { proto.RegisterFile("rpc.proto", fileDescriptor_rpc_41b52fd17eb5f7c5) }
+
+var fileDescriptor_rpc_41b52fd17eb5f7c5 = []byte{
+	// 2212 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x59, 0xcd, 0x72, 0x1b, 0xc7,
+	0x11, 0x26, 0x00, 0x82, 0x00, 0x9a, 0xe0, 0xdf, 0x88, 0x22, 0xc1, 0x15, 0x25, 0x33, 0x93, 0xb8,
+	0x4c, 0xc7, 0x12, 0xe5, 0x50, 0xf1, 0x21, 0x2e, 0x97, 0x2a, 0x94, 0x64, 0xc9, 0x74, 0x18, 0x87,
+	0x1e, 0x4a, 0xaa, 0xdc, 0x50, 0x83, 0xdd, 0x21, 0xb9, 0x11, 0x31, 0x0b, 0xef, 0x0c, 0x28, 0x48,
+	0xb7, 0xbc, 0x43, 0x72, 0xcd, 0x53, 0xe4, 0x92, 0x43, 0x4e, 0xc9, 0x2d, 0xaf, 0x92, 0x87, 0x48,
+	0xcd, 0xdf, 0xee, 0xec, 0x62, 0x41, 0x48, 0x95, 0x1b, 0xfa, 0x67, 0x7a, 0x7b, 0x7a, 0xbe, 0xe9,
+	0xe9, 0x6e, 0x40, 0x27, 0x1d, 0x85, 0x07, 0xa3, 0x34, 0x91, 0x09, 0x6a, 0xa6, 0xa3, 0x70, 0x34,
+	0xc0, 0x08, 0xd6, 0x7f, 0x48, 0xf8, 0x29, 0x4d, 0xe9, 0x50, 0x10, 0xf6, 0xd3, 0x98, 0x09, 0x89,
+	0xff, 0x51, 0x87, 0x95, 0x27, 0x57, 0x49, 0xf8, 0x86, 0x30, 0x31, 0x4a, 0xb8, 0x60, 0x08, 0xc1,
+	0xe2, 0x25, 0x15, 0x97, 0xbd, 0xda, 0x5e, 0x6d, 0xbf, 0x43, 0xf4, 0x6f, 0xf4, 0x09, 0x2c, 0x8f,
+	0x68, 0xca, 0xb8, 0xec, 0x6b, 0x51, 0x5d, 0x8b, 0xc0, 0xb0, 0xbe, 0x53, 0x0a, 0x5b, 0xb0, 0x74,
+	0xc9, 0xe2, 0x8b, 0x4b, 0xd9, 0x6b, 0xec, 0xd5, 0xf6, 0x17, 0x89, 0xa5, 0xd0, 0x2e, 0x74, 0x64,
+	0x3c, 0x64, 0x42, 0xd2, 0xe1, 0xa8, 0xb7, 0xa8, 0x45, 0x39, 0x03, 0xed, 0x40, 0x3b, 0xbc, 0xa4,
+	0x31, 0xef, 0xc7, 0x51, 0xaf, 0xb9, 0x57, 0xdb, 0x5f, 0x21, 0x2d, 0x4d, 0x1f, 0x47, 0xe8, 0x53,
+	0x58, 0x0d, 0x95, 0x3b, 0x5c, 0x8c, 0x45, 0x3f, 0x4d, 0x12, 0xd9, 0x5b, 0xd2, 0x1f, 0x5d, 0xc9,
+	0xb8, 0x24, 0x49, 0x24, 0xba, 0x0b, 0x20, 0x24, 0x95, 0xcc, 0xa8, 0xb4, 0xb4, 0x4a, 0x47, 0x73,
+	0xb4, 0x78, 0x07, 0xda, 0x72, 0x62, 0xd7, 0xb7, 0xb5, 0xb0, 0x25, 0x27, 0x66, 0xe5, 0xcf, 0x61,
+	0x25, 0x65, 0x21, 0x8b, 0x47, 0xd2, 0xca, 0x3b, 0x5a, 0xde, 0x75, 0x4c, 0xad, 0xb4, 0x0b, 0x9d,
+	0xf3, 0x98, 0xd3, 0xab, 0xf8, 0x3d, 0x8b, 0x7a, 0xb0, 0x57, 0xdb, 0x6f, 0x93, 0x9c, 0x81, 0xbf,
+	0x80, 0xdb, 0x2f, 0x98, 0xd4, 0xd1, 0x7b, 0xf2, 0x4e, 0x85, 0xc1, 0x06, 0xb5, 0x2a, 0x84, 0xf8,
+	0x57, 0xb0, 0xed, 0x29, 0xeb, 0xe8, 0x38, 0xf5, 0x3c, 0x78, 0x35, 0x3f, 0x78, 0xf8, 0x35, 0x6c,
+	0xbe, 0x60, 0xf2, 0x84, 0x0a, 0x39, 0xff, 0x84, 0x7e, 0x09, 0xcd, 0x81, 0x52, 0xd2, 0x67, 0xb3,
+	0x7c, 0xb8, 0x79, 0xa0, 0x8f, 0xfc, 0xa0, 0xb0, 0x90, 0x18, 0x15, 0x7c, 0x1b, 0x6e, 0x15, 0xed,
+	0x1a, 0x28, 0x7c, 0x03, 0x3b, 0x67, 0xe3, 0x81, 0x08, 0xd3, 0x78, 0xc0, 0x9e, 0xbb, 0x4d, 0x3a,
+	0x1f, 0x3f, 0x81, 0xe5, 0xf3, 0x34, 0x19, 0xf6, 0x0b, 0x8e, 0x82, 0x62, 0x99, 0xbd, 0xe0, 0xff,
+	0xd4, 0xe0, 0xd6, 0xcb, 0x94, 0x72, 0x41, 0x43, 0x19, 0x27, 0xfc, 0x46, 0x67, 0x37, 0xa1, 0xc9,
+	0x13, 0x1e, 0x32, 0xed, 0xec, 0x22, 0x31, 0x84, 0xd2, 0x54, 0xf6, 0x34, 0x82, 0x3a, 0x44, 0xff,
+	0x56, 0x07, 0x90, 0xb2, 0x30, 0x1e, 0xc5, 0x8c, 0x4b, 0x8d, 0x9f, 0x0e, 0xc9, 0x19, 0x2a, 0x70,
+	0x74, 0x98, 0x8c, 0xb9, 0xd4, 0xe8, 0xe9, 0x10, 0x4b, 0x29, 0x67, 0xaf, 0xe9, 0x55, 0x1c, 0xf5,
+	0xe9, 0xb9, 0x64, 0xa9, 0x46, 0xce, 0x22, 0x01, 0xcd, 0x3a, 0x52, 0x9c, 0x5c, 0x61, 0xcc, 0x65,
+	0x7c, 0xd5, 0x6b, 0x79, 0x0a, 0xaf, 0x14, 0x07, 0xbf, 0x82, 0x35, 0x62, 0x80, 0x70, 0xe3, 0x46,
+	0xf2, 0x93, 0xab, 0x17, 0x60, 0xbf, 0x05, 0x4b, 0x29, 0xa3, 0x22, 0xe1, 0x76, 0x33, 0x96, 0xc2,
+	0xfb, 0x80, 0x5e, 0x30, 0xf9, 0x72, 0x32, 0x1f, 0x2e, 0xa1, 0x86, 0xcb, 0x51, 0x18, 0xaa, 0x0d,
+	0x9d, 0x69, 0x40, 0x3b, 0x47, 0x7a, 0xd0, 0xa2, 0x51, 0x94, 0x32, 0x21, 0xec, 0x0a, 0x47, 0xce,
+	0x88, 0x6b, 0x0f, 0x5a, 0x03, 0x7a, 0x45, 0x15, 0xdf, 0x78, 0xe3, 0x48, 0x7c, 0x08, 0x5b, 0x53,
+	0x1f, 0x31, 0x2e, 0xcd, 0xfc, 0x06, 0xbe, 0x84, 0xcd, 0xd7, 0x2c, 0x8d, 0xcf, 0xdf, 0xfd, 0x9e,
+	0x09, 0x41, 0x2f, 0xe6, 0xaf, 0x50, 0x92, 0xa1, 0xd1, 0xd5, 0x7e, 0x75, 0x89, 0x23, 0xd5, 0xe9,
+	0x8a, 0xf8, 0x82, 0x53, 0x39, 0x4e, 0x9d, 0x6f, 0x39, 0x03, 0x3f, 0x84, 0xdb, 0xa5, 0x2f, 0xd9,
+	0x00, 0xe8, 0xe8, 0x8a, 0xf1, 0x95, 0x81, 0x61, 0x9b, 0x58, 0x0a, 0x3f, 0x84, 0x9d, 0x33, 0xc6,
+	0x23, 0x42, 0xdf, 0x16, 0x80, 0x98, 0x05, 0x39, 0xa2, 0x92, 0xea, 0x25, 0x5d, 0xa2, 0x7f, 0xe3,
+	0x03, 0x7d, 0x1c, 0xdf, 0x5e, 0xc7, 0x11, 0xe3, 0xe1, 0x07, 0xec, 0x7d, 0x04, 0xeb, 0xb9, 0xb2,
+	0x75, 0x66, 0x17, 0x3a, 0x1a, 0x37, 0x54, 0x26, 0xa9, 0xd5, 0xcf, 0x19, 0x33, 0x01, 0xe2, 0x8e,
+	0xbc, 0xe1, 0x81, 0xc9, 0x79, 0x68, 0x60, 0x6e, 0x3c, 0x3c, 0xd1, 0x57, 0x75, 0xea, 0xa3, 0x5f,
+	0x41, 0x87, 0x59, 0x9e, 0x72, 0xb2, 0xb1, 0xbf, 0x7c, 0xb8, 0x6d, 0x6f, 0x7c, 0x59, 0x97, 0xe4,
+	0x9a, 0xf8, 0x6f, 0x0d, 0xd8, 0x7e, 0xea, 0xf2, 0xa7, 0x7b, 0x07, 0xf2, 0xa0, 0x32, 0x7e, 0x11,
+	0x73, 0x66, 0x37, 0x61, 0x29, 0xf4, 0x19, 0xac, 0x0f, 0xe9, 0xa4, 0x2f, 0x27, 0xfd, 0x11, 0x4b,
+	0xfb, 0xec, 0x9a, 0x71, 0xb7, 0x97, 0x95, 0x21, 0x9d, 0xbc, 0x9c, 0x9c, 0xb2, 0xf4, 0x5b, 0xc5,
+	0x44, 0x7b, 0xd0, 0xb5, 0x8a, 0x11, 0xbb, 0xa2, 0xef, 0xec, 0x43, 0x00, 0x5a, 0xe9, 0x99, 0xe2,
+	0xa0, 0x87, 0xb0, 0x39, 0x8c, 0xb9, 0xb2, 0x13, 0x27, 0x51, 0xff, 0x3c, 0x71, 0xe6, 0xcc, 0xbb,
+	0xb0, 0x31, 0x8c, 0xf9, 0xa9, 0x16, 0x3d, 0x4f, 0xac, 0x49, 0xb5, 0x80, 0x4e, 0xa6, 0x17, 0x34,
+	0xed, 0x02, 0x3a, 0x29, 0x2d, 0x40, 0xb0, 0x28, 0xe3, 0xf0, 0x8d, 0xbd, 0xf1, 0xfa, 0x37, 0xda,
+	0x87, 0x75, 0xbd, 0xaa, 0x1f, 0xd2, 0xf0, 0x92, 0xf5, 0x45, 0xfc, 0x9e, 0xd9, 0x0b, 0xbf, 0xaa,
+	0xf9, 0x4f, 0x15, 0xfb, 0x2c, 0x7e, 0xcf, 0xd0, 0x7d, 0x40, 0x46, 0x33, 0x35, 0x48, 0x30, 0xba,
+	0x6d, 0xad, 0x6b, 0x6c, 0x58, 0x88, 0x68, 0xed, 0x5f, 0xc0, 0xaa, 0x7a, 0x5b, 0x3c, 0xab, 0x1d,
+	0xad, 0xd9, 0x95, 0x13, 0x51, 0xb0, 0xa9, 0xb5, 0x92, 0xe1, 0x30, 0x96, 0x92, 0x45, 0x46, 0x13,
+	0x8c, 0x4d, 0xa5, 0xe9, 0x04, 0x4a, 0x1b, 0x7f, 0xa3, 0x5e, 0xe8, 0x88, 0x1d, 0xf3, 0xf3, 0x24,
+	0x3b, 0x98, 0x55, 0xa8, 0xc7, 0x91, 0x3d, 0x94, 0x7a, 0x1c, 0x29, 0x78, 0x5e, 0xb3, 0x54, 0xc4,
+	0x09, 0xd7, 0xe7, 0xb0, 0x42, 0x1c, 0x89, 0xbf, 0x84, 0x75, 0x7b, 0x97, 0x85, 0x0f, 0x4f, 0x8b,
+	0x5e, 0x8b, 0x94, 0x0e, 0xc9, 0x19, 0xf8, 0x11, 0x6c, 0xfc, 0xc0, 0xde, 0xda, 0x45, 0x0e, 0xff,
+	0xf7, 0x00, 0x46, 0x54, 0x88, 0xd1, 0x65, 0x4a, 0x85, 0x43, 0x83, 0xc7, 0x51, 0xb7, 0xc6, 0x5f,
+	0x34, 0x2f, 0x2b, 0xe1, 0x7f, 0xd7, 0x60, 0xf3, 0x15, 0x57, 0x2f, 0x4d, 0xe9, 0x43, 0x33, 0x97,
+	0x94, 0x5c, 0xa8, 0x97, 0x5d, 0x40, 0x01, 0xb4, 0xa3, 0x71, 0x4a, 0xd5, 0xfd, 0xb6, 0x38, 0xcb,
+	0x68, 0x55, 0x12, 0x28, 0xd0, 0xd8, 0x87, 0xc1, 0xbe, 0x19, 0x43, 0x3a, 0x39, 0xd2, 0x0c, 0x65,
+	0x3a, 0x7b, 0x40, 0x44, 0xaf, 0xa9, 0x23, 0xe2, 0x71, 0xd0, 0x36, 0xb4, 0x0c, 0x8c, 0x85, 0x45,
+	0xd1, 0x92, 0x46, 0xb0, 0x50, 0xe9, 0xa8, 0xb4, 0x8b, 0x39, 0xe9, 0xe8, 0x00, 0xd0, 0xc9, 0x47,
+	0x6c, 0x1a, 0x3f, 0x80, 0x5b, 0x27, 0x1f, 0x61, 0xfe, 0x4f, 0xb0, 0xa5, 0xb2, 0x5d, 0x75, 0xaa,
+	0xd3, 0x0f, 0x69, 0xcd, 0x7b, 0x48, 0x57, 0xa1, 0x2e, 0x13, 0x1b, 0xc9, 0xba, 0x4c, 0xbc, 0xa7,
+	0xb3, 0x51, 0x78, 0x3a, 0xb3, 0x27, 0x64, 0xcd, 0x7b, 0x42, 0xf0, 0x03, 0xd8, 0x9e, 0xfa, 0xd6,
+	0xec, 0x67, 0x11, 0x7f, 0x07, 0xe8, 0x2c, 0xbe, 0xe0, 0xff, 0xff, 0x0b, 0x81, 0x1f, 0xc1, 0xad,
+	0x82, 0xa5, 0x1c, 0xd5, 0xf9, 0xc3, 0x51, 0x2b, 0x3f, 0x1c, 0xff, 0xaa, 0xc1, 0x9a, 0x39, 0x2a,
+	0x16, 0xd9, 0x68, 0xde, 0xf0, 0x71, 0x95, 0xf8, 0x26, 0xa3, 0x38, 0x35, 0xdf, 0x6e, 0x10, 0x4b,
+	0x95, 0x70, 0xd4, 0xb8, 0x19, 0x47, 0x8b, 0x37, 0xe1, 0xa8, 0xe9, 0xe3, 0x48, 0x45, 0x58, 0x8c,
+	0x54, 0x16, 0x33, 0x05, 0xad, 0x21, 0xd0, 0x3a, 0x34, 0x94, 0xaa, 0x49, 0x4c, 0xea, 0x27, 0xfe,
+	0x1e, 0x36, 0x4f, 0x62, 0x21, 0xdd, 0x46, 0xb2, 0xbd, 0x1f, 0x42, 0x9b, 0xda, 0x5b, 0x6e, 0x53,
+	0xff, 0x96, 0x4d, 0xfd, 0xa5, 0x3d, 0x93, 0x4c, 0x0f, 0x3f, 0x84, 0x5b, 0x84, 0x5d, 0x27, 0x6f,
+	0x98, 0x51, 0x99, 0x8f, 0xc5, 0x5f, 0xc3, 0x66, 0x71, 0xc1, 0x07, 0xa5, 0x93, 0x57, 0x00, 0x4f,
+	0x28, 0xe7, 0x2c, 0x3a, 0x65, 0x2c, 0x55, 0x11, 0xe0, 0x49, 0xc4, 0xfa, 0x59, 0xf6, 0x5a, 0x52,
+	0xe4, 0xb1, 0xce, 0x60, 0xc9, 0xf9, 0x39, 0x73, 0x85, 0x4a, 0x87, 0x38, 0x52, 0xc5, 0xc6, 0x54,
+	0x64, 0x0d, 0x7d, 0x14, 0x86, 0xc0, 0xbf, 0x81, 0x75, 0x15, 0x89, 0x27, 0x94, 0xe7, 0x79, 0xed,
+	0x53, 0x58, 0x1c, 0x50, 0xee, 0x22, 0xb0, 0xe1, 0xca, 0xdd, 0xec, 0xeb, 0x44, 0x8b, 0xf1, 0x17,
+	0xb0, 0xfe, 0xf4, 0x8a, 0xd1, 0xd4, 0xac, 0x35, 0xbb, 0x9e, 0xe5, 0x17, 0x7e, 0x00, 0x1b, 0x9e,
+	0x72, 0x9e, 0xd7, 0x42, 0xc5, 0x64, 0x46, 0x7b, 0x85, 0x38, 0x12, 0x3f, 0x86, 0xd5, 0xa3, 0xc8,
+	0x7c, 0x2c, 0xbf, 0x78, 0xca, 0x94, 0xbb, 0x0b, 0xea, 0xb7, 0x5a, 0x2f, 0xd3, 0xb1, 0x90, 0x2c,
+	0xd2, 0x9b, 0x6d, 0x13, 0x47, 0xe2, 0xcf, 0x61, 0x2d, 0x5b, 0x3f, 0xe7, 0xae, 0xdf, 0x87, 0x0d,
+	0xc2, 0x86, 0xc9, 0x35, 0xf3, 0xbf, 0x36, 0x73, 0x1f, 0xf7, 0x01, 0xf9, 0xda, 0x73, 0x6c, 0xff,
+	0xb3, 0x06, 0x6d, 0xa5, 0xa8, 0x1e, 0x9d, 0xd9, 0x67, 0xe6, 0xb6, 0x56, 0xf7, 0xb6, 0xb6, 0x05,
+	0x4b, 0x62, 0x3c, 0xe0, 0x2c, 0xcb, 0x21, 0x86, 0x52, 0x5b, 0x8e, 0xf9, 0x20, 0x19, 0xf3, 0x48,
+	0xa7, 0xdf, 0x36, 0x71, 0xa4, 0x82, 0x4f, 0x98, 0x70, 0xce, 0x42, 0x15, 0x8e, 0xa6, 0x96, 0xe5,
+	0x0c, 0x95, 0xd5, 0x53, 0x26, 0x58, 0x7a, 0xcd, 0x22, 0x7d, 0x39, 0xda, 0x24, 0xa3, 0xfd, 0x30,
+	0xb6, 0x8a, 0x61, 0xfc, 0x1a, 0x36, 0x14, 0x3a, 0xd4, 0x16, 0x7c, 0x78, 0x34, 0x47, 0x8a, 0x61,
+	0xf1, 0xb1, 0x66, 0xf1, 0xe1, 0xf6, 0x49, 0x8c, 0x14, 0xa7, 0xb0, 0xfa, 0xda, 0xd5, 0x6a, 0xba,
+	0xfe, 0x55, 0xf7, 0xf0, 0x3a, 0xdb, 0xbc, 0xfa, 0x39, 0xb3, 0x84, 0xeb, 0x41, 0x6b, 0xc4, 0x78,
+	0x14, 0xf3, 0x0b, 0xfb, 0x04, 0x39, 0x52, 0xed, 0xf2, 0x92, 0xd1, 0x54, 0x0e, 0x18, 0x35, 0x0f,
+	0x50, 0x83, 0xe4, 0x0c, 0xfc, 0x97, 0x3a, 0xb4, 0x9f, 0xd1, 0x8b, 0xac, 0x60, 0x99, 0x6a, 0x2a,
+	0xac, 0x0b, 0xf5, 0x2a, 0x17, 0x8a, 0xdd, 0x75, 0x17, 0x6a, 0xdc, 0x56, 0x4f, 0x35, 0x6e, 0x8a,
+	0x9f, 0x21, 0xd3, 0x71, 0x6d, 0x10, 0xfd, 0x5b, 0x5d, 0xa8, 0x54, 0x1f, 0x84, 0x8a, 0x67, 0x93,
+	0x18, 0x42, 0xb9, 0xfe, 0x36, 0x96, 0x5c, 0xdd, 0x7b, 0x1b, 0x4c, 0x4b, 0xa2, 0x3d, 0x58, 0xb6,
+	0xa5, 0x0a, 0x1d, 0x5c, 0x99, 0xda, 0xa7, 0x49, 0x7c, 0x96, 0xb6, 0xc8, 0x68, 0xf4, 0x4e, 0x57,
+	0x3b, 0x6d, 0x62, 0x08, 0x97, 0xbe, 0x40, 0xdf, 0x10, 0xf5, 0x53, 0x87, 0x47, 0xcf, 0x07, 0x44,
+	0x6f, 0x59, 0xe7, 0x09, 0x47, 0x2a, 0x0b, 0xe7, 0x49, 0xfa, 0x46, 0xf4, 0xba, 0x9a, 0x6f, 0x08,
+	0xfc, 0x23, 0x00, 0x51, 0xce, 0x99, 0x63, 0xc8, 0xfc, 0xae, 0xf9, 0x7e, 0x3f, 0x80, 0x8e, 0x75,
+	0x94, 0x89, 0x5e, 0xbd, 0x70, 0xb2, 0x2e, 0xa2, 0x24, 0xd7, 0xc0, 0x7f, 0xad, 0xc3, 0x56, 0x56,
+	0xee, 0x16, 0x7b, 0xa8, 0x0f, 0x3f, 0x66, 0x1d, 0xe3, 0x86, 0x8b, 0xf1, 0x57, 0x00, 0x59, 0x71,
+	0x6f, 0xb2, 0xfe, 0xf2, 0xe1, 0x6d, 0xeb, 0x42, 0x11, 0x49, 0xc4, 0x53, 0x44, 0x3f, 0x03, 0x55,
+	0x15, 0xf6, 0x69, 0x18, 0xb2, 0x91, 0x83, 0xfe, 0x22, 0x59, 0x96, 0x13, 0x71, 0x64, 0x59, 0xaa,
+	0x25, 0x55, 0x2a, 0x0e, 0x52, 0xb6, 0x67, 0x95, 0x13, 0x71, 0x6a, 0x38, 0xea, 0x3d, 0x52, 0x0a,
+	0x3f, 0x8d, 0xd9, 0xd8, 0x5e, 0x02, 0x35, 0x4b, 0x99, 0x88, 0x1f, 0x35, 0x03, 0x7d, 0x0e, 0x4b,
+	0x3a, 0x48, 0xa2, 0xd7, 0x2e, 0xa4, 0xc4, 0x3c, 0xa8, 0xc4, 0x2a, 0xa8, 0x42, 0x23, 0x0b, 0xcb,
+	0x33, 0x7a, 0xe1, 0x8d, 0x21, 0xac, 0x05, 0x93, 0xe8, 0x9c, 0xfa, 0x9f, 0x6b, 0xb0, 0x59, 0xd4,
+	0xff, 0xe8, 0x20, 0xe6, 0xa6, 0x1b, 0xbe, 0x69, 0xf4, 0x19, 0x2c, 0xe9, 0xba, 0xda, 0x85, 0x72,
+	0xea, 0x34, 0xad, 0xf8, 0xf0, 0xbf, 0x2d, 0x80, 0xa3, 0x51, 0x7c, 0xc6, 0xd2, 0xeb, 0x38, 0x64,
+	0xe8, 0x31, 0xb4, 0x5d, 0x9d, 0x8c, 0x5c, 0xe3, 0x53, 0x1e, 0x6d, 0x05, 0xb9, 0xa0, 0x58, 0x51,
+	0xe3, 0x05, 0xf4, 0x1c, 0x56, 0x8b, 0x93, 0x1b, 0xb4, 0x6b, 0x95, 0x2b, 0x07, 0x3a, 0x41, 0xe5,
+	0x38, 0x05, 0x2f, 0xa0, 0xef, 0x61, 0xbd, 0x3c, 0xd4, 0x41, 0xf7, 0xa6, 0x2d, 0xf9, 0xd3, 0x9e,
+	0x99, 0xb6, 0x8e, 0xa1, 0xeb, 0x4f, 0x65, 0x50, 0x90, 0xdb, 0x29, 0x8f, 0x6a, 0x82, 0x3b, 0x95,
+	0x32, 0x6f, 0x7b, 0xcb, 0xde, 0x98, 0x01, 0xed, 0xe4, 0xda, 0xa5, 0xd1, 0x43, 0xe0, 0x3e, 0x52,
+	0x51, 0xd9, 0xe1, 0x05, 0xf4, 0x54, 0xbb, 0xf4, 0x72, 0x62, 0x47, 0x21, 0x37, 0x19, 0x72, 0x35,
+	0x48, 0x69, 0x6a, 0x82, 0x17, 0x10, 0x81, 0xb5, 0xd2, 0x90, 0x01, 0xdd, 0xcd, 0xed, 0x54, 0x0c,
+	0x1f, 0x82, 0x7b, 0xb3, 0xc4, 0x99, 0xcd, 0x13, 0x58, 0x29, 0x8c, 0x06, 0x90, 0x0b, 0x48, 0xd5,
+	0x68, 0x22, 0xd8, 0xad, 0x16, 0x66, 0xd6, 0xfe, 0x08, 0x68, 0x7a, 0x6e, 0x80, 0xf6, 0xec, 0xaa,
+	0x99, 0x23, 0x85, 0xe0, 0x9e, 0xa7, 0x51, 0x1d, 0x40, 0x73, 0x10, 0xae, 0x25, 0xf7, 0xe3, 0x57,
+	0x1a, 0x3a, 0x04, 0x41, 0x95, 0x28, 0xb3, 0xf3, 0x07, 0x3d, 0xa8, 0x28, 0xb5, 0xee, 0xb3, 0x91,
+	0xef, 0x1c, 0x9b, 0xd1, 0xeb, 0xe3, 0x05, 0xf4, 0x3b, 0xd8, 0x78, 0xc1, 0x64, 0x36, 0xe5, 0x33,
+	0x88, 0x9b, 0x69, 0x6f, 0x0e, 0xdc, 0x4e, 0x01, 0x4d, 0x0f, 0x0e, 0xf3, 0xf8, 0xcd, 0x9a, 0x29,
+	0xce, 0xba, 0x09, 0x5f, 0xd6, 0x0e, 0xff, 0xde, 0x86, 0xee, 0x51, 0x34, 0x8c, 0xb9, 0x77, 0xe1,
+	0x5d, 0x6b, 0x3b, 0xff, 0xc2, 0x97, 0x9b, 0x60, 0x8d, 0x64, 0xc8, 0x7b, 0x56, 0xd4, 0x73, 0x16,
+	0xca, 0xbd, 0x6f, 0xb0, 0x53, 0x21, 0xf1, 0x51, 0x57, 0xe8, 0x00, 0x33, 0xd4, 0x55, 0x75, 0xb7,
+	0xc1, 0x6e, 0xb5, 0xd0, 0xc7, 0x86, 0xd7, 0xee, 0x65, 0xd8, 0x98, 0x6e, 0x19, 0x83, 0xa0, 0x4a,
+	0xe4, 0xdf, 0xaf, 0x12, 0x00, 0xb3, 0xfb, 0x55, 0xdd, 0x1f, 0x7e, 0x18, 0x6e, 0xbd, 0xb6, 0x2b,
+	0xf3, 0x6d, 0xba, 0xa9, 0x0b, 0x82, 0x2a, 0x91, 0x67, 0xa7, 0xeb, 0xf7, 0x30, 0xf3, 0x11, 0x56,
+	0xd5, 0xf1, 0x98, 0xdc, 0xe8, 0xb7, 0x23, 0x59, 0x6e, 0xac, 0x68, 0x6a, 0x82, 0x3b, 0x95, 0xb2,
+	0xcc, 0xd4, 0x63, 0x68, 0xbb, 0x66, 0x62, 0x3e, 0x92, 0xca, 0x6d, 0x07, 0x5e, 0x40, 0xbf, 0x85,
+	0x4e, 0xd6, 0x24, 0x64, 0x06, 0xca, 0x3d, 0x46, 0xd0, 0x9b, 0x16, 0x64, 0x16, 0xbe, 0x86, 0x96,
+	0xad, 0xfb, 0x91, 0x2b, 0x1d, 0x8a, 0x7d, 0x44, 0xb0, 0x55, 0x66, 0xfb, 0x38, 0xce, 0x4b, 0xfb,
+	0x0c, 0xc7, 0x53, 0xbd, 0x41, 0xb0, 0x53, 0x21, 0xf1, 0xb7, 0x90, 0x55, 0xcc, 0xb3, 0x63, 0xd0,
+	0xf3, 0x62, 0x50, 0x28, 0xae, 0xf5, 0xbb, 0xb7, 0x5a, 0x2c, 0xac, 0x66, 0x9b, 0xb9, 0x5b, 0xce,
+	0x45, 0xe5, 0x5c, 0x7e, 0x0c, 0x5d, 0xbf, 0xba, 0xc8, 0xce, 0xb6, 0xa2, 0x44, 0x09, 0xee, 0x54,
+	0xca, 0x9c, 0xa9, 0xc1, 0x92, 0xfe, 0xbb, 0xeb, 0xd1, 0xff, 0x06, 0x00, 0x22, 0xd3, 0x6a, 0x6b,
+	0xfb, 0x1a, 0x00, 0x00,
 }
@@ -52,6 +52,11 @@ func NewServer(conf *config.Config, node core.INode) *Server {
 		core:      node.Core(),
 		rpcServer: rpc,
 	}
+	// light node has no core, only api is served
+	if ln, ok := node.(lightNode); ok && ln.Light() != nil {
+		rpcpb.RegisterApiServiceServer(rpc, newLightAPIService(srv, ln.Light()))
+		return srv
+	}
 	rpcpb.RegisterAdminServiceServer(rpc, newAdminService(srv))
 	rpcpb.RegisterApiServiceServer(rpc, newAPIService(srv))
 
@@ -24,6 +24,7 @@ import (
 	"net"
 
 	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/light"
 )
 
 type RPCServer interface {
@@ -34,3 +35,8 @@ type RPCServer interface {
 	Start() error
 	Stop()
 }
+
+// node running light client instead of core
+type lightNode interface {
+	Light() *light.Client
+}
@@ -282,7 +282,7 @@ func dftConfig(nodeDir string, portShift uint16) *config.Config {
 			LocalDhtPort: p2pCfg.DftDhtPort + portShift + 32,
 			NatType:      "none",
 		},
-		Rpc: &config.RpcConfig{},
+		Rpc: &config.RpcConfig{RpcListen: []string{"127.0.0.1:0"}},
 	}
 
 	return cfg
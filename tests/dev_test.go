@@ -0,0 +1,121 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/node"
+	"github.com/yeeco/gyee/p2p/simnet"
+)
+
+// single node chain with dev engine seals every tx submitted to it
+func TestDevChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yee-dev-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys := genKeys(1)
+	genesis, err := genGenesis(keys)
+	if err != nil {
+		t.Fatalf("genGenesis() %v", err)
+	}
+	cfg := dftConfig(dir, 0)
+	cfg.Chain.Key = keys[0]
+	cfg.Chain.Engine = core.EngineDev
+	cfg.Rpc.RpcListen = []string{"127.0.0.1:0"}
+	net := simnet.New(simnet.Config{Realtime: true})
+	defer net.Close()
+	n, err := node.NewNodeWithGenesis(cfg, genesis, net.NewService("dev"))
+	if err != nil {
+		t.Fatalf("NewNodeWithGenesis() %v", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatalf("Start() %v", err)
+	}
+	defer n.Stop()
+
+	signer, err := n.Core().GetMinerSigner()
+	if err != nil {
+		t.Fatalf("GetMinerSigner() %v", err)
+	}
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx := core.NewTransaction(testChainID, nonce, n.Core().MinerAddr().CommonAddress(), big.NewInt(100))
+		if err := tx.Sign(signer); err != nil {
+			t.Fatalf("Sign() %v", err)
+		}
+		if err := n.Core().TxBroadcast(tx); err != nil {
+			t.Fatalf("TxBroadcast() %v", err)
+		}
+		// a block for each tx
+		deadline := time.Now().Add(10 * time.Second)
+		for n.Core().Chain().GetTxByHash(*tx.Hash()) == nil {
+			if time.Now().After(deadline) {
+				t.Fatalf("tx %d not sealed, height %d", nonce, n.Core().Chain().CurrentBlockHeight())
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		if h := n.Core().Chain().CurrentBlockHeight(); h != nonce+1 {
+			t.Fatalf("height %d after tx %d", h, nonce)
+		}
+	}
+	chain := n.Core().Chain()
+
+	// tx held in pool till block time reaches validAfter
+	validAfter := uint64(time.Now().Add(1500*time.Millisecond).UnixNano() / int64(time.Millisecond))
+	tx := core.NewTransaction(testChainID, 3, n.Core().MinerAddr().CommonAddress(), big.NewInt(100))
+	tx.SetValidity(validAfter, 0)
+	if err := tx.Sign(signer); err != nil {
+		t.Fatalf("Sign() %v", err)
+	}
+	if err := n.Core().TxBroadcast(tx); err != nil {
+		t.Fatalf("TxBroadcast() %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	if chain.GetTxByHash(*tx.Hash()) != nil {
+		t.Fatalf("tx sealed before validAfter")
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for chain.GetTxByHash(*tx.Hash()) == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("held tx not sealed, height %d", chain.CurrentBlockHeight())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if b := chain.LastBlock(); b.Time() < validAfter {
+		t.Fatalf("tx sealed in block %d time %d before %d", b.Number(), b.Time(), validAfter)
+	}
+
+	// single validator signature finalizes its blocks
+	if h := chain.FinalizedHeight(); h != chain.CurrentBlockHeight() {
+		t.Fatalf("finalized %d, height %d", h, chain.CurrentBlockHeight())
+	}
+	if !chain.IsFinalized(chain.LastBlock()) {
+		t.Fatalf("last block not finalized")
+	}
+	if err := chain.Reset(); err != core.ErrBlockRewindFinalized {
+		t.Fatalf("Reset() %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/node"
+	"github.com/yeeco/gyee/p2p/simnet"
+)
+
+// light node follows a dev chain by headers, and proves balances with a full peer
+func TestLightFollowsDevChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yee-light-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys := genKeys(1)
+	genesis, err := genGenesis(keys)
+	if err != nil {
+		t.Fatalf("genGenesis() %v", err)
+	}
+	net := simnet.New(simnet.Config{Realtime: true})
+	defer net.Close()
+
+	cfg := dftConfig(filepath.Join(dir, "full"), 0)
+	cfg.Chain.Key = keys[0]
+	cfg.Chain.Engine = core.EngineDev
+	full, err := node.NewNodeWithGenesis(cfg, genesis, net.NewService("full"))
+	if err != nil {
+		t.Fatalf("NewNodeWithGenesis() full %v", err)
+	}
+	if err := full.Start(); err != nil {
+		t.Fatalf("Start() full %v", err)
+	}
+	defer full.Stop()
+
+	lightCfg := dftConfig(filepath.Join(dir, "light"), 1)
+	lightCfg.Chain.Mine = false
+	lightCfg.Chain.Light = true
+	ln, err := node.NewNodeWithGenesis(lightCfg, genesis, net.NewService("light"))
+	if err != nil {
+		t.Fatalf("NewNodeWithGenesis() light %v", err)
+	}
+	if err := ln.Start(); err != nil {
+		t.Fatalf("Start() light %v", err)
+	}
+	defer ln.Stop()
+	client := ln.Light()
+
+	signer, err := full.Core().GetMinerSigner()
+	if err != nil {
+		t.Fatalf("GetMinerSigner() %v", err)
+	}
+	recipient := common.BytesToAddress([]byte("light test recipient"))
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx := core.NewTransaction(testChainID, nonce, &recipient, big.NewInt(100))
+		if err := tx.Sign(signer); err != nil {
+			t.Fatalf("Sign() %v", err)
+		}
+		if err := full.Core().TxBroadcast(tx); err != nil {
+			t.Fatalf("TxBroadcast() %v", err)
+		}
+		deadline := time.Now().Add(10 * time.Second)
+		for full.Core().Chain().GetTxByHash(*tx.Hash()) == nil {
+			if time.Now().After(deadline) {
+				t.Fatalf("tx %d not sealed", nonce)
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for client.Head().Number() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("light head %d, want 3", client.Head().Number())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if want := full.Core().Chain().GetBlockByNumber(3).Hash(); client.Head().Hash() != want {
+		t.Fatalf("light head %x, want %x", client.Head().Hash(), want)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	account, err := client.GetAccount(ctx, recipient)
+	if err != nil {
+		t.Fatalf("GetAccount() %v", err)
+	}
+	if account == nil || account.Balance().Cmp(big.NewInt(300)) != 0 {
+		t.Fatalf("recipient account %v, want balance 300", account)
+	}
+	missing := common.BytesToAddress([]byte("light test missing"))
+	if account, err := client.GetAccount(ctx, missing); err != nil || account != nil {
+		t.Fatalf("missing account %v, %v", account, err)
+	}
+}
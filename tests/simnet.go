@@ -0,0 +1,254 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/common"
+	"github.com/yeeco/gyee/core"
+	"github.com/yeeco/gyee/log"
+	"github.com/yeeco/gyee/node"
+	"github.com/yeeco/gyee/p2p"
+	"github.com/yeeco/gyee/p2p/simnet"
+)
+
+// cluster of full nodes sharing one genesis on a simulated network, all of
+// them are validators
+type simCluster struct {
+	t       *testing.T
+	net     *simnet.Network
+	dir     string
+	genesis *core.Genesis
+	keys    [][]byte
+	lock    sync.Mutex
+	nodes   []*node.Node // nil if crashed
+	nonces  []uint64
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newSimCluster(t *testing.T, numNodes uint, netCfg simnet.Config) *simCluster {
+	tmpDir, err := ioutil.TempDir("", "yee-simnet-")
+	if err != nil {
+		t.Fatalf("TempDir() %v", err)
+	}
+	keys := genKeys(numNodes)
+	genesis, err := genGenesis(keys)
+	if err != nil {
+		t.Fatalf("genGenesis() %v", err)
+	}
+	c := &simCluster{
+		t:       t,
+		net:     simnet.New(netCfg),
+		dir:     tmpDir,
+		genesis: genesis,
+		keys:    keys,
+		nodes:   make([]*node.Node, numNodes),
+		nonces:  make([]uint64, numNodes),
+		quit:    make(chan struct{}),
+	}
+	for i := range keys {
+		c.startNode(i)
+	}
+	return c
+}
+
+func simNodeID(i int) string {
+	return "sim" + strconv.Itoa(i)
+}
+
+func (c *simCluster) startNode(i int) {
+	cfg := dftConfig(filepath.Join(c.dir, strconv.Itoa(i)), uint16(i))
+	cfg.Chain.Key = c.keys[i]
+	cfg.Rpc.RpcListen = []string{"127.0.0.1:0"}
+	n, err := node.NewNodeWithGenesis(cfg, c.genesis, c.net.NewService(simNodeID(i)))
+	if err != nil {
+		c.t.Fatalf("node %d: NewNodeWithGenesis() %v", i, err)
+	}
+	if err := n.Start(); err != nil {
+		c.t.Fatalf("node %d: start %v", i, err)
+	}
+	c.lock.Lock()
+	c.nodes[i] = n
+	c.lock.Unlock()
+}
+
+// start a node which does not mine, it only follows the chain of the
+// cluster, the caller stops it before the cluster
+func (c *simCluster) startFollower(id string) *node.Node {
+	cfg := dftConfig(filepath.Join(c.dir, id), 0)
+	cfg.Chain.Mine = false
+	cfg.P2p.Validator = false
+	cfg.Rpc.RpcListen = []string{"127.0.0.1:0"}
+	n, err := node.NewNodeWithGenesis(cfg, c.genesis, c.net.NewService(id))
+	if err != nil {
+		c.t.Fatalf("follower %s: NewNodeWithGenesis() %v", id, err)
+	}
+	if err := n.Start(); err != nil {
+		c.t.Fatalf("follower %s: start %v", id, err)
+	}
+	return n
+}
+
+// crash node, it's cut off the network and stopped, data kept for restart
+func (c *simCluster) crash(i int) {
+	c.net.Crash(simNodeID(i))
+	c.lock.Lock()
+	n := c.nodes[i]
+	c.nodes[i] = nil
+	c.lock.Unlock()
+	if n != nil {
+		_ = n.Stop()
+	}
+}
+
+// restart node crashed with data it had
+func (c *simCluster) restart(i int) {
+	c.net.Recover(simNodeID(i))
+	c.startNode(i)
+}
+
+func (c *simCluster) stop() {
+	close(c.quit)
+	c.wg.Wait()
+	for i := range c.nodes {
+		c.crash(i)
+	}
+	c.net.Close()
+	_ = os.RemoveAll(c.dir)
+}
+
+// send transfers between live nodes every interval until cluster stopped,
+// tetris only outputs blocks when there are txs to pack
+func (c *simCluster) genTxs(interval time.Duration) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.quit:
+				return
+			case <-ticker.C:
+			}
+			c.sendTxs()
+		}
+	}()
+}
+
+// holds lock so that no node is stopped while txs fed to it
+func (c *simCluster) sendTxs() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	nodes := c.nodes
+	for i, from := range nodes {
+		if from == nil {
+			continue
+		}
+		signer, err := from.Core().GetMinerSigner()
+		if err != nil {
+			c.t.Errorf("node %d: signer failed %v", i, err)
+			return
+		}
+		to := nodes[(i+1)%len(nodes)]
+		if to == nil || to == from {
+			continue
+		}
+		tx := core.NewTransaction(testChainID, c.nonces[i], to.Core().MinerAddr().CommonAddress(), big.NewInt(100))
+		if err := tx.Sign(signer); err != nil {
+			c.t.Errorf("node %d: sign failed %v", i, err)
+			continue
+		}
+		data, err := tx.Encode()
+		if err != nil {
+			c.t.Errorf("node %d: encode tx failed %v", i, err)
+			continue
+		}
+		c.nonces[i]++
+		msg := &p2p.Message{
+			MsgType: p2p.MessageTypeTx,
+			Data:    data,
+		}
+		_ = from.P2pService().DhtSetValue(tx.Hash()[:], data)
+		_ = from.P2pService().BroadcastMessage(*msg)
+		from.Core().FakeP2pRecv(msg)
+	}
+}
+
+func (c *simCluster) live() []*node.Node {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	live := make([]*node.Node, 0, len(c.nodes))
+	for _, n := range c.nodes {
+		if n != nil {
+			live = append(live, n)
+		}
+	}
+	return live
+}
+
+// min height of live nodes
+func (c *simCluster) height() uint64 {
+	var min uint64
+	for i, n := range c.live() {
+		h := n.Core().Chain().CurrentBlockHeight()
+		if i == 0 || h < min {
+			min = h
+		}
+	}
+	return min
+}
+
+// wait all live nodes reach height
+func (c *simCluster) waitHeight(height uint64, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if c.height() >= height {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	log.Info("simnet wait height timeout", "want", height, "got", c.height(), "stats", c.net.Stats())
+	return false
+}
+
+// all live nodes have the same blocks up to the min height of them
+func (c *simCluster) checkAgreement() {
+	nodes := c.live()
+	top := c.height()
+	for height := uint64(1); height <= top; height++ {
+		hashes := make(map[common.Hash]int)
+		for _, n := range nodes {
+			if b := n.Core().Chain().GetBlockByNumber(height); b != nil {
+				hashes[b.Hash()]++
+			}
+		}
+		if len(hashes) != 1 {
+			c.t.Errorf("chains disagree at height %d: %v", height, hashes)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2019 gyee authors
+//
+// This file is part of the gyee library.
+//
+// The gyee library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The gyee library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the gyee library.  If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yeeco/gyee/p2p/simnet"
+)
+
+var simLink = simnet.Link{
+	Latency: 20 * time.Millisecond,
+	Jitter:  30 * time.Millisecond,
+	Loss:    0.02,
+	Reorder: 0.05,
+}
+
+// link without loss, for requests expected to be answered in time
+var simLinkReliable = simnet.Link{
+	Latency: 20 * time.Millisecond,
+	Jitter:  30 * time.Millisecond,
+}
+
+func TestSimnetAgreement(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	c := newSimCluster(t, 4, simnet.Config{Seed: 1, Realtime: true, Link: simLink})
+	defer c.stop()
+	c.genTxs(100 * time.Millisecond)
+	if !c.waitHeight(3, 60*time.Second) {
+		t.Fatalf("height %d, want 3", c.height())
+	}
+	c.checkAgreement()
+
+	// consensus inspection on a running engine
+	n := c.live()[0]
+	state, err := n.Core().ConsensusState()
+	if err != nil {
+		t.Fatalf("ConsensusState() %v", err)
+	}
+	if state.H < 3 || len(state.Validators) != 4 || state.Vid != n.Core().MinerAddr().String() {
+		t.Errorf("unexpected state h %d validators %d vid %s", state.H, len(state.Validators), state.Vid)
+	}
+	dag, err := n.Core().ConsensusDag(0)
+	if err != nil {
+		t.Fatalf("ConsensusDag() %v", err)
+	}
+	for _, ev := range dag.Events {
+		if ev.N <= dag.H {
+			t.Errorf("event %s:%d below height %d", ev.Vid, ev.N, dag.H)
+		}
+	}
+}
+
+func TestSimnetCrash(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	c := newSimCluster(t, 4, simnet.Config{Seed: 2, Realtime: true, Link: simLink})
+	defer c.stop()
+	c.genTxs(100 * time.Millisecond)
+	if !c.waitHeight(2, 60*time.Second) {
+		t.Fatalf("height %d, want 2", c.height())
+	}
+	// 3 of 4 validators still reach the signature threshold
+	c.crash(3)
+	target := c.height() + 2
+	if !c.waitHeight(target, 60*time.Second) {
+		t.Fatalf("height %d with node crashed, want %d", c.height(), target)
+	}
+	c.checkAgreement()
+}
+
+func TestSimnetRestart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	c := newSimCluster(t, 4, simnet.Config{Seed: 3, Realtime: true, Link: simLink})
+	defer c.stop()
+	c.genTxs(100 * time.Millisecond)
+	if !c.waitHeight(2, 60*time.Second) {
+		t.Fatalf("height %d, want 2", c.height())
+	}
+	// rolling restart, the validator resumes its events instead of forking them
+	c.crash(3)
+	target := c.height() + 1
+	if !c.waitHeight(target, 60*time.Second) {
+		t.Fatalf("height %d with node crashed, want %d", c.height(), target)
+	}
+	c.restart(3)
+	target = c.height() + 2
+	if !c.waitHeight(target, 90*time.Second) {
+		t.Fatalf("height %d after restart, want %d", c.height(), target)
+	}
+	c.checkAgreement()
+	for _, n := range c.live() {
+		if jailed := n.Core().Chain().GetJailed(); len(jailed) > 0 {
+			t.Errorf("validator jailed after restart: %v", jailed[0].Validator)
+		}
+	}
+}
+
+func TestSimnetChainProtocol(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	c := newSimCluster(t, 4, simnet.Config{Seed: 4, Realtime: true, Link: simLinkReliable})
+	defer c.stop()
+	c.genTxs(100 * time.Millisecond)
+	if !c.waitHeight(3, 60*time.Second) {
+		t.Fatalf("height %d, want 3", c.height())
+	}
+	local := c.live()[0].Core()
+	remote := c.live()[1].Core().Chain()
+	peer := simNodeID(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status, err := local.GetRemoteStatus(ctx, peer)
+	if err != nil {
+		t.Fatalf("GetRemoteStatus() %v", err)
+	}
+	if status.From != peer || status.Status.Height < 3 {
+		t.Errorf("status from %s height %d", status.From, status.Status.Height)
+	}
+	if genesis := local.Chain().GetBlockByNumber(0).Hash(); !bytes.Equal(status.Status.Genesis, genesis.Bytes()) {
+		t.Errorf("status genesis %x, want %x", status.Status.Genesis, genesis)
+	}
+
+	headers, hashes, err := local.GetRemoteHeaders(ctx, peer, 1, 3)
+	if err != nil {
+		t.Fatalf("GetRemoteHeaders() %v", err)
+	}
+	if len(headers) != 3 || len(hashes) != 3 {
+		t.Fatalf("got %d headers %d hashes, want 3", len(headers), len(hashes))
+	}
+	for i, header := range headers {
+		want := remote.GetBlockByNumber(uint64(i + 1))
+		if header.Number != uint64(i+1) || hashes[i] != want.Hash() {
+			t.Errorf("header %d: number %d hash %x, want %x", i, header.Number, hashes[i], want.Hash())
+		}
+	}
+
+	blocks, err := local.GetRemoteBlocks(ctx, peer, hashes)
+	if err != nil {
+		t.Fatalf("GetRemoteBlocks() %v", err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("got %d blocks, want 3", len(blocks))
+	}
+	for i, b := range blocks {
+		if b.Hash() != hashes[i] {
+			t.Errorf("block %d hash %x, want %x", i, b.Hash(), hashes[i])
+		}
+	}
+
+	// without peer any other node answers
+	status, err = local.GetRemoteStatus(ctx, "")
+	if err != nil {
+		t.Fatalf("GetRemoteStatus() any %v", err)
+	}
+	if status.From == simNodeID(0) || len(status.From) == 0 {
+		t.Errorf("status from %q", status.From)
+	}
+
+	// request to a crashed peer is not answered by others
+	c.crash(2)
+	crashedCtx, crashedCancel := context.WithTimeout(ctx, time.Second)
+	defer crashedCancel()
+	if status, err := local.GetRemoteStatus(crashedCtx, simNodeID(2)); err == nil {
+		t.Errorf("crashed peer answered, from %s", status.From)
+	}
+}
+
+func TestSimnetSync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	c := newSimCluster(t, 4, simnet.Config{Seed: 5, Realtime: true, Link: simLinkReliable})
+	defer c.stop()
+	c.genTxs(100 * time.Millisecond)
+	if !c.waitHeight(4, 60*time.Second) {
+		t.Fatalf("height %d, want 4", c.height())
+	}
+
+	// blocks before it joined are only got by syncing
+	f := c.startFollower("follower")
+	defer f.Stop()
+	target := c.height()
+	f.Core().TriggerSync()
+	chain := f.Core().Chain()
+	deadline := time.Now().Add(60 * time.Second)
+	for chain.CurrentBlockHeight() < target && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if h := chain.CurrentBlockHeight(); h < target {
+		t.Fatalf("follower height %d, want %d", h, target)
+	}
+	for height := uint64(1); height <= target; height++ {
+		want := c.live()[0].Core().Chain().GetBlockByNumber(height).Hash()
+		if got := chain.GetBlockByNumber(height); got == nil || got.Hash() != want {
+			t.Errorf("follower block %d differs, want %x", height, want)
+		}
+	}
+}
@@ -111,7 +111,10 @@ func main() {
 }
 
 func loadAccounts(cfg *config.Config, password string) ([]crypto.Signer, []common.Address, error) {
-	ks := keystore.NewKeystoreWithConfig(cfg)
+	ks, err := keystore.NewKeystoreWithConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
 	addrList := ks.List()
 	if len(addrList) == 0 {
 		return nil, nil, errors.New("no local account found")